@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adviseRuleStr   string // To hold the advise --rule flag value
+	adviseOpponents int    // To hold the advise --opponents flag value
+	adviseSamples   int    // To hold the advise --samples flag value (runouts sampled for the equity)
+	advisePot       int    // To hold the advise --pot flag value (the pot before the bet faced)
+	adviseBet       int    // To hold the advise --bet flag value (the bet faced)
+	adviseSeed      int64  // To hold the advise --seed flag value (0 means a time-based seed)
+)
+
+// adviseCmd tells the strength of a hand typed in by the user.
+var adviseCmd = &cobra.Command{
+	Use:   `advise ["<hole cards> / <board>"]`,
+	Short: "Tells the strength, outs and equity of a hand you type in",
+	Long: `Evaluates hole cards on a board, typed as "As Ks 2s / Qs Js 4d", and prints
+the best hand made so far, the outs to improve it by hand rank, and its equity
+against random hands. With --pot, it also tells the largest bet worth calling,
+and with --bet, whether calling that bet is profitable.
+
+Without arguments, it asks for hands one after another until you type "q".`,
+	RunE: runAdvise,
+}
+
+func runAdvise(cmd *cobra.Command, args []string) error {
+	switch {
+	case adviseOpponents < 1 || adviseOpponents > 9:
+		return fmt.Errorf("opponents must be between 1 and 9, got %d", adviseOpponents)
+	case adviseSamples < 1:
+		return fmt.Errorf("samples must be 1 or more, got %d", adviseSamples)
+	case advisePot < 0 || adviseBet < 0:
+		return fmt.Errorf("pot and bet must be 0 or more, got %d and %d", advisePot, adviseBet)
+	case adviseBet > 0 && advisePot == 0:
+		return errors.New("bet needs --pot")
+	}
+	rules, err := config.LoadGameRulesFromOptions(adviseRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+	seed := adviseSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	if len(args) > 0 {
+		return printAdvice(ctx, strings.Join(args, " "), rules, r)
+	}
+
+	fmt.Printf("%s hand advisor. Type your %d hole cards and the board, e.g. \"As Ks 2s / Qs Js 4d\", or 'q' to quit.\n",
+		rules.Name, rules.HoleCards.Count)
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("Cards > ")
+		input, err := reader.ReadString('\n')
+		input = strings.TrimSpace(input)
+		if input == "q" || (input == "" && err != nil) {
+			return nil
+		}
+		if input != "" {
+			if err := printAdvice(ctx, input, rules, r); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return err
+				}
+				fmt.Println("Invalid input:", err)
+			}
+		}
+	}
+}
+
+// printAdvice parses the hole cards and board and prints the advice on them.
+func printAdvice(ctx context.Context, input string, rules *poker.GameRules, r *rand.Rand) error {
+	hole, board, err := poker.ParseHandAndBoard(input)
+	if err != nil {
+		return err
+	}
+	advice, err := poker.Advise(ctx, hole, board, adviseOpponents, adviseSamples, rules, r)
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("equity estimate interrupted: %w", err)
+	}
+	if err != nil {
+		return err
+	}
+	for _, line := range cli.FormatAdvice(advice, advisePot, adviseBet) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func init() {
+	adviseCmd.Flags().StringVarP(&adviseRuleStr, "rule", "r", "pls7", "Game rule to evaluate the hand with (pls7, pls, nlh, lhe, plo, plo8).")
+	adviseCmd.Flags().IntVar(&adviseOpponents, "opponents", 1, "Number of opponents holding random hands to estimate the equity against.")
+	adviseCmd.Flags().IntVar(&adviseSamples, "samples", 20000, "Number of random runouts sampled to estimate the equity.")
+	adviseCmd.Flags().IntVar(&advisePot, "pot", 0, "The pot before the bet you face, to tell the largest bet worth calling. 0 leaves it out.")
+	adviseCmd.Flags().IntVar(&adviseBet, "bet", 0, "The bet you face, with --pot, to tell whether calling it is profitable.")
+	adviseCmd.Flags().Int64Var(&adviseSeed, "seed", 0, "Random seed for a reproducible equity estimate (0 uses the current time).")
+	rootCmd.AddCommand(adviseCmd)
+}
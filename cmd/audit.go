@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+// auditCmd prints the chip-accounting audit of a saved hand.
+var auditCmd = &cobra.Command{
+	Use:   "audit <hand-id|last>",
+	Short: "Prints the chip-accounting audit of a saved hand",
+	Long: `Prints each player's starting stack, total contributed (less any uncalled
+bet returned), amount won, and ending stack for a saved hand, along with the
+payout of every pot tier. Any chip that was created or lost is reported with
+the player or pot tier at fault.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAudit,
+}
+
+func runAudit(_ *cobra.Command, args []string) error {
+	id, h, err := loadSavedHand(args[0])
+	if err != nil {
+		return err
+	}
+	if h.Audit == nil {
+		return errors.New("hand " + id + " was saved without an audit")
+	}
+	fmt.Printf("Hand %s (#%d, %s)\n", id, h.HandNumber, h.Rule)
+	for _, line := range cli.FormatChipAudit(h.Audit) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+}
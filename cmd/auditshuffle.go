@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditShuffleCount   int     // To hold the audit-shuffle --shuffles flag value
+	auditShuffleBackend string  // To hold the audit-shuffle --backend flag value (empty means every backend)
+	auditShuffleAlpha   float64 // To hold the audit-shuffle --alpha flag value
+)
+
+// auditShuffleCmd runs statistical tests of the shuffle.
+var auditShuffleCmd = &cobra.Command{
+	Use:   "audit-shuffle",
+	Short: "Tests the fairness of the shuffle with chi-square tests",
+	Long: `Shuffles a full deck many times with each random number generator the game
+can use, "math" (math/rand seeded with the time, as in a game) and "crypto"
+(crypto/rand), and runs chi-square tests on the orders dealt:
+
+  card-position uniformity  every card is dealt at every position equally often
+  adjacency independence    every card is followed by every other card equally often
+  shuffle independence      the first card of a shuffle does not depend on the
+                            shuffle before, as it would if the generator were
+                            reseeded to repeat itself
+
+A test fails if its p-value is below --alpha/2, or above 1 - --alpha/2: a
+shuffle that is more even than chance is not fair either. The command exits
+with an error if any test fails.`,
+	RunE: runAuditShuffle,
+}
+
+func runAuditShuffle(_ *cobra.Command, _ []string) error {
+	backends := poker.ShuffleBackendNames()
+	if auditShuffleBackend != "" {
+		backends = []string{auditShuffleBackend}
+	}
+
+	fmt.Printf("Shuffle audit: %s shuffles per backend, alpha %g\n", cli.FormatNumber(auditShuffleCount), auditShuffleAlpha)
+	failed := 0
+	for _, backend := range backends {
+		r, err := poker.NewShuffleRand(backend)
+		if err != nil {
+			return err
+		}
+		audit, err := poker.AuditShuffle(backend, r, auditShuffleCount, auditShuffleAlpha)
+		if err != nil {
+			return err
+		}
+		for _, line := range formatShuffleAudit(audit) {
+			fmt.Println(line)
+		}
+		for _, test := range audit.Tests {
+			if !test.Passed {
+				failed++
+			}
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("the shuffle failed %d test(s)", failed)
+	}
+	fmt.Println("Result: PASS")
+	return nil
+}
+
+// formatShuffleAudit lists each test of a backend's audit with its verdict.
+func formatShuffleAudit(audit *poker.ShuffleAudit) []string {
+	lines := []string{fmt.Sprintf("\n[%s]", audit.Backend)}
+	for _, test := range audit.Tests {
+		verdict := "PASS"
+		if !test.Passed {
+			verdict = "FAIL"
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %-26s chi2 = %9.1f  df = %4d  p = %.4f",
+			verdict, test.Name, test.ChiSquare, test.DegreesOfFreedom, test.PValue))
+	}
+	return lines
+}
+
+func init() {
+	auditShuffleCmd.Flags().IntVarP(&auditShuffleCount, "shuffles", "n", 20000, fmt.Sprintf("Number of shuffles per backend (at least %d).", poker.MinAuditShuffles))
+	auditShuffleCmd.Flags().StringVar(&auditShuffleBackend, "backend", "", fmt.Sprintf("Audit only this backend %v. Defaults to all of them.", poker.ShuffleBackendNames()))
+	auditShuffleCmd.Flags().Float64Var(&auditShuffleAlpha, "alpha", 0.001, "Significance level of each test.")
+	rootCmd.AddCommand(auditShuffleCmd)
+}
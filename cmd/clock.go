@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	clockLevelMinutes int // To hold the clock --level-minutes flag value
+	clockPlayers      int // To hold the clock --players flag value
+)
+
+// clockCmd runs a standalone tournament clock, e.g. for a home game played with real chips.
+var clockCmd = &cobra.Command{
+	Use:   "clock",
+	Short: "Runs a standalone casino-style tournament clock",
+	Long: `Runs a tournament clock that shows the current level, the time remaining,
+the next blinds, the average stack, and the number of players remaining.
+The blinds double at every level. Press Ctrl+C to stop.`,
+	Run: runClock,
+}
+
+func runClock(_ *cobra.Command, _ []string) {
+	clock := engine.NewTournamentClock(time.Duration(clockLevelMinutes) * time.Minute)
+	sb, bb := smallBlind, bigBlind
+	totalChips := initialChips * clockPlayers
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if clock.LevelExpired() {
+			clock.NextLevel()
+			sb, bb = engine.NextBlindLevel(sb, bb)
+			fmt.Printf("\n*** Blinds are now %s/%s ***\n", cli.FormatNumber(sb), cli.FormatNumber(bb))
+		}
+		status := clock.Status(sb, bb, clockPlayers, totalChips)
+		fmt.Printf("\rBLINDS: %s/%s | %s ", cli.FormatNumber(sb), cli.FormatNumber(bb), cli.FormatClockStatus(status))
+		<-ticker.C
+	}
+}
+
+func init() {
+	clockCmd.Flags().IntVar(&clockLevelMinutes, "level-minutes", 15, "Length of each blind level in minutes.")
+	clockCmd.Flags().IntVar(&clockPlayers, "players", 6, "Number of players remaining, used for the average stack.")
+	clockCmd.Flags().IntVar(&initialChips, "initial-chips", 300000, "Initial chips for each player.")
+	clockCmd.Flags().IntVar(&smallBlind, "small-blind", 500, "Small blind amount.")
+	clockCmd.Flags().IntVar(&bigBlind, "big-blind", 1000, "Big blind amount.")
+	rootCmd.AddCommand(clockCmd)
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// debugLogCmd prints the full logs kept while playing with --dev-privacy.
+var debugLogCmd = &cobra.Command{
+	Use:   "debug-log",
+	Short: "Prints the full logs kept while playing with --dev-privacy",
+	Long: `With --dev-privacy, every card is hidden in the logs printed while you play,
+so that you cannot see the CPUs' hole cards. The full logs are kept, encoded
+so that they cannot be read at a glance, in debug.log in your user config
+directory. This command prints them as plain text, e.g. to review a session
+after it is over.`,
+	Args: cobra.NoArgs,
+	RunE: runDebugLog,
+}
+
+func runDebugLog(_ *cobra.Command, _ []string) error {
+	path, err := debugLogPath()
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return util.DecodeDebugLog(f, os.Stdout)
+}
+
+// debugLogPath returns the path of the debug log kept with --dev-privacy.
+func debugLogPath() (string, error) {
+	dir, err := storage.DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debug.log"), nil
+}
+
+// initPrivateLogger sets up dev mode logging that hides the cards printed to
+// the console and keeps the full logs in the debug log, which stays open until
+// the program exits.
+func initPrivateLogger() {
+	debugLog, err := openDebugLog()
+	if err != nil {
+		util.InitPrivateLogger(cli.RedactCards, io.Discard)
+		logrus.Warnf("Could not open the debug log, so the full logs will not be kept: %v", err)
+		return
+	}
+	util.InitPrivateLogger(cli.RedactCards, debugLog)
+	fmt.Println("Dev privacy: cards are hidden in the logs. Run \"pls7 debug-log\" to read the full logs.")
+}
+
+// openDebugLog opens the debug log for appending, creating it if needed.
+func openDebugLog() (*os.File, error) {
+	path, err := debugLogPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+}
+
+func init() {
+	rootCmd.AddCommand(debugLogCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffRuleStr   string // To hold the diff-evaluators --rule flag value
+	diffReference string // To hold the diff-evaluators --reference flag value
+	diffCandidate string // To hold the diff-evaluators --candidate flag value (empty means the rules' evaluator)
+	diffDeals     int    // To hold the diff-evaluators --deals flag value
+	diffSeed      int64  // To hold the diff-evaluators --seed flag value (0 means a time-based seed)
+)
+
+// diffEvaluatorsCmd cross-checks two hand evaluation backends.
+var diffEvaluatorsCmd = &cobra.Command{
+	Use:   "diff-evaluators",
+	Short: "Cross-checks a fast hand evaluator against the generic one",
+	Long: `Evaluates random deals under a variant's rules with two hand evaluation
+backends, by default the generic one and the backend the rules select, and
+reports every deal on which they find a different hand, with the exact cards.
+Each deal has the variant's hole cards and a flop, a turn or a full board.
+
+The command exits with an error if the backends diverge on any deal. Press
+Ctrl+C to stop early and report the deals done so far.`,
+	RunE: runDiffEvaluators,
+}
+
+func runDiffEvaluators(cmd *cobra.Command, _ []string) error {
+	if diffDeals < 1 {
+		return fmt.Errorf("deals must be 1 or more, got %d", diffDeals)
+	}
+	rules, err := config.LoadGameRulesFromOptions(diffRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+	candidate := diffCandidate
+	if candidate == "" {
+		candidate = rules.Evaluator
+	}
+	if candidate == "" || candidate == diffReference {
+		return fmt.Errorf("the %s rules use the %s evaluator; choose another with --candidate %v", rules.Abbreviation, diffReference, poker.EvaluatorNames())
+	}
+	seed := diffSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("Diffing %s against %s on %s random %s deals (seed %d)...\n",
+		candidate, diffReference, cli.FormatNumber(diffDeals), rules.Abbreviation, seed)
+	started := time.Now()
+	diff, err := poker.DiffEvaluators(ctx, diffReference, candidate, rules, diffDeals, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+	for _, d := range diff.Examples {
+		fmt.Println("  " + d.String())
+	}
+	if diff.Divergences > len(diff.Examples) {
+		fmt.Printf("  ... and %s more\n", cli.FormatNumber(diff.Divergences-len(diff.Examples)))
+	}
+	fmt.Printf("%s deals in %s, %s divergences\n",
+		cli.FormatNumber(diff.Deals), time.Since(started).Round(time.Millisecond), cli.FormatNumber(diff.Divergences))
+	if diff.Divergences > 0 {
+		return fmt.Errorf("%s diverged from %s on %d deal(s)", candidate, diffReference, diff.Divergences)
+	}
+	fmt.Println("Result: PASS")
+	return nil
+}
+
+func init() {
+	diffEvaluatorsCmd.Flags().StringVarP(&diffRuleStr, "rule", "r", "nlh", "Poker variant whose rules the deals are evaluated under.")
+	diffEvaluatorsCmd.Flags().StringVar(&diffReference, "reference", poker.DefaultEvaluator, fmt.Sprintf("Evaluator %v trusted to be right.", poker.EvaluatorNames()))
+	diffEvaluatorsCmd.Flags().StringVar(&diffCandidate, "candidate", "", fmt.Sprintf("Evaluator %v to check. Defaults to the one the rules select.", poker.EvaluatorNames()))
+	diffEvaluatorsCmd.Flags().IntVarP(&diffDeals, "deals", "n", 1_000_000, "Number of random deals to evaluate.")
+	diffEvaluatorsCmd.Flags().Int64Var(&diffSeed, "seed", 0, "Random seed for reproducible deals (0 uses the current time).")
+	rootCmd.AddCommand(diffEvaluatorsCmd)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportSince  time.Duration // To hold the export --since flag value (0 means every saved hand)
+	exportOutput string        // To hold the export --output flag value (empty means stdout)
+)
+
+// exportCmd writes saved hands in the PokerStars hand history format.
+var exportCmd = &cobra.Command{
+	Use:   "export [hand-id|last]",
+	Short: "Exports saved hands in the PokerStars hand history format",
+	Long: `Writes saved hands in the PokerStars hand history text format, so that a session
+can be imported into hand trackers and analysis tools. Without a hand ID, every
+saved hand is exported, or only those played within --since, e.g. --since 3h.
+
+Trackers only know the standard hands, so a Skip Straight is written as a
+straight and a Skip Straight Flush as a straight flush.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExport,
+}
+
+func runExport(_ *cobra.Command, args []string) error {
+	var hands []*engine.HandHistory
+	if len(args) == 1 {
+		_, h, err := loadSavedHand(args[0])
+		if err != nil {
+			return err
+		}
+		hands = []*engine.HandHistory{h}
+	} else {
+		store, err := openStorage()
+		if err != nil {
+			return err
+		}
+		var since time.Time
+		if exportSince > 0 {
+			since = time.Now().Add(-exportSince)
+		}
+		if hands, err = store.LoadHandHistoriesSince(since); err != nil {
+			return err
+		}
+		if len(hands) == 0 {
+			return errors.New("no saved hands to export")
+		}
+	}
+
+	var out io.Writer = os.Stdout
+	if exportOutput != "" {
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+		logrus.Infof("Writing %d hands to %s", len(hands), exportOutput)
+	}
+	for i, h := range hands {
+		// Hands are separated by blank lines, as in PokerStars' own files.
+		if i > 0 {
+			if _, err := fmt.Fprint(out, "\n\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprint(out, engine.FormatPokerStars(h)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func init() {
+	exportCmd.Flags().DurationVar(&exportSince, "since", 0, "Only export the hands played within this long, e.g. 3h (0 exports every saved hand).")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "Text file to write (defaults to stdout).")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// parseDifficulty converts the --difficulty flag value into an engine.Difficulty,
+// defaulting to medium for unknown values.
+func parseDifficulty(s string) engine.Difficulty {
+	switch s {
+	case "easy":
+		return engine.DifficultyEasy
+	case "medium":
+		return engine.DifficultyMedium
+	case "hard":
+		return engine.DifficultyHard
+	default:
+		logrus.Warnf("Invalid difficulty '%s' specified. Defaulting to medium.", s)
+		return engine.DifficultyMedium
+	}
+}
+
+// printMessage prints a game message on its own line to stdout.
+func printMessage(message string) {
+	fmt.Println(cli.Speak(message))
+}
+
+// playHand plays a single hand from the deal to the end-of-hand cleanup. Every
+// message meant for the player is passed to emit, so the same loop can drive a
+// single table on stdout or one of several tables in multi-table mode.
+func playHand(g *engine.Game, actionProvider engine.ActionProvider, emit func(string)) {
+	show := emit
+	emit = func(msg string) {
+		if !backgroundHand {
+			show(msg)
+		}
+	}
+	g.OnTableEvent = func(e engine.TableEvent) {
+		for _, msg := range cli.FormatTableEvent(e) {
+			emit(msg)
+		}
+	}
+	defer func() { g.OnTableEvent = nil }()
+	if rules := g.RotateChaosVariant(); rules != nil {
+		for _, msg := range cli.FormatVariantAnnouncement(rules) {
+			emit(msg)
+		}
+	}
+	g.StartNewHand()
+	if human := g.Players[0]; !human.IsCPU && len(human.Hand) > 0 {
+		animate(cli.DealFrames(human.Hand, human.HandHidden))
+	}
+
+	// Single Hand Loop
+	equityShown, insuranceOffered, runoutShown := false, false, false
+	for g.Phase != engine.PhaseShowdown && g.Phase != engine.PhaseHandOver {
+		if g.CountNonFoldedPlayers() <= 1 {
+			break
+		}
+		g.PrepareNewBettingRound()
+
+		// New Turn-by-turn Betting Loop
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+
+			if player.Status != engine.PlayerStatusPlaying {
+				g.AdvanceTurn()
+				continue
+			}
+
+			action := actionProvider.GetAction(g, player, g.Rand)
+			if action.Type == engine.ActionFastForward {
+				// The betting round was replaced by a later street in dev mode.
+				emit(fmt.Sprintf("*** Fast-forwarded to the %s: %s ***", g.Phase, g.CommunityCards))
+				continue
+			}
+
+			if _, event := g.ProcessAction(player, action); event != nil {
+				pace(actionPause(g, event))
+			}
+			if fastFolds(player, action) && !backgroundHand {
+				// Speed poker: the CPUs play out the rest of the hand unseen,
+				// and the next one is dealt at once.
+				emit("Fast fold: on to the next hand.")
+				backgroundHand, fastFolded = true, true
+				defer func() { backgroundHand = false }()
+			}
+			g.AdvanceTurn()
+		}
+
+		// Once three or more players are all-in, show who is ahead in each pot.
+		if !equityShown && g.ShowsAllInEquity() {
+			equityShown = true
+			if equity, err := g.CalculateAllInEquity(g.HandContext()); err != nil {
+				logrus.Warnf("Could not calculate all-in equity: %v", err)
+			} else {
+				for _, msg := range cli.FormatAllInEquity(equity) {
+					emit(msg)
+				}
+			}
+		}
+		if g.OffersInsurance && !insuranceOffered {
+			insuranceOffered = offerInsurance(g, emit)
+		}
+		if players := g.RunItOffer(); players != nil {
+			offerRunIt(g, players, emit)
+		}
+		dealt, logged := len(g.CommunityCards), len(g.Events)
+		g.Advance()
+		animate(cli.BoardFrames(g.CommunityCards, dealt))
+		for _, e := range g.Events[logged:] {
+			if e.Type == engine.EventBoardRunout {
+				for _, msg := range cli.FormatBoardRunout(e, !runoutShown) {
+					emit(msg)
+				}
+				runoutShown = true
+				pace(time.Duration(runoutPause * float64(time.Second)))
+			}
+		}
+	}
+
+	// Conclude the hand
+	if g.CountNonFoldedPlayers() > 1 {
+		// Build up to the showdown of a dramatic pot with its betting line.
+		if isDramaticPot(g, g.Pot) && g.History != nil {
+			for _, msg := range cli.FormatBettingRecap(g.History) {
+				emit(msg)
+			}
+			pace(slowMotionFactor * g.CPUThinkTime())
+		}
+		if readHands {
+			readOpponentHands(g, emit)
+		}
+		results := g.DistributePot()
+		g.MuckLosingHands(results)
+		if human := g.Players[0]; !human.IsCPU && !g.AutoMuck && g.CanMuck(human, results) && cli.PromptForMuck(human) {
+			if err := g.MuckHand(human, results); err != nil {
+				logrus.Warnf("Could not muck: %v", err)
+			}
+		}
+		for _, msg := range cli.FormatShowdownResults(g, results) {
+			emit(msg)
+		}
+		if g.Insurance != nil && g.Insurance.Settled {
+			emit(cli.FormatInsuranceSettlement(g.Insurance))
+		}
+	} else {
+		results := g.AwardPotToLastPlayer()
+		emit("--- POT AWARDED ---")
+		if g.Uncalled != nil {
+			emit(cli.FormatUncalledBet(g.Uncalled))
+		}
+		for _, result := range results {
+			emit(fmt.Sprintf(
+				"%s wins %s chips with %s",
+				result.PlayerName, cli.FormatNumber(result.AmountWon), result.HandDesc,
+			))
+		}
+		emit("------------------------")
+	}
+
+	g.CleanupHand()
+	if g.DevMode && g.History != nil && g.History.Audit != nil {
+		for _, msg := range cli.FormatChipAudit(g.History.Audit) {
+			emit(msg)
+		}
+	}
+}
+
+// readOpponentHands runs the hand reading exercise: before the showdown
+// reveals the hands, the human guesses the category of each opponent's hand.
+// The guesses are only scored once they are all in.
+func readOpponentHands(g *engine.Game, emit func(string)) {
+	opponents := g.HandReadingOpponents()
+	if len(opponents) == 0 {
+		return
+	}
+	emit("--- READ THE HANDS ---")
+	emit(fmt.Sprintf("Board: %s", g.CommunityCards))
+	guesses := make(map[*engine.Player]engine.HandRead)
+	for _, p := range opponents {
+		if guess, ok := cli.PromptForHandRead(p); ok {
+			guesses[p] = guess
+		}
+	}
+	for _, p := range opponents {
+		guess, ok := guesses[p]
+		if !ok {
+			continue
+		}
+		actual, err := g.ScoreHandRead(p, guess)
+		if err != nil {
+			logrus.Warnf("Could not read %s's hand: %v", p.Name, err)
+			continue
+		}
+		emit(cli.FormatHandRead(p.Name, guess, actual))
+	}
+	stats := g.HumanStats
+	emit(fmt.Sprintf("Hands read right this session: %d of %d", stats.HandReadsRight, stats.HandReads))
+}
+
+// offerInsurance offers insurance to the favorite of an all-in pot, if there
+// is one. The human is asked, and a CPU decides by its playing style. It
+// reports whether an offer was made, so that it is made once a hand.
+func offerInsurance(g *engine.Game, emit func(string)) bool {
+	offer, err := g.InsuranceOffer(g.HandContext())
+	if err != nil {
+		logrus.Warnf("Could not price insurance: %v", err)
+		return false
+	}
+	if offer == nil {
+		return false
+	}
+
+	var coverage float64
+	if offer.PlayerName == g.Players[0].Name {
+		coverage, _ = cli.PromptForInsurance(offer)
+	} else {
+		coverage = g.CPUInsuranceCoverage(offer)
+	}
+	if coverage == 0 {
+		emit(fmt.Sprintf("%s declines insurance.", offer.PlayerName))
+		return true
+	}
+	policy, err := g.TakeInsurance(offer, coverage)
+	if err != nil {
+		logrus.Warnf("Could not take insurance: %v", err)
+		return true
+	}
+	emit(cli.FormatInsuranceTaken(policy))
+	return true
+}
+
+// offerRunIt asks the players in an all-in pot whether to run the rest of the
+// board more than once. The human is asked, and a CPU decides by its playing
+// style; the board is run more than once only if everyone agrees.
+func offerRunIt(g *engine.Game, players []*engine.Player, emit func(string)) {
+	times := cli.FormatRunItTimes(g.RunItTimes)
+	agreed := true
+	for _, p := range players {
+		var agrees bool
+		if p.IsCPU {
+			agrees = g.CPUAgreesToRunIt(p)
+		} else {
+			agrees = cli.PromptForRunIt(g.RunItTimes)
+		}
+		if !agrees {
+			emit(fmt.Sprintf("%s declines to run it %s.", p.Name, times))
+			agreed = false
+			break
+		}
+		emit(fmt.Sprintf("%s agrees to run it %s.", p.Name, times))
+	}
+	if err := g.DecideRunIt(agreed); err != nil {
+		logrus.Warnf("Could not decide on running it %s: %v", times, err)
+	}
+}
+
+// isGameOver reports whether the game has ended for the human player, along
+// with the message announcing it.
+func isGameOver(g *engine.Game) (bool, string) {
+	if g.Players[0].Status == engine.PlayerStatusEliminated {
+		return true, "You have been eliminated. GAME OVER."
+	}
+	if g.CountRemainingPlayers() <= 1 {
+		return true, "--- GAME OVER ---"
+	}
+	return false, ""
+}
+
+// offerShowCard lets the human reveal one hole card after a hand they mucked or
+// won uncontested.
+func offerShowCard(g *engine.Game, emit func(string)) {
+	human := g.Players[0]
+	if !g.CanShowPartial(human) {
+		return
+	}
+	action, ok := cli.PromptForShowCard(human)
+	if !ok {
+		return
+	}
+	event, err := g.ShowPartial(human, action)
+	if err != nil {
+		logrus.Warnf("Could not show card: %v", err)
+		return
+	}
+	emit(cli.FormatActionEvent(event))
+}
+
+// saveHandHistory saves the hand just played so it can be reviewed or shared
+// later, and announces its ID. Failures are logged but do not stop the game.
+func saveHandHistory(g *engine.Game, emit func(string)) {
+	if g.History == nil {
+		return
+	}
+	store, err := openStorage()
+	if err != nil {
+		logrus.Warnf("Could not open storage: %v", err)
+		return
+	}
+	if err := store.SaveHandHistory(g.History); err != nil {
+		logrus.Warnf("Could not save hand history: %v", err)
+		return
+	}
+	emit(fmt.Sprintf("Hand ID: %s", g.History.ID))
+	announceMilestones(store, g.History, emit)
+}
+
+// milestonesMu keeps tables from saving milestones over each other.
+var milestonesMu sync.Mutex
+
+// announceMilestones celebrates the rare hands made in a hand with a banner,
+// and adds the human's to the profile's milestones. Failures are logged but do
+// not stop the game.
+func announceMilestones(store storage.Storage, h *engine.HandHistory, emit func(string)) {
+	milestones := engine.FindMilestones(h)
+	if len(milestones) == 0 {
+		return
+	}
+
+	milestonesMu.Lock()
+	defer milestonesMu.Unlock()
+	stored, err := store.LoadMilestones()
+	if err != nil {
+		logrus.Warnf("Could not load milestones: %v", err)
+	}
+	var human, others []engine.Milestone
+	for _, m := range milestones {
+		if m.IsHuman {
+			human = append(human, m)
+		} else {
+			others = append(others, m)
+		}
+	}
+	engine.MarkFirstMilestones(human, stored[profileName])
+	for _, m := range append(human, others...) {
+		for _, line := range cli.FormatMilestoneBanner(m) {
+			emit(line)
+		}
+	}
+
+	if err != nil || len(human) == 0 {
+		return
+	}
+	stored[profileName] = append(stored[profileName], human...)
+	if err := store.SaveMilestones(stored); err != nil {
+		logrus.Warnf("Could not save milestones: %v", err)
+	}
+}
+
+// offerRebuy asks a busted human whether to rebuy for the initial chips, as
+// long as rebuys are left. It reports whether the player rebought.
+func offerRebuy(g *engine.Game, rebuysLeft int) bool {
+	human := g.Players[0]
+	if human.Status != engine.PlayerStatusEliminated || rebuysLeft <= 0 {
+		return false
+	}
+
+	fmt.Printf("You busted. Rebuy for %s chips? (%d left) (y/n) > ", cli.FormatNumber(initialChips), rebuysLeft)
+	input := cli.ReadLine()
+	if strings.TrimSpace(strings.ToLower(input)) != "y" {
+		return false
+	}
+	if err := g.Rebuy(human, initialChips); err != nil {
+		logrus.Warnf("Could not rebuy: %v", err)
+		return false
+	}
+	fmt.Printf("You rebought for %s chips.\n", cli.FormatNumber(initialChips))
+	return true
+}
+
+// collectTimeCharge takes the time charge from the players if it is due
+// before the next hand.
+func collectTimeCharge(g *engine.Game, emit func(string)) {
+	if !g.TimeChargeDue() {
+		return
+	}
+	payments, err := g.CollectTimeCharge()
+	if err != nil {
+		logrus.Warnf("Could not collect the time charge: %v", err)
+		return
+	}
+	emit(cli.FormatTimeCharge(payments))
+}
+
+// changeLineup lets CPUs leave and join the table after a hand, if the
+// lineup changes, and announces each change.
+func changeLineup(g *engine.Game, emit func(string)) {
+	for _, change := range g.ChangeLineup(g.Rand) {
+		emit(cli.FormatLineupChange(change))
+	}
+}
+
+// offerStraddle asks the human whether to straddle the next hand, if they
+// will be in the straddle seat, and records the answer for the engine.
+func offerStraddle(g *engine.Game) {
+	g.HumanStraddles = false
+	if straddler := g.NextStraddler(); straddler == nil || straddler.IsCPU {
+		return
+	}
+
+	fmt.Printf("Straddle %s? (y/N) > ", cli.FormatNumber(g.StraddleAmount()))
+	input := cli.ReadLine()
+	g.HumanStraddles = strings.TrimSpace(strings.ToLower(input)) == "y"
+}
+
+// printSessionSummary prints the highlights of the hands saved since the
+// session started. Failures are logged, as the game is already over.
+func printSessionSummary(sessionStart time.Time) {
+	store, err := openStorage()
+	if err != nil {
+		logrus.Warnf("Could not open storage: %v", err)
+		return
+	}
+	histories, err := store.LoadHandHistoriesSince(sessionStart)
+	if err != nil {
+		logrus.Warnf("Could not load this session's hands: %v", err)
+		return
+	}
+	for _, line := range cli.FormatSessionSummary(engine.SummarizeSession(histories)) {
+		fmt.Println(line)
+	}
+}
+
+// newCoach returns a coach with the thresholds from the flags, or nil if the
+// coach is off.
+func newCoach() *engine.Coach {
+	if !showCoach {
+		return nil
+	}
+	return engine.NewCoach(coachThresholds)
+}
+
+// coachAdvice returns the coach's advice on the human's spot before they act,
+// or "" if the coach is off or has none: whether to defend a blind against a
+// steal and, after the flop, how likely their made hand is already beaten.
+// Both are simulated with their own random source so the game's deals are
+// left as they are.
+func coachAdvice(g *engine.Game, coach *engine.Coach, player *engine.Player) string {
+	if coach == nil {
+		return ""
+	}
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	var advice []string
+	defense, err := g.AdviseBlindDefense(g.HandContext(), player, r)
+	if err != nil {
+		logrus.Warnf("Could not advise on the blind defense: %v", err)
+	} else if defense != nil {
+		advice = append(advice, "[Coach] "+cli.FormatBlindDefense(defense))
+	}
+	reverseOuts, err := g.EstimateReverseOuts(g.HandContext(), player, r)
+	if err != nil {
+		logrus.Warnf("Could not estimate the reverse outs: %v", err)
+	} else if reverseOuts != nil {
+		advice = append(advice, "[Coach] "+cli.FormatReverseOuts(reverseOuts))
+	}
+	return strings.Join(advice, "\n")
+}
+
+// printCoachFeedback emits the coach's new comments on the player's session
+// statistics, if the coach is on.
+func printCoachFeedback(g *engine.Game, coach *engine.Coach, emit func(string)) {
+	if coach == nil {
+		return
+	}
+	for _, note := range coach.Feedback(g.HumanStreetStats) {
+		emit("[Coach] " + note)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// importCmd saves hands played elsewhere, read from PokerStars hand histories.
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Imports No Limit Hold'em hands from a PokerStars hand history file",
+	Long: `Reads a PokerStars hand history text file and saves its No Limit Hold'em
+hands alongside your own, so that they can be replayed, shared and exported
+like hands played here. The hero, whose hole cards were dealt face up, is
+recorded as the human player, and amounts in a currency are saved in cents.
+
+Hands that cannot be imported, such as other games or boards run twice, are
+skipped with the reason.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func runImport(_ *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	hands := engine.SplitPokerStarsHands(string(data))
+	if len(hands) == 0 {
+		return errors.New("no PokerStars hands found")
+	}
+	rules, err := config.LoadGameRulesFromOptions("nlh")
+	if err != nil {
+		return fmt.Errorf("could not load the No Limit Hold'em rules: %w", err)
+	}
+	store, err := openStorage()
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for _, text := range hands {
+		h, err := engine.ParsePokerStars(text, rules)
+		if err != nil {
+			fmt.Printf("Skipped %v\n", err)
+			continue
+		}
+		if err := store.SaveHandHistory(h); err != nil {
+			return fmt.Errorf("could not save hand %s: %w", h.ID, err)
+		}
+		fmt.Printf("Imported hand %s\n", h.ID)
+		imported++
+	}
+	fmt.Printf("Imported %d of %d hands.\n", imported, len(hands))
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"io"
+	"os"
+	"sync"
+)
+
+// machineOutputBuffer is the number of events held for the writer before
+// further events are dropped.
+const machineOutputBuffer = 1024
+
+// machineOutput writes a game's events to a pipe as newline-delimited JSON, in
+// the same form as the event log. The events are written from their own
+// goroutine, so a slow reader never holds up the game loop: once the buffer is
+// full, events are dropped and counted instead.
+type machineOutput struct {
+	events  chan engine.GameEvent
+	done    sync.WaitGroup
+	dropped int
+}
+
+// divertTerminalOutput sends the formatted text meant for the terminal, and
+// the logs, to standard error, so that standard output carries only the
+// events, and returns standard output. The screen is no longer cleared, as
+// that would wipe a terminal the events are read on. It must be called before
+// the logger is set up.
+func divertTerminalOutput() io.Writer {
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	cli.ClearsScreen = false
+	return out
+}
+
+// startMachineOutput starts writing the game's events to out, beginning with
+// those already logged, as the human player sees them: the deck and the CPUs'
+// hole cards are left out.
+func startMachineOutput(g *engine.Game, out io.Writer) *machineOutput {
+	m := &machineOutput{events: make(chan engine.GameEvent, machineOutputBuffer)}
+	m.done.Add(1)
+	go m.write(out)
+	viewer := g.Players[0].Name
+	for _, e := range g.Events {
+		m.send(g.PublicEvent(e, viewer))
+	}
+	g.OnEvent = func(e engine.GameEvent) {
+		m.send(g.PublicEvent(e, viewer))
+	}
+	return m
+}
+
+// send queues an event for the writer, or drops it if the queue is full.
+func (m *machineOutput) send(e engine.GameEvent) {
+	select {
+	case m.events <- e:
+	default:
+		m.dropped++
+	}
+}
+
+// write encodes the queued events to w, one per line, until the queue is
+// closed.
+func (m *machineOutput) write(w io.Writer) {
+	defer m.done.Done()
+	enc := json.NewEncoder(w)
+	for e := range m.events {
+		// A reader that has gone away is ignored; the game goes on.
+		_ = enc.Encode(e)
+	}
+}
+
+// stop writes the events still queued and reports any that were dropped.
+func (m *machineOutput) stop() {
+	close(m.events)
+	m.done.Wait()
+	if m.dropped > 0 {
+		fmt.Fprintf(os.Stderr, "Machine output: %d events were dropped because the reader fell behind.\n", m.dropped)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+
+	"github.com/spf13/cobra"
+)
+
+var milestonesProfile string // To hold the milestones --profile flag value
+
+// milestonesCmd lists the rare hands a player profile has made.
+var milestonesCmd = &cobra.Command{
+	Use:   "milestones",
+	Short: "Lists the rare hands a player profile has made",
+	Long: `Lists the milestones a player profile has reached, oldest first: every royal
+flush, skip straight flush, and four of a kind that beat another four of a kind
+at showdown, with how rarely a five-card hand makes it. The first of each kind
+is marked.`,
+	Args: cobra.NoArgs,
+	RunE: runMilestones,
+}
+
+func runMilestones(_ *cobra.Command, _ []string) error {
+	store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	milestones, err := store.LoadMilestones()
+	if err != nil {
+		return err
+	}
+	list := milestones[milestonesProfile]
+	if len(list) == 0 {
+		fmt.Printf("No milestones yet for profile %q.\n", milestonesProfile)
+		return nil
+	}
+	for _, line := range cli.FormatMilestones(list) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func init() {
+	milestonesCmd.Flags().StringVar(&milestonesProfile, "profile", "default", "Player profile whose milestones to list.")
+	rootCmd.AddCommand(milestonesCmd)
+}
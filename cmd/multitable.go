@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"math/rand"
+	"strings"
+	"sync"
+)
+
+// maxRecentMessages is the number of recent messages kept per table and shown
+// when the player switches to that table.
+const maxRecentMessages = 12
+
+// table is one of several games played simultaneously by the human player.
+type table struct {
+	number int
+	game   *engine.Game
+	coach  *engine.Coach
+
+	mu       sync.Mutex
+	messages []string
+}
+
+// emit records a message for the table instead of printing it, since output
+// from concurrently running tables would otherwise be interleaved.
+func (t *table) emit(message string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messages = append(t.messages, strings.TrimSpace(message))
+	if len(t.messages) > maxRecentMessages {
+		t.messages = t.messages[len(t.messages)-maxRecentMessages:]
+	}
+}
+
+// recentMessages returns the table's recent messages.
+func (t *table) recentMessages() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string(nil), t.messages...)
+}
+
+// tablePrompt is a request for the human player to act at a table. The table's
+// goroutine blocks until the chosen action is sent on reply.
+type tablePrompt struct {
+	table *table
+	reply chan engine.PlayerAction
+}
+
+// queuedActionProvider implements engine.ActionProvider for multi-table play.
+// CPU players act immediately; the human's decisions are queued so that the
+// terminal prompts for one table at a time.
+type queuedActionProvider struct {
+	table   *table
+	prompts chan<- tablePrompt
+}
+
+// GetAction method for queuedActionProvider
+func (p *queuedActionProvider) GetAction(g *engine.Game, player *engine.Player, r *rand.Rand) engine.PlayerAction {
+	if player.IsCPU {
+		pace(g.CPUThinkTime())
+		return g.GetCPUAction(player, r)
+	}
+	reply := make(chan engine.PlayerAction)
+	p.prompts <- tablePrompt{table: p.table, reply: reply}
+	return <-reply
+}
+
+// newTable sets up a table for multi-table play. Its losing hands are always
+// mucked at showdown: only action prompts are queued, and a muck prompt would
+// read stdin at the same time as the prompts of the other tables.
+func newTable(number int, newGame func() *engine.Game) *table {
+	g := newGame()
+	g.AutoMuck = true
+	return &table{number: number, game: g, coach: newCoach()}
+}
+
+// runMultiTable plays several tables at once. Each table runs its own engine
+// instance in a goroutine, while the terminal serves the queued prompts one at a
+// time. The player can press "t" to leave a decision for later and switch to the
+// next table waiting for action.
+func runMultiTable(newGame func() *engine.Game, numTables int) {
+	prompts := make(chan tablePrompt)
+	tables := make([]*table, numTables)
+
+	var wg sync.WaitGroup
+	for i := range tables {
+		t := newTable(i+1, newGame)
+		tables[i] = t
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			playTable(t, &queuedActionProvider{table: t, prompts: prompts})
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(prompts)
+	}()
+
+	var pending []tablePrompt
+	for {
+		// Wait for a prompt if none is queued, then collect any others that are ready.
+		if len(pending) == 0 {
+			p, ok := <-prompts
+			if !ok {
+				break
+			}
+			pending = append(pending, p)
+		}
+		pending = drainPrompts(prompts, pending)
+
+		current := pending[0]
+		pending = pending[1:]
+
+		header := formatTableHeader(current.table, numTables, len(pending))
+		// The table's goroutine waits for the reply, so its game is still.
+		g := current.table.game
+		if advice := coachAdvice(g, current.table.coach, g.Players[g.CurrentTurnPos]); advice != "" {
+			header += "\n" + advice
+		}
+		action, switched := cli.PromptForTableAction(current.table.game, header)
+		if switched {
+			pending = drainPrompts(prompts, pending)
+			if len(pending) == 0 {
+				fmt.Println("No other table is waiting for you.")
+			}
+			pending = append(pending, current)
+			continue
+		}
+		current.reply <- action
+	}
+
+	fmt.Println("\n--- ALL TABLES FINISHED ---")
+	for _, t := range tables {
+		messages := t.recentMessages()
+		last := ""
+		if len(messages) > 0 {
+			last = messages[len(messages)-1]
+		}
+		fmt.Printf("Table %d: %s\n", t.number, last)
+	}
+}
+
+// drainPrompts appends every prompt that is immediately available without blocking.
+func drainPrompts(prompts <-chan tablePrompt, pending []tablePrompt) []tablePrompt {
+	for {
+		select {
+		case p, ok := <-prompts:
+			if !ok {
+				return pending
+			}
+			pending = append(pending, p)
+		default:
+			return pending
+		}
+	}
+}
+
+// formatTableHeader identifies the table being shown and lists its recent messages.
+func formatTableHeader(t *table, numTables, numWaiting int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("=== TABLE %d of %d | %d other table(s) waiting ===\n", t.number, numTables, numWaiting))
+	for _, msg := range t.recentMessages() {
+		if msg != "" {
+			sb.WriteString(msg + "\n")
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// playTable plays hands at a table until the game is over for the human player,
+// or the table has played its hands limit.
+// Hands start automatically, since there is no single "next hand" prompt when
+// several tables are running.
+func playTable(t *table, actionProvider engine.ActionProvider) {
+	for {
+		playHand(t.game, actionProvider, t.emit)
+		// Tables play their hands at the same time, so keep their IDs apart.
+		t.game.History.ID += fmt.Sprintf("-t%d", t.number)
+		saveHandHistory(t.game, t.emit)
+		printCoachFeedback(t.game, t.coach, t.emit)
+		if t.game.HandsLimitReached() {
+			for _, line := range cli.FormatStandings(t.game) {
+				t.emit(line)
+			}
+			return
+		}
+		if over, message := isGameOver(t.game); over {
+			t.emit(message)
+			return
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestNewTable_AutoMucks(t *testing.T) {
+	rules := &poker.GameRules{Abbreviation: "NLH", HoleCards: poker.HoleCardRules{Count: 2}, BettingLimit: "no_limit"}
+	newGame := func() *engine.Game {
+		g := engine.NewGame([]string{"YOU", "CPU 1"}, 10000, 50, 100, engine.DifficultyMedium, rules, false, false, 0)
+		g.AutoMuck = false
+		return g
+	}
+
+	tbl := newTable(2, newGame)
+	if tbl.number != 2 || !tbl.game.AutoMuck {
+		t.Errorf("Expected table 2 to muck the human's losing hands, but got table %d with AutoMuck %v", tbl.number, tbl.game.AutoMuck)
+	}
+}
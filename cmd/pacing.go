@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"time"
+)
+
+// slowMotionFactor is how many times the CPU think time the table pauses after
+// each action once the pot is dramatic.
+const slowMotionFactor = 3
+
+// pace pauses the table. Every pause in the game goes through it, so that the
+// pace is decided in one place from the game's events. A hand played out in
+// the background is not paused.
+func pace(d time.Duration) {
+	if d > 0 && !backgroundHand {
+		time.Sleep(d)
+	}
+}
+
+// animationFrameTime is how long each frame of an animation stays on screen.
+const animationFrameTime = 40 * time.Millisecond
+
+// animationsEnabled reports whether cards are animated: unless --animations is
+// off or the output is for a screen reader, at a single table, whose messages
+// are printed straight to the terminal, and never in a hand played out in the
+// background.
+func animationsEnabled() bool {
+	return animationsName == "on" && numTables == 1 && !cli.Accessible && !backgroundHand
+}
+
+// animate plays an animation on one line of the terminal, drawing each frame
+// over the last and leaving the last one on screen.
+func animate(frames []string) {
+	if !animationsEnabled() || len(frames) == 0 {
+		return
+	}
+	for _, frame := range frames {
+		fmt.Print("\r\033[K" + frame)
+		pace(animationFrameTime)
+	}
+	fmt.Println()
+}
+
+// isDramaticPot reports whether the pot has reached --dramatic-pot big blinds.
+func isDramaticPot(g *engine.Game, pot int) bool {
+	return dramaticPotBB > 0 && pot >= dramaticPotBB*g.BigBlind
+}
+
+// actionPause returns how long to pause after an action is announced. Once the
+// pot is dramatic, the rest of the hand is played back in slow motion.
+func actionPause(g *engine.Game, event *engine.ActionEvent) time.Duration {
+	if !isDramaticPot(g, event.Pot) {
+		return 0
+	}
+	return slowMotionFactor * g.CPUThinkTime()
+}
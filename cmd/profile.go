@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileRuleStr       string // To hold the profile --rule flag value
+	profileDifficultyStr string // To hold the profile --difficulty flag value
+	profileHands         int    // To hold the profile --hands flag value
+	profileSeed          int64  // To hold the profile --seed flag value (0 means a time-based seed)
+	profileCPUFile       string // To hold the profile --cpu-profile flag value
+	profileMemFile       string // To hold the profile --mem-profile flag value
+)
+
+// profileCmd plays CPU-only hands without a terminal under the profiler.
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Profiles the engine on headless CPU-only hands",
+	Long: `Plays hands between six CPUs at the default stakes, with no terminal and no
+pauses, while writing a pprof CPU profile and a heap profile. A short summary
+shows where the time went: the CPUs' decisions, evaluating outs on the flop and
+turn, and distributing the pot. A busted table is replaced with a new one.
+
+Compare the summary before and after a change to spot performance regressions,
+and open the profiles with "go tool pprof" for details.`,
+	RunE: runProfile,
+}
+
+// profileTimings accumulates the time spent in each part of the engine.
+type profileTimings struct {
+	decisions, outs, potDistribution time.Duration
+}
+
+func runProfile(_ *cobra.Command, _ []string) error {
+	if profileHands < 1 {
+		return fmt.Errorf("--hands must be at least 1, got %d", profileHands)
+	}
+	rules, err := config.LoadGameRulesFromOptions(profileRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+	difficulty := parseDifficulty(profileDifficultyStr)
+	// Keep the engine's log out of the summary.
+	util.InitLogger(false)
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	seed := profileSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	r := rand.New(rand.NewSource(seed))
+	newGame := func() *engine.Game {
+		g := engine.NewGame([]string{"YOU", "CPU 1", "CPU 2", "CPU 3", "CPU 4", "CPU 5"}, initialChips, smallBlind, bigBlind, difficulty, rules, false, false, 0)
+		g.Rand = r
+		// A CPU plays the human's seat too.
+		profile := *g.Players[1].Profile
+		g.Players[0].IsCPU, g.Players[0].Profile = true, &profile
+		return g
+	}
+
+	cpuFile, err := os.Create(profileCPUFile)
+	if err != nil {
+		return err
+	}
+	defer cpuFile.Close()
+	if err := pprof.StartCPUProfile(cpuFile); err != nil {
+		return err
+	}
+
+	var timings profileTimings
+	start := time.Now()
+	g := newGame()
+	for i := 0; i < profileHands; i++ {
+		if g.CountRemainingPlayers() <= 1 {
+			g = newGame()
+		}
+		playProfiledHand(g, &timings)
+	}
+	elapsed := time.Since(start)
+	pprof.StopCPUProfile()
+
+	memFile, err := os.Create(profileMemFile)
+	if err != nil {
+		return err
+	}
+	defer memFile.Close()
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(memFile); err != nil {
+		return err
+	}
+
+	for _, line := range formatProfileSummary(rules, profileHands, elapsed, timings) {
+		fmt.Println(line)
+	}
+	fmt.Printf("Wrote the CPU profile to %s and the heap profile to %s.\n", profileCPUFile, profileMemFile)
+	fmt.Printf("For details, run: go tool pprof -top %s\n", profileCPUFile)
+	return nil
+}
+
+// playProfiledHand plays one hand between CPUs, as playHand does without the
+// messages and pauses, adding the time spent in each part of the engine to
+// timings. Every player still in the hand has their outs evaluated on the flop
+// and turn, as the outs panel does for the human.
+func playProfiledHand(g *engine.Game, timings *profileTimings) {
+	timed := func(total *time.Duration) func(func()) {
+		return func(f func()) {
+			start := time.Now()
+			f()
+			*total += time.Since(start)
+		}
+	}
+	g.PlayCPUHand(engine.CPUHandHooks{
+		BeforeBettingRound: func() {
+			if g.Phase == engine.PhaseFlop || g.Phase == engine.PhaseTurn {
+				timed(&timings.outs)(func() { evaluateOuts(g) })
+			}
+		},
+		Decision:        timed(&timings.decisions),
+		PotDistribution: timed(&timings.potDistribution),
+	})
+}
+
+// evaluateOuts evaluates the outs of every player still in the hand.
+func evaluateOuts(g *engine.Game) {
+	for _, p := range g.Players {
+		if p.Status == engine.PlayerStatusPlaying || p.Status == engine.PlayerStatusAllIn {
+			if _, err := poker.EvaluateStreet(g.HandContext(), p.Hand, g.CommunityCards, g.Phase.Street(), g.Rules); err != nil {
+				logrus.Errorf("Could not evaluate %s's outs: %v", p.Name, err)
+			}
+		}
+	}
+}
+
+// formatProfileSummary describes the speed of the profiled hands and the share
+// of the time spent in each part of the engine, largest first.
+func formatProfileSummary(rules *poker.GameRules, hands int, elapsed time.Duration, timings profileTimings) []string {
+	lines := []string{fmt.Sprintf(
+		"Played %s hands of %s in %v (%s hands/s, %v per hand).",
+		cli.FormatNumber(hands), rules.Abbreviation, elapsed.Round(time.Millisecond),
+		cli.FormatNumber(int(float64(hands)/elapsed.Seconds())), (elapsed / time.Duration(hands)).Round(time.Microsecond),
+	)}
+
+	type hotspot struct {
+		name string
+		time time.Duration
+	}
+	hotspots := []hotspot{
+		{"CPU decisions", timings.decisions},
+		{"Outs", timings.outs},
+		{"Pot distribution", timings.potDistribution},
+	}
+	other := elapsed
+	for _, h := range hotspots {
+		other -= h.time
+	}
+	sort.SliceStable(hotspots, func(i, j int) bool { return hotspots[i].time > hotspots[j].time })
+	hotspots = append(hotspots, hotspot{"Dealing and bookkeeping", other})
+
+	lines = append(lines, "Hotspots:")
+	for _, h := range hotspots {
+		lines = append(lines, fmt.Sprintf("  %-24s %10v %6.1f%%", h.name, h.time.Round(time.Millisecond), 100*h.time.Seconds()/elapsed.Seconds()))
+	}
+	return lines
+}
+
+func init() {
+	profileCmd.Flags().StringVarP(&profileRuleStr, "rule", "r", "pls7", "Game rule to play (pls7, pls, nlh, lhe, plo, plo8).")
+	profileCmd.Flags().StringVarP(&profileDifficultyStr, "difficulty", "d", "medium", "AI difficulty (easy, medium, hard).")
+	profileCmd.Flags().IntVarP(&profileHands, "hands", "n", 1000, "Number of hands to play.")
+	profileCmd.Flags().Int64Var(&profileSeed, "seed", 0, "Random seed for reproducible hands (0 uses the current time).")
+	profileCmd.Flags().StringVar(&profileCPUFile, "cpu-profile", "cpu.pprof", "File to write the CPU profile to.")
+	profileCmd.Flags().StringVar(&profileMemFile, "mem-profile", "mem.pprof", "File to write the heap profile to.")
+	rootCmd.AddCommand(profileCmd)
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayStep   bool   // To hold the replay --step flag value
+	replayAudit  bool   // To hold the replay --audit flag value
+	replayExport string // To hold the replay --export flag value
+)
+
+// replayCmd replays a saved hand with every hole card face up.
+var replayCmd = &cobra.Command{
+	Use:   "replay <hand-id|last|file>",
+	Short: "Replays a saved hand with every hole card face up",
+	Long: `Replays a saved hand street by street as a spectator sees it, with every
+player's hole cards face up. Whenever a card turns the hand around, it is
+pointed out: a made hand that was ahead being outdrawn, or the best low being
+counterfeited when the board pairs one of its hole cards. Use "last" for the
+most recent hand, or give the path of a hand history JSON file, such as one
+written with --export.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+func runReplay(_ *cobra.Command, args []string) error {
+	h, err := loadReplayHand(args[0])
+	if err != nil {
+		return err
+	}
+	if replayExport != "" {
+		if err := storage.WriteHandHistoryFile(replayExport, h); err != nil {
+			return fmt.Errorf("could not export hand %s: %w", h.ID, err)
+		}
+		fmt.Printf("Hand %s exported to %s\n", h.ID, replayExport)
+		return nil
+	}
+
+	rules := h.Rules
+	if rules == nil {
+		// Hands saved before their rules were recorded name their variant.
+		if rules, err = config.LoadGameRulesFromOptions(strings.ToLower(h.Rule)); err != nil {
+			return fmt.Errorf("could not load the rules of hand %s: %w", h.ID, err)
+		}
+	}
+	reader := bufio.NewReader(os.Stdin)
+	for i, step := range cli.FormatHandReplaySteps(h, engine.AnnotateHand(h, rules)) {
+		if replayStep && i > 0 {
+			fmt.Print("Press ENTER for the next street > ")
+			_, _ = reader.ReadString('\n')
+		}
+		for _, line := range step {
+			fmt.Println(line)
+		}
+	}
+	if replayAudit && h.Audit != nil {
+		for _, line := range cli.FormatChipAudit(h.Audit) {
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+// loadReplayHand loads the hand to replay: a hand history file when the
+// argument names one, or else a saved hand by its ID.
+func loadReplayHand(arg string) (*engine.HandHistory, error) {
+	if strings.HasSuffix(arg, ".json") {
+		return storage.ReadHandHistoryFile(arg)
+	}
+	_, h, err := loadSavedHand(arg)
+	return h, err
+}
+
+func init() {
+	replayCmd.Flags().BoolVar(&replayStep, "step", false, "Pause before each street until ENTER is pressed.")
+	replayCmd.Flags().BoolVar(&replayAudit, "audit", false, "Print the chip audit of the pot distribution after the result.")
+	replayCmd.Flags().StringVar(&replayExport, "export", "", "Write the hand as a JSON file that can be replayed later, instead of replaying it.")
+	rootCmd.AddCommand(replayCmd)
+}
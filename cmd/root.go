@@ -1,14 +1,15 @@
 package cmd
 
 import (
-	"bufio"
 	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"io"
 	"math/rand"
 	"os"
-	"pls7-cli/internal/cli"
-	"pls7-cli/internal/config"
-	"pls7-cli/internal/util"
-	"pls7-cli/pkg/engine"
 	"strings"
 	"time"
 
@@ -20,11 +21,49 @@ var (
 	ruleStr         string // To hold the --rule flag value (load rules/{rule}.yml when the game starts)
 	difficultyStr   string // To hold the flag value
 	devMode         bool   // To hold the --dev flag value
+	devPrivacy      bool   // To hold the --dev-privacy flag value (hide the CPUs' hole cards in dev mode)
 	showOuts        bool   // To hold the --outs flag value (this does not work if devMode is true, as it will always show outs in dev mode)
 	blindUpInterval int    // To hold the --blind-up flag value
+	blindMinutes    int    // To hold the --blind-minutes flag value (time-based blind levels; overrides --blind-up)
 	initialChips    int    // To hold the --initial-chips flag value
 	smallBlind      int    // To hold the --small-blind flag value
 	bigBlind        int    // To hold the --big-blind flag value
+	profileName     string // To hold the --profile flag value (key for the AI's memory of the human player)
+	freshOpponents  bool   // To hold the --fresh-opponents flag value (ignore and reset the AI's memory of the player)
+	numTables       int    // To hold the --tables flag value (number of tables played simultaneously)
+	autoMuck        bool   // To hold the --auto-muck flag value (muck the player's losing hand at showdown)
+	chaosMode       bool   // To hold the --chaos flag value (a random variant every orbit)
+	maxRebuys       int    // To hold the --rebuys flag value (how many times the player may rebuy after busting)
+	showHUD         bool   // To hold the --hud flag value (show pre-flop line statistics for each player)
+	structureName   string // To hold the --structure flag value (regular, turbo or hyper blind structure with antes)
+	anteFormatName  string // To hold the --ante-format flag value (everyone, big-blind or button antes)
+	showCoach       bool   // To hold the --coach flag value (comment on the player's session statistics between hands)
+	coachThresholds = engine.DefaultCoachThresholds()
+	readHands       bool    // To hold the --read-hands flag value (guess the opponents' hand categories before the showdown)
+	dramaticPotBB   int     // To hold the --dramatic-pot flag value (pot size in big blinds played back in slow motion)
+	streamerMode    bool    // To hold the --streamer flag value (hide the player's hole cards behind a toggle key)
+	outsDelay       int     // To hold the --outs-delay flag value (seconds to hold back the outs and equity panel)
+	useInsurance    bool    // To hold the --insurance flag value (offer insurance to the favorite of an all-in pot)
+	runItTimes      int     // To hold the --run-it flag value (how many times the rest of the board is run in all-in pots)
+	straddleName    string  // To hold the --straddle flag value (none, utg or button)
+	animationsName  string  // To hold the --animations flag value (on or off)
+	askToRunIt      bool    // To hold the --run-it-ask flag value (ask the players in an all-in pot before running the board more than once)
+	profilesPath    string  // To hold the --profiles flag value (YAML file of CPU personalities and their lineups)
+	machineOutputOn bool    // To hold the --machine-output flag value (write the game's events to standard output as JSON lines)
+	timeCharge      int     // To hold the --time-charge flag value (chips each player pays per period instead of a rake)
+	timeChargeHands int     // To hold the --time-charge-hands flag value (the time charge's period in hands)
+	timeChargeMins  int     // To hold the --time-charge-minutes flag value (the time charge's period in minutes)
+	leaveChance     float64 // To hold the --leave-chance flag value (chance each CPU leaves the table after a hand)
+	joinChance      float64 // To hold the --join-chance flag value (chance a new CPU takes an empty seat after a hand)
+	handsLimit      int     // To hold the --hands-limit flag value (hands after which the chip leader wins)
+	accessibleOn    bool    // To hold the --accessible flag value (announce the table in sentences for a screen reader)
+	verbosityName   string  // To hold the --verbosity flag value (how much the accessible mode announces)
+	runoutPause     float64 // To hold the --runout-pause flag value (seconds between the streets of an all-in runout)
+	speedMinutes    int     // To hold the --speed flag value (minutes of speed poker, with fast folds and a new lineup every hand)
+	speedTimer      int     // To hold the --speed-timer flag value (seconds the human has to act in speed poker, 0 for no limit)
+
+	actionMacros map[string]engine.ActionCommand // The saved macros, by the name typed at the action prompt
+	sessionGoals engine.SessionGoals             // To hold the --stop-win, --stop-loss and --session-hands flag values
 )
 
 // CLIActionProvider implements the ActionProvider interface using the CLI.
@@ -42,141 +81,312 @@ func (p *CPUActionProvider) GetAction(g *engine.Game, pl *engine.Player, r *rand
 }
 
 // CombinedActionProvider decides which provider to use based on player type.
-type CombinedActionProvider struct{}
+type CombinedActionProvider struct {
+	coach *engine.Coach // The coach advising the human, or nil if the coach is off
+}
 
 // GetAction method for CombinedActionProvider
 func (p *CombinedActionProvider) GetAction(g *engine.Game, player *engine.Player, r *rand.Rand) engine.PlayerAction {
 	if player.IsCPU {
-		time.Sleep(g.CPUThinkTime())
+		pace(g.CPUThinkTime())
 		return g.GetCPUAction(player, r)
 	}
-	return cli.PromptForAction(g)
+	return cli.PromptForActionWithHeader(g, coachAdvice(g, p.coach, player))
 }
 
-func runGame(_ *cobra.Command, _ []string) {
-	util.InitLogger(devMode)
+func runGame(cmd *cobra.Command, _ []string) {
+	var machineOut io.Writer
+	if machineOutputOn {
+		machineOut = divertTerminalOutput()
+	}
+	if devPrivacy {
+		initPrivateLogger()
+	} else {
+		util.InitLogger(devMode)
+	}
+	applySavedSettings(cmd)
+	cli.Accessible = accessibleOn
+	cli.AccessibleVerbosity, _ = cli.ParseVerbosity(verbosityName)
 
 	// Load game rules
 	rules, err := config.LoadGameRulesFromOptions(ruleStr)
 	if err != nil {
 		logrus.Fatalf("Failed to load game rules: %v", err)
 	}
+	if unit := rules.ChipUnit; unit > 1 && (smallBlind%unit != 0 || bigBlind%unit != 0) {
+		logrus.Fatalf("The blinds (%d/%d) must be multiples of the %s chip unit, %d", smallBlind, bigBlind, rules.Abbreviation, unit)
+	}
 
 	fmt.Printf("======== %s ========\n", rules.Name)
 
 	playerNames := []string{"YOU", "CPU 1", "CPU 2", "CPU 3", "CPU 4", "CPU 5"}
 
-	var difficulty engine.Difficulty
-	switch difficultyStr {
-	case "easy":
-		difficulty = engine.DifficultyEasy
-	case "medium":
-		difficulty = engine.DifficultyMedium
-	case "hard":
-		difficulty = engine.DifficultyHard
-	default:
-		logrus.Warnf("Invalid difficulty '%s' specified. Defaulting to medium.", difficultyStr)
-		difficulty = engine.DifficultyMedium
+	difficulty := parseDifficulty(difficultyStr)
+
+	profiles, err := loadAIProfiles(profilesPath)
+	if err != nil {
+		logrus.Fatalf("Failed to load AI profiles: %v", err)
+	}
+
+	var structure *engine.BlindStructure
+	if structureName != "" {
+		if structure, err = engine.LookupBlindStructure(structureName); err != nil {
+			logrus.Fatalf("Failed to load blind structure: %v", err)
+		}
+		if structure.AnteFormat, err = engine.ParseAnteFormat(anteFormatName); err != nil {
+			logrus.Fatalf("Failed to set the ante format: %v", err)
+		}
+		printStructureSummary(structure, len(playerNames))
+	}
+
+	newGame := func() *engine.Game {
+		g := engine.NewGame(playerNames, initialChips, smallBlind, bigBlind, difficulty, rules, devMode, showOuts, blindUpInterval)
+		if blindMinutes > 0 {
+			g.Clock = engine.NewTournamentClock(time.Duration(blindMinutes) * time.Minute)
+		}
+		if structure != nil {
+			g.UseBlindStructure(structure)
+		}
+		if err := g.UseAIProfiles(profiles); err != nil {
+			logrus.Fatalf("Failed to use AI profiles: %v", err)
+		}
+		g.AutoMuck = autoMuck
+		g.DevPrivacy = devPrivacy
+		g.ChaosMode = chaosMode
+		g.ShowsHUD = showHUD
+		g.StreamerMode = streamerMode
+		g.OffersInsurance = useInsurance
+		g.RunItTimes = runItTimes
+		g.AsksToRunIt = askToRunIt
+		g.Straddle, _ = engine.ParseStraddleSeat(straddleName)
+		g.Players[0].HandHidden = streamerMode
+		g.OutsDelay = time.Duration(outsDelay) * time.Second
+		g.Macros = actionMacros
+		g.HandsLimit = handsLimit
+		if leaveChance > 0 || joinChance > 0 {
+			g.LineupChurn = &engine.LineupChurn{LeaveChance: leaveChance, JoinChance: joinChance, BuyIn: initialChips}
+		}
+		if timeCharge > 0 {
+			g.TimeCharge = &engine.TimeCharge{
+				Amount:     timeCharge,
+				EveryHands: timeChargeHands,
+				Every:      time.Duration(timeChargeMins) * time.Minute,
+			}
+		}
+		return g
+	}
+
+	sessionStart := time.Now()
+
+	// Restore the CPUs' memory of this player from previous sessions.
+	opponentModelsStore, opponentModels := loadOpponentModels()
+	if _, ok := opponentModels[profileName]; !ok || freshOpponents {
+		opponentModels[profileName] = engine.NewOpponentModel(profileName)
+	}
+	defer saveOpponentModels(opponentModelsStore, opponentModels)
+
+	if numTables > 1 {
+		// The opponent model is not safe for concurrent updates, so only the
+		// first table learns from (and adapts to) the player.
+		first := true
+		runMultiTable(func() *engine.Game {
+			g := newGame()
+			if first {
+				g.HumanModel = opponentModels[profileName]
+				first = false
+			}
+			return g
+		}, numTables)
+		printSessionSummary(sessionStart)
+		return
+	}
+
+	g := newGame()
+	g.HumanModel = opponentModels[profileName]
+	if machineOut != nil {
+		defer startMachineOutput(g, machineOut).stop()
 	}
+	coach := newCoach()
 
-	g := engine.NewGame(playerNames, initialChips, smallBlind, bigBlind, difficulty, rules, devMode, showOuts, blindUpInterval)
+	actionProvider := &CombinedActionProvider{coach: coach}
+	rebuysLeft := maxRebuys
+	goals := newSessionGoalTracker(sessionGoals)
+	defer recordSession(g, sessionStart, goals)
 
-	actionProvider := &CombinedActionProvider{}
+	speedStart, speedHands := time.Now(), g.HandCount
+	if speedPoker() {
+		cli.ActionTimeout = time.Duration(speedTimer) * time.Second
+	}
 
 	// Main Game Loop (multi-hand)
 	for {
+		collectTimeCharge(g, printMessage)
 		cli.DisplayGameState(g)
 
-		blindEvent := g.StartNewHand()
-		if blindEvent != nil {
-			message := fmt.Sprintf("\n*** Blinds are now %s/%s ***\n", cli.FormatNumber(blindEvent.SmallBlind), cli.FormatNumber(blindEvent.BigBlind))
-			fmt.Println(message)
-		}
-
-		// Single Hand Loop
-		for g.Phase != engine.PhaseShowdown && g.Phase != engine.PhaseHandOver {
-			if g.CountNonFoldedPlayers() <= 1 {
-				break
-			}
-			g.PrepareNewBettingRound()
-
-			// New Turn-by-turn Betting Loop
-			for !g.IsBettingRoundOver() {
-				player := g.CurrentPlayer()
-				var action engine.PlayerAction
-
-				if player.Status != engine.PlayerStatusPlaying {
-					g.AdvanceTurn()
-					continue
-				}
-
-				action = actionProvider.GetAction(g, player, g.Rand)
-
-				_, event := g.ProcessAction(player, action)
-				if event != nil {
-					var eventMessage string
-					switch event.Action {
-					case engine.ActionFold:
-						eventMessage = fmt.Sprintf("%s folds.", event.PlayerName)
-					case engine.ActionCheck:
-						eventMessage = fmt.Sprintf("%s checks.", event.PlayerName)
-					case engine.ActionCall:
-						eventMessage = fmt.Sprintf("%s calls %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					case engine.ActionBet:
-						eventMessage = fmt.Sprintf("%s bets %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					case engine.ActionRaise:
-						eventMessage = fmt.Sprintf("%s raises to %s.", event.PlayerName, cli.FormatNumber(event.Amount))
-					}
-					if eventMessage != "" {
-						fmt.Println(eventMessage)
-					}
-				}
-				g.AdvanceTurn()
+		offerStraddle(g)
+		playHand(g, actionProvider, printMessage)
+		offerShowCard(g, printMessage)
+		saveHandHistory(g, printMessage)
+		printCoachFeedback(g, coach, printMessage)
+		if g.HandsLimitReached() {
+			goals.endedBy = engine.SessionLimitHands
+			for _, line := range cli.FormatStandings(g) {
+				fmt.Println(line)
 			}
-			g.Advance()
+			break
 		}
-
-		// Conclude the hand
-		if g.CountNonFoldedPlayers() > 1 {
-			showdownMessages := cli.FormatShowdownResults(g)
-			for _, msg := range showdownMessages {
-				fmt.Println(msg)
-			}
-		} else {
-			results := g.AwardPotToLastPlayer()
-			fmt.Println("--- POT AWARDED ---")
-			for _, result := range results {
-				fmt.Printf(
-					"%s wins %s chips with %s\n",
-					result.PlayerName, cli.FormatNumber(result.AmountWon), result.HandDesc,
-				)
-			}
-			fmt.Println("------------------------")
+		if offerRebuy(g, rebuysLeft) {
+			rebuysLeft--
 		}
-
-		cleanupMessages := g.CleanupHand()
-		for _, msg := range cleanupMessages {
-			fmt.Println(msg)
+		if speedPoker() {
+			recycleLineup(g, printMessage)
+		} else {
+			changeLineup(g, printMessage)
 		}
-
-		if g.Players[0].Status == engine.PlayerStatusEliminated {
-			fmt.Println("You have been eliminated. GAME OVER.")
+		goals.buyIns = 1 + maxRebuys - rebuysLeft
+		if goals.offerToEnd(g) {
+			fmt.Println("Thanks for playing!")
 			break
 		}
 
-		if g.CountRemainingPlayers() <= 1 {
-			fmt.Println("--- GAME OVER ---")
+		if over, message := isGameOver(g); over {
+			fmt.Println(message)
 			break
 		}
 
+		if speedPoker() {
+			if speedTimeUp(speedStart) {
+				fmt.Println(cli.FormatSpeedSummary(g.HandCount-speedHands, time.Since(speedStart)))
+				break
+			}
+			// After a fast fold the next hand is dealt at once; a hand played
+			// to the end stays on screen until ENTER.
+			if fastFolded {
+				fastFolded = false
+				continue
+			}
+		}
 		fmt.Print("Press ENTER to start the next hand, or type 'q' to exit > ")
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
+		input := cli.ReadLine()
 		if strings.TrimSpace(strings.ToLower(input)) == "q" {
 			fmt.Println("Thanks for playing!")
 			break
 		}
 	}
+	printSessionSummary(sessionStart)
+	for _, line := range cli.FormatSessionStats("YOUR SESSION", g.HumanStats) {
+		fmt.Println(line)
+	}
+	for _, line := range cli.FormatTimeChargeSummary(g) {
+		fmt.Println(line)
+	}
+}
+
+// printStructureSummary describes the chosen blind structure and estimates how
+// long the session will last.
+func printStructureSummary(structure *engine.BlindStructure, numPlayers int) {
+	levelDuration := structure.LevelDuration
+	if blindMinutes > 0 {
+		levelDuration = time.Duration(blindMinutes) * time.Minute
+	}
+	levels := structure.EstimatedLevels(initialChips*numPlayers, smallBlind)
+
+	estimate := time.Duration(levels) * levelDuration
+
+	antes := "antes"
+	switch structure.AnteFormat {
+	case engine.AnteBigBlind:
+		antes = "big blind antes"
+	case engine.AnteButton:
+		antes = "button antes"
+	}
+	fmt.Printf("Blind structure: %s (%d-minute levels, %s from level %d)\n", structure.Name, int(levelDuration.Minutes()), antes, structure.FirstAnteLevel())
+	fmt.Printf("Estimated session length: about %dh%02dm (%d levels)\n", int(estimate.Hours()), int(estimate.Minutes())%60, levels)
+}
+
+// applySavedSettings fills in the flags that were not given on the command
+// line from the saved settings. Failures are logged and leave the defaults.
+func applySavedSettings(cmd *cobra.Command) {
+	store, err := openStorage()
+	if err != nil {
+		logrus.Warnf("Could not open storage: %v", err)
+		return
+	}
+	settings, err := store.LoadSettings()
+	if err != nil {
+		logrus.Warnf("Could not load settings: %v", err)
+		return
+	}
+	if !cmd.Flags().Changed("streamer") {
+		streamerMode = settings.StreamerMode
+	}
+	if !cmd.Flags().Changed("outs-delay") {
+		outsDelay = settings.OutsDelaySeconds
+	}
+	if !cmd.Flags().Changed("accessible") {
+		accessibleOn = settings.Accessible
+	}
+	if !cmd.Flags().Changed("verbosity") && settings.Verbosity != "" {
+		verbosityName = settings.Verbosity
+	}
+	if !cmd.Flags().Changed("stop-win") {
+		sessionGoals.StopWinBB = settings.Goals.StopWinBB
+	}
+	if !cmd.Flags().Changed("stop-loss") {
+		sessionGoals.StopLossBB = settings.Goals.StopLossBB
+	}
+	if !cmd.Flags().Changed("session-hands") {
+		sessionGoals.Hands = settings.Goals.Hands
+	}
+	actionMacros = make(map[string]engine.ActionCommand, len(settings.Macros))
+	for name, text := range settings.Macros {
+		if err := cli.ValidateMacroName(name); err != nil {
+			logrus.Warnf("Skipping macro %s: %v", name, err)
+			continue
+		}
+		command, err := engine.ParseActionCommand(text)
+		if err != nil {
+			logrus.Warnf("Skipping macro %s: %v", name, err)
+			continue
+		}
+		actionMacros[name] = command
+	}
+}
+
+// loadOpponentModels loads the stored opponent models. Failures are logged and
+// result in an empty set so that corrupt or unreadable storage never blocks a
+// game. The storage is nil if it could not be opened.
+func loadOpponentModels() (storage.Storage, map[string]*engine.OpponentModel) {
+	store, err := openStorage()
+	if err != nil {
+		logrus.Warnf("Could not open storage: %v", err)
+		return nil, make(map[string]*engine.OpponentModel)
+	}
+	models, err := store.LoadOpponentModels()
+	if err != nil {
+		logrus.Warnf("Could not load opponent memory: %v", err)
+		return store, make(map[string]*engine.OpponentModel)
+	}
+	return store, models
+}
+
+// saveOpponentModels persists the opponent models, logging any failure.
+func saveOpponentModels(store storage.Storage, models map[string]*engine.OpponentModel) {
+	if store == nil {
+		return
+	}
+	if err := store.SaveOpponentModels(models); err != nil {
+		logrus.Warnf("Could not save opponent memory: %v", err)
+	}
+}
+
+// loadAIProfiles loads the CPU profiles from the file given with --profiles,
+// or returns the built-in ones if no file is given.
+func loadAIProfiles(path string) (*config.AIProfileSet, error) {
+	if path == "" {
+		return config.DefaultAIProfiles(), nil
+	}
+	return config.LoadAIProfilesFromFile(path)
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -191,20 +401,64 @@ var rootCmd = &cobra.Command{
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
 	err := rootCmd.Execute()
+	closeStorage()
 	if err != nil {
 		os.Exit(1)
 	}
 }
 
 func init() {
-	rootCmd.Flags().StringVarP(&ruleStr, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh).")
+	rootCmd.PersistentFlags().StringVar(&storageSpec, "storage", "", "Where profiles, settings and hand histories are kept: file (default), file:<dir> or sqlite:<path>.")
+	rootCmd.Flags().StringVarP(&ruleStr, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, lhe).")
 	rootCmd.Flags().StringVarP(&difficultyStr, "difficulty", "d", "medium", "Set AI difficulty (easy, medium, hard)")
 	rootCmd.Flags().BoolVar(&devMode, "dev", false, "Enable development mode for verbose logging.")
+	rootCmd.Flags().BoolVar(&devPrivacy, "dev-privacy", false, "With --dev, hide the CPUs' hole cards at the table and every card in the logs. The full logs are kept for \"pls7 debug-log\".")
 	rootCmd.Flags().BoolVar(&showOuts, "outs", false, "Shows outs for players if found (temporarily draws fixed good hole cards).")
 	rootCmd.Flags().IntVar(&blindUpInterval, "blind-up", 2, "Sets the number of rounds for blind up. 0 means no blind up.")
+	rootCmd.Flags().IntVar(&blindMinutes, "blind-minutes", 0, "Sets the length of each blind level in minutes, shown with a tournament clock. Overrides --blind-up. 0 disables it.")
+	rootCmd.Flags().StringVar(&structureName, "structure", "", "Tournament blind structure with antes and chip races (regular, turbo, hyper). Blinds start at --small-blind and rise with a tournament clock.")
+	rootCmd.Flags().StringVar(&anteFormatName, "ante-format", "everyone", "Who posts the antes of --structure: everyone, big-blind (the big blind antes for the table) or button.")
 	rootCmd.Flags().IntVar(&initialChips, "initial-chips", 300000, "Initial chips for each player.")
 	rootCmd.Flags().IntVar(&smallBlind, "small-blind", 500, "Small blind amount.")
 	rootCmd.Flags().IntVar(&bigBlind, "big-blind", 1000, "Big blind amount.")
+	rootCmd.Flags().IntVar(&numTables, "tables", 1, "Number of tables to play simultaneously (1-4). Press 't' at a prompt to switch tables.")
+	rootCmd.Flags().BoolVar(&chaosMode, "chaos", false, "Dealer's choice chaos mode: play a random variant each orbit (overrides --rule).")
+	rootCmd.Flags().IntVar(&maxRebuys, "rebuys", 0, "Number of times you may rebuy for the initial chips after busting.")
+	rootCmd.Flags().BoolVar(&showHUD, "hud", false, "Show each player's cold-call, squeeze and limp-reraise statistics at the table.")
+	rootCmd.Flags().BoolVar(&showCoach, "coach", false, "Comment on your continuation bets, folds and aggression on each street between hands.")
+	rootCmd.Flags().BoolVar(&readHands, "read-hands", false, "Guess each opponent's hand category at showdown before the hands are revealed, scored in your session statistics.")
+	rootCmd.Flags().IntVar(&coachThresholds.MinSpots, "coach-min-spots", coachThresholds.MinSpots, "Number of spots a statistic needs before the coach comments on it.")
+	rootCmd.Flags().Float64Var(&coachThresholds.FoldToBet, "coach-fold", coachThresholds.FoldToBet, "Fold frequency facing bets at or above which the coach comments (0-1).")
+	rootCmd.Flags().Float64Var(&coachThresholds.LowCBet, "coach-cbet-low", coachThresholds.LowCBet, "Continuation-bet frequency at or below which the coach comments (0-1).")
+	rootCmd.Flags().Float64Var(&coachThresholds.HighCBet, "coach-cbet-high", coachThresholds.HighCBet, "Continuation-bet frequency at or above which the coach comments (0-1).")
+	rootCmd.Flags().Float64Var(&coachThresholds.Aggression, "coach-aggression", coachThresholds.Aggression, "Post-flop aggression frequency at or below which the coach comments (0-1).")
+	rootCmd.Flags().IntVar(&dramaticPotBB, "dramatic-pot", 100, "Pot size in big blinds from which the hand is played back in slow motion, with a betting recap before the showdown. 0 disables it.")
+	rootCmd.Flags().BoolVar(&streamerMode, "streamer", false, "Streamer mode: hide your hole cards, hand ranks and outs until you press 'h' at a prompt. Defaults to the saved setting.")
+	rootCmd.Flags().IntVar(&outsDelay, "outs-delay", 0, "Seconds to hold back the outs and equity panel after the table is shown. Defaults to the saved setting.")
+	rootCmd.Flags().BoolVar(&useInsurance, "insurance", false, "Offer insurance, priced from exact equities, to the favorite of an all-in pot.")
+	rootCmd.Flags().IntVar(&runItTimes, "run-it", 1, fmt.Sprintf("Run the rest of the board this many times (1-%d) once all the chips are in, splitting the pot between the runs.", engine.MaxRunItTimes))
+	rootCmd.Flags().Float64Var(&runoutPause, "runout-pause", 2, "Seconds to pause after each street of an all-in runout, dealt with the players' hands face up. 0 deals the board at once.")
+	rootCmd.Flags().BoolVar(&askToRunIt, "run-it-ask", false, "With --run-it 2 or more, ask the players in each all-in pot first; the board is run once unless everyone agrees.")
+	rootCmd.Flags().StringVar(&straddleName, "straddle", "none", "Seat that may straddle twice the big blind before the cards are dealt: none, utg or button. You are asked each hand you sit there.")
+	rootCmd.Flags().StringVar(&animationsName, "animations", "on", "Animate the cards dealt to you and to the board (on, off). Only at a single table.")
+	rootCmd.Flags().BoolVar(&autoMuck, "auto-muck", true, "Muck your losing hand at showdown. You may still show one card afterwards.")
+	rootCmd.Flags().StringVar(&profilesPath, "profiles", "", "YAML file of CPU personalities (hand thresholds, bluffing frequency, raise multipliers) and the lineup played at each difficulty. Empty for the built-in profiles.")
+	rootCmd.Flags().IntVar(&sessionGoals.StopWinBB, "stop-win", 0, "Offer to end the session once you are this many big blinds up. 0 for none. Defaults to the saved setting.")
+	rootCmd.Flags().IntVar(&sessionGoals.StopLossBB, "stop-loss", 0, "Offer to end the session once you are this many big blinds down. 0 for none. Defaults to the saved setting.")
+	rootCmd.Flags().IntVar(&sessionGoals.Hands, "session-hands", 0, "Offer to end the session after this many hands. 0 for none. Defaults to the saved setting.")
+	rootCmd.Flags().BoolVar(&machineOutputOn, "machine-output", false, "Write the game's events to standard output as newline-delimited JSON for scripts and dashboards. The table and prompts go to standard error, and the screen is not cleared.")
+	rootCmd.Flags().IntVar(&timeCharge, "time-charge", 0, "Cash game time charge: chips every player pays per period, with --time-charge-hands or --time-charge-minutes, instead of a rake. 0 for none.")
+	rootCmd.Flags().IntVar(&timeChargeHands, "time-charge-hands", 0, "Collect --time-charge every this many hands.")
+	rootCmd.Flags().IntVar(&timeChargeMins, "time-charge-minutes", 0, "Collect --time-charge every this many minutes.")
+	rootCmd.Flags().Float64Var(&leaveChance, "leave-chance", 0, "Cash game lineup changes: the chance (0-1) that each CPU leaves the table after a hand. Busted CPUs always leave when lineups change.")
+	rootCmd.Flags().Float64Var(&joinChance, "join-chance", 0, "Cash game lineup changes: the chance (0-1) that a new CPU takes an empty seat after a hand, with --initial-chips.")
+	rootCmd.Flags().IntVar(&speedMinutes, "speed", 0, "Play speed poker for this many minutes: as soon as you fold, the hand is played out in the background and a new one is dealt at once against a new lineup from a pool of CPUs. 0 for none.")
+	rootCmd.Flags().IntVar(&speedTimer, "speed-timer", 15, "Speed poker: the seconds you have to act, after which you check if you can and fold otherwise. 0 for no limit.")
+	rootCmd.Flags().IntVar(&handsLimit, "hands-limit", 0, "End the game after this many hands, won by the chip leader, and print the standings. 0 plays until you are eliminated.")
+	rootCmd.Flags().BoolVar(&accessibleOn, "accessible", false, "Screen reader mode: announce the table and your turn in full sentences, name the cards in words, and leave out emojis, rules and animations. Defaults to the saved setting.")
+	rootCmd.Flags().StringVar(&verbosityName, "verbosity", "normal", fmt.Sprintf("How much the screen reader mode announces of the table (%s). Defaults to the saved setting.", strings.Join(cli.VerbosityNames(), ", ")))
+	rootCmd.Flags().StringVar(&profileName, "profile", "default", "Player profile name; CPUs remember each profile's tendencies across sessions.")
+	rootCmd.Flags().BoolVar(&freshOpponents, "fresh-opponents", false, "Reset the CPUs' memory of the current profile.")
 
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		if initialChips <= 0 {
@@ -216,6 +470,140 @@ func init() {
 		if bigBlind <= 0 {
 			return fmt.Errorf("big-blind는 0보다 커야 합니다. 입력값: %d", bigBlind)
 		}
+		if maxRebuys < 0 {
+			return fmt.Errorf("rebuys는 0 이상이어야 합니다. 입력값: %d", maxRebuys)
+		}
+		if numTables < 1 || numTables > 4 {
+			return fmt.Errorf("tables는 1 이상 4 이하여야 합니다. 입력값: %d", numTables)
+		}
+		if structureName != "" {
+			if _, err := engine.LookupBlindStructure(structureName); err != nil {
+				return fmt.Errorf("structure는 %v 중 하나여야 합니다. 입력값: %s", engine.BlindStructureNames(), structureName)
+			}
+		}
+		if _, err := engine.ParseAnteFormat(anteFormatName); err != nil {
+			return fmt.Errorf("ante-format는 %v 중 하나여야 합니다. 입력값: %s", engine.AnteFormatNames(), anteFormatName)
+		}
+		if dramaticPotBB < 0 {
+			return fmt.Errorf("dramatic-pot는 0 이상이어야 합니다. 입력값: %d", dramaticPotBB)
+		}
+		if speedMinutes < 0 {
+			return fmt.Errorf("speed는 0 이상이어야 합니다. 입력값: %d", speedMinutes)
+		}
+		if speedTimer < 0 {
+			return fmt.Errorf("speed-timer는 0 이상이어야 합니다. 입력값: %d", speedTimer)
+		}
+		if speedMinutes > 0 && numTables > 1 {
+			return fmt.Errorf("speed는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if speedMinutes > 0 && (leaveChance > 0 || joinChance > 0) {
+			return fmt.Errorf("speed는 --leave-chance, --join-chance와 함께 사용할 수 없습니다. 입력값: %g, %g", leaveChance, joinChance)
+		}
+		if speedMinutes > 0 && structureName != "" {
+			return fmt.Errorf("speed는 캐시 게임 옵션이므로 --structure와 함께 사용할 수 없습니다. 입력값: %s", structureName)
+		}
+		if runoutPause < 0 {
+			return fmt.Errorf("runout-pause는 0 이상이어야 합니다. 입력값: %g", runoutPause)
+		}
+		if useInsurance && numTables > 1 {
+			return fmt.Errorf("insurance는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if err := engine.ValidateRunItTimes(runItTimes); err != nil {
+			return fmt.Errorf("run-it은 1 이상 %d 이하여야 합니다. 입력값: %d", engine.MaxRunItTimes, runItTimes)
+		}
+		if askToRunIt && runItTimes < 2 {
+			return fmt.Errorf("run-it-ask는 --run-it 2 이상과 함께 사용해야 합니다. 입력값: %d", runItTimes)
+		}
+		if askToRunIt && numTables > 1 {
+			return fmt.Errorf("run-it-ask는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if useInsurance && runItTimes > 1 {
+			return fmt.Errorf("insurance는 --run-it 1에서만 사용할 수 있습니다. 입력값: %d", runItTimes)
+		}
+		if _, err := engine.ParseStraddleSeat(straddleName); err != nil {
+			return fmt.Errorf("straddle는 %v 중 하나여야 합니다. 입력값: %s", engine.StraddleSeatNames(), straddleName)
+		}
+		if animationsName != "on" && animationsName != "off" {
+			return fmt.Errorf("animations는 on 또는 off여야 합니다. 입력값: %s", animationsName)
+		}
+		if _, err := cli.ParseVerbosity(verbosityName); err != nil {
+			return fmt.Errorf("verbosity는 %v 중 하나여야 합니다. 입력값: %s", cli.VerbosityNames(), verbosityName)
+		}
+		if devPrivacy && !devMode {
+			return fmt.Errorf("dev-privacy는 --dev와 함께 사용해야 합니다")
+		}
+		for name, limit := range map[string]int{
+			"stop-win":      sessionGoals.StopWinBB,
+			"stop-loss":     sessionGoals.StopLossBB,
+			"session-hands": sessionGoals.Hands,
+		} {
+			if limit < 0 {
+				return fmt.Errorf("%s는 0 이상이어야 합니다. 입력값: %d", name, limit)
+			}
+		}
+		if sessionGoals.IsSet() && numTables > 1 {
+			return fmt.Errorf("stop-win, stop-loss, session-hands는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if readHands && numTables > 1 {
+			return fmt.Errorf("read-hands는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if machineOutputOn && numTables > 1 {
+			return fmt.Errorf("machine-output는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+		}
+		if timeCharge < 0 || timeChargeHands < 0 || timeChargeMins < 0 {
+			return fmt.Errorf("time-charge, time-charge-hands, time-charge-minutes는 0 이상이어야 합니다")
+		}
+		if timeCharge > 0 {
+			if (timeChargeHands > 0) == (timeChargeMins > 0) {
+				return fmt.Errorf("time-charge는 --time-charge-hands 또는 --time-charge-minutes 중 하나와 함께 사용해야 합니다")
+			}
+			if numTables > 1 {
+				return fmt.Errorf("time-charge는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+			}
+			if structureName != "" {
+				return fmt.Errorf("time-charge는 캐시 게임 옵션이므로 --structure와 함께 사용할 수 없습니다. 입력값: %s", structureName)
+			}
+		} else if timeChargeHands > 0 || timeChargeMins > 0 {
+			return fmt.Errorf("time-charge-hands와 time-charge-minutes는 --time-charge와 함께 사용해야 합니다")
+		}
+		for name, chance := range map[string]float64{
+			"leave-chance": leaveChance,
+			"join-chance":  joinChance,
+		} {
+			if chance < 0 || chance > 1 {
+				return fmt.Errorf("%s는 0 이상 1 이하여야 합니다. 입력값: %g", name, chance)
+			}
+		}
+		if leaveChance > 0 || joinChance > 0 {
+			if numTables > 1 {
+				return fmt.Errorf("leave-chance와 join-chance는 --tables 1에서만 사용할 수 있습니다. 입력값: %d", numTables)
+			}
+			if structureName != "" {
+				return fmt.Errorf("leave-chance와 join-chance는 캐시 게임 옵션이므로 --structure와 함께 사용할 수 없습니다. 입력값: %s", structureName)
+			}
+		}
+		if handsLimit < 0 {
+			return fmt.Errorf("hands-limit는 0 이상이어야 합니다. 입력값: %d", handsLimit)
+		}
+		if outsDelay < 0 {
+			return fmt.Errorf("outs-delay는 0 이상이어야 합니다. 입력값: %d", outsDelay)
+		}
+		if coachThresholds.MinSpots < 1 {
+			return fmt.Errorf("coach-min-spots는 1 이상이어야 합니다. 입력값: %d", coachThresholds.MinSpots)
+		}
+		for name, f := range map[string]float64{
+			"coach-fold":       coachThresholds.FoldToBet,
+			"coach-cbet-low":   coachThresholds.LowCBet,
+			"coach-cbet-high":  coachThresholds.HighCBet,
+			"coach-aggression": coachThresholds.Aggression,
+		} {
+			if f < 0 || f > 1 {
+				return fmt.Errorf("%s는 0 이상 1 이하여야 합니다. 입력값: %g", name, f)
+			}
+		}
+		if coachThresholds.LowCBet >= coachThresholds.HighCBet {
+			return fmt.Errorf("coach-cbet-low(%g)는 coach-cbet-high(%g)보다 작아야 합니다", coachThresholds.LowCBet, coachThresholds.HighCBet)
+		}
 		if smallBlind >= bigBlind {
 			return fmt.Errorf("small-blind(%d)는 big-blind(%d)보다 작아야 합니다", smallBlind, bigBlind)
 		}
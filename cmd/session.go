@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// sessionGoalTracker follows the human's result against their session goals.
+type sessionGoalTracker struct {
+	goals engine.SessionGoals
+	// buyIns counts the stacks of --initial-chips the human has bought,
+	// rebuys included.
+	buyIns int
+	// offered holds the goals the human has already been offered to stop at.
+	offered map[engine.SessionLimit]bool
+	// endedBy is the goal the human ended the session at.
+	endedBy engine.SessionLimit
+}
+
+func newSessionGoalTracker(goals engine.SessionGoals) *sessionGoalTracker {
+	return &sessionGoalTracker{goals: goals, buyIns: 1, offered: make(map[engine.SessionLimit]bool)}
+}
+
+// net returns the human's net result of the session, in chips.
+func (t *sessionGoalTracker) net(g *engine.Game) int {
+	return g.Players[0].Chips - t.buyIns*initialChips
+}
+
+// offerToEnd warns the human when a session goal has been reached and asks
+// whether to end the session. Each goal is offered once, so a player who
+// plays on is not asked again until another goal is reached. It reports
+// whether the player chose to stop.
+func (t *sessionGoalTracker) offerToEnd(g *engine.Game) bool {
+	limit := t.goals.Reached(t.net(g), bigBlind, g.HandCount)
+	if limit == engine.SessionLimitNone || t.offered[limit] {
+		return false
+	}
+	t.offered[limit] = true
+
+	fmt.Println(cli.FormatSessionGoal(limit, t.net(g), bigBlind, g.HandCount))
+	fmt.Print("End the session now? (Y/n) > ")
+	input := cli.ReadLine()
+	if strings.TrimSpace(strings.ToLower(input)) == "n" {
+		return false
+	}
+	t.endedBy = limit
+	return true
+}
+
+// recordSession adds the session to the profile's bankroll in storage, and
+// prints the bankroll with it. Failures are logged, as the game is already
+// over.
+func recordSession(g *engine.Game, sessionStart time.Time, t *sessionGoalTracker) {
+	if g.HandCount == 0 {
+		return
+	}
+	store, err := openStorage()
+	if err != nil {
+		logrus.Warnf("Could not open storage: %v", err)
+		return
+	}
+	sessions, err := store.LoadSessions()
+	if err != nil {
+		logrus.Warnf("Could not load past sessions: %v", err)
+		return
+	}
+	record := engine.SessionRecord{
+		StartedAt:   sessionStart,
+		EndedAt:     time.Now(),
+		Rule:        g.Rules.Abbreviation,
+		BigBlind:    bigBlind,
+		Hands:       g.HandCount,
+		NetChips:    t.net(g),
+		Goals:       t.goals,
+		EndedBy:     t.endedBy,
+		TimeCharged: g.TimeCharged[g.Players[0].Name],
+		Stats:       g.HumanStats,
+	}
+	sessions[profileName] = append(sessions[profileName], record)
+	if err := store.SaveSessions(sessions); err != nil {
+		logrus.Warnf("Could not save the session: %v", err)
+	}
+	for _, line := range cli.FormatBankroll(profileName, sessions[profileName]) {
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// settingsCmd shows or changes the preferences saved between sessions.
+var settingsCmd = &cobra.Command{
+	Use:   "settings [<name> <value> | macro <name> <action>]",
+	Short: "Shows or changes your saved settings",
+	Long: `Shows your saved settings, or changes one of them. Saved settings apply to
+every game, unless overridden by the game's flags:
+
+  streamer     on or off: start with your hole cards hidden (see --streamer)
+  outs-delay   seconds to hold back the outs and equity panel (see --outs-delay)
+  stop-win     big blinds won at which to offer to end the session, 0 for none (see --stop-win)
+  stop-loss    big blinds lost at which to offer to end the session, 0 for none (see --stop-loss)
+  session-hands  hands after which to offer to end the session, 0 for none (see --session-hands)
+  accessible   on or off: announce the table in sentences for a screen reader (see --accessible)
+  verbosity    brief, normal or full: how much the screen reader mode announces (see --verbosity)
+
+For example, "pls7 settings streamer on".
+
+Macros bind a short name typed at the action prompt to an action, with the
+bet size in chips, big blinds, a percent of the pot, "pot" or "all-in":
+
+  pls7 settings macro m1 "raise 2.5bb"
+  pls7 settings macro m2 "bet 66% pot"
+  pls7 settings macro m2 ""             removes the macro`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		switch {
+		case len(args) == 0, len(args) == 2 && args[0] != "macro", len(args) == 3 && args[0] == "macro":
+			return nil
+		}
+		return fmt.Errorf("expected no arguments, a setting name and value, or macro with a name and action, but got %d arguments", len(args))
+	},
+	RunE: runSettings,
+}
+
+func runSettings(_ *cobra.Command, args []string) error {
+	store, err := openStorage()
+	if err != nil {
+		return err
+	}
+	settings, err := store.LoadSettings()
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		if len(args) == 3 {
+			err = setMacro(settings, args[1], args[2])
+		} else {
+			err = applySetting(settings, args[0], args[1])
+		}
+		if err != nil {
+			return err
+		}
+		if err := store.SaveSettings(settings); err != nil {
+			return err
+		}
+	}
+
+	streamer := "off"
+	if settings.StreamerMode {
+		streamer = "on"
+	}
+	fmt.Printf("streamer     %s\n", streamer)
+	fmt.Printf("outs-delay   %d\n", settings.OutsDelaySeconds)
+	fmt.Printf("stop-win     %d\n", settings.Goals.StopWinBB)
+	fmt.Printf("stop-loss    %d\n", settings.Goals.StopLossBB)
+	fmt.Printf("session-hands %d\n", settings.Goals.Hands)
+	accessible := "off"
+	if settings.Accessible {
+		accessible = "on"
+	}
+	verbosity := settings.Verbosity
+	if verbosity == "" {
+		verbosity = cli.VerbosityNormal.String()
+	}
+	fmt.Printf("accessible   %s\n", accessible)
+	fmt.Printf("verbosity    %s\n", verbosity)
+	names := make([]string, 0, len(settings.Macros))
+	for name := range settings.Macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("macro %-6s %s\n", name, settings.Macros[name])
+	}
+	return nil
+}
+
+// setMacro binds the macro name to the action, or removes it if the action is
+// empty.
+func setMacro(settings *storage.Settings, name, action string) error {
+	if strings.TrimSpace(action) == "" {
+		delete(settings.Macros, name)
+		return nil
+	}
+	if err := cli.ValidateMacroName(name); err != nil {
+		return err
+	}
+	command, err := engine.ParseActionCommand(action)
+	if err != nil {
+		return fmt.Errorf("macro %s: %w", name, err)
+	}
+	if settings.Macros == nil {
+		settings.Macros = make(map[string]string)
+	}
+	settings.Macros[name] = command.Text
+	return nil
+}
+
+// applySetting sets the named setting from its value on the command line.
+func applySetting(settings *storage.Settings, name, value string) error {
+	switch name {
+	case "streamer":
+		switch value {
+		case "on":
+			settings.StreamerMode = true
+		case "off":
+			settings.StreamerMode = false
+		default:
+			return fmt.Errorf("streamer must be on or off, got %q", value)
+		}
+	case "accessible":
+		switch value {
+		case "on":
+			settings.Accessible = true
+		case "off":
+			settings.Accessible = false
+		default:
+			return fmt.Errorf("accessible must be on or off, got %q", value)
+		}
+	case "verbosity":
+		if _, err := cli.ParseVerbosity(value); err != nil {
+			return err
+		}
+		settings.Verbosity = value
+	case "outs-delay":
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			return fmt.Errorf("outs-delay must be a number of seconds, 0 or more, got %q", value)
+		}
+		settings.OutsDelaySeconds = seconds
+	case "stop-win", "stop-loss", "session-hands":
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("%s must be a number, 0 or more, got %q", name, value)
+		}
+		switch name {
+		case "stop-win":
+			settings.Goals.StopWinBB = limit
+		case "stop-loss":
+			settings.Goals.StopLossBB = limit
+		default:
+			settings.Goals.Hands = limit
+		}
+	default:
+		return fmt.Errorf("unknown setting %q (use streamer, outs-delay, stop-win, stop-loss, session-hands, accessible or verbosity)", name)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(settingsCmd)
+}
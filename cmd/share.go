@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var shareCopy bool // To hold the share --copy flag value
+
+// shareCmd prints an anonymized hand history that can be posted on forums.
+var shareCmd = &cobra.Command{
+	Use:   "share <hand-id|last>",
+	Short: "Prints an anonymized hand history for sharing",
+	Long: `Prints a saved hand as plain text that is safe to share: player names are
+replaced with seat numbers, unrevealed hole cards are removed, and all amounts
+are given in big blinds. The hand ID is shown at the end of every hand; use
+"last" for the most recent hand.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runShare,
+}
+
+func runShare(_ *cobra.Command, args []string) error {
+	id, h, err := loadSavedHand(args[0])
+	if err != nil {
+		return err
+	}
+	text := cli.FormatSharedHand(h)
+
+	if shareCopy {
+		if err := copyToClipboard(text); err != nil {
+			return fmt.Errorf("could not copy to clipboard: %w", err)
+		}
+		fmt.Printf("Hand %s copied to clipboard.\n", id)
+		return nil
+	}
+	fmt.Print(text)
+	return nil
+}
+
+// loadSavedHand loads a saved hand by ID, or the most recent hand if id is
+// "last". It returns the resolved ID along with the hand.
+func loadSavedHand(id string) (string, *engine.HandHistory, error) {
+	store, err := openStorage()
+	if err != nil {
+		return "", nil, err
+	}
+	if id == "last" {
+		ids, err := store.ListHandHistoryIDs()
+		if err != nil {
+			return "", nil, err
+		}
+		if len(ids) == 0 {
+			return "", nil, errors.New("no saved hands yet")
+		}
+		id = ids[len(ids)-1]
+	}
+
+	h, err := store.LoadHandHistory(id)
+	return id, h, err
+}
+
+// copyToClipboard copies text to the system clipboard using the platform's
+// clipboard utility.
+func copyToClipboard(text string) error {
+	var candidates [][]string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = [][]string{{"pbcopy"}}
+	case "windows":
+		candidates = [][]string{{"clip"}}
+	default:
+		candidates = [][]string{{"wl-copy"}, {"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+	}
+
+	for _, candidate := range candidates {
+		if _, err := exec.LookPath(candidate[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(candidate[0], candidate[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return errors.New("no clipboard utility found")
+}
+
+func init() {
+	shareCmd.Flags().BoolVarP(&shareCopy, "copy", "c", false, "Copy the hand to the clipboard instead of printing it.")
+	rootCmd.AddCommand(shareCmd)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	simulateRuleStr       string // To hold the simulate --rule flag value
+	simulateDifficultyStr string // To hold the simulate --difficulty flag value
+	simulateHands         int    // To hold the simulate --hands flag value
+	simulatePlayers       int    // To hold the simulate --players flag value
+	simulateSeed          int64  // To hold the simulate --seed flag value (0 means a time-based seed)
+	simulateProfilesPath  string // To hold the simulate --profiles flag value
+	simulateInitialChips  int    // To hold the simulate --initial-chips flag value
+	simulateSmallBlind    int    // To hold the simulate --small-blind flag value
+	simulateBigBlind      int    // To hold the simulate --big-blind flag value
+)
+
+// simulateCmd plays CPU-only games in batch and reports how each profile did.
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Plays headless CPU-only games and reports how each profile did",
+	Long: `Plays hands between CPUs only, with no prompts and no pauses, at fixed blinds.
+Each game seats the lineup of the difficulty in a random order and is played
+until one CPU has all the chips; then a new game starts, until the given number
+of hands has been played.
+
+The report shows, for each profile, the hands its CPUs were dealt in, the share
+of them they won chips in, their win rate in big blinds per 100 hands, and how
+often they finished in each place of the games played to a winner. Press Ctrl+C
+to stop early and report the hands played so far.`,
+	RunE: runSimulate,
+}
+
+func runSimulate(cmd *cobra.Command, _ []string) error {
+	if simulateInitialChips <= 0 || simulateSmallBlind <= 0 || simulateBigBlind < simulateSmallBlind {
+		return fmt.Errorf("the stacks and blinds must be positive, with the big blind at least the small blind, got %d and %d/%d",
+			simulateInitialChips, simulateSmallBlind, simulateBigBlind)
+	}
+	rules, err := config.LoadGameRulesFromOptions(simulateRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+	profiles, err := loadAIProfiles(simulateProfilesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load AI profiles: %w", err)
+	}
+	util.InitLogger(false)
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	seed := simulateSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	setup := engine.SimulationSetup{
+		Rules:        rules,
+		Difficulty:   parseDifficulty(simulateDifficultyStr),
+		Profiles:     profiles,
+		Players:      simulatePlayers,
+		InitialChips: simulateInitialChips,
+		SmallBlind:   simulateSmallBlind,
+		BigBlind:     simulateBigBlind,
+		Hands:        simulateHands,
+	}
+	fmt.Printf("Simulating %s hands of %s between %d CPUs (seed %d)...\n",
+		cli.FormatNumber(simulateHands), rules.Abbreviation, simulatePlayers, seed)
+	started := time.Now()
+	report, err := engine.Simulate(ctx, setup, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+	for _, line := range formatSimulationReport(rules, report, time.Since(started)) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// formatSimulationReport describes the hands simulated and each profile's
+// results, one profile per line.
+func formatSimulationReport(rules *poker.GameRules, report *engine.SimulationReport, elapsed time.Duration) []string {
+	lines := []string{fmt.Sprintf(
+		"Played %s hands of %s in %v, %s games to a winner.",
+		cli.FormatNumber(report.Hands), rules.Abbreviation, elapsed.Round(time.Millisecond), cli.FormatNumber(report.Games),
+	)}
+	var places []string
+	if len(report.Profiles) > 0 {
+		for i := range report.Profiles[0].Places {
+			places = append(places, fmt.Sprintf("%5s", engine.Ordinal(i+1)))
+		}
+	}
+	header := fmt.Sprintf("%-20s %8s %6s %9s  %s", "Profile", "Hands", "Won", "bb/100", strings.Join(places, " "))
+	lines = append(lines, header)
+	for _, p := range report.Profiles {
+		places := make([]string, len(p.Places))
+		for i, n := range p.Places {
+			places[i] = fmt.Sprintf("%5d", n)
+		}
+		lines = append(lines, fmt.Sprintf("%-20s %8s %5.1f%% %+9.1f  %s",
+			p.Profile, cli.FormatNumber(p.Hands), p.WinRate()*100, p.BBPer100(report.BigBlind), strings.Join(places, " ")))
+	}
+	return lines
+}
+
+func init() {
+	simulateCmd.Flags().StringVarP(&simulateRuleStr, "rule", "r", "pls7", "Game rule to play (pls7, pls, nlh, lhe, plo, plo8).")
+	simulateCmd.Flags().StringVarP(&simulateDifficultyStr, "difficulty", "d", "medium", "AI difficulty whose lineup is seated (easy, medium, hard).")
+	simulateCmd.Flags().IntVarP(&simulateHands, "hands", "n", 10000, "Number of hands to play over all the games.")
+	simulateCmd.Flags().IntVar(&simulatePlayers, "players", engine.MaxTableSize, fmt.Sprintf("Number of CPUs at the table (2-%d).", engine.MaxTableSize))
+	simulateCmd.Flags().Int64Var(&simulateSeed, "seed", 0, "Random seed for reproducible hands (0 uses the current time).")
+	simulateCmd.Flags().StringVar(&simulateProfilesPath, "profiles", "", "YAML file of CPU personalities and the lineup played at each difficulty. Empty for the built-in profiles.")
+	// Short stacks, 20 big blinds deep, play most games to a winner within a
+	// few hundred hands.
+	simulateCmd.Flags().IntVar(&simulateInitialChips, "initial-chips", 2000, "Initial chips for each CPU.")
+	simulateCmd.Flags().IntVar(&simulateSmallBlind, "small-blind", 50, "Small blind amount.")
+	simulateCmd.Flags().IntVar(&simulateBigBlind, "big-blind", 100, "Big blind amount.")
+	rootCmd.AddCommand(simulateCmd)
+}
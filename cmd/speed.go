@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"time"
+)
+
+// backgroundHand is set while the CPUs play out the rest of a hand the human
+// fast-folded in speed poker: the hand is resolved unseen and without pauses.
+var backgroundHand bool
+
+// fastFolded is set when the human fast-folded the last hand, which skips the
+// pause before the next one.
+var fastFolded bool
+
+// speedPoker reports whether the session is a timed speed poker session.
+func speedPoker() bool {
+	return speedMinutes > 0
+}
+
+// fastFolds reports whether the action folds the human out of the hand in
+// speed poker, so that the rest of it is played out in the background.
+func fastFolds(player *engine.Player, action engine.PlayerAction) bool {
+	return speedPoker() && !player.IsCPU && action.Type == engine.ActionFold
+}
+
+// speedTimeUp reports whether the speed poker session that started at start
+// has run for the minutes given with --speed.
+func speedTimeUp(start time.Time) bool {
+	return speedPoker() && time.Since(start) >= time.Duration(speedMinutes)*time.Minute
+}
+
+// recycleLineup seats a new lineup of CPUs from the fast-fold pool for the
+// next hand of speed poker, each with --initial-chips.
+func recycleLineup(g *engine.Game, emit func(string)) {
+	if changes := g.RecycleLineup(g.Rand, initialChips); len(changes) > 0 {
+		emit(cli.FormatNewLineup(changes))
+	}
+}
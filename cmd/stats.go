@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsRuleStr string // To hold the stats --rule flag value
+	statsPlayers int    // To hold the stats --players flag value
+	statsHands   int    // To hold the stats --hands flag value
+	statsSeed    int64  // To hold the stats --seed flag value (0 means a time-based seed)
+	statsOutput  string // To hold the stats --output flag value (empty means stdout)
+)
+
+// statsCmd simulates showdowns and reports how often each hand rank is made and wins.
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Reports hand rank frequencies at showdown as CSV",
+	Long: `Simulates random hands run to the river for a variant and player count, and
+reports how often each hand rank (including Skip Straight and Skip Straight Flush)
+is made and wins at showdown. The result is written as CSV. Press Ctrl+C to stop
+a long simulation.`,
+	RunE: runStats,
+}
+
+func runStats(cmd *cobra.Command, _ []string) error {
+	rules, err := config.LoadGameRulesFromOptions(statsRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+
+	seed := statsSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	stats, err := poker.SimulateShowdowns(ctx, rules, statsPlayers, statsHands, rand.New(rand.NewSource(seed)))
+	if errors.Is(err, context.Canceled) {
+		return errors.New("simulation interrupted")
+	}
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if statsOutput != "" {
+		f, err := os.Create(statsOutput)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+		logrus.Infof("Writing hand rank statistics to %s", statsOutput)
+	}
+	return writeHandRankStatsCSV(out, rules, stats)
+}
+
+// writeHandRankStatsCSV writes one row per hand rank, from strongest to weakest.
+func writeHandRankStatsCSV(w io.Writer, rules *poker.GameRules, stats *poker.HandRankStats) error {
+	cw := csv.NewWriter(w)
+	header := []string{"variant", "players", "hands", "hand_rank", "made", "made_pct", "wins", "win_pct"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for rank := poker.RoyalFlush; rank >= poker.HighCard; rank-- {
+		row := []string{
+			rules.Abbreviation,
+			strconv.Itoa(stats.Players),
+			strconv.Itoa(stats.Hands),
+			rank.String(),
+			strconv.Itoa(stats.Made[rank]),
+			strconv.FormatFloat(stats.MadeFrequency(rank)*100, 'f', 4, 64),
+			strconv.Itoa(stats.Wins[rank]),
+			strconv.FormatFloat(stats.WinFrequency(rank)*100, 'f', 4, 64),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsRuleStr, "rule", "r", "pls7", "Game rule to simulate (pls7, pls, nlh, lhe, plo, plo8).")
+	statsCmd.Flags().IntVarP(&statsPlayers, "players", "p", 6, "Number of players dealt into each hand.")
+	statsCmd.Flags().IntVarP(&statsHands, "hands", "n", 10000, "Number of hands to simulate.")
+	statsCmd.Flags().Int64Var(&statsSeed, "seed", 0, "Random seed for reproducible results (0 uses the current time).")
+	statsCmd.Flags().StringVarP(&statsOutput, "output", "o", "", "CSV file to write (defaults to stdout).")
+	rootCmd.AddCommand(statsCmd)
+}
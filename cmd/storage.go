@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/philipjkim/pls7-cli/internal/storage"
+	"sync"
+)
+
+var (
+	storageSpec string // To hold the --storage flag value (where data is kept between sessions)
+
+	storeOnce sync.Once
+	store     storage.Storage
+	storeErr  error
+)
+
+// openStorage opens the storage chosen with --storage the first time it is
+// called, and returns the same storage afterwards. Tables played at once share it.
+func openStorage() (storage.Storage, error) {
+	storeOnce.Do(func() {
+		store, storeErr = storage.Open(storageSpec)
+	})
+	return store, storeErr
+}
+
+// closeStorage closes the storage if it was opened.
+func closeStorage() {
+	if store != nil {
+		store.Close()
+	}
+}
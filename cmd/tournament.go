@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tournamentRuleStr       string // To hold the tournament --rule flag value
+	tournamentDifficultyStr string // To hold the tournament --difficulty flag value
+	tournamentEntrants      int    // To hold the tournament --entrants flag value
+	tournamentTableSize     int    // To hold the tournament --table-size flag value
+	tournamentStack         int    // To hold the tournament --starting-stack flag value
+	tournamentStructure     string // To hold the tournament --structure flag value
+	tournamentAnteFormat    string // To hold the tournament --ante-format flag value
+	tournamentHandsPerLevel int    // To hold the tournament --hands-per-level flag value
+	tournamentLevelMinutes  int    // To hold the tournament --level-minutes flag value (0 uses the structure's)
+	tournamentBuyIn         int    // To hold the tournament --buy-in flag value
+	tournamentPayouts       string // To hold the tournament --payouts flag value (empty uses the default payouts)
+)
+
+// tournamentCmd plays a multi-table tournament against CPUs.
+var tournamentCmd = &cobra.Command{
+	Use:   "tournament",
+	Short: "Plays a multi-table tournament with a prize pool",
+	Long: `Seats the entrants at tables of up to --table-size players, with you at
+table 1, and plays down to a winner. Every table deals a hand at the same time;
+you play yours, and the CPU tables play theirs at once. The blinds and antes
+follow --structure, going up every --hands-per-level hands or, with 0, on the
+tournament clock. As players are eliminated, players move between tables to
+keep them balanced, and tables break until the final table.
+
+At the end, the finishing positions are listed with the prizes paid from the
+buy-ins. Once you are eliminated, the rest of the tournament is played out
+without you.`,
+	RunE: runTournament,
+}
+
+func runTournament(_ *cobra.Command, _ []string) error {
+	rules, err := config.LoadGameRulesFromOptions(tournamentRuleStr)
+	if err != nil {
+		return fmt.Errorf("failed to load game rules: %w", err)
+	}
+	structure, err := engine.LookupBlindStructure(tournamentStructure)
+	if err != nil {
+		return err
+	}
+	if structure.AnteFormat, err = engine.ParseAnteFormat(tournamentAnteFormat); err != nil {
+		return err
+	}
+	payouts, err := parsePayouts(tournamentPayouts)
+	if err != nil {
+		return err
+	}
+	t, err := engine.NewTournament(engine.TournamentConfig{
+		Entrants:      tournamentEntrants,
+		TableSize:     tournamentTableSize,
+		StartingStack: tournamentStack,
+		SmallBlind:    smallBlind,
+		Structure:     structure,
+		HandsPerLevel: tournamentHandsPerLevel,
+		LevelDuration: time.Duration(tournamentLevelMinutes) * time.Minute,
+		BuyIn:         tournamentBuyIn,
+		Payouts:       payouts,
+		Human:         true,
+		Difficulty:    parseDifficulty(tournamentDifficultyStr),
+		Rules:         rules,
+	})
+	if err != nil {
+		return err
+	}
+	// Keep the engine's warnings about ordinary side pots off the table.
+	util.InitLogger(false)
+	logrus.SetLevel(logrus.ErrorLevel)
+
+	fmt.Printf("======== %s TOURNAMENT ========\n", rules.Name)
+	fmt.Printf("%d entrants at %d tables | Prize pool: %s\n", tournamentEntrants, len(t.Tables), cli.FormatNumber(t.PrizePool))
+
+	actionProvider := &CombinedActionProvider{}
+	reader := bufio.NewReader(os.Stdin)
+	for !t.Over() {
+		t.StartRound()
+		t.PlayCPUHands()
+		human := t.HumanTable()
+		if human != nil {
+			fmt.Printf("\n--- Table %d | %d players remaining ---\n", human.Number, t.PlayersRemaining())
+			cli.DisplayGameState(human.Game)
+			playHand(human.Game, actionProvider, printMessage)
+			offerShowCard(human.Game, printMessage)
+		}
+		for _, msg := range t.FinishRound() {
+			if human != nil {
+				printMessage(msg)
+			}
+		}
+
+		if human == nil || t.Over() {
+			continue
+		}
+		if t.HumanTable() == nil {
+			fmt.Println("Playing out the rest of the tournament...")
+			continue
+		}
+		fmt.Print("Press ENTER to start the next hand, or type 'q' to exit > ")
+		input, _ := reader.ReadString('\n')
+		if strings.TrimSpace(strings.ToLower(input)) == "q" {
+			fmt.Println("Thanks for playing!")
+			return nil
+		}
+	}
+
+	for _, line := range formatTournamentResults(t) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// parsePayouts parses comma-separated payout percentages, e.g. "50,30,20". An
+// empty string selects the default payouts.
+func parsePayouts(s string) ([]float64, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var payouts []float64
+	for _, field := range strings.Split(s, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payout %q: %w", field, err)
+		}
+		payouts = append(payouts, p)
+	}
+	return payouts, nil
+}
+
+// formatTournamentResults lists the finishing positions from the winner down,
+// with the prizes paid.
+func formatTournamentResults(t *engine.Tournament) []string {
+	lines := []string{
+		"\n--- TOURNAMENT RESULTS ---",
+		fmt.Sprintf("Prize pool: %s (%d entrants x %s)", cli.FormatNumber(t.PrizePool), t.Config.Entrants, cli.FormatNumber(t.Config.BuyIn)),
+	}
+	for _, f := range t.Results() {
+		line := fmt.Sprintf("%5s  %s", engine.Ordinal(f.Place), f.PlayerName)
+		if f.Prize > 0 {
+			line = fmt.Sprintf("%5s  %-8s  %s", engine.Ordinal(f.Place), f.PlayerName, cli.FormatNumber(f.Prize))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func init() {
+	tournamentCmd.Flags().StringVarP(&tournamentRuleStr, "rule", "r", "pls7", "Game rule to use (pls7, pls, nlh, lhe).")
+	tournamentCmd.Flags().StringVarP(&tournamentDifficultyStr, "difficulty", "d", "medium", "AI difficulty (easy, medium, hard).")
+	tournamentCmd.Flags().IntVar(&tournamentEntrants, "entrants", 18, fmt.Sprintf("Number of players, you included (2-%d).", engine.MaxEntrants))
+	tournamentCmd.Flags().IntVar(&tournamentTableSize, "table-size", engine.MaxTableSize, fmt.Sprintf("Most players seated at one table (3-%d).", engine.MaxTableSize))
+	tournamentCmd.Flags().IntVar(&tournamentStack, "starting-stack", 50000, "Chips every player starts with.")
+	tournamentCmd.Flags().IntVar(&smallBlind, "small-blind", 500, "Small blind of the first level; the structure's levels are multiples of it.")
+	tournamentCmd.Flags().StringVar(&tournamentStructure, "structure", "turbo", "Blind structure with antes (regular, turbo, hyper).")
+	tournamentCmd.Flags().StringVar(&tournamentAnteFormat, "ante-format", "everyone", "Who posts the antes: everyone, big-blind (the big blind antes for the table) or button.")
+	tournamentCmd.Flags().IntVar(&tournamentHandsPerLevel, "hands-per-level", 8, "Number of hands at each blind level. 0 times the levels with the tournament clock instead.")
+	tournamentCmd.Flags().IntVar(&tournamentLevelMinutes, "level-minutes", 0, "Length of each blind level in minutes with --hands-per-level 0. 0 uses the structure's.")
+	tournamentCmd.Flags().IntVar(&tournamentBuyIn, "buy-in", 100, "Buy-in each entrant pays into the prize pool.")
+	tournamentCmd.Flags().StringVar(&tournamentPayouts, "payouts", "", "Comma-separated percentages of the prize pool paid to 1st, 2nd, and so on, e.g. \"50,30,20\". Defaults to about the top fifth of the field.")
+	rootCmd.AddCommand(tournamentCmd)
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/internal/tutorial"
+	"github.com/philipjkim/pls7-cli/internal/util"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// tutorialCmd walks a new player through the PLS7 rules with guided hands.
+var tutorialCmd = &cobra.Command{
+	Use:   "tutorial",
+	Short: "Learn PLS7 with an interactive tutorial",
+	Long: `Walks through a series of guided hands that introduce reading the display,
+skip straights, the 7-or-better low, counterfeited lows, and pot-limit betting. Each lesson pauses
+to explain a concept and then asks a quiz question about the hand on screen.`,
+	RunE: runTutorial,
+}
+
+func runTutorial(_ *cobra.Command, _ []string) error {
+	util.InitLogger(false)
+
+	rules, err := config.LoadGameRulesFromOptions("pls7")
+	if err != nil {
+		return fmt.Errorf("failed to load PLS7 rules: %w", err)
+	}
+	lessons, err := tutorial.Lessons(rules)
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	correct := 0
+	for i, lesson := range lessons {
+		cli.DisplayGameState(lesson.Game)
+		fmt.Printf("\n=== Lesson %d/%d: %s ===\n", i+1, len(lessons), lesson.Title)
+		for _, line := range lesson.Explanation {
+			fmt.Println(line)
+		}
+
+		quiz := lesson.Quiz
+		fmt.Printf("\nQUIZ: %s\n", quiz.Question)
+		for j, choice := range quiz.Choices {
+			fmt.Printf("  %d) %s\n", j+1, choice)
+		}
+		choice := promptForChoice(reader, len(quiz.Choices))
+		if quiz.IsCorrect(choice) {
+			correct++
+			fmt.Println("Correct!")
+		} else {
+			fmt.Printf("Not quite. The answer is %s.\n", quiz.Choices[quiz.Answer])
+		}
+		fmt.Println(quiz.Explanation)
+
+		fmt.Print("\nPress ENTER to continue > ")
+		_, _ = reader.ReadString('\n')
+	}
+
+	fmt.Printf("\nYou answered %d of %d questions correctly.\n", correct, len(lessons))
+	fmt.Println("You're ready to play! Start a game with: pls7 --rule pls7")
+	return nil
+}
+
+// promptForChoice reads a 1-based choice until a valid one is entered, and
+// returns it as a zero-based index.
+func promptForChoice(reader *bufio.Reader, numChoices int) int {
+	for {
+		fmt.Printf("Your answer (1-%d) > ", numChoices)
+		input, _ := reader.ReadString('\n')
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err == nil && choice >= 1 && choice <= numChoices {
+			return choice - 1
+		}
+		fmt.Println("Invalid choice. Please try again.")
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(tutorialCmd)
+}
@@ -0,0 +1,88 @@
+// Command embed shows how a program outside this repository plays poker with
+// the engine, importing only the public packages of the module:
+//
+//	go get github.com/philipjkim/pls7-cli@latest
+//
+// It loads the rules of a variant, seats a bot of its own against the CPU
+// opponents, and plays a few hands, printing what happens. Run it from the
+// root of the repository, where the rules are:
+//
+//	go run ./examples/embed -rule nlh -hands 5
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/validate"
+	"log"
+	"math/rand"
+)
+
+func main() {
+	rule := flag.String("rule", "nlh", "the variant's rules file in rules/, without .yml")
+	hands := flag.Int("hands", 5, "the number of hands to play")
+	seed := flag.Int64("seed", 1, "the seed for the cards and the CPU decisions")
+	flag.Parse()
+
+	rules, err := config.LoadGameRulesFromFile(fmt.Sprintf("rules/%s.yml", *rule))
+	if err != nil {
+		log.Fatalf("Failed to load the rules: %v", err)
+	}
+	// The player named "YOU" is the seat the embedding program plays; the
+	// others are CPUs.
+	names := []string{"YOU", "CPU 1", "CPU 2", "CPU 3"}
+	g := engine.NewGame(names, 10000, 50, 100, engine.DifficultyMedium, rules, false, false, 0)
+	g.Rand = rand.New(rand.NewSource(*seed))
+
+	for i := 0; i < *hands && g.CountRemainingPlayers() > 1; i++ {
+		playHand(g)
+	}
+	for _, s := range g.Standings() {
+		fmt.Printf("%d. %s: %d\n", s.Place, s.PlayerName, s.Chips)
+	}
+}
+
+// playHand plays a hand from the deal to the cleanup, the way the CLI does.
+func playHand(g *engine.Game) {
+	g.StartNewHand()
+	fmt.Printf("--- Hand #%d: you hold %v ---\n", g.HandCount, g.Players[0].Hand)
+	for g.Phase != engine.PhaseShowdown && g.CountNonFoldedPlayers() > 1 {
+		g.PrepareNewBettingRound()
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+			if player.Status == engine.PlayerStatusPlaying {
+				action := botAction(g, player)
+				if player.IsCPU {
+					action = g.GetCPUAction(player, g.Rand)
+				}
+				g.ProcessAction(player, action)
+				fmt.Printf("%s: %s\n", player.Name, player.LastActionDesc)
+			}
+			g.AdvanceTurn()
+		}
+		g.Advance()
+	}
+
+	var results []engine.DistributionResult
+	if g.CountNonFoldedPlayers() > 1 {
+		results = g.DistributePot()
+	} else {
+		results = g.AwardPotToLastPlayer()
+	}
+	for _, r := range results {
+		fmt.Printf("%s wins %d (%s)\n", r.PlayerName, r.AmountWon, r.HandDesc)
+	}
+	g.CleanupHand()
+}
+
+// botAction is the embedding program's own strategy, here the simplest one:
+// check when it can, and call otherwise. The engine's validator tells which
+// moves the spot allows.
+func botAction(g *engine.Game, player *engine.Player) engine.PlayerAction {
+	if g.ActionSpot(player).Allows(validate.Check) == nil {
+		return engine.PlayerAction{Type: engine.ActionCheck}
+	}
+	return engine.PlayerAction{Type: engine.ActionCall}
+}
@@ -0,0 +1,327 @@
+//go:build js && wasm
+
+// Command wasm is a minimal JavaScript bridge to the poker engine, so that it
+// can power a browser demo without a server. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o examples/wasm/pls7.wasm ./examples/wasm
+//
+// It registers a global pls7 object whose functions take the variant's rules
+// as YAML text, e.g. fetched from rules/pls7.yml, and return JSON strings:
+//
+//	pls7.evaluateHand(rulesYAML, "As Ah Ad", "Ks Kh 2c 3d 4s")
+//	pls7.newHand(rulesYAML, seed)
+//	pls7.act("raise", 400, seq)
+//
+// newHand deals a single hand against CPU opponents and plays it until it is
+// the human's turn; act applies the human's action and plays on the same way.
+// The seq passed to act is the one of the state the action was decided on: an
+// action sent twice, or on a state that has moved on, is not applied, and the
+// current state is returned instead with the reason it was rejected.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"strings"
+	"syscall/js"
+)
+
+// startingChips and the blinds below are the stakes of every demo hand.
+const (
+	startingChips = 10000
+	smallBlind    = 100
+	bigBlind      = 200
+)
+
+// hand is the single hand being played, or nil before the first newHand.
+var hand *handLoop
+
+func main() {
+	js.Global().Set("pls7", js.ValueOf(map[string]interface{}{
+		"evaluateHand": js.FuncOf(bridge(evaluateHand)),
+		"newHand":      js.FuncOf(bridge(newHand)),
+		"act":          js.FuncOf(bridge(act)),
+	}))
+	// Keep the Go program running so that the functions stay callable.
+	select {}
+}
+
+// bridge adapts a function to JavaScript. Its result is returned as JSON, and
+// errors and panics as {"error": "..."}, so a bad call never stops the program.
+func bridge(f func(args []js.Value) (interface{}, error)) func(js.Value, []js.Value) interface{} {
+	return func(_ js.Value, args []js.Value) (result interface{}) {
+		defer func() {
+			if r := recover(); r != nil {
+				result = errorJSON(fmt.Errorf("%v", r))
+			}
+		}()
+		v, err := f(args)
+		if err != nil {
+			return errorJSON(err)
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return errorJSON(err)
+		}
+		return string(data)
+	}
+}
+
+// errorJSON encodes an error for JavaScript.
+func errorJSON(err error) string {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return string(data)
+}
+
+// loadRules parses and validates the rules passed as the first argument.
+func loadRules(args []js.Value) (*poker.GameRules, error) {
+	if len(args) < 1 {
+		return nil, fmt.Errorf("missing rules")
+	}
+	rules, err := config.LoadGameRulesFromBytes([]byte(args[0].String()))
+	if err != nil {
+		return nil, err
+	}
+	return rules, rules.Validate()
+}
+
+// evaluateHand evaluates hole cards on a board: evaluateHand(rules, hole, board).
+func evaluateHand(args []js.Value) (interface{}, error) {
+	rules, err := loadRules(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) < 3 {
+		return nil, fmt.Errorf("usage: evaluateHand(rules, holeCards, board)")
+	}
+	hole, err := poker.ParseCards(args[1].String())
+	if err != nil {
+		return nil, err
+	}
+	board, err := poker.ParseCards(args[2].String())
+	if err != nil {
+		return nil, err
+	}
+	high, low := poker.EvaluateHand(hole, board, rules)
+	result := map[string]string{"high": high.String()}
+	if low != nil {
+		result["low"] = low.String()
+	}
+	return result, nil
+}
+
+// newHand deals a new hand: newHand(rules, seed). The seed makes the cards
+// and the CPU decisions repeatable.
+func newHand(args []js.Value) (interface{}, error) {
+	rules, err := loadRules(args)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{"YOU", "CPU 1", "CPU 2", "CPU 3"}
+	g := engine.NewGame(names, startingChips, smallBlind, bigBlind, engine.DifficultyMedium, rules, false, false, 0)
+	if len(args) > 1 && args[1].Type() == js.TypeNumber {
+		g.Rand = rand.New(rand.NewSource(int64(args[1].Int())))
+	}
+	g.StartNewHand()
+	hand = &handLoop{g: g}
+	hand.run()
+	return hand.state(), nil
+}
+
+// act applies the human's action: act(type, amount, seq), where type is one
+// of fold, check, call, bet or raise, amount is the bet size or the total a
+// raise is made to, and seq is the state's seq. Without seq, the action is
+// taken on the current state.
+func act(args []js.Value) (interface{}, error) {
+	if hand == nil {
+		return nil, fmt.Errorf("no hand is waiting for an action")
+	}
+	if len(args) < 1 {
+		return nil, fmt.Errorf("usage: act(type, amount, seq)")
+	}
+	amount := 0
+	if len(args) > 1 && args[1].Type() == js.TypeNumber {
+		amount = args[1].Int()
+	}
+	seq := hand.g.ActionSeq
+	if len(args) > 2 && args[2].Type() == js.TypeNumber {
+		seq = args[2].Int()
+	}
+	if hand.over || seq != hand.g.ActionSeq {
+		// The action is rejected whatever it is, so it is not checked
+		// against a state it was not decided on.
+		return hand.submit(seq, engine.PlayerAction{}), nil
+	}
+	action, err := hand.humanAction(args[0].String(), amount)
+	if err != nil {
+		return nil, err
+	}
+	return hand.submit(seq, action), nil
+}
+
+// handLoop plays a single hand the way the CLI does, but stops whenever the
+// human must act, since the browser cannot block waiting for input.
+type handLoop struct {
+	g *engine.Game
+	// roundOpen is true once the current street's betting round has started.
+	roundOpen bool
+	over      bool
+	log       []string
+	results   []engine.DistributionResult
+}
+
+// run plays on until it is the human's turn or the hand is over.
+func (h *handLoop) run() {
+	g := h.g
+	for !h.over {
+		if !h.roundOpen {
+			if g.Phase == engine.PhaseShowdown || g.CountNonFoldedPlayers() <= 1 {
+				h.finish()
+				return
+			}
+			g.PrepareNewBettingRound()
+			h.roundOpen = true
+		}
+		if g.IsBettingRoundOver() {
+			g.Advance()
+			h.roundOpen = false
+			continue
+		}
+		player := g.CurrentPlayer()
+		if player.Status != engine.PlayerStatusPlaying {
+			g.AdvanceTurn()
+			continue
+		}
+		if !player.IsCPU {
+			return
+		}
+		h.apply(player, g.GetCPUAction(player, g.Rand))
+	}
+}
+
+// apply processes a player's action and passes the turn.
+func (h *handLoop) apply(player *engine.Player, action engine.PlayerAction) {
+	_, event := h.g.ProcessAction(player, action)
+	h.passTurn(player, event)
+}
+
+// submit submits the human's action and plays on. An action out of turn or
+// stale is not applied; the current state is returned with the reason, so
+// that the page can redraw the table before the human acts again.
+func (h *handLoop) submit(seq int, action engine.PlayerAction) handState {
+	player := h.g.Players[0]
+	event, err := h.g.SubmitAction(player.Name, seq, action)
+	if err != nil {
+		s := h.state()
+		s.Rejected = err.Error()
+		return s
+	}
+	h.passTurn(player, event)
+	h.run()
+	return h.state()
+}
+
+// passTurn logs a player's action and passes the turn.
+func (h *handLoop) passTurn(player *engine.Player, event *engine.ActionEvent) {
+	h.log = append(h.log, fmt.Sprintf("%s: %s", event.PlayerName, player.LastActionDesc))
+	h.g.AdvanceTurn()
+}
+
+// finish awards the pot and cleans up the hand.
+func (h *handLoop) finish() {
+	g := h.g
+	if g.CountNonFoldedPlayers() > 1 {
+		h.results = g.DistributePot()
+	} else {
+		h.results = g.AwardPotToLastPlayer()
+	}
+	for _, r := range h.results {
+		h.log = append(h.log, fmt.Sprintf("%s wins %d with %s", r.PlayerName, r.AmountWon, r.HandDesc))
+	}
+	g.CleanupHand()
+	h.over = true
+}
+
+// humanAction turns the name of the human's action into the action. Whether
+// it is legal is checked when it is submitted.
+func (h *handLoop) humanAction(name string, amount int) (engine.PlayerAction, error) {
+	g := h.g
+	player := g.CurrentPlayer()
+	facingBet := g.BetToCall > player.CurrentBet
+	switch strings.ToLower(name) {
+	case "fold":
+		return engine.PlayerAction{Type: engine.ActionFold}, nil
+	case "check":
+		return engine.PlayerAction{Type: engine.ActionCheck}, nil
+	case "call":
+		return engine.PlayerAction{Type: engine.ActionCall}, nil
+	case "bet", "raise":
+		// The page has one button for both; the action is a raise when
+		// facing a bet.
+		if facingBet {
+			return engine.PlayerAction{Type: engine.ActionRaise, Amount: amount}, nil
+		}
+		return engine.PlayerAction{Type: engine.ActionBet, Amount: amount}, nil
+	}
+	return engine.PlayerAction{}, fmt.Errorf("unknown action %q", name)
+}
+
+// handState is the JSON view of the hand returned to JavaScript. Config
+// describes the variant, so that the page can draw any rules it is given.
+type handState struct {
+	Config   engine.TableConfig `json:"config"`
+	Phase    string             `json:"phase"`
+	Pot      int                `json:"pot"`
+	Board    []string           `json:"board"`
+	Players  []playerState      `json:"players"`
+	ToCall   int                `json:"toCall"`
+	MinRaise int                `json:"minRaise"`
+	MaxRaise int                `json:"maxRaise"`
+	Log      []string           `json:"log"`
+	Over     bool               `json:"over"`
+	// Seq is passed back with the human's next action.
+	Seq int `json:"seq"`
+	// Rejected says why the last action was not applied.
+	Rejected string `json:"rejected,omitempty"`
+}
+
+// playerState is one player's part of handState. Hole cards are only shown
+// for the human, or for everyone once the hand is over.
+type playerState struct {
+	Name   string   `json:"name"`
+	Chips  int      `json:"chips"`
+	Bet    int      `json:"bet"`
+	Status string   `json:"status"`
+	Cards  []string `json:"cards,omitempty"`
+}
+
+// state describes the hand as it stands.
+func (h *handLoop) state() handState {
+	g := h.g
+	s := handState{Config: g.TableConfig(), Phase: g.Phase.String(), Pot: g.Pot, Board: cardNames(g.CommunityCards), Log: h.log, Over: h.over, Seq: g.ActionSeq}
+	for _, p := range g.Players {
+		ps := playerState{Name: p.Name, Chips: p.Chips, Bet: p.CurrentBet, Status: p.Status.String()}
+		if !p.IsCPU || (h.over && p.Status != engine.PlayerStatusFolded) {
+			ps.Cards = cardNames(p.Hand)
+		}
+		s.Players = append(s.Players, ps)
+	}
+	if !h.over {
+		s.ToCall = g.BetToCall - g.CurrentPlayer().CurrentBet
+		s.MinRaise, s.MaxRaise = g.CalculateBettingLimits()
+	}
+	return s
+}
+
+// cardNames returns the cards' names without the padding added by Card.String.
+func cardNames(cards []poker.Card) []string {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = strings.TrimSpace(c.String())
+	}
+	return names
+}
@@ -1,4 +1,4 @@
-module pls7-cli
+module github.com/philipjkim/pls7-cli
 
 go 1.23
 
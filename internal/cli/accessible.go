@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Accessible is whether the output is meant for a screen reader: the table is
+// announced in full sentences instead of drawn, and cards are named in words
+// instead of suit emojis.
+var Accessible = false
+
+// Verbosity is how much of the table is announced in accessible mode.
+type Verbosity int
+
+// Verbosity constants, from the least said to the most.
+const (
+	// VerbosityBrief announces the hand, the board, the pot and your cards.
+	VerbosityBrief Verbosity = iota
+	// VerbosityNormal also announces the other players still in the hand.
+	VerbosityNormal
+	// VerbosityFull also announces the blinds, the dealer, and every
+	// player's bet, including the players who have folded.
+	VerbosityFull
+)
+
+// verbosityNames are the names of the verbosity levels, as used on the
+// command line.
+var verbosityNames = []string{"brief", "normal", "full"}
+
+// String returns the verbosity's name, e.g. "brief".
+func (v Verbosity) String() string {
+	return verbosityNames[v]
+}
+
+// ParseVerbosity returns the verbosity with the given name.
+func ParseVerbosity(name string) (Verbosity, error) {
+	for i, n := range verbosityNames {
+		if n == name {
+			return Verbosity(i), nil
+		}
+	}
+	return VerbosityNormal, fmt.Errorf("unknown verbosity %q (available: %v)", name, verbosityNames)
+}
+
+// VerbosityNames returns the names of the verbosity levels.
+func VerbosityNames() []string {
+	return append([]string(nil), verbosityNames...)
+}
+
+// AccessibleVerbosity is how much of the table is announced in accessible
+// mode.
+var AccessibleVerbosity = VerbosityNormal
+
+// rankWords and suitWords name the ranks and suits of the cards in words.
+var (
+	rankWords = map[poker.Rank]string{
+		poker.Two: "two", poker.Three: "three", poker.Four: "four", poker.Five: "five",
+		poker.Six: "six", poker.Seven: "seven", poker.Eight: "eight", poker.Nine: "nine",
+		poker.Ten: "ten", poker.Jack: "jack", poker.Queen: "queen", poker.King: "king", poker.Ace: "ace",
+	}
+	suitWords = []string{"spades", "hearts", "diamonds", "clubs"}
+)
+
+// spokenCard names a card in words, e.g. "ace of spades".
+func spokenCard(c poker.Card) string {
+	return rankWords[c.Rank] + " of " + suitWords[c.Suit]
+}
+
+// spokenCards names cards in words, e.g. "ace of spades, king of hearts and
+// two of clubs".
+func spokenCards(cards []poker.Card) string {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = spokenCard(c)
+	}
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// cardSpeaker replaces every card, as printed by poker.Card.String, with its
+// name in words, as RedactCards does with "??".
+var cardSpeaker = func() *strings.Replacer {
+	var oldNew []string
+	for suit := poker.Spade; suit <= poker.Club; suit++ {
+		for rank := poker.Two; rank <= poker.Ace; rank++ {
+			c := poker.Card{Suit: suit, Rank: rank}
+			oldNew = append(oldNew, strings.TrimSpace(c.String()), spokenCard(c)+",")
+		}
+	}
+	return strings.NewReplacer(oldNew...)
+}()
+
+// decorations are the characters that draw rules and banners around messages.
+const decorations = "-*= "
+
+// Speak rewrites a message for a screen reader in accessible mode: cards are
+// named in words, and the rules framing headings such as "--- SHOWDOWN ---"
+// are dropped. Outside accessible mode, the message is returned unchanged.
+func Speak(message string) string {
+	if !Accessible {
+		return message
+	}
+	lines := strings.Split(message, "\n")
+	for i, line := range lines {
+		lines[i] = speakLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// speakLine is Speak for a single line.
+func speakLine(line string) string {
+	spoken := strings.Join(strings.Fields(cardSpeaker.Replace(line)), " ")
+	// A card ends up followed by a comma, which also stands in for the dashes
+	// between the cards of a hand; the last card of a list needs none.
+	spoken = strings.NewReplacer(",]", "]", ", ]", "]", ",)", ")", ", -", ", ", ", |", " |").Replace(spoken)
+	spoken = strings.TrimSuffix(spoken, ",")
+	return strings.Trim(spoken, decorations)
+}
+
+// numberWords name the numbers below twenty, and tensWords the tens.
+var (
+	numberWords = []string{
+		"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten",
+		"eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen",
+	}
+	tensWords = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// SpokenNumber writes a number in words, as a screen reader says it, e.g.
+// "twelve thousand five hundred" for 12,500.
+func SpokenNumber(n int) string {
+	if n < 0 {
+		return "minus " + SpokenNumber(-n)
+	}
+	if n < 1000 {
+		return spokenHundreds(n)
+	}
+	scales := []struct {
+		size int
+		name string
+	}{{1_000_000_000, "billion"}, {1_000_000, "million"}, {1_000, "thousand"}}
+	var words []string
+	for _, scale := range scales {
+		if n >= scale.size {
+			words = append(words, SpokenNumber(n/scale.size)+" "+scale.name)
+			n %= scale.size
+		}
+	}
+	if n > 0 {
+		words = append(words, spokenHundreds(n))
+	}
+	return strings.Join(words, " ")
+}
+
+// spokenHundreds writes a number below a thousand in words.
+func spokenHundreds(n int) string {
+	var words []string
+	if n >= 100 {
+		words = append(words, numberWords[n/100]+" hundred")
+		n %= 100
+		if n == 0 {
+			return words[0]
+		}
+	}
+	switch {
+	case n < 20:
+		words = append(words, numberWords[n])
+	case n%10 == 0:
+		words = append(words, tensWords[n/10])
+	default:
+		words = append(words, tensWords[n/10]+"-"+numberWords[n%10])
+	}
+	return strings.Join(words, " ")
+}
+
+// describeGameState announces the table in sentences, as much of it as
+// AccessibleVerbosity asks for.
+func describeGameState(g *engine.Game) []string {
+	board := "No cards are on the board."
+	if len(g.CommunityCards) > 0 {
+		board = fmt.Sprintf("The board is %s.", spokenCards(g.CommunityCards))
+	}
+	lines := []string{
+		fmt.Sprintf("Hand %d, %s. %s The pot is %s.", g.HandCount, strings.ToLower(g.Phase.String()), board, SpokenNumber(g.Pot)),
+	}
+	if noLowNote(g) != "" {
+		lines = append(lines, "No low is possible.")
+	}
+	if AccessibleVerbosity == VerbosityFull {
+		lines = append(lines, fmt.Sprintf("The blinds are %s and %s.", SpokenNumber(g.SmallBlind), SpokenNumber(g.BigBlind)))
+		if ante := g.PostedAnte(); ante > 0 {
+			lines = append(lines, fmt.Sprintf("The ante is %s.", SpokenNumber(ante)))
+		}
+		if status := g.ClockStatus(); status != nil {
+			lines = append(lines, FormatClockStatus(*status))
+		}
+	}
+	for i, p := range g.Players {
+		if p.Status == engine.PlayerStatusEliminated {
+			continue
+		}
+		if p.IsCPU && AccessibleVerbosity == VerbosityBrief {
+			continue
+		}
+		if p.Status == engine.PlayerStatusFolded && AccessibleVerbosity != VerbosityFull {
+			continue
+		}
+		lines = append(lines, describePlayer(g, i, p))
+	}
+	return lines
+}
+
+// describePlayer announces one player at the table: their chips, their bet,
+// their last action, and their hand if it may be seen.
+func describePlayer(g *engine.Game, i int, p *engine.Player) string {
+	subject, have, are, their := p.Name, "has", "is", "Their"
+	if !p.IsCPU {
+		subject, have, are, their = "You", "have", "are", "Your"
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s %s chips", subject, have, SpokenNumber(p.Chips))
+	if AccessibleVerbosity == VerbosityFull {
+		if i == g.DealerPos {
+			sb.WriteString(" and the button")
+		}
+		if p.CurrentBet > 0 {
+			fmt.Fprintf(&sb, ", with %s bet", SpokenNumber(p.CurrentBet))
+		}
+	}
+	sb.WriteString(".")
+	switch {
+	case p.Status == engine.PlayerStatusFolded:
+		fmt.Fprintf(&sb, " %s folded.", subject)
+	case p.Status == engine.PlayerStatusAllIn:
+		fmt.Fprintf(&sb, " %s %s all in.", subject, are)
+	case p.LastActionDesc != "" && i != g.CurrentTurnPos:
+		fmt.Fprintf(&sb, " Last action: %s.", strings.TrimSuffix(Speak(p.LastActionDesc), "."))
+	}
+	if !p.IsCPU && p.Status == engine.PlayerStatusPlaying && len(p.Hand) > 0 {
+		fmt.Fprintf(&sb, " Your stack is %s.", g.StackDepth(p))
+	}
+
+	if len(p.Hand) == 0 || p.HandHidden || (p.IsCPU && (!g.DevMode || g.DevPrivacy)) {
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, " %s cards are %s.", their, spokenCards(p.Hand))
+	if g.Phase > engine.PhasePreFlop {
+		evaluation, err := g.EvaluatePlayerHand(p)
+		if err != nil {
+			logrus.Warnf("Failed to evaluate hand for %s: %v", p.Name, err)
+			return sb.String()
+		}
+		if evaluation.High != nil {
+			fmt.Fprintf(&sb, " High: %s.", Speak(evaluation.High.String()))
+		}
+		if g.Rules.LowHand.Enabled && evaluation.Low != nil {
+			fmt.Fprintf(&sb, " Low: %s.", formatLowHand(evaluation.Low))
+		}
+	}
+	return sb.String()
+}
+
+// AnnounceTurn tells the player it is their turn, with the pot and what they
+// need to call, e.g. "It is your turn. The pot is twelve thousand. You need
+// three thousand to call."
+func AnnounceTurn(g *engine.Game, player *engine.Player) string {
+	announcement := fmt.Sprintf("It is your turn. The pot is %s.", SpokenNumber(g.Pot))
+	amountToCall := g.BetToCall - player.CurrentBet
+	switch {
+	case amountToCall <= 0:
+		announcement += " You can check."
+	case amountToCall >= player.Chips:
+		announcement += fmt.Sprintf(" Calling puts you all in for %s.", SpokenNumber(player.Chips))
+	default:
+		announcement += fmt.Sprintf(" You need %s to call.", SpokenNumber(amountToCall))
+	}
+	if AccessibleVerbosity != VerbosityBrief {
+		announcement += fmt.Sprintf(" You have %s chips.", SpokenNumber(player.Chips))
+	}
+	return announcement
+}
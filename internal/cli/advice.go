@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"strings"
+)
+
+// FormatAdvice renders the advice on a hand for "pls7 advise": the hand made,
+// the outs, the equity, and what that equity is worth facing a bet of bet into
+// a pot of pot, the bet not counted. Without a pot, the part about calling is
+// left out; without a bet, only the largest call worth making is given.
+func FormatAdvice(advice *poker.Advice, pot, bet int) []string {
+	var lines []string
+	switch {
+	case advice.High != nil:
+		lines = append(lines, fmt.Sprintf("Best hand: %s", advice.High))
+	case advice.Outs == nil && advice.Low == nil:
+		lines = append(lines, "Best hand: none yet, the board is still to come")
+	default:
+		lines = append(lines, "Best hand: none")
+	}
+	if advice.Low != nil {
+		lines = append(lines, fmt.Sprintf("Low hand: %s", formatLowHand(advice.Low)))
+	}
+
+	if advice.Outs != nil {
+		if len(advice.Outs.AllOuts) == 0 {
+			lines = append(lines, "Outs: none")
+		} else {
+			lines = append(lines, "Outs:")
+			lines = append(lines, strings.Split(strings.TrimRight(formatOuts(advice.Outs), "\n"), "\n")...)
+		}
+	}
+
+	opponents := "1 random hand"
+	if advice.Opponents != 1 {
+		opponents = fmt.Sprintf("%d random hands", advice.Opponents)
+	}
+	lines = append(lines, fmt.Sprintf("Equity: %.1f%% against %s", advice.Equity*100, opponents))
+
+	if pot <= 0 {
+		return lines
+	}
+	if maxCall := advice.MaxProfitableCall(pot); maxCall < 0 {
+		lines = append(lines, fmt.Sprintf("Into a pot of %s, any bet is worth calling.", FormatNumber(pot)))
+	} else {
+		lines = append(lines, fmt.Sprintf("Into a pot of %s, calling is profitable up to a bet of %s.", FormatNumber(pot), FormatNumber(maxCall)))
+	}
+	if bet > 0 {
+		needed := poker.BreakEvenEquity(pot, bet)
+		verdict := "Call"
+		if advice.Equity < needed {
+			verdict = "Fold"
+		}
+		lines = append(lines, fmt.Sprintf(
+			"Facing %s: you need %.1f%% equity to call, and have %.1f%%. %s.",
+			FormatNumber(bet), needed*100, advice.Equity*100, verdict,
+		))
+	}
+	return lines
+}
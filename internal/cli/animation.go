@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"strings"
+)
+
+// cardBack is a face-down card.
+const cardBack = "[##]"
+
+// cardEdge is a card seen edge-on, half-way through being turned over.
+const cardEdge = " || "
+
+// slideWidth is how many columns a dealt card slides in from, and slideStep how
+// many it moves each frame.
+const (
+	slideWidth = 12
+	slideStep  = 3
+)
+
+// DealFrames returns the frames of the player's hole cards being dealt: each
+// card slides in face down, then, unless hidden, the cards are turned over one
+// by one. Every frame is one line, meant to be drawn over the last.
+func DealFrames(cards []poker.Card, hidden bool) []string {
+	return cardFrames("Dealt:", nil, cards, !hidden)
+}
+
+// BoardFrames returns the frames of the board cards from index dealt onward
+// sliding in face down next to the board already out, and then turning over.
+func BoardFrames(board []poker.Card, dealt int) []string {
+	if dealt >= len(board) {
+		return nil
+	}
+	return cardFrames("Board:", board[:dealt], board[dealt:], true)
+}
+
+// cardFrames animates the dealt cards arriving after the cards already shown.
+func cardFrames(label string, shown, dealt []poker.Card, turnOver bool) []string {
+	faces := make([]string, 0, len(shown)+len(dealt))
+	for _, c := range shown {
+		faces = append(faces, strings.TrimSpace(c.String()))
+	}
+	frame := func(extra ...string) string {
+		return strings.TrimRight(label+" "+strings.Join(append(append([]string(nil), faces...), extra...), " "), " ")
+	}
+
+	var frames []string
+	for range dealt {
+		for offset := slideWidth; offset > 0; offset -= slideStep {
+			frames = append(frames, frame(strings.Repeat(" ", offset)+cardBack))
+		}
+		faces = append(faces, cardBack)
+		frames = append(frames, frame())
+	}
+	if !turnOver {
+		return frames
+	}
+	for i, c := range dealt {
+		pos := len(shown) + i
+		faces[pos] = cardEdge
+		frames = append(frames, frame())
+		faces[pos] = strings.TrimSpace(c.String())
+		frames = append(frames, frame())
+	}
+	return frames
+}
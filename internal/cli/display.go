@@ -2,16 +2,26 @@ package cli
 
 import (
 	"fmt"
-	"pls7-cli/pkg/engine"
-	"pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
 // DisplayGameState prints the current state of the game board and players.
 func DisplayGameState(g *engine.Game) {
+	if Accessible {
+		// The screen is not cleared, so a screen reader can go back over
+		// what was said.
+		for _, line := range describeGameState(g) {
+			fmt.Println(line)
+		}
+		return
+	}
 	if !g.DevMode {
 		clearScreen()
 	}
@@ -19,18 +29,23 @@ func DisplayGameState(g *engine.Game) {
 	var output string // Concat all output here and print at once not to be mixed with other logs
 
 	phaseName := strings.ToUpper(g.Phase.String())
-	output += fmt.Sprintf("\n\n--- %s (%s) | HAND #%d | PHASE: %s | POT: %s | BLINDS: %s/%s ---\n",
+	output += fmt.Sprintf("\n\n--- %s (%s) | HAND #%d | PHASE: %s | POT: %s | BLINDS: %s ---\n",
 		g.Rules.Abbreviation, g.Difficulty, g.HandCount, phaseName,
-		FormatNumber(g.Pot), FormatNumber(g.SmallBlind), FormatNumber(g.BigBlind),
+		FormatNumber(g.Pot), FormatBlinds(g.SmallBlind, g.BigBlind, g.PostedAnte(), g.AnteFormat),
 	)
 
+	if status := g.ClockStatus(); status != nil {
+		output += FormatClockStatus(*status) + "\n"
+	}
+
 	var communityCardStrings []string
 	for _, c := range g.CommunityCards {
 		communityCardStrings = append(communityCardStrings, c.String())
 	}
-	output += fmt.Sprintf("Board: %s\n\n", strings.Join(communityCardStrings, " "))
+	output += fmt.Sprintf("Board: %s%s\n\n", strings.Join(communityCardStrings, " "), noLowNote(g))
 
 	totalChips := g.Pot
+	var outsPanel string // The outs and equity panel, when it is held back by g.OutsDelay.
 	output += fmt.Sprintln("Players:")
 	for i, p := range g.Players {
 		// --- NEW: Skip eliminated players from the display ---
@@ -56,7 +71,10 @@ func DisplayGameState(g *engine.Game) {
 		}
 
 		handInfo := ""
-		if !p.IsCPU || g.DevMode {
+		var evaluation *poker.StreetEvaluation
+		if p.HandHidden {
+			handInfo = "| Hand: [hidden]"
+		} else if !p.IsCPU || (g.DevMode && !g.DevPrivacy) {
 			var handStrings []string
 			for _, c := range p.Hand {
 				handStrings = append(handStrings, c.String())
@@ -64,14 +82,22 @@ func DisplayGameState(g *engine.Game) {
 			handInfo = fmt.Sprintf("| Hand: %s", strings.Join(handStrings, " "))
 
 			if g.Phase > engine.PhasePreFlop {
-				highRank, lowRank := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
-				rankInfo := fmt.Sprintf(" | High: %s", highRank.String())
-				if g.Rules.LowHand.Enabled && lowRank != nil {
-					rankInfo += fmt.Sprintf(", Low: %s", lowRank.String())
+				var err error
+				evaluation, err = g.EvaluatePlayerHand(p)
+				if err != nil {
+					logrus.Warnf("Failed to evaluate hand for %s: %v", p.Name, err)
+				} else if evaluation.High != nil {
+					rankInfo := fmt.Sprintf(" | High: %s", evaluation.High.String())
+					if g.Rules.LowHand.Enabled && evaluation.Low != nil {
+						rankInfo += fmt.Sprintf(", Low: %s", evaluation.Low.String())
+					}
+					handInfo += rankInfo
 				}
-				handInfo += rankInfo
 			}
 		}
+		if !p.IsCPU && p.Status == engine.PlayerStatusPlaying && len(p.Hand) > 0 {
+			handInfo += formatStackDepth(g, p)
+		}
 
 		actionInfo := ""
 		if p.Status != engine.PlayerStatusEliminated {
@@ -92,20 +118,27 @@ func DisplayGameState(g *engine.Game) {
 		line := fmt.Sprintf("% -30s: Chips: %-9s%s %s %s", nameInfo, FormatNumber(p.Chips), actionInfo, status, handInfo)
 		output += fmt.Sprintln(strings.TrimSpace(line))
 
+		if g.ShowsHUD {
+			output += fmt.Sprintf("    HUD: %s\n", formatHUD(g.PreFlopStats[p.Name]))
+		}
+
 		// Display outs for the player in dev mode
-		if g.CanShowOuts(p) {
-			hasOuts, outsInfo := poker.CalculateOuts(p.Hand, g.CommunityCards, g.Rules)
-			if hasOuts {
+		if g.CanShowOuts(p) && evaluation != nil && evaluation.Outs != nil {
+			outsInfo := evaluation.Outs
+			if len(outsInfo.AllOuts) > 0 {
 				sort.Slice(outsInfo.AllOuts, func(i, j int) bool {
 					if outsInfo.AllOuts[i].Suit != outsInfo.AllOuts[j].Suit {
 						return outsInfo.AllOuts[i].Suit < outsInfo.AllOuts[j].Suit
 					}
 					return outsInfo.AllOuts[i].Rank < outsInfo.AllOuts[j].Rank
 				})
-				output += formatOuts(outsInfo)
-
 				amountToCall := g.BetToCall - p.CurrentBet
-				output += formatEquities(g.Pot, amountToCall, len(outsInfo.AllOuts), g.Phase)
+				panel := formatOuts(outsInfo) + formatEquities(g.Pot, amountToCall, len(outsInfo.AllOuts), g.Phase)
+				if g.OutsDelay > 0 {
+					outsPanel = panel
+				} else {
+					output += panel
+				}
 			}
 		}
 
@@ -130,38 +163,211 @@ func DisplayGameState(g *engine.Game) {
 
 	output += fmt.Sprintln("-------------------------------------------------")
 	fmt.Print(output)
+
+	if outsPanel != "" {
+		time.Sleep(g.OutsDelay)
+		fmt.Print(outsPanel)
+	}
 }
 
-// formatOuts formats the outs cards for display.
-func formatOuts(outsInfo *poker.OutsInfo) string {
-	result := "\tAll Outs: "
-	outStrings := make([]string, 0, len(outsInfo.AllOuts))
-	for _, c := range outsInfo.AllOuts {
-		outStrings = append(outStrings, c.String())
+// formatHUD summarizes a player's pre-flop lines as counts over opportunities,
+// e.g. "CC 2/5 (40%) | SQZ -/0 | LRR 1/1 (100%) | ...". LMP counts limps, CMP
+// small blind completions, and OPT big blind option checks.
+func formatHUD(stats *engine.PreFlopStats) string {
+	if stats == nil {
+		stats = &engine.PreFlopStats{}
+	}
+	stat := func(label string, n, opportunities int, freq float64) string {
+		if opportunities == 0 {
+			return label + " -/0"
+		}
+		return fmt.Sprintf("%s %d/%d (%.0f%%)", label, n, opportunities, freq*100)
+	}
+	return strings.Join([]string{
+		stat("CC", stats.ColdCalls, stats.ColdCallOpportunities, stats.ColdCallFrequency()),
+		stat("SQZ", stats.Squeezes, stats.SqueezeOpportunities, stats.SqueezeFrequency()),
+		stat("LRR", stats.LimpReraises, stats.LimpReraiseOpportunities, stats.LimpReraiseFrequency()),
+		stat("LMP", stats.Limps, stats.LimpOpportunities, stats.LimpFrequency()),
+		stat("CMP", stats.Completes, stats.CompleteOpportunities, stats.CompleteFrequency()),
+		stat("OPT", stats.OptionChecks, stats.OptionOpportunities, stats.OptionCheckFrequency()),
+	}, " | ")
+}
+
+// FormatBlinds renders blinds with the ante, if any, e.g. "500/1,000 (ante 100)",
+// or "500/1,000 (BB ante 600)" when the big blind antes for the table.
+func FormatBlinds(smallBlind, bigBlind, ante int, format engine.AnteFormat) string {
+	blinds := fmt.Sprintf("%s/%s", FormatNumber(smallBlind), FormatNumber(bigBlind))
+	if ante > 0 {
+		blinds += fmt.Sprintf(" (%s %s)", anteLabels[format], FormatNumber(ante))
 	}
-	result += strings.Join(outStrings, ", ")
+	return blinds
+}
+
+// FormatTableConfig describes the game played at a table in one line, e.g.
+// "PLS7 (Pot-Limit Sampyeong 7-or-Better): pot limit, 3 hole cards, high-low
+// with 7-or-better lows | Level 2: 500/1,000, ante 100 (everyone)".
+func FormatTableConfig(config engine.TableConfig) string {
+	game := fmt.Sprintf("%s (%s): %s, %d hole cards", config.Variant, config.RulesName,
+		strings.ReplaceAll(config.BettingLimit, "_", " "), config.HoleCards)
+	if config.LowHand {
+		game += fmt.Sprintf(", high-low with %d-or-better lows", config.LowMaxRank)
+	}
+	blinds := fmt.Sprintf("Level %d: %s/%s", config.BlindLevel, FormatNumber(config.SmallBlind), FormatNumber(config.BigBlind))
+	if config.Ante > 0 {
+		blinds += fmt.Sprintf(", ante %s (%s)", FormatNumber(config.Ante), config.AnteFormat)
+	}
+	return game + " | " + blinds
+}
+
+// anteLabels names the ante in each ante format.
+var anteLabels = map[engine.AnteFormat]string{
+	engine.AnteEveryone: "ante",
+	engine.AnteBigBlind: "BB ante",
+	engine.AnteButton:   "button ante",
+}
+
+// FormatTableEvent describes a table event in the lines printed as it
+// happens. The deal, the new streets and the pot paid out are shown with the
+// table and the showdown instead, and get none.
+func FormatTableEvent(e engine.TableEvent) []string {
+	switch e := e.(type) {
+	case *engine.BlindEvent:
+		return FormatBlindEvent(e)
+	case *engine.ActionEvent:
+		if msg := FormatActionEvent(e); msg != "" {
+			return []string{msg}
+		}
+	case *engine.HandEndEvent:
+		lines := []string{"\n--- End of Hand ---"}
+		for _, v := range e.ChipViolations {
+			lines = append(lines, fmt.Sprintf("!!! Table stakes violated: %s", v))
+		}
+		return lines
+	case *engine.EliminationEvent:
+		return []string{fmt.Sprintf("%s has been eliminated!", e.PlayerName)}
+	case *engine.GameWonEvent:
+		return []string{fmt.Sprintf("%s wins the game!", e.PlayerName)}
+	}
+	return nil
+}
+
+// FormatActionEvent describes a player's action in a single line, or returns an
+// empty string for actions that are not announced.
+func FormatActionEvent(event *engine.ActionEvent) string {
+	switch event.Action {
+	case engine.ActionFold:
+		return fmt.Sprintf("%s folds.", event.PlayerName)
+	case engine.ActionCheck, engine.ActionCall:
+		switch event.Detail {
+		case engine.ActionDetailOption:
+			return fmt.Sprintf("%s checks their option.", event.PlayerName)
+		case engine.ActionDetailComplete:
+			return fmt.Sprintf("%s completes %s.", event.PlayerName, FormatNumber(event.Amount))
+		case engine.ActionDetailLimp:
+			return fmt.Sprintf("%s limps %s.", event.PlayerName, FormatNumber(event.Amount))
+		}
+		if event.Action == engine.ActionCheck {
+			return fmt.Sprintf("%s checks.", event.PlayerName)
+		}
+		return fmt.Sprintf("%s calls %s.", event.PlayerName, FormatNumber(event.Amount))
+	case engine.ActionBet:
+		return fmt.Sprintf("%s bets %s.", event.PlayerName, FormatNumber(event.Amount))
+	case engine.ActionRaise:
+		return fmt.Sprintf("%s raises to %s.", event.PlayerName, FormatNumber(event.Amount))
+	case engine.ActionShowPartial:
+		return fmt.Sprintf("%s shows %s.", event.PlayerName, strings.TrimSpace(fmt.Sprint(event.Cards)))
+	}
+	return ""
+}
+
+// FormatBlindEvent announces a new blind level, followed by the result of any
+// chip race held at the level change.
+func FormatBlindEvent(event *engine.BlindEvent) []string {
+	lines := []string{fmt.Sprintf("\n*** Blinds are now %s ***", FormatBlinds(event.SmallBlind, event.BigBlind, event.Ante, event.AnteFormat))}
+	if len(event.ChipRace) > 0 {
+		lines = append(lines, fmt.Sprintf("*** Chip race: chips below %s are colored up ***", FormatNumber(event.Denomination)))
+		for _, result := range event.ChipRace {
+			lines = append(lines, fmt.Sprintf("  %-8s %s -> %s", result.PlayerName, FormatNumber(result.ChipsBefore), FormatNumber(result.ChipsAfter)))
+		}
+	}
+	return append(lines, "")
+}
+
+// FormatClockStatus renders a casino-style tournament clock line, e.g.
+// "LEVEL 3 | 12:34 LEFT | NEXT BLINDS: 2,000/4,000 | AVG STACK: 300,000 | PLAYERS: 6".
+// Levels counted in hands show the hands left instead, e.g. "4 HANDS LEFT".
+func FormatClockStatus(status engine.ClockStatus) string {
+	remaining := status.TimeRemaining.Round(time.Second)
+	minutes := int(remaining / time.Minute)
+	seconds := int((remaining % time.Minute) / time.Second)
+	left := fmt.Sprintf("%02d:%02d LEFT", minutes, seconds)
+	if status.HandsRemaining > 0 {
+		left = fmt.Sprintf("%d HANDS LEFT", status.HandsRemaining)
+	}
+	return fmt.Sprintf(
+		"LEVEL %d | %s | NEXT BLINDS: %s | AVG STACK: %s | PLAYERS: %d",
+		status.Level, left,
+		FormatBlinds(status.NextSmallBlind, status.NextBigBlind, status.NextAnte, status.AnteFormat),
+		FormatNumber(status.AverageStack), status.PlayersRemaining,
+	)
+}
+
+// formatOuts formats the outs for display: the cards, and how many there are
+// and how likely they are to come, in total and for each hand rank from the
+// best down, e.g.
+//
+//	Flush: 9 outs, 19.6% on the turn, 35.7% by the river, 38.9% for a flush or better
+func formatOuts(outsInfo *poker.OutsInfo) string {
+	result := fmt.Sprintf("\tAll Outs (%s): %s", formatOutsOdds(outsInfo.Improvement, outsInfo.CardsToCome), formatCards(outsInfo.AllOuts))
 
 	if outsInfo.OutsPerHandRank != nil {
 		result += "\n\tOuts by Hand Rank:\n"
-		for rank, outs := range outsInfo.OutsPerHandRank {
+		ranks := make([]poker.HandRank, 0, len(outsInfo.OutsPerHandRank))
+		for rank := range outsInfo.OutsPerHandRank {
+			ranks = append(ranks, rank)
+		}
+		sort.Slice(ranks, func(i, j int) bool { return ranks[i] > ranks[j] })
+		for _, rank := range ranks {
+			outs := outsInfo.OutsPerHandRank[rank]
 			if len(outs) == 0 {
 				continue
 			}
+			odds := outsInfo.OddsPerHandRank[rank]
 			possibleHandRankStr := rank.String()
-			if rank.String() == poker.HighCard.String() {
+			summary := formatOutsOdds(odds, outsInfo.CardsToCome)
+			if rank == poker.HighCard {
 				possibleHandRankStr = possibleHandRankStr + " (for low hand)"
+			} else if odds.Cumulative > odds.ByRiver {
+				summary += fmt.Sprintf(", %.1f%% for %s or better", odds.Cumulative*100, strings.ToLower(rank.String()))
 			}
-			result += fmt.Sprintf("\t\t%s: ", possibleHandRankStr)
-			outRankStrings := make([]string, 0, len(outs))
-			for _, c := range outs {
-				outRankStrings = append(outRankStrings, c.String())
-			}
-			result += strings.Join(outRankStrings, ", ") + "\n"
+			result += fmt.Sprintf("\t\t%s: %s\n\t\t\t%s\n", possibleHandRankStr, summary, formatCards(outs))
 		}
 	}
 	return result
 }
 
+// formatOutsOdds describes the number of outs and the chance of hitting one,
+// e.g. "9 outs, 19.6% on the turn, 35.7% by the river".
+func formatOutsOdds(odds poker.OutsOdds, cardsToCome int) string {
+	unit := "outs"
+	if odds.Count == 1 {
+		unit = "out"
+	}
+	if cardsToCome < 2 {
+		return fmt.Sprintf("%d %s, %.1f%% on the river", odds.Count, unit, odds.NextCard*100)
+	}
+	return fmt.Sprintf("%d %s, %.1f%% on the turn, %.1f%% by the river", odds.Count, unit, odds.NextCard*100, odds.ByRiver*100)
+}
+
+// formatCards lists cards separated by commas.
+func formatCards(cards []poker.Card) string {
+	cardStrings := make([]string, 0, len(cards))
+	for _, c := range cards {
+		cardStrings = append(cardStrings, c.String())
+	}
+	return strings.Join(cardStrings, ", ")
+}
+
 func formatEquities(pot, amountToCall, numOuts int, phase engine.GamePhase) string {
 	numCommunityCards := 0
 	if phase == engine.PhaseFlop {
@@ -179,26 +385,61 @@ func formatEquities(pot, amountToCall, numOuts int, phase engine.GamePhase) stri
 	)
 }
 
+// ClearsScreen is whether the console is cleared before the table is shown.
+// It is turned off when the output is read by another program.
+var ClearsScreen = true
+
 // clearScreen clears the console. (Note: This is a simple implementation)
 func clearScreen() {
+	if !ClearsScreen {
+		return
+	}
 	fmt.Print("\033[H\033[2J")
 }
 
-func FormatShowdownResults(g *engine.Game) []string {
+// FormatShowdownResults shows the hands at showdown, the mucked ones as
+// mucked, and how the pot was distributed.
+func FormatShowdownResults(g *engine.Game, distributionResults []engine.DistributionResult) []string {
 	var outputLines []string
 	outputLines = append(outputLines, "\n--- SHOWDOWN ---")
-	outputLines = append(outputLines, fmt.Sprintf("Community Cards: %s", g.CommunityCards))
+	outputLines = append(outputLines, fmt.Sprintf("Community Cards: %s%s", g.CommunityCards, noLowNote(g)))
 
-	distributionResults := g.DistributePot()
+	if len(g.Runs) == 0 {
+		outputLines = append(outputLines, formatShowdownHands(g, g.CommunityCards, distributionResults)...)
+	}
+	for i, run := range g.Runs {
+		outputLines = append(outputLines, fmt.Sprintf("\nRun %d of %d: %s%s", i+1, len(g.Runs), run.Board, boardNoLowNote(g, run.Board)))
+		outputLines = append(outputLines, formatShowdownHands(g, run.Board, run.Results)...)
+	}
 
-	winnerMap := make(map[string][]string)
+	outputLines = append(outputLines, "\n--- POT DISTRIBUTION ---")
+	if g.Uncalled != nil {
+		outputLines = append(outputLines, FormatUncalledBet(g.Uncalled))
+	}
 	for _, result := range distributionResults {
-		winType := ""
-		if strings.HasPrefix(result.HandDesc, "High") || strings.HasPrefix(result.HandDesc, "takes") {
-			winType = "High Winner"
-		} else if strings.HasPrefix(result.HandDesc, "Scoop") {
+		outputLines = append(outputLines, fmt.Sprintf(
+			"%s wins %s chips with %s",
+			result.PlayerName, FormatNumber(result.AmountWon), result.HandDesc,
+		))
+	}
+	outputLines = append(outputLines, "------------------------")
+	return outputLines
+}
+
+// formatShowdownHands lists the hands shown down on a board, marking the
+// winners of the given results.
+func formatShowdownHands(g *engine.Game, board []poker.Card, results []engine.DistributionResult) []string {
+	var outputLines []string
+	winnerMap := make(map[string][]string)
+	for _, result := range results {
+		// A player may win the high half of one pot tier and the low half of
+		// another, as well as both halves of one.
+		wonHigh := strings.Contains(result.HandDesc, "High:") || strings.HasPrefix(result.HandDesc, "takes")
+		wonLow := strings.Contains(result.HandDesc, "Low:")
+		winType := "High Winner"
+		if wonHigh && wonLow {
 			winType = "High/Low Winner"
-		} else {
+		} else if wonLow {
 			winType = "Low Winner"
 		}
 		winnerMap[result.PlayerName] = append(winnerMap[result.PlayerName], winType)
@@ -208,18 +449,15 @@ func FormatShowdownResults(g *engine.Game) []string {
 		if player.Status == engine.PlayerStatusFolded || player.Status == engine.PlayerStatusEliminated {
 			continue
 		}
-		highHand, lowHand := poker.EvaluateHand(player.Hand, g.CommunityCards, g.Rules)
+		if player.Mucked {
+			outputLines = append(outputLines, fmt.Sprintf("- %-7s: mucks", player.Name))
+			continue
+		}
+		highHand, lowHand := poker.EvaluateHand(player.Hand, board, g.Rules)
 
 		handDesc := highHand.String()
 		if g.Rules.LowHand.Enabled && lowHand != nil {
-			var lowHandRanks []string
-			for _, c := range lowHand.Cards {
-				lowHandRanks = append(lowHandRanks, c.Rank.String())
-			}
-			if len(lowHandRanks) > 0 && lowHandRanks[0] == "A" {
-				lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
-			}
-			handDesc += fmt.Sprintf(" | Low: %s-High", strings.Join(lowHandRanks, "-"))
+			handDesc += " | Low: " + formatLowHand(lowHand)
 		}
 
 		winnerStatus := ""
@@ -228,15 +466,325 @@ func FormatShowdownResults(g *engine.Game) []string {
 		}
 
 		outputLines = append(outputLines, fmt.Sprintf("- %-7s: %v -> %s%s", player.Name, player.Hand, handDesc, winnerStatus))
+		outputLines = append(outputLines, formatCardsUsed("High", highHand))
+		if g.Rules.LowHand.Enabled && lowHand != nil {
+			outputLines = append(outputLines, formatCardsUsed("Low", lowHand))
+		}
 	}
+	return outputLines
+}
 
-	outputLines = append(outputLines, "\n--- POT DISTRIBUTION ---")
-	for _, result := range distributionResults {
-		outputLines = append(outputLines, fmt.Sprintf(
-			"%s wins %s chips with %s",
-			result.PlayerName, FormatNumber(result.AmountWon), result.HandDesc,
-		))
+// noLowNote returns a note to append to the board in Hi-Lo games once the
+// board can no longer make a qualifying low, or "" otherwise.
+func noLowNote(g *engine.Game) string {
+	return boardNoLowNote(g, g.CommunityCards)
+}
+
+// boardNoLowNote is noLowNote for a given board, such as one of the runs of an
+// all-in pot.
+func boardNoLowNote(g *engine.Game, board []poker.Card) string {
+	if !g.Rules.LowHand.Enabled || len(board) == 0 || poker.LowPossible(board, g.Rules) {
+		return ""
 	}
-	outputLines = append(outputLines, "------------------------")
-	return outputLines
+	return " (no low possible)"
+}
+
+// formatLowHand describes a low hand by its ranks, e.g. "7-5-4-2-A-High".
+func formatLowHand(lowHand *poker.HandResult) string {
+	var lowHandRanks []string
+	for _, c := range lowHand.Cards {
+		lowHandRanks = append(lowHandRanks, c.Rank.String())
+	}
+	if len(lowHandRanks) > 0 && lowHandRanks[0] == "A" {
+		lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
+	}
+	return fmt.Sprintf("%s-High", strings.Join(lowHandRanks, "-"))
+}
+
+// formatCardsUsed describes which hole cards and board cards make up a hand, so
+// players can see exactly how their best five cards were formed.
+func formatCardsUsed(label string, hand *poker.HandResult) string {
+	if hand == nil {
+		return fmt.Sprintf("    %s uses: N/A", label)
+	}
+	return fmt.Sprintf(
+		"    %s uses: hole [%s] + board [%s]",
+		label, formatCardList(hand.HoleCards), formatCardList(hand.BoardCards),
+	)
+}
+
+// formatCardList joins cards with single spaces, trimming the padding added by Card.String.
+func formatCardList(cards []poker.Card) string {
+	cardStrings := make([]string, 0, len(cards))
+	for _, c := range cards {
+		cardStrings = append(cardStrings, strings.TrimSpace(c.String()))
+	}
+	return strings.Join(cardStrings, " ")
+}
+
+// FormatVariantAnnouncement describes a dealer's choice variant before its
+// first hand is dealt, so players know which rules are in play.
+func FormatVariantAnnouncement(rules *poker.GameRules) []string {
+	holeCards := fmt.Sprintf("%d hole cards, use any", rules.HoleCards.Count)
+	switch rules.HoleCards.UseConstraint {
+	case "exact":
+		holeCards = fmt.Sprintf("%d hole cards, use exactly %d", rules.HoleCards.Count, rules.HoleCards.UseCount)
+	case "max":
+		holeCards = fmt.Sprintf("%d hole cards, use up to %d", rules.HoleCards.Count, rules.HoleCards.UseCount)
+	}
+	lowHand := "off"
+	if rules.LowHand.Enabled {
+		lowHand = fmt.Sprintf("%d-or-better", rules.LowHand.MaxRank)
+	}
+	skipStraights := "off"
+	if len(rules.HandRankings.CustomRankings) > 0 {
+		skipStraights = "on"
+	}
+	betting := "Pot Limit"
+	switch rules.BettingLimit {
+	case "no_limit":
+		betting = "No Limit"
+	case "fixed_limit":
+		betting = "Fixed Limit"
+	}
+	return []string{
+		fmt.Sprintf("\n*** Dealer's Choice: %s ***", rules.Name),
+		fmt.Sprintf("    %s | Hi-Lo: %s | Skip straights: %s | %s\n", holeCards, lowHand, skipStraights, betting),
+	}
+}
+
+// FormatAllInEquity shows each player's equity in every pot tier of a
+// multi-way all-in, e.g. "Main pot (4,500): YOU 45.2% | CPU 1 30.1% | CPU 2 24.7%".
+func FormatAllInEquity(equity *engine.AllInEquity) []string {
+	lines := []string{"\n--- ALL-IN EQUITY ---"}
+	for _, tier := range equity.Tiers {
+		shares := make([]string, len(tier.Players))
+		for i, name := range tier.Players {
+			shares[i] = fmt.Sprintf("%s %.1f%%", name, tier.Equity[i]*100)
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s): %s", tier.Name(), FormatNumber(tier.Amount), strings.Join(shares, " | ")))
+	}
+	if equity.Exact {
+		lines = append(lines, fmt.Sprintf("(exact, over all %s runouts)", FormatNumber(equity.Runouts)))
+	} else {
+		lines = append(lines, fmt.Sprintf("(estimated from %s random runouts)", FormatNumber(equity.Runouts)))
+	}
+	return lines
+}
+
+// FormatInsuranceTaken announces insurance taken in an all-in pot, e.g.
+// "CPU 2 insures 100% of 40,000 chips at 72.5% equity for a premium of 11,000."
+func FormatInsuranceTaken(policy *engine.InsurancePolicy) string {
+	return fmt.Sprintf(
+		"%s insures %.0f%% of %s chips at %.1f%% equity for a premium of %s.",
+		policy.PlayerName, policy.Coverage*100, FormatNumber(policy.Stake), policy.Equity*100, FormatNumber(policy.Premium),
+	)
+}
+
+// FormatRunItTimes names how many times the board is run, e.g. "twice".
+func FormatRunItTimes(times int) string {
+	switch times {
+	case 1:
+		return "once"
+	case 2:
+		return "twice"
+	}
+	return fmt.Sprintf("%d times", times)
+}
+
+// FormatBoardRunout announces a street dealt in an all-in runout, e.g.
+// "Turn: Ah Kd 7c 2s". The first street dealt also turns the hands of the
+// players still in face up.
+func FormatBoardRunout(e engine.GameEvent, first bool) []string {
+	var lines []string
+	if first {
+		lines = append(lines, "\n--- ALL-IN RUNOUT ---")
+		for i, name := range e.Players {
+			lines = append(lines, fmt.Sprintf("%s shows %s", name, formatCardList(e.Hands[i])))
+		}
+	}
+	return append(lines, fmt.Sprintf("%s: %s", e.Phase, formatCardList(e.Board)))
+}
+
+// FormatInsuranceSettlement describes how the hand's insurance was settled at
+// the showdown.
+func FormatInsuranceSettlement(policy *engine.InsurancePolicy) string {
+	if policy.Payout == 0 {
+		return fmt.Sprintf("Insurance: %s's premium of %s goes to the insurance pool.", policy.PlayerName, FormatNumber(policy.Premium))
+	}
+	return fmt.Sprintf(
+		"Insurance: the insurance pool pays %s %s (net %s).",
+		policy.PlayerName, FormatNumber(policy.Payout), formatSigned(policy.Net()),
+	)
+}
+
+// FormatUncalledBet describes the bet returned before the pot was
+// distributed, e.g. "Uncalled bet of 5,000 returned to CPU 2".
+func FormatUncalledBet(uncalled *engine.UncalledBet) string {
+	return fmt.Sprintf("Uncalled bet of %s returned to %s", FormatNumber(uncalled.Amount), uncalled.PlayerName)
+}
+
+// FormatBlindDefense advises on defending a blind against a steal in one
+// line: what to do, and why, from the equity against the steal range and the
+// expected values of calling and 3-betting.
+func FormatBlindDefense(d *engine.BlindDefense) string {
+	against := fmt.Sprintf("%s's steal range (%.0f%% of hands)", d.Stealer, d.StealRange*100)
+	threeBet := ""
+	if d.ThreeBetTo > 0 {
+		threeBet = fmt.Sprintf(", a 3-bet to %s %s", FormatNumber(d.ThreeBetTo), formatSigned(int(math.Round(d.ThreeBetEV))))
+	}
+	switch d.Advice {
+	case engine.ActionRaise:
+		return fmt.Sprintf(
+			"3-bet to %s: %s folds %.0f%% of %s to it; the 3-bet earns %s chips, a call %s.",
+			FormatNumber(d.ThreeBetTo), d.Stealer, d.FoldToThreeBet*100, against,
+			formatSigned(int(math.Round(d.ThreeBetEV))), formatSigned(int(math.Round(d.CallEV))),
+		)
+	case engine.ActionCall:
+		return fmt.Sprintf(
+			"Call: your %.1f%% equity against %s beats the %.1f%% the call needs; calling earns %s chips%s.",
+			d.Equity*100, against, d.BreakEven*100, formatSigned(int(math.Round(d.CallEV))), threeBet,
+		)
+	}
+	return fmt.Sprintf(
+		"Fold: your %.1f%% equity against %s is short of the %.1f%% the call needs, and a 3-bet does not pay either.",
+		d.Equity*100, against, d.BreakEven*100,
+	)
+}
+
+// FormatReverseOuts tells how likely the player's made hand is already
+// beaten, e.g. "Reverse outs: a 7.6% chance one of your 3 opponents already
+// beats your One Pair."
+func FormatReverseOuts(ro *engine.ReverseOuts) string {
+	who := "your opponent"
+	if ro.Opponents > 1 {
+		who = fmt.Sprintf("one of your %d opponents", ro.Opponents)
+	}
+	return fmt.Sprintf("Reverse outs: a %.1f%% chance %s already beats your %s.", ro.Behind*100, who, ro.Hand.Rank)
+}
+
+// formatStackDepth tags the player's situation in the hand, e.g.
+// " | Stack: short (SPR 2.4)".
+func formatStackDepth(g *engine.Game, p *engine.Player) string {
+	return fmt.Sprintf(" | Stack: %s (SPR %.1f)", g.StackDepth(p), g.SPR(p))
+}
+
+// FormatCommitmentWarning warns that an action commits the player to the pot,
+// e.g. "Warning: calling 4,000 commits you to the pot: 3,000 behind in a pot
+// of 12,000."
+func FormatCommitmentWarning(action string, c engine.Commitment) string {
+	return fmt.Sprintf("Warning: %s commits you to the pot: %s behind in a pot of %s.",
+		action, FormatNumber(c.Behind), FormatNumber(c.Pot))
+}
+
+// FormatTimeCharge announces a time charge collection, e.g. "Time charge: 500
+// from each of YOU, CPU 1, CPU 2 (1,500 in all)."
+func FormatTimeCharge(payments []engine.TimeChargePayment) string {
+	if len(payments) == 0 {
+		return "Time charge: no stack covers the charge this period."
+	}
+	names := make([]string, len(payments))
+	total := 0
+	for i, p := range payments {
+		names[i] = p.PlayerName
+		total += p.Amount
+	}
+	return fmt.Sprintf(
+		"Time charge: %s from each of %s (%s in all).",
+		FormatNumber(payments[0].Amount), strings.Join(names, ", "), FormatNumber(total),
+	)
+}
+
+// FormatTimeChargeSummary lists the time charge every player paid during the
+// session, in seat order, and the total taken by the house.
+func FormatTimeChargeSummary(g *engine.Game) []string {
+	if len(g.TimeCharged) == 0 {
+		return nil
+	}
+	lines := []string{"Time charges paid:"}
+	for _, p := range g.Players {
+		if amount := g.TimeCharged[p.Name]; amount > 0 {
+			lines = append(lines, fmt.Sprintf("  %-8s %s", p.Name, FormatNumber(amount)))
+		}
+	}
+	return append(lines, fmt.Sprintf("  %-8s %s", "Total", FormatNumber(g.TotalTimeCharged())))
+}
+
+// FormatLineupChange announces a player joining or leaving the table, e.g.
+// "CPU 7 (Loose-Aggressive) sits down with 300,000 chips."
+func FormatLineupChange(c engine.LineupChange) string {
+	if !c.Joined {
+		return fmt.Sprintf("%s leaves the table with %s chips.", c.PlayerName, FormatNumber(c.Chips))
+	}
+	if c.Profile == "" {
+		return fmt.Sprintf("%s sits down with %s chips.", c.PlayerName, FormatNumber(c.Chips))
+	}
+	return fmt.Sprintf("%s (%s) sits down with %s chips.", c.PlayerName, c.Profile, FormatNumber(c.Chips))
+}
+
+// FormatNewLineup announces the CPUs seated from a fast-fold pool, e.g.
+// "New table: CPU 7, CPU 8, CPU 9."
+func FormatNewLineup(changes []engine.LineupChange) string {
+	var names []string
+	for _, c := range changes {
+		if c.Joined {
+			names = append(names, c.PlayerName)
+		}
+	}
+	return fmt.Sprintf("New table: %s.", strings.Join(names, ", "))
+}
+
+// FormatSpeedSummary sums up a speed poker session, e.g. "Speed poker: 84
+// hands in 20m0s, 252 hands per hour."
+func FormatSpeedSummary(hands int, elapsed time.Duration) string {
+	perHour := 0.0
+	if elapsed > 0 {
+		perHour = float64(hands) / elapsed.Hours()
+	}
+	return fmt.Sprintf("Speed poker: %d hands in %s, %.0f hands per hour.", hands, elapsed.Round(time.Second), perHour)
+}
+
+// FormatStandings announces the end of a match played to a hands limit: the
+// players ranked by their stacks, and the chip leader who wins it.
+func FormatStandings(g *engine.Game) []string {
+	lines := []string{fmt.Sprintf("\n--- STANDINGS AFTER %d HANDS ---", g.HandCount)}
+	for _, s := range g.Standings() {
+		lines = append(lines, fmt.Sprintf("%5s  %-8s  %s", engine.Ordinal(s.Place), s.PlayerName, FormatNumber(s.Chips)))
+	}
+	leaders := g.ChipLeaders()
+	if len(leaders) == 1 {
+		return append(lines, fmt.Sprintf("%s wins as the chip leader.", leaders[0]))
+	}
+	return append(lines, fmt.Sprintf("%s tie as the chip leaders.", strings.Join(leaders, " and ")))
+}
+
+// milestoneBannerRule frames a milestone banner.
+var milestoneBannerRule = strings.Repeat("*", 60)
+
+// FormatMilestoneBanner announces a milestone with a banner: its title, e.g.
+// "FIRST SKIP STRAIGHT FLUSH! YOU", the hand, and how rarely it is made.
+func FormatMilestoneBanner(m engine.Milestone) []string {
+	title := strings.ToUpper(m.Kind.String()) + "!"
+	if m.First {
+		title = "FIRST " + title
+	}
+	lines := []string{
+		"",
+		milestoneBannerRule,
+		fmt.Sprintf("  %s %s", title, m.PlayerName),
+		"  " + strings.TrimSpace(m.Hand),
+	}
+	if odds := formatMilestoneOdds(m); odds != "" {
+		lines = append(lines, "  "+odds)
+	}
+	return append(lines, milestoneBannerRule)
+}
+
+// formatMilestoneOdds quotes the exact frequency of a milestone's hand, or
+// returns "" if it is not known.
+func formatMilestoneOdds(m engine.Milestone) string {
+	if m.Frequency <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Made by 1 in every %s five-card hands (%.4f%%)", FormatNumber(int(math.Round(1/m.Frequency))), m.Frequency*100)
 }
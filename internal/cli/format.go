@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 	"strconv"
+	"strings"
 )
 
 // FormatNumber takes an integer and returns a string with commas as thousands separators.
@@ -25,3 +27,21 @@ func FormatNumber(n int) string {
 
 	return result
 }
+
+// cardRedactor replaces every card, as printed by poker.Card.String, with "??".
+var cardRedactor = func() *strings.Replacer {
+	var oldNew []string
+	for suit := poker.Spade; suit <= poker.Club; suit++ {
+		for rank := poker.Two; rank <= poker.Ace; rank++ {
+			oldNew = append(oldNew, poker.Card{Suit: suit, Rank: rank}.String(), "?? ")
+		}
+	}
+	return strings.NewReplacer(oldNew...)
+}()
+
+// RedactCards hides the cards in a log message, e.g. "[?? ?? ?? ]" for a
+// hand. Log messages cannot tell whose cards they print, so every card is
+// hidden.
+func RedactCards(message string) string {
+	return cardRedactor.Replace(message)
+}
@@ -0,0 +1,435 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FormatSharedHand renders a hand history as plain text suitable for posting
+// on forums. Player names are replaced with seat numbers, hole cards that were
+// never revealed are removed, and every amount is given in big blinds.
+func FormatSharedHand(h *engine.HandHistory) string {
+	anon := h.Anonymize()
+	bb := func(amount int) string { return formatBigBlinds(amount, anon.BigBlind) }
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s - Hand #%d - Blinds %s/%s\n", anon.Rule, anon.HandNumber, bb(anon.SmallBlind), bb(anon.BigBlind))
+	for _, seat := range anon.Seats {
+		var tags []string
+		if seat.Name == anon.Dealer {
+			tags = append(tags, "button")
+		}
+		if seat.IsHuman {
+			tags = append(tags, "hero")
+		}
+		line := fmt.Sprintf("%s: %s", seat.Name, bb(seat.StartingChips))
+		if len(tags) > 0 {
+			line += fmt.Sprintf(" (%s)", strings.Join(tags, ", "))
+		}
+		if len(seat.HoleCards) > 0 {
+			line += fmt.Sprintf(" [%s]", formatCardNotation(seat.HoleCards))
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	if anon.Ante > 0 && anon.AntePlayer == "" {
+		for _, seat := range anon.Seats {
+			fmt.Fprintf(&sb, "%s posts ante %s\n", seat.Name, bb(anon.Ante))
+		}
+	}
+	if anon.SmallBlindPlayer != "" {
+		fmt.Fprintf(&sb, "%s posts small blind %s\n", anon.SmallBlindPlayer, bb(anon.SmallBlind))
+	}
+	fmt.Fprintf(&sb, "%s posts big blind %s\n", anon.BigBlindPlayer, bb(anon.BigBlind))
+	if anon.StraddlePlayer != "" {
+		fmt.Fprintf(&sb, "%s posts straddle %s\n", anon.StraddlePlayer, bb(anon.Straddle))
+	}
+	if anon.AntePlayer != "" {
+		fmt.Fprintf(&sb, "%s posts ante %s for the table\n", anon.AntePlayer, bb(anon.Ante))
+	}
+
+	phase := engine.PhasePreFlop
+	fmt.Fprintf(&sb, "*** %s ***\n", strings.ToUpper(phase.String()))
+	for _, action := range anon.Actions {
+		for phase < action.Phase {
+			phase++
+			writeStreetHeader(&sb, phase, anon.Board)
+		}
+		fmt.Fprintf(&sb, "%s %s\n", action.PlayerName, describeRecordedAction(action, bb))
+	}
+	// Deal out the rest of the board, e.g. after an all-in.
+	for phase < engine.PhaseRiver && len(anon.Board) >= phase.Street().BoardSize()+1 {
+		phase++
+		writeStreetHeader(&sb, phase, anon.Board)
+	}
+
+	sb.WriteString("*** RESULT ***\n")
+	if anon.Uncalled != nil {
+		fmt.Fprintf(&sb, "Uncalled bet of %s returned to %s\n", bb(anon.Uncalled.Amount), anon.Uncalled.PlayerName)
+	}
+	for _, result := range anon.Results {
+		fmt.Fprintf(&sb, "%s wins %s with %s\n", result.PlayerName, bb(result.AmountWon), result.HandDesc)
+	}
+	return sb.String()
+}
+
+// FormatBettingRecap recaps the betting line of the hand so far, one line per
+// street, e.g. "Flop: CPU 1 bets 6,000, YOU calls 6,000".
+func FormatBettingRecap(h *engine.HandHistory) []string {
+	lines := []string{"--- BETTING RECAP ---"}
+	var streets [engine.PhaseRiver + 1][]string
+	for _, action := range h.Actions {
+		if action.Phase > engine.PhaseRiver {
+			continue
+		}
+		streets[action.Phase] = append(streets[action.Phase],
+			fmt.Sprintf("%s %s", action.PlayerName, describeRecordedAction(action, FormatNumber)))
+	}
+	for phase, actions := range streets {
+		if len(actions) > 0 {
+			lines = append(lines, fmt.Sprintf("%s: %s", engine.GamePhase(phase), strings.Join(actions, ", ")))
+		}
+	}
+	return lines
+}
+
+// writeStreetHeader writes the header for a street along with the board cards
+// dealt so far.
+func writeStreetHeader(sb *strings.Builder, phase engine.GamePhase, board []poker.Card) {
+	size := phase.Street().BoardSize()
+	if size > len(board) {
+		size = len(board)
+	}
+	fmt.Fprintf(sb, "*** %s *** [%s]\n", strings.ToUpper(phase.String()), formatCardNotation(board[:size]))
+}
+
+// describeRecordedAction describes a recorded action, e.g. "raises to 3 BB".
+func describeRecordedAction(action engine.ActionRecord, bb func(int) string) string {
+	switch action.Detail {
+	case engine.ActionDetailOption:
+		return "checks (option)"
+	case engine.ActionDetailComplete:
+		return "completes " + bb(action.Amount)
+	case engine.ActionDetailLimp:
+		return "limps " + bb(action.Amount)
+	}
+	switch action.Action {
+	case engine.ActionFold:
+		return "folds"
+	case engine.ActionCheck:
+		return "checks"
+	case engine.ActionCall:
+		if action.Amount == 0 {
+			return "checks"
+		}
+		return "calls " + bb(action.Amount)
+	case engine.ActionBet:
+		return "bets " + bb(action.Amount)
+	case engine.ActionRaise:
+		return "raises to " + bb(action.Amount)
+	}
+	return strings.ToLower(action.Action.String())
+}
+
+// formatBigBlinds expresses a chip amount in big blinds, rounded to two decimals.
+func formatBigBlinds(amount, bigBlind int) string {
+	if bigBlind <= 0 {
+		return FormatNumber(amount)
+	}
+	bbs := math.Round(float64(amount)/float64(bigBlind)*100) / 100
+	return strconv.FormatFloat(bbs, 'f', -1, 64) + " BB"
+}
+
+// formatCardNotation joins cards in plain-text notation, e.g. "As Kd 7c".
+func formatCardNotation(cards []poker.Card) string {
+	notations := make([]string, len(cards))
+	for i, c := range cards {
+		notations[i] = c.Notation()
+	}
+	return strings.Join(notations, " ")
+}
+
+// FormatChipAudit renders a hand's chip audit as a table, followed by any
+// discrepancies found, or a line confirming that the hand balances.
+func FormatChipAudit(audit *engine.ChipAudit) []string {
+	lines := []string{
+		"--- CHIP AUDIT ---",
+		fmt.Sprintf("%-8s %12s %12s %12s %12s %12s", "Player", "Start", "Contributed", "Won", "End", "Net"),
+	}
+	for _, row := range audit.Rows {
+		lines = append(lines, fmt.Sprintf(
+			"%-8s %12s %12s %12s %12s %12s",
+			row.PlayerName, FormatNumber(row.StartingStack), FormatNumber(row.Contributed),
+			FormatNumber(row.Won), FormatNumber(row.EndingStack), formatSigned(row.Net()),
+		))
+	}
+	for _, tier := range audit.Tiers {
+		line := fmt.Sprintf("%s: %s of %s awarded", tier.Name(), FormatNumber(tier.Awarded), FormatNumber(tier.Amount))
+		if tier.DeadAntes > 0 {
+			line += fmt.Sprintf(" (%s in dead antes)", FormatNumber(tier.DeadAntes))
+		}
+		lines = append(lines, line)
+	}
+	for _, row := range audit.Rows {
+		if row.Insurance != 0 {
+			lines = append(lines, fmt.Sprintf("Insurance: %s %s from the insurance pool", row.PlayerName, formatSigned(row.Insurance)))
+		}
+	}
+
+	if problems := audit.Discrepancies(); len(problems) > 0 {
+		for _, problem := range problems {
+			lines = append(lines, "MISMATCH: "+problem)
+		}
+	} else {
+		lines = append(lines, "Net total: 0 (balanced)")
+	}
+	lines = append(lines, "------------------------")
+	return lines
+}
+
+// formatSigned formats a chip amount with an explicit sign, e.g. "+1,500".
+func formatSigned(n int) string {
+	if n < 0 {
+		return "-" + FormatNumber(-n)
+	}
+	return "+" + FormatNumber(n)
+}
+
+// FormatSessionSummary renders the end-of-game summary: each player's best
+// high and low hands of the session, followed by the hand of the session with
+// its full board and hole cards.
+func FormatSessionSummary(summary *engine.SessionSummary) []string {
+	hands := fmt.Sprintf("%d hands", summary.Hands)
+	if summary.Hands == 1 {
+		hands = "1 hand"
+	}
+	lines := []string{fmt.Sprintf("\n======== SESSION SUMMARY (%s) ========", hands)}
+	if summary.HandOfTheSession == nil {
+		return append(lines, "No hands were played to the river.")
+	}
+
+	lines = append(lines, "Best hands:")
+	for _, name := range summary.Players {
+		var parts []string
+		if best := summary.BestHigh[name]; best != nil {
+			parts = append(parts, fmt.Sprintf("High: %s (hand #%d)", best.Hand.Rank, best.HandNumber))
+		}
+		if best := summary.BestLow[name]; best != nil {
+			parts = append(parts, fmt.Sprintf("Low: %s (hand #%d)", formatLowHand(best.Hand), best.HandNumber))
+		}
+		lines = append(lines, fmt.Sprintf("  %-8s %s", name, strings.Join(parts, " | ")))
+	}
+
+	top := summary.HandOfTheSession
+	lines = append(lines,
+		"*** HAND OF THE SESSION ***",
+		fmt.Sprintf("Hand #%d (ID: %s): %s made %s", top.HandNumber, top.HandID, top.PlayerName, top.Hand),
+		fmt.Sprintf("  Hole cards: %s", formatCardList(top.HoleCards)),
+		fmt.Sprintf("  Board:      %s", formatCardList(top.Board)),
+		"============================================",
+	)
+	return lines
+}
+
+// FormatSessionStats renders the human player's statistics under a title,
+// e.g. "YOUR SESSION": hands played, VPIP, showdowns won, the biggest pot,
+// and the net result per hand.
+func FormatSessionStats(title string, stats engine.SessionStats) []string {
+	lines := []string{fmt.Sprintf("--- %s ---", title)}
+	if stats.HandsPlayed == 0 {
+		return append(lines, "No hands played yet.")
+	}
+	lines = append(lines,
+		fmt.Sprintf("Hands played: %d | VPIP: %.1f%%", stats.HandsPlayed, stats.VPIP()*100),
+		fmt.Sprintf("Showdowns won: %d of %d (%.1f%%)", stats.ShowdownsWon, stats.Showdowns, stats.ShowdownWinRate()*100),
+		fmt.Sprintf("Biggest pot won: %s", FormatNumber(stats.BiggestPot)),
+		fmt.Sprintf("Net: %s chips (%+.1f per hand)", formatSigned(stats.NetChips), stats.NetPerHand()),
+	)
+	if stats.HandReads > 0 {
+		lines = append(lines, fmt.Sprintf("Hands read right: %d of %d (%.1f%%)",
+			stats.HandReadsRight, stats.HandReads, stats.HandReadAccuracy()*100))
+	}
+	return lines
+}
+
+// FormatHandRead tells the human how their guess at an opponent's hand went,
+// e.g. "CPU 1: you read two pair, and it was two pair. Right!"
+func FormatHandRead(name string, guess, actual engine.HandRead) string {
+	if guess == actual {
+		return fmt.Sprintf("%s: you read %s, and it was %s. Right!", name, guess, actual)
+	}
+	return fmt.Sprintf("%s: you read %s, but it was %s.", name, guess, actual)
+}
+
+// FormatBankroll renders a profile's bankroll: its statistics over all its
+// recorded sessions, and their net result in big blinds.
+func FormatBankroll(profile string, records []engine.SessionRecord) []string {
+	sessions := fmt.Sprintf("%d sessions", len(records))
+	if len(records) == 1 {
+		sessions = "1 session"
+	}
+	lines := FormatSessionStats(fmt.Sprintf("BANKROLL: %s, %s", profile, sessions), engine.Bankroll(records))
+	netBB := 0.0
+	for _, r := range records {
+		netBB += r.NetBB()
+	}
+	return append(lines, fmt.Sprintf("Net over all sessions, rebuys included: %+.1f BB", netBB))
+}
+
+// FormatSessionGoal announces a session goal that has been reached, with the
+// session's net result so far, e.g. "Stop-loss reached: you are -5,000
+// (-50 BB) after 37 hands."
+func FormatSessionGoal(limit engine.SessionLimit, netChips, bigBlind, hands int) string {
+	what := "Stop-win reached"
+	switch limit {
+	case engine.SessionLimitStopLoss:
+		what = "Stop-loss reached"
+	case engine.SessionLimitHands:
+		what = "Session length reached"
+	}
+	bb := formatBigBlinds(netChips, bigBlind)
+	if netChips >= 0 {
+		bb = "+" + bb
+	}
+	return fmt.Sprintf("%s: you are %s (%s) after %d hands.", what, formatSigned(netChips), bb, hands)
+}
+
+// FormatHandReplay replays a saved hand street by street, as a spectator sees
+// it, with every hole card face up. The annotations are announced right after
+// the card that caused them.
+func FormatHandReplay(h *engine.HandHistory, annotations []engine.HandAnnotation) []string {
+	var lines []string
+	for _, step := range FormatHandReplaySteps(h, annotations) {
+		lines = append(lines, step...)
+	}
+	return lines
+}
+
+// FormatHandReplaySteps is FormatHandReplay split into steps, so that the hand
+// can be stepped through: the seats and blinds, each street with its actions,
+// and the result, with the pot's chip audit when one was recorded.
+func FormatHandReplaySteps(h *engine.HandHistory, annotations []engine.HandAnnotation) [][]string {
+	lines := []string{fmt.Sprintf("--- REPLAY: HAND #%d (%s) | BLINDS: %s/%s ---",
+		h.HandNumber, h.Rule, FormatNumber(h.SmallBlind), FormatNumber(h.BigBlind))}
+	if h.Config != nil {
+		lines = append(lines, FormatTableConfig(*h.Config))
+	}
+	for _, seat := range h.Seats {
+		line := fmt.Sprintf("%s: %s [%s]", seat.Name, FormatNumber(seat.StartingChips), formatCardList(seat.HoleCards))
+		if seat.Name == h.Dealer {
+			line += " (button)"
+		}
+		lines = append(lines, line)
+	}
+	if h.SmallBlindPlayer != "" {
+		lines = append(lines, fmt.Sprintf("%s posts small blind %s", h.SmallBlindPlayer, FormatNumber(h.SmallBlind)))
+	}
+	lines = append(lines, fmt.Sprintf("%s posts big blind %s", h.BigBlindPlayer, FormatNumber(h.BigBlind)))
+	if h.StraddlePlayer != "" {
+		lines = append(lines, fmt.Sprintf("%s posts straddle %s", h.StraddlePlayer, FormatNumber(h.Straddle)))
+	}
+	steps := [][]string{lines}
+
+	phase := engine.PhasePreFlop
+	street := []string{fmt.Sprintf("*** %s ***", strings.ToUpper(phase.String()))}
+	deal := func() {
+		steps = append(steps, street)
+		phase++
+		size := min(phase.Street().BoardSize(), len(h.Board))
+		street = []string{fmt.Sprintf("*** %s *** [%s]", strings.ToUpper(phase.String()), formatCardList(h.Board[:size]))}
+		for _, annotation := range annotations {
+			if annotation.Phase == phase {
+				street = append(street, "  >> "+FormatHandAnnotation(annotation))
+			}
+		}
+	}
+	for _, action := range h.Actions {
+		for phase < action.Phase {
+			deal()
+		}
+		street = append(street, fmt.Sprintf("%s %s", action.PlayerName, describeRecordedAction(action, FormatNumber)))
+	}
+	// Deal out the rest of the board, e.g. after an all-in.
+	for phase < engine.PhaseRiver && len(h.Board) >= phase.Street().BoardSize()+1 {
+		deal()
+	}
+	steps = append(steps, street)
+
+	result := []string{"*** RESULT ***"}
+	if h.Uncalled != nil {
+		result = append(result, FormatUncalledBet(h.Uncalled))
+	}
+	for i, run := range h.Runs {
+		result = append(result, fmt.Sprintf("Run %d of %d [%s]", i+1, len(h.Runs), formatCardList(run.Board)))
+		for _, r := range run.Results {
+			result = append(result, fmt.Sprintf("  %s wins %s with %s", r.PlayerName, FormatNumber(r.AmountWon), r.HandDesc))
+		}
+	}
+	for _, r := range h.Results {
+		result = append(result, fmt.Sprintf("%s wins %s with %s", r.PlayerName, FormatNumber(r.AmountWon), r.HandDesc))
+	}
+	if h.Insurance != nil && h.Insurance.Settled {
+		result = append(result, FormatInsuranceSettlement(h.Insurance))
+	}
+	return append(steps, result)
+}
+
+// FormatHandAnnotation describes a turnaround in one line, e.g. "CPU 3's
+// 6-4-3-2-A low was counterfeited by the 3♦. Now tied: CPU 1, CPU 3".
+func FormatHandAnnotation(a engine.HandAnnotation) string {
+	owner := a.PlayerName + "'s"
+	if a.PlayerName == "YOU" {
+		owner = "Your"
+	}
+	hand, leaderHand := a.Hand.Rank.String(), ""
+	if a.LeaderHand != nil {
+		leaderHand = a.LeaderHand.Rank.String()
+	}
+	what := "outdrawn"
+	if a.Kind != engine.AnnotationHighOutdrawn {
+		hand = formatLowRanks(a.Hand) + " low"
+		leaderHand = formatLowRanks(a.LeaderHand)
+		if a.Kind == engine.AnnotationLowCounterfeited {
+			what = "counterfeited"
+		}
+	}
+	now := "Now ahead"
+	if slices.Contains(a.Leaders, a.PlayerName) {
+		now = "Now tied"
+	}
+	return fmt.Sprintf("%s %s was %s by the %s. %s: %s (%s)",
+		owner, hand, what, strings.TrimSpace(a.Card.String()), now, strings.Join(a.Leaders, ", "), leaderHand)
+}
+
+// formatLowRanks lists the ranks of a low hand from the highest, e.g.
+// "6-4-3-2-A".
+func formatLowRanks(low *poker.HandResult) string {
+	ranks := make([]string, len(low.HighValues))
+	for i, rank := range low.HighValues {
+		ranks[i] = rank.String()
+	}
+	return strings.Join(ranks, "-")
+}
+
+// FormatMilestones lists a profile's milestones, oldest first, one per line,
+// e.g. "2025-01-01 12:00  PLS7  Skip Straight Flush (first)  hand 20250101-120000-0001".
+func FormatMilestones(milestones []engine.Milestone) []string {
+	lines := make([]string, 0, len(milestones))
+	for _, m := range milestones {
+		kind := m.Kind.String()
+		if m.First {
+			kind += " (first)"
+		}
+		line := fmt.Sprintf("%s  %-5s %-28s hand %s", m.PlayedAt.Format("2006-01-02 15:04"), m.Rule, kind, m.HandID)
+		if odds := formatMilestoneOdds(m); odds != "" {
+			line += "\n    " + strings.TrimSpace(m.Hand) + " | " + odds
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -1,97 +1,465 @@
 package cli
 
 import (
-	"bufio"
 	"fmt"
-	"os"
-	"pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/validate"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // PromptForAction requests the player to choose an action during their turn.
 func PromptForAction(g *engine.Game) engine.PlayerAction {
+	action, _ := promptForAction(g, "", false)
+	return action
+}
+
+// PromptForActionWithHeader is PromptForAction with a header, such as the
+// coach's advice, printed under the table.
+func PromptForActionWithHeader(g *engine.Game, header string) engine.PlayerAction {
+	action, _ := promptForAction(g, header, false)
+	return action
+}
+
+// PromptForTableAction is the multi-table variant of PromptForAction. The header
+// is printed below the game state to identify the table, and the player may type
+// "t" to switch to another table instead of acting, in which case switched is true.
+func PromptForTableAction(g *engine.Game, header string) (action engine.PlayerAction, switched bool) {
+	return promptForAction(g, header, true)
+}
+
+// promptForAction displays the game state and keeps prompting until a valid
+// action is chosen, or until the player switches tables when allowSwitch is set.
+func promptForAction(g *engine.Game, header string, allowSwitch bool) (engine.PlayerAction, bool) {
 	DisplayGameState(g)
+	if header != "" {
+		fmt.Println(Speak(header))
+	}
+	if Accessible {
+		fmt.Println(AnnounceTurn(g, g.Players[g.CurrentTurnPos]))
+	}
+	if len(g.Macros) > 0 {
+		fmt.Println(formatMacros(g.Macros))
+	}
+	if player := g.Players[g.CurrentTurnPos]; g.BetToCall > player.CurrentBet {
+		if c := g.CommitmentAfter(player, engine.PlayerAction{Type: engine.ActionCall}); c.Commits {
+			fmt.Println(Speak(FormatCommitmentWarning("calling "+FormatNumber(g.BetToCall-player.CurrentBet), c)))
+		}
+	}
+
+	// With a time limit, the human has ActionTimeout for the whole decision,
+	// however many times they are prompted.
+	var deadline time.Time
+	if ActionTimeout > 0 {
+		deadline = time.Now().Add(ActionTimeout)
+	}
 
 	// for loop to keep prompting until a valid action is chosen
 	for {
 		player := g.Players[g.CurrentTurnPos]
-		canCheck := player.CurrentBet == g.BetToCall
+		// The keys offered are the moves the validator allows, so the prompt
+		// accepts exactly what the engine does.
+		spot := g.ActionSpot(player)
+		allows := func(m validate.Move) bool { return spot.Allows(m) == nil }
+		canCheck := allows(validate.Check)
 		amountToCall := g.BetToCall - player.CurrentBet
 
+		quickBets := g.QuickBetOptions(player)
+		if len(quickBets) > 0 {
+			fmt.Println(formatQuickBets(quickBets))
+		}
+
 		var prompt strings.Builder
-		prompt.WriteString("Choose your action: ")
+		prompt.WriteString("Choose your action")
+		if !deadline.IsZero() {
+			prompt.WriteString(fmt.Sprintf(" (%ds left)", int(time.Until(deadline).Round(time.Second).Seconds())))
+		}
+		prompt.WriteString(": ")
+		if allowSwitch {
+			prompt.WriteString("(t)able switch, ")
+		}
+		if g.StreamerMode {
+			if player.HandHidden {
+				prompt.WriteString("(h) Show cards, ")
+			} else {
+				prompt.WriteString("(h)ide cards, ")
+			}
+		}
 
-		if canCheck {
-			prompt.WriteString("chec(k), (b)et, (f)old > ")
+		if canCheck || amountToCall == 0 {
+			switch {
+			case !canCheck:
+				prompt.WriteString("you must bet: ")
+			case g.HasBigBlindOption(player):
+				prompt.WriteString("(k) Check your option, ")
+			default:
+				prompt.WriteString("chec(k), ")
+			}
+			if allows(validate.Bet) {
+				prompt.WriteString("(b)et, ")
+			}
+			prompt.WriteString("(f)old > ")
 		} else {
 			// If amountToCall is negative, it means remaining players have bet all-in with less than the current bet.
 			// So the player does not need to act anything, call.
 			if amountToCall < 0 {
-				return engine.PlayerAction{Type: engine.ActionCall}
+				return engine.PlayerAction{Type: engine.ActionCall}, false
 			}
 
-			prompt.WriteString(fmt.Sprintf("(c)all %s, ", FormatNumber(amountToCall)))
-			// Only show raise option if the player has enough chips to make a valid raise.
-			minRaise, _ := g.CalculateBettingLimits()
-			if player.Chips > amountToCall && player.CurrentBet+player.Chips >= minRaise {
+			if g.CanComplete(player) {
+				prompt.WriteString(fmt.Sprintf("(c) Complete (%s), ", FormatNumber(amountToCall)))
+			} else {
+				prompt.WriteString(fmt.Sprintf("(c)all %s, ", FormatNumber(amountToCall)))
+			}
+			if allows(validate.Raise) {
 				prompt.WriteString("(r)aise, ")
 			}
 			prompt.WriteString("(f)old > ")
 		}
 
 		fmt.Print(prompt.String())
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
+		input, ok := readLineBy(deadline)
+		if !ok {
+			return timeUpAction(canCheck), false
+		}
 		input = strings.TrimSpace(input)
 
+		if g.DevMode && strings.HasPrefix(input, "goto ") {
+			if err := fastForward(g, strings.TrimPrefix(input, "goto ")); err != nil {
+				fmt.Printf("Cannot fast-forward: %v\n", err)
+				continue
+			}
+			return engine.PlayerAction{Type: engine.ActionFastForward}, false
+		}
+
+		// "shown <player>" looks back at the hands a player has shown down.
+		if input == "shown" || strings.HasPrefix(input, "shown ") {
+			for _, line := range FormatShownHands(g, strings.TrimPrefix(input, "shown")) {
+				fmt.Println(line)
+			}
+			continue
+		}
+
+		if input == "stats" {
+			for _, line := range FormatSessionStats("YOUR SESSION", g.HumanStats) {
+				fmt.Println(line)
+			}
+			continue
+		}
+
 		switch input {
 		case "f":
-			return engine.PlayerAction{Type: engine.ActionFold}
+			return engine.PlayerAction{Type: engine.ActionFold}, false
 		case "k":
-			if canCheck {
-				return engine.PlayerAction{Type: engine.ActionCheck}
+			if allows(validate.Check) {
+				return engine.PlayerAction{Type: engine.ActionCheck}, false
 			}
 		case "c":
-			if !canCheck {
-				return engine.PlayerAction{Type: engine.ActionCall}
+			if allows(validate.Call) {
+				return engine.PlayerAction{Type: engine.ActionCall}, false
 			}
 		case "b":
-			if canCheck {
-				return promptForAmount(g, engine.ActionBet)
+			if allows(validate.Bet) {
+				return promptForAmount(g, engine.ActionBet, deadline), false
 			}
 		case "r":
-			if !canCheck {
-				return promptForAmount(g, engine.ActionRaise)
+			if allows(validate.Raise) {
+				return promptForAmount(g, engine.ActionRaise, deadline), false
+			}
+		case "t":
+			if allowSwitch {
+				return engine.PlayerAction{}, true
 			}
+		case "h":
+			if g.StreamerMode {
+				player.HandHidden = !player.HandHidden
+				DisplayGameState(g)
+				if header != "" {
+					fmt.Println(header)
+				}
+				continue
+			}
+		}
+
+		// A number picks one of the quick bets.
+		if n, err := strconv.Atoi(input); err == nil && n >= 1 && n <= len(quickBets) {
+			return quickBets[n-1].Action, false
+		}
+
+		// Otherwise, try a macro or a typed command, e.g. "raise 2.5bb".
+		cmd, ok := g.Macros[input]
+		if !ok {
+			var err error
+			if cmd, err = engine.ParseActionCommand(input); err != nil {
+				fmt.Println("Invalid action.")
+				continue
+			}
+		}
+		action, err := g.ResolveActionCommand(player, cmd)
+		if err != nil {
+			fmt.Printf("Cannot %s: %v\n", cmd.Text, err)
+			continue
 		}
+		return action, false
+	}
+}
+
+// reservedInputs are the keys the action prompt already uses, which macros
+// may not be named after.
+var reservedInputs = []string{"f", "k", "c", "b", "r", "t", "h", "q", "goto", "shown", "stats"}
+
+// ValidateMacroName checks that a macro can be typed at the action prompt as
+// a single word that does not shadow one of its keys or an action.
+func ValidateMacroName(name string) error {
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("macro names must be a single word, got %q", name)
+	}
+	if slices.Contains(reservedInputs, name) {
+		return fmt.Errorf("%q is already a key at the action prompt", name)
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return fmt.Errorf("%q would pick a quick bet at the action prompt", name)
+	}
+	if _, err := engine.ParseActionCommand(name); err == nil {
+		return fmt.Errorf("%q is already an action", name)
+	}
+	return nil
+}
+
+// formatMacros lists the macros by name, e.g. "Macros: m1 = raise 2.5bb".
+func formatMacros(macros map[string]engine.ActionCommand) string {
+	names := make([]string, 0, len(macros))
+	for name := range macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = fmt.Sprintf("%s = %s", name, macros[name].Text)
+	}
+	return "Macros: " + strings.Join(names, ", ")
+}
 
-		fmt.Println("Invalid action.")
+// formatQuickBets lists the quick bets by number with their totals, e.g.
+// "Quick raises: (1) 33% pot 2,400, (2) 50% pot 2,750, (3) pot 3,500".
+func formatQuickBets(options []engine.QuickBetOption) string {
+	kind := "bets"
+	if options[0].Action.Type == engine.ActionRaise {
+		kind = "raises"
 	}
+	items := make([]string, len(options))
+	for i, option := range options {
+		items[i] = fmt.Sprintf("(%d) %s %s", i+1, option.Label, FormatNumber(option.Action.Amount))
+	}
+	return fmt.Sprintf("Quick %s: %s", kind, strings.Join(items, ", "))
+}
+
+// fastForward handles the dev-mode "goto" command, e.g. "goto river As Kd 3c 7h 2s",
+// which jumps the hand to the start of the flop, turn or river with the given
+// board. The board may be given in full or as just the cards still to come.
+func fastForward(g *engine.Game, args string) error {
+	street, cards, _ := strings.Cut(strings.TrimSpace(args), " ")
+	phases := map[string]engine.GamePhase{"flop": engine.PhaseFlop, "turn": engine.PhaseTurn, "river": engine.PhaseRiver}
+	phase, ok := phases[strings.ToLower(street)]
+	if !ok {
+		return fmt.Errorf("unknown street %q (use flop, turn or river)", street)
+	}
+	board, err := poker.ParseCards(cards)
+	if err != nil {
+		return err
+	}
+	return g.FastForward(phase, board)
+}
+
+// timeUpAction is the action taken for the human when their time to act runs
+// out: a check if they can, and a fold otherwise.
+func timeUpAction(canCheck bool) engine.PlayerAction {
+	if canCheck {
+		fmt.Println("\nTime's up: you check.")
+		return engine.PlayerAction{Type: engine.ActionCheck}
+	}
+	fmt.Println("\nTime's up: you fold.")
+	return engine.PlayerAction{Type: engine.ActionFold}
 }
 
-// promptForAmount requests the betting/raising amount.
-func promptForAmount(g *engine.Game, actionType engine.ActionType) engine.PlayerAction {
+// promptForAmount requests the betting/raising amount, by the deadline of the
+// action prompt unless it is zero.
+func promptForAmount(g *engine.Game, actionType engine.ActionType, deadline time.Time) engine.PlayerAction {
+	actionName := "bet"
+	if actionType == engine.ActionRaise {
+		actionName = "raise to"
+	}
+	// A fixed limit allows a single size, so there is nothing to ask.
+	if _, fixed := g.BettingCalculator.(*engine.FixedLimitCalculator); fixed {
+		amount, _ := g.CalculateBettingLimits()
+		fmt.Printf("You %s %s (fixed limit).\n", actionName, FormatNumber(amount))
+		return engine.PlayerAction{Type: actionType, Amount: amount}
+	}
+
+	move := validate.Bet
+	if actionType == engine.ActionRaise {
+		move = validate.Raise
+	}
 	for {
-		minBet, maxBet := g.CalculateBettingLimits()
-		actionName := "bet"
-		if actionType == engine.ActionRaise {
-			actionName = "raise to"
-		}
+		spot := g.ActionSpot(g.CurrentPlayer())
+		minBet, maxBet := spot.MinTotal, spot.MaxTotal
 
 		fmt.Printf(
-			"Enter amount to %s (min: %s, max: %s): ",
-			actionName, FormatNumber(minBet), FormatNumber(maxBet),
+			"Enter amount to %s (min: %s, max: %s, or +N to %s by N): ",
+			actionName, FormatNumber(minBet), FormatNumber(maxBet), strings.Fields(actionName)[0],
 		)
 
-		reader := bufio.NewReader(os.Stdin)
-		input, _ := reader.ReadString('\n')
-		amount, err := strconv.Atoi(strings.TrimSpace(input))
+		input, ok := readLineBy(deadline)
+		if !ok {
+			return timeUpAction(spot.Allows(validate.Check) == nil)
+		}
+		typed, by, err := parseAmountInput(input)
+		if by {
+			typed += g.BetToCall
+		}
+		amount := typed
+		if err == nil {
+			amount = g.RoundBetAmount(typed)
+		}
 
-		if err != nil || amount < minBet || amount > maxBet {
+		if err == nil {
+			err = spot.Validate(move, amount)
+		}
+		if err != nil {
 			fmt.Println("Invalid amount. Please try again.")
 		} else {
-			return engine.PlayerAction{Type: actionType, Amount: amount}
+			if amount != typed {
+				fmt.Printf("Rounded to %s: chips come in units of %s.\n", FormatNumber(amount), FormatNumber(g.ChipUnit()))
+			} else if by {
+				fmt.Printf("You %s %s.\n", actionName, FormatNumber(amount))
+			}
+			action := engine.PlayerAction{Type: actionType, Amount: amount}
+			if c := g.CommitmentAfter(g.CurrentPlayer(), action); c.Commits {
+				gerund := strings.Replace(actionName, "bet", "betting", 1)
+				gerund = strings.Replace(gerund, "raise", "raising", 1)
+				fmt.Println(FormatCommitmentWarning(gerund+" "+FormatNumber(amount), c))
+			}
+			return action
+		}
+	}
+}
+
+// parseAmountInput parses an amount typed at the bet prompt: a total, e.g.
+// "6000" or "to 6000", or an increment over the current bet, e.g. "+4000" or
+// "by 4000", in which case by is true.
+func parseAmountInput(input string) (amount int, by bool, err error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if rest, ok := strings.CutPrefix(input, "to "); ok {
+		input = rest
+	} else if rest, ok := strings.CutPrefix(input, "by "); ok {
+		input, by = rest, true
+	} else if rest, ok := strings.CutPrefix(input, "+"); ok {
+		input, by = rest, true
+	}
+	amount, err = strconv.Atoi(strings.TrimSpace(input))
+	return amount, by, err
+}
+
+// PromptForShowCard asks the player whether to reveal one hole card after the
+// hand is over. It returns ok=false if the player declines by pressing ENTER.
+// A hidden hand stays hidden until the player types "h".
+func PromptForShowCard(player *engine.Player) (action engine.PlayerAction, ok bool) {
+	for {
+		if player.HandHidden {
+			fmt.Printf("Show one card? Your hand is hidden (h to show it). Enter 1-%d, or press ENTER to skip > ", len(player.Hand))
+		} else {
+			fmt.Printf("Show one card? Your hand: %v. Enter 1-%d, or press ENTER to skip > ", player.Hand, len(player.Hand))
+		}
+		input := ReadLine()
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return engine.PlayerAction{}, false
+		}
+		if input == "h" && player.HandHidden {
+			player.HandHidden = false
+			continue
+		}
+
+		index, err := strconv.Atoi(input)
+		if err == nil && index >= 1 && index <= len(player.Hand) {
+			return engine.PlayerAction{Type: engine.ActionShowPartial, CardIndex: index - 1}, true
+		}
+		fmt.Println("Invalid card. Please try again.")
+	}
+}
+
+// PromptForMuck asks the player whether to muck their losing hand at showdown
+// instead of showing it. Anything but "y" shows it.
+func PromptForMuck(player *engine.Player) bool {
+	if player.HandHidden {
+		fmt.Print("You lost. Muck your hand? (y/N) > ")
+	} else {
+		fmt.Printf("You lost with %v. Muck your hand? (y/N) > ", player.Hand)
+	}
+	input := ReadLine()
+	return strings.TrimSpace(strings.ToLower(input)) == "y"
+}
+
+// insuranceCoverages are the fractions of the stake the human may insure.
+var insuranceCoverages = []float64{0.25, 0.5, 1}
+
+// PromptForInsurance offers the human insurance on an all-in pot they are
+// favored to win. It returns the fraction of the stake to insure, or ok=false
+// if the player declines by pressing ENTER.
+func PromptForInsurance(offer *engine.InsuranceOffer) (coverage float64, ok bool) {
+	options := make([]string, len(insuranceCoverages))
+	for i, c := range insuranceCoverages {
+		options[i] = fmt.Sprintf("(%d) %.0f%% for %s", i+1, c*100, FormatNumber(offer.Premium(c)))
+	}
+	fmt.Printf(
+		"Insurance: you have %.1f%% equity in %s chips. Insure %s?\n",
+		offer.Equity*100, FormatNumber(offer.Stake), strings.Join(options, ", "),
+	)
+	for {
+		fmt.Printf("Enter 1-%d, or press ENTER to decline > ", len(insuranceCoverages))
+		input := ReadLine()
+		input = strings.TrimSpace(input)
+		if input == "" {
+			return 0, false
+		}
+		index, err := strconv.Atoi(input)
+		if err == nil && index >= 1 && index <= len(insuranceCoverages) {
+			return insuranceCoverages[index-1], true
+		}
+		fmt.Println("Invalid choice. Please try again.")
+	}
+}
+
+// PromptForRunIt asks the human whether to run the rest of the board the given
+// number of times. Anything but "y" declines.
+func PromptForRunIt(times int) bool {
+	fmt.Printf("Everyone is all-in. Run it %s? (y/N) > ", FormatRunItTimes(times))
+	input := ReadLine()
+	return strings.TrimSpace(strings.ToLower(input)) == "y"
+}
+
+// PromptForHandRead asks the human to guess the category of an opponent's
+// hand before the showdown, by name or number. It returns false if the human
+// skips the guess with an empty line.
+func PromptForHandRead(opponent *engine.Player) (engine.HandRead, bool) {
+	var options []string
+	for i, name := range engine.HandReadNames() {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, name))
+	}
+	for {
+		fmt.Printf("What does %s have? %s (Enter to skip) > ", opponent.Name, strings.Join(options, ", "))
+		input := ReadLine()
+		input = strings.TrimSpace(strings.ToLower(input))
+		if input == "" {
+			return engine.HandReadNothing, false
+		}
+		read, err := engine.ParseHandRead(input)
+		if err == nil {
+			return read, true
 		}
+		fmt.Println("Unknown hand category. Please try again.")
 	}
 }
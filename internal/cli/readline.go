@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bufio"
+	"os"
+	"sync"
+	"time"
+)
+
+// ActionTimeout is how long the human has to act at the action prompt, or 0
+// for no limit. When it runs out, the human checks if they can and folds
+// otherwise.
+var ActionTimeout time.Duration
+
+// stdinLines delivers the lines typed at stdin once a timed prompt has been
+// shown. They are read by a single goroutine from then on, so that a prompt
+// given up on when its time ran out does not swallow the line typed at the
+// next one.
+var (
+	stdinOnce  sync.Once
+	stdinLines chan string
+)
+
+// ReadLine reads a line typed at stdin, including its newline. It returns an
+// empty string at the end of the input.
+func ReadLine() string {
+	line, _ := readLineBy(time.Time{})
+	return line
+}
+
+// readLineBy is ReadLine, giving up with ok=false at the deadline unless it
+// is zero.
+func readLineBy(deadline time.Time) (line string, ok bool) {
+	if deadline.IsZero() && stdinLines == nil {
+		reader := bufio.NewReader(os.Stdin)
+		line, _ = reader.ReadString('\n')
+		return line, true
+	}
+	stdinOnce.Do(func() {
+		stdinLines = make(chan string)
+		go func() {
+			reader := bufio.NewReader(os.Stdin)
+			for {
+				line, err := reader.ReadString('\n')
+				if line != "" {
+					stdinLines <- line
+				}
+				if err != nil {
+					close(stdinLines)
+					return
+				}
+			}
+		}()
+	})
+	if deadline.IsZero() {
+		return <-stdinLines, true
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case line = <-stdinLines:
+		return line, true
+	case <-timer.C:
+		return "", false
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"strings"
+)
+
+// FormatShownHands renders the hands a player has shown down this session,
+// for the "shown <player>" command of the action prompt. The name is matched
+// without regard to case or spaces, so "shown cpu3" finds "CPU 3". Without a
+// name, it lists how many hands each player has shown down.
+func FormatShownHands(g *engine.Game, name string) []string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		lines := []string{"Hands shown down this session:"}
+		for _, p := range g.Players {
+			lines = append(lines, fmt.Sprintf("  %-8s %d", p.Name, len(g.ShowdownGallery[p.Name])))
+		}
+		return lines
+	}
+
+	var player *engine.Player
+	for _, p := range g.Players {
+		if normalizeName(p.Name) == normalizeName(name) {
+			player = p
+		}
+	}
+	if player == nil {
+		return []string{fmt.Sprintf("There is no player named %q.", name)}
+	}
+	hands := g.ShowdownGallery[player.Name]
+	if len(hands) == 0 {
+		return []string{fmt.Sprintf("%s has not shown down a hand this session.", player.Name)}
+	}
+
+	lines := []string{fmt.Sprintf("--- %s's showdowns ---", player.Name)}
+	for _, h := range hands {
+		result := "lost"
+		if h.Won > 0 {
+			result = "won " + FormatNumber(h.Won)
+		}
+		line := fmt.Sprintf("Hand #%d: %s | Board: %s", h.HandNumber, formatCardList(h.HoleCards), formatCardList(h.Board))
+		if h.High != nil {
+			line += fmt.Sprintf(" | High: %s", h.High.Rank)
+		}
+		if h.Low != nil {
+			line += fmt.Sprintf(" | Low: %s", formatLowHand(h.Low))
+		}
+		lines = append(lines, line+" | "+result)
+	}
+	return lines
+}
+
+// normalizeName lowers a player name and drops its spaces.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SaveHandHistory writes the hand history to dir as <id>.json, creating the
+// directory if needed.
+func SaveHandHistory(dir string, h *engine.HandHistory) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return WriteHandHistoryFile(filepath.Join(dir, h.ID+".json"), h)
+}
+
+// LoadHandHistory reads the hand history with the given ID from dir.
+func LoadHandHistory(dir, id string) (*engine.HandHistory, error) {
+	h, err := ReadHandHistoryFile(filepath.Join(dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("hand %q not found in %s", id, dir)
+	}
+	return h, err
+}
+
+// WriteHandHistoryFile writes a hand history to the given file as indented
+// JSON, the format the replay command reads back.
+func WriteHandHistoryFile(path string, h *engine.HandHistory) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ReadHandHistoryFile reads a hand history from a JSON file, wherever it was
+// saved or exported to.
+func ReadHandHistoryFile(path string) (*engine.HandHistory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var h engine.HandHistory
+	if err := json.Unmarshal(data, &h); err != nil {
+		return nil, fmt.Errorf("%s is not a hand history: %w", path, err)
+	}
+	return &h, nil
+}
+
+// ListHandHistoryIDs returns the IDs of all hand histories saved in dir, oldest
+// first. A missing directory yields no IDs.
+func ListHandHistoryIDs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasSuffix(name, ".json") {
+			ids = append(ids, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// LoadHandHistoriesSince loads the hand histories in dir played at or after
+// since, oldest first. Hands are selected by the timestamp in their IDs, so
+// this works across several tables played at once.
+func LoadHandHistoriesSince(dir string, since time.Time) ([]*engine.HandHistory, error) {
+	ids, err := ListHandHistoryIDs(dir)
+	if err != nil {
+		return nil, err
+	}
+	start := since.Format(engine.HandIDTimeFormat)
+	var histories []*engine.HandHistory
+	for _, id := range ids {
+		if len(id) < len(start) || id[:len(start)] < start {
+			continue
+		}
+		h, err := LoadHandHistory(dir, id)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+	return histories, nil
+}
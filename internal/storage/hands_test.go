@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHandHistory_SaveAndLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "hands")
+
+	h := &engine.HandHistory{
+		ID:         "20250101-120000-0003",
+		HandNumber: 3,
+		BigBlind:   1000,
+		Seats: []engine.SeatRecord{
+			{Name: "YOU", IsHuman: true, StartingChips: 300000, HoleCards: poker.CardsFromStrings("As Kd 7c")},
+		},
+		Actions: []engine.ActionRecord{{Phase: engine.PhasePreFlop, PlayerName: "YOU", Action: engine.ActionRaise, Amount: 3000}},
+	}
+	if err := SaveHandHistory(dir, h); err != nil {
+		t.Fatalf("Expected no error saving hand, but got: %v", err)
+	}
+
+	loaded, err := LoadHandHistory(dir, h.ID)
+	if err != nil {
+		t.Fatalf("Expected no error loading hand, but got: %v", err)
+	}
+	if loaded.HandNumber != 3 || len(loaded.Seats) != 1 || loaded.Seats[0].HoleCards[0] != h.Seats[0].HoleCards[0] {
+		t.Errorf("Loaded hand does not match saved hand: %+v", loaded)
+	}
+	if len(loaded.Actions) != 1 || loaded.Actions[0].Action != engine.ActionRaise {
+		t.Errorf("Loaded actions do not match saved actions: %+v", loaded.Actions)
+	}
+
+	ids, err := ListHandHistoryIDs(dir)
+	if err != nil {
+		t.Fatalf("Expected no error listing hands, but got: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != h.ID {
+		t.Errorf("Expected IDs [%s], but got %v", h.ID, ids)
+	}
+
+	if _, err := LoadHandHistory(dir, "missing"); err == nil {
+		t.Error("Expected an error for a missing hand")
+	}
+}
+
+func TestHandHistoryFile_WriteAndRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hand.json")
+
+	h := &engine.HandHistory{
+		ID:         "20250101-120000-0004",
+		HandNumber: 4,
+		Board:      poker.CardsFromStrings("2s 7s Jd"),
+		Results:    []engine.DistributionResult{{PlayerName: "YOU", AmountWon: 6000, HandDesc: "High Card"}},
+	}
+	if err := WriteHandHistoryFile(path, h); err != nil {
+		t.Fatalf("Expected no error writing hand, but got: %v", err)
+	}
+
+	loaded, err := ReadHandHistoryFile(path)
+	if err != nil {
+		t.Fatalf("Expected no error reading hand, but got: %v", err)
+	}
+	if loaded.ID != h.ID || len(loaded.Board) != 3 || len(loaded.Results) != 1 || loaded.Results[0].AmountWon != 6000 {
+		t.Errorf("Read hand does not match written hand: %+v", loaded)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadHandHistoryFile(path); err == nil {
+		t.Error("Expected an error for a file that is not a hand history")
+	}
+}
+
+func TestLoadHandHistoriesSince(t *testing.T) {
+	dir := t.TempDir()
+	for _, id := range []string{"20250101-115959-0001", "20250101-120000-0001", "20250101-120500-0002-t2"} {
+		if err := SaveHandHistory(dir, &engine.HandHistory{ID: id}); err != nil {
+			t.Fatalf("Expected no error saving hand, but got: %v", err)
+		}
+	}
+
+	since := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+	histories, err := LoadHandHistoriesSince(dir, since)
+	if err != nil {
+		t.Fatalf("Expected no error loading hands, but got: %v", err)
+	}
+	if len(histories) != 2 || histories[0].ID != "20250101-120000-0001" || histories[1].ID != "20250101-120500-0002-t2" {
+		t.Errorf("Expected the two hands played since noon, but got %+v", histories)
+	}
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+)
+
+// LoadMilestones reads the milestones stored at filePath, keyed by player
+// profile and oldest first. A missing file is not an error; it yields an empty
+// set.
+func LoadMilestones(filePath string) (map[string][]engine.Milestone, error) {
+	milestones := make(map[string][]engine.Milestone)
+
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return milestones, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &milestones); err != nil {
+		return nil, err
+	}
+	return milestones, nil
+}
+
+// SaveMilestones writes the milestones to filePath as JSON, creating the
+// parent directory if needed.
+func SaveMilestones(filePath string, milestones map[string][]engine.Milestone) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(milestones, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMilestones_MissingFile(t *testing.T) {
+	milestones, err := LoadMilestones(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, but got: %v", err)
+	}
+	if len(milestones) != 0 {
+		t.Errorf("Expected no milestones, but got %v", milestones)
+	}
+}
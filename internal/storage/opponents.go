@@ -0,0 +1,43 @@
+// Package storage persists player-related data between game sessions, such as
+// the AI's memory of human opponents and the history of played hands.
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+)
+
+// LoadOpponentModels reads the opponent models stored at filePath, keyed by
+// player profile. A missing file is not an error; it yields an empty set.
+func LoadOpponentModels(filePath string) (map[string]*engine.OpponentModel, error) {
+	models := make(map[string]*engine.OpponentModel)
+
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return models, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &models); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// SaveOpponentModels writes the opponent models to filePath as JSON, creating
+// the parent directory if needed.
+func SaveOpponentModels(filePath string, models map[string]*engine.OpponentModel) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(models, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
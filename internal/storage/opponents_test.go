@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpponentModels_SaveAndLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nested", "opponents.json")
+
+	models := map[string]*engine.OpponentModel{
+		"alice": {Profile: "alice", HandsObserved: 20, VoluntaryHands: 8, BetsFaced: 12, FoldsToBet: 9},
+	}
+	if err := SaveOpponentModels(filePath, models); err != nil {
+		t.Fatalf("Expected no error saving models, but got: %v", err)
+	}
+
+	loaded, err := LoadOpponentModels(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error loading models, but got: %v", err)
+	}
+	alice, ok := loaded["alice"]
+	if !ok {
+		t.Fatal("Expected a model for profile 'alice'")
+	}
+	if alice.HandsObserved != 20 || alice.VoluntaryHands != 8 || alice.BetsFaced != 12 || alice.FoldsToBet != 9 {
+		t.Errorf("Loaded model does not match saved model: %+v", alice)
+	}
+}
+
+func TestLoadOpponentModels_MissingFile(t *testing.T) {
+	models, err := LoadOpponentModels(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, but got: %v", err)
+	}
+	if len(models) != 0 {
+		t.Errorf("Expected no models, but got %d", len(models))
+	}
+}
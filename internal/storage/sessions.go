@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+)
+
+// LoadSessions reads the session records stored at filePath, keyed by player
+// profile and oldest first. A missing file is not an error; it yields an empty
+// set.
+func LoadSessions(filePath string) (map[string][]engine.SessionRecord, error) {
+	sessions := make(map[string][]engine.SessionRecord)
+
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return sessions, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// SaveSessions writes the session records to filePath as JSON, creating the
+// parent directory if needed.
+func SaveSessions(filePath string, sessions map[string][]engine.SessionRecord) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+)
+
+// Settings holds the player's preferences that apply to every game unless
+// overridden by flags.
+type Settings struct {
+	// StreamerMode starts games with the human's hole cards hidden, so that
+	// the screen can be shared live.
+	StreamerMode bool `json:"streamer_mode"`
+	// OutsDelaySeconds holds back the outs and equity panel after the table
+	// is shown.
+	OutsDelaySeconds int `json:"outs_delay_seconds"`
+	// Macros binds short names typed at the action prompt to action commands,
+	// e.g. "m1" to "raise 2.5bb" (see engine.ParseActionCommand).
+	Macros map[string]string `json:"macros,omitempty"`
+	// Goals are the stop-win, stop-loss and number of hands at which the
+	// player is offered to end a session.
+	Goals engine.SessionGoals `json:"goals"`
+	// Accessible announces the table in sentences for a screen reader, as
+	// much of it as Verbosity says: brief, normal or full. An empty Verbosity
+	// is normal.
+	Accessible bool   `json:"accessible,omitempty"`
+	Verbosity  string `json:"verbosity,omitempty"`
+}
+
+// LoadSettings reads the settings stored at filePath. A missing file is not an
+// error; it yields the default settings.
+func LoadSettings(filePath string) (*Settings, error) {
+	settings := &Settings{}
+
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveSettings writes the settings to filePath as JSON, creating the parent
+// directory if needed.
+func SaveSettings(filePath string, settings *Settings) error {
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0644)
+}
@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSettings_SaveAndLoad(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "nested", "settings.json")
+
+	if err := SaveSettings(filePath, &Settings{StreamerMode: true, OutsDelaySeconds: 5, Macros: map[string]string{"m1": "raise 2.5bb"}}); err != nil {
+		t.Fatalf("Expected no error saving settings, but got: %v", err)
+	}
+
+	loaded, err := LoadSettings(filePath)
+	if err != nil {
+		t.Fatalf("Expected no error loading settings, but got: %v", err)
+	}
+	if !loaded.StreamerMode || loaded.OutsDelaySeconds != 5 || loaded.Macros["m1"] != "raise 2.5bb" {
+		t.Errorf("Loaded settings do not match saved settings: %+v", loaded)
+	}
+}
+
+func TestLoadSettings_MissingFile(t *testing.T) {
+	settings, err := LoadSettings(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected no error for a missing file, but got: %v", err)
+	}
+	if !reflect.DeepEqual(*settings, Settings{}) {
+		t.Errorf("Expected the default settings, but got %+v", settings)
+	}
+}
@@ -0,0 +1,304 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"slices"
+	"time"
+)
+
+// sqliteDriver is the database/sql driver name OpenSQLite uses. It is
+// registered by building with the sqlite tag (see sqlite_driver.go).
+const sqliteDriver = "sqlite"
+
+// sqlSchema creates the tables SQLStorage uses. Every record is kept as JSON,
+// so the schema does not change with the engine's types; hands are keyed by
+// their IDs, which start with the time they were played, so that a session's
+// hands are found through the primary key.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS opponent_models (profile TEXT PRIMARY KEY, data TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS settings (id INTEGER PRIMARY KEY, data TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS hand_histories (id TEXT PRIMARY KEY, played_at TIMESTAMP NOT NULL, data TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS milestones (profile TEXT PRIMARY KEY, data TEXT NOT NULL)`,
+	`CREATE TABLE IF NOT EXISTS sessions (profile TEXT PRIMARY KEY, data TEXT NOT NULL)`,
+}
+
+// SQLStorage keeps data in an SQL database through database/sql. Its queries
+// are written for SQLite.
+type SQLStorage struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens, and creates if needed, an SQLite database at path.
+func OpenSQLite(path string) (*SQLStorage, error) {
+	if !slices.Contains(sql.Drivers(), sqliteDriver) {
+		return nil, errors.New("SQLite support is not built in; rebuild with -tags sqlite")
+	}
+	db, err := sql.Open(sqliteDriver, path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewSQLStorage(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSQLStorage returns a storage backed by db, creating its tables if needed.
+// Closing the storage closes db.
+func NewSQLStorage(db *sql.DB) (*SQLStorage, error) {
+	for _, stmt := range sqlSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, fmt.Errorf("could not create tables: %w", err)
+		}
+	}
+	return &SQLStorage{db: db}, nil
+}
+
+// LoadOpponentModels implements Storage.
+func (s *SQLStorage) LoadOpponentModels() (map[string]*engine.OpponentModel, error) {
+	rows, err := s.db.Query(`SELECT profile, data FROM opponent_models`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	models := make(map[string]*engine.OpponentModel)
+	for rows.Next() {
+		var profile, data string
+		if err := rows.Scan(&profile, &data); err != nil {
+			return nil, err
+		}
+		var m engine.OpponentModel
+		if err := json.Unmarshal([]byte(data), &m); err != nil {
+			return nil, fmt.Errorf("opponent model %q: %w", profile, err)
+		}
+		models[profile] = &m
+	}
+	return models, rows.Err()
+}
+
+// SaveOpponentModels implements Storage.
+func (s *SQLStorage) SaveOpponentModels(models map[string]*engine.OpponentModel) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM opponent_models`); err != nil {
+		return err
+	}
+	for profile, m := range models {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO opponent_models (profile, data) VALUES (?, ?)`, profile, string(data)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// LoadSettings implements Storage.
+func (s *SQLStorage) LoadSettings() (*Settings, error) {
+	settings := &Settings{}
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM settings WHERE id = 1`).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(data), settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveSettings implements Storage.
+func (s *SQLStorage) SaveSettings(settings *Settings) error {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO settings (id, data) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data`, string(data))
+	return err
+}
+
+// SaveHandHistory implements Storage.
+func (s *SQLStorage) SaveHandHistory(h *engine.HandHistory) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO hand_histories (id, played_at, data) VALUES (?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET played_at = excluded.played_at, data = excluded.data`,
+		h.ID, h.PlayedAt.UTC(), string(data))
+	return err
+}
+
+// LoadHandHistory implements Storage.
+func (s *SQLStorage) LoadHandHistory(id string) (*engine.HandHistory, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM hand_histories WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("hand %q not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h engine.HandHistory
+	if err := json.Unmarshal([]byte(data), &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// ListHandHistoryIDs implements Storage.
+func (s *SQLStorage) ListHandHistoryIDs() ([]string, error) {
+	rows, err := s.db.Query(`SELECT id FROM hand_histories ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// LoadHandHistoriesSince implements Storage. Like the file storage, it selects
+// hands by the timestamp in their IDs.
+func (s *SQLStorage) LoadHandHistoriesSince(since time.Time) ([]*engine.HandHistory, error) {
+	rows, err := s.db.Query(`SELECT data FROM hand_histories WHERE id >= ? ORDER BY id`, since.Format(engine.HandIDTimeFormat))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var histories []*engine.HandHistory
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var h engine.HandHistory
+		if err := json.Unmarshal([]byte(data), &h); err != nil {
+			return nil, err
+		}
+		histories = append(histories, &h)
+	}
+	return histories, rows.Err()
+}
+
+// LoadMilestones implements Storage.
+func (s *SQLStorage) LoadMilestones() (map[string][]engine.Milestone, error) {
+	rows, err := s.db.Query(`SELECT profile, data FROM milestones`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	milestones := make(map[string][]engine.Milestone)
+	for rows.Next() {
+		var profile, data string
+		if err := rows.Scan(&profile, &data); err != nil {
+			return nil, err
+		}
+		var list []engine.Milestone
+		if err := json.Unmarshal([]byte(data), &list); err != nil {
+			return nil, fmt.Errorf("milestones of %q: %w", profile, err)
+		}
+		milestones[profile] = list
+	}
+	return milestones, rows.Err()
+}
+
+// SaveMilestones implements Storage.
+func (s *SQLStorage) SaveMilestones(milestones map[string][]engine.Milestone) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM milestones`); err != nil {
+		return err
+	}
+	for profile, list := range milestones {
+		data, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO milestones (profile, data) VALUES (?, ?)`, profile, string(data)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// LoadSessions implements Storage.
+func (s *SQLStorage) LoadSessions() (map[string][]engine.SessionRecord, error) {
+	rows, err := s.db.Query(`SELECT profile, data FROM sessions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make(map[string][]engine.SessionRecord)
+	for rows.Next() {
+		var profile, data string
+		if err := rows.Scan(&profile, &data); err != nil {
+			return nil, err
+		}
+		var list []engine.SessionRecord
+		if err := json.Unmarshal([]byte(data), &list); err != nil {
+			return nil, fmt.Errorf("sessions of %q: %w", profile, err)
+		}
+		sessions[profile] = list
+	}
+	return sessions, rows.Err()
+}
+
+// SaveSessions implements Storage.
+func (s *SQLStorage) SaveSessions(sessions map[string][]engine.SessionRecord) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM sessions`); err != nil {
+		return err
+	}
+	for profile, list := range sessions {
+		data, err := json.Marshal(list)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO sessions (profile, data) VALUES (?, ?)`, profile, string(data)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Close implements Storage.
+func (s *SQLStorage) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,10 @@
+//go:build sqlite
+
+package storage
+
+// Building with the sqlite tag registers a pure Go SQLite driver, so that
+// OpenSQLite works without cgo. The module must be added first:
+//
+//	go get modernc.org/sqlite
+//	go build -tags sqlite
+import _ "modernc.org/sqlite"
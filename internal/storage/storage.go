@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Storage persists the data kept between sessions: the AI's memory of each
+// player profile, the player's settings, the history of played hands, the
+// milestones each profile has reached, and the sessions each profile played.
+// FileStorage, the default, keeps them as JSON files; SQLStorage keeps them in
+// a database, e.g. for server deployments.
+type Storage interface {
+	// LoadOpponentModels returns the stored opponent models, keyed by player
+	// profile, or an empty set if none are stored yet.
+	LoadOpponentModels() (map[string]*engine.OpponentModel, error)
+	// SaveOpponentModels replaces the stored opponent models.
+	SaveOpponentModels(models map[string]*engine.OpponentModel) error
+	// LoadSettings returns the stored settings, or the defaults if none are
+	// stored yet.
+	LoadSettings() (*Settings, error)
+	// SaveSettings replaces the stored settings.
+	SaveSettings(settings *Settings) error
+	// SaveHandHistory stores a hand history under its ID.
+	SaveHandHistory(h *engine.HandHistory) error
+	// LoadHandHistory returns the hand history with the given ID.
+	LoadHandHistory(id string) (*engine.HandHistory, error)
+	// ListHandHistoryIDs returns the IDs of all stored hands, oldest first.
+	ListHandHistoryIDs() ([]string, error)
+	// LoadHandHistoriesSince returns the hands played at or after since,
+	// oldest first.
+	LoadHandHistoriesSince(since time.Time) ([]*engine.HandHistory, error)
+	// LoadMilestones returns the stored milestones, keyed by player profile
+	// and oldest first, or an empty set if none are stored yet.
+	LoadMilestones() (map[string][]engine.Milestone, error)
+	// SaveMilestones replaces the stored milestones.
+	SaveMilestones(milestones map[string][]engine.Milestone) error
+	// LoadSessions returns the stored session records, keyed by player
+	// profile and oldest first, or an empty set if none are stored yet.
+	LoadSessions() (map[string][]engine.SessionRecord, error)
+	// SaveSessions replaces the stored session records.
+	SaveSessions(sessions map[string][]engine.SessionRecord) error
+	// Close releases the storage's resources.
+	Close() error
+}
+
+// Open opens the storage described by spec:
+//
+//   - "" or "file": JSON files in the default directory (see DefaultDir)
+//   - "file:<dir>": JSON files in dir
+//   - "sqlite:<path>": an SQLite database at path (see OpenSQLite)
+func Open(spec string) (Storage, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "", "file":
+		if arg != "" {
+			return NewFileStorage(arg), nil
+		}
+		s, err := DefaultFileStorage()
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	case "sqlite":
+		if arg == "" {
+			return nil, fmt.Errorf("missing database path, e.g. sqlite:pls7.db")
+		}
+		s, err := OpenSQLite(arg)
+		if err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown storage %q (use file, file:<dir> or sqlite:<path>)", spec)
+}
+
+// DefaultDir returns the default directory in which data is kept between
+// sessions.
+func DefaultDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pls7-cli"), nil
+}
+
+// FileStorage keeps data as JSON files in a directory: opponents.json,
+// settings.json, and one file per hand under hands/.
+type FileStorage struct {
+	Dir string
+}
+
+// NewFileStorage returns a storage that keeps its files in dir.
+func NewFileStorage(dir string) *FileStorage {
+	return &FileStorage{Dir: dir}
+}
+
+// DefaultFileStorage returns a storage that keeps its files in the default
+// directory.
+func DefaultFileStorage() (*FileStorage, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewFileStorage(dir), nil
+}
+
+func (s *FileStorage) opponentModelsPath() string { return filepath.Join(s.Dir, "opponents.json") }
+func (s *FileStorage) settingsPath() string       { return filepath.Join(s.Dir, "settings.json") }
+func (s *FileStorage) handHistoryDir() string     { return filepath.Join(s.Dir, "hands") }
+func (s *FileStorage) milestonesPath() string     { return filepath.Join(s.Dir, "milestones.json") }
+func (s *FileStorage) sessionsPath() string       { return filepath.Join(s.Dir, "sessions.json") }
+
+// LoadOpponentModels implements Storage.
+func (s *FileStorage) LoadOpponentModels() (map[string]*engine.OpponentModel, error) {
+	return LoadOpponentModels(s.opponentModelsPath())
+}
+
+// SaveOpponentModels implements Storage.
+func (s *FileStorage) SaveOpponentModels(models map[string]*engine.OpponentModel) error {
+	return SaveOpponentModels(s.opponentModelsPath(), models)
+}
+
+// LoadSettings implements Storage.
+func (s *FileStorage) LoadSettings() (*Settings, error) {
+	return LoadSettings(s.settingsPath())
+}
+
+// SaveSettings implements Storage.
+func (s *FileStorage) SaveSettings(settings *Settings) error {
+	return SaveSettings(s.settingsPath(), settings)
+}
+
+// SaveHandHistory implements Storage.
+func (s *FileStorage) SaveHandHistory(h *engine.HandHistory) error {
+	return SaveHandHistory(s.handHistoryDir(), h)
+}
+
+// LoadHandHistory implements Storage.
+func (s *FileStorage) LoadHandHistory(id string) (*engine.HandHistory, error) {
+	return LoadHandHistory(s.handHistoryDir(), id)
+}
+
+// ListHandHistoryIDs implements Storage.
+func (s *FileStorage) ListHandHistoryIDs() ([]string, error) {
+	return ListHandHistoryIDs(s.handHistoryDir())
+}
+
+// LoadHandHistoriesSince implements Storage.
+func (s *FileStorage) LoadHandHistoriesSince(since time.Time) ([]*engine.HandHistory, error) {
+	return LoadHandHistoriesSince(s.handHistoryDir(), since)
+}
+
+// LoadMilestones implements Storage.
+func (s *FileStorage) LoadMilestones() (map[string][]engine.Milestone, error) {
+	return LoadMilestones(s.milestonesPath())
+}
+
+// SaveMilestones implements Storage.
+func (s *FileStorage) SaveMilestones(milestones map[string][]engine.Milestone) error {
+	return SaveMilestones(s.milestonesPath(), milestones)
+}
+
+// LoadSessions implements Storage.
+func (s *FileStorage) LoadSessions() (map[string][]engine.SessionRecord, error) {
+	return LoadSessions(s.sessionsPath())
+}
+
+// SaveSessions implements Storage.
+func (s *FileStorage) SaveSessions(sessions map[string][]engine.SessionRecord) error {
+	return SaveSessions(s.sessionsPath(), sessions)
+}
+
+// Close implements Storage. Files need no cleanup.
+func (s *FileStorage) Close() error {
+	return nil
+}
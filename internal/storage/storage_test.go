@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"database/sql"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// testStorage checks that a storage keeps what is saved to it.
+func testStorage(t *testing.T, s Storage) {
+	models, err := s.LoadOpponentModels()
+	if err != nil || len(models) != 0 {
+		t.Fatalf("Expected no opponent models in new storage, but got %v (%v)", models, err)
+	}
+	if err := s.SaveOpponentModels(map[string]*engine.OpponentModel{"alice": {Profile: "alice", BetsFaced: 12, FoldsToBet: 9}}); err != nil {
+		t.Fatalf("Expected no error saving models, but got: %v", err)
+	}
+	models, err = s.LoadOpponentModels()
+	if err != nil {
+		t.Fatalf("Expected no error loading models, but got: %v", err)
+	}
+	if alice := models["alice"]; alice == nil || alice.BetsFaced != 12 || alice.FoldsToBet != 9 {
+		t.Errorf("Loaded models do not match saved models: %v", models)
+	}
+
+	if err := s.SaveSettings(&Settings{StreamerMode: true, OutsDelaySeconds: 3}); err != nil {
+		t.Fatalf("Expected no error saving settings, but got: %v", err)
+	}
+	if settings, err := s.LoadSettings(); err != nil || !settings.StreamerMode || settings.OutsDelaySeconds != 3 {
+		t.Errorf("Loaded settings do not match saved settings: %+v (%v)", settings, err)
+	}
+
+	for _, id := range []string{"20250101-110000-0001", "20250101-120000-0002", "20250101-130000-0003"} {
+		if err := s.SaveHandHistory(&engine.HandHistory{ID: id, HandNumber: 1}); err != nil {
+			t.Fatalf("Expected no error saving hand %s, but got: %v", id, err)
+		}
+	}
+	ids, err := s.ListHandHistoryIDs()
+	if err != nil || !slices.Equal(ids, []string{"20250101-110000-0001", "20250101-120000-0002", "20250101-130000-0003"}) {
+		t.Errorf("Expected the hands oldest first, but got %v (%v)", ids, err)
+	}
+	if h, err := s.LoadHandHistory("20250101-120000-0002"); err != nil || h.ID != "20250101-120000-0002" {
+		t.Errorf("Expected to load hand 20250101-120000-0002, but got %v (%v)", h, err)
+	}
+	if _, err := s.LoadHandHistory("missing"); err == nil {
+		t.Errorf("Expected an error loading a missing hand")
+	}
+	histories, err := s.LoadHandHistoriesSince(time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local))
+	if err != nil || len(histories) != 2 || histories[0].ID != "20250101-120000-0002" {
+		t.Errorf("Expected the 2 hands played since noon, but got %d (%v)", len(histories), err)
+	}
+
+	milestones, err := s.LoadMilestones()
+	if err != nil || len(milestones) != 0 {
+		t.Fatalf("Expected no milestones in new storage, but got %v (%v)", milestones, err)
+	}
+	saved := map[string][]engine.Milestone{"alice": {{Kind: engine.MilestoneRoyalFlush, HandID: "20250101-110000-0001", First: true}}}
+	if err := s.SaveMilestones(saved); err != nil {
+		t.Fatalf("Expected no error saving milestones, but got: %v", err)
+	}
+	milestones, err = s.LoadMilestones()
+	if alice := milestones["alice"]; err != nil || len(alice) != 1 || alice[0].Kind != engine.MilestoneRoyalFlush || !alice[0].First {
+		t.Errorf("Loaded milestones do not match saved milestones: %v (%v)", milestones, err)
+	}
+
+	sessions, err := s.LoadSessions()
+	if err != nil || len(sessions) != 0 {
+		t.Fatalf("Expected no sessions in new storage, but got %v (%v)", sessions, err)
+	}
+	record := engine.SessionRecord{BigBlind: 100, Hands: 40, NetChips: 5000, Goals: engine.SessionGoals{StopWinBB: 50}, EndedBy: engine.SessionLimitStopWin}
+	if err := s.SaveSessions(map[string][]engine.SessionRecord{"alice": {record}}); err != nil {
+		t.Fatalf("Expected no error saving sessions, but got: %v", err)
+	}
+	sessions, err = s.LoadSessions()
+	if alice := sessions["alice"]; err != nil || len(alice) != 1 || alice[0] != record {
+		t.Errorf("Loaded sessions do not match saved sessions: %v (%v)", sessions, err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Errorf("Expected no error closing the storage, but got: %v", err)
+	}
+}
+
+func TestFileStorage(t *testing.T) {
+	testStorage(t, NewFileStorage(filepath.Join(t.TempDir(), "nested")))
+}
+
+func TestSQLStorage(t *testing.T) {
+	if !slices.Contains(sql.Drivers(), sqliteDriver) {
+		t.Skip("SQLite support is not built in; run with -tags sqlite")
+	}
+	s, err := OpenSQLite(filepath.Join(t.TempDir(), "pls7.db"))
+	if err != nil {
+		t.Fatalf("Expected no error opening the database, but got: %v", err)
+	}
+	testStorage(t, s)
+}
+
+func TestOpen(t *testing.T) {
+	dir := t.TempDir()
+	s, err := Open("file:" + dir)
+	if err != nil {
+		t.Fatalf("Expected no error opening file storage, but got: %v", err)
+	}
+	if fs, ok := s.(*FileStorage); !ok || fs.Dir != dir {
+		t.Errorf("Expected file storage in %s, but got %#v", dir, s)
+	}
+
+	for _, spec := range []string{"sqlite:", "postgres:db", "files"} {
+		if s, err := Open(spec); err == nil || s != nil {
+			t.Errorf("Expected only an error opening %q, but got %#v and %v", spec, s, err)
+		}
+	}
+}
@@ -0,0 +1,293 @@
+// Package tutorial provides the scripted lessons of the interactive PLS7
+// tutorial. Every lesson is set up as a real game position, and every quiz
+// answer is computed by the engine itself, so the tutorial cannot drift from
+// the rules it teaches.
+package tutorial
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/internal/cli"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"slices"
+)
+
+// Lesson is a single step of the tutorial: a position to look at, an
+// explanation of the concept it shows, and a quiz question about it.
+type Lesson struct {
+	Title string
+	// Explanation is printed line by line below the position.
+	Explanation []string
+	// Game is the position shown to the player, with the human to act.
+	Game *engine.Game
+	Quiz Quiz
+}
+
+// Quiz is a multiple-choice question.
+type Quiz struct {
+	Question string
+	Choices  []string
+	// Answer is the index of the correct choice.
+	Answer int
+	// Explanation is shown after the player answers.
+	Explanation string
+}
+
+// IsCorrect reports whether the zero-based choice answers the quiz.
+func (q Quiz) IsCorrect(choice int) bool {
+	return choice == q.Answer
+}
+
+// Lessons returns the tutorial's lessons, in order, for the PLS7 rules.
+func Lessons(rules *poker.GameRules) ([]Lesson, error) {
+	builders := []func(*poker.GameRules) (Lesson, error){
+		readingTheDisplayLesson,
+		skipStraightLesson,
+		skipStraightRankingLesson,
+		lowHandLesson,
+		counterfeitLesson,
+		potLimitLesson,
+	}
+	lessons := make([]Lesson, 0, len(builders))
+	for _, build := range builders {
+		lesson, err := build(rules)
+		if err != nil {
+			return nil, err
+		}
+		lessons = append(lessons, lesson)
+	}
+	return lessons, nil
+}
+
+// scenario describes a position for a lesson. The human ("YOU") is always
+// first to act against a single bet of betToCall.
+type scenario struct {
+	hole, board string
+	pot         int
+	betToCall   int
+}
+
+// newScenarioGame sets up a heads-up game at the given position.
+func newScenarioGame(rules *poker.GameRules, s scenario) (*engine.Game, error) {
+	hole, err := poker.ParseCards(s.hole)
+	if err != nil {
+		return nil, err
+	}
+	board, err := poker.ParseCards(s.board)
+	if err != nil {
+		return nil, err
+	}
+
+	g := engine.NewGame([]string{"YOU", "CPU 1"}, 100000, 500, 1000, engine.DifficultyEasy, rules, false, false, 0)
+	g.HandCount = 1
+	g.DealerPos = 1
+	g.CurrentTurnPos = 0
+	g.Phase = map[int]engine.GamePhase{0: engine.PhasePreFlop, 3: engine.PhaseFlop, 4: engine.PhaseTurn, 5: engine.PhaseRiver}[len(board)]
+	g.CommunityCards = board
+	g.Players[0].Hand = hole
+
+	// The opponent has put the pot in, plus the bet facing the human.
+	cpu := g.Players[1]
+	cpu.Chips -= s.pot
+	cpu.TotalBetInHand = s.pot
+	cpu.CurrentBet = s.betToCall
+	cpu.LastActionDesc = fmt.Sprintf("Bet %d", s.betToCall)
+	g.Pot = s.pot
+	g.BetToCall = s.betToCall
+	g.LastRaiseAmount = s.betToCall
+	return g, nil
+}
+
+// rankQuiz builds a "what is your best hand?" quiz whose answer is the
+// evaluator's verdict for the human's hand.
+func rankQuiz(g *engine.Game, choices []poker.HandRank, explanation string) (Quiz, error) {
+	high, _ := poker.EvaluateHand(g.Players[0].Hand, g.CommunityCards, g.Rules)
+	quiz := Quiz{Question: "What is your best high hand?", Answer: -1, Explanation: explanation}
+	for i, rank := range choices {
+		quiz.Choices = append(quiz.Choices, rank.String())
+		if high != nil && high.Rank == rank {
+			quiz.Answer = i
+		}
+	}
+	if quiz.Answer < 0 {
+		return Quiz{}, fmt.Errorf("the hand %v evaluates to %v, which is not among the choices", g.Players[0].Hand, high)
+	}
+	return quiz, nil
+}
+
+func readingTheDisplayLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "Ks Kd 4c", board: "Kh 9c 2s", pot: 3000, betToCall: 1000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	return Lesson{
+		Title: "Reading the display",
+		Explanation: []string{
+			"The header shows the game, the hand number, the betting round (PHASE), the pot, and the blinds.",
+			"Below the board, each player has a line: chips, current bet, and their last action.",
+			"'>' marks the player to act (you), and 'D' marks the dealer button.",
+			"Your line also shows your hole cards and the best hand you have made so far.",
+			"In PLS7 you are dealt 3 hole cards and may use any number of them with the 5-card board.",
+		},
+		Game: g,
+		Quiz: Quiz{
+			Question:    "How much do you need to call?",
+			Choices:     []string{"1,000", "3,000", "4,000"},
+			Answer:      0,
+			Explanation: "CPU 1 bet 1,000 and you have nothing in yet, so calling costs 1,000. The pot of 3,000 already includes that bet.",
+		},
+	}, nil
+}
+
+func skipStraightLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "As Qd 5h", board: "Tc 8h 6s 2d", pot: 4000, betToCall: 2000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	quiz, err := rankQuiz(g, []poker.HandRank{poker.HighCard, poker.Straight, poker.SkipStraight},
+		"A-Q-T-8-6 skips exactly one rank between each card, which makes a skip straight.")
+	if err != nil {
+		return Lesson{}, err
+	}
+	return Lesson{
+		Title: "Skip straights",
+		Explanation: []string{
+			"A skip straight is five cards whose ranks go up by two each time, skipping one rank in between.",
+			"Examples are 2-4-6-8-T, 3-5-7-9-J, and the highest, 6-8-T-Q-A.",
+			"The Ace may also play low, as in A-3-5-7-9.",
+			"Look at your hole cards together with the board.",
+		},
+		Game: g,
+		Quiz: quiz,
+	}, nil
+}
+
+func skipStraightRankingLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "9s Jd Kh", board: "5c 7h Kc 2s 3d", pot: 6000, betToCall: 3000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	quiz, err := rankQuiz(g, []poker.HandRank{poker.OnePair, poker.Straight, poker.SkipStraight},
+		"5-7-9-J-K is a skip straight. It beats your pair of kings, and in PLS7 it also beats a regular straight.")
+	if err != nil {
+		return Lesson{}, err
+	}
+	return Lesson{
+		Title: "Where skip straights rank",
+		Explanation: []string{
+			"Skip straights are harder to make than straights, so they rank above them.",
+			"The full PLS7 order, from the top, is:",
+			"Royal Flush > Skip Straight Flush > Straight Flush > Four of a Kind > Full House",
+			"> Flush > Skip Straight > Straight > Three of a Kind > Two Pair > One Pair > High Card.",
+		},
+		Game: g,
+		Quiz: quiz,
+	}, nil
+}
+
+func lowHandLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "As 2d Kh", board: "3c 5h 7s Qd Jc", pot: 8000, betToCall: 4000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	_, low := poker.EvaluateHand(g.Players[0].Hand, g.CommunityCards, g.Rules)
+	answer := 1
+	if low != nil {
+		answer = 0
+	}
+	return Lesson{
+		Title: "The 7-or-better low",
+		Explanation: []string{
+			"PLS7 is a hi-lo game: the pot is split between the best high hand and the best low hand.",
+			fmt.Sprintf("A low hand is five different ranks of %d or lower. Aces count as low, and straights and flushes do not hurt.", rules.LowHand.MaxRank),
+			"The lowest hand wins the low half: 5-4-3-2-A is the best possible low.",
+			"If nobody has a qualifying low, the high hand scoops the whole pot.",
+		},
+		Game: g,
+		Quiz: Quiz{
+			Question:    "Do you have a qualifying low hand?",
+			Choices:     []string{"Yes", "No"},
+			Answer:      answer,
+			Explanation: "Your A-2 combine with the board's 3-5-7 for a 7-5-3-2-A low, so you are competing for both halves.",
+		},
+	}, nil
+}
+
+func counterfeitLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "As 2d 3h", board: "4c 6h Ks 3d", pot: 6000, betToCall: 3000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	you := g.Players[0]
+	flop, turn := g.CommunityCards[:3], g.CommunityCards[3]
+
+	// Let the engine find the counterfeit, as a replay would, against an
+	// opponent holding A-2.
+	opponent, err := poker.ParseCards("Ac 2c Qd")
+	if err != nil {
+		return Lesson{}, err
+	}
+	history := &engine.HandHistory{
+		Seats: []engine.SeatRecord{
+			{Name: you.Name, HoleCards: you.Hand},
+			{Name: g.Players[1].Name, HoleCards: opponent},
+		},
+		Board: g.CommunityCards,
+	}
+	var annotation *engine.HandAnnotation
+	for _, a := range engine.AnnotateHand(history, rules) {
+		if a.Kind == engine.AnnotationLowCounterfeited && a.Phase == engine.PhaseTurn {
+			annotation = &a
+		}
+	}
+	if annotation == nil {
+		return Lesson{}, fmt.Errorf("the %s does not counterfeit the low of %v on %v", turn, you.Hand, flop)
+	}
+
+	_, lowBefore := poker.EvaluateHand(you.Hand, flop, rules)
+	_, lowAfter := poker.EvaluateHand(you.Hand, g.CommunityCards, rules)
+	if lowBefore == nil || lowAfter == nil || !slices.Equal(lowBefore.HighValues, lowAfter.HighValues) {
+		return Lesson{}, fmt.Errorf("the %s changes the low of %v on %v from %v to %v", turn, you.Hand, flop, lowBefore, lowAfter)
+	}
+	return Lesson{
+		Title: "Counterfeited lows",
+		Explanation: []string{
+			"On the flop, your A-2-3 made a 6-4-3-2-A low with the 4 and 6 on the board.",
+			"The turn brought a 3, pairing one of the hole cards your low is made with.",
+			"When the board pairs a low card you hold, it gives that card to everyone: your low is counterfeited.",
+			"Replays point out the card that does it, like this:",
+			"  " + cli.FormatHandAnnotation(*annotation),
+		},
+		Game: g,
+		Quiz: Quiz{
+			Question:    "What did the 3 on the turn do to your low?",
+			Choices:     []string{"It made my low worse", "My low is the same, but an opponent with A-2 now ties it"},
+			Answer:      1,
+			Explanation: "You may use any of your hole cards, so your low stays 6-4-3-2-A. But the board's 3 lets any A-2 make the same low, and a tied low splits the low half.",
+		},
+	}, nil
+}
+
+func potLimitLesson(rules *poker.GameRules) (Lesson, error) {
+	g, err := newScenarioGame(rules, scenario{hole: "8s 8d 3h", board: "8c Kh 4d", pot: 3000, betToCall: 1000})
+	if err != nil {
+		return Lesson{}, err
+	}
+	_, maxRaise := g.CalculateBettingLimits()
+	choices := []string{"3,000", cli.FormatNumber(maxRaise), "All-in"}
+	return Lesson{
+		Title: "Pot-limit betting",
+		Explanation: []string{
+			"In pot-limit games the most you can raise is the size of the pot after you call.",
+			"First add your call to the pot; the result is how much you may raise on top of the call.",
+			"Pot-limit keeps big hands from being bet all-in early, so draws like skip straights stay in play.",
+		},
+		Game: g,
+		Quiz: Quiz{
+			Question:    "CPU 1 bet 1,000 into a 2,000 pot. What is the most you can raise to?",
+			Choices:     choices,
+			Answer:      1,
+			Explanation: fmt.Sprintf("Calling 1,000 makes the pot 4,000, so you may raise 4,000 on top of the call: a raise to %s.", cli.FormatNumber(maxRaise)),
+		},
+	}, nil
+}
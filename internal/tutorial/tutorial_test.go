@@ -0,0 +1,62 @@
+package tutorial
+
+import (
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestLessons(t *testing.T) {
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7.yml")
+	if err != nil {
+		t.Fatalf("Failed to load PLS7 rules: %v", err)
+	}
+
+	lessons, err := Lessons(rules)
+	if err != nil {
+		t.Fatalf("Expected every lesson to be built, but got: %v", err)
+	}
+	if len(lessons) != 6 {
+		t.Fatalf("Expected 6 lessons, but got %d", len(lessons))
+	}
+
+	for _, lesson := range lessons {
+		quiz := lesson.Quiz
+		if quiz.Answer < 0 || quiz.Answer >= len(quiz.Choices) {
+			t.Errorf("%s: answer %d is not one of %d choices", lesson.Title, quiz.Answer, len(quiz.Choices))
+		}
+		if lesson.Game.CurrentPlayer().Name != "YOU" {
+			t.Errorf("%s: expected the human to act", lesson.Title)
+		}
+	}
+
+	// Spot-check answers that come from the engine rather than from the script.
+	if got := lessons[1].Quiz.Choices[lessons[1].Quiz.Answer]; got != "Skip Straight" {
+		t.Errorf("Expected the skip straight lesson's answer to be Skip Straight, but got %s", got)
+	}
+	if got := lessons[2].Quiz.Choices[lessons[2].Quiz.Answer]; got != "Skip Straight" {
+		t.Errorf("Expected the ranking lesson's answer to be Skip Straight, but got %s", got)
+	}
+	if got := lessons[3].Quiz.Choices[lessons[3].Quiz.Answer]; got != "Yes" {
+		t.Errorf("Expected the low hand lesson's answer to be Yes, but got %s", got)
+	}
+	if got := lessons[4].Quiz.Answer; got != 1 {
+		t.Errorf("Expected the counterfeit lesson's answer to be that the low ties, but got choice %d", got)
+	}
+	if got := lessons[5].Quiz.Choices[lessons[5].Quiz.Answer]; got != "5,000" {
+		t.Errorf("Expected a pot-limit maximum raise to 5,000, but got %s", got)
+	}
+}
+
+func TestNewScenarioGame_InvalidCards(t *testing.T) {
+	rules, err := config.LoadGameRulesFromFile("../../rules/pls7.yml")
+	if err != nil {
+		t.Fatalf("Failed to load PLS7 rules: %v", err)
+	}
+	_, err = newScenarioGame(rules, scenario{hole: "As Kd 7c", board: "Qh 7h Zz"})
+	var parseErr *poker.CardParseError
+	if !errors.As(err, &parseErr) || parseErr.Token != "Zz" {
+		t.Errorf("Expected a *CardParseError for Zz, but got %v", err)
+	}
+}
@@ -1,7 +1,12 @@
 package util
 
 import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -26,3 +31,73 @@ func InitLogger(isDevMode bool) {
 		logrus.SetFormatter(&logrus.TextFormatter{})
 	}
 }
+
+// InitPrivateLogger initializes the logger in dev mode, like InitLogger, but
+// passes every message printed to the console through redact. Each entry is
+// also written in full to debugLog, base64-encoded one per line so that it
+// cannot be read at a glance; DecodeDebugLog reads it back.
+func InitPrivateLogger(redact func(string) string, debugLog io.Writer) {
+	InitLogger(true)
+	logrus.SetFormatter(&redactingFormatter{
+		Formatter: logrus.StandardLogger().Formatter,
+		redact:    redact,
+	})
+	logrus.AddHook(&debugLogHook{
+		w:         debugLog,
+		formatter: &logrus.TextFormatter{FullTimestamp: true, DisableColors: true},
+	})
+}
+
+// redactingFormatter formats entries with the wrapped formatter after
+// redacting their message.
+type redactingFormatter struct {
+	logrus.Formatter
+	redact func(string) string
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	redacted := *entry
+	redacted.Message = f.redact(entry.Message)
+	return f.Formatter.Format(&redacted)
+}
+
+// debugLogHook writes every entry, unredacted, to the debug log. Hooks run
+// before the entry is formatted for the console.
+type debugLogHook struct {
+	w         io.Writer
+	formatter logrus.Formatter
+}
+
+func (h *debugLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *debugLogHook) Fire(entry *logrus.Entry) error {
+	line, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(h.w, base64.StdEncoding.EncodeToString(line))
+	return err
+}
+
+// DecodeDebugLog copies a debug log written by InitPrivateLogger from r to w
+// as plain text.
+func DecodeDebugLog(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(line)
+		if err != nil {
+			return fmt.Errorf("line %d of the debug log is corrupt: %w", lineNumber, err)
+		}
+		if _, err := w.Write(decoded); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
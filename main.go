@@ -1,6 +1,6 @@
 package main
 
-import "pls7-cli/cmd"
+import "github.com/philipjkim/pls7-cli/cmd"
 
 func main() {
 	cmd.Execute()
@@ -0,0 +1,175 @@
+package config
+
+import (
+	_ "embed"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// builtInProfiles is profiles.yml, the CPU personalities the game ships with
+// and the lineups they are played in.
+//
+//go:embed profiles.yml
+var builtInProfiles []byte
+
+// AIProfile defines the behavioral characteristics and decision-making parameters
+// for a CPU-controlled player. It allows for creating different "personalities"
+// for AI opponents, from tight and passive to loose and aggressive. How well a
+// profile is played depends on the game's difficulty preset, which gates equity
+// simulation and opponent modeling and scales BluffingFrequency.
+type AIProfile struct {
+	// Name is the identifier for the profile, e.g., "Tight-Aggressive".
+	Name string `yaml:"name"`
+	// PlayHandThreshold is the minimum hand strength score required for the AI to
+	// consider playing a hand pre-flop. A higher value means the AI is "tighter"
+	// and plays fewer hands.
+	PlayHandThreshold float64 `yaml:"play_hand_threshold"`
+	// RaiseHandThreshold is the minimum hand strength score required for the AI
+	// to open with a raise pre-flop.
+	RaiseHandThreshold float64 `yaml:"raise_hand_threshold"`
+	// BluffingFrequency is the probability (0.0 to 1.0) that the AI will attempt
+	// a bluff with a weak hand.
+	BluffingFrequency float64 `yaml:"bluffing_frequency"`
+	// AggressionFactor is the probability (0.0 to 1.0) that the AI will choose
+	// to bet or raise instead of check or call when it has a reasonably strong hand.
+	AggressionFactor float64 `yaml:"aggression_factor"`
+	// MinRaiseMultiplier is the minimum multiplier for a raise amount, e.g., 2.0x the bet.
+	MinRaiseMultiplier float64 `yaml:"min_raise_multiplier"`
+	// MaxRaiseMultiplier is the maximum multiplier for a raise amount.
+	MaxRaiseMultiplier float64 `yaml:"max_raise_multiplier"`
+	// OpenSizeBB is the preferred size of a pre-flop open raise, in big blinds.
+	// The actual amount is clamped to the legal range of the betting structure,
+	// so a pot-limit game never opens above a pot-sized raise (3.5bb).
+	OpenSizeBB float64 `yaml:"open_size_bb"`
+}
+
+// Validate checks that the profile can be played. It returns an error
+// describing the first problem found.
+func (p *AIProfile) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("a profile needs a name")
+	}
+	if p.PlayHandThreshold < 0 || p.RaiseHandThreshold < 0 {
+		return fmt.Errorf("profile %q: hand thresholds must not be negative, got %g and %g", p.Name, p.PlayHandThreshold, p.RaiseHandThreshold)
+	}
+	for name, freq := range map[string]float64{"bluffing frequency": p.BluffingFrequency, "aggression factor": p.AggressionFactor} {
+		if freq < 0 || freq > 1 {
+			return fmt.Errorf("profile %q: the %s must be between 0 and 1, got %g", p.Name, name, freq)
+		}
+	}
+	if p.MinRaiseMultiplier < 1 || p.MaxRaiseMultiplier < p.MinRaiseMultiplier {
+		return fmt.Errorf("profile %q: the raise multipliers must satisfy 1 <= min <= max, got %g and %g", p.Name, p.MinRaiseMultiplier, p.MaxRaiseMultiplier)
+	}
+	if p.OpenSizeBB <= 0 {
+		return fmt.Errorf("profile %q: the open size must be positive, got %g", p.Name, p.OpenSizeBB)
+	}
+	return nil
+}
+
+// AIProfileSet is a set of CPU personalities and, for each difficulty, the
+// lineup of profiles the CPUs play, loaded from a profiles file so that new
+// personalities can be tried without recompiling.
+type AIProfileSet struct {
+	// Profiles are the personalities the lineups may name.
+	Profiles []AIProfile `yaml:"profiles"`
+	// Lineups holds, keyed by difficulty ("easy", "medium", "hard"), the
+	// names of the profiles given to the CPUs in seat order. A lineup shorter
+	// than the number of CPUs starts over from its first profile.
+	Lineups map[string][]string `yaml:"lineups"`
+}
+
+// AIProfileDifficulties are the difficulties every profile set needs a lineup for.
+var AIProfileDifficulties = []string{"easy", "medium", "hard"}
+
+// DefaultAIProfiles returns the built-in profiles, and the lineups the CPUs
+// play them in at each difficulty, read from the embedded profiles.yml. Each
+// call returns a new set, which the caller may change.
+func DefaultAIProfiles() *AIProfileSet {
+	set, err := parseAIProfiles(builtInProfiles)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in AI profiles: %v", err))
+	}
+	return set
+}
+
+// parseAIProfiles unmarshals a set of CPU profiles and their lineups from
+// YAML and checks it.
+func parseAIProfiles(data []byte) (*AIProfileSet, error) {
+	var set AIProfileSet
+	if err := yaml.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	if err := set.Validate(); err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// Profile returns the profile with the given name, or nil.
+func (s *AIProfileSet) Profile(name string) *AIProfile {
+	for i := range s.Profiles {
+		if s.Profiles[i].Name == name {
+			return &s.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// Lineup returns the names of the profiles the given number of CPUs play at
+// the difficulty.
+func (s *AIProfileSet) Lineup(difficulty string, numCPUs int) []string {
+	lineup := s.Lineups[difficulty]
+	if len(lineup) == 0 {
+		return nil
+	}
+	names := make([]string, numCPUs)
+	for i := range names {
+		names[i] = lineup[i%len(lineup)]
+	}
+	return names
+}
+
+// Validate checks that the set can be played: every profile is valid and
+// named uniquely, and every difficulty has a lineup of known profiles. It
+// returns an error describing the first problem found.
+func (s *AIProfileSet) Validate() error {
+	seen := make(map[string]bool, len(s.Profiles))
+	for i := range s.Profiles {
+		p := &s.Profiles[i]
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("profile %q is defined more than once", p.Name)
+		}
+		seen[p.Name] = true
+	}
+	for _, difficulty := range AIProfileDifficulties {
+		lineup := s.Lineups[difficulty]
+		if len(lineup) == 0 {
+			return fmt.Errorf("no lineup for the %s difficulty", difficulty)
+		}
+		for _, name := range lineup {
+			if !seen[name] {
+				return fmt.Errorf("the %s lineup names unknown profile %q", difficulty, name)
+			}
+		}
+	}
+	for difficulty := range s.Lineups {
+		if !isAIProfileDifficulty(difficulty) {
+			return fmt.Errorf("unknown difficulty %q in the lineups (available: %v)", difficulty, AIProfileDifficulties)
+		}
+	}
+	return nil
+}
+
+// isAIProfileDifficulty reports whether a lineup key names a difficulty.
+func isAIProfileDifficulty(name string) bool {
+	for _, d := range AIProfileDifficulties {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
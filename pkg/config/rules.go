@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	os "os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadGameRulesFromFile reads a YAML file from the given path and returns a GameRules struct.
+// The AI tuning pack the rules name, if any, is loaded from the same directory.
+// Rules that do not pass GameRules.Validate, e.g. because they name an unknown
+// evaluator, are rejected.
+func LoadGameRulesFromFile(filePath string) (*poker.GameRules, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules poker.GameRules
+	err = yaml.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if rules.AITuningFile != "" {
+		tuning, err := LoadAITuningFromFile(filepath.Join(filepath.Dir(filePath), rules.AITuningFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the AI tuning pack: %w", err)
+		}
+		rules.AITuning = tuning
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rules in %s: %w", filePath, err)
+	}
+
+	return &rules, nil
+}
+
+// LoadAITuningFromFile reads an AI tuning pack from a YAML file and checks it.
+func LoadAITuningFromFile(filePath string) (*poker.AITuning, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var tuning poker.AITuning
+	if err := yaml.Unmarshal(data, &tuning); err != nil {
+		return nil, err
+	}
+	if err := tuning.Validate(); err != nil {
+		return nil, err
+	}
+	return &tuning, nil
+}
+
+// LoadAIProfilesFromFile reads a set of CPU profiles and their lineups from a
+// YAML file and checks it.
+func LoadAIProfilesFromFile(filePath string) (*AIProfileSet, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	set, err := parseAIProfiles(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AI profiles in %s: %w", filePath, err)
+	}
+	return set, nil
+}
+
+// LoadGameRulesFromBytes unmarshals a byte slice into a GameRules struct.
+// There is no file to resolve an AI tuning pack from, so the CPUs play the
+// rules by generic heuristics. As with LoadGameRulesFromFile, the rules must
+// pass GameRules.Validate.
+func LoadGameRulesFromBytes(data []byte) (*poker.GameRules, error) {
+	var rules poker.GameRules
+	err := yaml.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, err
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+	return &rules, nil
+}
+
+// LoadGameRulesFromOptions loads game rules from a YAML string by option value.
+// - Available ruleStr: "pls", "pls7"
+func LoadGameRulesFromOptions(ruleStr string) (*poker.GameRules, error) {
+	filePath := fmt.Sprintf("rules/%s.yml", ruleStr)
+	return LoadGameRulesFromFile(filePath)
+}
@@ -0,0 +1,320 @@
+package config
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestLoadGameRulesFromFile tests the loading and parsing of a game rule YAML file.
+func TestLoadGameRulesFromFile(t *testing.T) {
+	// Create a temporary directory and a dummy YAML file for the test.
+	tempDir := t.TempDir()
+	rulesDir := filepath.Join(tempDir, "rules")
+	err := os.Mkdir(rulesDir, 0755)
+	if err != nil {
+		t.Fatalf("Failed to create temp rules dir: %v", err)
+	}
+
+	yamlContent := `
+name: "Pot-Limit Sampyeong 7-or-Better"
+abbreviation: "PLS7"
+betting_limit: "pot_limit"
+chip_unit: 100
+hole_cards:
+  count: 3
+  use_constraint: "any"
+  use_count: 0
+hand_rankings:
+  use_standard_rankings: false
+  custom_rankings:
+    - name: "skip_straight_flush"
+      insert_after_rank: "royal_flush"
+    - name: "skip_straight"
+      insert_after_rank: "flush"
+low_hand:
+  enabled: true
+  max_rank: 7
+`
+	filePath := filepath.Join(rulesDir, "pls7.yml")
+	err = os.WriteFile(filePath, []byte(yamlContent), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write temp yaml file: %v", err)
+	}
+
+	// --- This is the function we are testing ---
+	rules, err := LoadGameRulesFromFile(filePath)
+
+	// ---
+
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	// --- Assertions ---
+	if rules.Name != "Pot-Limit Sampyeong 7-or-Better" {
+		t.Errorf("Expected name to be 'Pot-Limit Sampyeong 7-or-Better', but got '%s'", rules.Name)
+	}
+	if rules.Abbreviation != "PLS7" {
+		t.Errorf("Expected abbreviation to be 'PLS7', but got '%s'", rules.Abbreviation)
+	}
+	if rules.BettingLimit != "pot_limit" {
+		t.Errorf("Expected betting_limit to be 'pot_limit', but got '%s'", rules.BettingLimit)
+	}
+	if rules.ChipUnit != 100 {
+		t.Errorf("Expected chip_unit to be 100, but got %d", rules.ChipUnit)
+	}
+	if rules.HoleCards.Count != 3 {
+		t.Errorf("Expected hole_cards.count to be 3, but got %d", rules.HoleCards.Count)
+	}
+	if rules.HoleCards.UseConstraint != "any" {
+		t.Errorf("Expected hole_cards.use_constraint to be 'any', but got '%s'", rules.HoleCards.UseConstraint)
+	}
+	if rules.HandRankings.UseStandardRankings != false {
+		t.Errorf("Expected use_standard_rankings to be false, but got %t", rules.HandRankings.UseStandardRankings)
+	}
+	if len(rules.HandRankings.CustomRankings) != 2 {
+		t.Errorf("Expected 2 custom hand rankings, but got %d", len(rules.HandRankings.CustomRankings))
+	}
+	if rules.HandRankings.CustomRankings[0].Name != "skip_straight_flush" {
+		t.Errorf("Expected first custom ranking name to be 'skip_straight_flush', but got '%s'", rules.HandRankings.CustomRankings[0].Name)
+	}
+	if rules.HandRankings.CustomRankings[0].InsertAfterRank != "royal_flush" {
+		t.Errorf("Expected first custom ranking insert_after_rank to be 'royal_flush', but got '%s'", rules.HandRankings.CustomRankings[0].InsertAfterRank)
+	}
+	if rules.HandRankings.CustomRankings[1].Name != "skip_straight" {
+		t.Errorf("Expected second custom ranking name to be 'skip_straight', but got '%s'", rules.HandRankings.CustomRankings[1].Name)
+	}
+	if rules.HandRankings.CustomRankings[1].InsertAfterRank != "flush" {
+		t.Errorf("Expected second custom ranking insert_after_rank to be 'flush', but got '%s'", rules.HandRankings.CustomRankings[1].InsertAfterRank)
+	}
+	if !rules.LowHand.Enabled {
+		t.Error("Expected low_hand.enabled to be true, but got false")
+	}
+	if rules.LowHand.MaxRank != 7 {
+		t.Errorf("Expected low_hand.max_rank to be 7, but got %d", rules.LowHand.MaxRank)
+	}
+}
+
+// TestLoadGameRulesFromFile_AITuning tests loading the AI tuning pack named by
+// a rules file, relative to it.
+func TestLoadGameRulesFromFile_AITuning(t *testing.T) {
+	rulesDir := filepath.Join(t.TempDir(), "rules")
+	if err := os.MkdirAll(filepath.Join(rulesDir, "ai"), 0755); err != nil {
+		t.Fatalf("Failed to create temp rules dir: %v", err)
+	}
+	rulesYAML := `
+name: "Pot-Limit Omaha 8-or-Better"
+abbreviation: "PLO8"
+betting_limit: "pot_limit"
+hole_cards:
+  count: 4
+  use_constraint: "exact"
+  use_count: 2
+low_hand:
+  enabled: true
+  max_rank: 8
+ai_tuning: "ai/plo8.yml"
+`
+	rulesPath := filepath.Join(rulesDir, "plo8.yml")
+	if err := os.WriteFile(rulesPath, []byte(rulesYAML), 0644); err != nil {
+		t.Fatalf("Failed to write temp yaml file: %v", err)
+	}
+	tuningPath := filepath.Join(rulesDir, "ai", "plo8.yml")
+
+	// A missing pack is an error, as the rules name it.
+	if _, err := LoadGameRulesFromFile(rulesPath); err == nil {
+		t.Error("Expected an error for a missing AI tuning pack, but got nil")
+	}
+
+	tuningYAML := `
+starting_hands:
+  rank_points: { A: 10, K: 8 }
+  counted_cards: 3
+  double_suited_bonus: 4
+aggression:
+  river: 0.8
+low_hand:
+  made_low_bonus: 1
+`
+	if err := os.WriteFile(tuningPath, []byte(tuningYAML), 0644); err != nil {
+		t.Fatalf("Failed to write temp yaml file: %v", err)
+	}
+	rules, err := LoadGameRulesFromFile(rulesPath)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	tuning := rules.AITuning
+	if tuning == nil {
+		t.Fatal("Expected the AI tuning pack to be loaded, but got nil")
+	}
+	if tuning.StartingHands.RankPoints["A"] != 10 || tuning.StartingHands.CountedCards != 3 || tuning.StartingHands.DoubleSuitedBonus != 4 {
+		t.Errorf("Unexpected starting hand table: %+v", tuning.StartingHands)
+	}
+	if tuning.Aggression.River != 0.8 || tuning.LowHand.MadeLowBonus != 1 {
+		t.Errorf("Unexpected aggression curve %+v or low hand weights %+v", tuning.Aggression, tuning.LowHand)
+	}
+
+	// An invalid pack is rejected.
+	if err := os.WriteFile(tuningPath, []byte("starting_hands:\n  rank_points: { X: 10 }\n"), 0644); err != nil {
+		t.Fatalf("Failed to write temp yaml file: %v", err)
+	}
+	if _, err := LoadGameRulesFromFile(rulesPath); err == nil {
+		t.Error("Expected an error for an invalid AI tuning pack, but got nil")
+	}
+}
+
+// TestLoadGameRulesFromFile_Evaluator tests that rules naming an unknown
+// evaluation backend fail to load.
+func TestLoadGameRulesFromFile_Evaluator(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "nlh.yml")
+	rulesYAML := `
+name: "No-Limit Texas Hold'em"
+abbreviation: "NLH"
+betting_limit: "no_limit"
+hole_cards:
+  count: 2
+  use_constraint: "any"
+hand_rankings:
+  use_standard_rankings: true
+evaluator: "%s"
+`
+	for evaluator, wantErr := range map[string]bool{"lookup_nlh": false, "generic": false, "short_deck": true} {
+		if err := os.WriteFile(rulesPath, []byte(fmt.Sprintf(rulesYAML, evaluator)), 0644); err != nil {
+			t.Fatalf("Failed to write temp yaml file: %v", err)
+		}
+		rules, err := LoadGameRulesFromFile(rulesPath)
+		switch {
+		case wantErr && err == nil:
+			t.Errorf("Expected an error for evaluator %q, but got nil", evaluator)
+		case !wantErr && err != nil:
+			t.Errorf("Expected no error for evaluator %q, but got: %v", evaluator, err)
+		case !wantErr && rules.Evaluator != evaluator:
+			t.Errorf("Expected evaluator %q, but got %q", evaluator, rules.Evaluator)
+		}
+	}
+}
+
+func TestLoadGameRulesFromFile_Ante(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "nlh.yml")
+	rulesYAML := `
+name: "No-Limit Texas Hold'em"
+abbreviation: "NLH"
+betting_limit: "no_limit"
+chip_unit: 100
+hole_cards:
+  count: 2
+  use_constraint: "any"
+hand_rankings:
+  use_standard_rankings: true
+ante: %d
+`
+	for ante, wantErr := range map[int]bool{0: false, 200: false, 250: true, -100: true} {
+		if err := os.WriteFile(rulesPath, []byte(fmt.Sprintf(rulesYAML, ante)), 0644); err != nil {
+			t.Fatalf("Failed to write temp yaml file: %v", err)
+		}
+		rules, err := LoadGameRulesFromFile(rulesPath)
+		switch {
+		case wantErr && err == nil:
+			t.Errorf("Expected an error for ante %d, but got nil", ante)
+		case !wantErr && err != nil:
+			t.Errorf("Expected no error for ante %d, but got: %v", ante, err)
+		case !wantErr && rules.Ante != ante:
+			t.Errorf("Expected ante %d, but got %d", ante, rules.Ante)
+		}
+	}
+}
+
+func TestLoadGameRulesFromFile_MustBet(t *testing.T) {
+	rulesPath := filepath.Join(t.TempDir(), "nlh.yml")
+	rulesYAML := `
+name: "No-Limit Texas Hold'em"
+abbreviation: "NLH"
+betting_limit: "no_limit"
+hole_cards:
+  count: 2
+  use_constraint: "any"
+hand_rankings:
+  use_standard_rankings: true
+must_bet:
+  streets: ["flop", "river"]
+  player: "previous_winner"
+`
+	if err := os.WriteFile(rulesPath, []byte(rulesYAML), 0644); err != nil {
+		t.Fatalf("Failed to write temp yaml file: %v", err)
+	}
+	rules, err := LoadGameRulesFromFile(rulesPath)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if rules.MustBet == nil || rules.MustBet.Player != "previous_winner" {
+		t.Fatalf("Expected the must-bet rule to bind the previous winner, but got %+v", rules.MustBet)
+	}
+	for street, want := range map[poker.Street]bool{poker.StreetFlop: true, poker.StreetTurn: false, poker.StreetRiver: true} {
+		if got := rules.MustBet.Applies(street); got != want {
+			t.Errorf("Expected the rule to apply on the %s %v, but got %v", street, want, got)
+		}
+	}
+}
+
+// TestDefaultAIProfiles tests that the built-in profiles load, with a lineup
+// for every difficulty, and that each call returns a set of its own.
+func TestDefaultAIProfiles(t *testing.T) {
+	set := DefaultAIProfiles()
+	if len(set.Profiles) != 4 || set.Profile("Tight-Aggressive") == nil {
+		t.Errorf("Expected the four built-in profiles, but got %+v", set.Profiles)
+	}
+	for _, difficulty := range AIProfileDifficulties {
+		if lineup := set.Lineup(difficulty, 5); len(lineup) != 5 {
+			t.Errorf("Expected a lineup of 5 CPUs for the %s difficulty, but got %v", difficulty, lineup)
+		}
+	}
+	set.Profile("Tight-Aggressive").OpenSizeBB = 10
+	if DefaultAIProfiles().Profile("Tight-Aggressive").OpenSizeBB == 10 {
+		t.Error("Expected a change to one set of built-in profiles not to show in the next")
+	}
+}
+
+// TestLoadAIProfilesFromFile tests that a profiles file loads, and that
+// invalid profiles are rejected.
+func TestLoadAIProfilesFromFile(t *testing.T) {
+	profile := `
+profiles:
+  - name: Maniac
+    play_hand_threshold: 5
+    raise_hand_threshold: 10
+    bluffing_frequency: %s
+    aggression_factor: 1
+    min_raise_multiplier: 3
+    max_raise_multiplier: 5
+    open_size_bb: 4
+lineups:
+  easy: [Maniac]
+  medium: [Maniac]
+  hard: [%s]
+`
+	for _, tc := range []struct {
+		name      string
+		bluff     string
+		hardNames string
+		valid     bool
+	}{
+		{"valid", "0.5", "Maniac", true},
+		{"bluffing frequency above 1", "1.5", "Maniac", false},
+		{"unknown profile in a lineup", "0.5", "Nit", false},
+	} {
+		path := filepath.Join(t.TempDir(), "profiles.yml")
+		if err := os.WriteFile(path, []byte(fmt.Sprintf(profile, tc.bluff, tc.hardNames)), 0644); err != nil {
+			t.Fatalf("Failed to write temp yaml file: %v", err)
+		}
+		set, err := LoadAIProfilesFromFile(path)
+		if tc.valid && (err != nil || set.Profile("Maniac").OpenSizeBB != 4) {
+			t.Errorf("%s: expected the profiles to load, but got %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected an error, but got nil", tc.name)
+		}
+	}
+}
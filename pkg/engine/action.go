@@ -10,17 +10,37 @@ type ActionType int
 
 // ActionType constants represent the set of possible actions a player can take.
 const (
-	ActionFold  ActionType = iota // ActionFold signifies that the player forfeits their hand and any claim to the pot.
-	ActionCheck                   // ActionCheck passes the action to the next player without betting, only possible when there is no open bet or raise.
-	ActionCall                    // ActionCall matches the current bet amount.
-	ActionBet                     // ActionBet is the first bet made in a betting round.
-	ActionRaise                   // ActionRaise increases the size of the current bet.
+	ActionFold        ActionType = iota // ActionFold signifies that the player forfeits their hand and any claim to the pot.
+	ActionCheck                         // ActionCheck passes the action to the next player without betting, only possible when there is no open bet or raise.
+	ActionCall                          // ActionCall matches the current bet amount.
+	ActionBet                           // ActionBet is the first bet made in a betting round.
+	ActionRaise                         // ActionRaise increases the size of the current bet.
+	ActionShowPartial                   // ActionShowPartial reveals a single hole card after the hand is over, e.g. when mucking or after winning uncontested.
+	ActionFastForward                   // ActionFastForward reports that the hand was fast-forwarded to a later street in dev mode instead of acting; it is not processed.
 )
 
 // String returns the string representation of an ActionType (e.g., "Fold", "Check").
 // It implements the fmt.Stringer interface.
 func (at ActionType) String() string {
-	return []string{"Fold", "Check", "Call", "Bet", "Raise"}[at]
+	return []string{"Fold", "Check", "Call", "Bet", "Raise", "Show Partial", "Fast Forward"}[at]
+}
+
+// ActionDetail distinguishes the pre-flop calls and checks that have their
+// own names around the blinds from ordinary calls and checks.
+type ActionDetail int
+
+// ActionDetail constants. A player's action has one of these details only
+// pre-flop, before anyone has raised the big blind.
+const (
+	ActionDetailNone     ActionDetail = iota // ActionDetailNone marks an ordinary action.
+	ActionDetailLimp                         // ActionDetailLimp is a call of the big blind from outside the blinds.
+	ActionDetailComplete                     // ActionDetailComplete is the small blind calling the rest of the big blind.
+	ActionDetailOption                       // ActionDetailOption is the big blind checking its option.
+)
+
+// String returns the string representation of an ActionDetail (e.g., "Complete").
+func (d ActionDetail) String() string {
+	return []string{"", "Limp", "Complete", "Check Option"}[d]
 }
 
 // PlayerAction represents an action taken by a player, including the type of action
@@ -28,9 +48,14 @@ func (at ActionType) String() string {
 type PlayerAction struct {
 	// Type is the kind of action performed (e.g., Fold, Call, Raise).
 	Type ActionType
-	// Amount is the size of the bet or raise. It is only applicable for
-	// ActionBet and ActionRaise actions. For other actions, it should be 0.
+	// Amount is the total the player bets or raises to this round, including
+	// any chips already in front of them, e.g. 3000 for the big blind of 1000
+	// betting 2000 more on its option. It is only applicable for ActionBet and
+	// ActionRaise actions. For other actions, it should be 0.
 	Amount int
+	// CardIndex is the zero-based index of the hole card to reveal. It is only
+	// applicable for ActionShowPartial actions.
+	CardIndex int
 }
 
 // ActionProvider is a crucial interface that decouples the game engine from the
@@ -1,10 +1,11 @@
 package engine
 
 import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 	"math/rand"
-	"pls7-cli/pkg/poker"
 	"sort"
-	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 // byRank is a helper type that implements the sort.Interface for a slice of
@@ -15,70 +16,82 @@ func (a byRank) Len() int           { return len(a) }
 func (a byRank) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byRank) Less(i, j int) bool { return a[i] > a[j] } // Sort descending
 
-// aiProfiles contains a set of predefined AI personalities that dictate how a CPU
-// player behaves. Each profile has different thresholds for playing, raising,
-// and bluffing, creating varied opponent styles.
-var aiProfiles = map[string]AIProfile{
-	"Tight-Aggressive": {
-		Name:               "Tight-Aggressive",
-		PlayHandThreshold:  20,   // Plays only the top 20% of starting hands.
-		RaiseHandThreshold: 25,   // Raises with the top 15% of hands.
-		BluffingFrequency:  0.15, // Bluffs occasionally.
-		AggressionFactor:   0.7,  // Highly likely to bet or raise with strong hands.
-		MinRaiseMultiplier: 2.5,
-		MaxRaiseMultiplier: 4.0,
-	},
-	"Loose-Aggressive": {
-		Name:               "Loose-Aggressive",
-		PlayHandThreshold:  10,   // Plays a wide range of hands (top 40%).
-		RaiseHandThreshold: 20,   // Raises often.
-		BluffingFrequency:  0.35, // Bluffs frequently.
-		AggressionFactor:   0.9,  // Very aggressive.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 3.5,
-	},
-	"Tight-Passive": {
-		Name:               "Tight-Passive",
-		PlayHandThreshold:  22,   // Very selective with starting hands.
-		RaiseHandThreshold: 28,   // Rarely raises, only with premium hands.
-		BluffingFrequency:  0.05, // Almost never bluffs.
-		AggressionFactor:   0.3,  // Prefers to call rather than bet or raise.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 2.5,
-	},
-	"Loose-Passive": {
-		Name:               "Loose-Passive",
-		PlayHandThreshold:  8,    // Plays many hands (calling station).
-		RaiseHandThreshold: 24,   // Rarely raises.
-		BluffingFrequency:  0.10, // Bluffs infrequently.
-		AggressionFactor:   0.2,  // Very passive, calls often, folds to aggression.
-		MinRaiseMultiplier: 2.0,
-		MaxRaiseMultiplier: 3.0,
-	},
-}
-
 // GetCPUAction determines the action for an AI-controlled player based on their
 // assigned profile and the current game state. This method implements the
 // ActionProvider interface for CPU players.
 // The logic is divided into pre-flop and post-flop stages.
 func (g *Game) GetCPUAction(player *Player, r *rand.Rand) PlayerAction {
+	return g.fitStack(player, g.fitCommitment(player, g.fitFixedLimit(g.fitMove(player, g.decideCPUAction(player, r)))))
+}
+
+// fitMove turns a CPU's action into the legal one it stands for: a call or
+// raise with no bet to call is a check or bet, and a bet facing one is a raise.
+// A check the must-bet rule does not allow is the smallest bet. A bet or raise
+// is then sized within the betting limits.
+func (g *Game) fitMove(player *Player, action PlayerAction) PlayerAction {
+	facingBet := g.BetToCall > player.CurrentBet
+	if (action.Type == ActionCall || action.Type == ActionCheck) && !facingBet && g.mustBet(player) {
+		action = PlayerAction{Type: ActionBet}
+	}
+	switch {
+	case action.Type == ActionCall && !facingBet:
+		return PlayerAction{Type: ActionCheck}
+	case action.Type == ActionRaise && !facingBet:
+		action.Type = ActionBet
+	case action.Type == ActionBet && facingBet:
+		action.Type = ActionRaise
+	}
+	if action.Type == ActionBet || action.Type == ActionRaise {
+		action.Amount = g.clampRaiseAmount(action.Amount)
+	}
+	return action
+}
+
+// fitStack limits a CPU's bet or raise to its stack. A CPU whose stack does
+// not cover more than the bet to call calls all-in instead, since a raise
+// below the bet to call would lower it for everyone.
+func (g *Game) fitStack(player *Player, action PlayerAction) PlayerAction {
+	if action.Type != ActionBet && action.Type != ActionRaise {
+		return action
+	}
+	allIn := player.Chips + player.CurrentBet
+	if allIn <= g.BetToCall {
+		return PlayerAction{Type: ActionCall}
+	}
+	action.Amount = min(action.Amount, allIn)
+	return action
+}
+
+// decideCPUAction is GetCPUAction before the action is fitted to a fixed
+// limit.
+func (g *Game) decideCPUAction(player *Player, r *rand.Rand) PlayerAction {
 	// First, evaluate the strength of the player's hand.
 	strength := g.handEvaluator(g, player)
 	canCheck := player.CurrentBet == g.BetToCall
 
-	// Simulate thinking time for a more realistic game pace.
-	time.Sleep(g.CPUThinkTime())
-
 	// --- Pre-Flop Logic ---
 	// Based on a simplified hand strength score.
 	if g.Phase == PhasePreFlop {
+		// Both thresholds widen in late position and tighten in early
+		// position and with more players left to act. Between the blinds
+		// alone, they widen further.
+		playScale := g.positionalThresholdScale(player)
+		raiseScale := playScale
+		if g.blindVersusBlind(player) {
+			playScale, raiseScale = blindVersusBlindScales(player.Profile)
+		}
 		// Fold if hand strength is below the profile's play threshold.
-		if strength < player.Profile.PlayHandThreshold {
+		if strength < g.adjustedPlayHandThreshold(player)*playScale {
 			return PlayerAction{Type: ActionFold}
 		}
 		// Raise if hand strength is above the profile's raise threshold.
-		if strength >= player.Profile.RaiseHandThreshold {
-			return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
+		if strength >= player.Profile.RaiseHandThreshold*raiseScale {
+			return PlayerAction{Type: ActionRaise, Amount: g.preFlopRaiseAmount(player, r)}
+		}
+		// Flat-calling a raise in front of a frequent squeezer invites a
+		// re-raise that folds out the call, so give up marginal hands instead.
+		if g.BetToCall > g.BigBlind && g.humanSqueezesBehind() {
+			return PlayerAction{Type: ActionFold}
 		}
 		// Otherwise, just call.
 		return PlayerAction{Type: ActionCall}
@@ -89,34 +102,52 @@ func (g *Game) GetCPUAction(player *Player, r *rand.Rand) PlayerAction {
 
 	// 1. Bluffing Logic: Decide whether to bluff based on profile frequency.
 	// A bluff is only attempted with a weak hand (less than OnePair).
-	isBluffing := r.Float64() < player.Profile.BluffingFrequency
+	isBluffing := r.Float64() < g.readBluffingFrequency(player, g.adjustedBluffingFrequency(player))
 	if isBluffing && strength < float64(poker.OnePair) {
 		if canCheck {
 			// A "probe" bet when checked to.
-			return PlayerAction{Type: ActionBet, Amount: g.Pot / 2}
+			return PlayerAction{Type: ActionBet, Amount: g.RoundToChipUnit(g.Pot / 2)}
 		}
 		// A bluff raise.
-		return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
+		return PlayerAction{Type: ActionRaise, Amount: g.raiseAmount(player, r)}
 	}
 
 	// 2. Value Betting/Raising Logic (based on hand strength).
 	if strength >= float64(poker.TwoPair) { // Strong hands (Two Pair or better).
 		// Decide whether to be aggressive or "slow play" (trap).
-		if r.Float64() < player.Profile.AggressionFactor {
-			return PlayerAction{Type: ActionRaise, Amount: g.minRaiseAmount() * 2}
+		if r.Float64() < g.valueBetFrequency(player) {
+			return PlayerAction{Type: ActionRaise, Amount: g.raiseAmount(player, r)}
 		} else {
 			return PlayerAction{Type: ActionCall} // Slow play.
 		}
 	} else if strength >= float64(poker.OnePair) { // Decent, but vulnerable hands.
-		// Prefer to see the next card cheaply.
+		// Prefer to see the next card cheaply, unless a calling station
+		// will pay off a thin value bet.
+		if canCheck && g.betsThinValue(player) {
+			return PlayerAction{Type: ActionBet, Amount: g.RoundToChipUnit(g.Pot / 2)}
+		}
 		if canCheck {
 			return PlayerAction{Type: ActionCheck}
 		}
+		// A pair with a low, or a draw to the nut low, plays for both
+		// halves of the pot.
+		if g.continuesTwoWay(player) {
+			return PlayerAction{Type: ActionCall}
+		}
+		if g.Difficulty.Preset().SimulatesEquity {
+			return g.callByEquity(player, r)
+		}
 		return PlayerAction{Type: ActionCall}
 	} else { // Weak hands / draws.
 		if canCheck {
 			return PlayerAction{Type: ActionCheck}
 		}
+		if g.chasesWeakLowDraw(player, strength) {
+			return PlayerAction{Type: ActionFold}
+		}
+		if g.Difficulty.Preset().SimulatesEquity {
+			return g.callByEquity(player, r)
+		}
 		// Decide whether to fold or call based on a simplified version of pot odds.
 		potOdds := float64(g.BetToCall) / float64(g.Pot+g.BetToCall)
 		// A very rough estimation of equity.
@@ -127,13 +158,221 @@ func (g *Game) GetCPUAction(player *Player, r *rand.Rand) PlayerAction {
 	}
 }
 
+// positionThresholdScales scales a CPU's pre-flop thresholds by the group of
+// its position: it plays fewer hands from early position, with the whole
+// table behind it, and more from the cutoff and the button. The blinds,
+// already part in, defend a little wider than the middle seats.
+var positionThresholdScales = map[PositionGroup]float64{
+	PositionEarly:  1.1,
+	PositionMiddle: 1.0,
+	PositionLate:   0.85,
+	PositionBlinds: 0.95,
+}
+
+// thresholdScalePerPlayerLeft tightens a CPU's pre-flop thresholds for each
+// player left to act behind it, any of whom may hold a better hand.
+const thresholdScalePerPlayerLeft = 0.02
+
+// positionalThresholdScale returns the factor a CPU's pre-flop play and raise
+// thresholds are scaled by for its position and the players left to act.
+func (g *Game) positionalThresholdScale(player *Player) float64 {
+	scale := positionThresholdScales[g.positionGroup(player)]
+	return scale * (1 + thresholdScalePerPlayerLeft*float64(g.playersLeftToAct(player)))
+}
+
+// callByEquity calls a bet if the player's simulated equity against the other
+// players left in the hand beats the pot odds, and folds otherwise.
+func (g *Game) callByEquity(player *Player, r *rand.Rand) PlayerAction {
+	equity, err := g.estimateEquity(g.HandContext(), player.Hand, g.CountNonFoldedPlayers()-1, r)
+	if err != nil {
+		logrus.Warnf("Could not estimate %s's equity: %v", player.Name, err)
+		return PlayerAction{Type: ActionCall}
+	}
+	if equity >= poker.CalculateBreakEvenEquityBasedOnPotOdds(g.Pot, g.BetToCall-player.CurrentBet) {
+		return PlayerAction{Type: ActionCall}
+	}
+	return PlayerAction{Type: ActionFold}
+}
+
+// preFlopRaiseAmount returns the total amount a CPU raises to pre-flop. An open
+// raise (no one has raised yet) is sized by the profile's OpenSizeBB; a re-raise
+// is sized by raiseAmount, and grown by bvbThreeBetScale for an aggressive CPU
+// between the blinds. Either way the amount is clamped to the legal range.
+func (g *Game) preFlopRaiseAmount(player *Player, r *rand.Rand) int {
+	if g.BetToCall <= g.BigBlind && player.Profile.OpenSizeBB > 0 {
+		return g.clampRaiseAmount(int(g.adjustedOpenSizeBB(player) * float64(g.BigBlind)))
+	}
+	amount := g.raiseAmount(player, r)
+	if g.blindVersusBlind(player) && player.Profile.AggressionFactor >= bvbPassiveAggression {
+		amount = g.clampRaiseAmount(int(bvbThreeBetScale * float64(amount)))
+	}
+	return amount
+}
+
+// raisePotShares is, for each street after the flop is dealt, the share of the
+// pot (after calling) that a CPU raises by at least. A multiple of a small bet
+// into a big pot would be too small a raise, and the shares grow by street as
+// the bets do.
+var raisePotShares = map[GamePhase]float64{
+	PhaseFlop:  0.5,
+	PhaseTurn:  0.6,
+	PhaseRiver: 0.75,
+}
+
+// raiseAmount returns the total amount a CPU raises to: the bet to call (or the
+// big blind, with no bet yet) times a multiplier drawn from the profile's
+// MinRaiseMultiplier to MaxRaiseMultiplier, but at least the street's share of
+// the pot over the bet. The amount is clamped to the legal range.
+func (g *Game) raiseAmount(player *Player, r *rand.Rand) int {
+	lo, hi := player.Profile.MinRaiseMultiplier, player.Profile.MaxRaiseMultiplier
+	multiplier := lo
+	if hi > lo {
+		multiplier += r.Float64() * (hi - lo)
+	}
+	bet := max(g.BetToCall, g.BigBlind)
+	desired := int(multiplier * float64(bet))
+	if share := raisePotShares[g.Phase]; share > 0 {
+		potAfterCall := g.Pot + g.BetToCall - player.CurrentBet
+		desired = max(desired, g.BetToCall+int(share*float64(potAfterCall)))
+	}
+	return g.clampRaiseAmount(desired)
+}
+
+// clampRaiseAmount rounds a desired raise total to the chip unit and limits it
+// to the legal range reported by the game's betting calculator for the player
+// currently to act.
+func (g *Game) clampRaiseAmount(desired int) int {
+	if g.BettingCalculator == nil || len(g.Players) == 0 {
+		return g.RoundToChipUnit(desired)
+	}
+	desired = g.RoundBetAmount(desired)
+	minRaise, maxRaise := g.CalculateBettingLimits()
+	if desired < minRaise {
+		return minRaise
+	}
+	if desired > maxRaise {
+		return maxRaise
+	}
+	return desired
+}
+
+// fitFixedLimit sizes a CPU's bet or raise to the one size a fixed limit
+// allows, and turns a raise into a call once the street is capped. Other
+// betting limits leave the action as it is.
+func (g *Game) fitFixedLimit(action PlayerAction) PlayerAction {
+	if _, fixed := g.BettingCalculator.(*FixedLimitCalculator); !fixed {
+		return action
+	}
+	if action.Type != ActionBet && action.Type != ActionRaise {
+		return action
+	}
+	if action.Type == ActionRaise && g.BettingCapped() {
+		return PlayerAction{Type: ActionCall}
+	}
+	action.Amount, _ = g.CalculateBettingLimits()
+	return action
+}
+
+// minObservationsForAdjustment is the number of bets the human must have faced
+// before the AI trusts its opponent model enough to adjust its play.
+const minObservationsForAdjustment = 10
+
+// adjustedBluffingFrequency tunes a CPU's bluffing frequency using what it
+// remembers about the human player, scaled by the difficulty. Bluffs work well against players who fold
+// too often and poorly against players who rarely fold.
+func (g *Game) adjustedBluffingFrequency(player *Player) float64 {
+	preset := g.Difficulty.Preset()
+	freq := min(player.Profile.BluffingFrequency*preset.BluffScale, 1)
+	m := g.HumanModel
+	if m == nil || !preset.UsesOpponentModel || m.BetsFaced < minObservationsForAdjustment || !g.isHumanInHand() {
+		return freq
+	}
+
+	switch foldToBet := m.FoldToBetFrequency(); {
+	case foldToBet >= 0.6:
+		freq *= 1.5
+	case foldToBet <= 0.2:
+		freq *= 0.5
+	}
+	if freq > 1 {
+		freq = 1
+	}
+	return freq
+}
+
+// readBluffingFrequency tunes a CPU's bluffing frequency, when it reads its
+// opponents, to the session statistics of those left in the hand: a calling
+// station halves it, and a table of players who fold to continuation bets
+// raises it by half.
+func (g *Game) readBluffingFrequency(player *Player, freq float64) float64 {
+	if !g.readsOpponents() {
+		return freq
+	}
+	switch read := g.readOpponents(player); {
+	case read.station:
+		freq *= 0.5
+	case read.folders:
+		freq = min(freq*1.5, 1)
+	}
+	return freq
+}
+
+// minPreFlopOpportunities is the number of spots for a pre-flop line (e.g.
+// cold calls) the human must have had before the AI adjusts to it.
+const minPreFlopOpportunities = 5
+
+// adjustedOpenSizeBB tunes a CPU's open size against the human player. A
+// player who cold-calls raises too often pays more to see the flop.
+func (g *Game) adjustedOpenSizeBB(player *Player) float64 {
+	size := player.Profile.OpenSizeBB
+	m := g.HumanModel
+	if m == nil || !g.Difficulty.Preset().UsesOpponentModel || m.PreFlop.ColdCallOpportunities < minPreFlopOpportunities || !g.isHumanInHand() {
+		return size
+	}
+	if m.PreFlop.ColdCallFrequency() >= 0.5 {
+		size *= 1.5
+	}
+	return size
+}
+
+// humanSqueezesBehind reports whether the human, who often squeezes, is still
+// to act in the current pre-flop betting round.
+func (g *Game) humanSqueezesBehind() bool {
+	m := g.HumanModel
+	if m == nil || !g.Difficulty.Preset().UsesOpponentModel || m.PreFlop.SqueezeOpportunities < minPreFlopOpportunities || m.PreFlop.SqueezeFrequency() < 0.3 {
+		return false
+	}
+	for _, p := range g.Players {
+		if !p.IsCPU && p.Status == PlayerStatusPlaying && p.CurrentBet < g.BetToCall {
+			return true
+		}
+	}
+	return false
+}
+
+// isHumanInHand reports whether a human player is still contesting the current hand.
+func (g *Game) isHumanInHand() bool {
+	for _, p := range g.Players {
+		if !p.IsCPU && (p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn) {
+			return true
+		}
+	}
+	return false
+}
+
 // evaluateHandStrength calculates a numerical score for a player's hand to guide
 // AI decision-making. The evaluation method differs between pre-flop and post-flop.
 //
-// Post-flop, the score is simply the rank of the player's best 5-card hand.
+// Post-flop, the score is the rank of the player's best 5-card hand, as
+// EvaluatePlayerHand evaluates it for the street. In a
+// High-Low split game, the player's low potential is added to it (see
+// lowStrengthBonus): a made low, more for the nut low, a draw to the nut low,
+// and less for a low that the cards to come may counterfeit.
 //
-// Pre-flop, it uses a custom scoring system to assess the potential of the hole
-// cards, considering:
+// Pre-flop, a variant with an AI tuning pack scores the hole cards by its
+// starting hand table (see tunedStartingHandScore). Otherwise, it uses a
+// generic scoring system to assess the potential of the hole cards,
+// considering:
 // - High card values (points for cards Ten and above).
 // - A significant bonus for pairs.
 // - A small bonus for suited cards.
@@ -141,16 +380,30 @@ func (g *Game) GetCPUAction(player *Player, r *rand.Rand) PlayerAction {
 func evaluateHandStrength(g *Game, player *Player) float64 {
 	// Post-Flop: The strength is the actual rank of the hand.
 	if g.Phase > PhasePreFlop {
-		highHand, _ := poker.EvaluateHand(player.Hand, g.CommunityCards, g.Rules)
-		if highHand != nil {
-			return float64(highHand.Rank)
+		evaluation, err := g.EvaluatePlayerHand(player)
+		if err != nil {
+			// The hand was canceled: the decision no longer matters.
+			logrus.Debugf("Could not evaluate %s's hand: %v", player.Name, err)
+			return 0
+		}
+		var strength float64
+		if evaluation.High != nil {
+			strength = float64(evaluation.MadeRank)
 		}
-		return 0
+		return strength + g.lowStrengthBonus(g.readLowPotential(player, evaluation.Low))
+	}
+	return g.startingHandScore(player.Hand)
+}
+
+// startingHandScore scores hole cards before the flop, as evaluateHandStrength
+// does pre-flop.
+func (g *Game) startingHandScore(hand []poker.Card) float64 {
+	if tuning := g.aiTuning(); tuning != nil {
+		return tunedStartingHandScore(hand, tuning, g.Rules)
 	}
 
 	// Pre-Flop: Evaluate potential based on hole cards using a custom heuristic.
 	var score float64
-	hand := player.Hand
 
 	// 1. High card points for cards Ten or higher.
 	rankPoints := map[poker.Rank]float64{
@@ -0,0 +1,29 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"strings"
+)
+
+// UseAIProfiles gives the CPUs, in seat order, the profiles of the set's
+// lineup for the game's difficulty, in place of the built-in ones. The set
+// must pass AIProfileSet.Validate. CPUs who join later take their profiles
+// from the same set.
+func (g *Game) UseAIProfiles(set *config.AIProfileSet) error {
+	if err := set.Validate(); err != nil {
+		return fmt.Errorf("invalid AI profiles: %w", err)
+	}
+	var cpus []*Player
+	for _, p := range g.Players {
+		if p.IsCPU {
+			cpus = append(cpus, p)
+		}
+	}
+	for i, name := range set.Lineup(strings.ToLower(g.Difficulty.String()), len(cpus)) {
+		profile := *set.Profile(name)
+		cpus[i].Profile = &profile
+	}
+	g.profiles = set
+	return nil
+}
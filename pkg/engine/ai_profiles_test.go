@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"testing"
+)
+
+// builtInProfile returns a copy of the built-in profile with the given name.
+func builtInProfile(name string) AIProfile {
+	return *config.DefaultAIProfiles().Profile(name)
+}
+
+func TestUseAIProfiles(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100, "NLH")
+	set := config.DefaultAIProfiles()
+	maniac := AIProfile{
+		Name: "Maniac", PlayHandThreshold: 5, RaiseHandThreshold: 10, BluffingFrequency: 0.6,
+		AggressionFactor: 1, MinRaiseMultiplier: 3, MaxRaiseMultiplier: 5, OpenSizeBB: 4,
+	}
+	set.Profiles = append(set.Profiles, maniac)
+	set.Lineups["medium"] = []string{"Maniac", "Tight-Passive"}
+
+	if err := g.UseAIProfiles(set); err != nil {
+		t.Fatalf("Expected the profiles to be used, but got %v", err)
+	}
+	if g.Players[0].Profile != nil {
+		t.Error("Expected YOU to have no profile")
+	}
+	// The lineup of two starts over for the third CPU.
+	for i, want := range []string{"Maniac", "Tight-Passive", "Maniac"} {
+		if got := g.Players[i+1].Profile; got == nil || got.Name != want {
+			t.Errorf("Expected CPU%d to play %s, but got %v", i+1, want, got)
+		}
+	}
+	if g.Players[1].Profile == g.Players[3].Profile {
+		t.Error("Expected every CPU to get its own copy of a profile")
+	}
+
+	set.Lineups["hard"] = nil
+	if err := g.UseAIProfiles(set); err == nil {
+		t.Error("Expected an error for a set without a hard lineup")
+	}
+}
@@ -1,8 +1,9 @@
 package engine
 
 import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
 	"math/rand"
-	"pls7-cli/pkg/poker"
 	"testing"
 )
 
@@ -37,9 +38,82 @@ func TestEvaluateHandStrength(t *testing.T) {
 	}
 }
 
+// plo8Tuning returns Pot-Limit Omaha 8-or-Better rules with the values of its
+// shipped AI tuning pack.
+func plo8Tuning() *poker.GameRules {
+	return &poker.GameRules{
+		HoleCards:    poker.HoleCardRules{Count: 4, UseConstraint: "exact", UseCount: 2},
+		HandRankings: poker.HandRankingsRules{UseStandardRankings: true},
+		LowHand:      poker.LowHandRules{Enabled: true, MaxRank: 8},
+		AITuning: &poker.AITuning{
+			StartingHands: poker.StartingHandTable{
+				RankPoints:   map[string]float64{"A": 10, "K": 8, "Q": 7, "J": 6, "T": 5},
+				CountedCards: 3, PairBonus: 12, SuitedBonus: 2, DoubleSuitedBonus: 4, ConnectorBonus: 2,
+			},
+			Aggression: poker.AggressionCurve{Flop: 1, Turn: 0.9, River: 0.8},
+			LowHand:    poker.LowHandWeights{CardPoints: 2, MadeLowBonus: 1},
+		},
+	}
+}
+
+func TestEvaluateHandStrength_TuningPack(t *testing.T) {
+	testCases := []struct {
+		name              string
+		phase             GamePhase
+		holeCardsStr      string
+		communityCardsStr string
+		expectedScore     float64
+	}{
+		// A, K and 3 score 18, two suits 4, K-A and 3-2 in sequence 4, and
+		// three low cards 6.
+		{name: "Pre-Flop - Double-Suited Low Hand", phase: PhasePreFlop, holeCardsStr: "As 2s 3d Kd", expectedScore: 32},
+		// The three highest of the four cards score, with a pair of queens
+		// and one low card.
+		{name: "Pre-Flop - Pair", phase: PhasePreFlop, holeCardsStr: "Qs Qh 9d 4c", expectedScore: 14 + 12 + float64(poker.Queen) + 2},
+		{name: "Post-Flop - High Card with a Low", phase: PhaseRiver, holeCardsStr: "2s 5s 9d Kd", communityCardsStr: "3c 4h 8c Jd Qh", expectedScore: float64(poker.HighCard) + 1},
+		{name: "Post-Flop - High Card with the Nut Low", phase: PhaseRiver, holeCardsStr: "As 2s 9d Kd", communityCardsStr: "3c 4h 8c Jd Qh", expectedScore: float64(poker.HighCard) + 1.5},
+		{name: "Post-Flop - One Pair without a Low", phase: PhaseRiver, holeCardsStr: "Ks Kh 9d Jc", communityCardsStr: "3c 4h 8c Jd Qh", expectedScore: float64(poker.OnePair)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &Game{
+				Phase:          tc.phase,
+				CommunityCards: poker.CardsFromStrings(tc.communityCardsStr),
+				Rules:          plo8Tuning(),
+			}
+			player := &Player{Hand: poker.CardsFromStrings(tc.holeCardsStr)}
+			score := evaluateHandStrength(g, player)
+			if score != tc.expectedScore {
+				t.Errorf("Expected score %.2f, but got %.2f", tc.expectedScore, score)
+			}
+		})
+	}
+}
+
+func TestAggressionFactor_TuningPack(t *testing.T) {
+	player := &Player{Profile: &AIProfile{AggressionFactor: 0.9}}
+	testCases := []struct {
+		phase    GamePhase
+		rules    *poker.GameRules
+		expected float64
+	}{
+		{PhaseFlop, plo8Tuning(), 0.9},
+		{PhaseRiver, plo8Tuning(), 0.72},
+		// Without a pack, the profile's aggression is used on every street.
+		{PhaseRiver, &poker.GameRules{}, 0.9},
+	}
+	for _, tc := range testCases {
+		g := &Game{Phase: tc.phase, Rules: tc.rules}
+		if got := g.aggressionFactor(player); math.Abs(got-tc.expected) > 1e-9 {
+			t.Errorf("%s: expected aggression %.2f, but got %.2f", tc.phase, tc.expected, got)
+		}
+	}
+}
+
 func TestCPUActionProfileBased(t *testing.T) {
-	lagProfile := aiProfiles["Loose-Aggressive"]
-	tpProfile := aiProfiles["Tight-Passive"]
+	lagProfile := builtInProfile("Loose-Aggressive")
+	tpProfile := builtInProfile("Tight-Passive")
 
 	testCases := []struct {
 		name           string
@@ -60,15 +134,16 @@ func TestCPUActionProfileBased(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			g := &Game{
-				Phase:     tc.phase,
-				Pot:       100,
-				BetToCall: 0,
-				Rules:     &poker.GameRules{LowHand: poker.LowHandRules{Enabled: false}},
+				Phase:      tc.phase,
+				Difficulty: DifficultyMedium,
+				Pot:        100,
+				BetToCall:  0,
+				Rules:      &poker.GameRules{LowHand: poker.LowHandRules{Enabled: false}},
 			}
 			if !tc.canCheck {
 				g.BetToCall = 10
 			}
-			player := &Player{Profile: tc.profile}
+			player := &Player{Profile: tc.profile, Chips: 1000}
 
 			g.handEvaluator = func(g *Game, p *Player) float64 { return tc.handStrength }
 
@@ -81,3 +156,174 @@ func TestCPUActionProfileBased(t *testing.T) {
 		})
 	}
 }
+
+func TestCPUPreFlopRangeByPosition(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 500, 1000)
+	g.StartNewHand()
+	tag := builtInProfile("Tight-Aggressive")
+	for _, p := range g.Players {
+		p.Profile = &tag
+	}
+	// A hand just good enough for a Tight-Aggressive profile in a middle seat.
+	g.handEvaluator = func(*Game, *Player) float64 { return tag.PlayHandThreshold + 1 }
+
+	testCases := []struct {
+		seat     int
+		group    PositionGroup
+		left     int
+		expected ActionType
+	}{
+		{seat: 3, group: PositionEarly, left: 5, expected: ActionFold},
+		{seat: 5, group: PositionLate, left: 3, expected: ActionCall},
+		{seat: 0, group: PositionLate, left: 2, expected: ActionCall},
+		{seat: 2, group: PositionBlinds, left: 0, expected: ActionCall},
+	}
+	for _, tc := range testCases {
+		player := g.Players[tc.seat]
+		if got := g.positionGroup(player); got != tc.group {
+			t.Errorf("%s: position group = %s, want %s", player.Name, got, tc.group)
+		}
+		if got := g.playersLeftToAct(player); got != tc.left {
+			t.Errorf("%s: players left to act = %d, want %d", player.Name, got, tc.left)
+		}
+		if got := g.decideCPUAction(player, rand.New(rand.NewSource(1))); got.Type != tc.expected {
+			t.Errorf("%s: action = %s, want %s", player.Name, got.Type, tc.expected)
+		}
+	}
+}
+
+func TestPreFlopRaiseAmount_ClampedToLegalRange(t *testing.T) {
+	testCases := []struct {
+		name           string
+		ruleAbbr       string
+		openSizeBB     float64
+		expectedAmount int
+	}{
+		{name: "Pot-limit pot-sized open", ruleAbbr: "PLS", openSizeBB: 3.5, expectedAmount: 3500},
+		{name: "Pot-limit oversized open is clamped", ruleAbbr: "PLS", openSizeBB: 5, expectedAmount: 3500},
+		{name: "Pot-limit undersized open is raised to the minimum", ruleAbbr: "PLS", openSizeBB: 1.5, expectedAmount: 2000},
+		{name: "No-limit large open is allowed", ruleAbbr: "NLH", openSizeBB: 5, expectedAmount: 5000},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000, tc.ruleAbbr)
+			g.StartNewHand()
+			player := g.CurrentPlayer()
+			profile := builtInProfile("Loose-Aggressive")
+			profile.OpenSizeBB = tc.openSizeBB
+			player.Profile = &profile
+
+			if amount := g.preFlopRaiseAmount(player, rand.New(rand.NewSource(1))); amount != tc.expectedAmount {
+				t.Errorf("Expected open raise to %d, but got %d", tc.expectedAmount, amount)
+			}
+		})
+	}
+}
+
+func TestRaiseAmount_DrawnFromProfileMultipliers(t *testing.T) {
+	newFacingRaise := func(ruleAbbr string, minMult, maxMult float64) (*Game, *Player) {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000, ruleAbbr)
+		g.StartNewHand()
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionRaise, Amount: 3000})
+		g.AdvanceTurn()
+		player := g.CurrentPlayer()
+		profile := builtInProfile("Tight-Aggressive")
+		profile.MinRaiseMultiplier, profile.MaxRaiseMultiplier = minMult, maxMult
+		player.Profile = &profile
+		return g, player
+	}
+
+	g, player := newFacingRaise("NLH", 2.5, 2.5)
+	if got := g.raiseAmount(player, rand.New(rand.NewSource(1))); got != 7500 {
+		t.Errorf("Expected a 2.5x re-raise to 7500, but got %d", got)
+	}
+
+	g, player = newFacingRaise("NLH", 2, 4)
+	seen := make(map[int]bool)
+	for seed := int64(0); seed < 20; seed++ {
+		got := g.raiseAmount(player, rand.New(rand.NewSource(seed)))
+		if got < 6000 || got > 12000 {
+			t.Errorf("Expected a re-raise between 2x and 4x the bet, but got %d", got)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Expected re-raises of varying sizes, but got only %v", seen)
+	}
+
+	g, player = newFacingRaise("PLS", 4, 4)
+	if _, maxRaise := g.CalculateBettingLimits(); g.raiseAmount(player, rand.New(rand.NewSource(1))) != maxRaise {
+		t.Errorf("Expected a 4x re-raise to be clamped to the pot limit, %d", maxRaise)
+	}
+
+	// After the flop, a small bet into a big pot is raised by at least half
+	// the pot.
+	g, player = newFacingRaise("NLH", 2, 2)
+	g.Phase = PhaseFlop
+	g.Pot, g.BetToCall, g.LastRaiseAmount = 20000, 1000, 1000
+	player.CurrentBet = 0
+	if got := g.raiseAmount(player, rand.New(rand.NewSource(1))); got != 11500 {
+		t.Errorf("Expected a raise to 11500 (half of the 21,000 pot over the bet), but got %d", got)
+	}
+}
+
+func TestDifficultyPresets(t *testing.T) {
+	lagProfile := builtInProfile("Loose-Aggressive")
+	cpu := &Player{Name: "CPU1", IsCPU: true, Profile: &lagProfile}
+	testCases := []struct {
+		difficulty Difficulty
+		expected   float64
+	}{
+		// Easy ignores that the human folds too often, and bluffs half as much.
+		{difficulty: DifficultyEasy, expected: lagProfile.BluffingFrequency * 0.5},
+		{difficulty: DifficultyMedium, expected: lagProfile.BluffingFrequency * 1.5},
+		{difficulty: DifficultyHard, expected: lagProfile.BluffingFrequency * 1.25 * 1.5},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.difficulty.String(), func(t *testing.T) {
+			g := &Game{
+				Players:    []*Player{{Name: "YOU", Status: PlayerStatusPlaying}},
+				Difficulty: tc.difficulty,
+				HumanModel: &OpponentModel{BetsFaced: 10, FoldsToBet: 8},
+			}
+			if got := g.adjustedBluffingFrequency(cpu); math.Abs(got-tc.expected) > 1e-9 {
+				t.Errorf("Expected bluffing frequency %.4f, but got %.4f", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestHardCPUCallsByEquity(t *testing.T) {
+	testCases := []struct {
+		name           string
+		difficulty     Difficulty
+		pot, bet       int
+		expectedAction ActionType
+	}{
+		{name: "Hard folds to an overbet", difficulty: DifficultyHard, pot: 1000, bet: 10000, expectedAction: ActionFold},
+		{name: "Hard calls a cheap bet", difficulty: DifficultyHard, pot: 10000, bet: 100, expectedAction: ActionCall},
+		{name: "Medium gives up the cheap bet", difficulty: DifficultyMedium, pot: 10000, bet: 100, expectedAction: ActionFold},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTests([]string{"YOU", "CPU1"}, 100000, 500, 1000)
+			g.Difficulty = tc.difficulty
+			g.Phase = PhaseFlop
+			g.CommunityCards = mustParseCards(t, "As Ks 2d")
+			g.Pot = tc.pot
+			g.BetToCall = tc.bet
+			cpu := g.Players[1]
+			cpu.Hand = mustParseCards(t, "9c 8d 4h")
+			profile := builtInProfile("Tight-Passive")
+			profile.BluffingFrequency = 0
+			cpu.Profile = &profile
+
+			if action := g.GetCPUAction(cpu, rand.New(rand.NewSource(1))); action.Type != tc.expectedAction {
+				t.Errorf("Expected action %v, but got %v", tc.expectedAction, action.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"sort"
+)
+
+// aiTuning returns the AI tuning pack of the variant being played, or nil if
+// the CPUs play it by generic heuristics.
+func (g *Game) aiTuning() *poker.AITuning {
+	if g.Rules == nil {
+		return nil
+	}
+	return g.Rules.AITuning
+}
+
+// aggressionFactor returns the player's aggression on the current street: the
+// profile's, scaled by the variant's aggression curve when it has one.
+func (g *Game) aggressionFactor(player *Player) float64 {
+	factor := player.Profile.AggressionFactor
+	tuning := g.aiTuning()
+	if tuning == nil {
+		return factor
+	}
+	var scale float64
+	switch g.Phase {
+	case PhaseFlop:
+		scale = tuning.Aggression.Flop
+	case PhaseTurn:
+		scale = tuning.Aggression.Turn
+	case PhaseRiver:
+		scale = tuning.Aggression.River
+	}
+	if scale == 0 {
+		return factor
+	}
+	return min(factor*scale, 1)
+}
+
+// tunedStartingHandScore scores hole cards before the flop by the variant's AI
+// tuning pack, in place of the generic heuristic of evaluateHandStrength.
+func tunedStartingHandScore(hand []poker.Card, tuning *poker.AITuning, rules *poker.GameRules) float64 {
+	table := tuning.StartingHands
+	var score float64
+
+	// 1. Points for the highest cards.
+	ranks := make([]poker.Rank, len(hand))
+	for i, c := range hand {
+		ranks[i] = c.Rank
+	}
+	sort.Sort(byRank(ranks))
+	counted := ranks
+	if table.CountedCards > 0 && table.CountedCards < len(ranks) {
+		counted = ranks[:table.CountedCards]
+	}
+	for _, rank := range counted {
+		score += table.PointsFor(rank)
+	}
+
+	// 2. A bonus for the highest pair, which ranks sorts first.
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] == ranks[i-1] {
+			score += table.PairBonus + float64(ranks[i])
+			break
+		}
+	}
+
+	// 3. A bonus for suited cards, larger for two suits.
+	suitCounts := make(map[poker.Suit]int)
+	for _, c := range hand {
+		suitCounts[c.Suit]++
+	}
+	suited := 0
+	for _, n := range suitCounts {
+		if n >= 2 {
+			suited++
+		}
+	}
+	if suited >= 2 && table.DoubleSuitedBonus > 0 {
+		score += table.DoubleSuitedBonus
+	} else if suited >= 1 {
+		score += table.SuitedBonus
+	}
+
+	// 4. A bonus for every two ranks in sequence.
+	distinct := distinctRanks(ranks)
+	for i := 1; i < len(distinct); i++ {
+		if distinct[i-1] == distinct[i]+1 {
+			score += table.ConnectorBonus
+		}
+	}
+
+	// 5. Points for the cards that can make a low.
+	if rules.LowHand.Enabled {
+		for _, rank := range distinct {
+			if rank == poker.Ace || int(rank) <= rules.LowHand.MaxRank {
+				score += tuning.LowHand.CardPoints
+			}
+		}
+	}
+	return score
+}
+
+// distinctRanks returns the ranks without repeats, keeping their order. The
+// ranks must be sorted.
+func distinctRanks(ranks []poker.Rank) []poker.Rank {
+	var distinct []poker.Rank
+	for i, rank := range ranks {
+		if i == 0 || rank != ranks[i-1] {
+			distinct = append(distinct, rank)
+		}
+	}
+	return distinct
+}
@@ -0,0 +1,163 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"slices"
+)
+
+// AnnotationKind is the kind of turnaround marked by a HandAnnotation.
+type AnnotationKind int
+
+// AnnotationKind constants.
+const (
+	AnnotationHighOutdrawn     AnnotationKind = iota // AnnotationHighOutdrawn marks a made high hand that was ahead falling behind.
+	AnnotationLowOutdrawn                            // AnnotationLowOutdrawn marks the best low being beaten by an opponent's improved low.
+	AnnotationLowCounterfeited                       // AnnotationLowCounterfeited marks the best low being tied or beaten when the board pairs one of its hole cards.
+)
+
+// HandAnnotation marks the street on which a player who was alone in the lead
+// lost it, for replays of the hand.
+type HandAnnotation struct {
+	Kind AnnotationKind
+	// Phase is the street that was dealt, and Card the card that turned the
+	// hand around.
+	Phase GamePhase
+	Card  poker.Card
+	// PlayerName names the player who was ahead, and Hand is the hand they
+	// were ahead with.
+	PlayerName string
+	Hand       *poker.HandResult
+	// Leaders names the players ahead after the card, and LeaderHand is their
+	// hand. For a counterfeited low, Leaders may include PlayerName, now tied.
+	Leaders    []string
+	LeaderHand *poker.HandResult
+}
+
+// AnnotateHand finds the turns and rivers on which the player alone in the lead
+// for the high or the low, among the players still in the hand, lost it, as a
+// spectator who sees every hole card would. A high hand only counts once it is
+// made, better than a high card.
+func AnnotateHand(h *HandHistory, rules *poker.GameRules) []HandAnnotation {
+	// The street on which each player folded, if they did.
+	folded := make(map[string]GamePhase)
+	for _, action := range h.Actions {
+		if action.Action == ActionFold {
+			folded[action.PlayerName] = action.Phase
+		}
+	}
+
+	var annotations []HandAnnotation
+	for _, phase := range []GamePhase{PhaseTurn, PhaseRiver} {
+		size := phase.Street().BoardSize()
+		if len(h.Board) < size {
+			break
+		}
+		var live []SeatRecord
+		for _, seat := range h.Seats {
+			if foldedOn, ok := folded[seat.Name]; (ok && foldedOn < phase) || len(seat.HoleCards) == 0 {
+				continue
+			}
+			live = append(live, seat)
+		}
+		if len(live) < 2 {
+			break
+		}
+
+		card := h.Board[size-1]
+		before := evaluateSeats(live, h.Board[:size-1], rules)
+		after := evaluateSeats(live, h.Board[:size], rules)
+		annotation := HandAnnotation{Phase: phase, Card: card}
+
+		if leader, ok := soleLeader(before.high, poker.CompareHigh); ok && before.high[leader].Rank > poker.HighCard {
+			if leaders, hand := bestSeats(after.high, poker.CompareHigh); !slices.Contains(leaders, leader) {
+				annotation.Kind = AnnotationHighOutdrawn
+				annotation.PlayerName, annotation.Hand = live[leader].Name, before.high[leader]
+				annotation.Leaders, annotation.LeaderHand = seatNames(live, leaders), hand
+				annotations = append(annotations, annotation)
+			}
+		}
+		if leader, ok := soleLeader(before.low, poker.CompareLow); ok {
+			leaders, hand := bestSeats(after.low, poker.CompareLow)
+			// A low tied by an opponent's improvement is not worth a mention,
+			// but one tied because the board paired it is.
+			switch {
+			case pairsHoleCardInLow(card, live[leader].HoleCards, before.low[leader]):
+				if len(leaders) == 1 && leaders[0] == leader {
+					continue
+				}
+				annotation.Kind = AnnotationLowCounterfeited
+			case !slices.Contains(leaders, leader):
+				annotation.Kind = AnnotationLowOutdrawn
+			default:
+				continue
+			}
+			annotation.PlayerName, annotation.Hand = live[leader].Name, before.low[leader]
+			annotation.Leaders, annotation.LeaderHand = seatNames(live, leaders), hand
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations
+}
+
+// seatHands holds the high and low hand of each seat, by index.
+type seatHands struct {
+	high, low []*poker.HandResult
+}
+
+// evaluateSeats evaluates every seat's hand on the given board.
+func evaluateSeats(seats []SeatRecord, board []poker.Card, rules *poker.GameRules) seatHands {
+	hands := seatHands{high: make([]*poker.HandResult, len(seats)), low: make([]*poker.HandResult, len(seats))}
+	for i, seat := range seats {
+		hands.high[i], hands.low[i] = poker.EvaluateHand(seat.HoleCards, board, rules)
+	}
+	return hands
+}
+
+// bestSeats returns the indexes of the seats with the best hand by compare,
+// poker.CompareHigh or poker.CompareLow, and that hand. Seats without a hand
+// are skipped.
+func bestSeats(hands []*poker.HandResult, compare func(a, b *poker.HandResult) int) ([]int, *poker.HandResult) {
+	var best []int
+	var bestHand *poker.HandResult
+	for i, hand := range hands {
+		if hand == nil {
+			continue
+		}
+		if bestHand == nil || compare(hand, bestHand) > 0 {
+			best, bestHand = []int{i}, hand
+		} else if compare(hand, bestHand) == 0 {
+			best = append(best, i)
+		}
+	}
+	return best, bestHand
+}
+
+// soleLeader returns the index of the seat alone with the best hand, if there
+// is one.
+func soleLeader(hands []*poker.HandResult, compare func(a, b *poker.HandResult) int) (int, bool) {
+	best, _ := bestSeats(hands, compare)
+	if len(best) != 1 {
+		return 0, false
+	}
+	return best[0], true
+}
+
+// pairsHoleCardInLow reports whether the card pairs one of the hole cards
+// that the low hand is made with.
+func pairsHoleCardInLow(card poker.Card, holeCards []poker.Card, low *poker.HandResult) bool {
+	for _, c := range low.Cards {
+		if c.Rank == card.Rank && slices.Contains(holeCards, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// seatNames returns the names of the seats at the given indexes.
+func seatNames(seats []SeatRecord, indexes []int) []string {
+	names := make([]string, len(indexes))
+	for i, index := range indexes {
+		names[i] = seats[index].Name
+	}
+	return names
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"reflect"
+	"testing"
+)
+
+func annotationTestRules() *poker.GameRules {
+	return &poker.GameRules{
+		Abbreviation: "PLS7",
+		HoleCards:    poker.HoleCardRules{Count: 3},
+		LowHand:      poker.LowHandRules{Enabled: true, MaxRank: 7},
+		BettingLimit: "pot_limit",
+	}
+}
+
+func TestAnnotateHand_LowCounterfeited(t *testing.T) {
+	// CPU 3 has the only low on the flop, 6-4-3-2-A. The 3d pairs CPU 3's 3h
+	// and gives CPU 1 the same low.
+	h := &HandHistory{
+		Seats: []SeatRecord{
+			{Name: "CPU 1", HoleCards: mustParseCards(t, "Ac 2c Qd")},
+			{Name: "CPU 3", HoleCards: mustParseCards(t, "As 2d 3h")},
+		},
+		Board: mustParseCards(t, "4c 6h Ks 3d Jh"),
+	}
+
+	annotations := AnnotateHand(h, annotationTestRules())
+	if len(annotations) != 1 {
+		t.Fatalf("Expected 1 annotation, got %+v", annotations)
+	}
+	a := annotations[0]
+	if a.Kind != AnnotationLowCounterfeited || a.Phase != PhaseTurn || a.Card != mustParseCards(t, "3d")[0] || a.PlayerName != "CPU 3" {
+		t.Errorf("Expected CPU 3's low to be counterfeited by the 3d on the turn, got %+v", a)
+	}
+	if !reflect.DeepEqual(a.Leaders, []string{"CPU 1", "CPU 3"}) {
+		t.Errorf("Expected CPU 1 and CPU 3 to tie for the low, got %v", a.Leaders)
+	}
+}
+
+func TestAnnotateHand_Outdrawn(t *testing.T) {
+	// YOU flops a set of kings. The 3c gives CPU 2 a flush, and the Ks then
+	// gives YOU four of a kind. CPU 3 folded the best hand before the flop.
+	h := &HandHistory{
+		Seats: []SeatRecord{
+			{Name: "YOU", HoleCards: mustParseCards(t, "Kh Kd 5s")},
+			{Name: "CPU 2", HoleCards: mustParseCards(t, "9c Tc 2h")},
+			{Name: "CPU 3", HoleCards: mustParseCards(t, "7h 7s 7d")},
+		},
+		Actions: []ActionRecord{{Phase: PhasePreFlop, PlayerName: "CPU 3", Action: ActionFold}},
+		Board:   mustParseCards(t, "Kc 8c 2d 3c Ks"),
+	}
+
+	annotations := AnnotateHand(h, annotationTestRules())
+	if len(annotations) != 2 {
+		t.Fatalf("Expected 2 annotations, got %+v", annotations)
+	}
+	turn, river := annotations[0], annotations[1]
+	if turn.Kind != AnnotationHighOutdrawn || turn.Phase != PhaseTurn || turn.PlayerName != "YOU" || turn.Hand.Rank != poker.ThreeOfAKind ||
+		!reflect.DeepEqual(turn.Leaders, []string{"CPU 2"}) || turn.LeaderHand.Rank != poker.Flush {
+		t.Errorf("Expected YOU's set to be outdrawn by CPU 2's flush on the turn, got %+v", turn)
+	}
+	if river.Kind != AnnotationHighOutdrawn || river.Phase != PhaseRiver || river.PlayerName != "CPU 2" ||
+		!reflect.DeepEqual(river.Leaders, []string{"YOU"}) || river.LeaderHand.Rank != poker.FourOfAKind {
+		t.Errorf("Expected CPU 2's flush to be outdrawn by YOU's quads on the river, got %+v", river)
+	}
+}
+
+func TestAnnotateHand_NoTurnaround(t *testing.T) {
+	// The hand ends on the flop, so there is nothing to annotate.
+	h := &HandHistory{
+		Seats: []SeatRecord{
+			{Name: "YOU", HoleCards: mustParseCards(t, "Kh Kd 5s")},
+			{Name: "CPU 2", HoleCards: mustParseCards(t, "9c Tc 2h")},
+		},
+		Board: mustParseCards(t, "Kc 8c 2d"),
+	}
+	if annotations := AnnotateHand(h, annotationTestRules()); len(annotations) != 0 {
+		t.Errorf("Expected no annotations, got %+v", annotations)
+	}
+}
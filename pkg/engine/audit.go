@@ -0,0 +1,170 @@
+package engine
+
+import "fmt"
+
+// ChipAudit accounts for every chip that moved during a hand. Each player's
+// ending stack must equal their starting stack minus what they put in plus
+// what they won, plus their net from any insurance, every pot tier must be paid
+// out in full, the main pot must hold every dead ante, and the players' net
+// results from the pot must add up to zero.
+type ChipAudit struct {
+	Rows  []ChipAuditRow `json:"rows"`
+	Tiers []PotTierAudit `json:"tiers"`
+}
+
+// ChipAuditRow is one player's line in the audit.
+type ChipAuditRow struct {
+	PlayerName    string `json:"player_name"`
+	StartingStack int    `json:"starting_stack"`
+	// Contributed is the total the player put into the pot, blinds and antes
+	// included, less any uncalled bet returned to them.
+	Contributed int `json:"contributed"`
+	// Ante is the part of Contributed posted as an ante, for the player or
+	// for the whole table.
+	Ante int `json:"ante,omitempty"`
+	// DeadAnte is the part of Ante that was dead money for the main pot: an
+	// ante posted for the whole table, or the player's own if they folded.
+	DeadAnte int `json:"dead_ante,omitempty"`
+	// Won is the total the player won from the pot.
+	Won int `json:"won"`
+	// Insurance is the player's net from insurance taken in the hand: the
+	// payout less the premium. The insurance pool takes up the difference.
+	Insurance   int `json:"insurance,omitempty"`
+	EndingStack int `json:"ending_stack"`
+}
+
+// Net returns the player's result for the hand.
+func (r ChipAuditRow) Net() int {
+	return r.EndingStack - r.StartingStack
+}
+
+// PotTierAudit records how a single pot tier (main pot or side pot) was paid.
+type PotTierAudit struct {
+	// Index is 0 for the main pot and 1, 2, ... for side pots.
+	Index   int `json:"index"`
+	Amount  int `json:"amount"`
+	Awarded int `json:"awarded"`
+	// DeadAntes is the part of Amount that was dead antes. Only the main pot
+	// holds any.
+	DeadAntes int `json:"dead_antes,omitempty"`
+}
+
+// NetTotal returns the sum of every player's net result from the pot, leaving
+// out insurance. It is zero when no chips were created or lost.
+func (a *ChipAudit) NetTotal() int {
+	total := 0
+	for _, row := range a.Rows {
+		total += row.Net() - row.Insurance
+	}
+	return total
+}
+
+// Discrepancies lists every accounting error found in the audit, naming the
+// player or pot tier at fault. It is empty for a balanced hand.
+func (a *ChipAudit) Discrepancies() []string {
+	var problems []string
+	for _, row := range a.Rows {
+		expected := row.StartingStack - row.Contributed + row.Won + row.Insurance
+		if expected == row.EndingStack {
+			continue
+		}
+		if row.Insurance != 0 {
+			problems = append(problems, fmt.Sprintf(
+				"%s: ending stack %d does not match %d - %d + %d %+d = %d",
+				row.PlayerName, row.EndingStack, row.StartingStack, row.Contributed, row.Won, row.Insurance, expected,
+			))
+		} else {
+			problems = append(problems, fmt.Sprintf(
+				"%s: ending stack %d does not match %d - %d + %d = %d",
+				row.PlayerName, row.EndingStack, row.StartingStack, row.Contributed, row.Won, expected,
+			))
+		}
+	}
+	posted, held := 0, 0
+	for _, row := range a.Rows {
+		if row.DeadAnte > row.Ante {
+			problems = append(problems, fmt.Sprintf(
+				"%s: dead ante %d is more than the ante %d posted", row.PlayerName, row.DeadAnte, row.Ante,
+			))
+		}
+		posted += row.DeadAnte
+	}
+	for _, tier := range a.Tiers {
+		if tier.Awarded != tier.Amount {
+			problems = append(problems, fmt.Sprintf(
+				"%s: awarded %d of %d chips", tier.Name(), tier.Awarded, tier.Amount,
+			))
+		}
+		if tier.Index > 0 && tier.DeadAntes > 0 {
+			problems = append(problems, fmt.Sprintf("%s: holds %d in dead antes", tier.Name(), tier.DeadAntes))
+		}
+		held += tier.DeadAntes
+	}
+	if len(a.Tiers) > 0 && held != posted {
+		problems = append(problems, fmt.Sprintf("dead antes: the pots hold %d, but %d were posted", held, posted))
+	}
+	if total := a.NetTotal(); total != 0 {
+		problems = append(problems, fmt.Sprintf("net results sum to %d instead of 0", total))
+	}
+	return problems
+}
+
+// Name returns "Main pot" or "Side pot N".
+func (t PotTierAudit) Name() string {
+	return potTierName(t.Index)
+}
+
+// potTierName names a pot tier by its index: "Main pot" or "Side pot N".
+func potTierName(index int) string {
+	if index == 0 {
+		return "Main pot"
+	}
+	return fmt.Sprintf("Side pot %d", index)
+}
+
+// recordTierAudits adds the payout of each pot tier to the current hand's audit.
+func (g *Game) recordTierAudits(tiers []PotTierAudit) {
+	if g.History == nil {
+		return
+	}
+	if g.History.Audit == nil {
+		g.History.Audit = &ChipAudit{}
+	}
+	g.History.Audit.Tiers = append(g.History.Audit.Tiers, tiers...)
+}
+
+// buildChipAudit completes the current hand's audit with a row per player. It
+// must run after the pot is distributed and before the next hand starts.
+func (g *Game) buildChipAudit() *ChipAudit {
+	audit := g.History.Audit
+	if audit == nil {
+		audit = &ChipAudit{}
+	}
+	won := make(map[string]int)
+	for _, result := range g.History.Results {
+		won[result.PlayerName] += result.AmountWon
+	}
+	insurance := make(map[string]int)
+	if policy := g.History.Insurance; policy != nil && policy.Settled {
+		insurance[policy.PlayerName] = policy.Net()
+	}
+	audit.Rows = nil
+	for _, seat := range g.History.Seats {
+		for _, p := range g.Players {
+			if p.Name != seat.Name {
+				continue
+			}
+			audit.Rows = append(audit.Rows, ChipAuditRow{
+				PlayerName:    p.Name,
+				StartingStack: seat.StartingChips,
+				Contributed:   p.TotalBetInHand + p.DeadAnte,
+				Ante:          p.Ante + p.DeadAnte,
+				DeadAnte:      p.deadAnte(),
+				Won:           won[p.Name],
+				Insurance:     insurance[p.Name],
+				EndingStack:   p.Chips,
+			})
+		}
+	}
+	return audit
+}
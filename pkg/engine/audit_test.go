@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChipAudit_BalancedHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+
+	audit := g.History.Audit
+	if audit == nil {
+		t.Fatal("expected the hand history to include a chip audit")
+	}
+	if len(audit.Rows) != 3 {
+		t.Fatalf("expected 3 audit rows, got %d", len(audit.Rows))
+	}
+	if problems := audit.Discrepancies(); len(problems) != 0 {
+		t.Errorf("expected a balanced audit, got %v", problems)
+	}
+	// The big blind's 50 nobody called goes back before the pot is awarded.
+	if len(audit.Tiers) != 1 || audit.Tiers[0].Amount != 100 {
+		t.Errorf("expected a single 100-chip tier, got %+v", audit.Tiers)
+	}
+}
+
+func TestChipAudit_Discrepancies(t *testing.T) {
+	audit := &ChipAudit{
+		Rows: []ChipAuditRow{
+			{PlayerName: "YOU", StartingStack: 1000, Contributed: 500, Won: 0, EndingStack: 500},
+			{PlayerName: "CPU 1", StartingStack: 1000, Contributed: 500, Won: 999, EndingStack: 1499},
+		},
+		Tiers: []PotTierAudit{
+			{Index: 0, Amount: 800, Awarded: 800},
+			{Index: 1, Amount: 200, Awarded: 199},
+		},
+	}
+
+	problems := audit.Discrepancies()
+	if len(problems) != 2 {
+		t.Fatalf("expected 2 discrepancies, got %v", problems)
+	}
+	if !strings.HasPrefix(problems[0], "Side pot 1") {
+		t.Errorf("expected the short side pot to be reported, got %q", problems[0])
+	}
+	if audit.NetTotal() != -1 {
+		t.Errorf("expected a net total of -1, got %d", audit.NetTotal())
+	}
+}
+
+func TestChipAudit_DeadAntes(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Ante = 10
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+
+	audit := g.History.Audit
+	if problems := audit.Discrepancies(); len(problems) != 0 {
+		t.Errorf("expected a balanced audit, got %v", problems)
+	}
+	// The button and the small blind folded, leaving their antes dead.
+	dead := 0
+	for _, row := range audit.Rows {
+		if row.Ante != 10 {
+			t.Errorf("expected %s to have posted a 10 ante, got %d", row.PlayerName, row.Ante)
+		}
+		dead += row.DeadAnte
+	}
+	if dead != 20 || audit.Tiers[0].DeadAntes != 20 {
+		t.Errorf("expected 20 in dead antes, posted and in the main pot, got %d and %+v", dead, audit.Tiers)
+	}
+
+	// Dead antes in a side pot are an accounting error.
+	audit.Tiers = []PotTierAudit{
+		{Index: 0, Amount: 160, Awarded: 160},
+		{Index: 1, Amount: 20, Awarded: 20, DeadAntes: 20},
+	}
+	if problems := audit.Discrepancies(); len(problems) != 1 || !strings.HasPrefix(problems[0], "Side pot 1") {
+		t.Errorf("expected the side pot's dead antes to be reported, got %v", problems)
+	}
+}
@@ -1,5 +1,7 @@
 package engine
 
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
 // BettingLimitCalculator defines an interface for calculating valid bet and raise
 // sizes based on a specific betting structure (e.g., Pot-Limit, No-Limit).
 // This allows the game engine to handle different poker variants by plugging in
@@ -74,3 +76,126 @@ func (c *NoLimitCalculator) CalculateBettingLimits(g *Game) (minRaiseTotal int,
 
 	return minRaiseTotal, maxRaiseTotal
 }
+
+// DefaultRaiseCap is the most bets a fixed-limit street may have when the
+// rules do not set a cap: a bet and three raises.
+const DefaultRaiseCap = 4
+
+// FixedLimitCalculator implements the BettingLimitCalculator for Fixed-Limit
+// games, where every bet and raise is exactly one bet of the street's size.
+type FixedLimitCalculator struct {
+	Rules poker.FixedLimitRules
+}
+
+// CalculateBettingLimits calculates the only raise allowed in a Fixed-Limit
+// game: one bet more than the bet to call. A raise that completes a short
+// all-in counts from the last full bet. A player without enough chips may
+// still go all-in for less. Whether the street is capped is reported by
+// Game.BettingCapped, not by the limits.
+func (c *FixedLimitCalculator) CalculateBettingLimits(g *Game) (minRaiseTotal int, maxRaiseTotal int) {
+	player := g.Players[g.CurrentTurnPos]
+	size := c.BetSize(g)
+	raiseTotal := (g.BetToCall/size + 1) * size
+	raiseTotal = min(raiseTotal, player.Chips+player.CurrentBet)
+	return raiseTotal, raiseTotal
+}
+
+// BetSize returns the size of a bet or raise on the current street: the small
+// bet pre-flop and on the flop, and the big bet on the turn and river.
+func (c *FixedLimitCalculator) BetSize(g *Game) int {
+	small, big := c.BetSizes(g.BigBlind)
+	if g.Phase <= PhaseFlop {
+		return small
+	}
+	return big
+}
+
+// BetSizes returns the small and big bets at the given big blind.
+func (c *FixedLimitCalculator) BetSizes(bigBlind int) (small, big int) {
+	small, big = c.Rules.SmallBet, c.Rules.BigBet
+	if small == 0 {
+		small = bigBlind
+	}
+	if big == 0 {
+		big = 2 * small
+	}
+	return small, big
+}
+
+// RaiseCap returns the most bets a street may have.
+func (c *FixedLimitCalculator) RaiseCap() int {
+	if c.Rules.RaiseCap > 0 {
+		return c.Rules.RaiseCap
+	}
+	return DefaultRaiseCap
+}
+
+// BettingCapped reports whether the current street has had as many bets as
+// the fixed limit allows, so the player to act may only call or fold. It is
+// always false in Pot-Limit and No-Limit games.
+func (g *Game) BettingCapped() bool {
+	c, ok := g.BettingCalculator.(*FixedLimitCalculator)
+	return ok && g.BetToCall/c.BetSize(g) >= c.RaiseCap()
+}
+
+// ChipUnit returns the smallest chip in play under the game's rules. Every
+// bet, raise and blind is a multiple of it, except an all-in for a stack that
+// is not.
+func (g *Game) ChipUnit() int {
+	if g.Rules == nil || g.Rules.ChipUnit < 1 {
+		return 1
+	}
+	return g.Rules.ChipUnit
+}
+
+// RoundToChipUnit rounds an amount to the nearest multiple of the chip unit,
+// halves rounding up.
+func (g *Game) RoundToChipUnit(amount int) int {
+	unit := g.ChipUnit()
+	return (amount + unit/2) / unit * unit
+}
+
+// RoundBetAmount rounds a bet or raise total for the player to act to the
+// nearest multiple of the chip unit. Going all-in is never rounded, and an
+// amount that would round past the player's stack is rounded down instead.
+func (g *Game) RoundBetAmount(amount int) int {
+	player := g.Players[g.CurrentTurnPos]
+	allIn := player.Chips + player.CurrentBet
+	if amount >= allIn {
+		return amount
+	}
+	rounded := g.RoundToChipUnit(amount)
+	if rounded > allIn {
+		unit := g.ChipUnit()
+		rounded = amount / unit * unit
+	}
+	return rounded
+}
+
+// roundBettingLimits rounds a raise range to the chip unit: the minimum up and
+// the maximum down, so every multiple between them is legal. An all-in limit
+// is kept as it is. When no multiple fits between the limits, the minimum
+// rounded up is allowed as the maximum too, even over the pot limit.
+func (g *Game) roundBettingLimits(minRaiseTotal, maxRaiseTotal int) (int, int) {
+	unit := g.ChipUnit()
+	if unit == 1 {
+		return minRaiseTotal, maxRaiseTotal
+	}
+	player := g.Players[g.CurrentTurnPos]
+	allIn := player.Chips + player.CurrentBet
+	if minRaiseTotal < allIn {
+		minRaiseTotal = min((minRaiseTotal+unit-1)/unit*unit, allIn)
+	}
+	if maxRaiseTotal < allIn {
+		maxRaiseTotal = max(maxRaiseTotal/unit*unit, minRaiseTotal)
+	}
+	return minRaiseTotal, maxRaiseTotal
+}
+
+// roundBlinds rounds the blinds and ante up to multiples of the chip unit, so
+// no forced bet needs a chip smaller than the rules allow.
+func (g *Game) roundBlinds() {
+	unit := g.ChipUnit()
+	roundUp := func(amount int) int { return (amount + unit - 1) / unit * unit }
+	g.SmallBlind, g.BigBlind, g.Ante = roundUp(g.SmallBlind), roundUp(g.BigBlind), roundUp(g.Ante)
+}
@@ -1,6 +1,9 @@
 package engine
 
-import "testing"
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
 
 // MockCalculator is a mock implementation of BettingLimitCalculator for testing.
 type MockCalculator struct {
@@ -92,3 +95,163 @@ func TestNoLimitCalculator(t *testing.T) {
 		t.Errorf("expected max raise to be %d, got %d", expectedMax, max)
 	}
 }
+
+// TestCalculateBettingLimits_ChipUnit tests that the raise range is rounded to
+// the rules' chip unit: the minimum up, the maximum down, and an all-in never.
+func TestCalculateBettingLimits_ChipUnit(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		pot, betToCall, raise    int
+		chips                    int
+		expectedMin, expectedMax int
+	}{
+		{"limits already multiples", 1500, 1000, 1000, 10000, 2000, 3500},
+		{"pot limit rounded down", 1550, 1000, 1000, 10000, 2000, 3500},
+		{"minimum raise rounded up", 1500, 1000, 1050, 10000, 2100, 3500},
+		{"all-in is not rounded", 1500, 1000, 1000, 3480, 2000, 3480},
+		{"short all-in below the minimum", 1500, 1000, 1000, 1950, 1950, 1950},
+		{"no multiple between the limits", 70, 1010, 1000, 10000, 2100, 2100},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "PLS")
+			g.Rules.ChipUnit = 100
+			g.Pot = tc.pot
+			g.BetToCall = tc.betToCall
+			g.LastRaiseAmount = tc.raise
+			g.CurrentTurnPos = 0
+			g.Players[0].Chips = tc.chips
+
+			min, max := g.CalculateBettingLimits()
+			if min != tc.expectedMin || max != tc.expectedMax {
+				t.Errorf("expected limits %d-%d, got %d-%d", tc.expectedMin, tc.expectedMax, min, max)
+			}
+		})
+	}
+}
+
+// TestRoundBetAmount tests rounding bets to the nearest multiple of the chip unit.
+func TestRoundBetAmount(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 3480, 500, 1000, "NLH")
+	g.CurrentTurnPos = 0
+
+	testCases := []struct {
+		amount, chipUnit, expected int
+	}{
+		{2250, 0, 2250},
+		{2249, 100, 2200},
+		{2250, 100, 2300},
+		{3460, 100, 3400}, // Rounding up would go past the stack.
+		{3480, 100, 3480}, // All-in.
+		{3600, 100, 3600}, // More than the stack is left for the limits to reject.
+	}
+	for _, tc := range testCases {
+		g.Rules.ChipUnit = tc.chipUnit
+		if got := g.RoundBetAmount(tc.amount); got != tc.expected {
+			t.Errorf("RoundBetAmount(%d) with a chip unit of %d: expected %d, got %d", tc.amount, tc.chipUnit, tc.expected, got)
+		}
+	}
+}
+
+// TestFixedLimitCalculator tests that a fixed-limit raise is always one bet of
+// the street's size over the bet to call, or all-in for less.
+func TestFixedLimitCalculator(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rules     poker.FixedLimitRules
+		phase     GamePhase
+		betToCall int
+		chips     int
+		expected  int
+	}{
+		{"pre-flop raise of the big blind", poker.FixedLimitRules{}, PhasePreFlop, 1000, 10000, 2000},
+		{"flop bet is the small bet", poker.FixedLimitRules{}, PhaseFlop, 0, 10000, 1000},
+		{"turn bet is the big bet", poker.FixedLimitRules{}, PhaseTurn, 0, 10000, 2000},
+		{"river raise", poker.FixedLimitRules{}, PhaseRiver, 4000, 10000, 6000},
+		{"raise over a short all-in", poker.FixedLimitRules{}, PhasePreFlop, 1500, 10000, 2000},
+		{"short stack goes all-in", poker.FixedLimitRules{}, PhaseFlop, 1000, 1500, 1500},
+		{"sizes set by the rules", poker.FixedLimitRules{SmallBet: 400, BigBet: 1000}, PhaseTurn, 1000, 10000, 2000},
+		{"big bet defaults to twice the small bet", poker.FixedLimitRules{SmallBet: 400}, PhaseRiver, 0, 10000, 800},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "LHE")
+			g.Phase = tc.phase
+			g.BetToCall = tc.betToCall
+			g.CurrentTurnPos = 0
+			g.Players[0].CurrentBet = 0
+			g.Players[0].Chips = tc.chips
+
+			calculator := &FixedLimitCalculator{Rules: tc.rules}
+			min, max := calculator.CalculateBettingLimits(g)
+			if min != tc.expected || max != tc.expected {
+				t.Errorf("expected the raise to be exactly %d, got %d-%d", tc.expected, min, max)
+			}
+		})
+	}
+}
+
+// TestBettingCapped tests that a fixed-limit street is capped after the rules'
+// number of bets, the big blind counting as the first bet pre-flop.
+func TestBettingCapped(t *testing.T) {
+	testCases := []struct {
+		name      string
+		rule      string
+		raiseCap  int
+		phase     GamePhase
+		betToCall int
+		expected  bool
+	}{
+		{"pre-flop three bets", "LHE", 0, PhasePreFlop, 3000, false},
+		{"pre-flop four bets", "LHE", 0, PhasePreFlop, 4000, true},
+		{"turn three big bets", "LHE", 0, PhaseTurn, 6000, false},
+		{"turn four big bets", "LHE", 0, PhaseTurn, 8000, true},
+		{"cap set by the rules", "LHE", 3, PhaseFlop, 3000, true},
+		{"short all-in over the cap is not a full bet", "LHE", 0, PhasePreFlop, 3500, false},
+		{"no limit is never capped", "NLH", 0, PhasePreFlop, 100000, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1"}, 10000, 500, 1000, tc.rule)
+			if c, ok := g.BettingCalculator.(*FixedLimitCalculator); ok {
+				c.Rules.RaiseCap = tc.raiseCap
+			}
+			g.Phase = tc.phase
+			g.BetToCall = tc.betToCall
+			if got := g.BettingCapped(); got != tc.expected {
+				t.Errorf("expected BettingCapped() = %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+// TestFixedLimit_CappedRaises tests that once a street is capped, raises are
+// refused at the prompt's command parser, left out of the quick bets, and
+// turned into calls for the CPUs.
+func TestFixedLimit_CappedRaises(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1"}, 10000, 500, 1000, "LHE")
+	g.Phase = PhaseFlop
+	g.CurrentTurnPos = 0
+	player := g.Players[0]
+	player.CurrentBet = 1000
+
+	g.BetToCall = 3000
+	options := g.QuickBetOptions(player)
+	if len(options) != 1 || options[0].Action.Amount != 4000 {
+		t.Errorf("expected a single quick raise to 4000, got %+v", options)
+	}
+	if action := g.fitFixedLimit(PlayerAction{Type: ActionRaise, Amount: 9000}); action.Amount != 4000 {
+		t.Errorf("expected a CPU raise to be sized to 4000, got %+v", action)
+	}
+
+	g.BetToCall = 4000
+	if _, err := g.ResolveActionCommand(player, ActionCommand{Type: ActionRaise, Unit: BetSizeAllIn}); err == nil {
+		t.Error("expected a raise to be refused once the betting is capped")
+	}
+	if options := g.QuickBetOptions(player); options != nil {
+		t.Errorf("expected no quick raises once the betting is capped, got %+v", options)
+	}
+	if action := g.fitFixedLimit(PlayerAction{Type: ActionRaise, Amount: 5000}); action.Type != ActionCall {
+		t.Errorf("expected a CPU raise to become a call once the betting is capped, got %+v", action)
+	}
+}
@@ -1,8 +1,8 @@
 package engine
 
 import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 	"math/rand"
-	"pls7-cli/pkg/poker"
 	"testing"
 )
 
@@ -31,6 +31,17 @@ func newGameForBettingTests(playerNames []string, initialChips int, smallBlind i
 	return NewGame(playerNames, initialChips, smallBlind, bigBlind, DifficultyMedium, rules, true, false, 0)
 }
 
+// mustParseCards parses cards for a test, failing it on malformed or
+// duplicate cards.
+func mustParseCards(t *testing.T, s string) []poker.Card {
+	t.Helper()
+	cards, err := poker.ParseCards(s)
+	if err != nil {
+		t.Fatalf("Failed to parse cards %q: %v", s, err)
+	}
+	return cards
+}
+
 // newGameForBettingTestsWithRules creates a game with a specific rule abbreviation.
 func newGameForBettingTestsWithRules(playerNames []string, initialChips int, smallBlind int, bigBlind int, ruleAbbr string) *Game {
 	rules := &poker.GameRules{
@@ -41,6 +52,10 @@ func newGameForBettingTestsWithRules(playerNames []string, initialChips int, sma
 		rules.HoleCards = poker.HoleCardRules{Count: 2}
 		rules.LowHand = poker.LowHandRules{Enabled: false}
 		rules.BettingLimit = "no_limit"
+	case "LHE":
+		rules.HoleCards = poker.HoleCardRules{Count: 2}
+		rules.LowHand = poker.LowHandRules{Enabled: false}
+		rules.BettingLimit = "fixed_limit"
 	case "PLS7":
 		rules.HoleCards = poker.HoleCardRules{Count: 3}
 		rules.LowHand = poker.LowHandRules{Enabled: ruleAbbr == "PLS7", MaxRank: 7}
@@ -136,4 +151,133 @@ func TestIsBettingRoundOver(t *testing.T) {
 			t.Error("Expected betting round to BE over when a player is all-in and cannot call a raise")
 		}
 	})
+
+	t.Run("Round over - only one player can act and owes nothing", func(t *testing.T) {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1"}, 10000, 500, 1000, "NLH")
+		g.Players[0].Status = PlayerStatusAllIn
+		g.Players[1].Status = PlayerStatusPlaying
+		g.ActionsTakenThisRound = 0 // A new street, before anyone has acted
+		if !g.IsBettingRoundOver() {
+			t.Error("Expected betting round to BE over when only one player can act")
+		}
+	})
+
+	t.Run("Round not over - only one player can act but must call an all-in", func(t *testing.T) {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1"}, 10000, 500, 1000, "NLH")
+		g.Players[0].Status = PlayerStatusAllIn
+		g.Players[0].CurrentBet = 5000
+		g.Players[1].Status = PlayerStatusPlaying
+		g.Players[1].CurrentBet = 1000
+		g.BetToCall = 5000
+		g.ActionsTakenThisRound = 1
+		if g.IsBettingRoundOver() {
+			t.Error("Expected betting round to NOT be over while the all-in bet is uncalled")
+		}
+	})
+}
+
+// shoveProvider bets the effective stack, the most anyone can call, when it
+// may and calls otherwise, counting the actions it is asked for on each
+// street.
+type shoveProvider struct {
+	asked map[GamePhase]int
+}
+
+func (p *shoveProvider) GetAction(g *Game, player *Player, _ *rand.Rand) PlayerAction {
+	p.asked[g.Phase]++
+	if player.CurrentBet < g.BetToCall {
+		return PlayerAction{Type: ActionCall}
+	}
+	amount := player.CurrentBet + player.Chips
+	for _, other := range g.Players {
+		if other != player && other.Status != PlayerStatusFolded {
+			amount = min(amount, other.CurrentBet+other.Chips)
+		}
+	}
+	return PlayerAction{Type: ActionRaise, Amount: amount}
+}
+
+// playShovedHand plays a hand to the showdown the way the CLI does, with
+// every player asked by the provider.
+func playShovedHand(g *Game, provider ActionProvider) {
+	g.StartNewHand()
+	for g.Phase != PhaseShowdown && g.Phase != PhaseHandOver {
+		if g.CountNonFoldedPlayers() <= 1 {
+			break
+		}
+		g.PrepareNewBettingRound()
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+			if player.Status != PlayerStatusPlaying {
+				g.AdvanceTurn()
+				continue
+			}
+			g.ProcessAction(player, provider.GetAction(g, player, g.Rand))
+			g.AdvanceTurn()
+		}
+		g.Advance()
+	}
+}
+
+// TestHand_NoBettingOnceFewerThanTwoCanAct tests that once the chips are in,
+// the rest of the board is dealt without asking anyone to act: whether one
+// player still has chips behind, or everyone is all-in.
+func TestHand_NoBettingOnceFewerThanTwoCanAct(t *testing.T) {
+	testCases := []struct {
+		name   string
+		stacks []int
+		behind int // The players left with chips behind after the pre-flop.
+	}{
+		{"one player with chips", []int{10000, 3000}, 1},
+		{"everyone all-in", []int{10000, 10000, 10000}, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names := []string{"YOU", "CPU1", "CPU2"}[:len(tc.stacks)]
+			g := newGameForBettingTestsWithRules(names, 0, 500, 1000, "NLH")
+			for i, chips := range tc.stacks {
+				g.Players[i].Chips = chips
+			}
+			provider := &shoveProvider{asked: make(map[GamePhase]int)}
+
+			playShovedHand(g, provider)
+
+			if g.Phase != PhaseShowdown || len(g.CommunityCards) != 5 {
+				t.Fatalf("Expected the board to be run out to the showdown, but got %s with %d cards", g.Phase, len(g.CommunityCards))
+			}
+			if got := g.CountPlayersAbleToAct(); got != tc.behind {
+				t.Errorf("Expected %d players with chips behind, but got %d", tc.behind, got)
+			}
+			for _, phase := range []GamePhase{PhaseFlop, PhaseTurn, PhaseRiver} {
+				if provider.asked[phase] > 0 {
+					t.Errorf("Expected nobody to be asked to act on the %s, but got %d actions", phase, provider.asked[phase])
+				}
+			}
+		})
+	}
+}
+
+// TestProcessAction_BetIsATotal tests that a bet's amount is the total bet
+// to, so the big blind betting on its option puts in only the difference.
+func TestProcessAction_BetIsATotal(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "NLH")
+	g.StartNewHand()
+	for g.CurrentTurnPos != g.BigBlindPos {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCall})
+		g.AdvanceTurn()
+	}
+	bb := g.CurrentPlayer()
+	chips := bb.Chips
+
+	_, event := g.ProcessAction(bb, PlayerAction{Type: ActionBet, Amount: 3000})
+
+	if bb.CurrentBet != 3000 || chips-bb.Chips != 2000 {
+		t.Errorf("Expected the big blind to put in 2000 more for 3000 in total, but it put in %d for %d", chips-bb.Chips, bb.CurrentBet)
+	}
+	if g.BetToCall != 3000 || g.LastRaiseAmount != 2000 {
+		t.Errorf("Expected a bet to call of 3000 raised by 2000, but got %d raised by %d", g.BetToCall, g.LastRaiseAmount)
+	}
+	if event.Amount != 3000 || bb.LastActionDesc != "Bet to 3000" {
+		t.Errorf("Expected the bet to be shown as a total of 3000, but got %d and %q", event.Amount, bb.LastActionDesc)
+	}
 }
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+)
+
+// blindDefenseSamples is the number of runouts simulated against each range
+// when advising on a blind defense.
+const blindDefenseSamples = 2000
+
+// threeBetMultiplier sizes the 3-bet a blind defense is advised with, as a
+// multiple of the steal.
+const threeBetMultiplier = 3
+
+// BlindDefense is the coach's advice for a blind facing a steal: a lone raise
+// from the cutoff or the button, everyone else having folded. The expected
+// values are in chips, against folding, with the equity realized at a
+// showdown and no further betting.
+type BlindDefense struct {
+	// Stealer is the name of the player who raised.
+	Stealer string
+	// StealRange is the share of all hands the stealer raises with, as the
+	// AI models them, and Equity the hand's equity against that range.
+	StealRange float64
+	Equity     float64
+	// BreakEven is the equity calling needs.
+	BreakEven float64
+	CallEV    float64
+	// ThreeBetTo is the total the advice 3-bets to, or 0 if a raise is not
+	// allowed. FoldToThreeBet is the share of the steal range the stealer
+	// folds to it, and ThreeBetEquity the hand's equity against the rest.
+	ThreeBetTo     int
+	FoldToThreeBet float64
+	ThreeBetEquity float64
+	ThreeBetEV     float64
+	// Advice is the best of folding, calling and raising.
+	Advice ActionType
+}
+
+// AdviseBlindDefense simulates the player's hand against the modeled range of
+// a CPU stealing their blind, and advises on folding, calling or 3-betting.
+// It returns nil if the player, to act pre-flop, is not defending a blind
+// against a steal. r is used for the simulation only.
+func (g *Game) AdviseBlindDefense(ctx context.Context, player *Player, r *rand.Rand) (*BlindDefense, error) {
+	stealer := g.blindStealer(player)
+	if stealer == nil {
+		return nil, nil
+	}
+	// A CPU raises the hands scoring its raise threshold, widened for its
+	// late position, and stands up to a 3-bet with those that clear its
+	// threshold from any seat.
+	stealThreshold := stealer.Profile.RaiseHandThreshold * positionThresholdScales[PositionLate]
+	continueThreshold := stealer.Profile.RaiseHandThreshold
+	steals := func(hand []poker.Card) bool { return g.startingHandScore(hand) >= stealThreshold }
+	continues := func(hand []poker.Card) bool { return g.startingHandScore(hand) >= continueThreshold }
+
+	d := &BlindDefense{Stealer: stealer.Name, Advice: ActionFold}
+	var err error
+	if d.Equity, d.StealRange, err = poker.EstimateRangeEquity(ctx, player.Hand, nil, steals, blindDefenseSamples, g.Rules, r); err != nil {
+		return nil, err
+	}
+	toCall := g.BetToCall - player.CurrentBet
+	d.BreakEven = poker.CalculateBreakEvenEquityBasedOnPotOdds(g.Pot, toCall)
+	d.CallEV = d.Equity*float64(g.Pot+toCall) - float64(toCall)
+	if d.CallEV > 0 {
+		d.Advice = ActionCall
+	}
+
+	_, maxRaise := g.CalculateBettingLimits()
+	if maxRaise <= g.BetToCall {
+		return d, nil
+	}
+	d.ThreeBetTo = g.clampRaiseAmount(threeBetMultiplier * g.BetToCall)
+	var continueRange float64
+	if d.ThreeBetEquity, continueRange, err = poker.EstimateRangeEquity(ctx, player.Hand, nil, continues, blindDefenseSamples, g.Rules, r); err != nil {
+		return nil, err
+	}
+	d.FoldToThreeBet = min(max(1-continueRange/d.StealRange, 0), 1)
+	// Called, the 3-bet is matched by the stealer on top of their raise.
+	risked := d.ThreeBetTo - player.CurrentBet
+	calledPot := g.Pot + risked + min(d.ThreeBetTo-stealer.CurrentBet, stealer.Chips)
+	d.ThreeBetEV = d.FoldToThreeBet*float64(g.Pot) +
+		(1-d.FoldToThreeBet)*(d.ThreeBetEquity*float64(calledPot)-float64(risked))
+	if d.ThreeBetEV > 0 && d.ThreeBetEV > d.CallEV {
+		d.Advice = ActionRaise
+	}
+	return d, nil
+}
+
+// blindStealer returns the CPU that raised from late position while everyone
+// else folded, if the player to act is in a blind facing that raise alone;
+// otherwise it returns nil.
+func (g *Game) blindStealer(player *Player) *Player {
+	if g.Phase != PhasePreFlop || g.History == nil || g.positionGroup(player) != PositionBlinds {
+		return nil
+	}
+	var stealer *Player
+	for _, a := range g.History.Actions {
+		switch {
+		case a.Phase != PhasePreFlop || a.Action == ActionFold:
+			continue
+		case a.Action != ActionRaise || stealer != nil || positionGroupOf(a.Position) != PositionLate:
+			return nil
+		}
+		for _, p := range g.Players {
+			if p.Name == a.PlayerName {
+				stealer = p
+			}
+		}
+	}
+	if stealer == nil || !stealer.IsCPU || stealer.Profile == nil || stealer.Status != PlayerStatusPlaying {
+		return nil
+	}
+	return stealer
+}
@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"testing"
+)
+
+// dealToBigBlind deals hands until the human is in the big blind, and folds
+// the action round to the player on the button, who opens with raiseTo.
+func dealToBigBlind(t *testing.T, g *Game, raiseFrom string, raiseTo int) {
+	t.Helper()
+	for i := 0; ; i++ {
+		if i == len(g.Players) {
+			t.Fatal("Expected the human to be dealt the big blind")
+		}
+		g.StartNewHand()
+		if g.BigBlindPos == 0 {
+			break
+		}
+		g.PrepareNewBettingRound()
+		for g.CountNonFoldedPlayers() > 1 {
+			g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+			g.AdvanceTurn()
+		}
+		g.AwardPotToLastPlayer()
+		g.CleanupHand()
+	}
+	g.PrepareNewBettingRound()
+	positions := g.seatPositions(g.SmallBlindPos, g.BigBlindPos)
+	for player := g.CurrentPlayer(); player != g.Players[0]; player = g.CurrentPlayer() {
+		action := PlayerAction{Type: ActionFold}
+		if positions[player.Name] == raiseFrom {
+			action = PlayerAction{Type: ActionRaise, Amount: raiseTo}
+		}
+		g.ProcessAction(player, action)
+		g.AdvanceTurn()
+	}
+}
+
+func TestAdviseBlindDefense(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100, "NLH")
+	dealToBigBlind(t, g, PositionButton, 250)
+	g.Players[0].Hand = poker.CardsFromStrings("As Ah")
+
+	d, err := g.AdviseBlindDefense(context.Background(), g.Players[0], rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to advise: %v", err)
+	}
+	if d == nil {
+		t.Fatal("Expected a steal from the button to be defended")
+	}
+	if d.StealRange <= 0 || d.StealRange >= 1 {
+		t.Errorf("Expected the steal range to hold some of the hands, but got %.3f", d.StealRange)
+	}
+	if d.Equity < 0.7 || d.CallEV <= 0 {
+		t.Errorf("Expected aces to be far ahead of a steal, but got %.3f equity and %.1f to call", d.Equity, d.CallEV)
+	}
+	if d.Advice != ActionRaise || d.ThreeBetTo != 750 {
+		t.Errorf("Expected a 3-bet to 750, but got %v to %d", d.Advice, d.ThreeBetTo)
+	}
+}
+
+func TestAdviseBlindDefense_NotASteal(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2", "CPU 3", "CPU 4"}, 10000, 50, 100, "NLH")
+	// The seat after the big blind is not a stealing position.
+	dealToBigBlind(t, g, "UTG", 250)
+	if g.BetToCall != 250 {
+		t.Fatalf("Expected UTG to raise to 250, but the bet is %d", g.BetToCall)
+	}
+
+	d, err := g.AdviseBlindDefense(context.Background(), g.Players[0], rand.New(rand.NewSource(1)))
+	if err != nil || d != nil {
+		t.Errorf("Expected no advice against an early raise, but got %+v (%v)", d, err)
+	}
+}
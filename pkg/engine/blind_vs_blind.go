@@ -0,0 +1,56 @@
+package engine
+
+// Blind-versus-blind pots, folded round to the small blind, are played much
+// wider than the positional thresholds would have it: each blind holds a
+// random hand, with a bet already in, against a single opponent. A CPU plays
+// them with its own thresholds scaled as follows.
+const (
+	// bvbPlayScale scales the play threshold of either blind.
+	bvbPlayScale = 0.7
+	// bvbAggressiveRaiseScale scales the raise threshold of an aggressive
+	// CPU, which raises about as wide as it plays.
+	bvbAggressiveRaiseScale = 0.75
+	// bvbThreeBetScale grows an aggressive CPU's re-raises, made against
+	// the other blind's wide range.
+	bvbThreeBetScale = 1.3
+)
+
+// bvbPassiveAggression is the aggression factor below which a CPU plays
+// passively: a passive small blind completes with most of its hands instead
+// of raising, keeping its raise threshold as it is.
+const bvbPassiveAggression = 0.5
+
+// blindVersusBlind reports whether the player, in a blind before the flop, is
+// in a blind-versus-blind pot: every other player dealt in has folded, and
+// only the two blinds are left. A heads-up game, with no one to fold to the
+// blinds, is not one.
+func (g *Game) blindVersusBlind(player *Player) bool {
+	if g.Phase != PhasePreFlop || g.positionGroup(player) != PositionBlinds || g.SmallBlindPos < 0 {
+		return false
+	}
+	foldedToBlinds := false
+	for _, p := range g.Players {
+		switch {
+		case p.Status == PlayerStatusEliminated:
+		case g.positionGroup(p) == PositionBlinds:
+			if p.Status == PlayerStatusFolded {
+				return false
+			}
+		case p.Status != PlayerStatusFolded:
+			return false
+		default:
+			foldedToBlinds = true
+		}
+	}
+	return foldedToBlinds
+}
+
+// blindVersusBlindScales returns the factors a CPU's pre-flop play and raise
+// thresholds are scaled by in a blind-versus-blind pot: both ranges widen for
+// an aggressive CPU, and only the range it plays for a passive one.
+func blindVersusBlindScales(profile *AIProfile) (play, raise float64) {
+	if profile.AggressionFactor < bvbPassiveAggression {
+		return bvbPlayScale, 1
+	}
+	return bvbPlayScale, bvbAggressiveRaiseScale
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// foldToSmallBlind deals a hand and folds the action round to the small
+// blind, returning the small and big blinds.
+func foldToSmallBlind(g *Game) (sb, bb *Player) {
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CurrentTurnPos != g.SmallBlindPos {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	return g.Players[g.SmallBlindPos], g.Players[g.BigBlindPos]
+}
+
+func TestBlindVersusBlind(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100, "NLH")
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	if sb := g.Players[g.SmallBlindPos]; g.blindVersusBlind(sb) {
+		t.Error("Expected no blind-versus-blind pot before the others fold")
+	}
+
+	sb, bb := foldToSmallBlind(g)
+	if !g.blindVersusBlind(sb) || !g.blindVersusBlind(bb) {
+		t.Error("Expected a blind-versus-blind pot once folded round to the small blind")
+	}
+	if button := g.Players[g.DealerPos]; g.blindVersusBlind(button) {
+		t.Error("Expected the folded button not to be in a blind-versus-blind pot")
+	}
+
+	headsUp := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, 10000, 50, 100, "NLH")
+	headsUp.StartNewHand()
+	headsUp.PrepareNewBettingRound()
+	for _, p := range headsUp.Players {
+		if headsUp.blindVersusBlind(p) {
+			t.Errorf("Expected a heads-up game not to be played as blind versus blind, but %s is", p.Name)
+		}
+	}
+}
+
+func TestBlindVersusBlind_WiderRanges(t *testing.T) {
+	passive, aggressive := builtInProfile("Tight-Passive"), builtInProfile("Loose-Aggressive")
+	testCases := []struct {
+		name     string
+		profile  AIProfile
+		strength func(AIProfile) float64
+		before   ActionType
+		after    ActionType
+	}{
+		// A hand below the blinds' usual play threshold is played.
+		{"Passive limps wider", passive, func(p AIProfile) float64 { return 0.8 * p.PlayHandThreshold }, ActionFold, ActionCall},
+		// A passive small blind completes a hand that the blinds' widened
+		// raise threshold would raise with, short of its own...
+		{"Passive limps instead of raising", passive, func(p AIProfile) float64 { return 0.98 * p.RaiseHandThreshold }, ActionRaise, ActionCall},
+		// ... and an aggressive one raises with it.
+		{"Aggressive raises wider", aggressive, func(p AIProfile) float64 { return 0.8 * p.RaiseHandThreshold }, ActionCall, ActionRaise},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100, "NLH")
+			profile := tc.profile
+			for _, p := range g.Players {
+				p.Profile = &profile
+			}
+			g.handEvaluator = func(*Game, *Player) float64 { return tc.strength(profile) }
+			g.StartNewHand()
+			g.PrepareNewBettingRound()
+			sb := g.Players[g.SmallBlindPos]
+			if got := g.decideCPUAction(sb, rand.New(rand.NewSource(1))).Type; got != tc.before {
+				t.Errorf("Expected the small blind to %v with others in the hand, but got %v", tc.before, got)
+			}
+			for g.CurrentTurnPos != g.SmallBlindPos {
+				g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+				g.AdvanceTurn()
+			}
+			if got := g.decideCPUAction(sb, rand.New(rand.NewSource(1))).Type; got != tc.after {
+				t.Errorf("Expected the small blind to %v against the big blind alone, but got %v", tc.after, got)
+			}
+		})
+	}
+}
+
+func TestBlindVersusBlind_BiggerThreeBets(t *testing.T) {
+	for _, name := range []string{"Loose-Aggressive", "Tight-Passive"} {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100, "NLH")
+		profile := builtInProfile(name)
+		for _, p := range g.Players {
+			p.Profile = &profile
+		}
+		sb, bb := foldToSmallBlind(g)
+		g.ProcessAction(sb, PlayerAction{Type: ActionRaise, Amount: 300})
+		g.AdvanceTurn()
+
+		base := g.raiseAmount(bb, rand.New(rand.NewSource(1)))
+		got := g.preFlopRaiseAmount(bb, rand.New(rand.NewSource(1)))
+		want := base
+		if profile.AggressionFactor >= bvbPassiveAggression {
+			want = g.clampRaiseAmount(int(bvbThreeBetScale * float64(base)))
+		}
+		if got != want || (want == base) != (name == "Tight-Passive") {
+			t.Errorf("%s: expected a 3-bet to %d against a raise to 300, but got %d", name, want, got)
+		}
+	}
+}
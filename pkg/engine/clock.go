@@ -0,0 +1,143 @@
+package engine
+
+import "time"
+
+// TournamentClock tracks time-based blind levels, like the clock displayed on
+// the wall of a casino tournament room. When a game has a clock, the blinds go
+// up at the start of the first hand after the current level's time runs out.
+type TournamentClock struct {
+	// LevelDuration is how long each blind level lasts.
+	LevelDuration time.Duration
+	// Level is the current blind level, starting at 1.
+	Level int
+	// LevelStartedAt is when the current level began.
+	LevelStartedAt time.Time
+	// now returns the current time. It can be replaced in tests.
+	now func() time.Time
+}
+
+// ClockStatus is a snapshot of the tournament clock together with the table
+// information shown alongside it.
+type ClockStatus struct {
+	// Level is the current blind level, starting at 1.
+	Level int
+	// TimeRemaining is the time left until the blinds go up.
+	TimeRemaining time.Duration
+	// NextSmallBlind is the small blind of the next level.
+	NextSmallBlind int
+	// NextBigBlind is the big blind of the next level.
+	NextBigBlind int
+	// NextAnte is the ante each payer posts at the next level, or 0 if there
+	// is none.
+	NextAnte int
+	// AnteFormat is the format NextAnte is posted in.
+	AnteFormat AnteFormat
+	// AverageStack is the average chip count of the players still in the game.
+	AverageStack int
+	// PlayersRemaining is the number of players who have not been eliminated.
+	PlayersRemaining int
+	// HandsRemaining is the number of hands left until the blinds go up, when
+	// the levels are counted in hands rather than time, or 0 otherwise.
+	HandsRemaining int
+}
+
+// NewTournamentClock creates a clock at level 1 that starts running immediately.
+func NewTournamentClock(levelDuration time.Duration) *TournamentClock {
+	c := &TournamentClock{LevelDuration: levelDuration, Level: 1, now: time.Now}
+	c.LevelStartedAt = c.now()
+	return c
+}
+
+// TimeRemaining returns the time left in the current level, never less than zero.
+func (c *TournamentClock) TimeRemaining() time.Duration {
+	remaining := c.LevelDuration - c.now().Sub(c.LevelStartedAt)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// LevelExpired reports whether the current level's time has run out.
+func (c *TournamentClock) LevelExpired() bool {
+	return c.TimeRemaining() == 0
+}
+
+// NextLevel moves the clock to the next level and restarts the level timer.
+func (c *TournamentClock) NextLevel() {
+	c.Level++
+	c.LevelStartedAt = c.now()
+}
+
+// Status returns a snapshot of the clock for the given blinds and table state.
+func (c *TournamentClock) Status(smallBlind, bigBlind, playersRemaining, totalChips int) ClockStatus {
+	nextSB, nextBB := NextBlindLevel(smallBlind, bigBlind)
+	status := ClockStatus{
+		Level:            c.Level,
+		TimeRemaining:    c.TimeRemaining(),
+		NextSmallBlind:   nextSB,
+		NextBigBlind:     nextBB,
+		PlayersRemaining: playersRemaining,
+	}
+	if playersRemaining > 0 {
+		status.AverageStack = totalChips / playersRemaining
+	}
+	return status
+}
+
+// NextBlindLevel returns the blinds of the level following the given blinds.
+// Blinds double at every level.
+func NextBlindLevel(smallBlind, bigBlind int) (int, int) {
+	return smallBlind * 2, bigBlind * 2
+}
+
+// ClockStatus returns a snapshot of the game's tournament clock, or nil if the
+// game does not use time-based blinds.
+func (g *Game) ClockStatus() *ClockStatus {
+	if g.Clock == nil {
+		return nil
+	}
+	status := g.Clock.Status(g.SmallBlind, g.BigBlind, g.CountRemainingPlayers(), g.TotalInitialChips)
+	next := g.NextBlinds()
+	status.NextSmallBlind, status.NextBigBlind = next.SmallBlind, next.BigBlind
+	status.NextAnte, status.AnteFormat = g.AnteFormat.TableAnte(next.Ante, g.CountRemainingPlayers()), g.AnteFormat
+	if g.table != nil {
+		// A tournament table shows the whole field, not just its own players.
+		t := g.table.tournament
+		status.PlayersRemaining = t.PlayersRemaining()
+		if status.PlayersRemaining > 0 {
+			status.AverageStack = t.ChipsInPlay() / status.PlayersRemaining
+		}
+		status.HandsRemaining = t.handsRemaining()
+	}
+	return &status
+}
+
+// BlindLevel returns the current blind level, starting at 1: the tournament
+// clock's level, or else the number of blind-ups so far plus one.
+func (g *Game) BlindLevel() int {
+	switch {
+	case g.Clock != nil:
+		return g.Clock.Level
+	case g.BlindUpInterval > 0 && g.HandCount > 1:
+		return 1 + (g.HandCount-1)/g.BlindUpInterval
+	}
+	return 1
+}
+
+// shouldRaiseBlinds reports whether the blinds go up at the start of the current
+// hand. At a tournament table, the blinds follow the level of the tournament's
+// clock. Otherwise a tournament clock takes precedence over the hand-count
+// based interval.
+func (g *Game) shouldRaiseBlinds() bool {
+	if g.table != nil {
+		return g.table.syncLevel()
+	}
+	if g.Clock != nil {
+		if g.HandCount > 1 && g.Clock.LevelExpired() {
+			g.Clock.NextLevel()
+			return true
+		}
+		return false
+	}
+	return g.BlindUpInterval > 0 && g.HandCount > 1 && (g.HandCount-1)%g.BlindUpInterval == 0
+}
@@ -0,0 +1,61 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestClock returns a clock whose current time is controlled by the returned pointer.
+func newTestClock(levelDuration time.Duration) (*TournamentClock, *time.Time) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewTournamentClock(levelDuration)
+	c.now = func() time.Time { return now }
+	c.LevelStartedAt = now
+	return c, &now
+}
+
+func TestTournamentClock_Status(t *testing.T) {
+	c, now := newTestClock(10 * time.Minute)
+	*now = now.Add(4 * time.Minute)
+
+	status := c.Status(500, 1000, 4, 1200000)
+	if status.Level != 1 {
+		t.Errorf("Expected level 1, but got %d", status.Level)
+	}
+	if status.TimeRemaining != 6*time.Minute {
+		t.Errorf("Expected 6m remaining, but got %v", status.TimeRemaining)
+	}
+	if status.NextSmallBlind != 1000 || status.NextBigBlind != 2000 {
+		t.Errorf("Expected next blinds 1000/2000, but got %d/%d", status.NextSmallBlind, status.NextBigBlind)
+	}
+	if status.AverageStack != 300000 {
+		t.Errorf("Expected average stack 300000, but got %d", status.AverageStack)
+	}
+}
+
+func TestStartNewHand_ClockRaisesBlinds(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000)
+	c, now := newTestClock(10 * time.Minute)
+	g.Clock = c
+	g.BlindUpInterval = 1 // Ignored when a clock is present.
+
+	if event := g.StartNewHand(); event != nil {
+		t.Fatalf("Expected no blind event on the first hand, but got %+v", event)
+	}
+	*now = now.Add(5 * time.Minute)
+	if event := g.StartNewHand(); event != nil {
+		t.Fatalf("Expected no blind event before the level expires, but got %+v", event)
+	}
+
+	*now = now.Add(6 * time.Minute)
+	event := g.StartNewHand()
+	if event == nil || event.SmallBlind != 1000 || event.BigBlind != 2000 {
+		t.Fatalf("Expected blinds to rise to 1000/2000, but got %+v", event)
+	}
+	if g.Clock.Level != 2 {
+		t.Errorf("Expected clock level 2, but got %d", g.Clock.Level)
+	}
+	if g.Clock.TimeRemaining() != 10*time.Minute {
+		t.Errorf("Expected the level timer to restart, but %v remains", g.Clock.TimeRemaining())
+	}
+}
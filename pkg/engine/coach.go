@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CoachThresholds decide when the coach comments on a statistic.
+type CoachThresholds struct {
+	// MinSpots is the number of opportunities a statistic needs before the
+	// coach comments on it, and again before it comments on it a second time.
+	MinSpots int
+	// FoldToBet is the fold frequency, facing bets or continuation bets, at or
+	// above which the coach suggests defending more.
+	FoldToBet float64
+	// LowCBet and HighCBet bound the continuation-bet frequency the coach
+	// considers balanced.
+	LowCBet  float64
+	HighCBet float64
+	// Aggression is the post-flop aggression frequency at or below which the
+	// coach suggests betting more.
+	Aggression float64
+}
+
+// DefaultCoachThresholds returns thresholds suited to a typical session.
+func DefaultCoachThresholds() CoachThresholds {
+	return CoachThresholds{
+		MinSpots:   10,
+		FoldToBet:  0.7,
+		LowCBet:    0.3,
+		HighCBet:   0.9,
+		Aggression: 0.2,
+	}
+}
+
+// Coach comments on the human player's session statistics between hands. It
+// remembers what it has said, so a comment is repeated only once the statistic
+// has gathered MinSpots more opportunities.
+type Coach struct {
+	Thresholds CoachThresholds
+	// spotsNoted is the number of opportunities behind each statistic when the
+	// coach last commented on it.
+	spotsNoted map[string]int
+}
+
+// NewCoach creates a coach with the given thresholds.
+func NewCoach(thresholds CoachThresholds) *Coach {
+	return &Coach{Thresholds: thresholds, spotsNoted: make(map[string]int)}
+}
+
+// Feedback returns the coach's new comments on the statistics, if any.
+func (c *Coach) Feedback(s StreetStats) []string {
+	var notes []string
+	note := func(key string, spots int, applies bool, format string, args ...interface{}) {
+		if !applies || spots < c.spotsNoted[key]+c.Thresholds.MinSpots {
+			return
+		}
+		c.spotsNoted[key] = spots
+		notes = append(notes, fmt.Sprintf(format, args...))
+	}
+	percent := func(f float64) int { return int(f*100 + 0.5) }
+	th := c.Thresholds
+
+	cBet := s.CBetFrequency()
+	note("cbet-low", s.CBetOpportunities, cBet <= th.LowCBet,
+		"You continuation-bet only %d%% of flops after raising pre-flop. Your opponents expect a bet, so a small one often takes the pot.", percent(cBet))
+	note("cbet-high", s.CBetOpportunities, cBet >= th.HighCBet,
+		"You continuation-bet %d%% of flops after raising pre-flop. Checking some boards that miss you keeps your bets credible.", percent(cBet))
+	foldToCBet := s.FoldToCBetFrequency()
+	note("fold-to-cbet", s.CBetsFaced, foldToCBet >= th.FoldToBet,
+		"You folded to %d%% of continuation bets. The pre-flop raiser often misses the flop too; consider defending more often.", percent(foldToCBet))
+
+	for phase := PhaseFlop; phase <= PhaseRiver; phase++ {
+		street := strings.ToLower(phase.String())
+		counts := s.Streets[phase]
+		foldToBet := s.FoldToBetFrequency(phase)
+		note("fold-"+street, counts.BetsFaced, foldToBet >= th.FoldToBet,
+			"You folded to %d%% of %s bets. Some of those bets are bluffs, so calling with your better hands and draws can pay off.", percent(foldToBet), street)
+		aggression := s.AggressionFrequency(phase)
+		note("passive-"+street, counts.Aggressive+counts.Passive, aggression <= th.Aggression,
+			"Only %d%% of your %s actions were bets or raises. Betting your strong hands builds bigger pots.", percent(aggression), street)
+	}
+	return notes
+}
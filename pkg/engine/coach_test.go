@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCoach_Feedback(t *testing.T) {
+	coach := NewCoach(DefaultCoachThresholds())
+	var stats StreetStats
+
+	// 9 folds to 10 turn bets.
+	stats.Streets[PhaseTurn] = StreetCounts{Aggressive: 5, Passive: 10, BetsFaced: 10, FoldsToBet: 9}
+	notes := coach.Feedback(stats)
+	if len(notes) != 1 || !strings.Contains(notes[0], "90% of turn bets") {
+		t.Fatalf("Expected a note on folding to turn bets, but got %v", notes)
+	}
+
+	// The coach does not repeat itself until there are enough new spots.
+	stats.Streets[PhaseTurn] = StreetCounts{Aggressive: 5, Passive: 10, BetsFaced: 15, FoldsToBet: 14}
+	if notes := coach.Feedback(stats); len(notes) != 0 {
+		t.Errorf("Expected no repeated note, but got %v", notes)
+	}
+	stats.Streets[PhaseTurn] = StreetCounts{Aggressive: 5, Passive: 10, BetsFaced: 20, FoldsToBet: 18}
+	if notes := coach.Feedback(stats); len(notes) != 1 {
+		t.Errorf("Expected the note again after 10 more spots, but got %v", notes)
+	}
+}
+
+func TestCoach_Thresholds(t *testing.T) {
+	stats := StreetStats{CBetOpportunities: 4, CBets: 0, CBetsFaced: 4, FoldsToCBet: 4}
+
+	if notes := NewCoach(DefaultCoachThresholds()).Feedback(stats); len(notes) != 0 {
+		t.Errorf("Expected no notes with too few spots, but got %v", notes)
+	}
+
+	thresholds := DefaultCoachThresholds()
+	thresholds.MinSpots = 4
+	notes := NewCoach(thresholds).Feedback(stats)
+	if len(notes) != 2 || !strings.Contains(notes[0], "only 0% of flops") || !strings.Contains(notes[1], "100% of continuation bets") {
+		t.Errorf("Expected notes on continuation bets, but got %v", notes)
+	}
+}
@@ -0,0 +1,219 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// BetSizeUnit is the unit a bet size is given in.
+type BetSizeUnit int
+
+// BetSizeUnit constants.
+const (
+	BetSizeChips     BetSizeUnit = iota // BetSizeChips is an amount of chips, e.g. "3000".
+	BetSizeBigBlinds                    // BetSizeBigBlinds is a number of big blinds, e.g. "2.5bb".
+	BetSizePot                          // BetSizePot is a fraction of the pot, e.g. "66% pot" or "pot".
+	BetSizeAllIn                        // BetSizeAllIn is the most the player may bet, e.g. "all-in".
+)
+
+// ActionCommand is an action typed at the prompt or bound to a macro, e.g.
+// "raise 2.5bb" or "bet 66% pot". It is parsed once and resolved into a
+// PlayerAction against the state of the hand whenever it is used.
+type ActionCommand struct {
+	// Text is the command as it was written.
+	Text string
+	Type ActionType
+	// Unit and Size give the amount of a bet or raise. It is the total bet
+	// or raised to, and a fraction of the pot is of the pot after calling, as
+	// in pot-limit games, so "raise pot" is a pot-sized raise.
+	Unit BetSizeUnit
+	Size float64
+	// By makes an amount in chips or big blinds an increment on top of the
+	// current bet, as in "raise by 2000" or "raise +2000", rather than the
+	// total raised to.
+	By bool
+}
+
+// ParseActionCommand parses a command: fold, check, call, or bet or raise with
+// an amount in chips ("3000"), big blinds ("2.5bb"), percent of the pot
+// ("66% pot", "66%"), "pot" or "all-in". An amount is the total to bet or
+// raise to, and may say so ("raise to 6000"); "raise by 4000" or "raise
+// +4000" gives the increment over the current bet instead.
+func ParseActionCommand(text string) (ActionCommand, error) {
+	fields := strings.Fields(strings.ToLower(text))
+	if len(fields) == 0 {
+		return ActionCommand{}, fmt.Errorf("empty action")
+	}
+	cmd := ActionCommand{Text: strings.Join(fields, " ")}
+	switch fields[0] {
+	case "fold", "f":
+		cmd.Type = ActionFold
+	case "check", "k":
+		cmd.Type = ActionCheck
+	case "call", "c":
+		cmd.Type = ActionCall
+	case "bet", "b":
+		cmd.Type = ActionBet
+	case "raise", "r":
+		cmd.Type = ActionRaise
+	default:
+		return ActionCommand{}, fmt.Errorf("unknown action %q (use fold, check, call, bet or raise)", fields[0])
+	}
+
+	amount := fields[1:]
+	if cmd.Type != ActionBet && cmd.Type != ActionRaise {
+		if len(amount) > 0 {
+			return ActionCommand{}, fmt.Errorf("%s takes no amount", fields[0])
+		}
+		return cmd, nil
+	}
+	if len(amount) == 0 {
+		return ActionCommand{}, fmt.Errorf("%s needs an amount, e.g. 2.5bb, 66%% pot or 3000", fields[0])
+	}
+	switch amount[0] {
+	case "to":
+		amount = amount[1:]
+	case "by":
+		cmd.By, amount = true, amount[1:]
+	}
+	size := strings.Join(amount, " ")
+	if rest, ok := strings.CutPrefix(size, "+"); ok {
+		cmd.By, size = true, strings.TrimSpace(rest)
+	}
+	if size == "" {
+		return ActionCommand{}, fmt.Errorf("%s needs an amount, e.g. 2.5bb, 66%% pot or 3000", fields[0])
+	}
+	var err error
+	if cmd.Unit, cmd.Size, err = parseBetSize(size); err != nil {
+		return ActionCommand{}, err
+	}
+	return cmd, nil
+}
+
+// parseBetSize parses the amount of a bet or raise command.
+func parseBetSize(s string) (BetSizeUnit, float64, error) {
+	switch s {
+	case "pot":
+		return BetSizePot, 1, nil
+	case "all-in", "allin", "all in", "max":
+		return BetSizeAllIn, 0, nil
+	}
+
+	unit, number := BetSizeChips, s
+	switch {
+	case strings.HasSuffix(s, "bb"):
+		unit, number = BetSizeBigBlinds, strings.TrimSpace(strings.TrimSuffix(s, "bb"))
+	case strings.HasSuffix(s, "%") || strings.HasSuffix(s, "% pot"):
+		unit, number = BetSizePot, strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(s, " pot"), "%"))
+	}
+	size, err := strconv.ParseFloat(number, 64)
+	if err != nil || size <= 0 || math.IsInf(size, 0) {
+		return 0, 0, fmt.Errorf("invalid amount %q (use e.g. 2.5bb, 66%% pot, pot, all-in or 3000)", s)
+	}
+	if unit == BetSizePot {
+		size /= 100
+	}
+	return unit, size, nil
+}
+
+// ResolveActionCommand turns a command into the player's action in the current
+// hand, checking with the validate package that the action is legal: a check
+// or bet only when not facing a bet, a call or raise only when facing one, and
+// an amount within the betting limits. Amounts are rounded to the nearest multiple of the chip
+// unit, as RoundBetAmount does.
+func (g *Game) ResolveActionCommand(player *Player, cmd ActionCommand) (PlayerAction, error) {
+	spot := g.ActionSpot(player)
+	move := bettingMoves[cmd.Type]
+	if err := spot.Allows(move); err != nil {
+		return PlayerAction{}, err
+	}
+	if cmd.Type != ActionBet && cmd.Type != ActionRaise {
+		return PlayerAction{Type: cmd.Type}, nil
+	}
+
+	amount := g.betSizeTotal(player, cmd, spot.MaxTotal)
+	if err := spot.Validate(move, amount); err != nil {
+		return PlayerAction{}, fmt.Errorf("%s is %d, but it must be between %d and %d", cmd.Text, amount, spot.MinTotal, spot.MaxTotal)
+	}
+	return PlayerAction{Type: cmd.Type, Amount: amount}, nil
+}
+
+// betSizeTotal returns the total a bet or raise command bets, rounded to the
+// chip unit, where maxTotal is the most the player may bet.
+func (g *Game) betSizeTotal(player *Player, cmd ActionCommand, maxTotal int) int {
+	var amount int
+	switch cmd.Unit {
+	case BetSizeChips:
+		amount = int(math.Round(cmd.Size))
+	case BetSizeBigBlinds:
+		amount = int(math.Round(cmd.Size * float64(g.BigBlind)))
+	case BetSizePot:
+		toCall := g.BetToCall - player.CurrentBet
+		amount = g.BetToCall + int(math.Round(cmd.Size*float64(g.Pot+toCall)))
+	case BetSizeAllIn:
+		amount = maxTotal
+	}
+	if cmd.By && (cmd.Unit == BetSizeChips || cmd.Unit == BetSizeBigBlinds) {
+		amount += g.BetToCall
+	}
+	return g.RoundBetAmount(amount)
+}
+
+// QuickBetOption is a bet or raise offered at the prompt, e.g. a half-pot raise.
+type QuickBetOption struct {
+	// Label names the size, e.g. "50% pot", "pot" or "all-in".
+	Label  string
+	Action PlayerAction
+}
+
+// quickBetSizes are the sizes offered as quick bets, smallest first.
+var quickBetSizes = []struct {
+	label string
+	unit  BetSizeUnit
+	size  float64
+}{
+	{"33% pot", BetSizePot, 0.33},
+	{"50% pot", BetSizePot, 0.5},
+	{"75% pot", BetSizePot, 0.75},
+	{"pot", BetSizePot, 1},
+	{"all-in", BetSizeAllIn, 0},
+}
+
+// QuickBetOptions returns the quick bets or raises the player may make: a
+// third, half and three quarters of the pot, the pot and all-in, as totals
+// within the betting limits. A size below the minimum is raised to it and one
+// over the limit is capped by it, so sizes that come to the same total are
+// offered once, under the smallest size's label. Under a pot limit, a stack
+// larger than the pot is never offered all-in, and under a fixed limit the
+// one legal size is offered alone. It returns nil if the player cannot bet or
+// raise.
+func (g *Game) QuickBetOptions(player *Player) []QuickBetOption {
+	toCall := g.BetToCall - player.CurrentBet
+	actionType := ActionBet
+	if toCall > 0 {
+		actionType = ActionRaise
+		if player.Chips <= toCall {
+			return nil
+		}
+	}
+	if player.Chips == 0 || g.BettingCapped() {
+		return nil
+	}
+
+	minTotal, maxTotal := g.CalculateBettingLimits()
+	if _, fixed := g.BettingCalculator.(*FixedLimitCalculator); fixed {
+		return []QuickBetOption{{Label: "fixed limit", Action: PlayerAction{Type: actionType, Amount: minTotal}}}
+	}
+	var options []QuickBetOption
+	for _, size := range quickBetSizes {
+		cmd := ActionCommand{Type: actionType, Unit: size.unit, Size: size.size}
+		amount := min(max(g.betSizeTotal(player, cmd, maxTotal), minTotal), maxTotal)
+		if n := len(options); n > 0 && options[n-1].Action.Amount == amount {
+			continue
+		}
+		options = append(options, QuickBetOption{Label: size.label, Action: PlayerAction{Type: actionType, Amount: amount}})
+	}
+	return options
+}
@@ -0,0 +1,178 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseActionCommand(t *testing.T) {
+	testCases := []struct {
+		text     string
+		expected ActionCommand
+	}{
+		{"fold", ActionCommand{Text: "fold", Type: ActionFold}},
+		{"k", ActionCommand{Text: "k", Type: ActionCheck}},
+		{"Call", ActionCommand{Text: "call", Type: ActionCall}},
+		{"raise 2.5bb", ActionCommand{Text: "raise 2.5bb", Type: ActionRaise, Unit: BetSizeBigBlinds, Size: 2.5}},
+		{"r 2.5 bb", ActionCommand{Text: "r 2.5 bb", Type: ActionRaise, Unit: BetSizeBigBlinds, Size: 2.5}},
+		{"bet 66% pot", ActionCommand{Text: "bet 66% pot", Type: ActionBet, Unit: BetSizePot, Size: 0.66}},
+		{"b 50%", ActionCommand{Text: "b 50%", Type: ActionBet, Unit: BetSizePot, Size: 0.5}},
+		{"raise pot", ActionCommand{Text: "raise pot", Type: ActionRaise, Unit: BetSizePot, Size: 1}},
+		{"bet  all-in", ActionCommand{Text: "bet all-in", Type: ActionBet, Unit: BetSizeAllIn}},
+		{"bet 3000", ActionCommand{Text: "bet 3000", Type: ActionBet, Unit: BetSizeChips, Size: 3000}},
+		{"raise to 6000", ActionCommand{Text: "raise to 6000", Type: ActionRaise, Unit: BetSizeChips, Size: 6000}},
+		{"raise by 4000", ActionCommand{Text: "raise by 4000", Type: ActionRaise, Unit: BetSizeChips, Size: 4000, By: true}},
+		{"r +2bb", ActionCommand{Text: "r +2bb", Type: ActionRaise, Unit: BetSizeBigBlinds, Size: 2, By: true}},
+		{"r + 2bb", ActionCommand{Text: "r + 2bb", Type: ActionRaise, Unit: BetSizeBigBlinds, Size: 2, By: true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			cmd, err := ParseActionCommand(tc.text)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cmd != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, cmd)
+			}
+		})
+	}
+
+	for _, text := range []string{"", "shove", "call 100", "raise", "bet -2bb", "bet lots", "raise 0", "raise to", "raise by", "raise +"} {
+		if _, err := ParseActionCommand(text); err == nil {
+			t.Errorf("expected an error parsing %q", text)
+		}
+	}
+}
+
+func TestResolveActionCommand(t *testing.T) {
+	// Facing a bet of 1000 with 1500 in the pot: raises run from 2000 to a
+	// pot-sized 3500.
+	facingBet := func() *Game {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "PLS")
+		g.Pot = 1500
+		g.BetToCall = 1000
+		g.LastRaiseAmount = 1000
+		g.CurrentTurnPos = 0
+		return g
+	}
+	// Checked to, with 1500 in the pot: bets run from 1000 to 1500.
+	checkedTo := func() *Game {
+		g := facingBet()
+		g.BetToCall = 0
+		g.LastRaiseAmount = 0
+		return g
+	}
+
+	// Either spot, with chips in units of 100.
+	inHundreds := func(game func() *Game) func() *Game {
+		return func() *Game {
+			g := game()
+			g.Rules.ChipUnit = 100
+			return g
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		game     func() *Game
+		text     string
+		expected PlayerAction
+		wantErr  bool
+	}{
+		{"raise in big blinds", facingBet, "raise 2.5bb", PlayerAction{Type: ActionRaise, Amount: 2500}, false},
+		{"raise half pot", facingBet, "raise 50% pot", PlayerAction{Type: ActionRaise, Amount: 2250}, false},
+		{"raise pot is the pot limit", facingBet, "raise pot", PlayerAction{Type: ActionRaise, Amount: 3500}, false},
+		{"raise all-in is capped by the pot limit", facingBet, "raise all-in", PlayerAction{Type: ActionRaise, Amount: 3500}, false},
+		{"call", facingBet, "call", PlayerAction{Type: ActionCall}, false},
+		{"fold", facingBet, "fold", PlayerAction{Type: ActionFold}, false},
+		{"raise over the pot limit", facingBet, "raise 10bb", PlayerAction{}, true},
+		{"raise under the minimum", facingBet, "raise 1.5bb", PlayerAction{}, true},
+		{"check facing a bet", facingBet, "check", PlayerAction{}, true},
+		{"bet facing a bet", facingBet, "bet 2bb", PlayerAction{}, true},
+		{"bet a percent of the pot", checkedTo, "bet 75% pot", PlayerAction{Type: ActionBet, Amount: 1125}, false},
+		{"bet in chips", checkedTo, "bet 1200", PlayerAction{Type: ActionBet, Amount: 1200}, false},
+		{"check", checkedTo, "check", PlayerAction{Type: ActionCheck}, false},
+		{"bet under the big blind", checkedTo, "bet 50% pot", PlayerAction{}, true},
+		{"call with no bet", checkedTo, "call", PlayerAction{}, true},
+		{"raise with no bet", checkedTo, "raise 2bb", PlayerAction{}, true},
+		{"bet rounded to the chip unit", inHundreds(checkedTo), "bet 75% pot", PlayerAction{Type: ActionBet, Amount: 1100}, false},
+		{"raise rounded to the chip unit", inHundreds(facingBet), "raise 2.45bb", PlayerAction{Type: ActionRaise, Amount: 2500}, false},
+		{"raise to a total", facingBet, "raise to 3000", PlayerAction{Type: ActionRaise, Amount: 3000}, false},
+		{"raise by chips is a total over the bet", facingBet, "raise by 2000", PlayerAction{Type: ActionRaise, Amount: 3000}, false},
+		{"raise by big blinds", facingBet, "raise +1.5bb", PlayerAction{Type: ActionRaise, Amount: 2500}, false},
+		{"raise by pot is a pot-sized raise", facingBet, "raise by pot", PlayerAction{Type: ActionRaise, Amount: 3500}, false},
+		{"raise by under the minimum", facingBet, "raise +500", PlayerAction{}, true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := tc.game()
+			cmd, err := ParseActionCommand(tc.text)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %v", err)
+			}
+			action, err := g.ResolveActionCommand(g.Players[0], cmd)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", action)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if action != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, action)
+			}
+		})
+	}
+}
+
+func TestQuickBetOptions(t *testing.T) {
+	t.Run("pot-limit raise", func(t *testing.T) {
+		// Facing a bet of 1000 with 1500 in the pot: raises run from 2000 to a
+		// pot-sized 3500, which also caps the all-in.
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "PLS")
+		g.Pot = 1500
+		g.BetToCall = 1000
+		g.LastRaiseAmount = 1000
+		g.CurrentTurnPos = 0
+
+		expected := []QuickBetOption{
+			{Label: "33% pot", Action: PlayerAction{Type: ActionRaise, Amount: 2000}},
+			{Label: "50% pot", Action: PlayerAction{Type: ActionRaise, Amount: 2250}},
+			{Label: "75% pot", Action: PlayerAction{Type: ActionRaise, Amount: 2875}},
+			{Label: "pot", Action: PlayerAction{Type: ActionRaise, Amount: 3500}},
+		}
+		if options := g.QuickBetOptions(g.Players[0]); !reflect.DeepEqual(options, expected) {
+			t.Errorf("expected %+v, got %+v", expected, options)
+		}
+	})
+
+	t.Run("no-limit bet", func(t *testing.T) {
+		// Checked to, with 1500 in the pot: a third and half the pot are both
+		// raised to the big blind.
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "NLH")
+		g.Pot = 1500
+		g.CurrentTurnPos = 0
+
+		expected := []QuickBetOption{
+			{Label: "33% pot", Action: PlayerAction{Type: ActionBet, Amount: 1000}},
+			{Label: "75% pot", Action: PlayerAction{Type: ActionBet, Amount: 1125}},
+			{Label: "pot", Action: PlayerAction{Type: ActionBet, Amount: 1500}},
+			{Label: "all-in", Action: PlayerAction{Type: ActionBet, Amount: 10000}},
+		}
+		if options := g.QuickBetOptions(g.Players[0]); !reflect.DeepEqual(options, expected) {
+			t.Errorf("expected %+v, got %+v", expected, options)
+		}
+	})
+
+	t.Run("too short to raise", func(t *testing.T) {
+		g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 800, 500, 1000, "NLH")
+		g.Pot = 1500
+		g.BetToCall = 1000
+		g.CurrentTurnPos = 0
+		if options := g.QuickBetOptions(g.Players[0]); options != nil {
+			t.Errorf("expected no quick raises, got %+v", options)
+		}
+	})
+}
@@ -0,0 +1,137 @@
+package engine
+
+// StackDepth tags a player's situation in a hand by the stack-to-pot ratio of
+// their effective stack, and by how much they have already put in.
+type StackDepth int
+
+// StackDepth constants, from the most room to play to the least.
+const (
+	// StackDeep leaves room for several streets of bets and raises.
+	StackDeep StackDepth = iota
+	// StackMedium leaves room for about two streets of pot-sized bets.
+	StackMedium
+	// StackShort leaves room for about one more pot-sized bet.
+	StackShort
+	// StackCommitted is a player who can no longer fold to a shove: the pot
+	// lays them the odds to call off the rest, or they have already put in
+	// as much as they have behind.
+	StackCommitted
+)
+
+// stackDepthNames are the names of the stack depths.
+var stackDepthNames = []string{"deep", "medium", "short", "committed"}
+
+// String returns the stack depth's name, e.g. "short".
+func (d StackDepth) String() string {
+	return stackDepthNames[d]
+}
+
+// deepSPR and shortSPR are the stack-to-pot ratios at and above which a
+// stack is deep, and below which it is short.
+const (
+	deepSPR  = 10
+	shortSPR = 4
+)
+
+// commitEquity is the equity below which calling off the rest of a stack is
+// still right, for any hand with a chance: a player whose call for the rest
+// would need no more than this is committed to the pot.
+const commitEquity = 0.25
+
+// EffectiveStack returns the chips the player can still win or lose in the
+// hand: their stack, capped at what the deepest opponent left in the hand
+// could put in on top of the player's current bet.
+func (g *Game) EffectiveStack(player *Player) int {
+	deepest := 0
+	for _, p := range g.Players {
+		if p != player && (p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn) {
+			deepest = max(deepest, p.Chips+p.CurrentBet-player.CurrentBet)
+		}
+	}
+	return min(player.Chips, deepest)
+}
+
+// SPR returns the player's stack-to-pot ratio: their effective stack over
+// the pot. It is 0 with an empty pot.
+func (g *Game) SPR(player *Player) float64 {
+	return ratio(g.EffectiveStack(player), g.Pot)
+}
+
+// StackDepth tags the player's situation in the hand.
+func (g *Game) StackDepth(player *Player) StackDepth {
+	behind := g.EffectiveStack(player)
+	spr := g.SPR(player)
+	switch {
+	case player.Chips == 0 || committed(player.TotalBetInHand, behind, g.Pot):
+		return StackCommitted
+	case spr >= deepSPR:
+		return StackDeep
+	case spr >= shortSPR:
+		return StackMedium
+	}
+	return StackShort
+}
+
+// committed reports whether a player with invested chips in the pot and
+// behind chips left to play is committed to a pot of the given size. With
+// nothing left to play, as when no opponent can bet, there is nothing to be
+// committed to.
+func committed(invested, behind, pot int) bool {
+	if behind <= 0 {
+		return false
+	}
+	// Calling a shove for the rest needs behind/(pot + 2*behind) equity.
+	return float64(behind) <= commitEquity*float64(pot+2*behind) || invested >= behind
+}
+
+// Commitment is what an action would leave a player with, if its bet were
+// matched: the chips they would have behind, and the pot.
+type Commitment struct {
+	Behind int
+	Pot    int
+	// Commits is set if the action would commit the player to the pot
+	// without putting them all in, when they are not committed already.
+	Commits bool
+}
+
+// CommitmentAfter tells whether the player's action would commit them to the
+// pot: whether, once a bet or raise is called, the pot would lay them the
+// odds to call off the rest of their stack against a shove.
+func (g *Game) CommitmentAfter(player *Player, action PlayerAction) Commitment {
+	var put int
+	switch action.Type {
+	case ActionCall:
+		put = g.BetToCall - player.CurrentBet
+	case ActionBet, ActionRaise:
+		put = action.Amount - player.CurrentBet
+	}
+	behind := g.EffectiveStack(player)
+	put = min(put, player.Chips)
+	c := Commitment{Behind: behind - put, Pot: g.Pot + put}
+	// Calling a bet or raise adds its excess over the bet to call.
+	if total := player.CurrentBet + put; total > g.BetToCall {
+		c.Pot += min(total-g.BetToCall, behind)
+	}
+	if put <= 0 || put >= player.Chips || g.StackDepth(player) == StackCommitted {
+		return c
+	}
+	c.Commits = committed(player.TotalBetInHand+put, c.Behind, c.Pot)
+	return c
+}
+
+// fitCommitment applies the pot commitment a CPU reads for itself, as the
+// human is warned of it: a committed CPU calls instead of folding to a bet,
+// and a bet or raise that would commit it goes all in instead.
+func (g *Game) fitCommitment(player *Player, action PlayerAction) PlayerAction {
+	switch action.Type {
+	case ActionFold:
+		if g.BetToCall > player.CurrentBet && g.StackDepth(player) == StackCommitted {
+			return PlayerAction{Type: ActionCall}
+		}
+	case ActionBet, ActionRaise:
+		if g.CommitmentAfter(player, action).Commits {
+			action.Amount = g.clampRaiseAmount(player.Chips + player.CurrentBet)
+		}
+	}
+	return action
+}
@@ -0,0 +1,111 @@
+package engine
+
+import "testing"
+
+// newHeadsUpFlop returns a no-limit game on the flop between YOU and CPU 1,
+// with nothing bet yet and the given pot.
+func newHeadsUpFlop(chips, pot int) *Game {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, chips, 50, 100, "NLH")
+	g.Phase = PhaseFlop
+	g.Pot = pot
+	g.BetToCall = 0
+	g.CurrentTurnPos = 0
+	for _, p := range g.Players {
+		p.Status = PlayerStatusPlaying
+	}
+	return g
+}
+
+func TestStackDepth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pot      int
+		cpuChips int
+		invested int
+		expected StackDepth
+	}{
+		{name: "Deep", pot: 500, cpuChips: 10000, expected: StackDeep},
+		{name: "Medium", pot: 2000, cpuChips: 10000, expected: StackMedium},
+		{name: "Short", pot: 4000, cpuChips: 10000, expected: StackShort},
+		{name: "Committed by the pot odds", pot: 40000, cpuChips: 10000, expected: StackCommitted},
+		{name: "Committed by the chips put in", pot: 25000, cpuChips: 10000, invested: 10000, expected: StackCommitted},
+		// The effective stack is only the 1,000 CPU 1 has left.
+		{name: "Deep against a short stack", pot: 20, cpuChips: 1000, expected: StackDeep},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newHeadsUpFlop(10000, tc.pot)
+			g.Players[1].Chips = tc.cpuChips
+			g.Players[0].TotalBetInHand = tc.invested
+			if got := g.StackDepth(g.Players[0]); got != tc.expected {
+				t.Errorf("Expected %v at SPR %.1f, but got %v", tc.expected, g.SPR(g.Players[0]), got)
+			}
+		})
+	}
+
+	g := newHeadsUpFlop(10000, 500)
+	g.Players[0].Chips = 0
+	g.Players[0].Status = PlayerStatusAllIn
+	if got := g.StackDepth(g.Players[0]); got != StackCommitted {
+		t.Errorf("Expected a player all in to be committed, but got %v", got)
+	}
+}
+
+func TestCommitmentAfter(t *testing.T) {
+	g := newHeadsUpFlop(10000, 6000)
+	you := g.Players[0]
+
+	small := g.CommitmentAfter(you, PlayerAction{Type: ActionBet, Amount: 1000})
+	if small.Commits || small.Behind != 9000 || small.Pot != 8000 {
+		t.Errorf("Expected a bet of 1,000 to leave 9,000 behind in a pot of 8,000 uncommitted, but got %+v", small)
+	}
+	big := g.CommitmentAfter(you, PlayerAction{Type: ActionBet, Amount: 4000})
+	if !big.Commits || big.Behind != 6000 || big.Pot != 14000 {
+		t.Errorf("Expected a bet of 4,000 to commit 6,000 behind to a pot of 14,000, but got %+v", big)
+	}
+	if allIn := g.CommitmentAfter(you, PlayerAction{Type: ActionBet, Amount: 10000}); allIn.Commits {
+		t.Errorf("Expected an all-in not to be warned of, but got %+v", allIn)
+	}
+
+	// CPU 1 bets 4,000 into 6,000.
+	cpu := g.Players[1]
+	cpu.Chips, cpu.CurrentBet, cpu.TotalBetInHand = 6000, 4000, 4000
+	g.Pot, g.BetToCall = 10000, 4000
+	if got := g.CommitmentAfter(you, PlayerAction{Type: ActionCall}); !got.Commits || got.Behind != 6000 || got.Pot != 14000 {
+		t.Errorf("Expected calling 4,000 to commit 6,000 behind to a pot of 14,000, but got %+v", got)
+	}
+}
+
+func TestFitCommitment(t *testing.T) {
+	// CPU 1 has put in 5,000 and has 5,000 left, facing a bet of 3,000.
+	g := newHeadsUpFlop(10000, 13000)
+	you, cpu := g.Players[0], g.Players[1]
+	cpu.Chips, cpu.TotalBetInHand = 5000, 5000
+	you.Chips, you.CurrentBet, you.TotalBetInHand = 7000, 3000, 8000
+	g.BetToCall = 3000
+	g.CurrentTurnPos = 1
+
+	if got := g.fitCommitment(cpu, PlayerAction{Type: ActionFold}); got.Type != ActionCall {
+		t.Errorf("Expected a committed CPU to call instead of folding, but got %v", got.Type)
+	}
+
+	// CPU 1 has 10,000 left, facing a bet of 3,000 into 3,000.
+	raiser := newHeadsUpFlop(10000, 6000)
+	raiser.Players[0].Chips, raiser.Players[0].CurrentBet = 7000, 3000
+	raiser.BetToCall = 3000
+	raiser.CurrentTurnPos = 1
+	// Raising to 6,000 would leave 4,000 behind in a pot of 15,000.
+	if got := raiser.fitCommitment(raiser.Players[1], PlayerAction{Type: ActionRaise, Amount: 6000}); got.Amount != 10000 {
+		t.Errorf("Expected a committing raise to go all in for 10,000, but got %d", got.Amount)
+	}
+
+	deep := newHeadsUpFlop(10000, 500)
+	deep.BetToCall, deep.Players[0].CurrentBet = 300, 300
+	deep.CurrentTurnPos = 1
+	if got := deep.fitCommitment(deep.Players[1], PlayerAction{Type: ActionFold}); got.Type != ActionFold {
+		t.Errorf("Expected a deep CPU to keep its fold, but got %v", got.Type)
+	}
+	if got := deep.fitCommitment(deep.Players[1], PlayerAction{Type: ActionRaise, Amount: 900}); got.Amount != 900 {
+		t.Errorf("Expected a deep CPU to keep its raise to 900, but got %d", got.Amount)
+	}
+}
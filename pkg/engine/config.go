@@ -25,3 +25,40 @@ func (d Difficulty) String() string {
 		return "Unknown"
 	}
 }
+
+// DifficultyPreset gates the skills of the AI opponents. Difficulty chooses
+// both the mix of profiles the CPUs play and this preset, which applies to
+// every CPU whatever its profile, so that harder opponents play genuinely
+// better rather than just differently.
+type DifficultyPreset struct {
+	// SimulatesEquity lets CPUs estimate their equity against the players
+	// left in the hand by simulation when deciding whether to call a bet
+	// after the flop. Without it, they rely on hand-strength heuristics.
+	SimulatesEquity bool
+	// BluffScale multiplies the bluffing frequency of every profile.
+	BluffScale float64
+	// UsesOpponentModel lets CPUs adjust to what they remember about the
+	// human player: bluffing more against frequent folders, opening bigger
+	// against cold-callers, and giving up marginal hands ahead of squeezes.
+	// It also lets them defend against raises by the table image of the
+	// raiser, whoever it is (see TableImage).
+	UsesOpponentModel bool
+	// ReadsOpponents lets CPUs adjust their bluffs and value bets to the
+	// session statistics of the opponents left in the hand, the human and
+	// the other CPUs alike (see PlayerStats): bluffing less and betting
+	// thinner for value against calling stations, bluffing more against
+	// players who fold to continuation bets, and slow-playing against maniacs.
+	ReadsOpponents bool
+}
+
+// Preset returns the AI skills enabled at the difficulty.
+func (d Difficulty) Preset() DifficultyPreset {
+	switch d {
+	case DifficultyEasy:
+		return DifficultyPreset{BluffScale: 0.5}
+	case DifficultyHard:
+		return DifficultyPreset{SimulatesEquity: true, BluffScale: 1.25, UsesOpponentModel: true, ReadsOpponents: true}
+	default:
+		return DifficultyPreset{BluffScale: 1, UsesOpponentModel: true}
+	}
+}
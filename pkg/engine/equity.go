@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+)
+
+// TierEquity is the players' equity in a single pot tier.
+type TierEquity struct {
+	// Index is 0 for the main pot and 1, 2, ... for side pots.
+	Index  int
+	Amount int
+	// Players lists the names of the players eligible for the tier, and Equity
+	// holds their expected shares of it (between 0 and 1) in the same order.
+	Players []string
+	Equity  []float64
+}
+
+// Name returns "Main pot" or "Side pot N".
+func (t TierEquity) Name() string {
+	return potTierName(t.Index)
+}
+
+// AllInEquity breaks down the players' equity pot tier by pot tier. A short
+// stack can be the favorite for the main pot while being ineligible for the
+// side pots, which a single overall equity figure would hide.
+type AllInEquity struct {
+	Tiers []TierEquity
+	// Runouts is the number of board runouts evaluated, and Exact reports
+	// whether they were all the possible runouts or a random sample.
+	Runouts int
+	Exact   bool
+}
+
+// ShowsAllInEquity reports whether the hand has reached a multi-way all-in:
+// three or more players are all-in and no further betting is possible.
+func (g *Game) ShowsAllInEquity() bool {
+	allIn := 0
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusAllIn {
+			allIn++
+		}
+	}
+	return allIn >= 3 && g.CountPlayersAbleToAct() <= 1 && len(g.CommunityCards) < 5
+}
+
+// CalculateAllInEquity computes each showdown player's equity in every pot
+// tier from the current board. It stops with ctx's error if ctx is canceled
+// first.
+func (g *Game) CalculateAllInEquity(ctx context.Context) (*AllInEquity, error) {
+	showdownPlayers := g.getShowdownPlayers()
+	index := make(map[*Player]int, len(showdownPlayers))
+	hands := make([][]poker.Card, len(showdownPlayers))
+	for i, p := range showdownPlayers {
+		index[p] = i
+		hands[i] = p.Hand
+	}
+
+	pots := g.buildPotTiers(showdownPlayers)
+	tiers := make([][]int, len(pots))
+	for t, pot := range pots {
+		for _, p := range pot.Players {
+			tiers[t] = append(tiers[t], index[p])
+		}
+	}
+
+	result, err := poker.CalculateTierEquity(ctx, hands, g.CommunityCards, tiers, g.Rules, g.Rand)
+	if err != nil {
+		return nil, err
+	}
+
+	equity := &AllInEquity{Runouts: result.Runouts, Exact: result.Exact}
+	for t, pot := range pots {
+		tier := TierEquity{Index: t, Amount: pot.Amount}
+		for _, p := range pot.Players {
+			tier.Players = append(tier.Players, p.Name)
+			tier.Equity = append(tier.Equity, result.Equities[t][index[p]])
+		}
+		equity.Tiers = append(equity.Tiers, tier)
+	}
+	return equity, nil
+}
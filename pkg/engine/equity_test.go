@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"testing"
+)
+
+func TestCalculateAllInEquity_PerTier(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100, "NLH")
+	g.Rules.HandRankings = poker.HandRankingsRules{UseStandardRankings: true}
+	stacks := []int{1000, 3000, 5000}
+	hands := []string{"As Ah", "Ks Kh", "Qs Qh"}
+	for i, p := range g.Players {
+		p.Hand = poker.CardsFromStrings(hands[i])
+		p.TotalBetInHand = stacks[i]
+		p.Chips = 0
+		p.Status = PlayerStatusAllIn
+		g.Pot += stacks[i]
+	}
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h")
+
+	if !g.ShowsAllInEquity() {
+		t.Fatal("expected equity to be shown for a three-way all-in")
+	}
+	equity, err := g.CalculateAllInEquity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !equity.Exact {
+		t.Error("expected exact equity on the flop")
+	}
+	if len(equity.Tiers) != 3 {
+		t.Fatalf("expected main pot, side pot and uncalled tier, got %d tiers", len(equity.Tiers))
+	}
+
+	main, side := equity.Tiers[0], equity.Tiers[1]
+	if main.Name() != "Main pot" || main.Amount != 3000 || len(main.Players) != 3 {
+		t.Errorf("unexpected main pot: %+v", main)
+	}
+	if side.Name() != "Side pot 1" || side.Amount != 4000 || len(side.Players) != 2 || side.Players[0] != "CPU 1" {
+		t.Errorf("unexpected side pot: %+v", side)
+	}
+	if main.Equity[0] < 0.5 {
+		t.Errorf("expected aces to be the main pot favorite, got %f", main.Equity[0])
+	}
+	if side.Equity[0] < 0.8 {
+		t.Errorf("expected kings to be the side pot favorite once aces are ineligible, got %f", side.Equity[0])
+	}
+	if last := equity.Tiers[2]; len(last.Players) != 1 || math.Abs(last.Equity[0]-1) > 1e-9 {
+		t.Errorf("expected the uncalled chips to belong to CPU 2 outright, got %+v", last)
+	}
+}
+
+func TestHandContext_CanceledWhenHandEnds(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	if err := g.HandContext().Err(); err != nil {
+		t.Fatalf("expected the context before the first hand to be live, got %v", err)
+	}
+	g.StartNewHand()
+	ctx := g.HandContext()
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("expected the hand's context to be live, got %v", err)
+	}
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("expected the hand's context to be canceled by the cleanup, got %v", ctx.Err())
+	}
+	if _, err := g.CalculateAllInEquity(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the equity calculation to be canceled, got %v", err)
+	}
+}
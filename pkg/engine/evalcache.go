@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+)
+
+// evalCache shares hand evaluations within a street. Every CPU decision, the
+// showdown and each redraw of the table evaluate hands against the same board,
+// so each set of hole cards is evaluated once per board. The cache only holds
+// entries for the current board and starts over whenever the board changes.
+// It is also cleared at the start of every hand, as the rules may change
+// between hands. Like the rest of Game, it is not safe for concurrent use.
+type evalCache struct {
+	board   string
+	entries map[string]*evalCacheEntry
+	// hits and misses count lookups, for tests and dev-mode diagnostics.
+	hits, misses int
+}
+
+// evalCacheEntry holds the evaluations of one set of hole cards. The made
+// hands and the full street evaluation, which also looks for outs, are cached
+// separately, so callers that need only the made hands never pay for the outs.
+type evalCacheEntry struct {
+	made      bool
+	high, low *poker.HandResult
+	street    *poker.StreetEvaluation
+	// equity holds simulated equities, keyed by the number of opponents.
+	equity map[int]float64
+}
+
+// cachedEvaluation returns the cache entry for the hole cards on the current board.
+func (g *Game) cachedEvaluation(hand []poker.Card) *evalCacheEntry {
+	c := &g.evalCache
+	if board := cardsKey(g.CommunityCards); c.entries == nil || c.board != board {
+		c.board = board
+		c.entries = make(map[string]*evalCacheEntry)
+	}
+	key := cardsKey(hand)
+	e, ok := c.entries[key]
+	if !ok {
+		e = &evalCacheEntry{}
+		c.entries[key] = e
+	}
+	return e
+}
+
+// evaluateMadeHand returns the best high and low hands the hole cards make on
+// the current board, evaluating them at most once per board.
+func (g *Game) evaluateMadeHand(hand []poker.Card) (high, low *poker.HandResult) {
+	e := g.cachedEvaluation(hand)
+	if e.made {
+		g.evalCache.hits++
+		return e.high, e.low
+	}
+	g.evalCache.misses++
+	e.high, e.low = poker.EvaluateHand(hand, g.CommunityCards, g.Rules)
+	e.made = true
+	return e.high, e.low
+}
+
+// equitySamples is the number of deals simulated to estimate a CPU's equity.
+const equitySamples = 200
+
+// estimateEquity returns the hole cards' simulated equity against a number of
+// opponents on the current board, simulating at most once per board. The
+// simulation stops with ctx's error if ctx is canceled first.
+func (g *Game) estimateEquity(ctx context.Context, hand []poker.Card, opponents int, r *rand.Rand) (float64, error) {
+	e := g.cachedEvaluation(hand)
+	if equity, ok := e.equity[opponents]; ok {
+		g.evalCache.hits++
+		return equity, nil
+	}
+	g.evalCache.misses++
+	equity, err := poker.EstimateEquity(ctx, hand, g.CommunityCards, opponents, equitySamples, g.Rules, r)
+	if err != nil {
+		return 0, err
+	}
+	if e.equity == nil {
+		e.equity = make(map[int]float64)
+	}
+	e.equity[opponents] = equity
+	return equity, nil
+}
+
+// cardsKey encodes cards, in order, as a string usable as a map key.
+func cardsKey(cards []poker.Card) string {
+	key := make([]byte, 0, 2*len(cards))
+	for _, c := range cards {
+		key = append(key, byte(c.Rank), byte(c.Suit))
+	}
+	return string(key)
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"testing"
+)
+
+func TestEvalCache(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.Phase = PhaseFlop
+	g.dealCommunityCards(3)
+	you := g.Players[0]
+
+	first, err := g.EvaluatePlayerHand(you)
+	if err != nil {
+		t.Fatalf("Failed to evaluate the hand: %v", err)
+	}
+	second, _ := g.EvaluatePlayerHand(you)
+	if first != second || g.evalCache.hits != 1 || g.evalCache.misses != 1 {
+		t.Errorf("Expected the second evaluation to come from the cache, but got %d hits and %d misses", g.evalCache.hits, g.evalCache.misses)
+	}
+
+	// The made hand is cached apart from the street evaluation.
+	high, _ := g.evaluateMadeHand(you.Hand)
+	if g.evalCache.misses != 2 || high.Rank != first.High.Rank {
+		t.Errorf("Expected one evaluation of the made hand, but got %d misses and %s", g.evalCache.misses, high)
+	}
+
+	// A new card starts the cache over.
+	g.Phase = PhaseTurn
+	g.dealCommunityCards(1)
+	turn, _ := g.EvaluatePlayerHand(you)
+	if turn == first || turn.Street != PhaseTurn.Street() || g.evalCache.misses != 3 {
+		t.Errorf("Expected the turn to be evaluated afresh, but got %d misses", g.evalCache.misses)
+	}
+}
+
+// TestEvaluatePlayerHand_ShortBoard tests that a hand that reaches the
+// showdown, or is over, before the river is evaluated on the board dealt.
+func TestEvaluatePlayerHand_ShortBoard(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.dealCommunityCards(3)
+	you := g.Players[0]
+
+	for _, phase := range []GamePhase{PhaseShowdown, PhaseHandOver} {
+		g.Phase = phase
+		evaluation, err := g.EvaluatePlayerHand(you)
+		if err != nil {
+			t.Fatalf("Expected the hand to be evaluated at %s, but got %v", phase, err)
+		}
+		if evaluation.Street != poker.StreetFlop || evaluation.High == nil {
+			t.Errorf("Expected a flop evaluation with a made hand at %s, but got %+v", phase, evaluation)
+		}
+	}
+}
+
+func TestEvalCache_SharedAcrossCPUDecisions(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.Phase = PhaseFlop
+	g.dealCommunityCards(3)
+	g.PrepareNewBettingRound()
+
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 3; round++ {
+		for _, p := range g.Players[1:] {
+			g.GetCPUAction(p, r)
+		}
+	}
+	// Each CPU's hand is evaluated once for the street, however often it acts.
+	if g.evalCache.misses != 5 || g.evalCache.hits != 10 {
+		t.Errorf("Expected 5 evaluations and 10 cache hits, but got %d and %d", g.evalCache.misses, g.evalCache.hits)
+	}
+
+	// A new hand starts with an empty cache.
+	g.StartNewHand()
+	if g.evalCache.entries != nil || g.evalCache.misses != 0 {
+		t.Errorf("Expected the cache to be cleared for the new hand, but got %+v", g.evalCache)
+	}
+}
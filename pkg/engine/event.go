@@ -1,5 +1,7 @@
 package engine
 
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
 // ActionEvent represents a significant action taken by a player during a betting
 // round. It is intended to be used for logging, display, or broadcasting game
 // state changes to observers like a UI.
@@ -8,9 +10,21 @@ type ActionEvent struct {
 	PlayerName string
 	// Action is the type of action taken (e.g., Fold, Call, Raise).
 	Action ActionType
-	// Amount is the value associated with the action, such as the size of a
-	// bet or raise. It is 0 for actions like Fold and Check.
+	// Amount is the value associated with the action: the amount called, or
+	// the total a bet or raise was made to. It is 0 for actions like Fold and
+	// Check.
 	Amount int
+	// Cards holds the cards revealed by the action. It is only set for
+	// ActionShowPartial.
+	Cards []poker.Card
+	// Detail tells a limp, a small blind completion, or the big blind
+	// checking its option apart from other calls and checks.
+	Detail ActionDetail
+	// Pot is the size of the pot after the action, so that observers can
+	// react to big pots. It is 0 for ActionShowPartial.
+	Pot int
+	// Seq is the game's ActionSeq when the action was taken.
+	Seq int
 }
 
 // BlindEvent represents the posting of the small and big blinds at the beginning
@@ -20,4 +34,14 @@ type BlindEvent struct {
 	SmallBlind int
 	// BigBlind is the size of the big blind.
 	BigBlind int
+	// Ante is the ante each payer posts, or 0 if there is none. In the big
+	// blind and button formats, it is the ante for the whole table.
+	Ante int
+	// AnteFormat is the format Ante is posted in.
+	AnteFormat AnteFormat
+	// ChipRace lists the stacks changed by a chip race at the new level. It is
+	// empty unless the level no longer needs the smallest chips.
+	ChipRace []ChipRaceResult
+	// Denomination is the new smallest chip after a chip race.
+	Denomination int
 }
@@ -0,0 +1,299 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+)
+
+// GameEventType identifies the change to the game recorded by a GameEvent.
+type GameEventType int
+
+// GameEventType constants. Most events stand for a call to the Game method of
+// the same name; the rest record the outcome of something that cannot be
+// repeated, such as a shuffle.
+const (
+	EventGameCreated         GameEventType = iota // EventGameCreated records the arguments to NewGame.
+	EventHandStarted                              // EventHandStarted records the blinds and cards of a new hand.
+	EventBettingRoundStarted                      // EventBettingRoundStarted stands for PrepareNewBettingRound.
+	EventActionTaken                              // EventActionTaken stands for ProcessAction.
+	EventTurnAdvanced                             // EventTurnAdvanced stands for AdvanceTurn.
+	EventPhaseAdvanced                            // EventPhaseAdvanced stands for Advance.
+	EventFastForwarded                            // EventFastForwarded stands for FastForward.
+	EventPotDistributed                           // EventPotDistributed stands for DistributePot.
+	EventPotAwarded                               // EventPotAwarded stands for AwardPotToLastPlayer.
+	EventHandsMucked                              // EventHandsMucked records the hands mucked at showdown.
+	EventCardShown                                // EventCardShown stands for ShowPartial.
+	EventHandCleanedUp                            // EventHandCleanedUp stands for CleanupHand.
+	EventRebuy                                    // EventRebuy stands for Rebuy.
+	EventAddOn                                    // EventAddOn stands for AddOn.
+	EventInsuranceTaken                           // EventInsuranceTaken stands for TakeInsurance.
+	EventRunItDecided                             // EventRunItDecided stands for DecideRunIt.
+	EventTimeCharged                              // EventTimeCharged stands for CollectTimeCharge.
+	EventPlayerJoined                             // EventPlayerJoined stands for JoinTable.
+	EventPlayerLeft                               // EventPlayerLeft stands for LeaveTable.
+	EventBoardRunout                              // EventBoardRunout records a street dealt in an all-in runout.
+)
+
+// String returns the name of the event type (e.g., "Hand Started").
+func (t GameEventType) String() string {
+	return []string{
+		"Game Created", "Hand Started", "Betting Round Started", "Action Taken",
+		"Turn Advanced", "Phase Advanced", "Fast Forwarded", "Pot Distributed",
+		"Pot Awarded", "Hands Mucked", "Card Shown", "Hand Cleaned Up", "Rebuy", "Add-On",
+		"Insurance Taken", "Run It Decided", "Time Charged",
+		"Player Joined", "Player Left", "Board Runout",
+	}[t]
+}
+
+// GameEvent is one entry in a game's event log. Only the fields used by its
+// type are set.
+type GameEvent struct {
+	Type GameEventType `json:"type"`
+	// Setup holds the arguments to NewGame, for EventGameCreated.
+	Setup *GameSetup `json:"setup,omitempty"`
+	// Hand describes the new hand, for EventHandStarted.
+	Hand *HandStart `json:"hand,omitempty"`
+	// Player names the player who acted, showed a card, rebought, added on or
+	// took insurance.
+	Player string `json:"player,omitempty"`
+	// Action is the player's action, for EventActionTaken and EventCardShown.
+	Action PlayerAction `json:"action"`
+	// Amount is the amount of a rebuy or add-on, the time charge each player
+	// paid, or the stack a player joined or left with.
+	Amount int `json:"amount,omitempty"`
+	// Coverage is the fraction of the stake insured, for EventInsuranceTaken.
+	Coverage float64 `json:"coverage,omitempty"`
+	// Agreed is whether the players agreed to run the board more than once,
+	// for EventRunItDecided.
+	Agreed bool `json:"agreed,omitempty"`
+	// Profile is the profile of a CPU that joined, for EventPlayerJoined.
+	Profile *AIProfile `json:"profile,omitempty"`
+	// Phase and Board are the street and board fast-forwarded to, or dealt in
+	// an all-in runout.
+	Phase GamePhase    `json:"phase,omitempty"`
+	Board []poker.Card `json:"board,omitempty"`
+	// Players names the players who mucked, for EventHandsMucked, or the
+	// players still in the hand, for EventBoardRunout.
+	Players []string `json:"players,omitempty"`
+	// Hands holds the hole cards of each of Players, turned face up in an
+	// all-in runout.
+	Hands [][]poker.Card `json:"hands,omitempty"`
+}
+
+// GameSetup holds the arguments a game was created with.
+type GameSetup struct {
+	PlayerNames     []string         `json:"player_names"`
+	InitialChips    int              `json:"initial_chips"`
+	SmallBlind      int              `json:"small_blind"`
+	BigBlind        int              `json:"big_blind"`
+	Difficulty      Difficulty       `json:"difficulty"`
+	Rules           *poker.GameRules `json:"rules"`
+	DevMode         bool             `json:"dev_mode"`
+	ShowsOuts       bool             `json:"shows_outs"`
+	BlindUpInterval int              `json:"blind_up_interval"`
+}
+
+// HandStart records what was decided when a hand was dealt, by the clock, the
+// rules of chaos mode, or the shuffle, so that the hand can be dealt again.
+type HandStart struct {
+	Rules      *poker.GameRules `json:"rules"`
+	SmallBlind int              `json:"small_blind"`
+	BigBlind   int              `json:"big_blind"`
+	Ante       int              `json:"ante,omitempty"`
+	AnteFormat AnteFormat       `json:"ante_format,omitempty"`
+	// RunItTimes is the number of times the rest of the board is run.
+	RunItTimes int `json:"run_it_times,omitempty"`
+	// AsksToRunIt is whether the players are asked before the board is run
+	// more than once.
+	AsksToRunIt bool `json:"asks_to_run_it,omitempty"`
+	// ChipRace lists the stacks changed by a chip race before the hand.
+	ChipRace []ChipRaceResult `json:"chip_race,omitempty"`
+	// Straddler names the player who straddled, if anyone did.
+	Straddler string `json:"straddler,omitempty"`
+	// HoleCards holds each seat's hole cards, in seating order.
+	HoleCards [][]poker.Card `json:"hole_cards"`
+	// Deck holds the cards left in the deck after the deal, in dealing order.
+	Deck []poker.Card `json:"deck"`
+}
+
+// logEvent appends an event to the game's event log.
+func (g *Game) logEvent(e GameEvent) {
+	g.Events = append(g.Events, e)
+	if g.OnEvent != nil {
+		g.OnEvent(e)
+	}
+}
+
+// PublicEvent returns the event as the named player may see it while the game
+// is on: the deck and the other players' hole cards are left out of a hand's
+// start. Other events hold nothing hidden and are returned as they are.
+func (g *Game) PublicEvent(e GameEvent, viewer string) GameEvent {
+	if e.Hand == nil {
+		return e
+	}
+	hand := *e.Hand
+	hand.Deck = nil
+	hand.HoleCards = make([][]poker.Card, len(e.Hand.HoleCards))
+	for i, cards := range e.Hand.HoleCards {
+		if i < len(g.Players) && g.Players[i].Name == viewer {
+			hand.HoleCards[i] = cards
+		}
+	}
+	e.Hand = &hand
+	return e
+}
+
+// Replay rebuilds a game from its event log by creating it again and applying
+// every event in order. The rebuilt game logs the same events, so it can carry
+// on where the original left off. Settings that do not change the state of
+// play, such as the tournament clock or chaos mode, are not logged and must be
+// set again.
+func Replay(events []GameEvent) (*Game, error) {
+	if len(events) == 0 || events[0].Type != EventGameCreated || events[0].Setup == nil {
+		return nil, errors.New("the event log does not start with the game's creation")
+	}
+	s := events[0].Setup
+	g := NewGame(
+		s.PlayerNames, s.InitialChips, s.SmallBlind, s.BigBlind,
+		s.Difficulty, s.Rules, s.DevMode, s.ShowsOuts, s.BlindUpInterval,
+	)
+	for i, e := range events[1:] {
+		if err := g.applyEvent(e); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", i+1, e.Type, err)
+		}
+	}
+	return g, nil
+}
+
+// applyEvent makes the change recorded by a logged event.
+func (g *Game) applyEvent(e GameEvent) error {
+	var player *Player
+	// A player who joins is not seated yet.
+	if e.Player != "" && e.Type != EventPlayerJoined {
+		if player = g.playerNamed(e.Player); player == nil {
+			return fmt.Errorf("unknown player %q", e.Player)
+		}
+	}
+
+	switch e.Type {
+	case EventHandStarted:
+		if e.Hand == nil {
+			return errors.New("missing hand")
+		}
+		return g.replayHandStart(e.Hand)
+	case EventBettingRoundStarted:
+		g.PrepareNewBettingRound()
+	case EventActionTaken:
+		if player == nil {
+			return errors.New("missing player")
+		}
+		g.ProcessAction(player, e.Action)
+	case EventTurnAdvanced:
+		g.AdvanceTurn()
+	case EventPhaseAdvanced:
+		g.Advance()
+	case EventFastForwarded:
+		return g.FastForward(e.Phase, e.Board)
+	case EventPotDistributed:
+		g.DistributePot()
+	case EventPotAwarded:
+		g.AwardPotToLastPlayer()
+	case EventHandsMucked:
+		return g.muckHands(e.Players)
+	case EventCardShown:
+		if player == nil {
+			return errors.New("missing player")
+		}
+		_, err := g.ShowPartial(player, e.Action)
+		return err
+	case EventHandCleanedUp:
+		g.CleanupHand()
+	case EventRebuy:
+		if player == nil {
+			return errors.New("missing player")
+		}
+		return g.Rebuy(player, e.Amount)
+	case EventAddOn:
+		if player == nil {
+			return errors.New("missing player")
+		}
+		return g.AddOn(player, e.Amount)
+	case EventInsuranceTaken:
+		// The offer is priced again from the same cards.
+		offer, err := g.InsuranceOffer(g.HandContext())
+		if err != nil {
+			return err
+		}
+		if offer == nil || offer.PlayerName != e.Player {
+			return fmt.Errorf("no insurance offer to %q", e.Player)
+		}
+		_, err = g.TakeInsurance(offer, e.Coverage)
+		return err
+	case EventRunItDecided:
+		return g.DecideRunIt(e.Agreed)
+	case EventTimeCharged:
+		_, err := g.chargeTime(e.Amount)
+		return err
+	case EventPlayerJoined:
+		_, err := g.JoinTable(e.Player, e.Amount, e.Profile)
+		return err
+	case EventPlayerLeft:
+		if player == nil {
+			return errors.New("missing player")
+		}
+		return g.LeaveTable(player)
+	case EventBoardRunout:
+		// Advance logs the street again as it deals it.
+	default:
+		return fmt.Errorf("unexpected event type %d", e.Type)
+	}
+	return nil
+}
+
+// replayHandStart deals a logged hand again: the same blinds, chip race and
+// cards, in place of the ones StartNewHand would draw.
+func (g *Game) replayHandStart(h *HandStart) error {
+	if h.Rules == nil {
+		return errors.New("missing rules")
+	}
+	if len(h.HoleCards) != len(g.Players) {
+		return fmt.Errorf("hole cards for %d seats, but the table has %d", len(h.HoleCards), len(g.Players))
+	}
+	deck, err := poker.NewStackedDeck(h.Deck)
+	if err != nil {
+		return err
+	}
+	for _, r := range h.ChipRace {
+		if g.playerNamed(r.PlayerName) == nil {
+			return fmt.Errorf("unknown player %q in chip race", r.PlayerName)
+		}
+	}
+
+	g.beginHand()
+	g.SetRules(h.Rules)
+	g.SmallBlind, g.BigBlind, g.Ante, g.AnteFormat = h.SmallBlind, h.BigBlind, h.Ante, h.AnteFormat
+	g.RunItTimes, g.AsksToRunIt = h.RunItTimes, h.AsksToRunIt
+	for _, r := range h.ChipRace {
+		p := g.playerNamed(r.PlayerName)
+		p.Chips = r.ChipsAfter
+		g.TotalInitialChips += r.ChipsAfter - r.ChipsBefore
+	}
+	sbPos, bbPos := g.setUpHand(deck)
+	g.postStraddle(func(p *Player) bool { return p.Name == h.Straddler })
+	for i, p := range g.Players {
+		p.Hand = append([]poker.Card(nil), h.HoleCards[i]...)
+	}
+	g.dealtHand(sbPos, bbPos, h.ChipRace)
+	return nil
+}
+
+// playerNamed returns the player with the given name, or nil if there is none.
+func (g *Game) playerNamed(name string) *Player {
+	for _, p := range g.Players {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+// playLoggedHand plays a hand the way the CLI does, with every player acting
+// as a CPU.
+func playLoggedHand(g *Game) {
+	g.StartNewHand()
+	for g.Phase != PhaseShowdown && g.Phase != PhaseHandOver {
+		if g.CountNonFoldedPlayers() <= 1 {
+			break
+		}
+		g.PrepareNewBettingRound()
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+			if player.Status != PlayerStatusPlaying {
+				g.AdvanceTurn()
+				continue
+			}
+			g.ProcessAction(player, g.GetCPUAction(player, g.Rand))
+			g.AdvanceTurn()
+		}
+		g.Advance()
+	}
+	if g.CountNonFoldedPlayers() > 1 {
+		g.MuckLosingHands(g.DistributePot())
+	} else {
+		g.AwardPotToLastPlayer()
+	}
+	g.CleanupHand()
+}
+
+func TestReplay_RebuildsSession(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 50000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(1))
+	g.Players[0].Profile = g.Players[3].Profile
+	// Neither setting is logged, but their effects are.
+	g.BlindUpInterval = 4
+	g.AutoMuck = true
+
+	showdowns := 0
+	for i := 0; i < 20 && g.CountRemainingPlayers() > 1; i++ {
+		playLoggedHand(g)
+		if len(g.History.Results) > 0 && g.History.Results[0].HandDesc != "takes the pot as the last remaining player" {
+			showdowns++
+		}
+		if human := g.Players[0]; g.CanShowPartial(human) {
+			if _, err := g.ShowPartial(human, PlayerAction{Type: ActionShowPartial}); err != nil {
+				t.Fatalf("Failed to show a card: %v", err)
+			}
+		}
+		if g.Players[0].Status == PlayerStatusEliminated {
+			if err := g.Rebuy(g.Players[0], 50000); err != nil {
+				t.Fatalf("Failed to rebuy: %v", err)
+			}
+		}
+	}
+	if showdowns == 0 {
+		t.Fatalf("Expected the session to reach a showdown")
+	}
+
+	// The log survives being saved, as a resumed game's would.
+	saved, err := json.Marshal(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to encode the event log: %v", err)
+	}
+	var events []GameEvent
+	if err := json.Unmarshal(saved, &events); err != nil {
+		t.Fatalf("Failed to decode the event log: %v", err)
+	}
+
+	replayed, err := Replay(events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	if replayed.HandCount != g.HandCount || replayed.SmallBlind != g.SmallBlind || replayed.TotalInitialChips != g.TotalInitialChips {
+		t.Errorf("Expected hand %d at %d/%d with %d chips, but got hand %d at %d/%d with %d chips",
+			g.HandCount, g.SmallBlind, g.BigBlind, g.TotalInitialChips,
+			replayed.HandCount, replayed.SmallBlind, replayed.BigBlind, replayed.TotalInitialChips)
+	}
+	for i, p := range g.Players {
+		r := replayed.Players[i]
+		if r.Chips != p.Chips || r.Status != p.Status {
+			t.Errorf("%s: expected %d chips (%v), but got %d (%v)", p.Name, p.Chips, p.Status, r.Chips, r.Status)
+		}
+	}
+	if len(replayed.ChipViolations) != 0 {
+		t.Errorf("Expected no chip violations in the replay, got %v", replayed.ChipViolations)
+	}
+
+	// The rebuilt game logs the same events, so it can be replayed in turn.
+	again, err := json.Marshal(replayed.Events)
+	if err != nil {
+		t.Fatalf("Failed to encode the replayed event log: %v", err)
+	}
+	if !bytes.Equal(again, saved) {
+		t.Errorf("Expected the replay to log the same %d events, but got %d", len(g.Events), len(replayed.Events))
+	}
+}
+
+func TestReplay_FastForward(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	board := g.Deck.PeekForDebug(5)
+	if err := g.FastForward(PhaseRiver, board); err != nil {
+		t.Fatalf("Failed to fast-forward: %v", err)
+	}
+	g.MuckLosingHands(g.DistributePot())
+	g.CleanupHand()
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	for i, p := range g.Players {
+		if replayed.Players[i].Chips != p.Chips {
+			t.Errorf("%s: expected %d chips, but got %d", p.Name, p.Chips, replayed.Players[i].Chips)
+		}
+	}
+}
+
+func TestReplay_InvalidLogs(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	playFoldedHand(g)
+
+	if _, err := Replay(nil); err == nil {
+		t.Errorf("Expected an error replaying an empty log")
+	}
+	if _, err := Replay(g.Events[1:]); err == nil {
+		t.Errorf("Expected an error replaying a log without the game's creation")
+	}
+
+	events := append([]GameEvent(nil), g.Events...)
+	for i, e := range events {
+		if e.Type == EventActionTaken {
+			events[i].Player = "CPU 9"
+			break
+		}
+	}
+	if _, err := Replay(events); err == nil {
+		t.Errorf("Expected an error replaying an action by an unknown player")
+	}
+}
+
+func TestOnEvent_SeesEventsAsLogged(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(3))
+	g.Players[0].Profile = g.Players[1].Profile
+	var seen []GameEvent
+	g.OnEvent = func(e GameEvent) { seen = append(seen, g.PublicEvent(e, "YOU")) }
+	logged := len(g.Events)
+
+	playLoggedHand(g)
+
+	if len(seen) != len(g.Events)-logged {
+		t.Fatalf("OnEvent saw %d events, want %d", len(seen), len(g.Events)-logged)
+	}
+	if seen[0].Type != EventHandStarted {
+		t.Fatalf("first event = %s, want %s", seen[0].Type, EventHandStarted)
+	}
+	public, full := seen[0].Hand, g.Events[logged].Hand
+	if public.Deck != nil {
+		t.Errorf("the public hand start shows the deck: %v", public.Deck)
+	}
+	if len(public.HoleCards[0]) == 0 {
+		t.Errorf("the public hand start hides the viewer's own cards")
+	}
+	for i := 1; i < len(public.HoleCards); i++ {
+		if public.HoleCards[i] != nil {
+			t.Errorf("the public hand start shows seat %d's cards: %v", i, public.HoleCards[i])
+		}
+	}
+	if len(full.Deck) == 0 || len(full.HoleCards[1]) == 0 {
+		t.Errorf("PublicEvent changed the logged hand start")
+	}
+}
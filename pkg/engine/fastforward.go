@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"strings"
+)
+
+// FastForward jumps the current hand to the start of a later street with a
+// chosen board, so that evaluation and pot scenarios can be reproduced in dev
+// mode. The board may be given in full, starting with the cards already dealt,
+// or as just the cards still to come. The rest of the current betting round
+// is skipped: bets already made stay in the pot, and any bet left unmatched is
+// settled like an uncalled bet when the pot is distributed.
+func (g *Game) FastForward(phase GamePhase, board []poker.Card) error {
+	if !g.DevMode {
+		return fmt.Errorf("fast-forward is only available in dev mode")
+	}
+	if !g.handInProgress || g.Phase >= PhaseShowdown {
+		return fmt.Errorf("no hand is being played")
+	}
+	if phase <= g.Phase || phase > PhaseRiver {
+		return fmt.Errorf("cannot fast-forward from the %s to the %s", g.Phase, phase)
+	}
+
+	size := phase.Street().BoardSize()
+	dealt := len(g.CommunityCards)
+	switch len(board) {
+	case size - dealt:
+		board = append(append([]poker.Card(nil), g.CommunityCards...), board...)
+	case size:
+		for i, card := range g.CommunityCards {
+			if board[i] != card {
+				return fmt.Errorf("board card %d must be %s, which is already dealt", i+1, cardName(card))
+			}
+		}
+	default:
+		return fmt.Errorf("the %s needs %d board cards, or the %d still to come, but got %d", phase, size, size-dealt, len(board))
+	}
+
+	newCards := board[dealt:]
+	if err := g.checkCardsAvailable(newCards); err != nil {
+		return err
+	}
+	if err := g.Deck.RemoveCards(newCards); err != nil {
+		return err
+	}
+
+	g.logEvent(GameEvent{Type: EventFastForwarded, Phase: phase, Board: board[dealt:]})
+	g.CommunityCards = board
+	g.Phase = phase
+	g.prepareNewBettingRound()
+	g.publish(&PhaseChangeEvent{Phase: phase, Board: append([]poker.Card(nil), board...)})
+	return nil
+}
+
+// checkCardsAvailable reports the first card that cannot be dealt because it
+// is listed twice, already on the board, or in a player's hand.
+func (g *Game) checkCardsAvailable(cards []poker.Card) error {
+	seen := make(map[poker.Card]bool, len(cards))
+	for _, card := range cards {
+		if seen[card] {
+			return fmt.Errorf("%s is listed twice", cardName(card))
+		}
+		seen[card] = true
+		for _, c := range g.CommunityCards {
+			if c == card {
+				return fmt.Errorf("%s is already on the board", cardName(card))
+			}
+		}
+		for _, p := range g.Players {
+			for _, c := range p.Hand {
+				if c == card {
+					return fmt.Errorf("%s is in %s's hand", cardName(card), p.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// cardName returns the card's name without the padding added by Card.String.
+func cardName(card poker.Card) string {
+	return strings.TrimSpace(card.String())
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newFastForwardGame starts a three-handed hand with known hole cards.
+func newFastForwardGame(t *testing.T) *Game {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.Deck = poker.NewDeck()
+	for i, hand := range []string{"As Ah Ad", "2c 3c 4c", "5h 6h 8h"} {
+		g.Players[i].Hand = mustParseCards(t, hand)
+		if err := g.Deck.RemoveCards(g.Players[i].Hand); err != nil {
+			t.Fatalf("Failed to deal %s: %v", hand, err)
+		}
+	}
+	return g
+}
+
+func TestFastForward(t *testing.T) {
+	g := newFastForwardGame(t)
+	g.ProcessAction(g.Players[0], PlayerAction{Type: ActionCall})
+	deckSize := g.Deck.RemainingCount()
+
+	if err := g.FastForward(PhaseTurn, mustParseCards(t, "Kh 7c 2d 9s")); err != nil {
+		t.Fatalf("Failed to fast-forward to the turn: %v", err)
+	}
+	if g.Phase != PhaseTurn || !reflect.DeepEqual(g.CommunityCards, mustParseCards(t, "Kh 7c 2d 9s")) {
+		t.Errorf("Expected the turn with the chosen board, but got %s with %v", g.Phase, g.CommunityCards)
+	}
+	if g.Deck.RemainingCount() != deckSize-4 {
+		t.Errorf("Expected the board cards to leave the deck, but %d cards remain", g.Deck.RemainingCount())
+	}
+	if g.BetToCall != 0 || g.Pot != 1000+500+1000 {
+		t.Errorf("Expected a new betting round with the bets kept in the pot, but got bet %d, pot %d", g.BetToCall, g.Pot)
+	}
+
+	// Only the river card is needed from here.
+	if err := g.FastForward(PhaseRiver, mustParseCards(t, "3d")); err != nil {
+		t.Fatalf("Failed to fast-forward to the river: %v", err)
+	}
+	if !reflect.DeepEqual(g.CommunityCards, mustParseCards(t, "Kh 7c 2d 9s 3d")) {
+		t.Errorf("Expected the river card to be added, but got %v", g.CommunityCards)
+	}
+}
+
+func TestFastForward_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		phase    GamePhase
+		board    string
+		expected string
+	}{
+		{name: "Card in a hand", phase: PhaseTurn, board: "As", expected: "in YOU's hand"},
+		{name: "Card listed twice", phase: PhaseRiver, board: "Qh Qh", expected: "listed twice"},
+		{name: "Wrong number of cards", phase: PhaseRiver, board: "Qh", expected: "needs 5 board cards"},
+		{name: "Already dealt card changed", phase: PhaseTurn, board: "Qh 7c 2d 9s", expected: "already dealt"},
+		{name: "Card already on the board", phase: PhaseTurn, board: "7c", expected: "already on the board"},
+		{name: "Backwards", phase: PhaseFlop, board: "Qh Qc Qd", expected: "cannot fast-forward"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newFastForwardGame(t)
+			if err := g.FastForward(PhaseFlop, mustParseCards(t, "Kh 7c 2d")); err != nil {
+				t.Fatalf("Failed to fast-forward to the flop: %v", err)
+			}
+			// CardsFromStrings keeps a card listed twice, for FastForward to reject.
+			err := g.FastForward(tc.phase, poker.CardsFromStrings(tc.board))
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected an error containing %q, but got %v", tc.expected, err)
+			}
+		})
+	}
+
+	g := newFastForwardGame(t)
+	g.DevMode = false
+	if err := g.FastForward(PhaseFlop, mustParseCards(t, "Kh 7c 2d")); err == nil {
+		t.Error("Expected fast-forward to require dev mode")
+	}
+}
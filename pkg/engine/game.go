@@ -1,13 +1,14 @@
 package engine
 
 import (
+	"context"
 	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 	"math/rand"
-	"os"
-	"pls7-cli/pkg/poker"
+	"strings"
+	"sync"
 	"time"
-
-	"github.com/sirupsen/logrus"
 )
 
 // GamePhase defines the current stage of a poker hand, from the initial deal
@@ -29,6 +30,23 @@ func (gp GamePhase) String() string {
 	return []string{"Pre-Flop", "Flop", "Turn", "River", "Showdown", "Hand Over"}[gp]
 }
 
+// Street maps a betting phase to the corresponding community-card street.
+// Showdown and Hand Over are mapped to the river, although a hand may end
+// before its board is complete; the street of the board at hand is the one
+// poker.StreetForBoard gives for its size.
+func (gp GamePhase) Street() poker.Street {
+	switch gp {
+	case PhasePreFlop:
+		return poker.StreetPreFlop
+	case PhaseFlop:
+		return poker.StreetFlop
+	case PhaseTurn:
+		return poker.StreetTurn
+	default:
+		return poker.StreetRiver
+	}
+}
+
 // Game is the central struct that encapsulates the entire state of a single poker hand.
 // It acts as the orchestrator, managing players, cards, betting, and the progression
 // through game phases. An instance of Game is a self-contained universe for one hand of poker.
@@ -44,7 +62,15 @@ type Game struct {
 	// Pot holds the total amount of chips wagered by all players in the current hand.
 	Pot int
 	// DealerPos is the index in the Players slice corresponding to the player with the dealer button.
+	// The seat's player may have been eliminated, when the button is dead (see moveBlinds).
 	DealerPos int
+	// SmallBlindPos and BigBlindPos are the seats of the blinds in the current
+	// hand. SmallBlindPos is -1 when the small blind is dead (see moveBlinds).
+	SmallBlindPos int
+	BigBlindPos   int
+	// smallBlindSeat is the small blind's seat in the current hand, even when
+	// it is dead: the button moves to it for the next hand.
+	smallBlindSeat int
 	// CurrentTurnPos is the index in the Players slice for the player whose turn it is to act.
 	CurrentTurnPos int
 	// Phase indicates the current stage of the hand (e.g., Pre-Flop, Flop, Turn).
@@ -60,39 +86,211 @@ type Game struct {
 	SmallBlind int
 	// BigBlind is the size of the big blind for the current hand.
 	BigBlind int
+	// Ante is the ante each player posts for the current hand. 0 means no ante.
+	Ante int
+	// AnteFormat is the format Ante is posted in, by everyone or by one
+	// player for the table.
+	AnteFormat AnteFormat
+	// Straddle decides which seat may straddle, if any.
+	Straddle StraddleSeat
+	// HumanStraddles makes the human straddle when in the straddle seat. It is
+	// set before each hand, e.g. after asking the player (see NextStraddler).
+	HumanStraddles bool
+	// Straddler is the player who straddled the current hand, or nil.
+	Straddler *Player
 	// Difficulty determines the skill level of the AI opponents.
 	Difficulty Difficulty
 	// handEvaluator is a function used to determine hand strength, primarily for AI decisions.
 	// It can be replaced in tests for predictable outcomes.
 	handEvaluator func(g *Game, player *Player) float64
+	// evalCache shares hand evaluations among the decisions made on a street.
+	evalCache evalCache
 	// DevMode enables development-specific features like detailed logging or predictable card dealing.
 	DevMode bool
+	// DevPrivacy keeps the CPUs' hole cards hidden at the table in development
+	// mode, until the showdown, as outside it.
+	DevPrivacy bool
 	// ShowsOuts enables a helper feature for human players to see their potential "outs" cards.
 	ShowsOuts bool
+	// AutoMuck mucks the human player's losing hand at showdown instead of showing it.
+	AutoMuck bool
 	// Rules contains the complete set of rules for the specific poker variant being played.
 	Rules *poker.GameRules
 	// Rand is the single source of randomness for the entire game, used for shuffling and AI decisions.
 	Rand *rand.Rand
+	// Now returns the current time, used to stamp hand histories and to time
+	// the time charge. It can be replaced by hosts with their own clock, such
+	// as a browser, or in tests. If nil, the system clock is used.
+	Now func() time.Time
 	// BlindUpInterval is the number of hands after which the blinds increase. 0 disables this.
 	BlindUpInterval int
+	// HandsLimit is the number of hands after which the game ends, won by the
+	// chip leader (see HandsLimitReached). 0 plays on until the players are
+	// eliminated.
+	HandsLimit int
+	// Clock is the tournament clock for time-based blind levels. When set, it
+	// replaces BlindUpInterval. It is nil for hand-count based blinds.
+	Clock *TournamentClock
+	// Structure is the blind structure played with the tournament clock, or
+	// nil if the blinds simply double at every level.
+	Structure *BlindStructure
+	// structureUnit is the starting small blind, in which the structure's
+	// levels are given.
+	structureUnit int
+	// chipDenomination is the smallest chip in play under the blind structure.
+	chipDenomination int
+	// table is the tournament table the game is played at, or nil outside a
+	// tournament. The tournament's clock then sets the blind level.
+	table *TournamentTable
 	// BettingCalculator is an interface that calculates valid bet/raise sizes based on the game's betting limit.
 	BettingCalculator BettingLimitCalculator
 	// Aggressor points to the player who made the last aggressive action (bet or raise).
 	// This is key to determining when a betting round ends.
 	Aggressor *Player
+	// calledAggressor is the last player whose bet or raise was called this
+	// hand, on any street, who must show at showdown.
+	calledAggressor *Player
 	// ActionCloserPos is the position of the player who can close the action in a round
-	// if no one raises. Pre-flop, this is the Big Blind. Post-flop, it's the first active
-	// player to the left of the dealer.
+	// if no one raises. Pre-flop, this is the Big Blind, or the straddler. Post-flop, it's
+	// the first active player to the left of the dealer.
 	ActionCloserPos int
 	// ActionsTakenThisRound counts player actions to help determine the end of a betting round.
 	ActionsTakenThisRound int
-	// TotalInitialChips stores the sum of all players' starting chips, used for sanity checks
-	// to ensure chip conservation.
+	// previousWinners names the players who won chips in the previous hand,
+	// whom the rules' must-bet rule may bind.
+	previousWinners map[string]bool
+	// handViolations is the number of ChipViolations found before the
+	// current hand began.
+	handViolations int
+	// subscribers are the channels table events are sent on, guarded by
+	// subscribersMu as they may subscribe from other goroutines.
+	subscribers   []chan TableEvent
+	subscribersMu sync.Mutex
+	// ActionSeq is the sequence number the next action must carry when it is
+	// submitted with SubmitAction. It moves on with every action taken, every
+	// new betting round and every new hand.
+	ActionSeq int
+	// TotalInitialChips stores the sum of all players' starting chips, plus any rebuys and
+	// add-ons, used for sanity checks to ensure chip conservation.
 	TotalInitialChips int
+	// HumanModel is the AI's memory of the human player's tendencies. It may be
+	// loaded from a previous session and is updated as the human acts. It is nil
+	// when opponent modeling is not in use.
+	HumanModel *OpponentModel
+	// PreFlopStats holds every player's pre-flop line statistics (cold calls,
+	// squeezes and limp-reraises) for the session, keyed by player name.
+	PreFlopStats map[string]*PreFlopStats
+	// PlayerStats holds every player's VPIP, pre-flop raises, aggression and
+	// folds to continuation bets for the session, keyed by player name.
+	PlayerStats map[string]*PlayerStats
+	// ShowdownGallery holds the hands every player has shown down this
+	// session, keyed by player name and oldest first.
+	ShowdownGallery map[string][]ShownHand
+	// TableImages holds what every player's showdowns revealed about their
+	// pre-flop ranges this session, keyed by player name.
+	TableImages map[string]*TableImage
+	// ShowsHUD displays each player's pre-flop line statistics at the table.
+	ShowsHUD bool
+	// StreamerMode lets the human hide and reveal their hole cards at the
+	// action prompt (see Player.HandHidden).
+	StreamerMode bool
+	// OffersInsurance offers insurance to the favorite of an all-in pot (see
+	// InsuranceOffer).
+	OffersInsurance bool
+	// RunItTimes is the number of times the rest of the board is run when
+	// players are all-in with cards to come, from 1 to MaxRunItTimes; each run
+	// wins an equal share of the pot. Zero runs it once.
+	RunItTimes int
+	// AsksToRunIt makes running the board RunItTimes times a choice: the
+	// players in an all-in pot are asked (see RunItOffer), and unless they all
+	// agree, the board is run once.
+	AsksToRunIt bool
+	// runItAnswer is the players' answer when asked to run the board more than
+	// once in the current hand.
+	runItAnswer runItAnswer
+	// Runs lists the boards the last pot was run out on, when it was run more
+	// than once.
+	Runs []BoardRun
+	// Uncalled is the bet returned to its bettor before the last pot was
+	// distributed, or nil if every bet was called.
+	Uncalled *UncalledBet
+	// runFrom is the number of board cards that were out when the rest of the
+	// board was set to be run RunItTimes times, or -1 until then.
+	runFrom int
+	// OutsDelay is how long the outs and equity panel is held back after the
+	// table is shown.
+	OutsDelay time.Duration
+	// Macros binds short names the human may type at the action prompt to
+	// action commands, e.g. "m1" to "raise 2.5bb".
+	Macros map[string]ActionCommand
+	// HumanStreetStats holds the human player's continuation bets and
+	// per-street aggression for the session, for the coach.
+	HumanStreetStats StreetStats
+	// Events is the game's event log: every change to the state of play, in
+	// order, from which Replay can rebuild the game.
+	Events []GameEvent
+	// OnEvent, if set, is called with every event as it is logged. It is
+	// called on the game loop, so it must return without waiting.
+	OnEvent func(GameEvent)
+	// OnTableEvent, if set, is called with every table event as it happens,
+	// before the subscribers get it: a frontend that presents the game on
+	// the game loop, like the CLI, can present each event there.
+	OnTableEvent func(TableEvent)
+	// Insurance is the insurance taken in the current hand, if any.
+	Insurance *InsurancePolicy
+	// InsurancePool holds the premiums paid for insurance, less the payouts,
+	// over the session. It goes negative when the pool has paid out more than
+	// it took in.
+	InsurancePool int
+	// History records the current (or most recently finished) hand. It is
+	// replaced at the start of every hand.
+	History *HandHistory
+	// ChaosMode deals a new random variant every orbit ("dealer's choice").
+	ChaosMode bool
+	// chaosHandsLeft counts the hands left in the current chaos-mode orbit.
+	chaosHandsLeft int
+	// ChipViolations lists every change to the chips in play that did not come
+	// from betting, pot distribution, a rebuy, or an add-on.
+	ChipViolations []ChipViolation
+	// IllegalActions lists every action applied by ProcessAction that the
+	// validate package rejected.
+	IllegalActions []IllegalAction
+	// TimeCharge is the time charge collected from the players between hands
+	// instead of a rake, or nil if there is none.
+	TimeCharge *TimeCharge
+	// TimeCharged holds the time charge each player has paid this session,
+	// keyed by player name.
+	TimeCharged map[string]int
+	// timeChargeCollected is set once the time charge has first been
+	// collected, at lastTimeCharge, before hand lastTimeChargeHand + 1.
+	timeChargeCollected bool
+	lastTimeCharge      time.Time
+	lastTimeChargeHand  int
+	// HumanStats holds the human player's results this session.
+	HumanStats SessionStats
+	// LineupChurn has CPUs leave and join the table between hands, or is nil
+	// for a fixed lineup.
+	LineupChurn *LineupChurn
+	// profiles is the profile set the CPUs were given, from which joining
+	// CPUs take theirs. It is nil for the built-in profiles.
+	profiles *config.AIProfileSet
+	// departed lists the names of the players who have left the table.
+	departed []string
+	// chipDrift is the net change from chip violations already reported.
+	chipDrift int
+	// stacksAfterHand holds each player's stack at the end of the last hand.
+	stacksAfterHand map[*Player]int
+	// handInProgress is true from StartNewHand until CleanupHand.
+	handInProgress bool
+	// handCtx is the current hand's context, and cancelHand cancels it when
+	// the hand is cleaned up (see HandContext).
+	handCtx    context.Context
+	cancelHand context.CancelFunc
 }
 
 // CPUThinkTime returns the delay used to simulate CPU "thinking" for a more
-// realistic game pace. In development mode, this delay is zero.
+// realistic game pace. In development mode, this delay is zero. The engine
+// never waits itself; it is up to the caller to pause before a CPU acts.
 func (g *Game) CPUThinkTime() time.Duration {
 	if g.DevMode {
 		return 0 // No delay in dev mode.
@@ -100,9 +298,18 @@ func (g *Game) CPUThinkTime() time.Duration {
 	return 500 * time.Millisecond // Default delay.
 }
 
+// now returns the current time by Now, or by the system clock if Now is nil.
+func (g *Game) now() time.Time {
+	if g.Now != nil {
+		return g.Now()
+	}
+	return time.Now()
+}
+
 // NewGame is the constructor for the Game object. It initializes the game state,
 // creates players, assigns AI profiles, and sets up the rules for the specified
-// poker variant.
+// poker variant. It panics if there are no CPU profiles for the players or
+// the difficulty, as the callers are expected to pass valid settings.
 func NewGame(
 	playerNames []string,
 	initialChips int,
@@ -116,21 +323,27 @@ func NewGame(
 ) *Game {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	players := make([]*Player, len(playerNames))
-	cpuProfilesToAssign, err := cpuProfiles(difficulty, len(playerNames)-1)
+	numCPUs := 0
+	for _, name := range playerNames {
+		if name != "YOU" {
+			numCPUs++
+		}
+	}
+	cpuProfilesToAssign, err := cpuProfiles(difficulty, numCPUs)
 	if err != nil {
-		logrus.Errorf("Failed to get CPU profiles: %v", err)
-		os.Exit(1)
+		panic(fmt.Sprintf("failed to get CPU profiles: %v", err))
 	}
 
-	if len(playerNames)-1 != len(cpuProfilesToAssign) {
-		logrus.Errorf(
-			"Mismatch in number of CPU profiles and players. %d != %d - 1",
-			len(cpuProfilesToAssign), len(playerNames),
-		)
-		os.Exit(1)
+	if numCPUs != len(cpuProfilesToAssign) {
+		panic(fmt.Sprintf(
+			"mismatch in number of CPU profiles and CPUs: %d != %d",
+			len(cpuProfilesToAssign), numCPUs,
+		))
 	}
 
-	// Create player objects, assigning AI profiles to CPUs.
+	// Create player objects, assigning AI profiles to CPUs. A table of CPUs
+	// only, such as another table of a tournament, has no "YOU".
+	cpuIndex := 0
 	for i, name := range playerNames {
 		isCPU := name != "YOU"
 		players[i] = &Player{
@@ -141,45 +354,89 @@ func NewGame(
 		}
 
 		if isCPU {
-			if profile, ok := aiProfiles[cpuProfilesToAssign[i-1]]; ok {
-				players[i].Profile = &profile
-			} else {
-				logrus.Errorf("Unknown AI profile: %s", cpuProfilesToAssign[i-1])
-				os.Exit(1)
-			}
+			profile := cpuProfilesToAssign[cpuIndex]
+			players[i].Profile = &profile
+			cpuIndex++
 		}
 	}
 
-	// Select the appropriate betting calculator based on the game rules.
-	var calculator BettingLimitCalculator
-	switch rules.BettingLimit {
-	case "pot_limit":
-		calculator = &PotLimitCalculator{}
-	case "no_limit":
-		calculator = &NoLimitCalculator{}
-	default:
-		logrus.Fatalf("Unknown betting limit type: %s", rules.BettingLimit)
-	}
-
 	g := &Game{
 		Players:           players,
 		DealerPos:         -1, // Dealer position is set at the start of the first hand.
+		SmallBlindPos:     -1,
+		BigBlindPos:       -1,
+		smallBlindSeat:    -1,
+		runFrom:           -1,
 		SmallBlind:        smallBlind,
 		BigBlind:          bigBlind,
+		Ante:              rules.Ante,
 		Difficulty:        difficulty,
 		DevMode:           isDev,
 		ShowsOuts:         showsOuts,
 		Rules:             rules,
 		Rand:              r,
+		Now:               time.Now,
 		BlindUpInterval:   blindUpInterval,
-		BettingCalculator: calculator,
+		BettingCalculator: newBettingCalculator(rules),
 		TotalInitialChips: initialChips * len(playerNames),
 	}
 	// Set the default hand evaluator function.
 	g.handEvaluator = evaluateHandStrength
+	g.logEvent(GameEvent{Type: EventGameCreated, Setup: &GameSetup{
+		PlayerNames:     append([]string(nil), playerNames...),
+		InitialChips:    initialChips,
+		SmallBlind:      smallBlind,
+		BigBlind:        bigBlind,
+		Difficulty:      difficulty,
+		Rules:           rules,
+		DevMode:         isDev,
+		ShowsOuts:       showsOuts,
+		BlindUpInterval: blindUpInterval,
+	}})
 	return g
 }
 
+// newBettingCalculator selects the appropriate betting calculator for the
+// game's betting limit.
+func newBettingCalculator(rules *poker.GameRules) BettingLimitCalculator {
+	switch rules.BettingLimit {
+	case "pot_limit":
+		return &PotLimitCalculator{}
+	case "no_limit":
+		return &NoLimitCalculator{}
+	case "fixed_limit":
+		return &FixedLimitCalculator{Rules: rules.FixedLimit}
+	default:
+		panic(fmt.Sprintf("unknown betting limit type: %s", rules.BettingLimit))
+	}
+}
+
+// SetRules switches the game to a different variant between hands, along with
+// the matching betting calculator.
+func (g *Game) SetRules(rules *poker.GameRules) {
+	g.Rules = rules
+	g.BettingCalculator = newBettingCalculator(rules)
+}
+
+// RotateChaosVariant is called before each hand in chaos mode. At the start of
+// every orbit it switches the game to a new random variant and returns its
+// rules; otherwise it returns nil.
+func (g *Game) RotateChaosVariant() *poker.GameRules {
+	if !g.ChaosMode {
+		return nil
+	}
+	if g.chaosHandsLeft > 0 {
+		g.chaosHandsLeft--
+		return nil
+	}
+	// The variant changes, but the chips on the table do not.
+	rules := poker.RandomVariant(g.Rand)
+	rules.ChipUnit = g.Rules.ChipUnit
+	g.SetRules(rules)
+	g.chaosHandsLeft = g.CountRemainingPlayers() - 1
+	return g.Rules
+}
+
 // String provides a formatted string representation of the current game state,
 // useful for debugging and logging.
 func (g *Game) String() string {
@@ -206,15 +463,40 @@ func (g *Game) String() string {
 }
 
 // CalculateBettingLimits delegates the calculation of valid bet and raise sizes
-// to the game's configured BettingLimitCalculator.
+// to the game's configured BettingLimitCalculator, rounded to the chip unit.
 func (g *Game) CalculateBettingLimits() (minRaiseTotal int, maxRaiseTotal int) {
-	return g.BettingCalculator.CalculateBettingLimits(g)
+	return g.roundBettingLimits(g.BettingCalculator.CalculateBettingLimits(g))
+}
+
+// EvaluatePlayerHand evaluates a player's hand on the community cards dealt so
+// far, returning the made hand and draw information in a single result. The
+// street is read from the board, so a hand that ended before the river is
+// evaluated on its short board at the showdown and once it is over.
+// Evaluations are shared for the rest of the street, so the result must not
+// be modified.
+func (g *Game) EvaluatePlayerHand(p *Player) (*poker.StreetEvaluation, error) {
+	street, err := poker.StreetForBoard(len(g.CommunityCards))
+	if err != nil {
+		return nil, err
+	}
+	e := g.cachedEvaluation(p.Hand)
+	if e.street != nil && e.street.Street == street {
+		g.evalCache.hits++
+		return e.street, nil
+	}
+	g.evalCache.misses++
+	evaluation, err := poker.EvaluateStreet(g.HandContext(), p.Hand, g.CommunityCards, street, g.Rules)
+	if err != nil {
+		return nil, err
+	}
+	e.street = evaluation
+	return evaluation, nil
 }
 
 // CanShowOuts determines if the "show outs" helper should be displayed for a player.
 // It is typically only enabled for the human player in development or easy modes.
 func (g *Game) CanShowOuts(p *Player) bool {
-	humanPlayerInPlay := p.Name == "YOU" && p.Status != PlayerStatusFolded
+	humanPlayerInPlay := p.Name == "YOU" && p.Status != PlayerStatusFolded && !p.HandHidden
 	availablePhase := g.Phase == PhaseFlop || g.Phase == PhaseTurn
 	optionEnabled := g.DevMode || g.ShowsOuts
 	return humanPlayerInPlay && optionEnabled && availablePhase
@@ -232,34 +514,21 @@ func (g *Game) minRaiseAmount() int {
 	return g.BetToCall + minRaiseIncrease
 }
 
-// cpuProfiles returns a slice of AI profile names to be assigned to CPU players,
-// based on the selected game difficulty and the number of CPUs.
-func cpuProfiles(difficulty Difficulty, numCPUs int) ([]string, error) {
-	if numCPUs < 1 || numCPUs > 5 {
-		return []string{}, fmt.Errorf("numCPUs must be between 1 and 5, got %d", numCPUs)
+// cpuProfiles returns the built-in AI profiles to be assigned to CPU players,
+// from the lineup of the selected game difficulty in config.DefaultAIProfiles.
+func cpuProfiles(difficulty Difficulty, numCPUs int) ([]AIProfile, error) {
+	if numCPUs < 1 || numCPUs > MaxTableSize {
+		return nil, fmt.Errorf("numCPUs must be between 1 and %d, got %d", MaxTableSize, numCPUs)
 	}
 
-	switch difficulty {
-	case DifficultyEasy:
-		// Easy difficulty features more passive opponents.
-		return []string{
-			"Loose-Passive", "Loose-Passive",
-			"Loose-Passive", "Loose-Passive", "Loose-Passive",
-		}[:numCPUs], nil
-	case DifficultyMedium:
-		// Medium difficulty introduces a mix of passive styles.
-		return []string{
-			"Loose-Passive", "Loose-Passive",
-			"Tight-Passive", "Tight-Passive", "Tight-Passive",
-		}[:numCPUs], nil
-	case DifficultyHard:
-		// Hard difficulty features more aggressive and varied opponents.
-		return []string{
-			"Tight-Passive",
-			"Loose-Aggressive", "Loose-Aggressive",
-			"Tight-Aggressive", "Tight-Aggressive",
-		}[:numCPUs], nil
-	default:
-		return []string{}, fmt.Errorf("unknown difficulty: %v", difficulty)
+	set := config.DefaultAIProfiles()
+	names := set.Lineup(strings.ToLower(difficulty.String()), numCPUs)
+	if names == nil {
+		return nil, fmt.Errorf("unknown difficulty: %v", difficulty)
+	}
+	profiles := make([]AIProfile, len(names))
+	for i, name := range names {
+		profiles[i] = *set.Profile(name)
 	}
+	return profiles, nil
 }
@@ -1,7 +1,7 @@
 package engine
 
 import (
-	"pls7-cli/internal/config"
+	"github.com/philipjkim/pls7-cli/pkg/config"
 	"reflect"
 	"testing"
 )
@@ -59,6 +59,11 @@ func TestNewGame_AssignsCorrectCalculator(t *testing.T) {
 			ruleStr:            "nlh",
 			expectedCalculator: &NoLimitCalculator{},
 		},
+		{
+			name:               "Fixed Limit Game",
+			ruleStr:            "lhe",
+			expectedCalculator: &FixedLimitCalculator{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -82,3 +87,207 @@ func TestNewGame_AssignsCorrectCalculator(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateChaosVariant(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	if g.RotateChaosVariant() != nil {
+		t.Fatal("expected no variant change when chaos mode is off")
+	}
+
+	g.ChaosMode = true
+	first := g.RotateChaosVariant()
+	if first == nil || g.Rules != first {
+		t.Fatal("expected a new variant at the start of the first orbit")
+	}
+	for i := 0; i < 2; i++ {
+		if g.RotateChaosVariant() != nil {
+			t.Errorf("expected the variant to be kept for the rest of the orbit (hand %d)", i+2)
+		}
+	}
+	if g.RotateChaosVariant() == nil {
+		t.Error("expected a new variant at the start of the next orbit")
+	}
+
+	g.StartNewHand()
+	if len(g.Players[0].Hand) != g.Rules.HoleCards.Count {
+		t.Errorf("expected %d hole cards, got %d", g.Rules.HoleCards.Count, len(g.Players[0].Hand))
+	}
+}
+
+// TestDealHoleCards_InvalidDebugHand tests that the human is dealt a full
+// hand at random in dev mode when the debug hand does not parse.
+func TestDealHoleCards_InvalidDebugHand(t *testing.T) {
+	debugHands := playerHoleCardsForDebug["PLS"]
+	valid := debugHands["3As"]
+	debugHands["3As"] = "As Zz Ad"
+	t.Cleanup(func() { debugHands["3As"] = valid })
+
+	g := newGameForBettingTests([]string{"YOU", "CPU 1"}, 10000, 50, 100)
+	g.StartNewHand()
+	if got := len(g.Players[0].Hand); got != g.Rules.HoleCards.Count {
+		t.Errorf("Expected the human to be dealt %d hole cards, but got %d", g.Rules.HoleCards.Count, got)
+	}
+}
+
+func TestCanShowOuts_HiddenHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	g.Phase = PhaseFlop
+	human := g.Players[0]
+
+	if !g.CanShowOuts(human) {
+		t.Fatal("Expected outs to be shown for the human in dev mode")
+	}
+	human.HandHidden = true
+	if g.CanShowOuts(human) {
+		t.Error("Expected no outs to be shown for a hidden hand")
+	}
+}
+
+func TestMoveBlinds_DeadSmallBlind(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}, 10000, 50, 100)
+	eliminate := func(positions ...int) {
+		for _, pos := range positions {
+			g.Players[pos].Chips = 0
+			g.Players[pos].Status = PlayerStatusEliminated
+		}
+	}
+	expectBlinds := func(hand string, dealer, sb, bb int) {
+		t.Helper()
+		if g.DealerPos != dealer || g.SmallBlindPos != sb || g.BigBlindPos != bb {
+			t.Fatalf("%s: expected button %d and blinds %d/%d, but got button %d and blinds %d/%d",
+				hand, dealer, sb, bb, g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+		}
+	}
+
+	playFoldedHand(g)
+	expectBlinds("Hand 1", 0, 1, 2)
+
+	// Both blinds bust: the big blind moves on one seat and the small blind,
+	// whose seat is now empty, is dead. So is the button, on the previous
+	// small blind's seat.
+	eliminate(1, 2)
+	g.StartNewHand()
+	expectBlinds("Hand 2", 1, -1, 3)
+	if g.ButtonPlayer() != nil || g.History.Dealer != "" {
+		t.Errorf("Expected the button to be dead, but got %q", g.History.Dealer)
+	}
+	if g.Pot != g.BigBlind {
+		t.Errorf("Expected only the big blind to be posted, but the pot is %d", g.Pot)
+	}
+	if g.History.SmallBlindPlayer != "" || g.History.BigBlindPlayer != "CPU3" {
+		t.Errorf("Expected a dead small blind and CPU3 in the big blind, but got %q and %q",
+			g.History.SmallBlindPlayer, g.History.BigBlindPlayer)
+	}
+	if g.CanComplete(g.Players[0]) {
+		t.Errorf("Expected nobody to be able to complete a dead small blind")
+	}
+	g.PrepareNewBettingRound()
+	if g.CurrentPlayer().Name != "CPU4" || g.ActionCloserPos != 3 {
+		t.Errorf("Expected CPU4 to act first and the big blind to close the action, but got %s and %d",
+			g.CurrentPlayer().Name, g.ActionCloserPos)
+	}
+	g.CleanupHand()
+
+	// The next big blind busts too, leaving two players: heads-up, there is no
+	// dead small blind, as the button posts it.
+	eliminate(3)
+	g.StartNewHand()
+	expectBlinds("Hand 3", 0, 0, 4)
+	g.CleanupHand()
+
+	// The last big blind takes the button and the small blind.
+	g.StartNewHand()
+	expectBlinds("Hand 4", 4, 4, 0)
+	if g.Players[4].CurrentBet != g.SmallBlind {
+		t.Errorf("Expected CPU4 to post the small blind, but got %d", g.Players[4].CurrentBet)
+	}
+}
+
+func TestMoveBlinds_SmallBlindBusts(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	playFoldedHand(g)
+
+	// The button moves to the empty seat, where it is dead, rather than
+	// staying with YOU for a second hand. The previous big blind posts the
+	// small blind as usual, and YOU still act last.
+	g.Players[1].Chips = 0
+	g.Players[1].Status = PlayerStatusEliminated
+	g.StartNewHand()
+	if g.DealerPos != 1 || g.SmallBlindPos != 2 || g.BigBlindPos != 3 {
+		t.Errorf("Expected button 1 and blinds 2/3, but got button %d and blinds %d/%d", g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+	if g.ButtonPlayer() != nil {
+		t.Errorf("Expected the button to be dead, but got %s", g.ButtonPlayer().Name)
+	}
+	if positions := g.seatPositions(g.SmallBlindPos, g.BigBlindPos); positions["YOU"] != PositionButton {
+		t.Errorf("Expected YOU in the button's position, but got %v", positions)
+	}
+	g.CleanupHand()
+
+	// The next hand, the button moves on to the previous small blind.
+	g.StartNewHand()
+	if g.DealerPos != 2 || g.SmallBlindPos != 3 || g.BigBlindPos != 0 {
+		t.Errorf("Expected button 2 and blinds 3/0, but got button %d and blinds %d/%d", g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+}
+
+func TestHeadsUp_ButtonPostsSmallBlind(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1"}, 10000, 50, 100)
+	for hand := 1; hand <= 2; hand++ {
+		g.StartNewHand()
+		bb := g.FindNextActivePlayer(g.DealerPos)
+		if g.SmallBlindPos != g.DealerPos || g.BigBlindPos != bb {
+			t.Fatalf("Hand %d: expected the button %d to post the small blind, but got blinds %d/%d",
+				hand, g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+		}
+		if g.Players[g.DealerPos].CurrentBet != g.SmallBlind {
+			t.Errorf("Hand %d: expected the button to post %d, but got %d", hand, g.SmallBlind, g.Players[g.DealerPos].CurrentBet)
+		}
+
+		// The button acts first pre-flop, and the big blind closes the action.
+		g.PrepareNewBettingRound()
+		if g.CurrentTurnPos != g.DealerPos || g.ActionCloserPos != bb {
+			t.Errorf("Hand %d: expected the button to act first pre-flop, but got %d and closer %d", hand, g.CurrentTurnPos, g.ActionCloserPos)
+		}
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCall})
+		g.AdvanceTurn()
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCheck})
+		g.AdvanceTurn()
+		if !g.IsBettingRoundOver() {
+			t.Fatalf("Hand %d: expected the pre-flop round to be over", hand)
+		}
+
+		// After the flop, the big blind acts first, and the button last.
+		g.Advance()
+		g.PrepareNewBettingRound()
+		if g.CurrentTurnPos != bb || g.ActionCloserPos != g.DealerPos {
+			t.Errorf("Hand %d: expected the big blind to act first on the flop, but got %d and closer %d", hand, g.CurrentTurnPos, g.ActionCloserPos)
+		}
+		g.CleanupHand()
+	}
+	if g.DealerPos != 1 {
+		t.Errorf("Expected the button to move to CPU1, but got %d", g.DealerPos)
+	}
+}
+
+func TestHeadsUp_DownFromFullTable(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100)
+	playFoldedHand(g)
+	if g.IsHeadsUp() || g.DealerPos != 0 || g.SmallBlindPos != 1 || g.BigBlindPos != 2 {
+		t.Fatalf("Expected button 0 and blinds 1/2 three-handed, but got button %d and blinds %d/%d",
+			g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+
+	// The small blind busts: the big blind moves on to YOU, and CPU2 takes
+	// the button with the small blind instead of it being dead.
+	g.Players[1].Chips = 0
+	g.Players[1].Status = PlayerStatusEliminated
+	g.StartNewHand()
+	if !g.IsHeadsUp() || g.DealerPos != 2 || g.SmallBlindPos != 2 || g.BigBlindPos != 0 {
+		t.Errorf("Expected button and small blind 2 and big blind 0 heads-up, but got button %d and blinds %d/%d",
+			g.DealerPos, g.SmallBlindPos, g.BigBlindPos)
+	}
+	if g.Pot != g.SmallBlind+g.BigBlind {
+		t.Errorf("Expected both blinds to be posted, but the pot is %d", g.Pot)
+	}
+}
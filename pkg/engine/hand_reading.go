@@ -0,0 +1,129 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+)
+
+// HandRead is the category of a hand shown down, as the human guesses it in
+// the hand reading exercise before the hands are revealed.
+type HandRead int
+
+// HandRead constants, from the weakest hand to the strongest.
+const (
+	// HandReadNothing is a high card hand that had no draw on the turn.
+	HandReadNothing HandRead = iota
+	// HandReadMissedDraw is a high card hand that had a flush or straight
+	// draw on the turn and missed it.
+	HandReadMissedDraw
+	HandReadPair
+	HandReadTwoPair
+	HandReadTrips
+	// HandReadStraight includes the skip straights of PLS.
+	HandReadStraight
+	HandReadFlush
+	HandReadFullHouse
+	// HandReadMonster is four of a kind or any straight flush.
+	HandReadMonster
+)
+
+// handReadNames are the names of the hand reading categories, as typed at the
+// prompt.
+var handReadNames = []string{
+	"nothing", "missed draw", "pair", "two pair", "trips", "straight", "flush", "full house", "monster",
+}
+
+// String returns the category's name, e.g. "missed draw".
+func (r HandRead) String() string {
+	return handReadNames[r]
+}
+
+// ParseHandRead returns the category with the given name, or with the given
+// number, counting from 1 in the order of HandReadNames.
+func ParseHandRead(name string) (HandRead, error) {
+	for i, n := range handReadNames {
+		if n == name || fmt.Sprint(i+1) == name {
+			return HandRead(i), nil
+		}
+	}
+	return HandReadNothing, fmt.Errorf("unknown hand category %q (available: %v)", name, handReadNames)
+}
+
+// HandReadNames returns the names of the hand reading categories.
+func HandReadNames() []string {
+	return append([]string(nil), handReadNames...)
+}
+
+// handReadsByRank maps the made hands to their categories.
+var handReadsByRank = map[poker.HandRank]HandRead{
+	poker.HighCard:          HandReadNothing,
+	poker.OnePair:           HandReadPair,
+	poker.TwoPair:           HandReadTwoPair,
+	poker.ThreeOfAKind:      HandReadTrips,
+	poker.Straight:          HandReadStraight,
+	poker.SkipStraight:      HandReadStraight,
+	poker.Flush:             HandReadFlush,
+	poker.FullHouse:         HandReadFullHouse,
+	poker.FourOfAKind:       HandReadMonster,
+	poker.StraightFlush:     HandReadMonster,
+	poker.SkipStraightFlush: HandReadMonster,
+	poker.RoyalFlush:        HandReadMonster,
+}
+
+// HandReadingOpponents returns the CPUs whose hands the human may read before
+// the showdown: those still in a hand that goes to showdown on a full board
+// with the human in it. It returns nil otherwise, e.g. when the board was run
+// more than once.
+func (g *Game) HandReadingOpponents() []*Player {
+	human := g.Players[0]
+	if human.IsCPU || human.Status == PlayerStatusFolded || human.Status == PlayerStatusEliminated {
+		return nil
+	}
+	if len(g.CommunityCards) < poker.StreetRiver.BoardSize() || len(g.Runs) > 0 || g.CountNonFoldedPlayers() < 2 {
+		return nil
+	}
+	var opponents []*Player
+	for _, p := range g.getShowdownPlayers() {
+		if p.IsCPU {
+			opponents = append(opponents, p)
+		}
+	}
+	return opponents
+}
+
+// ReadHand returns the category of the player's hand on the board.
+func (g *Game) ReadHand(player *Player) (HandRead, error) {
+	evaluation, err := g.EvaluatePlayerHand(player)
+	if err != nil {
+		return HandReadNothing, err
+	}
+	if evaluation.High == nil {
+		return HandReadNothing, fmt.Errorf("no hand to read for %s", player.Name)
+	}
+	read := handReadsByRank[evaluation.MadeRank]
+	if read != HandReadNothing {
+		return read, nil
+	}
+	turn, err := poker.EvaluateStreet(g.HandContext(), player.Hand, g.CommunityCards[:poker.StreetTurn.BoardSize()], poker.StreetTurn, g.Rules)
+	if err != nil {
+		return HandReadNothing, err
+	}
+	if d := turn.Draws; d.FlushDraw || d.StraightDraw || d.SkipStraightDraw {
+		return HandReadMissedDraw, nil
+	}
+	return HandReadNothing, nil
+}
+
+// ScoreHandRead scores the human's guess at the player's hand category,
+// adding it to their session statistics, and returns the actual category.
+func (g *Game) ScoreHandRead(player *Player, guess HandRead) (HandRead, error) {
+	actual, err := g.ReadHand(player)
+	if err != nil {
+		return actual, err
+	}
+	g.HumanStats.HandReads++
+	if guess == actual {
+		g.HumanStats.HandReadsRight++
+	}
+	return actual, nil
+}
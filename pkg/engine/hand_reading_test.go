@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestParseHandRead(t *testing.T) {
+	for i, name := range HandReadNames() {
+		if read, err := ParseHandRead(name); err != nil || read.String() != name {
+			t.Errorf("ParseHandRead(%q) = %v, %v", name, read, err)
+		}
+		if read, err := ParseHandRead(string(rune('1' + i))); err != nil || int(read) != i {
+			t.Errorf("ParseHandRead(%d) = %v, %v", i+1, read, err)
+		}
+	}
+	if _, err := ParseHandRead("quads"); err == nil {
+		t.Error("Expected an error for an unknown hand category")
+	}
+}
+
+func TestReadHand(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hand     string
+		expected HandRead
+	}{
+		{name: "Missed flush draw", hand: "Ah Kh", expected: HandReadMissedDraw},
+		{name: "Missed straight draw", hand: "Jc Tc", expected: HandReadMissedDraw},
+		{name: "Nothing", hand: "Kc Jd", expected: HandReadNothing},
+		{name: "Pair", hand: "Qc 5d", expected: HandReadPair},
+		{name: "Two pair", hand: "Qc 7d", expected: HandReadTwoPair},
+		{name: "Trips", hand: "Qc Qd", expected: HandReadTrips},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, 10000, 50, 100, "NLH")
+			g.CommunityCards = poker.CardsFromStrings("Qh 7h 2c 8s 3d")
+			g.Players[1].Hand = poker.CardsFromStrings(tc.hand)
+			if got, err := g.ReadHand(g.Players[1]); err != nil || got != tc.expected {
+				t.Errorf("Expected %v, but got %v (%v)", tc.expected, got, err)
+			}
+		})
+	}
+}
+
+func TestScoreHandRead(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100, "NLH")
+	g.StartNewHand()
+	if got := g.HandReadingOpponents(); got != nil {
+		t.Errorf("Expected no hands to read before the river, but got %d", len(got))
+	}
+	g.CommunityCards = poker.CardsFromStrings("Qh 7h 2c 8s 3d")
+	g.Players[1].Hand = poker.CardsFromStrings("Qc 5d")
+	g.Players[2].Status = PlayerStatusFolded
+	opponents := g.HandReadingOpponents()
+	if len(opponents) != 1 || opponents[0] != g.Players[1] {
+		t.Fatalf("Expected to read CPU 1's hand only, but got %v", opponents)
+	}
+
+	if actual, err := g.ScoreHandRead(opponents[0], HandReadPair); err != nil || actual != HandReadPair {
+		t.Errorf("Expected a pair, but got %v (%v)", actual, err)
+	}
+	if _, err := g.ScoreHandRead(opponents[0], HandReadMissedDraw); err != nil {
+		t.Errorf("Failed to score a read: %v", err)
+	}
+	if s := g.HumanStats; s.HandReads != 2 || s.HandReadsRight != 1 || s.HandReadAccuracy() != 0.5 {
+		t.Errorf("Expected 1 of 2 hands read right, but got %+v", s)
+	}
+
+	g.Players[0].Status = PlayerStatusFolded
+	if got := g.HandReadingOpponents(); got != nil {
+		t.Errorf("Expected no hands to read once YOU folded, but got %d", len(got))
+	}
+}
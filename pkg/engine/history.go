@@ -0,0 +1,274 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// HandIDTimeFormat is the layout of the timestamp that starts every hand ID.
+const HandIDTimeFormat = "20060102-150405"
+
+// HandHistory is a record of a single hand, from the starting stacks to the
+// pot distribution. It is built up by the Game as the hand is played and can
+// be saved, reviewed, or anonymized for sharing.
+type HandHistory struct {
+	// ID uniquely identifies the hand. It starts with the time the hand was
+	// played, so IDs sort chronologically.
+	ID string `json:"id"`
+	// HandNumber is the hand's number within its game session.
+	HandNumber int `json:"hand_number"`
+	// Rule is the abbreviation of the game variant (e.g., "PLS7").
+	Rule string `json:"rule"`
+	// Rules are the rules the hand was played with. Hands saved before they
+	// were recorded leave them nil.
+	Rules *poker.GameRules `json:"rules,omitempty"`
+	// Config is the table's configuration when the hand was dealt. Hands saved
+	// before it was recorded leave it nil.
+	Config *TableConfig `json:"config,omitempty"`
+	// PlayedAt is the time the hand was dealt.
+	PlayedAt time.Time `json:"played_at"`
+	// SmallBlind and BigBlind are the blinds for the hand.
+	SmallBlind int `json:"small_blind"`
+	BigBlind   int `json:"big_blind"`
+	// Ante is the ante each player posted, or 0 if there was none. When
+	// AntePlayer is set, it is the ante AntePlayer posted for the whole table.
+	Ante int `json:"ante,omitempty"`
+	// AntePlayer names the player who posted the antes for the whole table, in
+	// the big blind and button ante formats.
+	AntePlayer string `json:"ante_player,omitempty"`
+	// Straddle is the straddle StraddlePlayer posted, or 0 if nobody
+	// straddled.
+	Straddle       int    `json:"straddle,omitempty"`
+	StraddlePlayer string `json:"straddle_player,omitempty"`
+	// Dealer, SmallBlindPlayer and BigBlindPlayer name the players on the
+	// button and in the blinds. Dealer is empty when the button is dead, and
+	// SmallBlindPlayer when the small blind is.
+	Dealer           string `json:"dealer"`
+	SmallBlindPlayer string `json:"small_blind_player"`
+	BigBlindPlayer   string `json:"big_blind_player"`
+	// Seats lists the players dealt into the hand, in seating order.
+	Seats []SeatRecord `json:"seats"`
+	// Actions lists every betting action in the order it was taken.
+	Actions []ActionRecord `json:"actions"`
+	// Board holds the community cards dealt by the end of the hand.
+	Board []poker.Card `json:"board"`
+	// Results lists the pot distribution.
+	Results []DistributionResult `json:"results"`
+	// Uncalled is the bet returned to its bettor before the pot was
+	// distributed, which Results leave out. Hands saved before it was
+	// recorded leave it nil and count the bet returned in Results.
+	Uncalled *UncalledBet `json:"uncalled,omitempty"`
+	// Runs lists the boards the pot was run out on, when it was run more than
+	// once. Board is the first of them.
+	Runs []BoardRun `json:"runs,omitempty"`
+	// Insurance is the insurance taken in the hand, if any.
+	Insurance *InsurancePolicy `json:"insurance,omitempty"`
+	// Audit accounts for every chip that moved during the hand.
+	Audit *ChipAudit `json:"audit,omitempty"`
+}
+
+// SeatRecord describes one player's part in a recorded hand.
+type SeatRecord struct {
+	Name          string       `json:"name"`
+	IsHuman       bool         `json:"is_human"`
+	StartingChips int          `json:"starting_chips"`
+	HoleCards     []poker.Card `json:"hole_cards"`
+	// Position is the player's table position, e.g. "BTN", "SB" or "UTG".
+	Position string `json:"position,omitempty"`
+	// Showdown is true if the player's hand was shown at showdown.
+	Showdown bool `json:"showdown"`
+	// ShownCards holds any hole cards the player chose to show after the hand.
+	ShownCards []poker.Card `json:"shown_cards,omitempty"`
+	// High and Low are the hands the player made on a complete board. They
+	// are only recorded for hands that reached the end without folding and
+	// were known at the table: shown at showdown, or the human's own.
+	High *poker.HandResult `json:"high,omitempty"`
+	Low  *poker.HandResult `json:"low,omitempty"`
+}
+
+// ActionRecord is a single betting action in a recorded hand.
+type ActionRecord struct {
+	Phase      GamePhase `json:"phase"`
+	PlayerName string    `json:"player_name"`
+	// Position is the acting player's table position, as in SeatRecord.
+	Position string     `json:"position,omitempty"`
+	Action   ActionType `json:"action"`
+	// Detail tells limps, completions and big blind options apart, as in
+	// ActionEvent.Detail.
+	Detail ActionDetail `json:"detail,omitempty"`
+	// Amount follows ActionEvent.Amount: the amount called, or the total a
+	// bet or raise was made to.
+	Amount int `json:"amount,omitempty"`
+}
+
+// VisibleCards returns the hole cards that other players at the table could
+// see: all of them for the human player or a hand shown at showdown, and
+// otherwise only the cards shown after the hand.
+func (s SeatRecord) VisibleCards() []poker.Card {
+	if s.IsHuman || s.Showdown {
+		return s.HoleCards
+	}
+	return s.ShownCards
+}
+
+// Anonymize returns a copy of the hand history that is safe to share. Player
+// names are replaced with "Seat1".."SeatN" in seating order, and hole cards
+// that were never revealed at the table are removed.
+func (h *HandHistory) Anonymize() *HandHistory {
+	names := make(map[string]string, len(h.Seats))
+	anon := *h
+	anon.Seats = make([]SeatRecord, len(h.Seats))
+	for i, seat := range h.Seats {
+		names[seat.Name] = fmt.Sprintf("Seat%d", i+1)
+		seat.Name = names[seat.Name]
+		seat.HoleCards = seat.VisibleCards()
+		seat.ShownCards = nil
+		anon.Seats[i] = seat
+	}
+
+	anon.Dealer = names[h.Dealer]
+	anon.SmallBlindPlayer = names[h.SmallBlindPlayer]
+	anon.BigBlindPlayer = names[h.BigBlindPlayer]
+	if h.AntePlayer != "" {
+		anon.AntePlayer = names[h.AntePlayer]
+	}
+	if h.StraddlePlayer != "" {
+		anon.StraddlePlayer = names[h.StraddlePlayer]
+	}
+
+	anon.Actions = make([]ActionRecord, len(h.Actions))
+	for i, action := range h.Actions {
+		action.PlayerName = names[action.PlayerName]
+		anon.Actions[i] = action
+	}
+	anon.Results = make([]DistributionResult, len(h.Results))
+	for i, result := range h.Results {
+		result.PlayerName = names[result.PlayerName]
+		anon.Results[i] = result
+	}
+	if h.Uncalled != nil {
+		anon.Uncalled = &UncalledBet{PlayerName: names[h.Uncalled.PlayerName], Amount: h.Uncalled.Amount}
+	}
+	return &anon
+}
+
+// startHandHistory begins recording the hand that has just been dealt.
+func (g *Game) startHandHistory(sbPos, bbPos int) {
+	playedAt := g.now()
+	h := &HandHistory{
+		ID:             fmt.Sprintf("%s-%04d", playedAt.Format(HandIDTimeFormat), g.HandCount),
+		HandNumber:     g.HandCount,
+		Rule:           g.Rules.Abbreviation,
+		Rules:          g.Rules,
+		PlayedAt:       playedAt,
+		SmallBlind:     g.SmallBlind,
+		BigBlind:       g.BigBlind,
+		Ante:           g.Ante,
+		BigBlindPlayer: g.Players[bbPos].Name,
+	}
+	if button := g.ButtonPlayer(); button != nil {
+		h.Dealer = button.Name
+	}
+	if sbPos >= 0 {
+		h.SmallBlindPlayer = g.Players[sbPos].Name
+	}
+	if g.Straddler != nil {
+		h.Straddle, h.StraddlePlayer = g.Straddler.CurrentBet, g.Straddler.Name
+	}
+	if g.AnteFormat != AnteEveryone {
+		h.Ante = 0
+		for _, p := range g.Players {
+			if p.DeadAnte > 0 {
+				h.Ante, h.AntePlayer = p.DeadAnte, p.Name
+			}
+		}
+	}
+	config := g.TableConfig()
+	h.Config = &config
+	positions := g.seatPositions(sbPos, bbPos)
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusEliminated {
+			continue
+		}
+		h.Seats = append(h.Seats, SeatRecord{
+			Name:          p.Name,
+			IsHuman:       !p.IsCPU,
+			StartingChips: p.Chips + p.TotalBetInHand + p.DeadAnte,
+			Position:      positions[p.Name],
+			HoleCards:     append([]poker.Card(nil), p.Hand...),
+		})
+	}
+	g.History = h
+}
+
+// recordAction appends a betting action to the current hand history.
+func (g *Game) recordAction(event *ActionEvent) {
+	if g.History == nil {
+		return
+	}
+	position := ""
+	for _, seat := range g.History.Seats {
+		if seat.Name == event.PlayerName {
+			position = seat.Position
+		}
+	}
+	g.History.Actions = append(g.History.Actions, ActionRecord{
+		Phase:      g.Phase,
+		PlayerName: event.PlayerName,
+		Position:   position,
+		Action:     event.Action,
+		Detail:     event.Detail,
+		Amount:     event.Amount,
+	})
+}
+
+// recordResults adds the pot distribution to the current hand history.
+func (g *Game) recordResults(results []DistributionResult) {
+	if g.History == nil {
+		return
+	}
+	g.History.Results = append(g.History.Results, results...)
+}
+
+// recordRuns adds the boards the pot was run out on to the current hand
+// history.
+func (g *Game) recordRuns() {
+	if g.History == nil || len(g.Runs) == 0 {
+		return
+	}
+	g.History.Runs = g.Runs
+}
+
+// finishHandHistory records the final board and which hands were shown.
+func (g *Game) finishHandHistory() {
+	if g.History == nil {
+		return
+	}
+	g.History.Board = append([]poker.Card(nil), g.CommunityCards...)
+	showdown := g.CountNonFoldedPlayers() > 1
+	for i := range g.History.Seats {
+		seat := &g.History.Seats[i]
+		for _, p := range g.Players {
+			if p.Name == seat.Name {
+				seat.Showdown = showdown && p.Status != PlayerStatusFolded && !p.Mucked
+				seat.ShownCards = p.ShownCards
+				if len(g.CommunityCards) == 5 && p.Status != PlayerStatusFolded && (seat.IsHuman || seat.Showdown) {
+					seat.High, seat.Low = g.evaluateMadeHand(p.Hand)
+				}
+			}
+		}
+	}
+
+	g.recordPreFlopLines()
+	g.recordStreetLines()
+	g.recordTableImages()
+	g.recordShownHands()
+	g.recordSessionStats()
+	g.History.Audit = g.buildChipAudit()
+	for _, problem := range g.History.Audit.Discrepancies() {
+		logrus.Warnf("Chip audit for hand %s: %s", g.History.ID, problem)
+	}
+}
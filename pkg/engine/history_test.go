@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestHandHistory_RecordsHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Players[1].IsCPU = true
+	g.Players[2].IsCPU = true
+	g.StartNewHand()
+
+	h := g.History
+	if h == nil {
+		t.Fatal("expected StartNewHand to start a hand history")
+	}
+	if len(h.Seats) != 3 || h.Seats[0].StartingChips != 10000 || len(h.Seats[0].HoleCards) != 3 {
+		t.Fatalf("unexpected seats: %+v", h.Seats)
+	}
+	if h.BigBlindPlayer != g.Players[(g.DealerPos+2)%3].Name {
+		t.Errorf("expected big blind player %s, got %s", g.Players[(g.DealerPos+2)%3].Name, h.BigBlindPlayer)
+	}
+
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	results := g.AwardPotToLastPlayer()
+	g.CleanupHand()
+
+	if len(h.Actions) != 2 || h.Actions[0].Action != ActionFold || h.Actions[0].Phase != PhasePreFlop {
+		t.Errorf("unexpected actions: %+v", h.Actions)
+	}
+	if len(h.Results) != 1 || h.Results[0] != results[0] {
+		t.Errorf("expected results %+v, got %+v", results, h.Results)
+	}
+	for _, seat := range h.Seats {
+		if seat.Showdown {
+			t.Errorf("expected no showdown in an uncontested hand, but %s showed down", seat.Name)
+		}
+	}
+}
+
+func TestHandHistory_Anonymize(t *testing.T) {
+	h := &HandHistory{
+		Dealer:           "CPU 2",
+		SmallBlindPlayer: "YOU",
+		BigBlindPlayer:   "CPU 1",
+		Seats: []SeatRecord{
+			{Name: "YOU", IsHuman: true, HoleCards: poker.CardsFromStrings("As Kd 7c")},
+			{Name: "CPU 1", HoleCards: poker.CardsFromStrings("2h 3h 4h"), Showdown: true},
+			{Name: "CPU 2", HoleCards: poker.CardsFromStrings("9s 9d Tc"), ShownCards: poker.CardsFromStrings("9s")},
+		},
+		Actions: []ActionRecord{{PlayerName: "CPU 2", Action: ActionFold}},
+		Results: []DistributionResult{{PlayerName: "CPU 1", AmountWon: 300}},
+	}
+
+	anon := h.Anonymize()
+
+	if anon.Dealer != "Seat3" || anon.SmallBlindPlayer != "Seat1" || anon.BigBlindPlayer != "Seat2" {
+		t.Errorf("unexpected positions: %s/%s/%s", anon.Dealer, anon.SmallBlindPlayer, anon.BigBlindPlayer)
+	}
+	if anon.Actions[0].PlayerName != "Seat3" || anon.Results[0].PlayerName != "Seat2" {
+		t.Errorf("expected names to be replaced, got %+v and %+v", anon.Actions, anon.Results)
+	}
+	if len(anon.Seats[0].HoleCards) != 3 || len(anon.Seats[1].HoleCards) != 3 {
+		t.Error("expected the hero's and showdown hole cards to be kept")
+	}
+	if len(anon.Seats[2].HoleCards) != 1 || anon.Seats[2].HoleCards[0] != h.Seats[2].ShownCards[0] {
+		t.Errorf("expected only the shown card for Seat3, got %v", anon.Seats[2].HoleCards)
+	}
+	if h.Seats[0].Name != "YOU" || h.Actions[0].PlayerName != "CPU 2" {
+		t.Error("expected the original history to be left unchanged")
+	}
+}
+
+func TestHandHistory_RecordsMadeHands(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.CommunityCards = poker.CardsFromStrings("2c 3h 7s Kd Kc")
+	g.Players[1].Status = PlayerStatusFolded
+	g.finishHandHistory()
+
+	seats := g.History.Seats
+	if seats[0].High == nil || seats[2].High == nil {
+		t.Errorf("expected hands to be recorded for the players at showdown, got %+v and %+v", seats[0].High, seats[2].High)
+	}
+	if seats[1].High != nil || seats[1].Low != nil {
+		t.Errorf("expected no hand for the folded player, got %+v", seats[1].High)
+	}
+
+	// When a CPU wins uncontested, its hand stays hidden and is not recorded.
+	g.StartNewHand()
+	g.CommunityCards = poker.CardsFromStrings("2c 3h 7s Kd Kc")
+	g.Players[0].Status = PlayerStatusFolded
+	g.Players[1].Status = PlayerStatusFolded
+	g.finishHandHistory()
+	if high := g.History.Seats[2].High; high != nil {
+		t.Errorf("expected no hand for an uncontested CPU winner, got %+v", high)
+	}
+}
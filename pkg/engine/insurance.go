@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// InsuranceOffer is insurance offered to the favorite of an all-in pot, as in
+// live cash games. The player pays a premium now, and if they do not win their
+// whole stake at the showdown, the insurance pool pays back the covered part of
+// what they lost.
+type InsuranceOffer struct {
+	PlayerName string
+	// Stake is the chips the player wins by scooping every pot tier they are
+	// eligible for.
+	Stake int
+	// Equity is the player's expected share of the stake over every runout.
+	Equity float64
+}
+
+// Premium returns the price of insuring the given fraction of the stake. It
+// is the covered part of the stake the player expects to lose, so insurance
+// is a fair bet: it locks in the player's equity instead of changing it.
+func (o *InsuranceOffer) Premium(coverage float64) int {
+	return int(math.Round(coverage * float64(o.Stake) * (1 - o.Equity)))
+}
+
+// InsurancePolicy is insurance taken in a hand, and how it was settled.
+type InsurancePolicy struct {
+	PlayerName string    `json:"player_name"`
+	Phase      GamePhase `json:"phase"`
+	Stake      int       `json:"stake"`
+	Equity     float64   `json:"equity"`
+	// Coverage is the fraction of the stake insured, between 0 and 1.
+	Coverage float64 `json:"coverage"`
+	Premium  int     `json:"premium"`
+	// Payout is what the insurance pool paid back at the showdown: the covered
+	// part of the stake the player did not win. It is set once Settled.
+	Payout  int  `json:"payout"`
+	Settled bool `json:"settled"`
+}
+
+// Net returns the player's result from the insurance: the payout less the
+// premium.
+func (p *InsurancePolicy) Net() int {
+	return p.Payout - p.Premium
+}
+
+// InsuranceOffer offers insurance to the favorite of an all-in pot. It is
+// offered once no more betting is possible, with two or more players still in
+// the hand and cards to come, to the player with the best equity in the main
+// pot, unless they are sure to win. The price comes from exact equities, so
+// there is no offer while the runouts are too many to enumerate, typically
+// before the flop. It returns nil when there is no offer, including once
+// insurance has been taken in the hand. Pricing stops with ctx's error if ctx
+// is canceled first.
+func (g *Game) InsuranceOffer(ctx context.Context) (*InsuranceOffer, error) {
+	if g.Insurance != nil || g.CountNonFoldedPlayers() < 2 || g.CountPlayersAbleToAct() > 1 || len(g.CommunityCards) >= 5 {
+		return nil, nil
+	}
+	equity, err := g.CalculateAllInEquity(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !equity.Exact || len(equity.Tiers) == 0 {
+		return nil, nil
+	}
+
+	// The favorite alone has the best equity in the main pot.
+	main := equity.Tiers[0]
+	favorite := -1
+	for i, e := range main.Equity {
+		if favorite < 0 || e > main.Equity[favorite] {
+			favorite = i
+		} else if e == main.Equity[favorite] {
+			favorite = -2
+		}
+	}
+	if favorite < 0 {
+		return nil, nil
+	}
+
+	offer := &InsuranceOffer{PlayerName: main.Players[favorite]}
+	expected := 0.0
+	for _, tier := range equity.Tiers {
+		for i, name := range tier.Players {
+			if name == offer.PlayerName {
+				offer.Stake += tier.Amount
+				expected += float64(tier.Amount) * tier.Equity[i]
+			}
+		}
+	}
+	offer.Equity = expected / float64(offer.Stake)
+	if offer.Premium(1) == 0 {
+		return nil, nil
+	}
+	return offer, nil
+}
+
+// TakeInsurance buys insurance on the given fraction of the offer's stake.
+// The premium is paid from the player's winnings when the insurance is
+// settled with the pot, so a player who is all-in can insure too.
+func (g *Game) TakeInsurance(offer *InsuranceOffer, coverage float64) (*InsurancePolicy, error) {
+	if g.Insurance != nil {
+		return nil, errors.New("insurance has already been taken in this hand")
+	}
+	if coverage <= 0 || coverage > 1 {
+		return nil, fmt.Errorf("coverage must be more than 0 and at most 1, got %g", coverage)
+	}
+	if g.playerNamed(offer.PlayerName) == nil {
+		return nil, fmt.Errorf("unknown player %q", offer.PlayerName)
+	}
+	g.logEvent(GameEvent{Type: EventInsuranceTaken, Player: offer.PlayerName, Coverage: coverage})
+	g.Insurance = &InsurancePolicy{
+		PlayerName: offer.PlayerName,
+		Phase:      g.Phase,
+		Stake:      offer.Stake,
+		Equity:     offer.Equity,
+		Coverage:   coverage,
+		Premium:    offer.Premium(coverage),
+	}
+	if g.History != nil {
+		g.History.Insurance = g.Insurance
+	}
+	return g.Insurance, nil
+}
+
+// CPUInsuranceCoverage returns the fraction of its stake a CPU insures when
+// offered insurance, or 0 if it declines. Passive CPUs, who would rather call
+// than raise, are risk averse and insure all of it; aggressive ones gamble.
+func (g *Game) CPUInsuranceCoverage(offer *InsuranceOffer) float64 {
+	if player := g.playerNamed(offer.PlayerName); player != nil && player.Profile != nil && player.Profile.AggressionFactor < 0.5 {
+		return 1
+	}
+	return 0
+}
+
+// settleInsurance settles the hand's insurance once the pot has been
+// distributed, given what each player won: the premium is paid into the
+// insurance pool, and the payout, if any, comes out of it.
+func (g *Game) settleInsurance(won map[string]int) {
+	policy := g.Insurance
+	if policy == nil || policy.Settled {
+		return
+	}
+	lost := max(policy.Stake-won[policy.PlayerName], 0)
+	policy.Payout = int(math.Round(policy.Coverage * float64(lost)))
+	policy.Settled = true
+	g.playerNamed(policy.PlayerName).Chips += policy.Net()
+	g.InsurancePool -= policy.Net()
+}
@@ -0,0 +1,191 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// setUpInsuredTurn puts YOU's aces all-in on the turn against CPU 1's kings,
+// which have two outs.
+func setUpInsuredTurn(t *testing.T) *Game {
+	t.Helper()
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, 5000, 50, 100, "NLH")
+	g.Rules.HandRankings = poker.HandRankingsRules{UseStandardRankings: true}
+	hands := []string{"As Ah", "Ks Kh"}
+	for i, p := range g.Players {
+		p.Hand = poker.CardsFromStrings(hands[i])
+		p.TotalBetInHand = 5000
+		p.Chips = 0
+		p.Status = PlayerStatusAllIn
+		g.Pot += 5000
+	}
+	g.Phase = PhaseTurn
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h 3s")
+	return g
+}
+
+func TestInsuranceOffer_Pricing(t *testing.T) {
+	g := setUpInsuredTurn(t)
+	offer, err := g.InsuranceOffer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if offer == nil {
+		t.Fatal("expected an offer to the favorite")
+	}
+	if offer.PlayerName != "YOU" || offer.Stake != 10000 || math.Abs(offer.Equity-42.0/44) > 1e-9 {
+		t.Errorf("expected YOU to be offered 10,000 chips at 42/44 equity, got %+v", offer)
+	}
+	// Two outs in 44 of 10,000 chips.
+	if premium := offer.Premium(1); premium != 455 {
+		t.Errorf("expected a premium of 455 for full coverage, got %d", premium)
+	}
+	if premium := offer.Premium(0.5); premium != 227 {
+		t.Errorf("expected a premium of 227 for half coverage, got %d", premium)
+	}
+
+	if _, err := g.TakeInsurance(offer, 1.5); err == nil {
+		t.Error("expected an error insuring more than the stake")
+	}
+	if _, err := g.TakeInsurance(offer, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if offer, _ := g.InsuranceOffer(context.Background()); offer != nil {
+		t.Errorf("expected no second offer in the hand, got %+v", offer)
+	}
+}
+
+func TestInsuranceOffer_NoOffer(t *testing.T) {
+	// The river is dealt, so there is nothing left to insure against.
+	g := setUpInsuredTurn(t)
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h 3s 4d")
+	if offer, err := g.InsuranceOffer(context.Background()); err != nil || offer != nil {
+		t.Errorf("expected no offer on the river, got %+v, %v", offer, err)
+	}
+
+	// Neither player is the favorite of a chopped pot.
+	g = setUpInsuredTurn(t)
+	g.Players[1].Hand = poker.CardsFromStrings("Ad Ac")
+	if offer, err := g.InsuranceOffer(context.Background()); err != nil || offer != nil {
+		t.Errorf("expected no offer with equal equities, got %+v, %v", offer, err)
+	}
+}
+
+func TestSettleInsurance(t *testing.T) {
+	tests := []struct {
+		name         string
+		river        string
+		wantPayout   int
+		wantYOUChips int
+	}{
+		{"favorite wins", "4d", 0, 10000 - 455},
+		{"favorite is outdrawn", "Kd", 10000, 10000 - 455},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := setUpInsuredTurn(t)
+			offer, err := g.InsuranceOffer(context.Background())
+			if err != nil || offer == nil {
+				t.Fatalf("expected an offer, got %+v, %v", offer, err)
+			}
+			if _, err := g.TakeInsurance(offer, 1); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			g.CommunityCards = append(g.CommunityCards, poker.CardsFromStrings(tt.river)...)
+			g.DistributePot()
+
+			policy := g.Insurance
+			if !policy.Settled || policy.Payout != tt.wantPayout {
+				t.Errorf("expected a settled payout of %d, got %+v", tt.wantPayout, policy)
+			}
+			if chips := g.Players[0].Chips; chips != tt.wantYOUChips {
+				t.Errorf("expected YOU to end with %d chips, got %d", tt.wantYOUChips, chips)
+			}
+			if g.InsurancePool != -policy.Net() {
+				t.Errorf("expected the insurance pool to hold %d, got %d", -policy.Net(), g.InsurancePool)
+			}
+		})
+	}
+}
+
+// playInsuredHand plays a hand in which both players go all-in before the
+// flop and the favorite insures their whole stake on the flop. It reports
+// whether insurance was taken.
+func playInsuredHand(t *testing.T, g *Game) bool {
+	t.Helper()
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for !g.IsBettingRoundOver() {
+		player := g.CurrentPlayer()
+		if player.Status != PlayerStatusPlaying {
+			g.AdvanceTurn()
+			continue
+		}
+		action := PlayerAction{Type: ActionCall}
+		if g.Players[0] == player {
+			action = PlayerAction{Type: ActionRaise, Amount: player.Chips + player.CurrentBet}
+		}
+		g.ProcessAction(player, action)
+		g.AdvanceTurn()
+	}
+	g.Advance()
+
+	taken := false
+	offer, err := g.InsuranceOffer(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if offer != nil {
+		if _, err := g.TakeInsurance(offer, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		taken = true
+	}
+	for g.Phase != PhaseShowdown {
+		g.Advance()
+	}
+	g.DistributePot()
+	g.CleanupHand()
+	return taken
+}
+
+func TestInsurance_AuditAndReplay(t *testing.T) {
+	// Every hand is all-in, so each is played in a new game.
+	var g *Game
+	insured := false
+	for seed := int64(1); seed <= 20 && !insured; seed++ {
+		g = newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, 5000, 50, 100, "NLH")
+		g.Rand = rand.New(rand.NewSource(seed))
+		insured = playInsuredHand(t, g)
+	}
+	if !insured {
+		t.Fatal("expected insurance to be taken in one of the hands")
+	}
+
+	if g.History.Insurance == nil || !g.History.Insurance.Settled {
+		t.Fatalf("expected the hand history to record the settled insurance, got %+v", g.History.Insurance)
+	}
+	audit := g.History.Audit
+	if problems := audit.Discrepancies(); len(problems) != 0 {
+		t.Errorf("expected a balanced audit, got %v", problems)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("expected the insurance pool to keep the chips conserved, got %v", g.ChipViolations)
+	}
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	if replayed.InsurancePool != g.InsurancePool {
+		t.Errorf("expected an insurance pool of %d, but got %d", g.InsurancePool, replayed.InsurancePool)
+	}
+	for i, p := range g.Players {
+		if replayed.Players[i].Chips != p.Chips {
+			t.Errorf("%s: expected %d chips, but got %d", p.Name, p.Chips, replayed.Players[i].Chips)
+		}
+	}
+}
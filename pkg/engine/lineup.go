@@ -0,0 +1,186 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"math/rand"
+	"strings"
+)
+
+// LineupChurn makes the lineup of a cash game change between hands, as at a
+// live table over a long session: CPUs get up and leave, and new ones sit
+// down in the empty seats.
+type LineupChurn struct {
+	// LeaveChance is the chance that each CPU leaves after a hand, from 0 to 1.
+	// A CPU that has busted always leaves.
+	LeaveChance float64
+	// JoinChance is the chance, from 0 to 1, that a new CPU sits down in an
+	// empty seat after a hand.
+	JoinChance float64
+	// BuyIn is the stack a new CPU sits down with.
+	BuyIn int
+}
+
+// LineupChange is a player joining or leaving the table between hands.
+type LineupChange struct {
+	PlayerName string
+	// Joined is set if the player sat down, and unset if they left.
+	Joined bool
+	// Chips is the stack the player sat down or left with.
+	Chips int
+	// Profile is the name of a joining CPU's profile.
+	Profile string
+}
+
+// JoinTable seats a new player in the last seat between hands, with a stack
+// of chips that comes into play. profile is nil for a player who is not a CPU.
+// Names must be unique over the session, so that the statistics kept by name
+// for a player who has left are never taken for a newcomer's.
+func (g *Game) JoinTable(name string, chips int, profile *AIProfile) (*Player, error) {
+	if g.handInProgress {
+		return nil, errors.New("players may only join between hands")
+	}
+	if len(g.Players) >= MaxTableSize {
+		return nil, fmt.Errorf("the table is full (%d seats)", MaxTableSize)
+	}
+	if g.nameTaken(name) {
+		return nil, fmt.Errorf("the name %q is already taken this session", name)
+	}
+	if chips <= 0 {
+		return nil, fmt.Errorf("a player must sit down with chips, got %d", chips)
+	}
+	g.logEvent(GameEvent{Type: EventPlayerJoined, Player: name, Amount: chips, Profile: profile})
+	p := &Player{Name: name, Chips: chips, IsCPU: profile != nil}
+	if profile != nil {
+		copied := *profile
+		p.Profile = &copied
+	}
+	g.seat(p)
+	return p, nil
+}
+
+// LeaveTable removes a player from the table between hands. Their chips leave
+// the game with them. Their statistics for the session are kept, under a name
+// no newcomer may take.
+func (g *Game) LeaveTable(p *Player) error {
+	if g.handInProgress {
+		return errors.New("players may only leave between hands")
+	}
+	if g.playerNamed(p.Name) != p {
+		return fmt.Errorf("%s is not seated at the table", p.Name)
+	}
+	g.logEvent(GameEvent{Type: EventPlayerLeft, Player: p.Name, Amount: p.Chips})
+	g.unseat(p)
+	g.departed = append(g.departed, p.Name)
+	return nil
+}
+
+// ChangeLineup lets the CPUs leave, and new CPUs join, after a hand as the
+// game's LineupChurn has it, drawing from r. Busted CPUs always leave, but
+// others stay when leaving would leave the human without an opponent. New
+// CPUs take random profiles from the lineup of the game's difficulty, and
+// names no one has had this session. It returns the changes in the order
+// they were made, and none without a LineupChurn.
+func (g *Game) ChangeLineup(r *rand.Rand) []LineupChange {
+	churn := g.LineupChurn
+	if churn == nil || g.handInProgress {
+		return nil
+	}
+	var changes []LineupChange
+	for _, p := range append([]*Player(nil), g.Players...) {
+		if !p.IsCPU {
+			continue
+		}
+		busted := p.Status == PlayerStatusEliminated
+		if !busted && (g.CountRemainingPlayers() <= 2 || r.Float64() >= churn.LeaveChance) {
+			continue
+		}
+		if err := g.LeaveTable(p); err != nil {
+			continue
+		}
+		changes = append(changes, LineupChange{PlayerName: p.Name, Chips: p.Chips})
+	}
+
+	lineup := g.joinerLineup()
+	for len(g.Players) < MaxTableSize {
+		// Keep two players at the table, so that the game can go on.
+		if g.CountRemainingPlayers() >= 2 && r.Float64() >= churn.JoinChance {
+			break
+		}
+		profile := g.profiles.Profile(lineup[r.Intn(len(lineup))])
+		p, err := g.JoinTable(g.newCPUName(), churn.BuyIn, profile)
+		if err != nil {
+			break
+		}
+		changes = append(changes, LineupChange{PlayerName: p.Name, Joined: true, Chips: p.Chips, Profile: profile.Name})
+	}
+	return changes
+}
+
+// RecycleLineup replaces every CPU at the table after a hand, as a fast-fold
+// pool does: the next hand is dealt against a new lineup of as many CPUs,
+// each sitting down with buyIn chips and a random profile from the lineup of
+// the game's difficulty. The CPUs who leave take their chips with them. It
+// returns the changes in the order they were made.
+func (g *Game) RecycleLineup(r *rand.Rand, buyIn int) []LineupChange {
+	if g.handInProgress {
+		return nil
+	}
+	var changes []LineupChange
+	seats := 0
+	for _, p := range append([]*Player(nil), g.Players...) {
+		if !p.IsCPU {
+			continue
+		}
+		if err := g.LeaveTable(p); err != nil {
+			continue
+		}
+		seats++
+		changes = append(changes, LineupChange{PlayerName: p.Name, Chips: p.Chips})
+	}
+
+	lineup := g.joinerLineup()
+	for i := 0; i < seats; i++ {
+		profile := g.profiles.Profile(lineup[r.Intn(len(lineup))])
+		p, err := g.JoinTable(g.newCPUName(), buyIn, profile)
+		if err != nil {
+			break
+		}
+		changes = append(changes, LineupChange{PlayerName: p.Name, Joined: true, Chips: p.Chips, Profile: profile.Name})
+	}
+	return changes
+}
+
+// joinerLineup returns the names of the profiles new CPUs may be given: the
+// lineup of the game's difficulty.
+func (g *Game) joinerLineup() []string {
+	if g.profiles == nil {
+		g.profiles = config.DefaultAIProfiles()
+	}
+	return g.profiles.Lineup(strings.ToLower(g.Difficulty.String()), MaxTableSize)
+}
+
+// newCPUName returns the name for a new CPU, "CPU n", with the lowest number
+// no player seated or departed this session has.
+func (g *Game) newCPUName() string {
+	for n := 1; ; n++ {
+		if name := fmt.Sprintf("CPU %d", n); !g.nameTaken(name) {
+			return name
+		}
+	}
+}
+
+// nameTaken reports whether a player of the given name is seated, or has
+// left the table this session.
+func (g *Game) nameTaken(name string) bool {
+	if g.playerNamed(name) != nil {
+		return true
+	}
+	for _, departed := range g.departed {
+		if departed == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestJoinAndLeaveTable(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100)
+	playFoldedHand(g)
+	g.playerStats("CPU 2").Streets.Hands = 40
+
+	leaving := g.Players[2]
+	if err := g.LeaveTable(leaving); err != nil {
+		t.Fatalf("Failed to leave the table: %v", err)
+	}
+	if len(g.Players) != 3 || g.Players[2].Name != "CPU 3" || g.Players[2].Position != 2 {
+		t.Errorf("Expected CPU 3 to move up to seat 2, but got %v", g.Players)
+	}
+	if g.TotalInitialChips != 30000-leaving.Chips+10000 {
+		t.Errorf("Expected the leaving stack to be taken out of play, but %d chips are expected", g.TotalInitialChips)
+	}
+	if _, err := g.JoinTable("CPU 2", 10000, g.Players[1].Profile); err == nil {
+		t.Error("Expected a newcomer not to take the name of a player who left")
+	}
+
+	profile := builtInProfile("Tight-Aggressive")
+	joined, err := g.JoinTable(g.newCPUName(), 5000, &profile)
+	if err != nil {
+		t.Fatalf("Failed to join the table: %v", err)
+	}
+	if joined.Name != "CPU 4" || joined.Position != 3 || !joined.IsCPU {
+		t.Errorf("Expected CPU 4 to sit down as a CPU in seat 3, but got %+v", joined)
+	}
+	if g.PlayerStats["CPU 4"] != nil {
+		t.Error("Expected the newcomer to start without statistics")
+	}
+	if g.PlayerStats["CPU 2"] == nil {
+		t.Error("Expected the statistics of the player who left to be kept")
+	}
+
+	for i := 0; i < 3; i++ {
+		playFoldedHand(g)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected lineup changes to pass the chip audits, but got %v", g.ChipViolations)
+	}
+
+	g.StartNewHand()
+	if _, err := g.JoinTable("CPU 5", 5000, &profile); err == nil {
+		t.Error("Expected no player to join during a hand")
+	}
+	if err := g.LeaveTable(joined); err == nil {
+		t.Error("Expected no player to leave during a hand")
+	}
+}
+
+func TestChangeLineup(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100)
+	g.LineupChurn = &LineupChurn{LeaveChance: 1, BuyIn: 8000}
+	playFoldedHand(g)
+
+	changes := g.ChangeLineup(rand.New(rand.NewSource(1)))
+
+	// Every CPU leaves but the last one, which the human needs as an opponent.
+	if len(changes) != 2 || changes[0].PlayerName != "CPU 1" || changes[1].PlayerName != "CPU 2" {
+		t.Fatalf("Expected CPU 1 and CPU 2 to leave, but got %+v", changes)
+	}
+	if len(g.Players) != 2 {
+		t.Errorf("Expected two players left at the table, but got %d", len(g.Players))
+	}
+
+	g.LineupChurn = &LineupChurn{JoinChance: 1, BuyIn: 8000}
+	g.Players[1].Chips = 0
+	g.Players[1].Status = PlayerStatusEliminated
+	g.TotalInitialChips -= 10000
+	g.recordStacksAfterHand()
+
+	changes = g.ChangeLineup(rand.New(rand.NewSource(1)))
+	if len(changes) != 1+MaxTableSize-1 || changes[0].Joined {
+		t.Fatalf("Expected the busted CPU to leave and the empty seats to fill, but got %+v", changes)
+	}
+	for _, c := range changes[1:] {
+		if !c.Joined || c.Chips != 8000 || c.Profile == "" {
+			t.Errorf("Expected a CPU to sit down with 8000 chips and a profile, but got %+v", c)
+		}
+	}
+	if len(g.Players) != MaxTableSize {
+		t.Errorf("Expected a full table, but got %d players", len(g.Players))
+	}
+	playFoldedHand(g)
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected lineup changes to pass the chip audits, but got %v", g.ChipViolations)
+	}
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	// The bust above was made by hand, so only the seats are compared.
+	if len(replayed.Players) != len(g.Players) {
+		t.Fatalf("Expected %d players in the replay, but got %d", len(g.Players), len(replayed.Players))
+	}
+	for i, p := range g.Players {
+		if r := replayed.Players[i]; r.Name != p.Name || r.Chips != p.Chips {
+			t.Errorf("Seat %d: expected %s with %d chips, but got %s with %d", i, p.Name, p.Chips, r.Name, r.Chips)
+		}
+	}
+}
+
+func TestRecycleLineup(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100)
+	playFoldedHand(g)
+	g.TotalInitialChips -= g.Players[2].Chips
+	g.Players[2].Chips = 0
+	g.Players[2].Status = PlayerStatusEliminated
+	g.recordStacksAfterHand()
+
+	changes := g.RecycleLineup(rand.New(rand.NewSource(1)), 8000)
+
+	if len(changes) != 6 {
+		t.Fatalf("Expected three CPUs to leave and three to join, but got %+v", changes)
+	}
+	for i, c := range changes {
+		if joins := i >= 3; c.Joined != joins {
+			t.Errorf("Change %d: expected joined to be %v, but got %+v", i, joins, c)
+		}
+	}
+	if len(g.Players) != 4 || g.Players[0].Name != "YOU" {
+		t.Fatalf("Expected YOU and three new CPUs at the table, but got %v", g.Players)
+	}
+	for _, p := range g.Players[1:] {
+		if p.Name == "CPU 1" || p.Name == "CPU 2" || p.Name == "CPU 3" || p.Chips != 8000 || p.Profile == nil {
+			t.Errorf("Expected a new CPU with 8000 chips and a profile, but got %+v", p)
+		}
+	}
+	playFoldedHand(g)
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected the new lineup to pass the chip audits, but got %v", g.ChipViolations)
+	}
+	if _, err := Replay(g.Events); err != nil {
+		t.Errorf("Failed to replay the event log: %v", err)
+	}
+}
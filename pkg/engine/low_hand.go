@@ -0,0 +1,141 @@
+package engine
+
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
+// lowPotential sums up a player's prospects for the low half of the pot after
+// the flop, in a High-Low split game.
+type lowPotential struct {
+	// made is set if the player has a qualifying low now.
+	made bool
+	// draw is set if the player has no low yet but may make one with the
+	// cards to come.
+	draw bool
+	// nut is set if the player holds the two lowest ranks missing from the
+	// board, which the best possible low takes from the hole: their low, made
+	// or drawn, is the nut low.
+	nut bool
+	// counterfeitable is set if cards are to come and the player holds no low
+	// rank beyond the two their low needs, so a board card pairing either one
+	// would spoil it.
+	counterfeitable bool
+}
+
+// Weights of a player's low potential in their post-flop hand strength, which
+// is measured in hand ranks. A made low plays like one pair (or the tuning
+// pack's MadeLowBonus); the nut low like a little more, and a draw to it like
+// half a pair. A low that can be counterfeited is worth a little less.
+const (
+	defaultMadeLowBonus   = 1
+	nutLowBonus           = 0.5
+	nutLowDrawBonus       = 0.5
+	counterfeitLowPenalty = 0.25
+)
+
+// minMultiwayLowOpponents is the number of opponents from which a pot is
+// multiway, and low draws that are not to the nut low are given up.
+const minMultiwayLowOpponents = 2
+
+// readLowPotential works out the player's low potential on the current board.
+// low is the player's made low, if any. It is the zero value in games without
+// a low, before the flop, and once no low can be made on the board.
+func (g *Game) readLowPotential(player *Player, low *poker.HandResult) lowPotential {
+	board := g.CommunityCards
+	if !g.Rules.LowHand.Enabled || len(board) == 0 || !poker.LowPossible(board, g.Rules) {
+		return lowPotential{}
+	}
+	maxRank := poker.Rank(g.Rules.LowHand.MaxRank)
+	isLow := func(r poker.Rank) bool { return r == poker.Ace || r <= maxRank }
+
+	onBoard := make(map[poker.Rank]bool)
+	for _, c := range board {
+		onBoard[c.Rank] = true
+	}
+	// The low ranks in the hole that the board does not already hold.
+	live := make(map[poker.Rank]bool)
+	for _, c := range player.Hand {
+		if isLow(c.Rank) && !onBoard[c.Rank] {
+			live[c.Rank] = true
+		}
+	}
+
+	// The two lowest low ranks missing from the board, the ace first.
+	var nutRanks []poker.Rank
+	for _, r := range append([]poker.Rank{poker.Ace}, lowRanksUpTo(maxRank)...) {
+		if len(nutRanks) < 2 && !onBoard[r] {
+			nutRanks = append(nutRanks, r)
+		}
+	}
+
+	cardsToCome := len(board) < 5
+	p := lowPotential{
+		made: low != nil,
+		draw: low == nil && cardsToCome && len(live) >= 2,
+		nut:  len(nutRanks) == 2 && live[nutRanks[0]] && live[nutRanks[1]],
+	}
+	p.nut = p.nut && (p.made || p.draw)
+	p.counterfeitable = (p.made || p.draw) && cardsToCome && len(live) == 2
+	return p
+}
+
+// lowRanksUpTo returns the ranks from Two to maxRank, in order.
+func lowRanksUpTo(maxRank poker.Rank) []poker.Rank {
+	var ranks []poker.Rank
+	for r := poker.Two; r <= maxRank; r++ {
+		ranks = append(ranks, r)
+	}
+	return ranks
+}
+
+// lowStrengthBonus returns what the player's low potential adds to their hand
+// strength: a made low, more for the nut low, a draw to the nut low, less for
+// a low that may be counterfeited.
+func (g *Game) lowStrengthBonus(p lowPotential) float64 {
+	var bonus float64
+	switch {
+	case p.made:
+		bonus = defaultMadeLowBonus
+		if tuning := g.aiTuning(); tuning != nil {
+			bonus = tuning.LowHand.MadeLowBonus
+		}
+		if p.nut {
+			bonus += nutLowBonus
+		}
+	case p.draw && p.nut:
+		bonus = nutLowDrawBonus
+	}
+	if bonus > 0 && p.counterfeitable {
+		bonus -= counterfeitLowPenalty
+	}
+	return bonus
+}
+
+// chasesWeakLowDraw reports whether a CPU facing a bet would be calling only
+// for a low it does not have yet and that is not the nut low, with a weak high
+// hand, against two or more opponents. Multiway, such a low is often beaten or
+// quartered, so it is not worth chasing.
+func (g *Game) chasesWeakLowDraw(player *Player, strength float64) bool {
+	if !g.Rules.LowHand.Enabled || g.Phase == PhasePreFlop || strength >= float64(poker.OnePair) {
+		return false
+	}
+	if g.CountNonFoldedPlayers()-1 < minMultiwayLowOpponents {
+		return false
+	}
+	_, low := g.evaluateMadeHand(player.Hand)
+	p := g.readLowPotential(player, low)
+	return p.draw && !p.nut
+}
+
+// continuesTwoWay reports whether a CPU holds a two-way hand worth continuing
+// with against a bet: at least one pair for the high, and a low made or drawn
+// to the nut low. Such a hand is rarely shut out of both halves of the pot.
+func (g *Game) continuesTwoWay(player *Player) bool {
+	if !g.Rules.LowHand.Enabled || g.Phase == PhasePreFlop {
+		return false
+	}
+	high, low := g.evaluateMadeHand(player.Hand)
+	if high == nil || high.Rank < poker.OnePair {
+		return false
+	}
+	p := g.readLowPotential(player, low)
+	return p.made || (p.draw && p.nut)
+}
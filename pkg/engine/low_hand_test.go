@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestReadLowPotential(t *testing.T) {
+	testCases := []struct {
+		name     string
+		hole     string
+		board    string
+		expected lowPotential
+	}{
+		{name: "Nut low draw", hole: "As 2s Kd Kh", board: "3c 7h Qc", expected: lowPotential{draw: true, nut: true, counterfeitable: true}},
+		{name: "Nut low draw with a backup", hole: "As 2s 4d Kh", board: "3c 7h Qc", expected: lowPotential{draw: true, nut: true}},
+		{name: "Weak low draw", hole: "6s 5s Kd Kh", board: "3c 7h Qc", expected: lowPotential{draw: true, counterfeitable: true}},
+		{name: "Made low on the turn", hole: "As 2s Kd Kh", board: "3c 7h 5c Qd", expected: lowPotential{made: true, nut: true, counterfeitable: true}},
+		{name: "Made low on the river", hole: "2s 5s 9d Kd", board: "3c 4h 8c Jd Qh", expected: lowPotential{made: true}},
+		{name: "Counterfeited", hole: "As 3s Kd Kh", board: "2c 7h 3c Qd", expected: lowPotential{}},
+		{name: "No low possible", hole: "As 2s Kd Kh", board: "Tc Jh Qc", expected: lowPotential{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &Game{Phase: PhaseFlop, CommunityCards: poker.CardsFromStrings(tc.board), Rules: plo8Tuning()}
+			player := &Player{Hand: poker.CardsFromStrings(tc.hole)}
+			_, low := g.evaluateMadeHand(player.Hand)
+			if got := g.readLowPotential(player, low); got != tc.expected {
+				t.Errorf("Expected %+v, but got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCPULowDecisions(t *testing.T) {
+	newPot := func(hole, board string, players int) (*Game, *Player) {
+		g := &Game{
+			Phase: PhaseFlop, Difficulty: DifficultyMedium, Pot: 1000, BetToCall: 500,
+			CommunityCards: poker.CardsFromStrings(board), Rules: plo8Tuning(),
+		}
+		tag := builtInProfile("Tight-Aggressive")
+		for i := 0; i < players; i++ {
+			g.Players = append(g.Players, &Player{Profile: &tag, Chips: 10000, Status: PlayerStatusPlaying})
+		}
+		player := g.Players[0]
+		player.Hand = poker.CardsFromStrings(hole)
+		return g, player
+	}
+
+	// Multiway, a low draw that is not to the nut low is given up.
+	g, player := newPot("6s 5s Kd Jh", "3c 7h Qc", 3)
+	if !g.chasesWeakLowDraw(player, evaluateHandStrength(g, player)) {
+		t.Error("Expected a weak low draw to be given up multiway")
+	}
+	g, player = newPot("6s 5s Kd Jh", "3c 7h Qc", 2)
+	if g.chasesWeakLowDraw(player, evaluateHandStrength(g, player)) {
+		t.Error("Expected a weak low draw not to be given up heads-up")
+	}
+	g, player = newPot("As 2s Kd Jh", "3c 7h Qc", 3)
+	if g.chasesWeakLowDraw(player, evaluateHandStrength(g, player)) {
+		t.Error("Expected a nut low draw not to be given up multiway")
+	}
+
+	// A pair with a nut low draw plays for both halves.
+	g, player = newPot("As 2s Kd Qh", "3c 7h Qc", 3)
+	if !g.continuesTwoWay(player) {
+		t.Error("Expected a pair with a nut low draw to be a two-way hand")
+	}
+	g, player = newPot("9s 9d Kd Qh", "3c 7h Qc", 3)
+	if g.continuesTwoWay(player) {
+		t.Error("Expected a pair without a low to be a high-only hand")
+	}
+}
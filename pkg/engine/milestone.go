@@ -0,0 +1,134 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"time"
+)
+
+// MilestoneKind is a kind of rare hand worth celebrating.
+type MilestoneKind int
+
+const (
+	MilestoneRoyalFlush        MilestoneKind = iota // A royal flush made on a complete board.
+	MilestoneSkipStraightFlush                      // A skip straight flush made on a complete board.
+	MilestoneQuadsOverQuads                         // Four of a kind beating a lower four of a kind at showdown.
+)
+
+// String returns the name of the milestone, e.g. "Royal Flush".
+func (k MilestoneKind) String() string {
+	return []string{"Royal Flush", "Skip Straight Flush", "Quads over Quads"}[k]
+}
+
+// Milestone is a rare hand made by a player, kept as an achievement.
+type Milestone struct {
+	Kind       MilestoneKind `json:"kind"`
+	PlayerName string        `json:"player_name"`
+	IsHuman    bool          `json:"is_human"`
+	// HandID identifies the hand it was made in, and PlayedAt is when.
+	HandID   string    `json:"hand_id"`
+	PlayedAt time.Time `json:"played_at"`
+	// Rule is the abbreviation of the game variant.
+	Rule string `json:"rule"`
+	// Hand describes the hand that was made.
+	Hand string `json:"hand"`
+	// Frequency is the exact probability that a five-card hand dealt from a
+	// full deck makes the milestone's hand rank in the variant, or 0 if the
+	// hand's rules were not recorded.
+	Frequency float64 `json:"frequency"`
+	// First is true if it was the player's first milestone of its kind. It is
+	// only tracked for the human player.
+	First bool `json:"first"`
+}
+
+// milestoneRanks maps the hand ranks that are milestones on their own to
+// their kinds.
+var milestoneRanks = map[poker.HandRank]MilestoneKind{
+	poker.RoyalFlush:        MilestoneRoyalFlush,
+	poker.SkipStraightFlush: MilestoneSkipStraightFlush,
+}
+
+// FindMilestones returns the milestones made in a recorded hand, in seating
+// order. Only hands that were known at the table count: the human's own, and
+// those shown at showdown. Whether a milestone is a first is left to the
+// caller, who knows the player's earlier ones (see MarkFirstMilestones).
+func FindMilestones(h *HandHistory) []Milestone {
+	var milestones []Milestone
+	var frequencies map[poker.HandRank]int
+	add := func(kind MilestoneKind, seat SeatRecord) {
+		m := Milestone{
+			Kind:       kind,
+			PlayerName: seat.Name,
+			IsHuman:    seat.IsHuman,
+			HandID:     h.ID,
+			PlayedAt:   h.PlayedAt,
+			Rule:       h.Rule,
+			Hand:       seat.High.String(),
+		}
+		if h.Rules != nil {
+			if frequencies == nil {
+				frequencies = poker.FiveCardHandCounts(h.Rules)
+			}
+			m.Frequency = float64(frequencies[seat.High.Rank]) / poker.FiveCardHands
+		}
+		milestones = append(milestones, m)
+	}
+
+	for _, seat := range h.Seats {
+		if seat.High == nil {
+			continue
+		}
+		if kind, ok := milestoneRanks[seat.High.Rank]; ok {
+			add(kind, seat)
+		}
+		if seat.Showdown && wonPot(h, seat.Name) && beatsLowerQuads(seat, h.Seats) {
+			add(MilestoneQuadsOverQuads, seat)
+		}
+	}
+	return milestones
+}
+
+// beatsLowerQuads reports whether the seat's four of a kind beat another four
+// of a kind of a lower rank at showdown. Quads on the board are shared, so they
+// do not count.
+func beatsLowerQuads(seat SeatRecord, seats []SeatRecord) bool {
+	if seat.High.Rank != poker.FourOfAKind {
+		return false
+	}
+	beaten := false
+	for _, other := range seats {
+		if other.Name == seat.Name || !other.Showdown || other.High == nil || other.High.Rank != poker.FourOfAKind {
+			continue
+		}
+		switch {
+		case other.High.HighValues[0] > seat.High.HighValues[0]:
+			return false
+		case other.High.HighValues[0] < seat.High.HighValues[0]:
+			beaten = true
+		}
+	}
+	return beaten
+}
+
+// wonPot reports whether the player won chips in the recorded hand.
+func wonPot(h *HandHistory, name string) bool {
+	for _, r := range h.Results {
+		if r.PlayerName == name && r.AmountWon > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkFirstMilestones sets First on each new milestone of a kind the player
+// had not made before, judged by the player's earlier milestones. All the
+// milestones must be the same player's.
+func MarkFirstMilestones(milestones []Milestone, earlier []Milestone) {
+	seen := make(map[MilestoneKind]bool)
+	for _, m := range earlier {
+		seen[m.Kind] = true
+	}
+	for i := range milestones {
+		milestones[i].First = !seen[milestones[i].Kind]
+		seen[milestones[i].Kind] = true
+	}
+}
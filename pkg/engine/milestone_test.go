@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestFindMilestones(t *testing.T) {
+	rules := loadRule(t, "pls7.yml")
+	seat := func(name, hole, board string, showdown bool) SeatRecord {
+		holeCards := poker.CardsFromStrings(hole)
+		high, low := poker.EvaluateHand(holeCards, poker.CardsFromStrings(board), rules)
+		return SeatRecord{Name: name, IsHuman: name == "YOU", HoleCards: holeCards, Showdown: showdown, High: high, Low: low}
+	}
+
+	board := "9s 7s 5s Qh Qd"
+	h := &HandHistory{
+		ID: "20250101-120000-0001", Rule: "PLS7", Rules: rules, Board: poker.CardsFromStrings(board),
+		Seats: []SeatRecord{
+			seat("YOU", "Ks Js 2d", board, true), // K-J-9-7-5 of spades.
+			seat("CPU 1", "Qs Qc 3d", board, true),
+		},
+		Results: []DistributionResult{{PlayerName: "YOU", AmountWon: 4000}},
+	}
+	milestones := FindMilestones(h)
+	if len(milestones) != 1 {
+		t.Fatalf("Expected 1 milestone, but got %+v", milestones)
+	}
+	m := milestones[0]
+	if m.Kind != MilestoneSkipStraightFlush || m.PlayerName != "YOU" || !m.IsHuman || m.HandID != h.ID {
+		t.Errorf("Expected YOU's skip straight flush, but got %+v", m)
+	}
+	if want := poker.FiveCardFrequency(poker.SkipStraightFlush, rules); m.Frequency != want || want == 0 {
+		t.Errorf("Expected a frequency of %g, but got %g", want, m.Frequency)
+	}
+
+	// CPU 1's four nines beat YOU's four fours.
+	board = "9h 9d 4c 4s Qh"
+	h = &HandHistory{
+		ID: "20250101-120100-0002", Rule: "PLS7", Rules: rules, Board: poker.CardsFromStrings(board),
+		Seats: []SeatRecord{
+			seat("YOU", "4d 4h 2c", board, true),
+			seat("CPU 1", "9s 9c 3d", board, true),
+			// CPU 2 mucked, so its hand is not known.
+			{Name: "CPU 2", HoleCards: poker.CardsFromStrings("Qc Qd 2d")},
+		},
+		Results: []DistributionResult{{PlayerName: "CPU 1", AmountWon: 8000}},
+	}
+	milestones = FindMilestones(h)
+	if len(milestones) != 1 || milestones[0].Kind != MilestoneQuadsOverQuads || milestones[0].PlayerName != "CPU 1" {
+		t.Fatalf("Expected CPU 1's quads over quads, but got %+v", milestones)
+	}
+	if want := poker.FiveCardFrequency(poker.FourOfAKind, rules); milestones[0].Frequency != want {
+		t.Errorf("Expected the frequency of four of a kind (%g), but got %g", want, milestones[0].Frequency)
+	}
+
+	// Quads on the board are shared.
+	board = "9h 9d 9c 9s Qh"
+	h = &HandHistory{
+		Seats: []SeatRecord{
+			seat("YOU", "Ad 4h 2c", board, true),
+			seat("CPU 1", "Kd 8c 3d", board, true),
+		},
+		Results: []DistributionResult{{PlayerName: "YOU", AmountWon: 8000}},
+	}
+	if milestones := FindMilestones(h); len(milestones) != 0 {
+		t.Errorf("Expected no milestones for quads on the board, but got %+v", milestones)
+	}
+}
+
+func TestMarkFirstMilestones(t *testing.T) {
+	earlier := []Milestone{{Kind: MilestoneRoyalFlush}}
+	milestones := []Milestone{
+		{Kind: MilestoneRoyalFlush},
+		{Kind: MilestoneSkipStraightFlush},
+		{Kind: MilestoneSkipStraightFlush},
+	}
+	MarkFirstMilestones(milestones, earlier)
+	if milestones[0].First || !milestones[1].First || milestones[2].First {
+		t.Errorf("Expected only the first skip straight flush to be a first, but got %+v", milestones)
+	}
+}
@@ -0,0 +1,34 @@
+package engine
+
+// rememberPreviousWinners notes who won chips in the hand just played, before
+// the next one replaces its history.
+func (g *Game) rememberPreviousWinners() {
+	g.previousWinners = nil
+	if g.History == nil {
+		return
+	}
+	for _, result := range g.History.Results {
+		if result.AmountWon <= 0 {
+			continue
+		}
+		if g.previousWinners == nil {
+			g.previousWinners = make(map[string]bool)
+		}
+		g.previousWinners[result.PlayerName] = true
+	}
+}
+
+// mustBet reports whether the rules' must-bet rule binds the player to act,
+// who may then not check: on one of its streets, no one has bet yet, and the
+// player is the first to act on it or, by the "previous_winner" rule, a
+// winner of the previous hand.
+func (g *Game) mustBet(player *Player) bool {
+	rule := g.Rules.MustBet
+	if !rule.Applies(g.Phase.Street()) || g.BetToCall > 0 || player.Chips == 0 {
+		return false
+	}
+	if rule.Player == "previous_winner" {
+		return g.previousWinners[player.Name]
+	}
+	return g.ActionsTakenThisRound == 0
+}
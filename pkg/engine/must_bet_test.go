@@ -0,0 +1,80 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/validate"
+	"testing"
+)
+
+// newMustBetFlop deals a hand under the must-bet rule and moves it to the
+// start of the flop betting.
+func newMustBetFlop(rule *poker.MustBetRules) *Game {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Rules.MustBet = rule
+	g.StartNewHand()
+	g.Advance()
+	g.PrepareNewBettingRound()
+	return g
+}
+
+func TestMustBet_FirstToAct(t *testing.T) {
+	g := newMustBetFlop(&poker.MustBetRules{Streets: []string{"flop"}})
+	first := g.CurrentPlayer()
+	if err := g.ValidateAction(first, PlayerAction{Type: ActionCheck}); err == nil {
+		t.Errorf("Expected %s, first to act on the flop, not to be allowed to check", first.Name)
+	}
+	if moves := g.ActionSpot(first).Moves(); len(moves) != 2 || moves[0] != validate.Fold || moves[1] != validate.Bet {
+		t.Errorf("Expected %s to have to bet or fold, but got %v", first.Name, moves)
+	}
+	action := g.fitMove(first, PlayerAction{Type: ActionCheck})
+	if minBet, _ := g.CalculateBettingLimits(); action.Type != ActionBet || action.Amount != minBet {
+		t.Errorf("Expected a CPU's check to become the smallest bet, %d, but got %+v", minBet, action)
+	}
+
+	g.ProcessAction(first, action)
+	g.AdvanceTurn()
+	if next := g.CurrentPlayer(); g.ValidateAction(next, PlayerAction{Type: ActionCall}) != nil {
+		t.Errorf("Expected %s to be able to call the bet", next.Name)
+	}
+
+	// The rule does not reach the turn.
+	g = newMustBetFlop(&poker.MustBetRules{Streets: []string{"turn"}})
+	if err := g.ValidateAction(g.CurrentPlayer(), PlayerAction{Type: ActionCheck}); err != nil {
+		t.Errorf("Expected checking to be allowed on the flop, but got %v", err)
+	}
+}
+
+func TestMustBet_PreviousWinner(t *testing.T) {
+	g := newMustBetFlop(&poker.MustBetRules{Streets: []string{"flop", "turn", "river"}, Player: "previous_winner"})
+	// The last player to act on the flop won the previous hand, and may check
+	// only once someone has bet.
+	winner := g.Players[(g.CurrentTurnPos+2)%3]
+	g.previousWinners = map[string]bool{winner.Name: true}
+	for g.CurrentPlayer() != winner {
+		p := g.CurrentPlayer()
+		if err := g.ValidateAction(p, PlayerAction{Type: ActionCheck}); err != nil {
+			t.Fatalf("Expected %s, who did not win the previous hand, to be able to check, but got %v", p.Name, err)
+		}
+		g.ProcessAction(p, PlayerAction{Type: ActionCheck})
+		g.AdvanceTurn()
+	}
+	if err := g.ValidateAction(winner, PlayerAction{Type: ActionCheck}); err == nil {
+		t.Errorf("Expected %s, who won the previous hand, not to be allowed to check", winner.Name)
+	}
+}
+
+func TestRememberPreviousWinners(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	winner := g.AwardPotToLastPlayer()[0].PlayerName
+	g.CleanupHand()
+	g.StartNewHand()
+	if len(g.previousWinners) != 1 || !g.previousWinners[winner] {
+		t.Errorf("Expected %s to be remembered as the previous winner, but got %v", winner, g.previousWinners)
+	}
+}
@@ -0,0 +1,35 @@
+package engine
+
+import "sort"
+
+// splitChips splits an amount into n nearly equal parts, the earlier parts
+// taking the odd chips.
+func splitChips(amount, n int) []int {
+	parts := make([]int, n)
+	for i := range parts {
+		parts[i] = amount / n
+		if i < amount%n {
+			parts[i]++
+		}
+	}
+	return parts
+}
+
+// splitHighLow splits a High-Low pot between the high and the low hands, the
+// high hand taking the odd chip.
+func splitHighLow(amount int) (high, low int) {
+	low = amount / 2
+	return amount - low, low
+}
+
+// byOddChipOrder sorts players by the order in which they receive odd chips:
+// clockwise from the player to the left of the button.
+func (g *Game) byOddChipOrder(players []*Player) []*Player {
+	seat := make(map[*Player]int, len(g.Players))
+	for i, p := range g.Players {
+		seat[p] = (i - g.DealerPos - 1 + len(g.Players)) % len(g.Players)
+	}
+	ordered := append([]*Player(nil), players...)
+	sort.SliceStable(ordered, func(i, j int) bool { return seat[ordered[i]] < seat[ordered[j]] })
+	return ordered
+}
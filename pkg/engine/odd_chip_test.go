@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"reflect"
+	"testing"
+)
+
+func TestSplitChips(t *testing.T) {
+	testCases := []struct {
+		amount, n int
+		want      []int
+	}{
+		{10000, 1, []int{10000}},
+		{10000, 2, []int{5000, 5000}},
+		{10000, 3, []int{3334, 3333, 3333}},
+		{10001, 4, []int{2501, 2500, 2500, 2500}},
+		{3, 4, []int{1, 1, 1, 0}},
+	}
+	for _, tc := range testCases {
+		if got := splitChips(tc.amount, tc.n); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitChips(%d, %d) = %v, want %v", tc.amount, tc.n, got, tc.want)
+		}
+	}
+}
+
+// TestDistributePot_OddChipGoesLeftOfButton tests that the odd chip of a
+// split pot goes to the first tied player to the left of the button, and that
+// no chip is lost.
+func TestDistributePot_OddChipGoesLeftOfButton(t *testing.T) {
+	rules := loadRule(t, "pls.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.DealerPos = 0
+
+	g.Players[0].Hand = poker.CardsFromStrings("Ad Td 3s") // Ace-high straight
+	g.Players[1].Hand = poker.CardsFromStrings("Ac Tc 3h") // Ace-high straight
+	g.Players[2].Hand = poker.CardsFromStrings("5d 6d 7s")
+	for _, p := range g.Players {
+		p.TotalBetInHand = 1001
+		p.Status = PlayerStatusAllIn
+	}
+	g.CommunityCards = poker.CardsFromStrings("Ks Qd Jc 4h 2c")
+	g.Pot = 3003
+
+	g.DistributePot()
+
+	if g.Players[1].Chips != 1502 {
+		t.Errorf("Expected CPU1, left of the button, to win 1502 with the odd chip, but got %d", g.Players[1].Chips)
+	}
+	if g.Players[0].Chips != 1501 {
+		t.Errorf("Expected YOU to win 1501, but got %d", g.Players[0].Chips)
+	}
+	if g.Players[2].Chips != 0 {
+		t.Errorf("Expected CPU2 to win nothing, but got %d", g.Players[2].Chips)
+	}
+}
+
+// TestDistributePot_HiLoOddChipGoesHigh tests that the odd chip of a pot split
+// between the high and the low hands goes to the high hand.
+func TestDistributePot_HiLoOddChipGoesHigh(t *testing.T) {
+	rules := loadRule(t, "plo8.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 0, 0, DifficultyMedium, rules, true, false, 0)
+	g.Players[0].Hand = poker.CardsFromStrings("Kh 4d 5h 6h") // Kings full of fours
+	g.Players[1].Hand = poker.CardsFromStrings("2c 3c 9s Ts") // 8-7-4-3-2 low
+	g.Players[2].Hand = poker.CardsFromStrings("Qc Qd Js Th")
+	for _, p := range g.Players {
+		p.TotalBetInHand = 3001
+		p.Status = PlayerStatusAllIn
+	}
+	g.CommunityCards = poker.CardsFromStrings("Kc Kd 8s 7d 4c")
+	g.Pot = 9003
+	g.TotalInitialChips = 9003
+
+	g.DistributePot()
+
+	if g.Players[0].Chips != 4502 || g.Players[1].Chips != 4501 {
+		t.Errorf("Expected the high hand to win 4502 with the odd chip and the low hand 4501, but got %d and %d",
+			g.Players[0].Chips, g.Players[1].Chips)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected no chip lost, but got %v", g.ChipViolations)
+	}
+}
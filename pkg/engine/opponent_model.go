@@ -0,0 +1,101 @@
+package engine
+
+// OpponentModel captures the observed tendencies of a (human) player, as seen by
+// the AI opponents. It is designed to be serialized so that CPUs can "remember"
+// a returning player across sessions.
+type OpponentModel struct {
+	// Profile is the key identifying the modeled player across sessions.
+	Profile string `json:"profile"`
+	// HandsObserved is the number of hands in which the player was dealt in.
+	HandsObserved int `json:"hands_observed"`
+	// VoluntaryHands is the number of hands in which the player voluntarily put
+	// chips into the pot pre-flop (calls, bets and raises; blinds do not count).
+	VoluntaryHands int `json:"voluntary_hands"`
+	// PreFlopRaiseHands is the number of hands in which the player raised pre-flop.
+	PreFlopRaiseHands int `json:"pre_flop_raise_hands"`
+	// AggressiveActions counts bets and raises.
+	AggressiveActions int `json:"aggressive_actions"`
+	// PassiveActions counts calls and checks.
+	PassiveActions int `json:"passive_actions"`
+	// BetsFaced counts decisions made while facing a bet or raise.
+	BetsFaced int `json:"bets_faced"`
+	// FoldsToBet counts folds made while facing a bet or raise.
+	FoldsToBet int `json:"folds_to_bet"`
+	// PreFlop tracks the player's cold calls, squeezes and limp-reraises.
+	PreFlop PreFlopStats `json:"pre_flop"`
+	// Image is what the player's showdowns revealed about their ranges.
+	Image TableImage `json:"image"`
+
+	// lastHandSeen, lastVoluntaryHand and lastRaiseHand ensure that per-hand
+	// counters are incremented at most once per hand.
+	lastHandSeen      int
+	lastVoluntaryHand int
+	lastRaiseHand     int
+}
+
+// NewOpponentModel creates an empty model for the given profile key.
+func NewOpponentModel(profile string) *OpponentModel {
+	return &OpponentModel{Profile: profile}
+}
+
+// observe records a single action taken by the modeled player.
+func (m *OpponentModel) observe(handNum int, phase GamePhase, facingBet bool, action ActionType) {
+	if m.lastHandSeen != handNum {
+		m.lastHandSeen = handNum
+		m.HandsObserved++
+	}
+
+	if facingBet {
+		m.BetsFaced++
+		if action == ActionFold {
+			m.FoldsToBet++
+		}
+	}
+
+	switch action {
+	case ActionBet, ActionRaise:
+		m.AggressiveActions++
+	case ActionCall, ActionCheck:
+		m.PassiveActions++
+	}
+
+	if phase == PhasePreFlop {
+		voluntary := action == ActionCall || action == ActionBet || action == ActionRaise
+		if voluntary && m.lastVoluntaryHand != handNum {
+			m.lastVoluntaryHand = handNum
+			m.VoluntaryHands++
+		}
+		if action == ActionRaise && m.lastRaiseHand != handNum {
+			m.lastRaiseHand = handNum
+			m.PreFlopRaiseHands++
+		}
+	}
+}
+
+// VPIP returns the fraction of hands in which the player voluntarily put money in the pot.
+func (m *OpponentModel) VPIP() float64 {
+	return ratio(m.VoluntaryHands, m.HandsObserved)
+}
+
+// PFR returns the fraction of hands in which the player raised pre-flop.
+func (m *OpponentModel) PFR() float64 {
+	return ratio(m.PreFlopRaiseHands, m.HandsObserved)
+}
+
+// AggressionFrequency returns the fraction of non-fold actions that were bets or raises.
+func (m *OpponentModel) AggressionFrequency() float64 {
+	return ratio(m.AggressiveActions, m.AggressiveActions+m.PassiveActions)
+}
+
+// FoldToBetFrequency returns how often the player folds when facing a bet.
+func (m *OpponentModel) FoldToBetFrequency() float64 {
+	return ratio(m.FoldsToBet, m.BetsFaced)
+}
+
+// ratio returns n/d, or 0 when d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
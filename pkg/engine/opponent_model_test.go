@@ -0,0 +1,67 @@
+package engine
+
+import "testing"
+
+func TestOpponentModel_ObservesHumanActions(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.HumanModel = NewOpponentModel("default")
+	g.StartNewHand()
+
+	you := g.Players[0]
+	cpu := g.Players[1]
+
+	// Pre-flop: the human faces the big blind and raises, then calls a re-raise.
+	g.ProcessAction(you, PlayerAction{Type: ActionRaise, Amount: 3000})
+	g.ProcessAction(cpu, PlayerAction{Type: ActionRaise, Amount: 9000})
+	g.ProcessAction(you, PlayerAction{Type: ActionCall})
+
+	// Flop: the human checks, then folds to a bet.
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	g.ProcessAction(you, PlayerAction{Type: ActionCheck})
+	g.ProcessAction(cpu, PlayerAction{Type: ActionBet, Amount: 5000})
+	g.ProcessAction(you, PlayerAction{Type: ActionFold})
+
+	m := g.HumanModel
+	if m.HandsObserved != 1 {
+		t.Errorf("Expected 1 hand observed, but got %d", m.HandsObserved)
+	}
+	if m.VPIP() != 1 || m.PFR() != 1 {
+		t.Errorf("Expected VPIP and PFR to be 1, but got %.2f and %.2f", m.VPIP(), m.PFR())
+	}
+	if m.AggressiveActions != 1 || m.PassiveActions != 2 {
+		t.Errorf("Expected 1 aggressive and 2 passive actions, but got %d and %d", m.AggressiveActions, m.PassiveActions)
+	}
+	if m.BetsFaced != 3 || m.FoldsToBet != 1 {
+		t.Errorf("Expected 3 bets faced and 1 fold, but got %d and %d", m.BetsFaced, m.FoldsToBet)
+	}
+}
+
+func TestAdjustedBluffingFrequency(t *testing.T) {
+	lagProfile := builtInProfile("Loose-Aggressive")
+	testCases := []struct {
+		name       string
+		betsFaced  int
+		foldsToBet int
+		expected   float64
+	}{
+		{name: "Too few observations", betsFaced: 5, foldsToBet: 5, expected: lagProfile.BluffingFrequency},
+		{name: "Folds too often", betsFaced: 10, foldsToBet: 8, expected: lagProfile.BluffingFrequency * 1.5},
+		{name: "Calling station", betsFaced: 10, foldsToBet: 1, expected: lagProfile.BluffingFrequency * 0.5},
+		{name: "Balanced", betsFaced: 10, foldsToBet: 4, expected: lagProfile.BluffingFrequency},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := &Game{
+				Players:    []*Player{{Name: "YOU", Status: PlayerStatusPlaying}},
+				Difficulty: DifficultyMedium,
+				HumanModel: &OpponentModel{BetsFaced: tc.betsFaced, FoldsToBet: tc.foldsToBet},
+			}
+			cpu := &Player{Name: "CPU1", IsCPU: true, Profile: &lagProfile}
+			if got := g.adjustedBluffingFrequency(cpu); got != tc.expected {
+				t.Errorf("Expected bluffing frequency %.3f, but got %.3f", tc.expected, got)
+			}
+		})
+	}
+}
@@ -2,7 +2,8 @@ package engine
 
 import (
 	"fmt"
-	"pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 )
 
 // PlayerStatus defines the current state of a player within a single hand of poker.
@@ -33,30 +34,9 @@ func (s PlayerStatus) String() string {
 	}
 }
 
-// AIProfile defines the behavioral characteristics and decision-making parameters
-// for a CPU-controlled player. It allows for creating different "personalities"
-// for AI opponents, from tight and passive to loose and aggressive.
-type AIProfile struct {
-	// Name is the identifier for the profile, e.g., "Tight-Aggressive".
-	Name string
-	// PlayHandThreshold is the minimum hand strength score required for the AI to
-	// consider playing a hand pre-flop. A higher value means the AI is "tighter"
-	// and plays fewer hands.
-	PlayHandThreshold float64
-	// RaiseHandThreshold is the minimum hand strength score required for the AI
-	// to open with a raise pre-flop.
-	RaiseHandThreshold float64
-	// BluffingFrequency is the probability (0.0 to 1.0) that the AI will attempt
-	// a bluff with a weak hand.
-	BluffingFrequency float64
-	// AggressionFactor is the probability (0.0 to 1.0) that the AI will choose
-	// to bet or raise instead of check or call when it has a reasonably strong hand.
-	AggressionFactor float64
-	// MinRaiseMultiplier is the minimum multiplier for a raise amount, e.g., 2.0x the bet.
-	MinRaiseMultiplier float64
-	// MaxRaiseMultiplier is the maximum multiplier for a raise amount.
-	MaxRaiseMultiplier float64
-}
+// AIProfile defines the behavioral characteristics of a CPU player. It is
+// defined in the config package, which loads the profiles from YAML.
+type AIProfile = config.AIProfile
 
 // Player represents a single participant in the poker game. It holds all state
 // information relevant to the player, such as their cards, chip count, and status.
@@ -73,6 +53,13 @@ type Player struct {
 	// TotalBetInHand is the cumulative amount of chips the player has put into the
 	// pot throughout the entire current hand (across all betting rounds).
 	TotalBetInHand int
+	// Ante is the ante the player posted for themselves in the current hand.
+	// It is part of TotalBetInHand, but if the player folds, it is dead money
+	// for the main pot instead of a share of the side pots.
+	Ante int
+	// DeadAnte is the ante the player posted for the whole table in the current
+	// hand. It is dead money for the main pot and not part of TotalBetInHand.
+	DeadAnte int
 	// Status indicates the player's current state in the hand (e.g., Playing, Folded).
 	Status PlayerStatus
 	// IsCPU is true if the player is controlled by the AI.
@@ -83,6 +70,13 @@ type Player struct {
 	Profile *AIProfile
 	// Position is the player's seat at the table, represented by an index in the Game.Players slice.
 	Position int
+	// Mucked is true if the player's hand was mucked (discarded unseen) at showdown.
+	Mucked bool
+	// ShownCards holds the hole cards the player chose to reveal after the hand.
+	ShownCards []poker.Card
+	// HandHidden hides the player's hole cards at the table, along with their
+	// hand ranks and outs, e.g. so that a screen can be shared live.
+	HandHidden bool
 }
 
 // String provides a formatted string representation of the Player's state,
@@ -93,3 +87,9 @@ func (p *Player) String() string {
 		p.Name, p.Chips, p.Status, p.CurrentBet, p.IsCPU,
 	)
 }
+
+// dealtIn reports whether the player is dealt cards in the current hand. A
+// player put all-in by the antes or blinds is still dealt in.
+func (p *Player) dealtIn() bool {
+	return p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn
+}
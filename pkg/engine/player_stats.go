@@ -0,0 +1,139 @@
+package engine
+
+// PlayerStats holds one player's tendencies observed at the table this
+// session. Unlike the HumanModel, which only follows the human, it is kept
+// for every player, so that CPUs can read each other as well.
+type PlayerStats struct {
+	// Actions counts the player's decisions: the hands they voluntarily
+	// played and raised pre-flop, and their bets, raises, calls and checks.
+	Actions OpponentModel
+	// Streets holds the player's continuation bets, folds to them and
+	// per-street aggression.
+	Streets StreetStats
+}
+
+// VPIP returns the fraction of hands in which the player voluntarily put
+// money in the pot.
+func (s *PlayerStats) VPIP() float64 {
+	return s.Actions.VPIP()
+}
+
+// PFR returns the fraction of hands in which the player raised pre-flop.
+func (s *PlayerStats) PFR() float64 {
+	return s.Actions.PFR()
+}
+
+// AggressionFrequency returns the fraction of the player's non-fold actions
+// that were bets or raises.
+func (s *PlayerStats) AggressionFrequency() float64 {
+	return s.Actions.AggressionFrequency()
+}
+
+// FoldToCBetFrequency returns how often the player folds to a continuation bet.
+func (s *PlayerStats) FoldToCBetFrequency() float64 {
+	return s.Streets.FoldToCBetFrequency()
+}
+
+// observePlayerAction adds an action to the player's session statistics.
+func (g *Game) observePlayerAction(player *Player, action ActionType) {
+	g.playerStats(player.Name).Actions.observe(g.HandCount, g.Phase, g.BetToCall > player.CurrentBet, action)
+}
+
+// playerStats returns the named player's session statistics, creating them
+// if needed.
+func (g *Game) playerStats(name string) *PlayerStats {
+	if g.PlayerStats == nil {
+		g.PlayerStats = make(map[string]*PlayerStats)
+	}
+	stats, ok := g.PlayerStats[name]
+	if !ok {
+		stats = &PlayerStats{Actions: OpponentModel{Profile: name}}
+		g.PlayerStats[name] = stats
+	}
+	return stats
+}
+
+// minReadHands is the number of hands a player must have been seen in before
+// a CPU reads their statistics, and minReadCBets the number of continuation
+// bets they must have faced before their folds to them count.
+const (
+	minReadHands = 20
+	minReadCBets = 5
+)
+
+// opponentRead sums up what a CPU has seen of the opponents left in the hand.
+type opponentRead struct {
+	// station is set if an opponent plays many hands and seldom folds to
+	// continuation bets: bluffs against them fail, and thin value bets are
+	// paid off.
+	station bool
+	// folders is set if every opponent has been seen folding to continuation
+	// bets more often than not: bluffs against them work.
+	folders bool
+	// maniac is set if an opponent bets or raises most of the time: strong
+	// hands are better slow-played, letting them bet.
+	maniac bool
+}
+
+// readOpponents reads the session statistics of the player's opponents still
+// in the hand. Opponents who have not been seen for long enough are unknown,
+// so they never make the table read as folders.
+func (g *Game) readOpponents(player *Player) opponentRead {
+	read := opponentRead{folders: true}
+	opponents := 0
+	for _, p := range g.Players {
+		if p == player || (p.Status != PlayerStatusPlaying && p.Status != PlayerStatusAllIn) {
+			continue
+		}
+		opponents++
+		stats := g.PlayerStats[p.Name]
+		if stats == nil || stats.Streets.Hands < minReadHands {
+			read.folders = false
+			continue
+		}
+		knowsCBets := stats.Streets.CBetsFaced >= minReadCBets
+		if knowsCBets && stats.VPIP() >= 0.5 && stats.FoldToCBetFrequency() <= 0.3 {
+			read.station = true
+		}
+		if !knowsCBets || stats.FoldToCBetFrequency() < 0.6 {
+			read.folders = false
+		}
+		if stats.AggressionFrequency() >= 0.6 {
+			read.maniac = true
+		}
+	}
+	read.folders = read.folders && opponents > 0 && !read.station
+	return read
+}
+
+// readsOpponents reports whether the CPUs read the session statistics of their
+// opponents at the game's difficulty.
+func (g *Game) readsOpponents() bool {
+	return g.Difficulty.Preset().ReadsOpponents
+}
+
+// valueBetFrequency returns how often a CPU bets or raises a strong hand after
+// the flop rather than slow-playing it: its aggression on the street, raised
+// against a calling station, who pays off, and lowered against a maniac, who
+// bets for it.
+func (g *Game) valueBetFrequency(player *Player) float64 {
+	freq := g.aggressionFactor(player)
+	if !g.readsOpponents() {
+		return freq
+	}
+	read := g.readOpponents(player)
+	switch {
+	case read.maniac:
+		freq *= 0.75
+	case read.station:
+		freq = min(freq*1.25, 1)
+	}
+	return freq
+}
+
+// betsThinValue reports whether a CPU with a vulnerable made hand bets it when
+// checked to, rather than checking: only against a calling station, who pays
+// off with worse.
+func (g *Game) betsThinValue(player *Player) bool {
+	return g.readsOpponents() && g.readOpponents(player).station
+}
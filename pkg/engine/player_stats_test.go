@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlayerStats_ObservesEveryPlayer(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	you, cpu1, cpu2 := g.Players[0], g.Players[1], g.Players[2]
+	g.ProcessAction(you, PlayerAction{Type: ActionRaise, Amount: 3000})
+	g.ProcessAction(cpu1, PlayerAction{Type: ActionCall})
+	g.ProcessAction(cpu2, PlayerAction{Type: ActionFold})
+
+	// YOU continuation-bet the flop and CPU1 folds to it.
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	g.ProcessAction(cpu1, PlayerAction{Type: ActionCheck})
+	g.ProcessAction(you, PlayerAction{Type: ActionBet, Amount: 3000})
+	g.ProcessAction(cpu1, PlayerAction{Type: ActionFold})
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+
+	if stats := g.PlayerStats["YOU"]; stats == nil || stats.VPIP() != 1 || stats.PFR() != 1 || stats.AggressionFrequency() != 1 {
+		t.Errorf("Expected YOU to have played and raised the hand aggressively, but got %+v", stats)
+	}
+	cpu := g.PlayerStats["CPU1"]
+	if cpu == nil || cpu.VPIP() != 1 || cpu.PFR() != 0 {
+		t.Fatalf("Expected CPU1 to have called pre-flop, but got %+v", cpu)
+	}
+	if cpu.Streets.Hands != 1 || cpu.Streets.CBetsFaced != 1 || cpu.FoldToCBetFrequency() != 1 {
+		t.Errorf("Expected CPU1 to have folded to the continuation bet, but got %+v", cpu.Streets)
+	}
+	if stats := g.PlayerStats["CPU2"]; stats == nil || stats.VPIP() != 0 {
+		t.Errorf("Expected CPU2 not to have played the hand, but got %+v", stats)
+	}
+}
+
+// seatStats gives a player session statistics for the given number of hands,
+// having played the share vpip of them, faced ten continuation bets and folded
+// to the share foldToCBet of them, and bet or raised the share aggression of
+// their non-fold actions.
+func seatStats(vpip, foldToCBet, aggression float64) *PlayerStats {
+	const hands = 40
+	stats := &PlayerStats{}
+	stats.Actions.HandsObserved = hands
+	stats.Actions.VoluntaryHands = int(vpip * hands)
+	stats.Actions.AggressiveActions = int(aggression * 100)
+	stats.Actions.PassiveActions = 100 - stats.Actions.AggressiveActions
+	stats.Streets.Hands = hands
+	stats.Streets.CBetsFaced = 10
+	stats.Streets.FoldsToCBet = int(foldToCBet * 10)
+	return stats
+}
+
+func TestReadOpponents_AdjustsHardBluffsAndValueBets(t *testing.T) {
+	lagProfile := builtInProfile("Loose-Aggressive")
+	testCases := []struct {
+		name       string
+		difficulty Difficulty
+		stats      map[string]*PlayerStats
+		bluffScale float64
+		valueScale float64
+		thinValue  bool
+	}{
+		{
+			name: "Unknown opponents", difficulty: DifficultyHard,
+			bluffScale: 1, valueScale: 1,
+		},
+		{
+			name: "Calling station", difficulty: DifficultyHard,
+			stats:      map[string]*PlayerStats{"YOU": seatStats(0.6, 0.2, 0.3), "CPU2": seatStats(0.2, 0.8, 0.3)},
+			bluffScale: 0.5, valueScale: 1.25, thinValue: true,
+		},
+		{
+			name: "Folders", difficulty: DifficultyHard,
+			stats:      map[string]*PlayerStats{"YOU": seatStats(0.2, 0.8, 0.3), "CPU2": seatStats(0.3, 0.7, 0.3)},
+			bluffScale: 1.5, valueScale: 1,
+		},
+		{
+			name: "One folder and an unknown CPU", difficulty: DifficultyHard,
+			stats:      map[string]*PlayerStats{"YOU": seatStats(0.2, 0.8, 0.3)},
+			bluffScale: 1, valueScale: 1,
+		},
+		{
+			name: "Maniac", difficulty: DifficultyHard,
+			stats:      map[string]*PlayerStats{"YOU": seatStats(0.4, 0.5, 0.8), "CPU2": seatStats(0.3, 0.5, 0.3)},
+			bluffScale: 1, valueScale: 0.75,
+		},
+		{
+			name: "Medium does not read the table", difficulty: DifficultyMedium,
+			stats:      map[string]*PlayerStats{"YOU": seatStats(0.6, 0.2, 0.3), "CPU2": seatStats(0.6, 0.2, 0.3)},
+			bluffScale: 1, valueScale: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cpu := &Player{Name: "CPU1", IsCPU: true, Profile: &lagProfile, Status: PlayerStatusPlaying}
+			g := &Game{
+				Players: []*Player{
+					{Name: "YOU", Status: PlayerStatusPlaying},
+					cpu,
+					{Name: "CPU2", IsCPU: true, Status: PlayerStatusPlaying},
+				},
+				Difficulty:  tc.difficulty,
+				Phase:       PhaseFlop,
+				PlayerStats: tc.stats,
+			}
+			if got := g.readBluffingFrequency(cpu, 0.2); math.Abs(got-0.2*tc.bluffScale) > 1e-9 {
+				t.Errorf("Expected bluffing frequency %.3f, but got %.3f", 0.2*tc.bluffScale, got)
+			}
+			want := min(lagProfile.AggressionFactor*tc.valueScale, 1)
+			if got := g.valueBetFrequency(cpu); math.Abs(got-want) > 1e-9 {
+				t.Errorf("Expected value bet frequency %.3f, but got %.3f", want, got)
+			}
+			if got := g.betsThinValue(cpu); got != tc.thinValue {
+				t.Errorf("Expected thin value betting to be %v, but got %v", tc.thinValue, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,379 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// PokerStarsTableName is the table name written into exported hands.
+const PokerStarsTableName = "PLS7 CLI"
+
+// FormatPokerStars writes a completed hand in the PokerStars hand history text
+// format, the de facto standard read by hand trackers and analysis tools. Every
+// hole card the table saw is included: the human's are dealt to the hero, and
+// the others' are shown at showdown.
+//
+// Trackers only know the standard hands, so the variant's own are mapped to
+// their nearest standard equivalents: a Skip Straight is written as a straight
+// and a Skip Straight Flush as a straight flush, from the lowest card to the
+// highest. Chip amounts are written as they are, with no currency.
+func FormatPokerStars(h *HandHistory) string {
+	var sb strings.Builder
+	w := func(format string, args ...any) {
+		fmt.Fprintf(&sb, format, args...)
+		sb.WriteByte('\n')
+	}
+
+	button := 0
+	for i, seat := range h.Seats {
+		if seat.Name == h.Dealer {
+			button = i + 1
+		}
+	}
+	// Limit games are headed with the small and big bets instead of the blinds.
+	low, high := h.SmallBlind, h.BigBlind
+	if h.Rules != nil && h.Rules.BettingLimit == "fixed_limit" {
+		low, high = (&FixedLimitCalculator{Rules: h.Rules.FixedLimit}).BetSizes(h.BigBlind)
+	}
+	w("PokerStars Hand #%s: %s (%d/%d) - %s",
+		pokerStarsHandNumber(h.ID), pokerStarsGame(h), low, high, h.PlayedAt.Format("2006/01/02 15:04:05 MST"))
+	w("Table '%s' %d-max Seat #%d is the button", PokerStarsTableName, max(len(h.Seats), 2), button)
+	for i, seat := range h.Seats {
+		w("Seat %d: %s (%d in chips)", i+1, seat.Name, seat.StartingChips)
+	}
+
+	// Follow every stack through the hand, so that all-ins and uncalled bets
+	// can be told.
+	stacks := make(map[string]int, len(h.Seats))
+	for _, seat := range h.Seats {
+		stacks[seat.Name] = seat.StartingChips
+	}
+	post := func(name string, amount int) int {
+		amount = min(amount, stacks[name])
+		stacks[name] -= amount
+		return amount
+	}
+	allIn := func(name string) string {
+		if stacks[name] == 0 {
+			return " and is all-in"
+		}
+		return ""
+	}
+
+	switch {
+	case h.AntePlayer != "":
+		w("%s: posts the ante %d%s", h.AntePlayer, post(h.AntePlayer, h.Ante), allIn(h.AntePlayer))
+	case h.Ante > 0:
+		for _, seat := range h.Seats {
+			w("%s: posts the ante %d%s", seat.Name, post(seat.Name, h.Ante), allIn(seat.Name))
+		}
+	}
+	street := make(map[string]int)
+	if h.SmallBlindPlayer != "" {
+		street[h.SmallBlindPlayer] = post(h.SmallBlindPlayer, h.SmallBlind)
+		w("%s: posts small blind %d%s", h.SmallBlindPlayer, street[h.SmallBlindPlayer], allIn(h.SmallBlindPlayer))
+	}
+	street[h.BigBlindPlayer] = post(h.BigBlindPlayer, h.BigBlind)
+	w("%s: posts big blind %d%s", h.BigBlindPlayer, street[h.BigBlindPlayer], allIn(h.BigBlindPlayer))
+	if h.StraddlePlayer != "" {
+		street[h.StraddlePlayer] = post(h.StraddlePlayer, h.Straddle)
+		w("%s: posts straddle %d%s", h.StraddlePlayer, street[h.StraddlePlayer], allIn(h.StraddlePlayer))
+	}
+
+	w("*** HOLE CARDS ***")
+	for _, seat := range h.Seats {
+		if seat.IsHuman {
+			w("Dealt to %s [%s]", seat.Name, pokerStarsCards(seat.HoleCards))
+		}
+	}
+
+	// returned holds the uncalled bets. Hands saved before the engine
+	// returned them separately count them in the results.
+	returned := make(map[string]int)
+	returnUncalled := func() {
+		var top, second int
+		var bettor string
+		for name, amount := range street {
+			switch {
+			case amount > top:
+				top, second, bettor = amount, top, name
+			case amount > second:
+				second = amount
+			}
+		}
+		if top > second {
+			w("Uncalled bet (%d) returned to %s", top-second, bettor)
+			returned[bettor] += top - second
+			stacks[bettor] += top - second
+		}
+	}
+
+	phase := PhasePreFlop
+	betToCall := max(street[h.BigBlindPlayer], street[h.StraddlePlayer])
+	deal := func() {
+		returnUncalled()
+		phase++
+		street = make(map[string]int)
+		betToCall = 0
+		size := phase.Street().BoardSize()
+		if size > len(h.Board) {
+			return
+		}
+		if phase == PhaseFlop {
+			w("*** FLOP *** [%s]", pokerStarsCards(h.Board[:size]))
+		} else {
+			w("*** %s *** [%s] [%s]", strings.ToUpper(phase.String()), pokerStarsCards(h.Board[:size-1]), pokerStarsCards(h.Board[size-1:size]))
+		}
+	}
+	for _, action := range h.Actions {
+		for phase < action.Phase {
+			deal()
+		}
+		name := action.PlayerName
+		switch action.Action {
+		case ActionFold:
+			w("%s: folds", name)
+		case ActionCheck:
+			w("%s: checks", name)
+		case ActionCall:
+			if action.Amount == 0 {
+				w("%s: checks", name)
+				continue
+			}
+			called := post(name, action.Amount)
+			street[name] += called
+			w("%s: calls %d%s", name, called, allIn(name))
+		case ActionBet, ActionRaise:
+			// A bet is a raise when it adds to chips already in, as when
+			// the big blind bets on its option.
+			if action.Action == ActionBet && street[name] == 0 {
+				street[name] += post(name, action.Amount)
+				betToCall = street[name]
+				w("%s: bets %d%s", name, street[name], allIn(name))
+				continue
+			}
+			street[name] += post(name, action.Amount-street[name])
+			w("%s: raises %d to %d%s", name, street[name]-betToCall, street[name], allIn(name))
+			betToCall = street[name]
+		}
+	}
+	// Deal out the rest of the board, e.g. after an all-in.
+	for phase < PhaseRiver && len(h.Board) >= phase.Street().BoardSize()+1 {
+		deal()
+	}
+	returnUncalled()
+
+	collected := make(map[string]int)
+	var pot int
+	if h.Uncalled != nil {
+		returned = nil
+	}
+	for _, r := range h.Results {
+		amount := max(r.AmountWon-returned[r.PlayerName], 0)
+		collected[r.PlayerName] += amount
+		pot += amount
+	}
+	showdown := false
+	for _, seat := range h.Seats {
+		showdown = showdown || seat.Showdown
+	}
+	if showdown {
+		w("*** SHOW DOWN ***")
+		for _, seat := range h.Seats {
+			switch {
+			case seat.Showdown:
+				w("%s: shows [%s] (%s)", seat.Name, pokerStarsCards(seat.HoleCards), pokerStarsHandDescription(seat))
+			case !pokerStarsFolded(h, seat.Name):
+				w("%s: mucks hand", seat.Name)
+			}
+		}
+	}
+	for _, seat := range h.Seats {
+		if amount, ok := collected[seat.Name]; ok {
+			w("%s collected %d from pot", seat.Name, amount)
+			if !showdown {
+				w("%s: doesn't show hand", seat.Name)
+			}
+		}
+	}
+
+	w("*** SUMMARY ***")
+	w("Total pot %d | Rake 0", pot)
+	if len(h.Board) > 0 {
+		w("Board [%s]", pokerStarsCards(h.Board))
+	}
+	for i, seat := range h.Seats {
+		w("Seat %d: %s%s %s", i+1, seat.Name, pokerStarsSeatRole(h, seat.Name), pokerStarsSeatSummary(h, seat, collected))
+	}
+	return sb.String()
+}
+
+// pokerStarsHandNumber turns a hand ID into the all-digit hand number the
+// format expects, e.g. "20250101-120000-0003" into "202501011200000003".
+func pokerStarsHandNumber(id string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, id)
+}
+
+// pokerStarsGame names the game and its betting limit as PokerStars does, e.g.
+// "Omaha Hi/Lo Pot Limit". Hold'em and Omaha are told apart by the number of
+// hole cards, and a game with three is written as Hold'em with three cards.
+func pokerStarsGame(h *HandHistory) string {
+	rules := h.Rules
+	if rules == nil {
+		return h.Rule
+	}
+	game := "Hold'em"
+	switch count := rules.HoleCards.Count; {
+	case count == 4:
+		game = "Omaha"
+	case count > 4:
+		game = fmt.Sprintf("%d Card Omaha", count)
+	case count == 3:
+		game = "3 Card Hold'em"
+	}
+	if rules.LowHand.Enabled {
+		game += " Hi/Lo"
+	}
+	switch rules.BettingLimit {
+	case "no_limit":
+		return game + " No Limit"
+	case "fixed_limit":
+		return game + " Limit"
+	}
+	return game + " Pot Limit"
+}
+
+// pokerStarsCards joins cards in PokerStars notation, e.g. "As Td 7c".
+func pokerStarsCards(cards []poker.Card) string {
+	notations := make([]string, len(cards))
+	for i, c := range cards {
+		notations[i] = c.Notation()
+	}
+	return strings.Join(notations, " ")
+}
+
+// pokerStarsRankNames names each rank as PokerStars does, singular and plural.
+var pokerStarsRankNames = map[poker.Rank][2]string{
+	poker.Two: {"Deuce", "Deuces"}, poker.Three: {"Three", "Threes"}, poker.Four: {"Four", "Fours"},
+	poker.Five: {"Five", "Fives"}, poker.Six: {"Six", "Sixes"}, poker.Seven: {"Seven", "Sevens"},
+	poker.Eight: {"Eight", "Eights"}, poker.Nine: {"Nine", "Nines"}, poker.Ten: {"Ten", "Tens"},
+	poker.Jack: {"Jack", "Jacks"}, poker.Queen: {"Queen", "Queens"}, poker.King: {"King", "Kings"},
+	poker.Ace: {"Ace", "Aces"},
+}
+
+// pokerStarsHandDescription describes the hands a seat showed, e.g. "a pair of
+// Kings", or "HI: a flush, Ace high; LO: 7,5,4,3,A" in a High-Low split game.
+func pokerStarsHandDescription(seat SeatRecord) string {
+	if seat.High == nil {
+		return "no hand"
+	}
+	high := pokerStarsHighHand(seat.High)
+	if seat.Low == nil {
+		return high
+	}
+	ranks := make([]poker.Rank, len(seat.Low.Cards))
+	for i, c := range seat.Low.Cards {
+		ranks[i] = c.Rank
+		if c.Rank == poker.Ace {
+			ranks[i] = 1
+		}
+	}
+	sort.Slice(ranks, func(i, j int) bool { return ranks[i] > ranks[j] })
+	low := make([]string, len(ranks))
+	for i, rank := range ranks {
+		if rank == 1 {
+			rank = poker.Ace
+		}
+		low[i] = rank.String()
+	}
+	return fmt.Sprintf("HI: %s; LO: %s", high, strings.Join(low, ","))
+}
+
+// pokerStarsHighHand describes a high hand in PokerStars' words, mapping Skip
+// Straights to straights.
+func pokerStarsHighHand(hand *poker.HandResult) string {
+	name := func(rank poker.Rank) string { return pokerStarsRankNames[rank][0] }
+	plural := func(rank poker.Rank) string { return pokerStarsRankNames[rank][1] }
+	top := hand.HighValues[0]
+	// Straights run from their last card, the ace of a wheel included, to
+	// their first.
+	span := func() string {
+		return fmt.Sprintf("%s to %s", name(hand.Cards[len(hand.Cards)-1].Rank), name(hand.Cards[0].Rank))
+	}
+	switch hand.Rank {
+	case poker.RoyalFlush:
+		return "a Royal Flush"
+	case poker.StraightFlush, poker.SkipStraightFlush:
+		return "a straight flush, " + span()
+	case poker.FourOfAKind:
+		return "four of a kind, " + plural(top)
+	case poker.FullHouse:
+		return fmt.Sprintf("a full house, %s full of %s", plural(top), plural(hand.HighValues[1]))
+	case poker.Flush:
+		return fmt.Sprintf("a flush, %s high", name(top))
+	case poker.Straight, poker.SkipStraight:
+		return "a straight, " + span()
+	case poker.ThreeOfAKind:
+		return "three of a kind, " + plural(top)
+	case poker.TwoPair:
+		return fmt.Sprintf("two pair, %s and %s", plural(top), plural(hand.HighValues[1]))
+	case poker.OnePair:
+		return "a pair of " + plural(top)
+	}
+	return "high card " + name(top)
+}
+
+// pokerStarsSeatRole marks the button and the blinds in the summary.
+func pokerStarsSeatRole(h *HandHistory, name string) string {
+	switch name {
+	case h.Dealer:
+		return " (button)"
+	case h.SmallBlindPlayer:
+		return " (small blind)"
+	case h.BigBlindPlayer:
+		return " (big blind)"
+	}
+	return ""
+}
+
+// pokerStarsFolded reports whether the player folded in the recorded hand.
+func pokerStarsFolded(h *HandHistory, name string) bool {
+	for _, action := range h.Actions {
+		if action.PlayerName == name && action.Action == ActionFold {
+			return true
+		}
+	}
+	return false
+}
+
+// pokerStarsSeatSummary tells how a seat's hand ended, e.g. "folded before
+// Flop" or "showed [As Kd] and won (4000) with a pair of Kings".
+func pokerStarsSeatSummary(h *HandHistory, seat SeatRecord, collected map[string]int) string {
+	for _, action := range h.Actions {
+		if action.PlayerName != seat.Name || action.Action != ActionFold {
+			continue
+		}
+		if action.Phase == PhasePreFlop {
+			return "folded before Flop"
+		}
+		return "folded on the " + action.Phase.String()
+	}
+	amount, won := collected[seat.Name]
+	switch {
+	case seat.Showdown && won:
+		return fmt.Sprintf("showed [%s] and won (%d) with %s", pokerStarsCards(seat.HoleCards), amount, pokerStarsHandDescription(seat))
+	case seat.Showdown:
+		return fmt.Sprintf("showed [%s] and lost with %s", pokerStarsCards(seat.HoleCards), pokerStarsHandDescription(seat))
+	case won:
+		return fmt.Sprintf("collected (%d)", amount)
+	}
+	return "mucked"
+}
@@ -0,0 +1,491 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// pokerStarsHandStart starts every hand in a PokerStars hand history file.
+const pokerStarsHandStart = "PokerStars "
+
+var (
+	// pokerStarsHeader matches a hand's first line, e.g. "PokerStars Hand
+	// #123: Hold'em No Limit ($0.01/$0.02 USD) - 2024/05/01 20:15:00 ET", or
+	// a tournament's, with the level's blinds in the parentheses.
+	pokerStarsHeader = regexp.MustCompile(`^PokerStars (?:Zoom |Home Game )?Hand #(\d+):\s*(.*?)\(([^()]*)\)\s*-\s*(\d{4}/\d{1,2}/\d{1,2} \d{1,2}:\d{2}:\d{2})`)
+	// pokerStarsButton matches the table line, e.g. "Table 'Ariadne' 6-max
+	// Seat #4 is the button".
+	pokerStarsButton = regexp.MustCompile(`Seat #(\d+) is the button`)
+	// pokerStarsSeat matches a seat, e.g. "Seat 1: Alice ($2.15 in chips)".
+	pokerStarsSeat = regexp.MustCompile(`^Seat (\d+): (.+) \((\S+) in chips[^)]*\)(.*)$`)
+	// pokerStarsDealt matches the hero's hole cards, e.g. "Dealt to Alice [Ah Kd]".
+	pokerStarsDealt = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]$`)
+	// pokerStarsUncalled matches a bet returned, e.g. "Uncalled bet ($0.04)
+	// returned to Alice".
+	pokerStarsUncalled = regexp.MustCompile(`^Uncalled bet \((\S+)\) returned to (.+)$`)
+	// pokerStarsCollected matches a share of a pot won, e.g. "Alice collected
+	// $0.10 from side pot-1".
+	pokerStarsCollected = regexp.MustCompile(`^(.+) collected (\S+) from (?:main |side )?pot`)
+	// pokerStarsCardGroups matches the bracketed card groups of a line.
+	pokerStarsCardGroups = regexp.MustCompile(`\[([^\]]*)\]`)
+)
+
+// SplitPokerStarsHands splits the text of a PokerStars hand history file into
+// its hands, each starting with its "PokerStars Hand #" line.
+func SplitPokerStarsHands(text string) []string {
+	var hands []string
+	var current []string
+	flush := func() {
+		if hand := strings.TrimSpace(strings.Join(current, "\n")); hand != "" {
+			hands = append(hands, hand)
+		}
+		current = nil
+	}
+	for _, line := range pokerStarsLines(text) {
+		if strings.HasPrefix(line, pokerStarsHandStart) && pokerStarsHeader.MatchString(line) {
+			flush()
+		}
+		if len(current) > 0 || strings.HasPrefix(line, pokerStarsHandStart) {
+			current = append(current, line)
+		}
+	}
+	flush()
+	return hands
+}
+
+// ParsePokerStars reads one No Limit Hold'em hand written in the PokerStars
+// hand history format, such as a hand split off by SplitPokerStarsHands, into
+// a hand history played with the given rules, so that it can be replayed and
+// reviewed like a hand played here. The hero, the player whose hole cards were
+// dealt face up, is recorded as the human player.
+//
+// Amounts in a currency are converted to cents, so that they are whole chips,
+// and the time the hand was played is read as UTC. Hands the internal format
+// cannot hold are refused: other games, boards run more than once, and a
+// player posting both blinds.
+func ParsePokerStars(text string, rules *poker.GameRules) (*HandHistory, error) {
+	lines := pokerStarsLines(text)
+	header := pokerStarsHeader.FindStringSubmatch(strings.TrimSpace(lines[0]))
+	if header == nil {
+		return nil, fmt.Errorf("not a PokerStars hand: %q", lines[0])
+	}
+	number, game, stakes, date := header[1], header[2], header[3], header[4]
+	if !strings.Contains(game, "Hold'em No Limit") {
+		return nil, fmt.Errorf("hand #%s: only No Limit Hold'em can be imported, not %q", number, strings.TrimSpace(game))
+	}
+	p := &pokerStarsParser{rules: rules, street: make(map[string]int), collected: make(map[string]int), returned: make(map[string]int), shows: make(map[string][]poker.Card), shown: make(map[string]string)}
+	// Amounts in a currency are in cents.
+	p.scale = 1
+	if strings.ContainsAny(stakes, "$€£.") {
+		p.scale = 100
+	}
+	blinds := strings.Split(strings.Fields(stakes)[0], "/")
+	if len(blinds) != 2 {
+		return nil, fmt.Errorf("hand #%s: cannot read the stakes %q", number, stakes)
+	}
+	playedAt, err := time.ParseInLocation("2006/01/02 15:04:05", date, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("hand #%s: cannot read the date %q: %w", number, date, err)
+	}
+	p.h = &HandHistory{
+		ID:       fmt.Sprintf("%s-PS%s", playedAt.Format(HandIDTimeFormat), number),
+		Rule:     rules.Abbreviation,
+		Rules:    rules,
+		PlayedAt: playedAt,
+	}
+	p.h.HandNumber, _ = strconv.Atoi(number)
+	if p.h.SmallBlind, err = p.amount(blinds[0]); err == nil {
+		p.h.BigBlind, err = p.amount(blinds[1])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("hand #%s: %w", number, err)
+	}
+
+	for _, line := range lines[1:] {
+		if err := p.parseLine(strings.TrimSpace(line)); err != nil {
+			return nil, fmt.Errorf("hand #%s: %w", number, err)
+		}
+	}
+	if err := p.finish(); err != nil {
+		return nil, fmt.Errorf("hand #%s: %w", number, err)
+	}
+	return p.h, nil
+}
+
+// pokerStarsParser holds the state of a hand being read by ParsePokerStars.
+type pokerStarsParser struct {
+	h     *HandHistory
+	rules *poker.GameRules
+	scale int // scale converts amounts to chips: 100 for cents, or 1.
+
+	button    int   // button is the seat number of the button.
+	seatNums  []int // seatNums holds the seat number of each of h.Seats.
+	phase     GamePhase
+	heroDealt bool // heroDealt is set once the hero's hole cards are read.
+	showdown  bool // showdown is set once the SHOW DOWN section starts.
+	summary   bool // summary is set once the SUMMARY section starts.
+	raised    bool // raised is set once the pre-flop is raised.
+
+	street    map[string]int          // street holds each player's chips in this betting round.
+	collected map[string]int          // collected holds each player's winnings.
+	returned  map[string]int          // returned holds each player's uncalled bets.
+	shows     map[string][]poker.Card // shows holds the cards each player showed.
+	shown     map[string]string       // shown holds the hand each player showed, e.g. "a pair of Kings".
+}
+
+// amount converts an amount, e.g. "$0.25" or "1,500", to whole chips.
+func (p *pokerStarsParser) amount(s string) (int, error) {
+	clean := strings.NewReplacer("$", "", "€", "", "£", "", ",", "").Replace(s)
+	value, err := strconv.ParseFloat(clean, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("cannot read the amount %q", s)
+	}
+	return int(math.Round(value * float64(p.scale))), nil
+}
+
+// seat returns the named player's seat record, or nil if there is none.
+func (p *pokerStarsParser) seat(name string) *SeatRecord {
+	for i := range p.h.Seats {
+		if p.h.Seats[i].Name == name {
+			return &p.h.Seats[i]
+		}
+	}
+	return nil
+}
+
+// splitPlayerLine splits a line such as "Alice: calls 20" into the player's
+// name and what follows, matching the longest name seated, since names can
+// hold spaces and colons.
+func (p *pokerStarsParser) splitPlayerLine(line string) (name, rest string, ok bool) {
+	for _, seat := range p.h.Seats {
+		if strings.HasPrefix(line, seat.Name+": ") && len(seat.Name) > len(name) {
+			name, rest, ok = seat.Name, line[len(seat.Name)+2:], true
+		}
+	}
+	return name, rest, ok
+}
+
+// parseLine reads one line of the hand after its header. Lines that do not
+// change the hand, such as chat, are skipped.
+func (p *pokerStarsParser) parseLine(line string) error {
+	if p.summary {
+		return p.parseSummaryLine(line)
+	}
+	if m := pokerStarsButton.FindStringSubmatch(line); m != nil && strings.HasPrefix(line, "Table ") {
+		p.button, _ = strconv.Atoi(m[1])
+		return nil
+	}
+	if m := pokerStarsSeat.FindStringSubmatch(line); m != nil {
+		if strings.Contains(m[4], "sitting out") {
+			return nil
+		}
+		chips, err := p.amount(m[3])
+		if err != nil {
+			return err
+		}
+		seatNum, _ := strconv.Atoi(m[1])
+		p.seatNums = append(p.seatNums, seatNum)
+		p.h.Seats = append(p.h.Seats, SeatRecord{Name: m[2], StartingChips: chips})
+		return nil
+	}
+	if strings.HasPrefix(line, "*** ") {
+		return p.parseSection(line)
+	}
+	if m := pokerStarsDealt.FindStringSubmatch(line); m != nil {
+		seat := p.seat(m[1])
+		if seat == nil {
+			return fmt.Errorf("cards dealt to %q, who is not seated", m[1])
+		}
+		cards, err := parsePokerStarsCards(m[2])
+		if err != nil {
+			return err
+		}
+		// Only the first player dealt face up is the hero.
+		if !p.heroDealt {
+			seat.HoleCards, seat.IsHuman, p.heroDealt = cards, true, true
+		}
+		return nil
+	}
+	if m := pokerStarsUncalled.FindStringSubmatch(line); m != nil {
+		amount, err := p.amount(m[1])
+		if err != nil {
+			return err
+		}
+		p.returned[m[2]] += amount
+		return nil
+	}
+	if m := pokerStarsCollected.FindStringSubmatch(line); m != nil && p.seat(m[1]) != nil {
+		amount, err := p.amount(m[2])
+		if err != nil {
+			return err
+		}
+		p.collected[m[1]] += amount
+		return nil
+	}
+	if name, rest, ok := p.splitPlayerLine(line); ok {
+		return p.parseAction(name, rest)
+	}
+	return nil
+}
+
+// parseSection starts a street, the showdown or the summary.
+func (p *pokerStarsParser) parseSection(line string) error {
+	switch {
+	case strings.HasPrefix(line, "*** HOLE CARDS"):
+		return nil
+	case strings.HasPrefix(line, "*** FIRST") || strings.HasPrefix(line, "*** SECOND"):
+		return fmt.Errorf("boards run more than once cannot be imported")
+	case strings.HasPrefix(line, "*** FLOP"), strings.HasPrefix(line, "*** TURN"), strings.HasPrefix(line, "*** RIVER"):
+		var board []poker.Card
+		for _, group := range pokerStarsCardGroups.FindAllStringSubmatch(line, -1) {
+			cards, err := parsePokerStarsCards(group[1])
+			if err != nil {
+				return err
+			}
+			board = append(board, cards...)
+		}
+		p.h.Board = board
+		p.phase++
+		p.street = make(map[string]int)
+	case strings.HasPrefix(line, "*** SHOW DOWN"):
+		p.showdown = true
+	case strings.HasPrefix(line, "*** SUMMARY"):
+		p.summary = true
+	}
+	return nil
+}
+
+// parseAction reads what a player did, e.g. "raises 20 to 40 and is all-in".
+func (p *pokerStarsParser) parseAction(name, rest string) error {
+	rest = strings.TrimSuffix(rest, " and is all-in")
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil
+	}
+	record := ActionRecord{Phase: p.phase, PlayerName: name}
+	switch {
+	case strings.HasPrefix(rest, "posts small & big blinds"):
+		return fmt.Errorf("%s posts both blinds, which cannot be imported", name)
+	case strings.HasPrefix(rest, "posts small blind "):
+		amount, err := p.amount(fields[len(fields)-1])
+		p.h.SmallBlindPlayer, p.street[name] = name, amount
+		return err
+	case strings.HasPrefix(rest, "posts big blind "):
+		if p.h.BigBlindPlayer != "" {
+			return fmt.Errorf("%s posts a second big blind, which cannot be imported", name)
+		}
+		amount, err := p.amount(fields[len(fields)-1])
+		p.h.BigBlindPlayer, p.street[name] = name, amount
+		return err
+	case strings.HasPrefix(rest, "posts straddle "):
+		amount, err := p.amount(fields[len(fields)-1])
+		p.h.StraddlePlayer, p.h.Straddle, p.street[name] = name, amount, amount
+		return err
+	case strings.HasPrefix(rest, "posts the ante "):
+		amount, err := p.amount(fields[len(fields)-1])
+		p.h.Ante = amount
+		return err
+	case fields[0] == "shows":
+		return p.parseShow(name, rest)
+	case fields[0] == "folds":
+		record.Action = ActionFold
+	case fields[0] == "checks":
+		record.Action = ActionCheck
+	case fields[0] == "calls" && len(fields) == 2:
+		amount, err := p.amount(fields[1])
+		if err != nil {
+			return err
+		}
+		record.Action, record.Amount = ActionCall, amount
+	case fields[0] == "bets" && len(fields) == 2:
+		amount, err := p.amount(fields[1])
+		if err != nil {
+			return err
+		}
+		record.Action, record.Amount = ActionBet, p.street[name]+amount
+	case fields[0] == "raises" && len(fields) == 4 && fields[2] == "to":
+		amount, err := p.amount(fields[3])
+		if err != nil {
+			return err
+		}
+		record.Action, record.Amount = ActionRaise, amount
+	default:
+		// Anything else, such as "mucks hand" or "is sitting out", leaves
+		// the hand as it is.
+		return nil
+	}
+
+	record.Detail = p.actionDetail(name, record.Action)
+	switch record.Action {
+	case ActionCall:
+		p.street[name] += record.Amount
+	case ActionBet, ActionRaise:
+		p.street[name] = record.Amount
+		if p.phase == PhasePreFlop {
+			p.raised = true
+		}
+	}
+	p.h.Actions = append(p.h.Actions, record)
+	return nil
+}
+
+// actionDetail tells limps, small blind completions and the big blind's (or
+// the straddler's) option apart, as Game.actionDetail does for hands played
+// here.
+func (p *pokerStarsParser) actionDetail(name string, action ActionType) ActionDetail {
+	if p.phase != PhasePreFlop || p.raised {
+		return ActionDetailNone
+	}
+	optionPlayer := p.h.BigBlindPlayer
+	if p.h.StraddlePlayer != "" {
+		optionPlayer = p.h.StraddlePlayer
+	}
+	switch {
+	case action == ActionCheck && name == optionPlayer:
+		return ActionDetailOption
+	case action == ActionCall && name == p.h.SmallBlindPlayer && p.h.StraddlePlayer == "":
+		return ActionDetailComplete
+	case action == ActionCall && name != p.h.BigBlindPlayer && name != p.h.SmallBlindPlayer:
+		return ActionDetailLimp
+	}
+	return ActionDetailNone
+}
+
+// parseShow reads the cards a player showed, e.g. "shows [Ah Kd] (a pair of
+// Kings)".
+func (p *pokerStarsParser) parseShow(name, rest string) error {
+	m := pokerStarsCardGroups.FindStringSubmatch(rest)
+	if m == nil {
+		return nil
+	}
+	cards, err := parsePokerStarsCards(m[1])
+	if err != nil {
+		return err
+	}
+	p.shows[name] = cards
+	if open := strings.Index(rest, "("); open >= 0 {
+		p.shown[name] = strings.TrimSuffix(rest[open+1:], ")")
+	}
+	return nil
+}
+
+// parseSummaryLine reads the board and any cards mucked at the showdown from
+// the summary.
+func (p *pokerStarsParser) parseSummaryLine(line string) error {
+	if strings.HasPrefix(line, "Board [") {
+		cards, err := parsePokerStarsCards(strings.TrimSuffix(strings.TrimPrefix(line, "Board ["), "]"))
+		if err != nil {
+			return err
+		}
+		if len(cards) > len(p.h.Board) {
+			p.h.Board = cards
+		}
+		return nil
+	}
+	for i := range p.h.Seats {
+		seat := &p.h.Seats[i]
+		prefix := fmt.Sprintf("Seat %d: %s ", p.seatNums[i], seat.Name)
+		if !strings.HasPrefix(line, prefix) || !strings.Contains(line, " mucked [") {
+			continue
+		}
+		cards, err := parsePokerStarsCards(pokerStarsCardGroups.FindStringSubmatch(line[strings.Index(line, " mucked ["):])[1])
+		if err != nil {
+			return err
+		}
+		seat.HoleCards, seat.Showdown = cards, true
+	}
+	return nil
+}
+
+// finish names the button and the positions, evaluates the hands known at
+// the table, and records the pot distribution.
+func (p *pokerStarsParser) finish() error {
+	h := p.h
+	if len(h.Seats) < 2 {
+		return fmt.Errorf("fewer than two players were dealt in")
+	}
+	if h.BigBlindPlayer == "" {
+		return fmt.Errorf("nobody posted the big blind")
+	}
+
+	bbIndex := 0
+	for i, seat := range h.Seats {
+		if p.seatNums[i] == p.button {
+			h.Dealer = seat.Name
+		}
+		if seat.Name == h.BigBlindPlayer {
+			bbIndex = i
+		}
+	}
+	var order []string
+	for i := (bbIndex + 1) % len(h.Seats); i != bbIndex; i = (i + 1) % len(h.Seats) {
+		if h.Seats[i].Name == h.SmallBlindPlayer {
+			break
+		}
+		order = append(order, h.Seats[i].Name)
+	}
+	positions := tablePositions(order, h.SmallBlindPlayer, h.BigBlindPlayer)
+	folded := make(map[string]bool)
+	for i := range h.Actions {
+		h.Actions[i].Position = positions[h.Actions[i].PlayerName]
+		if h.Actions[i].Action == ActionFold {
+			folded[h.Actions[i].PlayerName] = true
+		}
+	}
+
+	for i := range h.Seats {
+		seat := &h.Seats[i]
+		seat.Position = positions[seat.Name]
+		// Cards shown by a player still in at a showdown were shown at it,
+		// even when shown as the players went all-in; others were shown
+		// after the hand.
+		if cards, ok := p.shows[seat.Name]; ok {
+			if p.showdown && !folded[seat.Name] {
+				seat.HoleCards, seat.Showdown = cards, true
+			} else {
+				seat.ShownCards = cards
+			}
+		}
+		if len(h.Board) == 5 && len(seat.HoleCards) > 0 && !folded[seat.Name] && (seat.IsHuman || seat.Showdown) {
+			seat.High, seat.Low = poker.EvaluateHand(seat.HoleCards, h.Board, p.rules)
+		}
+		if amount := p.returned[seat.Name]; amount > 0 && (h.Uncalled == nil || amount > h.Uncalled.Amount) {
+			h.Uncalled = &UncalledBet{PlayerName: seat.Name, Amount: amount}
+		}
+		if won := p.collected[seat.Name]; won > 0 {
+			result := DistributionResult{PlayerName: seat.Name, AmountWon: won}
+			if !p.showdown {
+				result.HandDesc = lastPlayerHandDesc
+			} else {
+				result.HandDesc = p.shown[seat.Name]
+			}
+			h.Results = append(h.Results, result)
+		}
+	}
+	return nil
+}
+
+// pokerStarsLines splits text into lines, dropping the byte order mark and
+// carriage returns that files saved on Windows have.
+func pokerStarsLines(text string) []string {
+	text = strings.TrimPrefix(text, "\ufeff")
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
+
+// parsePokerStarsCards parses cards in PokerStars notation, e.g. "Ah Kd".
+func parsePokerStarsCards(s string) ([]poker.Card, error) {
+	var cards []poker.Card
+	for _, notation := range strings.Fields(s) {
+		card, err := poker.ParseCard(notation)
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
@@ -0,0 +1,240 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatPokerStars(t *testing.T) {
+	rules := loadRule(t, "pls7.yml")
+	board := poker.CardsFromStrings("9s 7s 5s 3h 2d")
+	seat := func(name, hole string, showdown bool) SeatRecord {
+		holeCards := poker.CardsFromStrings(hole)
+		s := SeatRecord{Name: name, IsHuman: name == "YOU", StartingChips: 10000, HoleCards: holeCards, Showdown: showdown}
+		if showdown {
+			s.High, s.Low = poker.EvaluateHand(holeCards, board, rules)
+		}
+		return s
+	}
+	h := &HandHistory{
+		ID: "20250101-120000-0003", Rule: "PLS7", Rules: rules,
+		PlayedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		SmallBlind: 50, BigBlind: 100,
+		Dealer: "YOU", SmallBlindPlayer: "CPU 1", BigBlindPlayer: "CPU 2",
+		Seats: []SeatRecord{
+			seat("YOU", "Ks Js Ad", true), // A skip straight flush and a 7-5-3-2-A low.
+			seat("CPU 1", "Qs Qc 3d", true),
+			seat("CPU 2", "Th 8c 4h", false),
+		},
+		Actions: []ActionRecord{
+			{Phase: PhasePreFlop, PlayerName: "YOU", Action: ActionRaise, Amount: 300},
+			{Phase: PhasePreFlop, PlayerName: "CPU 1", Action: ActionCall, Amount: 250},
+			{Phase: PhasePreFlop, PlayerName: "CPU 2", Action: ActionFold},
+			{Phase: PhasePreFlop, PlayerName: "CPU 1", Action: ActionCall}, // Recorded as a zero call.
+			{Phase: PhaseFlop, PlayerName: "CPU 1", Action: ActionCheck},
+			{Phase: PhaseFlop, PlayerName: "YOU", Action: ActionBet, Amount: 500},
+			{Phase: PhaseFlop, PlayerName: "CPU 1", Action: ActionCall, Amount: 500},
+			{Phase: PhaseTurn, PlayerName: "CPU 1", Action: ActionCheck},
+			{Phase: PhaseTurn, PlayerName: "YOU", Action: ActionCheck},
+			{Phase: PhaseRiver, PlayerName: "CPU 1", Action: ActionBet, Amount: 9200},
+			{Phase: PhaseRiver, PlayerName: "YOU", Action: ActionCall, Amount: 9200},
+		},
+		Board:   board,
+		Results: []DistributionResult{{PlayerName: "YOU", AmountWon: 20100}},
+	}
+
+	text := FormatPokerStars(h)
+	for _, want := range []string{
+		"PokerStars Hand #202501011200000003: 3 Card Hold'em Hi/Lo Pot Limit (50/100) - 2025/01/01 12:00:00 UTC\n",
+		"Table 'PLS7 CLI' 3-max Seat #1 is the button\n",
+		"Seat 2: CPU 1 (10000 in chips)\n",
+		"CPU 1: posts small blind 50\nCPU 2: posts big blind 100\n*** HOLE CARDS ***\nDealt to YOU [Ks Js Ad]\n",
+		"YOU: raises 200 to 300\nCPU 1: calls 250\nCPU 2: folds\nCPU 1: checks\n",
+		"*** FLOP *** [9s 7s 5s]\n",
+		"*** TURN *** [9s 7s 5s] [3h]\n",
+		"*** RIVER *** [9s 7s 5s 3h] [2d]\nCPU 1: bets 9200 and is all-in\nYOU: calls 9200 and is all-in\n",
+		"*** SHOW DOWN ***\nYOU: shows [Ks Js Ad] (HI: a straight flush, Five to King; LO: 7,5,3,2,A)",
+		"YOU collected 20100 from pot\n",
+		"Total pot 20100 | Rake 0\n",
+		"Seat 1: YOU (button) showed [Ks Js Ad] and won (20100) with HI: a straight flush, Five to King",
+		"Seat 2: CPU 1 (small blind) showed [Qs Qc 3d] and lost with two pair, Queens and Threes\n",
+		"Seat 3: CPU 2 (big blind) folded before Flop\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the hand to contain %q, but got:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Uncalled") {
+		t.Errorf("Expected no uncalled bets, but got:\n%s", text)
+	}
+}
+
+func TestFormatPokerStars_UncalledBet(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(1))
+	playFoldedHand(g)
+
+	text := FormatPokerStars(g.History)
+	winner := g.History.Results[0].PlayerName
+	for _, want := range []string{
+		"Uncalled bet (50) returned to " + winner + "\n",
+		winner + " collected 100 from pot\n" + winner + ": doesn't show hand\n",
+		"Total pot 100 | Rake 0\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the hand to contain %q, but got:\n%s", want, text)
+		}
+	}
+
+	// Hands saved before the uncalled bet was recorded count it in the
+	// results, and read the same.
+	legacy := *g.History
+	legacy.Uncalled = nil
+	legacy.Results = []DistributionResult{g.History.Results[0]}
+	legacy.Results[0].AmountWon += 50
+	if got := FormatPokerStars(&legacy); got != text {
+		t.Errorf("Expected the hand saved before uncalled bets were recorded to read\n%s\nbut got\n%s", text, got)
+	}
+}
+
+func TestParsePokerStars(t *testing.T) {
+	rules := loadRule(t, "nlh.yml")
+	data, err := os.ReadFile(filepath.Join("testdata", "pokerstars_nlh.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read the hand histories: %v", err)
+	}
+	hands := SplitPokerStarsHands(string(data))
+	if len(hands) != 3 {
+		t.Fatalf("Expected 3 hands, but got %d", len(hands))
+	}
+
+	t.Run("showdown", func(t *testing.T) {
+		h, err := ParsePokerStars(hands[0], rules)
+		if err != nil {
+			t.Fatalf("Failed to parse the hand: %v", err)
+		}
+		if h.ID != "20250314-210733-PS254718394021" || h.Rule != "NLH" || h.SmallBlind != 5 || h.BigBlind != 10 {
+			t.Errorf("Expected hand 20250314-210733-PS254718394021 of NLH at 5/10 cents, but got %s of %s at %d/%d", h.ID, h.Rule, h.SmallBlind, h.BigBlind)
+		}
+		if h.Dealer != "Mr. Pink: 77" || h.SmallBlindPlayer != "ladyluck" || h.BigBlindPlayer != "Bluffalo" {
+			t.Errorf("Expected the button and blinds to be Mr. Pink: 77, ladyluck and Bluffalo, but got %s, %s and %s", h.Dealer, h.SmallBlindPlayer, h.BigBlindPlayer)
+		}
+
+		var seats []string
+		for _, seat := range h.Seats {
+			seats = append(seats, fmt.Sprintf("%s %s %d %v %v", seat.Position, seat.Name, seat.StartingChips, seat.IsHuman, seat.Showdown))
+		}
+		wantSeats := []string{"UTG Hero 1000 true true", "BTN Mr. Pink: 77 1240 false true", "SB ladyluck 435 false false", "BB Bluffalo 1055 false false"}
+		if !reflect.DeepEqual(seats, wantSeats) {
+			t.Errorf("Expected the seats %q, but got %q", wantSeats, seats)
+		}
+		if h.Seats[1].High == nil || h.Seats[1].High.Rank != poker.ThreeOfAKind {
+			t.Errorf("Expected Mr. Pink: 77's hand to be three of a kind, but got %v", h.Seats[1].High)
+		}
+
+		var actions []string
+		for _, a := range h.Actions {
+			actions = append(actions, fmt.Sprintf("%s %s %s %d", a.Phase, a.PlayerName, a.Action, a.Amount))
+		}
+		wantActions := []string{
+			"Pre-Flop Hero Raise 30", "Pre-Flop Mr. Pink: 77 Call 30", "Pre-Flop ladyluck Fold 0", "Pre-Flop Bluffalo Call 20",
+			"Flop Bluffalo Check 0", "Flop Hero Bet 60", "Flop Mr. Pink: 77 Call 60", "Flop Bluffalo Fold 0",
+			"Turn Hero Bet 150", "Turn Mr. Pink: 77 Raise 510", "Turn Hero Call 360",
+			"River Hero Bet 400", "River Mr. Pink: 77 Call 400",
+		}
+		if !reflect.DeepEqual(actions, wantActions) {
+			t.Errorf("Expected the actions %q, but got %q", wantActions, actions)
+		}
+
+		wantResults := []DistributionResult{{PlayerName: "Mr. Pink: 77", AmountWon: 1965, HandDesc: "three of a kind, Nines"}}
+		if !reflect.DeepEqual(h.Results, wantResults) {
+			t.Errorf("Expected the results %+v, but got %+v", wantResults, h.Results)
+		}
+		if got := pokerStarsCards(h.Board); got != "Ks 9d 4c 2h Jc" {
+			t.Errorf("Expected the board Ks 9d 4c 2h Jc, but got %s", got)
+		}
+	})
+
+	t.Run("uncalled bet and limps", func(t *testing.T) {
+		h, err := ParsePokerStars(hands[1], rules)
+		if err != nil {
+			t.Fatalf("Failed to parse the hand: %v", err)
+		}
+		var details []ActionDetail
+		for _, a := range h.Actions[1:4] {
+			details = append(details, a.Detail)
+		}
+		if want := []ActionDetail{ActionDetailLimp, ActionDetailComplete, ActionDetailOption}; !reflect.DeepEqual(details, want) {
+			t.Errorf("Expected the pre-flop calls and check to be %v, but got %v", want, details)
+		}
+		wantResults := []DistributionResult{{PlayerName: "Hero", AmountWon: 29, HandDesc: lastPlayerHandDesc}}
+		if !reflect.DeepEqual(h.Results, wantResults) {
+			t.Errorf("Expected the results %+v, but got %+v", wantResults, h.Results)
+		}
+		if want := (UncalledBet{PlayerName: "Hero", Amount: 20}); h.Uncalled == nil || *h.Uncalled != want {
+			t.Errorf("Expected the uncalled bet %+v, but got %+v", want, h.Uncalled)
+		}
+	})
+
+	t.Run("other games are refused", func(t *testing.T) {
+		if _, err := ParsePokerStars(hands[2], rules); err == nil || !strings.Contains(err.Error(), "Omaha Pot Limit") {
+			t.Errorf("Expected the Omaha hand to be refused, but got %v", err)
+		}
+	})
+}
+
+// TestParsePokerStars_ReadsExportedHands tests that a hand written by
+// FormatPokerStars reads back as it was played.
+func TestParsePokerStars_ReadsExportedHands(t *testing.T) {
+	rules := loadRule(t, "nlh.yml")
+	board := poker.CardsFromStrings("Kh 8d 8c 4s 2c")
+	seat := func(name, hole, position string, chips int, showdown bool) SeatRecord {
+		s := SeatRecord{Name: name, IsHuman: name == "YOU", StartingChips: chips, HoleCards: poker.CardsFromStrings(hole), Position: position, Showdown: showdown}
+		if showdown {
+			s.High, s.Low = poker.EvaluateHand(s.HoleCards, board, rules)
+		}
+		return s
+	}
+	h := &HandHistory{
+		ID: "20250101-120000-0007", HandNumber: 202501011200000007, Rule: "NLH", Rules: rules,
+		PlayedAt:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		SmallBlind: 50, BigBlind: 100,
+		Dealer: "YOU", SmallBlindPlayer: "CPU 1", BigBlindPlayer: "CPU 2",
+		Seats: []SeatRecord{
+			seat("YOU", "Ah Kd", "BTN", 10000, true),
+			seat("CPU 1", "8s 7s", "SB", 4000, true),
+			seat("CPU 2", "Qc Jc", "BB", 10000, false),
+		},
+		Actions: []ActionRecord{
+			{Phase: PhasePreFlop, PlayerName: "YOU", Position: "BTN", Action: ActionRaise, Amount: 300},
+			{Phase: PhasePreFlop, PlayerName: "CPU 1", Position: "SB", Action: ActionCall, Amount: 250},
+			{Phase: PhasePreFlop, PlayerName: "CPU 2", Position: "BB", Action: ActionCall, Amount: 200},
+			{Phase: PhaseFlop, PlayerName: "CPU 1", Position: "SB", Action: ActionBet, Amount: 3700},
+			{Phase: PhaseFlop, PlayerName: "CPU 2", Position: "BB", Action: ActionFold},
+			{Phase: PhaseFlop, PlayerName: "YOU", Position: "BTN", Action: ActionRaise, Amount: 9700},
+		},
+		Board:    board,
+		Results:  []DistributionResult{{PlayerName: "CPU 1", AmountWon: 8300, HandDesc: "three of a kind, Eights"}},
+		Uncalled: &UncalledBet{PlayerName: "YOU", Amount: 6000},
+	}
+
+	got, err := ParsePokerStars(FormatPokerStars(h), rules)
+	if err != nil {
+		t.Fatalf("Failed to parse the exported hand: %v", err)
+	}
+	want := *h
+	want.ID = "20250101-120000-PS202501011200000007"
+	// The folded hand was never shown.
+	want.Seats = append([]SeatRecord(nil), h.Seats...)
+	want.Seats[2].HoleCards = nil
+	if !reflect.DeepEqual(got, &want) {
+		t.Errorf("Expected the hand to read back as\n%+v\nbut got\n%+v", &want, got)
+	}
+}
@@ -2,7 +2,7 @@ package engine
 
 import (
 	"fmt"
-	"pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 	"sort"
 	"strings"
 
@@ -13,23 +13,60 @@ import (
 // distribution for a single player. It's used to communicate the results
 // back to the UI or logger.
 type DistributionResult struct {
-	PlayerName string // The name of the player who won a share of the pot.
-	AmountWon  int    // The total amount of chips won by the player.
-	HandDesc   string // A description of the winning hand (e.g., "High: Flush", "Low: 8-7-6-5-4").
+	PlayerName string `json:"player_name"` // The name of the player who won a share of the pot.
+	AmountWon  int    `json:"amount_won"`  // The total amount of chips won by the player.
+	HandDesc   string `json:"hand_desc"`   // A description of the winning hand (e.g., "High: Flush", "Low: 8-7-6-5-4").
 }
 
 // PotTier represents a single pot (either the main pot or a side pot) that is
 // created when one or more players are all-in. Each tier has a specific amount
 // and a list of players who are eligible to win it.
 type PotTier struct {
-	Amount  int       // The total chip amount in this specific pot tier.
-	Players []*Player // The slice of players who are eligible to win this pot tier.
-	MaxBet  int       // The maximum bet amount that players in this tier have contributed.
+	Amount    int       // The total chip amount in this specific pot tier.
+	Players   []*Player // The slice of players who are eligible to win this pot tier.
+	MaxBet    int       // The maximum bet amount that players in this tier have contributed.
+	DeadAntes int       // The dead antes included in Amount. Only the main pot holds any.
 }
 
+// showdownHand holds the evaluated high and low hands of a single player at
+// showdown. Hands are evaluated once per DistributePot call and reused across
+// every pot tier the player is eligible for.
+type showdownHand struct {
+	high *poker.HandResult
+	low  *poker.HandResult
+}
+
+// evaluateShowdownHands evaluates the hand of each given player exactly once and
+// returns the results keyed by player.
+func (g *Game) evaluateShowdownHands(players []*Player) map[*Player]showdownHand {
+	hands := make(map[*Player]showdownHand, len(players))
+	for _, p := range players {
+		high, low := g.evaluateMadeHand(p.Hand)
+		hands[p] = showdownHand{high: high, low: low}
+	}
+	return hands
+}
+
+// evaluateRunHands is evaluateShowdownHands for an extra run of the board,
+// whose hands the evaluation cache does not hold.
+func (g *Game) evaluateRunHands(players []*Player, board []poker.Card) map[*Player]showdownHand {
+	hands := make(map[*Player]showdownHand, len(players))
+	for _, p := range players {
+		high, low := poker.EvaluateHand(p.Hand, board, g.Rules)
+		hands[p] = showdownHand{high: high, low: low}
+	}
+	return hands
+}
+
+// lastPlayerHandDesc describes the hand of a player who won without a showdown.
+const lastPlayerHandDesc = "takes the pot as the last remaining player"
+
 // AwardPotToLastPlayer handles the simple scenario where all but one player have
-// folded. The remaining player wins the entire pot without a showdown.
+// folded. The remaining player takes back their uncalled bet and wins the rest
+// of the pot without a showdown.
 func (g *Game) AwardPotToLastPlayer() []DistributionResult {
+	g.logEvent(GameEvent{Type: EventPotAwarded})
+	g.returnUncalledBet()
 	var winner *Player
 	for _, p := range g.Players {
 		if p.Status != PlayerStatusFolded && p.Status != PlayerStatusEliminated {
@@ -43,9 +80,13 @@ func (g *Game) AwardPotToLastPlayer() []DistributionResult {
 		result := DistributionResult{
 			PlayerName: winner.Name,
 			AmountWon:  g.Pot,
-			HandDesc:   "takes the pot as the last remaining player",
+			HandDesc:   lastPlayerHandDesc,
 		}
 		g.Pot = 0
+		g.checkChipConservation("after pot distribution")
+		g.recordResults([]DistributionResult{result})
+		g.recordTierAudits([]PotTierAudit{{Index: 0, Amount: result.AmountWon, Awarded: result.AmountWon, DeadAntes: g.deadAntes()}})
+		g.publish(&PotAwardedEvent{Results: []DistributionResult{result}, Uncalled: g.Uncalled})
 		return []DistributionResult{result}
 	}
 	return []DistributionResult{}
@@ -56,7 +97,8 @@ func (g *Game) AwardPotToLastPlayer() []DistributionResult {
 // side pots for all-in players and High-Low split pots.
 //
 // The process is as follows:
-//  1. It identifies all players who contributed to the pot and are eligible for a showdown.
+//  1. It identifies all players who contributed to the pot and are eligible for a showdown,
+//     and returns the uncalled part of the largest bet to its bettor (see Game.Uncalled).
 //  2. It creates "bet tiers" based on the unique amounts players have bet. For example,
 //     if P1 bets 100, P2 bets 200, and P3 bets 200, the tiers are 100 and 200.
 //  3. It iterates through these tiers to build one or more `PotTier` objects (side pots).
@@ -64,11 +106,16 @@ func (g *Game) AwardPotToLastPlayer() []DistributionResult {
 //     players who bet at least that much are eligible. Subsequent tiers are built from
 //     the remaining amounts.
 //  4. It then distributes each `PotTier` individually. For each pot, it finds the best
-//     high hand and, if applicable, the best low hand among the eligible players.
+//     high hand and, if applicable, the best low hand among the eligible players. When
+//     the board is run more than once (see Game.RunItTimes), each run takes an equal
+//     share of every pot, and its winners are found on its own board.
 //  5. It splits the pot tier's amount among the high and low winners (or scoops to high
-//     if no qualifying low). It handles ties by splitting the shares further.
-//  6. Finally, it aggregates the results into a slice of DistributionResult for display.
+//     if no qualifying low). It handles ties by splitting the shares further, and gives
+//     the odd chips to the first winners clockwise from the button.
+//  6. Finally, it aggregates the results into a slice of DistributionResult for display,
+//     and checks that the chips paid out are exactly the chips that were in the pot.
 func (g *Game) DistributePot() []DistributionResult {
+	g.logEvent(GameEvent{Type: EventPotDistributed})
 	var results []DistributionResult
 	showdownPlayers := g.getShowdownPlayers()
 
@@ -76,10 +123,257 @@ func (g *Game) DistributePot() []DistributionResult {
 		return results
 	}
 
+	g.returnUncalledBet()
+	pots := g.buildPotTiers(showdownPlayers)
+
+	// Evaluate every showdown hand up front, on every board the pot is run
+	// on; each player may be eligible for several pot tiers, and evaluation
+	// is the expensive part of distribution.
+	boards := g.runBoards()
+	runs := make([]potRun, len(boards))
+	for r, board := range boards {
+		var hands map[*Player]showdownHand
+		if r == 0 {
+			hands = g.evaluateShowdownHands(showdownPlayers)
+		} else {
+			hands = g.evaluateRunHands(showdownPlayers, board)
+		}
+		runs[r] = potRun{
+			board:       board,
+			hands:       hands,
+			lowPossible: poker.LowPossible(board, g.Rules),
+			won:         make(map[string]int),
+			desc:        make(map[string]string),
+			halves:      make(map[string]*halvesWon),
+		}
+		if g.Rules.LowHand.Enabled && !runs[r].lowPossible {
+			logrus.Debugf("DistributePot: No low possible on board %v, skipping low evaluation", board)
+		}
+	}
+
+	var tierAudits []PotTierAudit
+
+	// Distribute each pot tier, starting with the main pot. When the board is
+	// run more than once, each run takes its share of every tier, and the
+	// players eligible for the tier stay the same on every run.
+	for i, pot := range pots {
+		awarded := 0
+		logrus.Debugf("Distributing PotTier: Amount: %d, MaxBet: %d, Eligible Players: %v", pot.Amount, pot.MaxBet, getPlayerNames(pot.Players))
+		for r, amount := range splitChips(pot.Amount, len(runs)) {
+			awarded += g.awardPotRun(pot.Players, amount, &runs[r])
+		}
+		tierAudits = append(tierAudits, PotTierAudit{Index: i, Amount: pot.Amount, Awarded: awarded, DeadAntes: pot.DeadAntes})
+	}
+	for _, run := range runs {
+		for name, h := range run.halves {
+			run.desc[name] = h.describe()
+		}
+	}
+
+	// Aggregate the winnings into the final result list.
+	winnerChipMap := make(map[string]int)
+	winnerHandDescMap := runs[0].desc
+	for _, run := range runs {
+		for name, amount := range run.won {
+			winnerChipMap[name] += amount
+		}
+	}
+	g.Runs = nil
+	if len(runs) > 1 {
+		winnerHandDescMap = make(map[string]string)
+		for r, run := range runs {
+			g.Runs = append(g.Runs, BoardRun{Board: run.board, Results: g.resultsBySeat(run.won, run.desc)})
+			for name, desc := range run.desc {
+				if winnerHandDescMap[name] != "" {
+					winnerHandDescMap[name] += "; "
+				}
+				winnerHandDescMap[name] += fmt.Sprintf("Run %d: %s", r+1, desc)
+			}
+		}
+	}
+	results = g.resultsBySeat(winnerChipMap, winnerHandDescMap)
+
+	g.settleInsurance(winnerChipMap)
+	g.recordResults(results)
+	g.recordRuns()
+	g.recordTierAudits(tierAudits)
+	g.Pot = 0
+	g.checkChipConservation("after pot distribution")
+	logrus.Debugf("DistributePot: Final results: %+v", results)
+	g.publish(&PotAwardedEvent{Results: results, Uncalled: g.Uncalled})
+	return results
+}
+
+// awardPotRun awards one run's share of a pot tier to the best hands on its
+// board among the eligible players, splitting it between the high and low
+// hands in High-Low games. Only the players eligible for the tier compete for
+// either half, and each half is split among its own winners. Odd chips go to
+// the high half, and among tied winners to the first clockwise from the
+// button. It records the halves each winner took in run, and returns the
+// chips awarded.
+func (g *Game) awardPotRun(players []*Player, amount int, run *potRun) int {
+	highWinners, bestHighHand := findBestHighHand(players, run.hands)
+	var lowWinners []*Player
+	var bestLowHand *poker.HandResult
+	if run.lowPossible {
+		lowWinners, bestLowHand = findBestLowHand(players, run.hands)
+	}
+	logrus.Debugf(
+		"DistributePot: High Winners: %v, Best High Hand: %s",
+		getPlayerNames(highWinners), bestHighHand,
+	)
+	logrus.Debugf(
+		"DistributePot: Low Winners: %v, Best Low Hand: %s",
+		getPlayerNames(lowWinners), bestLowHand,
+	)
+
+	awarded := 0
+	pay := func(winners []*Player, amount int) {
+		ordered := g.byOddChipOrder(winners)
+		for i, share := range splitChips(amount, len(winners)) {
+			winner := ordered[i]
+			winner.Chips += share
+			run.won[winner.Name] += share
+			awarded += share
+		}
+	}
+
+	// Check for a Hi-Lo split if the game rules allow it and there's a
+	// qualifying low hand among the players eligible for this tier.
+	if len(lowWinners) > 0 {
+		// Split the pot between high and low winners.
+		highPot, lowPot := splitHighLow(amount)
+
+		logrus.Debugf("  Split Pot: lowPot: %d, highPot: %d", lowPot, highPot)
+		pay(lowWinners, lowPot)
+		pay(highWinners, highPot)
+		for _, winner := range lowWinners {
+			run.halvesOf(winner).wonLow(bestLowHand, len(lowWinners))
+			logrus.Debugf("    %s wins a share of %d from low pot", winner.Name, lowPot)
+		}
+		for _, winner := range highWinners {
+			run.halvesOf(winner).wonHigh(bestHighHand, len(highWinners), false)
+			logrus.Debugf("    %s wins a share of %d from high pot", winner.Name, highPot)
+		}
+
+		// With the low tied two ways, each low winner takes a quarter of the
+		// tier: the one who also takes the high alone wins three quarters,
+		// and the other is quartered.
+		if len(lowWinners) == 2 {
+			for _, winner := range lowWinners {
+				h := run.halvesOf(winner)
+				if len(highWinners) == 1 && highWinners[0] == winner {
+					h.threeQuarters = true
+				} else if !h.high {
+					h.quartered = true
+				}
+			}
+		}
+	} else {
+		// If no qualifying low hand, the high hand "scoops" the entire pot.
+		pay(highWinners, amount)
+		for _, winner := range highWinners {
+			run.halvesOf(winner).wonHigh(bestHighHand, len(highWinners), true)
+			logrus.Debugf("    %s scoops a share of %d from pot", winner.Name, amount)
+		}
+	}
+	return awarded
+}
+
+// halvesWon records which halves of the pot tiers a player won on a run, and
+// how, so that their win is described once every tier has been paid.
+type halvesWon struct {
+	high, low         bool
+	highHand, lowHand *poker.HandResult
+	// highShared and lowShared are set if the player tied for a half.
+	highShared, lowShared bool
+	// scooped is set if the player won a whole tier with the high hand, no
+	// low qualifying.
+	scooped bool
+	// threeQuarters is set if the player won the high half of a tier alone
+	// and tied for its low half with one other player, and quartered if they
+	// only tied for the low half with one other player.
+	threeQuarters, quartered bool
+}
+
+// halvesOf returns what the player has won on the run so far.
+func (run *potRun) halvesOf(player *Player) *halvesWon {
+	h, ok := run.halves[player.Name]
+	if !ok {
+		h = &halvesWon{}
+		run.halves[player.Name] = h
+	}
+	return h
+}
+
+// wonHigh records a win of the high half of a tier, or of all of it if
+// whole, shared by the given number of winners.
+func (h *halvesWon) wonHigh(hand *poker.HandResult, winners int, whole bool) {
+	h.high, h.highHand = true, hand
+	h.highShared = h.highShared || winners > 1
+	h.scooped = h.scooped || whole
+}
+
+// wonLow records a win of the low half of a tier, shared by the given number
+// of winners.
+func (h *halvesWon) wonLow(hand *poker.HandResult, winners int) {
+	h.low, h.lowHand = true, hand
+	h.lowShared = h.lowShared || winners > 1
+}
+
+// describe describes the player's win, e.g. "High: Flush, ...", "Low:
+// 7-5-4-3-2-High (quartered)" or "Scoop! High: ..., Low: ...".
+func (h *halvesWon) describe() string {
+	high := fmt.Sprintf("High: %s", h.highHand)
+	if !h.low {
+		if h.scooped {
+			return high + " (Scoop)"
+		}
+		return high
+	}
+	low := fmt.Sprintf("Low: %s", lowHandDesc(h.lowHand))
+	switch {
+	case h.high && h.low && !h.highShared && !h.lowShared:
+		return fmt.Sprintf("Scoop! %s, %s", high, low)
+	case h.high && h.low && h.threeQuarters:
+		return fmt.Sprintf("%s, %s (three quarters)", high, low)
+	case h.high && h.low:
+		return fmt.Sprintf("%s, %s", high, low)
+	case h.quartered:
+		return low + " (quartered)"
+	}
+	return low
+}
+
+// lowHandDesc writes a low hand from its highest card down, e.g.
+// "8-7-4-3-2-High".
+func lowHandDesc(hand *poker.HandResult) string {
+	// An ace plays low, below the two.
+	lowValue := func(r poker.Rank) int {
+		if r == poker.Ace {
+			return 1
+		}
+		return int(r)
+	}
+	cards := append([]poker.Card(nil), hand.Cards...)
+	sort.SliceStable(cards, func(i, j int) bool { return lowValue(cards[i].Rank) > lowValue(cards[j].Rank) })
+	ranks := make([]string, len(cards))
+	for i, c := range cards {
+		ranks[i] = c.Rank.String()
+	}
+	return fmt.Sprintf("%s-High", strings.Join(ranks, "-"))
+}
+
+// buildPotTiers splits the pot into the main pot and any side pots. Each tier
+// holds the chips matched by every player who contributed at least its MaxBet,
+// and lists the showdown players eligible to win it. Dead antes, those posted
+// for the whole table and those of players who folded, are not split: they all
+// go to the main pot.
+func (g *Game) buildPotTiers(showdownPlayers []*Player) []PotTier {
 	// Create a list of all players who contributed to the pot.
 	var allContributors []*Player
 	for _, p := range g.Players {
-		if p.Status != PlayerStatusEliminated && p.TotalBetInHand > 0 {
+		if p.Status != PlayerStatusEliminated && p.tieredBet() > 0 {
 			allContributors = append(allContributors, p)
 		}
 	}
@@ -87,7 +381,7 @@ func (g *Game) DistributePot() []DistributionResult {
 	// Create a set of unique bet amounts from all contributors to define the tiers.
 	betTiers := make(map[int]bool)
 	for _, p := range allContributors {
-		betTiers[p.TotalBetInHand] = true
+		betTiers[p.tieredBet()] = true
 	}
 
 	// Create a sorted list of the bet tiers (from smallest to largest bet).
@@ -100,7 +394,7 @@ func (g *Game) DistributePot() []DistributionResult {
 	var pots []PotTier
 	lastBet := 0
 
-	logrus.Debugf("DistributePot: Initial Pot: %d, All Contributors: %v, Bet Tiers: %v", g.Pot, getPlayerNames(allContributors), sortedTiers)
+	logrus.Debugf("buildPotTiers: Initial Pot: %d, All Contributors: %v, Bet Tiers: %v", g.Pot, getPlayerNames(allContributors), sortedTiers)
 
 	// Build the main and side pots based on the bet tiers.
 	for _, tierBet := range sortedTiers {
@@ -112,7 +406,7 @@ func (g *Game) DistributePot() []DistributionResult {
 		// Count players who contributed at least this much.
 		numPlayersInTier := 0
 		for _, p := range allContributors {
-			if p.TotalBetInHand >= tierBet {
+			if p.tieredBet() >= tierBet {
 				numPlayersInTier++
 			}
 		}
@@ -121,12 +415,17 @@ func (g *Game) DistributePot() []DistributionResult {
 		// Find which of the showdown players are eligible for this tier.
 		var eligiblePlayers []*Player
 		for _, sp := range showdownPlayers {
-			if sp.TotalBetInHand >= tierBet {
+			if sp.tieredBet() >= tierBet {
 				eligiblePlayers = append(eligiblePlayers, sp)
 			}
 		}
 
-		if tierAmount > 0 && len(eligiblePlayers) > 0 {
+		if tierAmount > 0 && len(eligiblePlayers) == 0 && len(pots) > 0 {
+			// Every player who bet this much has folded, e.g. a small blind
+			// who posted more than the all-in players and then folded. Their
+			// chips are dead money for the last pot still contested.
+			pots[len(pots)-1].Amount += tierAmount
+		} else if tierAmount > 0 && len(eligiblePlayers) > 0 {
 			pots = append(pots, PotTier{
 				Amount:  tierAmount,
 				Players: eligiblePlayers,
@@ -136,97 +435,15 @@ func (g *Game) DistributePot() []DistributionResult {
 				"  New PotTier created: Amount: %d, MaxBet: %d, Players: %v",
 				tierAmount, tierBet, getPlayerNames(eligiblePlayers),
 			)
-			if len(eligiblePlayers) == 1 {
-				logrus.Warnf(
-					"  Single player %s eligible for PotTier with amount %d", eligiblePlayers[0].Name, tierAmount,
-				)
-			}
 		}
 		lastBet = tierBet
 	}
 
-	winnerChipMap := make(map[string]int)
-	winnerHandDescMap := make(map[string]string)
-
-	// Distribute each pot tier, starting with the main pot.
-	for _, pot := range pots {
-		logrus.Debugf("Distributing PotTier: Amount: %d, MaxBet: %d, Eligible Players: %v", pot.Amount, pot.MaxBet, getPlayerNames(pot.Players))
-		highWinners, bestHighHand := findBestHighHand(pot.Players, g)
-		lowWinners, bestLowHand := findBestLowHand(pot.Players, g)
-		logrus.Debugf(
-			"DistributePot: High Winners: %v, Best High Hand: %s",
-			getPlayerNames(highWinners), bestHighHand,
-		)
-		logrus.Debugf(
-			"DistributePot: Low Winners: %v, Best Low Hand: %s",
-			getPlayerNames(lowWinners), bestLowHand,
-		)
-
-		// Check for a Hi-Lo split if the game rules allow it and there's a qualifying low hand.
-		if g.Rules.LowHand.Enabled && len(lowWinners) > 0 {
-			// Split the pot between high and low winners.
-			lowPot := pot.Amount / 2
-			highPot := pot.Amount - lowPot
-
-			logrus.Debugf("  Split Pot: lowPot: %d, highPot: %d", lowPot, highPot)
-
-			// Distribute the low half of the pot.
-			lowShare := lowPot / len(lowWinners)
-			var lowHandRanks []string
-			for _, c := range bestLowHand.Cards {
-				lowHandRanks = append(lowHandRanks, c.Rank.String())
-			}
-			if len(lowHandRanks) > 0 && lowHandRanks[0] == poker.Ace.String() {
-				lowHandRanks = append(lowHandRanks[1:], lowHandRanks[0])
-			}
-			lowHandDesc := fmt.Sprintf("Low: %s-High", strings.Join(lowHandRanks, "-"))
-
-			for _, winner := range lowWinners {
-				winner.Chips += lowShare
-				winnerChipMap[winner.Name] += lowShare
-				winnerHandDescMap[winner.Name] = lowHandDesc
-				logrus.Debugf("    %s wins %d from low pot", winner.Name, lowShare)
-			}
-
-			// Distribute the high half of the pot.
-			highShare := highPot / len(highWinners)
-			highHandDesc := fmt.Sprintf("High: %s", bestHighHand.String())
-			for _, winner := range highWinners {
-				winner.Chips += highShare
-				winnerChipMap[winner.Name] += highShare
-				// If a player won both high and low, they "scoop" the pot.
-				if desc, exists := winnerHandDescMap[winner.Name]; exists && strings.HasPrefix(desc, "Low") {
-					winnerHandDescMap[winner.Name] = fmt.Sprintf("Scoop! %s, %s", highHandDesc, desc)
-				} else {
-					winnerHandDescMap[winner.Name] = highHandDesc
-				}
-				logrus.Debugf("    %s wins %d from high pot", winner.Name, highShare)
-			}
-		} else {
-			// If no qualifying low hand, the high hand "scoops" the entire pot.
-			highShare := pot.Amount / len(highWinners)
-			highHandDesc := fmt.Sprintf("High: %s (Scoop)", bestHighHand.String())
-			for _, winner := range highWinners {
-				winner.Chips += highShare
-				winnerChipMap[winner.Name] += highShare
-				winnerHandDescMap[winner.Name] = highHandDesc
-				logrus.Debugf("    %s scoops %d from pot", winner.Name, highShare)
-			}
-		}
-	}
-
-	// Aggregate the winnings into the final result list.
-	for name, amount := range winnerChipMap {
-		results = append(results, DistributionResult{
-			PlayerName: name,
-			AmountWon:  amount,
-			HandDesc:   winnerHandDescMap[name],
-		})
+	if dead := g.deadAntes(); dead > 0 && len(pots) > 0 {
+		pots[0].Amount += dead
+		pots[0].DeadAntes = dead
 	}
-
-	g.Pot = 0
-	logrus.Debugf("DistributePot: Final results: %+v", results)
-	return results
+	return pots
 }
 
 // getShowdownPlayers returns a slice of players who are still active in the
@@ -242,18 +459,18 @@ func (g *Game) getShowdownPlayers() []*Player {
 }
 
 // findBestHighHand iterates through a list of players and determines who has the
-// best high hand according to the game's rules. It returns the winning player(s)
-// (in case of a tie) and the best hand result.
-func findBestHighHand(players []*Player, g *Game) (winners []*Player, bestHand *poker.HandResult) {
+// best high hand using the pre-evaluated showdown hands. It returns the winning
+// player(s) (in case of a tie) and the best hand result.
+func findBestHighHand(players []*Player, hands map[*Player]showdownHand) (winners []*Player, bestHand *poker.HandResult) {
 	for _, p := range players {
-		highHand, _ := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
+		highHand := hands[p].high
 		if highHand == nil {
 			continue
 		}
-		if bestHand == nil || compareHandResults(highHand, bestHand) == 1 {
+		if bestHand == nil || poker.CompareHigh(highHand, bestHand) > 0 {
 			bestHand = highHand
 			winners = []*Player{p}
-		} else if compareHandResults(highHand, bestHand) == 0 {
+		} else if poker.CompareHigh(highHand, bestHand) == 0 {
 			winners = append(winners, p)
 		}
 	}
@@ -261,47 +478,25 @@ func findBestHighHand(players []*Player, g *Game) (winners []*Player, bestHand *
 }
 
 // findBestLowHand iterates through a list of players and determines who has the
-// best qualifying low hand. It returns the winning player(s) and the best low hand.
-// If no player has a qualifying low hand, it returns nil.
-func findBestLowHand(players []*Player, g *Game) (winners []*Player, bestHand *poker.HandResult) {
+// best qualifying low hand using the pre-evaluated showdown hands. It returns the
+// winning player(s) and the best low hand. If no player has a qualifying low hand,
+// it returns nil.
+func findBestLowHand(players []*Player, hands map[*Player]showdownHand) (winners []*Player, bestHand *poker.HandResult) {
 	for _, p := range players {
-		_, lowHand := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
+		lowHand := hands[p].low
 		if lowHand == nil {
 			continue
 		}
-		// For low hands, a lower result is better.
-		if bestHand == nil || compareHandResults(lowHand, bestHand) == -1 {
+		if bestHand == nil || poker.CompareLow(lowHand, bestHand) > 0 {
 			bestHand = lowHand
 			winners = []*Player{p}
-		} else if compareHandResults(lowHand, bestHand) == 0 {
+		} else if poker.CompareLow(lowHand, bestHand) == 0 {
 			winners = append(winners, p)
 		}
 	}
 	return
 }
 
-// compareHandResults compares two hand results to determine which is stronger.
-// It first compares by HandRank, then by HighValues for tie-breaking.
-// Returns 1 if h1 > h2, -1 if h1 < h2, 0 if h1 == h2.
-func compareHandResults(h1, h2 *poker.HandResult) int {
-	if h1.Rank > h2.Rank {
-		return 1
-	}
-	if h1.Rank < h2.Rank {
-		return -1
-	}
-	// Ranks are the same, compare kickers.
-	for i := 0; i < len(h1.HighValues); i++ {
-		if h1.HighValues[i] > h2.HighValues[i] {
-			return 1
-		}
-		if h1.HighValues[i] < h2.HighValues[i] {
-			return -1
-		}
-	}
-	return 0 // Hands are identical.
-}
-
 // getPlayerNames is a helper function for logging, returning a slice of player names.
 func getPlayerNames(players []*Player) []string {
 	names := make([]string, len(players))
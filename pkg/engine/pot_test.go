@@ -2,13 +2,24 @@ package engine
 
 import (
 	"embed"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"os"
 	"path/filepath"
-	"pls7-cli/internal/config"
-	"pls7-cli/internal/util"
-	"pls7-cli/pkg/poker"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/sirupsen/logrus"
 )
 
+// initDebugLogger shows the debug logs in tests, as the CLI's dev mode does.
+func initDebugLogger() {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetLevel(logrus.DebugLevel)
+}
+
 //go:embed testdata
 var rulesFS embed.FS
 
@@ -62,7 +73,7 @@ func TestAwardPotToLastPlayer_SkipsEliminatedPlayers(t *testing.T) {
 // TestDistributePot_SidePots tests the pot distribution logic with multiple all-in players,
 // which should create side pots.
 func TestDistributePot_SidePots(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	// Scenario: 3 players go all-in with different stack sizes.
 	// YOU (2000) has the best hand.
@@ -101,10 +112,14 @@ func TestDistributePot_SidePots(t *testing.T) {
 	// Expected distribution:
 	// Main Pot (2000 * 3 = 6000) goes to YOU.
 	// Side Pot 1 ((5000-2000) * 2 = 6000) goes to CPU1.
-	// Side Pot 2 ((10000-5000) * 1 = 5000) is returned to CPU2.
+	// CPU2's uncalled 5000 (10000-5000) is returned to it before the pot is
+	// split, so no side pot has a single player.
 
-	if len(results) != 3 {
-		t.Fatalf("Expected 3 distribution results, but got %d", len(results))
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 distribution results, but got %d", len(results))
+	}
+	if want := (UncalledBet{PlayerName: "CPU2", Amount: 5000}); g.Uncalled == nil || *g.Uncalled != want {
+		t.Errorf("Expected the uncalled bet %+v, but got %+v", want, g.Uncalled)
 	}
 
 	// Check chip distribution
@@ -122,7 +137,7 @@ func TestDistributePot_SidePots(t *testing.T) {
 // TestDistributePot_FoldedPlayerBetNotLost tests that a folded player's contribution to the pot
 // is not lost during distribution.
 func TestDistributePot_FoldedPlayerBetNotLost(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	// Scenario: 3 players. CPU2 bets 1000 and folds. YOU and B go to showdown with 3000 each.
 	// The total pot should be 7000. YOU has the winning hand.
@@ -174,10 +189,95 @@ func TestDistributePot_FoldedPlayerBetNotLost(t *testing.T) {
 	}
 }
 
+func TestDistributePot_FoldedBlindAboveAllIns(t *testing.T) {
+	// CPU2 posted a 1,500 small blind and folded to two smaller all-ins: the
+	// 500 nobody matched is dead money for the pot the all-in players contest.
+	rules := loadRule(t, "nlh.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.Players[0].TotalBetInHand, g.Players[0].Status = 1000, PlayerStatusAllIn
+	g.Players[0].Hand = poker.CardsFromStrings("As Ad")
+	g.Players[1].TotalBetInHand, g.Players[1].Status = 1000, PlayerStatusAllIn
+	g.Players[1].Hand = poker.CardsFromStrings("Ks Kd")
+	g.Players[2].TotalBetInHand, g.Players[2].Status = 1500, PlayerStatusFolded
+	g.Pot = 3500
+
+	pots := g.buildPotTiers(g.getShowdownPlayers())
+	if len(pots) != 1 || pots[0].Amount != 3500 {
+		t.Errorf("Expected a single pot of 3,500, but got %+v", pots)
+	}
+}
+
+// TestDistributePot_ReturnsUncalledBet tests that the part of the largest bet
+// nobody matched, including a player who folded, goes back to the bettor
+// instead of making a side pot of its own.
+func TestDistributePot_ReturnsUncalledBet(t *testing.T) {
+	rules := loadRule(t, "nlh.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.StartNewHand()
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h Jc 3s")
+	g.Players[0].Chips, g.Players[0].TotalBetInHand, g.Players[0].Status = 7000, 3000, PlayerStatusPlaying
+	g.Players[0].Hand = poker.CardsFromStrings("Ks Kd")
+	g.Players[1].Chips, g.Players[1].TotalBetInHand, g.Players[1].Status = 0, 1200, PlayerStatusAllIn
+	g.Players[1].Hand = poker.CardsFromStrings("As Ad")
+	g.Players[2].Chips, g.Players[2].TotalBetInHand, g.Players[2].Status = 8000, 2000, PlayerStatusFolded
+	g.Pot = 6200
+	g.TotalInitialChips = 7000 + 8000 + g.Pot
+
+	results := g.DistributePot()
+	won := make(map[string]int)
+	for _, r := range results {
+		won[r.PlayerName] = r.AmountWon
+	}
+	// CPU1 wins the 3,600 main pot, and YOU the 1,600 side pot CPU2 folded
+	// out of. The 1,000 of YOU's bet CPU2 did not match goes back.
+	if won["YOU"] != 1600 || won["CPU1"] != 3600 {
+		t.Errorf("Expected YOU to win 1,600 and CPU1 3,600, but got %+v", results)
+	}
+	if want := (UncalledBet{PlayerName: "YOU", Amount: 1000}); g.History.Uncalled == nil || *g.History.Uncalled != want {
+		t.Errorf("Expected the history to record the uncalled bet %+v, but got %+v", want, g.History.Uncalled)
+	}
+	if g.Players[0].Chips != 9600 || g.Players[0].TotalBetInHand != 2000 {
+		t.Errorf("Expected YOU to have 9,600 chips and 2,000 in the pot, but got %d and %d", g.Players[0].Chips, g.Players[0].TotalBetInHand)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected no chip lost, but got %v", g.ChipViolations)
+	}
+}
+
+func TestDistributePot_FoldedAntesAreDeadMoney(t *testing.T) {
+	// YOU is all-in for 50 of a 100 ante. CPU1 folded, leaving its whole ante
+	// in the main pot, not split with the side pot as a live bet would be.
+	rules := loadRule(t, "nlh.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.Players[0].TotalBetInHand, g.Players[0].Ante, g.Players[0].Status = 50, 50, PlayerStatusAllIn
+	g.Players[0].Hand = poker.CardsFromStrings("As Ad")
+	g.Players[1].TotalBetInHand, g.Players[1].Ante, g.Players[1].Status = 100, 100, PlayerStatusFolded
+	g.Players[2].TotalBetInHand, g.Players[2].Ante = 1100, 100
+	g.Players[2].Hand = poker.CardsFromStrings("Ks Kd")
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h Jc 3s")
+	g.Pot = 1250
+
+	pots := g.buildPotTiers(g.getShowdownPlayers())
+	if len(pots) != 2 || pots[0].Amount != 200 || pots[0].DeadAntes != 100 || pots[1].Amount != 1050 {
+		t.Fatalf("Expected a 200 main pot with 100 in dead antes and a 1,050 side pot, but got %+v", pots)
+	}
+	results := g.DistributePot()
+	won := make(map[string]int)
+	for _, r := range results {
+		won[r.PlayerName] = r.AmountWon
+	}
+	if won["YOU"] != 200 || won["CPU2"] != 0 {
+		t.Errorf("Expected YOU to win the 200 main pot, but got %+v", results)
+	}
+	if g.Uncalled == nil || g.Uncalled.PlayerName != "CPU2" || g.Uncalled.Amount != 1050 {
+		t.Errorf("Expected CPU2's uncalled 1,050 to be returned, but got %+v", g.Uncalled)
+	}
+}
+
 // TestDistributePot_ComplexSidePotAndAllIn reproduces the specific bug found in the log file.
 // This test covers a complex scenario with multiple all-ins, side pots, and a call.
 func TestDistributePot_ComplexSidePotAndAllIn(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	// Scenario setup based on the bug log
 	playerNames := []string{"YOU", "CPU 1", "CPU 4"}
@@ -250,7 +350,7 @@ func TestDistributePot_ComplexSidePotAndAllIn(t *testing.T) {
 // TestDistributePot_PLO8_HiLoSplit tests the pot distribution for a PLO8 game
 // where one player wins the high hand and another wins the low hand.
 func TestDistributePot_PLO8_HiLoSplit(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	// Scenario: 3 players, PLO8 rules.
 	// YOU wins High (Full House), CPU1 wins Low (8,7,4,3,2).
@@ -315,3 +415,155 @@ func TestDistributePot_PLO8_HiLoSplit(t *testing.T) {
 		t.Errorf("Expected pot to be 0 after distribution, but got %d", g.Pot)
 	}
 }
+
+// TestEvaluateShowdownHands tests that each showdown player's hand is evaluated once
+// and that the cached results match a direct evaluation.
+func TestEvaluateShowdownHands(t *testing.T) {
+	playerNames := []string{"YOU", "CPU1", "CPU2"}
+	rules := loadRule(t, "plo8.yml")
+	g := NewGame(playerNames, 10000, 0, 0, DifficultyMedium, rules, true, false, 0)
+
+	g.Players[0].Hand = poker.CardsFromStrings("Kh 4d 5h 6h")
+	g.Players[1].Hand = poker.CardsFromStrings("2c 3c 9s Ts")
+	g.Players[2].Hand = poker.CardsFromStrings("Qc Qd Js Th")
+	g.CommunityCards = poker.CardsFromStrings("Kc Kd 8s 7d 4c")
+
+	hands := g.evaluateShowdownHands(g.Players)
+	if len(hands) != len(g.Players) {
+		t.Fatalf("Expected %d evaluated hands, but got %d", len(g.Players), len(hands))
+	}
+
+	for _, p := range g.Players {
+		expectedHigh, expectedLow := poker.EvaluateHand(p.Hand, g.CommunityCards, g.Rules)
+		cached := hands[p]
+		if !reflect.DeepEqual(cached.high, expectedHigh) {
+			t.Errorf("%s: expected high hand %v, but got %v", p.Name, expectedHigh, cached.high)
+		}
+		if !reflect.DeepEqual(cached.low, expectedLow) {
+			t.Errorf("%s: expected low hand %v, but got %v", p.Name, expectedLow, cached.low)
+		}
+	}
+}
+
+// TestDistributePot_ConservesChips tests that random showdowns, with side
+// pots, folded money, odd amounts and split pots, pay out every chip in the
+// pot, no more and no fewer.
+func TestDistributePot_ConservesChips(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, ruleFile := range []string{"plo8.yml", "pls.yml", "nlh.yml"} {
+		rules := loadRule(t, ruleFile)
+		for hand := 0; hand < 300; hand++ {
+			names := []string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}[:2+r.Intn(5)]
+			g := NewGame(names, 0, 0, 0, DifficultyMedium, rules, true, false, 0)
+			g.DealerPos = r.Intn(len(names))
+			deck := poker.NewDeck()
+			deck.Shuffle(r)
+			for i, p := range g.Players {
+				for range rules.HoleCards.Count {
+					card, _ := deck.Deal()
+					p.Hand = append(p.Hand, card)
+				}
+				p.Chips = r.Intn(1000)
+				p.TotalBetInHand = 1 + r.Intn(500)
+				g.Pot += p.TotalBetInHand
+				g.TotalInitialChips += p.Chips + p.TotalBetInHand
+				// Everyone but the first two may have folded.
+				p.Status = PlayerStatusAllIn
+				if i >= 2 && r.Intn(3) == 0 {
+					p.Status = PlayerStatusFolded
+				}
+			}
+			for range 5 {
+				card, _ := deck.Deal()
+				g.CommunityCards = append(g.CommunityCards, card)
+			}
+			pot := g.Pot
+
+			paid := 0
+			for _, result := range g.DistributePot() {
+				paid += result.AmountWon
+			}
+			if g.Uncalled != nil {
+				paid += g.Uncalled.Amount
+			}
+			if paid != pot || g.Pot != 0 || len(g.ChipViolations) != 0 {
+				t.Fatalf("%s hand %d: paid %d of a pot of %d, leaving %d, with violations %v",
+					ruleFile, hand, paid, pot, g.Pot, g.ChipViolations)
+			}
+		}
+	}
+}
+
+// TestDistributePot_PLO8_Quartered tests that a player who takes the high
+// alone and ties for the low wins three quarters of the pot, and that the
+// other low winner is quartered.
+func TestDistributePot_PLO8_Quartered(t *testing.T) {
+	rules := loadRule(t, "plo8.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 0, 0, DifficultyMedium, rules, true, false, 0)
+	g.Players[0].Hand = poker.CardsFromStrings("Kh 4d 2d 3d") // Kings full of fours, and 8-7-4-3-2
+	g.Players[1].Hand = poker.CardsFromStrings("2c 3c 9s Ts") // 8-7-4-3-2
+	g.Players[2].Hand = poker.CardsFromStrings("Qc Qd Js Th")
+	for _, p := range g.Players {
+		p.TotalBetInHand = 4000
+		p.Status = PlayerStatusAllIn
+	}
+	g.CommunityCards = poker.CardsFromStrings("Kc Kd 8s 7d 4c")
+	g.Pot = 12000
+	g.TotalInitialChips = 12000
+
+	results := g.DistributePot()
+
+	if g.Players[0].Chips != 9000 || g.Players[1].Chips != 3000 || g.Players[2].Chips != 0 {
+		t.Errorf("Expected 9000, 3000 and 0, but got %d, %d and %d", g.Players[0].Chips, g.Players[1].Chips, g.Players[2].Chips)
+	}
+	want := map[string]string{
+		"YOU":  "High: Full House, " + g.Players[0].Hand[0].String(),
+		"CPU1": "Low: 8-7-4-3-2-High (quartered)",
+	}
+	for _, r := range results {
+		if !strings.HasPrefix(r.HandDesc, want[r.PlayerName]) {
+			t.Errorf("Expected %s to win with %q, but got %q", r.PlayerName, want[r.PlayerName], r.HandDesc)
+		}
+	}
+	if desc := results[0].HandDesc; !strings.HasSuffix(desc, ", Low: 8-7-4-3-2-High (three quarters)") {
+		t.Errorf("Expected YOU to win three quarters with the high and a share of the low, but got %q", desc)
+	}
+}
+
+// TestDistributePot_PLO8_LowPerTier tests that each pot tier's low half goes
+// to the best low among the players eligible for that tier only, and that a
+// player's wins in several tiers are described together.
+func TestDistributePot_PLO8_LowPerTier(t *testing.T) {
+	rules := loadRule(t, "plo8.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 0, 0, 0, DifficultyMedium, rules, true, false, 0)
+	g.Players[0].Hand = poker.CardsFromStrings("Kh 4d Jh Js") // Kings full of fours, no low
+	g.Players[1].Hand = poker.CardsFromStrings("2c 3c 9h Tc") // 8-7-4-3-2
+	g.Players[2].Hand = poker.CardsFromStrings("Ah 2h 9s Ts") // 8-7-4-2-A, all in for the main pot only
+	g.Players[0].TotalBetInHand = 5000
+	g.Players[1].TotalBetInHand = 5000
+	g.Players[2].TotalBetInHand = 1000
+	for _, p := range g.Players {
+		p.Status = PlayerStatusAllIn
+	}
+	g.CommunityCards = poker.CardsFromStrings("Kc Kd 8s 7d 4c")
+	g.Pot = 11000
+	g.TotalInitialChips = 11000
+
+	results := g.DistributePot()
+
+	// Main pot of 3000: 1500 high to YOU, 1500 low to CPU2. Side pot of
+	// 8000: 4000 high to YOU, 4000 low to CPU1, whose low is the best left.
+	if g.Players[0].Chips != 5500 || g.Players[1].Chips != 4000 || g.Players[2].Chips != 1500 {
+		t.Errorf("Expected 5500, 4000 and 1500, but got %d, %d and %d", g.Players[0].Chips, g.Players[1].Chips, g.Players[2].Chips)
+	}
+	want := map[string]string{
+		"YOU":  "High: Full House, " + g.Players[0].Hand[0].String(),
+		"CPU1": "Low: 8-7-4-3-2-High",
+		"CPU2": "Low: 8-7-4-2-A-High",
+	}
+	for _, r := range results {
+		if !strings.HasPrefix(r.HandDesc, want[r.PlayerName]) || strings.Contains(r.HandDesc, "Scoop") {
+			t.Errorf("Expected %s to win with %q, but got %q", r.PlayerName, want[r.PlayerName], r.HandDesc)
+		}
+	}
+}
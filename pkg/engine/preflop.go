@@ -0,0 +1,390 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position names used in hand histories. The seats between the big blind and
+// the button are named by middlePositionNames.
+const (
+	PositionButton     = "BTN"
+	PositionSmallBlind = "SB"
+	PositionBigBlind   = "BB"
+)
+
+// seatPositions names the table position of every player dealt into the hand.
+// The last seat before the small blind is the button, and the last before the
+// big blind when the small blind is dead (sbPos is -1); heads-up, only the
+// blinds remain.
+func (g *Game) seatPositions(sbPos, bbPos int) map[string]string {
+	// Seats after the big blind, in order, ending with the small blind if any.
+	var order []string
+	for pos := g.FindNextActivePlayer(bbPos); pos != bbPos && pos != sbPos; pos = g.FindNextActivePlayer(pos) {
+		order = append(order, g.Players[pos].Name)
+	}
+	sb := ""
+	if sbPos >= 0 {
+		sb = g.Players[sbPos].Name
+	}
+	return tablePositions(order, sb, g.Players[bbPos].Name)
+}
+
+// tablePositions names the positions of the blinds, sb and bb, and of the
+// players in order, the seats from the one after the big blind to the one
+// before the small blind (or before the big blind when sb is empty).
+func tablePositions(order []string, sb, bb string) map[string]string {
+	positions := make(map[string]string, len(order)+2)
+	positions[bb] = PositionBigBlind
+	if sb != "" {
+		positions[sb] = PositionSmallBlind
+	}
+	if len(order) > 0 {
+		positions[order[len(order)-1]] = PositionButton
+		middle := order[:len(order)-1]
+		for i, name := range middlePositionNames(len(middle)) {
+			positions[middle[i]] = name
+		}
+	}
+	return positions
+}
+
+// middlePositionNames names n seats between the big blind and the button, in
+// order of play: UTG first, then UTG+1.., MP, HJ, and the cutoff (CO) last.
+func middlePositionNames(n int) []string {
+	tail := []string{"CO", "HJ", "MP"}
+	names := make([]string, n)
+	for i := range names {
+		fromEnd := n - 1 - i
+		switch {
+		case i == 0:
+			names[i] = "UTG"
+		case fromEnd < len(tail):
+			names[i] = tail[fromEnd]
+		default:
+			names[i] = fmt.Sprintf("UTG+%d", i)
+		}
+	}
+	return names
+}
+
+// PreFlopLine describes the pre-flop line one player took in one hand. Each
+// pattern has an opportunity flag as well, so frequencies can be computed
+// over the spots where the pattern was possible.
+type PreFlopLine struct {
+	// ColdCallOpportunity is set when the player, outside the blinds, first
+	// acted facing a raise. ColdCalled is set if they called it.
+	ColdCallOpportunity bool
+	ColdCalled          bool
+	// SqueezeOpportunity is set when the player first acted facing an open
+	// raise and at least one caller. Squeezed is set if they re-raised.
+	SqueezeOpportunity bool
+	Squeezed           bool
+	// LimpReraiseOpportunity is set when a player who limped faced a raise.
+	// LimpReraised is set if they re-raised it.
+	LimpReraiseOpportunity bool
+	LimpReraised           bool
+	// LimpOpportunity is set when the player, outside the blinds, first acted
+	// with nobody having raised. Limped is set if they just called.
+	LimpOpportunity bool
+	Limped          bool
+	// CompleteOpportunity is set when the small blind first acted with nobody
+	// having raised. Completed is set if they called the rest of the big blind.
+	CompleteOpportunity bool
+	Completed           bool
+	// OptionOpportunity is set when the big blind got its option, with nobody
+	// having raised. CheckedOption is set if they checked instead of raising.
+	OptionOpportunity bool
+	CheckedOption     bool
+}
+
+// ClassifyPreFlopLines recognizes the cold calls, squeezes, limp-reraises,
+// limps, small blind completions and big blind option checks in a hand from
+// its ordered actions. Actions on later streets are ignored.
+// The big blind counts as the opening bet, so the first raise is the open.
+func ClassifyPreFlopLines(actions []ActionRecord) map[string]PreFlopLine {
+	lines := make(map[string]PreFlopLine)
+	acted := make(map[string]bool)
+	limped := make(map[string]bool)
+	raises, callersSinceRaise := 0, 0
+
+	for _, a := range actions {
+		if a.Phase != PhasePreFlop {
+			continue
+		}
+		line := lines[a.PlayerName]
+		isCall := a.Action == ActionCall && a.Amount > 0
+		isRaise := a.Action == ActionRaise || a.Action == ActionBet
+		inBlinds := a.Position == PositionSmallBlind || a.Position == PositionBigBlind
+
+		if !acted[a.PlayerName] {
+			if raises == 0 && isCall {
+				limped[a.PlayerName] = true
+			}
+			if raises == 0 {
+				switch a.Position {
+				case PositionSmallBlind:
+					line.CompleteOpportunity = true
+					line.Completed = a.Detail == ActionDetailComplete
+				case PositionBigBlind:
+					line.OptionOpportunity = true
+					line.CheckedOption = a.Detail == ActionDetailOption
+				default:
+					line.LimpOpportunity = true
+					line.Limped = a.Detail == ActionDetailLimp
+				}
+			}
+			if raises > 0 && !inBlinds {
+				line.ColdCallOpportunity = true
+				line.ColdCalled = isCall
+			}
+			if raises == 1 && callersSinceRaise > 0 {
+				line.SqueezeOpportunity = true
+				line.Squeezed = isRaise
+			}
+		} else if limped[a.PlayerName] && raises > 0 && !line.LimpReraiseOpportunity {
+			line.LimpReraiseOpportunity = true
+			line.LimpReraised = isRaise
+		}
+
+		switch {
+		case isRaise:
+			raises++
+			callersSinceRaise = 0
+		case isCall && raises > 0:
+			callersSinceRaise++
+		}
+		acted[a.PlayerName] = true
+		lines[a.PlayerName] = line
+	}
+	return lines
+}
+
+// PreFlopStats accumulates a player's pre-flop lines over many hands.
+type PreFlopStats struct {
+	ColdCallOpportunities    int `json:"cold_call_opportunities"`
+	ColdCalls                int `json:"cold_calls"`
+	SqueezeOpportunities     int `json:"squeeze_opportunities"`
+	Squeezes                 int `json:"squeezes"`
+	LimpReraiseOpportunities int `json:"limp_reraise_opportunities"`
+	LimpReraises             int `json:"limp_reraises"`
+	LimpOpportunities        int `json:"limp_opportunities"`
+	Limps                    int `json:"limps"`
+	CompleteOpportunities    int `json:"complete_opportunities"`
+	Completes                int `json:"completes"`
+	OptionOpportunities      int `json:"option_opportunities"`
+	OptionChecks             int `json:"option_checks"`
+}
+
+// Record adds one hand's line to the statistics.
+func (s *PreFlopStats) Record(line PreFlopLine) {
+	if line.ColdCallOpportunity {
+		s.ColdCallOpportunities++
+		if line.ColdCalled {
+			s.ColdCalls++
+		}
+	}
+	if line.SqueezeOpportunity {
+		s.SqueezeOpportunities++
+		if line.Squeezed {
+			s.Squeezes++
+		}
+	}
+	if line.LimpReraiseOpportunity {
+		s.LimpReraiseOpportunities++
+		if line.LimpReraised {
+			s.LimpReraises++
+		}
+	}
+	if line.LimpOpportunity {
+		s.LimpOpportunities++
+		if line.Limped {
+			s.Limps++
+		}
+	}
+	if line.CompleteOpportunity {
+		s.CompleteOpportunities++
+		if line.Completed {
+			s.Completes++
+		}
+	}
+	if line.OptionOpportunity {
+		s.OptionOpportunities++
+		if line.CheckedOption {
+			s.OptionChecks++
+		}
+	}
+}
+
+// ColdCallFrequency returns how often the player cold-calls a raise.
+func (s PreFlopStats) ColdCallFrequency() float64 {
+	return ratio(s.ColdCalls, s.ColdCallOpportunities)
+}
+
+// SqueezeFrequency returns how often the player squeezes an open raise and callers.
+func (s PreFlopStats) SqueezeFrequency() float64 {
+	return ratio(s.Squeezes, s.SqueezeOpportunities)
+}
+
+// LimpReraiseFrequency returns how often the player re-raises after limping.
+func (s PreFlopStats) LimpReraiseFrequency() float64 {
+	return ratio(s.LimpReraises, s.LimpReraiseOpportunities)
+}
+
+// LimpFrequency returns how often the player limps when nobody has raised.
+func (s PreFlopStats) LimpFrequency() float64 {
+	return ratio(s.Limps, s.LimpOpportunities)
+}
+
+// CompleteFrequency returns how often the player completes from the small blind.
+func (s PreFlopStats) CompleteFrequency() float64 {
+	return ratio(s.Completes, s.CompleteOpportunities)
+}
+
+// OptionCheckFrequency returns how often the player checks their option in the
+// big blind rather than raising.
+func (s PreFlopStats) OptionCheckFrequency() float64 {
+	return ratio(s.OptionChecks, s.OptionOpportunities)
+}
+
+// blindSeats returns the positions of the small and big blinds in the current
+// hand. The small blind's is -1 when it is dead.
+func (g *Game) blindSeats() (sbPos, bbPos int) {
+	return g.SmallBlindPos, g.BigBlindPos
+}
+
+// unraisedPreFlop reports whether a hand is being played pre-flop and nobody
+// has raised the big blind, or the straddle, yet.
+func (g *Game) unraisedPreFlop() bool {
+	return g.handInProgress && g.Phase == PhasePreFlop && g.BetToCall == g.openingBet()
+}
+
+// CanComplete reports whether the player is the small blind facing an
+// unraised big blind, and so may complete the half-bet. Facing a straddle,
+// the small blind calls like anyone else.
+func (g *Game) CanComplete(player *Player) bool {
+	sbPos, _ := g.blindSeats()
+	return g.Straddler == nil && g.unraisedPreFlop() && sbPos >= 0 && g.Players[sbPos] == player && player.CurrentBet < g.BetToCall
+}
+
+// HasBigBlindOption reports whether the player is the big blind, or the
+// straddler if anyone straddled, and nobody has raised, so the player may
+// check their option or raise.
+func (g *Game) HasBigBlindOption(player *Player) bool {
+	return g.unraisedPreFlop() && g.Players[g.optionPos()] == player && player.CurrentBet == g.BetToCall
+}
+
+// actionDetail classifies a call or check as a limp, a small blind
+// completion, or the big blind checking its option. The big blind's option
+// may also be taken as a call of nothing.
+func (g *Game) actionDetail(player *Player, actionType ActionType) ActionDetail {
+	if actionType != ActionCall && actionType != ActionCheck {
+		return ActionDetailNone
+	}
+	switch {
+	case g.HasBigBlindOption(player):
+		return ActionDetailOption
+	case actionType == ActionCall && g.CanComplete(player):
+		return ActionDetailComplete
+	case actionType == ActionCall && g.unraisedPreFlop() && player.CurrentBet < g.BetToCall:
+		if sbPos, bbPos := g.blindSeats(); g.Players[bbPos] != player && (sbPos < 0 || g.Players[sbPos] != player) {
+			return ActionDetailLimp
+		}
+	}
+	return ActionDetailNone
+}
+
+// recordPreFlopLines classifies the current hand's pre-flop lines and adds them
+// to every player's statistics, and to the human model for the human player.
+func (g *Game) recordPreFlopLines() {
+	if g.History == nil {
+		return
+	}
+	if g.PreFlopStats == nil {
+		g.PreFlopStats = make(map[string]*PreFlopStats)
+	}
+	lines := ClassifyPreFlopLines(g.History.Actions)
+	for _, seat := range g.History.Seats {
+		line := lines[seat.Name]
+		stats, ok := g.PreFlopStats[seat.Name]
+		if !ok {
+			stats = &PreFlopStats{}
+			g.PreFlopStats[seat.Name] = stats
+		}
+		stats.Record(line)
+		if seat.IsHuman && g.HumanModel != nil {
+			g.HumanModel.PreFlop.Record(line)
+		}
+	}
+}
+
+// PositionGroup groups the table positions by how late the seats act before
+// the flop.
+type PositionGroup int
+
+// PositionGroup constants.
+const (
+	// PositionMiddle covers MP and HJ, and any seat whose position is unknown.
+	PositionMiddle PositionGroup = iota
+	// PositionEarly covers UTG and the seats right after it (UTG+1..).
+	PositionEarly
+	// PositionLate covers the cutoff and the button.
+	PositionLate
+	// PositionBlinds covers the small and big blinds.
+	PositionBlinds
+)
+
+// String returns the group's name, e.g. "late".
+func (p PositionGroup) String() string {
+	return []string{"middle", "early", "late", "blinds"}[p]
+}
+
+// positionGroupOf returns the group of a position named by seatPositions.
+func positionGroupOf(position string) PositionGroup {
+	switch {
+	case position == PositionButton || position == "CO":
+		return PositionLate
+	case position == PositionSmallBlind || position == PositionBigBlind:
+		return PositionBlinds
+	case strings.HasPrefix(position, "UTG"):
+		return PositionEarly
+	}
+	return PositionMiddle
+}
+
+// positionGroup returns the group of the player's position in the current
+// hand.
+func (g *Game) positionGroup(player *Player) PositionGroup {
+	if g.BigBlindPos < 0 || g.BigBlindPos >= len(g.Players) {
+		return PositionMiddle
+	}
+	return positionGroupOf(g.seatPositions(g.SmallBlindPos, g.BigBlindPos)[player.Name])
+}
+
+// playersLeftToAct returns the number of players still in the hand, with chips
+// behind, who act after the player before the pre-flop betting comes round to
+// the big blind, or to the straddler.
+func (g *Game) playersLeftToAct(player *Player) int {
+	last := g.optionPos()
+	if last < 0 || last >= len(g.Players) {
+		return 0
+	}
+	seat := -1
+	for i, p := range g.Players {
+		if p == player {
+			seat = i
+		}
+	}
+	if seat < 0 || seat == last {
+		return 0
+	}
+	left := 0
+	for pos := (seat + 1) % len(g.Players); ; pos = (pos + 1) % len(g.Players) {
+		if g.Players[pos].Status == PlayerStatusPlaying {
+			left++
+		}
+		if pos == last {
+			return left
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMiddlePositionNames(t *testing.T) {
+	testCases := map[int][]string{
+		0: {},
+		1: {"UTG"},
+		2: {"UTG", "CO"},
+		3: {"UTG", "HJ", "CO"},
+		5: {"UTG", "UTG+1", "MP", "HJ", "CO"},
+	}
+	for n, expected := range testCases {
+		if got := middlePositionNames(n); !reflect.DeepEqual(got, expected) {
+			t.Errorf("middlePositionNames(%d) = %v, expected %v", n, got, expected)
+		}
+	}
+}
+
+func TestStartNewHand_RecordsPositions(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4", "CPU5"}, 10000, 500, 1000)
+	g.StartNewHand()
+
+	expected := []string{"BTN", "SB", "BB", "UTG", "HJ", "CO"}
+	for i, seat := range g.History.Seats {
+		if seat.Position != expected[i] {
+			t.Errorf("Expected %s to be in %s, but got %s", seat.Name, expected[i], seat.Position)
+		}
+	}
+
+	g.ProcessAction(g.Players[3], PlayerAction{Type: ActionFold})
+	if action := g.History.Actions[0]; action.Position != "UTG" {
+		t.Errorf("Expected the first action to be recorded from UTG, but got %q", action.Position)
+	}
+}
+
+func TestClassifyPreFlopLines(t *testing.T) {
+	pre := func(name, position string, action ActionType, amount int) ActionRecord {
+		return ActionRecord{Phase: PhasePreFlop, PlayerName: name, Position: position, Action: action, Amount: amount}
+	}
+
+	t.Run("Cold call and squeeze", func(t *testing.T) {
+		lines := ClassifyPreFlopLines([]ActionRecord{
+			pre("A", "UTG", ActionRaise, 3000),
+			pre("B", "CO", ActionCall, 3000),
+			pre("C", "BTN", ActionRaise, 12000),
+			pre("D", "SB", ActionFold, 0),
+			pre("E", "BB", ActionFold, 0),
+		})
+		if line := lines["A"]; !line.LimpOpportunity || line.Limped || line.ColdCallOpportunity || line.SqueezeOpportunity {
+			t.Errorf("Expected the opener only to pass on a limp, but got %+v", line)
+		}
+		if line := lines["B"]; !line.ColdCallOpportunity || !line.ColdCalled || line.SqueezeOpportunity {
+			t.Errorf("Expected B to cold-call without a squeeze opportunity, but got %+v", line)
+		}
+		if line := lines["C"]; !line.SqueezeOpportunity || !line.Squeezed || line.ColdCalled {
+			t.Errorf("Expected C to squeeze, but got %+v", line)
+		}
+		if line := lines["D"]; line != (PreFlopLine{}) {
+			t.Errorf("Expected no line for the small blind facing a 3-bet, but got %+v", line)
+		}
+	})
+
+	t.Run("Limp-reraise", func(t *testing.T) {
+		lines := ClassifyPreFlopLines([]ActionRecord{
+			pre("A", "UTG", ActionCall, 1000),
+			pre("B", "BTN", ActionRaise, 4000),
+			pre("C", "SB", ActionFold, 0),
+			pre("D", "BB", ActionFold, 0),
+			pre("A", "UTG", ActionRaise, 12000),
+			pre("B", "BTN", ActionCall, 8000),
+			{Phase: PhaseFlop, PlayerName: "A", Action: ActionBet, Amount: 10000},
+		})
+		if line := lines["A"]; !line.LimpReraiseOpportunity || !line.LimpReraised {
+			t.Errorf("Expected A to limp-reraise, but got %+v", line)
+		}
+		if line := lines["B"]; line.ColdCallOpportunity || line.LimpReraiseOpportunity {
+			t.Errorf("Expected an isolation raise not to count as a cold call, but got %+v", line)
+		}
+	})
+
+	t.Run("Limp, completion and option", func(t *testing.T) {
+		limp := pre("A", "BTN", ActionCall, 1000)
+		limp.Detail = ActionDetailLimp
+		complete := pre("B", "SB", ActionCall, 500)
+		complete.Detail = ActionDetailComplete
+		option := pre("C", "BB", ActionCall, 0)
+		option.Detail = ActionDetailOption
+		lines := ClassifyPreFlopLines([]ActionRecord{limp, complete, option})
+
+		if line := lines["A"]; !line.LimpOpportunity || !line.Limped {
+			t.Errorf("Expected A to limp, but got %+v", line)
+		}
+		if line := lines["B"]; !line.CompleteOpportunity || !line.Completed || line.LimpOpportunity {
+			t.Errorf("Expected the small blind to complete, but got %+v", line)
+		}
+		if line := lines["C"]; !line.OptionOpportunity || !line.CheckedOption || line.Limped {
+			t.Errorf("Expected the big blind to check its option, but got %+v", line)
+		}
+	})
+}
+
+func TestPreFlopStats_RecordedAtEndOfHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 100000, 500, 1000)
+	g.HumanModel = NewOpponentModel("default")
+	g.StartNewHand()
+
+	// YOU is on the button, facing an open raise from UTG (CPU3).
+	you, sb, bb, utg := g.Players[0], g.Players[1], g.Players[2], g.Players[3]
+	g.ProcessAction(utg, PlayerAction{Type: ActionRaise, Amount: 3000})
+	g.ProcessAction(you, PlayerAction{Type: ActionCall})
+	g.ProcessAction(sb, PlayerAction{Type: ActionRaise, Amount: 12000})
+	g.ProcessAction(bb, PlayerAction{Type: ActionFold})
+	g.finishHandHistory()
+
+	if stats := g.PreFlopStats["YOU"]; stats.ColdCalls != 1 || stats.ColdCallOpportunities != 1 {
+		t.Errorf("Expected YOU to have cold-called once, but got %+v", stats)
+	}
+	if stats := g.PreFlopStats["CPU1"]; stats.Squeezes != 1 || stats.SqueezeOpportunities != 1 {
+		t.Errorf("Expected CPU1 to have squeezed once, but got %+v", stats)
+	}
+	if g.HumanModel.PreFlop != *g.PreFlopStats["YOU"] {
+		t.Errorf("Expected the human model to record the same lines, but got %+v", g.HumanModel.PreFlop)
+	}
+}
+
+func TestPreFlopExploitation(t *testing.T) {
+	lagProfile := builtInProfile("Loose-Aggressive")
+	newGame := func(stats PreFlopStats) *Game {
+		return &Game{
+			Players:    []*Player{{Name: "YOU", Status: PlayerStatusPlaying}},
+			Difficulty: DifficultyMedium,
+			BigBlind:   1000,
+			BetToCall:  3000,
+			HumanModel: &OpponentModel{PreFlop: stats},
+		}
+	}
+	cpu := &Player{Name: "CPU1", IsCPU: true, Profile: &lagProfile}
+
+	if got := newGame(PreFlopStats{ColdCallOpportunities: 10, ColdCalls: 6}).adjustedOpenSizeBB(cpu); got != lagProfile.OpenSizeBB*1.5 {
+		t.Errorf("Expected a bigger open against a frequent cold-caller, but got %.2f", got)
+	}
+	if got := newGame(PreFlopStats{ColdCallOpportunities: 3, ColdCalls: 3}).adjustedOpenSizeBB(cpu); got != lagProfile.OpenSizeBB {
+		t.Errorf("Expected no adjustment with too few observations, but got %.2f", got)
+	}
+	if !newGame(PreFlopStats{SqueezeOpportunities: 10, Squeezes: 4}).humanSqueezesBehind() {
+		t.Error("Expected a frequent squeezer still to act to be detected")
+	}
+	if newGame(PreFlopStats{SqueezeOpportunities: 10, Squeezes: 1}).humanSqueezesBehind() {
+		t.Error("Expected a rare squeezer not to be treated as a threat")
+	}
+}
+
+func TestProcessAction_BlindActionDetails(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 500, 1000)
+	g.StartNewHand()
+
+	// YOU is on the button, CPU1 in the small blind and CPU2 in the big blind.
+	you, sb, bb, utg := g.Players[0], g.Players[1], g.Players[2], g.Players[3]
+	if !g.CanComplete(sb) || g.CanComplete(you) {
+		t.Error("Expected only the small blind to be able to complete")
+	}
+
+	testCases := []struct {
+		player   *Player
+		action   ActionType
+		expected ActionDetail
+		desc     string
+	}{
+		{player: utg, action: ActionCall, expected: ActionDetailLimp, desc: "Limp 1000"},
+		{player: you, action: ActionCall, expected: ActionDetailLimp, desc: "Limp 1000"},
+		{player: sb, action: ActionCall, expected: ActionDetailComplete, desc: "Complete 500"},
+		{player: bb, action: ActionCheck, expected: ActionDetailOption, desc: "Check (option)"},
+	}
+	for _, tc := range testCases {
+		_, event := g.ProcessAction(tc.player, PlayerAction{Type: tc.action})
+		if event.Detail != tc.expected || tc.player.LastActionDesc != tc.desc {
+			t.Errorf("Expected %s to %s (%q), but got %s (%q)", tc.player.Name, tc.expected, tc.desc, event.Detail, tc.player.LastActionDesc)
+		}
+	}
+
+	// After the flop, a call is just a call.
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	g.ProcessAction(sb, PlayerAction{Type: ActionBet, Amount: 1000})
+	if _, event := g.ProcessAction(bb, PlayerAction{Type: ActionCall}); event.Detail != ActionDetailNone {
+		t.Errorf("Expected no detail for a post-flop call, but got %s", event.Detail)
+	}
+
+	g.finishHandHistory()
+	if stats := g.PreFlopStats["CPU1"]; stats.Completes != 1 || stats.CompleteOpportunities != 1 {
+		t.Errorf("Expected one completion for the small blind, but got %+v", stats)
+	}
+	if stats := g.PreFlopStats["CPU2"]; stats.OptionChecks != 1 || stats.OptionOpportunities != 1 {
+		t.Errorf("Expected one option check for the big blind, but got %+v", stats)
+	}
+	if stats := g.PreFlopStats["YOU"]; stats.Limps != 1 || stats.LimpOpportunities != 1 {
+		t.Errorf("Expected one limp for YOU, but got %+v", stats)
+	}
+}
+
+func TestProcessAction_RaisedPotHasNoBlindDetails(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.StartNewHand()
+
+	// YOU is on the button and raises; the blinds' calls are ordinary calls.
+	g.ProcessAction(g.Players[0], PlayerAction{Type: ActionRaise, Amount: 3000})
+	if _, event := g.ProcessAction(g.Players[1], PlayerAction{Type: ActionCall}); event.Detail != ActionDetailNone {
+		t.Errorf("Expected a plain call from the small blind, but got %s", event.Detail)
+	}
+	if g.HasBigBlindOption(g.Players[2]) {
+		t.Error("Expected the big blind to have no option in a raised pot")
+	}
+}
+
+func TestIsBettingRoundOver_FoldLeavesTheBigBlindOption(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 500, 1000)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	// CPU3 folds under the gun, and the button and small blind limp.
+	for _, action := range []ActionType{ActionFold, ActionCall, ActionCall} {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: action})
+		g.AdvanceTurn()
+	}
+	if g.IsBettingRoundOver() || !g.HasBigBlindOption(g.CurrentPlayer()) {
+		t.Fatalf("Expected the big blind to have the option, but the round is over or %s is to act", g.CurrentPlayer().Name)
+	}
+}
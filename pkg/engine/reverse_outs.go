@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+)
+
+// ReverseOuts is the coach's reading of how likely the player's made hand is
+// already beaten by one of the live opponents, counting the cards the player
+// can see as removed from their hands.
+type ReverseOuts struct {
+	// Hand is the player's high hand made so far.
+	Hand *poker.HandResult
+	// Opponents is the number of opponents still in the hand.
+	Opponents int
+	// Behind is the chance that at least one of them holds a better hand.
+	Behind float64
+	// Exact is true if every hand a lone opponent can hold was counted, and
+	// false if the chance was estimated from random deals.
+	Exact bool
+}
+
+// EstimateReverseOuts estimates the chance that one of the player's live
+// opponents already holds a better high hand. It returns nil before the flop,
+// when the player has folded, or when no opponent is left. r is used for the
+// simulation only.
+func (g *Game) EstimateReverseOuts(ctx context.Context, player *Player, r *rand.Rand) (*ReverseOuts, error) {
+	inHand := player.Status == PlayerStatusPlaying || player.Status == PlayerStatusAllIn
+	if len(g.CommunityCards) < poker.StreetFlop.BoardSize() || !inHand {
+		return nil, nil
+	}
+	opponents := g.CountNonFoldedPlayers() - 1
+	if opponents < 1 {
+		return nil, nil
+	}
+	behind, err := poker.EstimateBehind(ctx, player.Hand, g.CommunityCards, opponents, g.Rules, r)
+	if err != nil {
+		return nil, err
+	}
+	return &ReverseOuts{Hand: behind.Hand, Opponents: opponents, Behind: behind.Probability, Exact: behind.Exact}, nil
+}
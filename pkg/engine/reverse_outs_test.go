@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"context"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateReverseOuts(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100, "NLH")
+	for _, p := range g.Players {
+		p.Status = PlayerStatusPlaying
+	}
+	g.Players[2].Status = PlayerStatusFolded
+	g.Players[0].Hand = poker.CardsFromStrings("Ah Kd")
+	r := rand.New(rand.NewSource(1))
+
+	if ro, err := g.EstimateReverseOuts(context.Background(), g.Players[0], r); err != nil || ro != nil {
+		t.Errorf("Expected no reverse outs before the flop, but got %+v (%v)", ro, err)
+	}
+
+	// Only the hole cards and the board are removed: the folded hand is
+	// unknown. 28 of the 1,081 hands left beat top pair, top kicker.
+	g.CommunityCards = poker.CardsFromStrings("Ac 7s 2d")
+	ro, err := g.EstimateReverseOuts(context.Background(), g.Players[0], r)
+	if err != nil {
+		t.Fatalf("Failed to estimate the reverse outs: %v", err)
+	}
+	if ro.Opponents != 1 || !ro.Exact || math.Abs(ro.Behind-28.0/1081) > 1e-9 {
+		t.Errorf("Expected an exact 2.6%% against one opponent, but got %+v", ro)
+	}
+
+	g.Players[0].Status = PlayerStatusFolded
+	if ro, err := g.EstimateReverseOuts(context.Background(), g.Players[0], r); err != nil || ro != nil {
+		t.Errorf("Expected no reverse outs for a folded hand, but got %+v (%v)", ro, err)
+	}
+}
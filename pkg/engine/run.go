@@ -1,8 +1,9 @@
 package engine
 
 import (
+	"context"
 	"fmt"
-	"pls7-cli/pkg/poker"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
 
 	"github.com/sirupsen/logrus"
 )
@@ -38,20 +39,52 @@ var playerHoleCardsForDebug = map[string]map[string]string{
 // It returns a boolean indicating if an aggressive action (bet or raise) was taken,
 // which is used to track the flow of the betting round, and an ActionEvent for logging.
 func (g *Game) ProcessAction(player *Player, action PlayerAction) (wasAggressive bool, event *ActionEvent) {
+	g.guardAction(player, action)
+	g.logEvent(GameEvent{Type: EventActionTaken, Player: player.Name, Action: action})
 	g.ActionsTakenThisRound++
-	event = &ActionEvent{PlayerName: player.Name, Action: action.Type}
+	event = &ActionEvent{PlayerName: player.Name, Action: action.Type, Detail: g.actionDetail(player, action.Type), Seq: g.ActionSeq}
+	g.ActionSeq++
+	defer func() {
+		event.Pot = g.Pot
+		g.recordAction(event)
+		g.publish(event)
+	}()
+
+	if !player.IsCPU && g.HumanModel != nil {
+		g.HumanModel.observe(g.HandCount, g.Phase, g.BetToCall > player.CurrentBet, action.Type)
+	}
+	g.observePlayerAction(player, action.Type)
 
 	switch action.Type {
 	case ActionFold:
 		player.Status = PlayerStatusFolded
 		player.LastActionDesc = "Fold"
+		// The folder no longer counts among the players able to act, so the
+		// fold must not count towards their actions either; otherwise a fold
+		// would end the pre-flop round before the big blind, or the
+		// straddler, took their option.
+		g.ActionsTakenThisRound--
 	case ActionCheck:
 		player.LastActionDesc = "Check"
+		if event.Detail == ActionDetailOption {
+			player.LastActionDesc = "Check (option)"
+		}
 	case ActionCall:
 		amountToCall := g.BetToCall - player.CurrentBet
 		event.Amount = amountToCall
 		g.postBet(player, amountToCall)
+		if g.Aggressor != nil {
+			g.calledAggressor = g.Aggressor
+		}
 		desc := fmt.Sprintf("Call %d", amountToCall)
+		switch event.Detail {
+		case ActionDetailLimp:
+			desc = fmt.Sprintf("Limp %d", amountToCall)
+		case ActionDetailComplete:
+			desc = fmt.Sprintf("Complete %d", amountToCall)
+		case ActionDetailOption:
+			desc = "Check (option)"
+		}
 		if player.Status == PlayerStatusAllIn {
 			desc += " (All-in)"
 		}
@@ -59,10 +92,16 @@ func (g *Game) ProcessAction(player *Player, action PlayerAction) (wasAggressive
 	case ActionBet:
 		g.ActionsTakenThisRound = 1 // This player is the new aggressor.
 		event.Amount = action.Amount
-		g.LastRaiseAmount = action.Amount
-		g.postBet(player, action.Amount)
-		g.BetToCall = player.CurrentBet
+		// The amount is the total bet to, which differs from the chips put
+		// in only when the big blind bets on its option.
 		desc := fmt.Sprintf("Bet %d", action.Amount)
+		if player.CurrentBet > 0 {
+			desc = fmt.Sprintf("Bet to %d", action.Amount)
+		}
+		previousBetToCall := g.BetToCall
+		g.postBet(player, action.Amount-player.CurrentBet)
+		g.BetToCall = player.CurrentBet
+		g.LastRaiseAmount = g.BetToCall - previousBetToCall
 		if player.Status == PlayerStatusAllIn {
 			desc += " (All-in)"
 		}
@@ -89,27 +128,48 @@ func (g *Game) ProcessAction(player *Player, action PlayerAction) (wasAggressive
 }
 
 // CleanupHand performs post-hand maintenance. It checks for and marks any players
-// who have been eliminated (run out of chips) and checks for a game-over condition.
-func (g *Game) CleanupHand() []string {
-	var events []string
-	events = append(events, "\n--- End of Hand ---")
+// who have been eliminated (run out of chips) and checks for a game-over
+// condition, publishing an EliminationEvent for each player out, a
+// HandEndEvent and, once a single player is left, a GameWonEvent.
+func (g *Game) CleanupHand() {
+	g.logEvent(GameEvent{Type: EventHandCleanedUp})
+	if g.cancelHand != nil {
+		g.cancelHand()
+	}
+	g.finishHandHistory()
+
+	// Report every violation found since the hand began, after the pot
+	// distribution as well as now.
+	violations := g.handViolations
+	g.checkChipConservation("at the end of the hand")
+	var eliminated []string
 	for _, p := range g.Players {
 		if p.Chips == 0 && p.Status != PlayerStatusEliminated {
 			p.Status = PlayerStatusEliminated
-			events = append(events, fmt.Sprintf("%s has been eliminated!", p.Name))
+			eliminated = append(eliminated, p.Name)
 		}
 	}
 
 	// Check if only one player is left in the entire game.
+	winner := ""
 	if g.CountRemainingPlayers() <= 1 {
 		for _, p := range g.Players {
 			if p.Status != PlayerStatusEliminated {
-				events = append(events, fmt.Sprintf("%s wins the game!", p.Name))
+				winner = p.Name
 				break
 			}
 		}
 	}
-	return events
+	g.recordStacksAfterHand()
+	g.handInProgress = false
+
+	g.publish(&HandEndEvent{HandNumber: g.HandCount, ChipViolations: append([]ChipViolation(nil), g.ChipViolations[violations:]...)})
+	for _, name := range eliminated {
+		g.publish(&EliminationEvent{PlayerName: name})
+	}
+	if winner != "" {
+		g.publish(&GameWonEvent{PlayerName: winner})
+	}
 }
 
 // CountRemainingPlayers counts players who have not been eliminated from the game.
@@ -152,24 +212,90 @@ func (g *Game) CountPlayersAbleToAct() int {
 // players' statuses and bets, shuffling the deck, moving the dealer button,
 // posting blinds, and dealing new hole cards.
 func (g *Game) StartNewHand() (event *BlindEvent) {
+	g.beginHand()
+
+	// Increase blinds if the blind-up interval or the clock's level has been reached.
+	if g.shouldRaiseBlinds() {
+		event = g.raiseBlinds()
+	}
+
+	deck := poker.NewDeck()
+	deck.Shuffle(g.Rand)
+	sbPos, bbPos := g.setUpHand(deck)
+	g.postStraddle(g.wantsToStraddle)
+	g.dealHoleCards()
+
+	var chipRace []ChipRaceResult
+	if event != nil {
+		chipRace = event.ChipRace
+	}
+	g.dealtHand(sbPos, bbPos, chipRace)
+	if event != nil {
+		g.publish(event)
+	}
+	g.publishDeal()
+	return event
+}
+
+// publishDeal publishes the hand just dealt.
+func (g *Game) publishDeal() {
+	deal := &DealEvent{HandNumber: g.HandCount}
+	if button := g.ButtonPlayer(); button != nil {
+		deal.Dealer = button.Name
+	}
+	for _, p := range g.Players {
+		if p.Status != PlayerStatusEliminated {
+			deal.Players = append(deal.Players, p.Name)
+		}
+	}
+	g.publish(deal)
+}
+
+// beginHand counts the new hand and checks the stacks it starts with. The
+// violations found from here on are the hand's.
+func (g *Game) beginHand() {
 	g.HandCount++
+	g.handViolations = len(g.ChipViolations)
+	g.checkStacksBetweenHands()
+	g.handInProgress = true
+	if g.cancelHand != nil {
+		g.cancelHand()
+	}
+	g.handCtx, g.cancelHand = context.WithCancel(context.Background())
+}
 
-	// Increase blinds if the blind-up interval has been reached.
-	if g.BlindUpInterval > 0 && g.HandCount > 1 && (g.HandCount-1)%g.BlindUpInterval == 0 {
-		g.SmallBlind *= 2
-		g.BigBlind *= 2
-		event = &BlindEvent{SmallBlind: g.SmallBlind, BigBlind: g.BigBlind}
+// HandContext returns the current hand's context. It is canceled when the hand
+// is cleaned up, so that long computations for the hand, such as equity
+// simulations, stop once it is over. Before the first hand, it is never
+// canceled.
+func (g *Game) HandContext() context.Context {
+	if g.handCtx == nil {
+		return context.Background()
 	}
+	return g.handCtx
+}
 
-	// Reset game state for the new hand.
+// setUpHand resets the game state for a hand played with the given deck,
+// moves the dealer button and the blinds, and posts the antes and blinds. It
+// returns the positions of the small and big blinds; the small blind's is -1
+// when it is dead.
+func (g *Game) setUpHand(deck *poker.Deck) (sbPos, bbPos int) {
+	g.rememberPreviousWinners()
 	g.Phase = PhasePreFlop
-	g.Deck = poker.NewDeck()
-	g.Deck.Shuffle(g.Rand)
+	g.ActionSeq++
+	g.Deck = deck
 	g.CommunityCards = []poker.Card{}
 	g.Pot = 0
 	g.LastRaiseAmount = 0
+	g.Insurance = nil
+	g.Runs = nil
+	g.Uncalled = nil
+	g.calledAggressor = nil
+	g.runFrom = -1
+	g.runItAnswer = runItUnasked
+	g.evalCache = evalCache{}
 
-	g.DealerPos = g.FindNextActivePlayer(g.DealerPos)
+	g.moveButtonAndBlinds()
 
 	// Reset each player's state for the new hand.
 	for _, p := range g.Players {
@@ -177,26 +303,114 @@ func (g *Game) StartNewHand() (event *BlindEvent) {
 			p.Hand = []poker.Card{}
 			p.CurrentBet = 0
 			p.TotalBetInHand = 0
+			p.Ante = 0
+			p.DeadAnte = 0
 			p.Status = PlayerStatusPlaying
 			p.LastActionDesc = ""
+			p.Mucked = false
+			p.ShownCards = nil
 		}
 	}
 
-	// Post blinds.
-	sbPos := g.FindNextActivePlayer(g.DealerPos)
-	bbPos := g.FindNextActivePlayer(sbPos)
-	g.postBet(g.Players[sbPos], g.SmallBlind)
+	// Post antes and blinds. Everyone's antes come before the blinds; a single
+	// player's ante for the table comes after them.
+	if g.AnteFormat == AnteEveryone {
+		g.postAntes()
+	}
+	sbPos, bbPos = g.SmallBlindPos, g.BigBlindPos
+	if sbPos >= 0 {
+		g.postBet(g.Players[sbPos], g.SmallBlind)
+	}
 	g.postBet(g.Players[bbPos], g.BigBlind)
+	switch g.AnteFormat {
+	case AnteBigBlind:
+		g.postTableAnte(g.Players[bbPos])
+	case AnteButton:
+		// With the button dead, the big blind posts the antes instead.
+		if button := g.ButtonPlayer(); button != nil {
+			g.postTableAnte(button)
+		} else {
+			g.postTableAnte(g.Players[bbPos])
+		}
+	}
 
 	g.BetToCall = g.BigBlind
 	g.CurrentTurnPos = g.FindNextActivePlayer(bbPos)
+	return sbPos, bbPos
+}
+
+// moveButtonAndBlinds places the button and the blinds for a new hand: after
+// the button's seat for the first hand, and moved on by moveBlinds after that.
+// Heads-up, the button posts the small blind, so that it acts first pre-flop
+// and last after the flop.
+func (g *Game) moveButtonAndBlinds() {
+	if g.BigBlindPos < 0 {
+		g.DealerPos = g.FindNextActivePlayer(g.DealerPos)
+		g.SmallBlindPos = g.FindNextActivePlayer(g.DealerPos)
+		if g.IsHeadsUp() {
+			g.SmallBlindPos = g.DealerPos
+		}
+		g.BigBlindPos = g.FindNextActivePlayer(g.SmallBlindPos)
+		g.smallBlindSeat = g.SmallBlindPos
+		return
+	}
+	g.moveBlinds()
+}
+
+// IsHeadsUp reports whether only two players are left in the game, and so
+// play by the heads-up rules: the button posts the small blind.
+func (g *Game) IsHeadsUp() bool {
+	return g.CountRemainingPlayers() == 2
+}
+
+// moveBlinds moves the blinds and the button on for the next hand, by the
+// dead button rule. The big blind moves to the next player still in the game,
+// so that nobody skips it, and the small blind to the previous big blind's
+// seat. If that player has been eliminated, the small blind is dead: it is not
+// posted, rather than shifting both blinds forward. The button moves to the
+// previous small blind's seat, so that nobody has it twice in a row; if that
+// player has been eliminated, or the small blind was dead, the button is dead
+// (see ButtonPlayer), and the last player before the blinds acts last.
+// Heads-up, there is no dead small blind or button: the player who is not in
+// the big blind takes the button and the small blind, even when down to two
+// players means they post it twice in a row.
+func (g *Game) moveBlinds() {
+	sbSeat := g.BigBlindPos
+	g.BigBlindPos = g.FindNextActivePlayer(sbSeat)
+	if g.IsHeadsUp() {
+		g.DealerPos = g.FindNextActivePlayer(g.BigBlindPos)
+		g.SmallBlindPos = g.DealerPos
+		g.smallBlindSeat = g.DealerPos
+		return
+	}
+	g.DealerPos = g.smallBlindSeat
+	if g.DealerPos < 0 {
+		g.DealerPos = g.FindPreviousActivePlayer(sbSeat)
+	}
+	g.SmallBlindPos = sbSeat
+	g.smallBlindSeat = sbSeat
+	if g.Players[sbSeat].Status == PlayerStatusEliminated {
+		g.SmallBlindPos = -1
+	}
+}
+
+// ButtonPlayer returns the player on the button, or nil if the button is
+// dead: its seat's player has been eliminated.
+func (g *Game) ButtonPlayer() *Player {
+	if g.DealerPos < 0 || g.DealerPos >= len(g.Players) || g.Players[g.DealerPos].Status == PlayerStatusEliminated {
+		return nil
+	}
+	return g.Players[g.DealerPos]
+}
 
-	// Deal hole cards.
-	// In dev/debug mode, specific cards can be dealt to the human player.
+// dealHoleCards deals every player in the hand their hole cards.
+func (g *Game) dealHoleCards() {
+	// In dev/debug mode, specific cards can be dealt to the human player. This is
+	// skipped in chaos mode, where the hole card count changes every orbit.
 	ruleAbbr := g.Rules.Abbreviation
-	if g.DevMode {
+	if g.DevMode && !g.ChaosMode {
 		you := g.Players[0]
-		if you.Status == PlayerStatusPlaying {
+		if you.dealtIn() {
 			// Deal specific debug cards to the human player.
 			if debugHand, ok := playerHoleCardsForDebug[ruleAbbr]; ok {
 				// A default hand from the map is chosen here, e.g., "3As" or "AA".
@@ -207,21 +421,33 @@ func (g *Game) StartNewHand() (event *BlindEvent) {
 				} else {
 					handStr = debugHand["3As"]
 				}
-				playerHoleCards := poker.CardsFromStrings(handStr)
+				playerHoleCards, err := poker.ParseCards(handStr)
+				if err != nil {
+					// The hand is dealt at random below instead.
+					logrus.Warnf("Invalid debug hand %q for %s: %v", handStr, ruleAbbr, err)
+				}
 				for _, card := range playerHoleCards {
 					dealtCard, err := g.Deck.DealForDebug(card)
-					if err == nil {
-						you.Hand = append(you.Hand, dealtCard)
+					if err != nil {
+						// Keep the hand complete with a random card instead.
+						logrus.Warnf("Could not deal debug card %s: %v", card, err)
+						dealtCard, _ = g.Deck.Deal()
 					}
+					you.Hand = append(you.Hand, dealtCard)
 				}
 			} else {
 				logrus.Warnf("Unsupported rule abbreviation for debug hands: %s", ruleAbbr)
 			}
+			// Deal at random whatever the debug hand could not supply.
+			for len(you.Hand) < g.Rules.HoleCards.Count {
+				card, _ := g.Deck.Deal()
+				you.Hand = append(you.Hand, card)
+			}
 		}
 		// Deal remaining cards randomly to CPUs.
 		for i := 1; i < len(g.Players); i++ {
 			for j := 0; j < g.Rules.HoleCards.Count; j++ {
-				if g.Players[i].Status == PlayerStatusPlaying {
+				if g.Players[i].dealtIn() {
 					card, _ := g.Deck.Deal()
 					g.Players[i].Hand = append(g.Players[i].Hand, card)
 				}
@@ -231,15 +457,35 @@ func (g *Game) StartNewHand() (event *BlindEvent) {
 		// In a normal game, deal cards to all players in order.
 		for i := 0; i < g.Rules.HoleCards.Count; i++ {
 			for pos, p := range g.Players {
-				if p.Status == PlayerStatusPlaying {
+				if p.dealtIn() {
 					card, _ := g.Deck.Deal()
 					g.Players[pos].Hand = append(g.Players[pos].Hand, card)
 				}
 			}
 		}
 	}
+}
 
-	return event
+// dealtHand logs the hand that has just been dealt and starts its history.
+func (g *Game) dealtHand(sbPos, bbPos int, chipRace []ChipRaceResult) {
+	start := &HandStart{
+		Rules:       g.Rules,
+		SmallBlind:  g.SmallBlind,
+		BigBlind:    g.BigBlind,
+		Ante:        g.Ante,
+		AnteFormat:  g.AnteFormat,
+		RunItTimes:  g.RunItTimes,
+		AsksToRunIt: g.AsksToRunIt,
+		ChipRace:    chipRace,
+		Straddler:   g.straddlerName(),
+		HoleCards:   make([][]poker.Card, len(g.Players)),
+		Deck:        g.Deck.PeekForDebug(g.Deck.RemainingCount()),
+	}
+	for i, p := range g.Players {
+		start.HoleCards[i] = append([]poker.Card(nil), p.Hand...)
+	}
+	g.logEvent(GameEvent{Type: EventHandStarted, Hand: start})
+	g.startHandHistory(sbPos, bbPos)
 }
 
 // FindNextActivePlayer finds the index of the next player at the table who has
@@ -270,18 +516,25 @@ func (g *Game) postBet(player *Player, amount int) {
 }
 
 // Advance moves the game state to the next phase (e.g., from Flop to Turn),
-// dealing community cards as required.
+// dealing community cards as required. A street dealt once no more betting is
+// possible is also logged as an EventBoardRunout, for a display to reveal it
+// slowly with the players' hands face up.
 func (g *Game) Advance() {
+	g.logEvent(GameEvent{Type: EventPhaseAdvanced})
+	g.lockRunout()
 	switch g.Phase {
 	case PhasePreFlop:
 		g.Phase = PhaseFlop
 		g.dealCommunityCards(3)
+		g.logBoardRunout()
 	case PhaseFlop:
 		g.Phase = PhaseTurn
 		g.dealCommunityCards(1)
+		g.logBoardRunout()
 	case PhaseTurn:
 		g.Phase = PhaseRiver
 		g.dealCommunityCards(1)
+		g.logBoardRunout()
 	case PhaseRiver:
 		g.Phase = PhaseShowdown
 	case PhaseShowdown:
@@ -289,6 +542,7 @@ func (g *Game) Advance() {
 	default:
 		panic("Undefined game phase in Advance()")
 	}
+	g.publish(&PhaseChangeEvent{Phase: g.Phase, Board: append([]poker.Card(nil), g.CommunityCards...)})
 }
 
 // dealCommunityCards deals n cards from the deck to the community cards on the board.
@@ -316,13 +570,21 @@ func (g *Game) isBettingActionRequired() bool {
 // (e.g., after the flop is dealt). It clears players' current bets and determines
 // who acts first.
 func (g *Game) PrepareNewBettingRound() {
+	g.logEvent(GameEvent{Type: EventBettingRoundStarted})
+	g.prepareNewBettingRound()
+}
+
+// prepareNewBettingRound resets the state for a new betting round without
+// logging it, for callers that log their own event.
+func (g *Game) prepareNewBettingRound() {
 	g.Aggressor = nil
 	g.ActionsTakenThisRound = 0
+	g.ActionSeq++
 
 	if g.Phase == PhasePreFlop {
-		// Pre-flop is special: blinds are already posted, and action starts after the big blind.
-		bbPos := g.FindNextActivePlayer(g.FindNextActivePlayer(g.DealerPos))
-		g.ActionCloserPos = bbPos
+		// Pre-flop is special: blinds are already posted, and action starts
+		// after the big blind, or the straddle, which closes it.
+		g.ActionCloserPos = g.optionPos()
 		return
 	}
 
@@ -359,6 +621,13 @@ func (g *Game) IsBettingRoundOver() bool {
 		return true
 	}
 
+	// Nobody is left to bet against once fewer than two players can act, so
+	// the rest of the board is dealt without prompting the one who still has
+	// chips, unless they have an all-in bet to call.
+	if g.CountPlayersAbleToAct() <= 1 && !g.isBettingActionRequired() {
+		return true
+	}
+
 	// All players who are able to act must have taken an action.
 	if g.ActionsTakenThisRound < g.CountPlayersAbleToAct() {
 		return false
@@ -383,5 +652,6 @@ func (g *Game) CurrentPlayer() *Player {
 
 // AdvanceTurn moves the action to the next active player in the hand.
 func (g *Game) AdvanceTurn() {
-	g.CurrentTurnPos = g.FindNextActivePlayer(g.CurrentTurnPos)
+	g.logEvent(GameEvent{Type: EventTurnAdvanced})
+	g.CurrentTurnPos = g.nextTurnPos(g.CurrentTurnPos)
 }
@@ -13,7 +13,7 @@ func TestProcessAction_ReturnsActionEvent(t *testing.T) {
 	g.BetToCall = 1000
 	player.CurrentBet = 0
 	_, event := g.ProcessAction(player, PlayerAction{Type: ActionCall})
-	expectedEvent := &ActionEvent{PlayerName: "YOU", Action: ActionCall, Amount: 1000}
+	expectedEvent := &ActionEvent{PlayerName: "YOU", Action: ActionCall, Amount: 1000, Pot: 1000}
 	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Errorf("For Call, expected event %+v, got %+v", expectedEvent, event)
 	}
@@ -22,7 +22,7 @@ func TestProcessAction_ReturnsActionEvent(t *testing.T) {
 	g.BetToCall = 1000
 	player.CurrentBet = 1000
 	_, event = g.ProcessAction(player, PlayerAction{Type: ActionRaise, Amount: 3000})
-	expectedEvent = &ActionEvent{PlayerName: "YOU", Action: ActionRaise, Amount: 3000}
+	expectedEvent = &ActionEvent{PlayerName: "YOU", Action: ActionRaise, Amount: 3000, Pot: 3000, Seq: 1}
 	if !reflect.DeepEqual(event, expectedEvent) {
 		t.Errorf("For Raise, expected event %+v, got %+v", expectedEvent, event)
 	}
@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+)
+
+// MaxRunItTimes is the most times the rest of the board can be run.
+const MaxRunItTimes = 4
+
+// BoardRun is one of the boards an all-in pot was run out on, when the rest
+// of the board is run more than once, with what each player won on it.
+type BoardRun struct {
+	Board   []poker.Card         `json:"board"`
+	Results []DistributionResult `json:"results"`
+}
+
+// potRun holds one board's share of a pot distribution: the board, the
+// showdown hands made on it, and what each player has won on it so far, in
+// chips and in halves of the pot tiers.
+type potRun struct {
+	board       []poker.Card
+	hands       map[*Player]showdownHand
+	lowPossible bool
+	won         map[string]int
+	desc        map[string]string
+	halves      map[string]*halvesWon
+}
+
+// ValidateRunItTimes checks that the rest of the board can be run the given
+// number of times.
+func ValidateRunItTimes(times int) error {
+	if times < 1 || times > MaxRunItTimes {
+		return fmt.Errorf("the board can be run 1 to %d times, got %d", MaxRunItTimes, times)
+	}
+	return nil
+}
+
+// allInRunout reports whether no more betting is possible with two or more
+// players in the hand and cards to come.
+func (g *Game) allInRunout() bool {
+	return len(g.CommunityCards) < 5 && g.CountNonFoldedPlayers() >= 2 && g.CountPlayersAbleToAct() <= 1
+}
+
+// lockRunout remembers how much of the board was out when no more betting
+// became possible with two or more players in the hand, since the rest of the
+// board is run RunItTimes times from there. With AsksToRunIt, the players
+// must have agreed to it first.
+func (g *Game) lockRunout() {
+	if g.RunItTimes <= 1 || g.runFrom >= 0 || !g.allInRunout() {
+		return
+	}
+	if g.AsksToRunIt && g.runItAnswer != runItAgreed {
+		return
+	}
+	g.runFrom = len(g.CommunityCards)
+}
+
+// runItAnswer is the answer of the players in an all-in pot when asked
+// whether to run the rest of the board more than once.
+type runItAnswer int
+
+// runItAnswer constants.
+const (
+	runItUnasked runItAnswer = iota
+	runItAgreed
+	runItDeclined
+)
+
+// RunItOffer returns the players to ask whether to run the rest of the board
+// RunItTimes times, in seating order. With AsksToRunIt, they are asked once a
+// hand, when no more betting is possible with two or more players in the hand
+// and cards to come: every player still in the hand must agree, or the board
+// is run once. It returns nil when nobody is to be asked.
+func (g *Game) RunItOffer() []*Player {
+	if !g.AsksToRunIt || g.RunItTimes <= 1 || g.runItAnswer != runItUnasked || !g.allInRunout() {
+		return nil
+	}
+	var players []*Player
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// DecideRunIt records whether the players asked by RunItOffer all agreed to
+// run the rest of the board RunItTimes times.
+func (g *Game) DecideRunIt(agreed bool) error {
+	if g.RunItOffer() == nil {
+		return errors.New("nobody has been asked to run the board more than once")
+	}
+	g.logEvent(GameEvent{Type: EventRunItDecided, Agreed: agreed})
+	g.runItAnswer = runItDeclined
+	if agreed {
+		g.runItAnswer = runItAgreed
+	}
+	return nil
+}
+
+// CPUAgreesToRunIt reports whether a CPU agrees to run the rest of the board
+// more than once. As with insurance, passive CPUs are risk averse and agree;
+// aggressive ones would rather gamble on one board.
+func (g *Game) CPUAgreesToRunIt(p *Player) bool {
+	return p.Profile != nil && p.Profile.AggressionFactor < 0.5
+}
+
+// runBoards returns the boards the pot is distributed on: the board that was
+// dealt, followed by the extra runs of its rest when the board is run more
+// than once. The extra runs are dealt from the deck, as many as it has cards
+// for.
+func (g *Game) runBoards() [][]poker.Card {
+	boards := [][]poker.Card{g.CommunityCards}
+	if g.runFrom < 0 {
+		return boards
+	}
+	needed := len(g.CommunityCards) - g.runFrom
+	for len(boards) < g.RunItTimes && g.Deck.RemainingCount() >= needed {
+		board := append([]poker.Card(nil), g.CommunityCards[:g.runFrom]...)
+		for range needed {
+			card, _ := g.Deck.Deal()
+			board = append(board, card)
+		}
+		boards = append(boards, board)
+	}
+	return boards
+}
+
+// resultsBySeat lists what each player won, in seating order.
+func (g *Game) resultsBySeat(won map[string]int, desc map[string]string) []DistributionResult {
+	var results []DistributionResult
+	for _, p := range g.Players {
+		if amount, ok := won[p.Name]; ok {
+			results = append(results, DistributionResult{PlayerName: p.Name, AmountWon: amount, HandDesc: desc[p.Name]})
+		}
+	}
+	return results
+}
@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRunItTimes(t *testing.T) {
+	for _, times := range []int{1, 2, MaxRunItTimes} {
+		if err := ValidateRunItTimes(times); err != nil {
+			t.Errorf("ValidateRunItTimes(%d) returned an error: %v", times, err)
+		}
+	}
+	for _, times := range []int{0, -1, MaxRunItTimes + 1} {
+		if err := ValidateRunItTimes(times); err == nil {
+			t.Errorf("ValidateRunItTimes(%d) returned no error", times)
+		}
+	}
+}
+
+// TestDistributePot_RunItThreeTimes tests that a pot run three times from the
+// flop is split into thirds, each awarded on its own board, with the odd chip
+// going to the first run.
+func TestDistributePot_RunItThreeTimes(t *testing.T) {
+	rules := loadRule(t, "pls.yml")
+	g := NewGame([]string{"YOU", "CPU1"}, 0, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.RunItTimes = 3
+	g.runFrom = 3
+
+	g.Players[0].Hand = poker.CardsFromStrings("Ad Td 3s")
+	g.Players[1].Hand = poker.CardsFromStrings("Kc Kh 8d")
+	for _, p := range g.Players {
+		p.TotalBetInHand = 5000
+		p.Status = PlayerStatusAllIn
+	}
+	// YOU makes a straight on the first run; CPU1's kings hold on the others.
+	g.CommunityCards = poker.CardsFromStrings("Ks Qd 2c Jc 4h")
+	deck, err := poker.NewStackedDeck(poker.CardsFromStrings("Th 5s 9d 9s"))
+	if err != nil {
+		t.Fatalf("Failed to stack the deck: %v", err)
+	}
+	g.Deck = deck
+	g.Pot = 10000
+
+	results := g.DistributePot()
+
+	// The hands are looked up in the cache for the first run's board only;
+	// the other runs are evaluated on their own boards.
+	if g.evalCache.misses != 2 || g.evalCache.hits != 0 {
+		t.Errorf("Expected 2 cache lookups for the first board, but got %d misses and %d hits", g.evalCache.misses, g.evalCache.hits)
+	}
+	if g.Players[0].Chips != 3334 {
+		t.Errorf("Expected YOU to win the first run's 3334, but got %d", g.Players[0].Chips)
+	}
+	if g.Players[1].Chips != 6666 {
+		t.Errorf("Expected CPU1 to win the other runs' 6666, but got %d", g.Players[1].Chips)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 distribution results, but got %d", len(results))
+	}
+
+	wantBoards := [][]poker.Card{
+		poker.CardsFromStrings("Ks Qd 2c Jc 4h"),
+		poker.CardsFromStrings("Ks Qd 2c Th 5s"),
+		poker.CardsFromStrings("Ks Qd 2c 9d 9s"),
+	}
+	wantWinners := []string{"YOU", "CPU1", "CPU1"}
+	if len(g.Runs) != len(wantBoards) {
+		t.Fatalf("Expected %d runs, but got %d", len(wantBoards), len(g.Runs))
+	}
+	for i, run := range g.Runs {
+		if !reflect.DeepEqual(run.Board, wantBoards[i]) {
+			t.Errorf("Run %d: expected board %v, but got %v", i+1, wantBoards[i], run.Board)
+		}
+		if len(run.Results) != 1 || run.Results[0].PlayerName != wantWinners[i] {
+			t.Errorf("Run %d: expected %s to win, but got %+v", i+1, wantWinners[i], run.Results)
+		}
+	}
+}
+
+// TestLockRunout tests that the runout is locked once no more betting is
+// possible with two or more players in the hand, and only when the board is
+// to be run more than once.
+func TestLockRunout(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+	g.CommunityCards = poker.CardsFromStrings("Ks Qd 2c")
+	g.Players[0].Status = PlayerStatusAllIn
+	g.Players[1].Status = PlayerStatusFolded
+
+	g.lockRunout()
+	if g.runFrom != -1 {
+		t.Errorf("Expected no runout to be locked when running once, but got %d", g.runFrom)
+	}
+
+	g.RunItTimes = 2
+	g.lockRunout()
+	if g.runFrom != 3 {
+		t.Errorf("Expected the runout to be locked from the flop, but got %d", g.runFrom)
+	}
+}
+
+// TestRunItOffer tests that with AsksToRunIt, the players in an all-in pot
+// are asked once, and the board is run more than once only if they agree.
+func TestRunItOffer(t *testing.T) {
+	for _, agreed := range []bool{false, true} {
+		g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000)
+		g.RunItTimes, g.AsksToRunIt = 2, true
+		g.CommunityCards = poker.CardsFromStrings("Ks Qd 2c")
+		g.Players[0].Status = PlayerStatusAllIn
+		g.Players[1].Status = PlayerStatusFolded
+
+		players := g.RunItOffer()
+		if len(players) != 2 || players[0] != g.Players[0] || players[1] != g.Players[2] {
+			t.Fatalf("Expected YOU and CPU2 to be asked, but got %v", players)
+		}
+		g.lockRunout()
+		if g.runFrom != -1 {
+			t.Errorf("Expected no runout to be locked before the players answer, but got %d", g.runFrom)
+		}
+
+		if err := g.DecideRunIt(agreed); err != nil {
+			t.Fatalf("DecideRunIt returned an error: %v", err)
+		}
+		if g.RunItOffer() != nil {
+			t.Error("Expected the players to be asked only once")
+		}
+		if err := g.DecideRunIt(agreed); err == nil {
+			t.Error("Expected an error for a second answer")
+		}
+		g.lockRunout()
+		if locked := g.runFrom == 3; locked != agreed {
+			t.Errorf("Agreed %v: expected the runout locked to be %v, but runFrom is %d", agreed, agreed, g.runFrom)
+		}
+	}
+}
+
+// TestDecideRunIt_Replay tests that the players' answer is replayed from the
+// event log, so the replayed hand is run the same number of times.
+func TestDecideRunIt_Replay(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU 1"}, 5000, 50, 100, "NLH")
+	g.RunItTimes, g.AsksToRunIt = 3, true
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	you := g.Players[0]
+	g.ProcessAction(you, PlayerAction{Type: ActionRaise, Amount: you.Chips + you.CurrentBet})
+	g.AdvanceTurn()
+	g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCall})
+	if err := g.DecideRunIt(true); err != nil {
+		t.Fatalf("DecideRunIt returned an error: %v", err)
+	}
+	for g.Phase != PhaseShowdown {
+		g.Advance()
+	}
+	g.DistributePot()
+	g.CleanupHand()
+	if len(g.Runs) != 3 {
+		t.Fatalf("Expected the board to be run 3 times, but got %d runs", len(g.Runs))
+	}
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	if len(replayed.Runs) != 3 {
+		t.Errorf("Expected the replayed board to be run 3 times, but got %d runs", len(replayed.Runs))
+	}
+	for i, p := range g.Players {
+		if replayed.Players[i].Chips != p.Chips {
+			t.Errorf("%s: expected %d chips, but got %d", p.Name, p.Chips, replayed.Players[i].Chips)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package engine
+
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
+// logBoardRunout logs the street just dealt as part of an all-in runout, with
+// the hands of the players still in, which are turned face up once no more
+// betting is possible. Nothing is logged while anyone can still bet.
+func (g *Game) logBoardRunout() {
+	if g.CountNonFoldedPlayers() < 2 || g.CountPlayersAbleToAct() > 1 {
+		return
+	}
+	e := GameEvent{Type: EventBoardRunout, Phase: g.Phase, Board: append([]poker.Card(nil), g.CommunityCards...)}
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusPlaying || p.Status == PlayerStatusAllIn {
+			e.Players = append(e.Players, p.Name)
+			e.Hands = append(e.Hands, append([]poker.Card(nil), p.Hand...))
+		}
+	}
+	g.logEvent(e)
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestAdvance_LogsBoardRunout tests that each street dealt once everyone is
+// all-in is logged with the hands of the players still in, and that the
+// runout is logged the same way when the game is replayed.
+func TestAdvance_LogsBoardRunout(t *testing.T) {
+	g := NewGame([]string{"YOU", "CPU 1", "CPU 2"}, 1000, 50, 100, DifficultyMedium, loadRule(t, "nlh.yml"), false, false, 0)
+	g.Rand = rand.New(rand.NewSource(1))
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionRaise, Amount: 1000})
+	g.AdvanceTurn()
+	g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+	g.AdvanceTurn()
+	g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCall})
+	if !g.IsBettingRoundOver() {
+		t.Fatalf("the betting round is not over with everyone all-in or folded")
+	}
+	folded := ""
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusFolded {
+			folded = p.Name
+		}
+	}
+
+	logged := len(g.Events)
+	for g.Phase != PhaseShowdown {
+		g.Advance()
+	}
+
+	var runouts []GameEvent
+	for _, e := range g.Events[logged:] {
+		if e.Type == EventBoardRunout {
+			runouts = append(runouts, e)
+		}
+	}
+	if len(runouts) != 3 {
+		t.Fatalf("logged %d runout streets, want 3", len(runouts))
+	}
+	for i, want := range []struct {
+		phase GamePhase
+		board int
+	}{{PhaseFlop, 3}, {PhaseTurn, 4}, {PhaseRiver, 5}} {
+		e := runouts[i]
+		if e.Phase != want.phase || len(e.Board) != want.board {
+			t.Errorf("runout %d = %s with %d cards, want %s with %d", i, e.Phase, len(e.Board), want.phase, want.board)
+		}
+		if len(e.Players) != 2 || len(e.Hands) != 2 {
+			t.Fatalf("runout %d shows %v with %d hands, want the 2 players still in", i, e.Players, len(e.Hands))
+		}
+		for j, name := range e.Players {
+			if name == folded {
+				t.Errorf("runout %d shows the hand of %s, who folded", i, name)
+			}
+			if !reflect.DeepEqual(e.Hands[j], g.playerNamed(name).Hand) {
+				t.Errorf("runout %d shows %v for %s, want %v", i, e.Hands[j], name, g.playerNamed(name).Hand)
+			}
+		}
+	}
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Replay returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(replayed.Events, g.Events) {
+		t.Errorf("the replayed game logged different events")
+	}
+}
@@ -0,0 +1,80 @@
+package engine
+
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
+// SessionHighlight is a notable hand made during a session, along with the
+// hand it was made in.
+type SessionHighlight struct {
+	PlayerName string
+	HandID     string
+	HandNumber int
+	Hand       *poker.HandResult
+	HoleCards  []poker.Card
+	Board      []poker.Card
+}
+
+// SessionSummary collects the highlights of a session of hands.
+type SessionSummary struct {
+	// Hands is the number of hands played.
+	Hands int
+	// Players lists the players who made a recorded hand, in order of their
+	// first appearance.
+	Players []string
+	// BestHigh and BestLow hold each player's best high and low hand.
+	BestHigh map[string]*SessionHighlight
+	BestLow  map[string]*SessionHighlight
+	// HandOfTheSession is the best high hand made by anyone, or nil if no hand
+	// was played out on a complete board.
+	HandOfTheSession *SessionHighlight
+}
+
+// SummarizeSession finds each player's best high and low hands across the hand
+// histories of a session, and the hand of the session. Only hands recorded as
+// made on a complete board count. Ties go to the earlier hand.
+func SummarizeSession(histories []*HandHistory) *SessionSummary {
+	summary := &SessionSummary{
+		Hands:    len(histories),
+		BestHigh: make(map[string]*SessionHighlight),
+		BestLow:  make(map[string]*SessionHighlight),
+	}
+	seen := make(map[string]bool)
+	for _, h := range histories {
+		for _, seat := range h.Seats {
+			if seat.High == nil && seat.Low == nil {
+				continue
+			}
+			if !seen[seat.Name] {
+				seen[seat.Name] = true
+				summary.Players = append(summary.Players, seat.Name)
+			}
+			highlight := func(hand *poker.HandResult) *SessionHighlight {
+				return &SessionHighlight{
+					PlayerName: seat.Name,
+					HandID:     h.ID,
+					HandNumber: h.HandNumber,
+					Hand:       hand,
+					HoleCards:  seat.HoleCards,
+					Board:      h.Board,
+				}
+			}
+			if best := summary.BestHigh[seat.Name]; seat.High != nil && (best == nil || poker.CompareHigh(seat.High, best.Hand) > 0) {
+				summary.BestHigh[seat.Name] = highlight(seat.High)
+			}
+			if best := summary.BestLow[seat.Name]; seat.Low != nil && (best == nil || poker.CompareLow(seat.Low, best.Hand) > 0) {
+				summary.BestLow[seat.Name] = highlight(seat.Low)
+			}
+		}
+	}
+
+	for _, name := range summary.Players {
+		best := summary.BestHigh[name]
+		if best == nil {
+			continue
+		}
+		if top := summary.HandOfTheSession; top == nil || poker.CompareHigh(best.Hand, top.Hand) > 0 ||
+			(poker.CompareHigh(best.Hand, top.Hand) == 0 && best.HandID < top.HandID) {
+			summary.HandOfTheSession = best
+		}
+	}
+	return summary
+}
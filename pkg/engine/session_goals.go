@@ -0,0 +1,85 @@
+package engine
+
+import "time"
+
+// SessionGoals are the limits at which the player is offered to end the
+// session: a stop-win and a stop-loss on their net result, in big blinds, and
+// a number of hands. A limit of zero is off.
+type SessionGoals struct {
+	StopWinBB  int `json:"stop_win_bb,omitempty"`
+	StopLossBB int `json:"stop_loss_bb,omitempty"`
+	Hands      int `json:"hands,omitempty"`
+}
+
+// SessionLimit is a session goal that has been reached.
+type SessionLimit int
+
+// SessionLimit constants.
+const (
+	// SessionLimitNone means no goal has been reached.
+	SessionLimitNone SessionLimit = iota
+	// SessionLimitStopWin means the player has won the stop-win or more.
+	SessionLimitStopWin
+	// SessionLimitStopLoss means the player has lost the stop-loss or more.
+	SessionLimitStopLoss
+	// SessionLimitHands means the session has played its number of hands.
+	SessionLimitHands
+)
+
+// String returns the limit's name, e.g. "stop-loss".
+func (l SessionLimit) String() string {
+	return []string{"none", "stop-win", "stop-loss", "hands"}[l]
+}
+
+// IsSet reports whether any limit is on.
+func (s SessionGoals) IsSet() bool {
+	return s.StopWinBB > 0 || s.StopLossBB > 0 || s.Hands > 0
+}
+
+// Reached returns the first limit reached by a session that has netted net
+// chips, at the given big blind, over the given number of hands, or
+// SessionLimitNone.
+func (s SessionGoals) Reached(net, bigBlind, hands int) SessionLimit {
+	switch {
+	case s.StopWinBB > 0 && net >= s.StopWinBB*bigBlind:
+		return SessionLimitStopWin
+	case s.StopLossBB > 0 && -net >= s.StopLossBB*bigBlind:
+		return SessionLimitStopLoss
+	case s.Hands > 0 && hands >= s.Hands:
+		return SessionLimitHands
+	}
+	return SessionLimitNone
+}
+
+// SessionRecord records a finished session in the player's bankroll: how long
+// it lasted, what it netted, the goals it was played with and the one that
+// ended it, if any.
+type SessionRecord struct {
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	// Rule is the abbreviation of the game variant.
+	Rule string `json:"rule"`
+	// BigBlind is the big blind the session started at, by which its goals
+	// and result are measured.
+	BigBlind int          `json:"big_blind"`
+	Hands    int          `json:"hands"`
+	NetChips int          `json:"net_chips"`
+	Goals    SessionGoals `json:"goals"`
+	// EndedBy is the goal the player ended the session at, or
+	// SessionLimitNone if they stopped for another reason.
+	EndedBy SessionLimit `json:"ended_by"`
+	// TimeCharged is the time charge the player paid during the session. It
+	// is already taken out of NetChips.
+	TimeCharged int `json:"time_charged,omitempty"`
+	// Stats are the player's statistics over the session's hands. Sessions
+	// recorded before they were kept leave them empty.
+	Stats SessionStats `json:"stats"`
+}
+
+// NetBB returns the session's net result in big blinds.
+func (r SessionRecord) NetBB() float64 {
+	if r.BigBlind == 0 {
+		return 0
+	}
+	return float64(r.NetChips) / float64(r.BigBlind)
+}
@@ -0,0 +1,27 @@
+package engine
+
+import "testing"
+
+func TestSessionGoals_Reached(t *testing.T) {
+	goals := SessionGoals{StopWinBB: 100, StopLossBB: 50, Hands: 200}
+	for _, tc := range []struct {
+		net, hands int
+		expected   SessionLimit
+	}{
+		{net: 9900, hands: 10, expected: SessionLimitNone},
+		{net: 10000, hands: 10, expected: SessionLimitStopWin},
+		{net: -4900, hands: 10, expected: SessionLimitNone},
+		{net: -5000, hands: 10, expected: SessionLimitStopLoss},
+		{net: 0, hands: 200, expected: SessionLimitHands},
+		// A stop-win or stop-loss is reported before the number of hands.
+		{net: -6000, hands: 250, expected: SessionLimitStopLoss},
+	} {
+		if got := goals.Reached(tc.net, 100, tc.hands); got != tc.expected {
+			t.Errorf("Reached(%d, 100, %d) = %s, want %s", tc.net, tc.hands, got, tc.expected)
+		}
+	}
+
+	if (SessionGoals{}).IsSet() || (SessionGoals{}).Reached(-1000000, 100, 1000) != SessionLimitNone {
+		t.Error("Expected goals of zero to be off")
+	}
+}
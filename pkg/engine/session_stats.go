@@ -0,0 +1,111 @@
+package engine
+
+// SessionStats holds the human player's results over a session of hands. It
+// is kept by the Game as hands are played, and saved with each SessionRecord,
+// so that the sessions of a profile add up to its bankroll.
+type SessionStats struct {
+	// HandsPlayed is the number of hands the player was dealt into.
+	HandsPlayed int `json:"hands_played"`
+	// VoluntaryHands is the number of hands in which the player voluntarily
+	// put chips into the pot pre-flop.
+	VoluntaryHands int `json:"voluntary_hands"`
+	// Showdowns is the number of hands the player took to showdown, and
+	// ShowdownsWon the number of those in which they won chips.
+	Showdowns    int `json:"showdowns"`
+	ShowdownsWon int `json:"showdowns_won"`
+	// BiggestPot is the most chips the player won in a single hand.
+	BiggestPot int `json:"biggest_pot"`
+	// NetChips is what the player won or lost in the hands, rebuys and time
+	// charges aside.
+	NetChips int `json:"net_chips"`
+	// HandReads is the number of opponents' hands the player guessed the
+	// category of in the hand reading exercise, and HandReadsRight the number
+	// of those they guessed right.
+	HandReads      int `json:"hand_reads,omitempty"`
+	HandReadsRight int `json:"hand_reads_right,omitempty"`
+}
+
+// VPIP returns the fraction of hands in which the player voluntarily put
+// chips into the pot.
+func (s SessionStats) VPIP() float64 {
+	return ratio(s.VoluntaryHands, s.HandsPlayed)
+}
+
+// ShowdownWinRate returns the fraction of showdowns the player won chips at.
+func (s SessionStats) ShowdownWinRate() float64 {
+	return ratio(s.ShowdownsWon, s.Showdowns)
+}
+
+// NetPerHand returns the player's average result per hand played, in chips.
+func (s SessionStats) NetPerHand() float64 {
+	return ratio(s.NetChips, s.HandsPlayed)
+}
+
+// HandReadAccuracy returns the fraction of hand reads the player got right.
+func (s SessionStats) HandReadAccuracy() float64 {
+	return ratio(s.HandReadsRight, s.HandReads)
+}
+
+// Add adds other's hands and results to s, keeping the bigger of their
+// biggest pots.
+func (s *SessionStats) Add(other SessionStats) {
+	s.HandsPlayed += other.HandsPlayed
+	s.VoluntaryHands += other.VoluntaryHands
+	s.Showdowns += other.Showdowns
+	s.ShowdownsWon += other.ShowdownsWon
+	s.BiggestPot = max(s.BiggestPot, other.BiggestPot)
+	s.NetChips += other.NetChips
+	s.HandReads += other.HandReads
+	s.HandReadsRight += other.HandReadsRight
+}
+
+// Bankroll adds up the statistics of a profile's recorded sessions.
+func Bankroll(records []SessionRecord) SessionStats {
+	var total SessionStats
+	for _, r := range records {
+		total.Add(r.Stats)
+	}
+	return total
+}
+
+// recordSessionStats adds the hand that has just ended to the human player's
+// session statistics.
+func (g *Game) recordSessionStats() {
+	var seat *SeatRecord
+	for i := range g.History.Seats {
+		if g.History.Seats[i].IsHuman {
+			seat = &g.History.Seats[i]
+		}
+	}
+	if seat == nil {
+		return
+	}
+	stats := &g.HumanStats
+	stats.HandsPlayed++
+	for _, a := range g.History.Actions {
+		voluntary := a.Action == ActionCall || a.Action == ActionBet || a.Action == ActionRaise
+		if a.PlayerName == seat.Name && a.Phase == PhasePreFlop && voluntary {
+			stats.VoluntaryHands++
+			break
+		}
+	}
+	won := 0
+	for _, result := range g.History.Results {
+		if result.PlayerName == seat.Name {
+			won += result.AmountWon
+		}
+	}
+	stats.BiggestPot = max(stats.BiggestPot, won)
+	for _, p := range g.Players {
+		if p.Name != seat.Name {
+			continue
+		}
+		stats.NetChips += p.Chips - seat.StartingChips
+		if p.Status != PlayerStatusFolded && g.CountNonFoldedPlayers() > 1 {
+			stats.Showdowns++
+			if won > 0 {
+				stats.ShowdownsWon++
+			}
+		}
+	}
+}
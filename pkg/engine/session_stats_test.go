@@ -0,0 +1,49 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSessionStats_RecordedEachHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 50000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(3))
+	g.Players[0].Profile = g.Players[3].Profile
+	for i := 0; i < 20; i++ {
+		playLoggedHand(g)
+	}
+
+	stats := g.HumanStats
+	if stats.HandsPlayed != 20 {
+		t.Errorf("Expected 20 hands played, but got %d", stats.HandsPlayed)
+	}
+	if net := g.Players[0].Chips - 50000; stats.NetChips != net {
+		t.Errorf("Expected a net result of %d, but got %d", net, stats.NetChips)
+	}
+	if stats.VoluntaryHands == 0 || stats.VoluntaryHands > stats.HandsPlayed {
+		t.Errorf("Expected between 1 and %d voluntary hands, but got %d", stats.HandsPlayed, stats.VoluntaryHands)
+	}
+	if stats.ShowdownsWon > stats.Showdowns || stats.Showdowns > stats.HandsPlayed {
+		t.Errorf("Expected showdowns won <= showdowns <= hands, but got %d, %d and %d", stats.ShowdownsWon, stats.Showdowns, stats.HandsPlayed)
+	}
+	if stats.BiggestPot < 0 {
+		t.Errorf("Expected a biggest pot of 0 or more, but got %d", stats.BiggestPot)
+	}
+}
+
+func TestBankroll(t *testing.T) {
+	records := []SessionRecord{
+		{Stats: SessionStats{HandsPlayed: 30, VoluntaryHands: 6, Showdowns: 4, ShowdownsWon: 3, BiggestPot: 9000, NetChips: 3000}},
+		{Stats: SessionStats{HandsPlayed: 10, VoluntaryHands: 4, Showdowns: 4, ShowdownsWon: 1, BiggestPot: 12000, NetChips: -1000}},
+		{}, // A session recorded before statistics were kept.
+	}
+	total := Bankroll(records)
+	expected := SessionStats{HandsPlayed: 40, VoluntaryHands: 10, Showdowns: 8, ShowdownsWon: 4, BiggestPot: 12000, NetChips: 2000}
+	if total != expected {
+		t.Errorf("Expected %+v, but got %+v", expected, total)
+	}
+	if total.VPIP() != 0.25 || total.ShowdownWinRate() != 0.5 || total.NetPerHand() != 50 {
+		t.Errorf("Expected a VPIP of 0.25, half the showdowns won and 50 per hand, but got %.3f, %.3f and %.1f",
+			total.VPIP(), total.ShowdownWinRate(), total.NetPerHand())
+	}
+}
@@ -0,0 +1,68 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestSummarizeSession(t *testing.T) {
+	rules := loadRule(t, "pls7.yml")
+	seat := func(name, hole, board string) SeatRecord {
+		holeCards := poker.CardsFromStrings(hole)
+		high, low := poker.EvaluateHand(holeCards, poker.CardsFromStrings(board), rules)
+		return SeatRecord{Name: name, HoleCards: holeCards, High: high, Low: low}
+	}
+	boardA := "2c 3h 7s Kd Kc"
+	boardB := "9h 9d 4c 5s Qh"
+	histories := []*HandHistory{
+		{
+			ID: "20250101-120000-0001", HandNumber: 1, Board: poker.CardsFromStrings(boardA),
+			Seats: []SeatRecord{
+				seat("YOU", "As 4d Ks", boardA),   // Three kings with a 7-4-3-2-A low.
+				seat("CPU 1", "Qc Qs 8h", boardA), // Two pair.
+				// CPU 2 folded, so no hand was recorded.
+				{Name: "CPU 2", HoleCards: poker.CardsFromStrings("Jc Td 6h")},
+			},
+		},
+		{
+			ID: "20250101-120100-0002", HandNumber: 2, Board: poker.CardsFromStrings(boardB),
+			Seats: []SeatRecord{
+				seat("YOU", "Ah 2d 6c", boardB),   // Pair of nines with a 6-5-4-2-A low.
+				seat("CPU 1", "9s 9c 3d", boardB), // Four nines.
+			},
+		},
+	}
+
+	summary := SummarizeSession(histories)
+
+	if summary.Hands != 2 {
+		t.Errorf("Expected 2 hands, but got %d", summary.Hands)
+	}
+	if len(summary.Players) != 2 || summary.Players[0] != "YOU" || summary.Players[1] != "CPU 1" {
+		t.Errorf("Expected players [YOU CPU 1], but got %v", summary.Players)
+	}
+	if best := summary.BestHigh["YOU"]; best.HandNumber != 1 || best.Hand.Rank != poker.ThreeOfAKind {
+		t.Errorf("Expected YOU's best high to be trips in hand #1, but got %v in hand #%d", best.Hand.Rank, best.HandNumber)
+	}
+	if best := summary.BestLow["YOU"]; best == nil || best.HandNumber != 2 {
+		t.Errorf("Expected YOU's best low to be the 6-low in hand #2, but got %+v", best)
+	}
+	if summary.BestLow["CPU 1"] != nil {
+		t.Errorf("Expected no low for CPU 1, but got %+v", summary.BestLow["CPU 1"])
+	}
+
+	top := summary.HandOfTheSession
+	if top == nil || top.PlayerName != "CPU 1" || top.Hand.Rank != poker.FourOfAKind || top.HandID != "20250101-120100-0002" {
+		t.Fatalf("Expected CPU 1's four of a kind to be the hand of the session, but got %+v", top)
+	}
+	if len(top.Board) != 5 || len(top.HoleCards) != 3 {
+		t.Errorf("Expected the full board and hole cards, but got %v and %v", top.Board, top.HoleCards)
+	}
+}
+
+func TestSummarizeSession_NoCompleteHands(t *testing.T) {
+	summary := SummarizeSession([]*HandHistory{{ID: "20250101-120000-0001", Seats: []SeatRecord{{Name: "YOU"}}}})
+	if summary.HandOfTheSession != nil || len(summary.Players) != 0 {
+		t.Errorf("Expected no highlights, but got %+v", summary)
+	}
+}
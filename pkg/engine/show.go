@@ -0,0 +1,109 @@
+package engine
+
+import "fmt"
+
+// cpuShowsBluffing is the bluffing frequency from which a CPU shows its
+// losing hands at showdown: a loose player is glad to advertise. CPUs that
+// bluff less muck them, as most players do.
+const cpuShowsBluffing = 0.3
+
+// CanMuck reports whether the player may muck their hand at showdown instead
+// of showing it, given the pot distribution: they reached showdown, won
+// nothing, and were never called. The last player in the hand whose bet or
+// raise was called must show, even if the later streets were checked down.
+func (g *Game) CanMuck(player *Player, results []DistributionResult) bool {
+	inHand := player.Status == PlayerStatusPlaying || player.Status == PlayerStatusAllIn
+	if !inHand || player.Mucked || g.CountNonFoldedPlayers() < 2 || player == g.calledAggressor {
+		return false
+	}
+	for _, result := range results {
+		if result.PlayerName == player.Name && result.AmountWon > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// MuckLosingHands mucks the losing hands that may be mucked at showdown: the
+// human players' when AutoMuck is enabled, and the CPUs' unless their profile
+// shows them. Without AutoMuck, the human decides with MuckHand.
+func (g *Game) MuckLosingHands(results []DistributionResult) {
+	var losers []string
+	for _, p := range g.getShowdownPlayers() {
+		if !g.CanMuck(p, results) {
+			continue
+		}
+		mucks := g.AutoMuck
+		if p.IsCPU {
+			mucks = p.Profile == nil || p.Profile.BluffingFrequency < cpuShowsBluffing
+		}
+		if mucks {
+			losers = append(losers, p.Name)
+		}
+	}
+	if len(losers) > 0 {
+		g.muckHands(losers)
+	}
+}
+
+// MuckHand mucks the player's hand at showdown instead of showing it. It
+// returns an error if the player may not muck (see CanMuck).
+func (g *Game) MuckHand(player *Player, results []DistributionResult) error {
+	if !g.CanMuck(player, results) {
+		return fmt.Errorf("%s cannot muck now", player.Name)
+	}
+	return g.muckHands([]string{player.Name})
+}
+
+// muckHands mucks the named players' hands.
+func (g *Game) muckHands(names []string) error {
+	g.logEvent(GameEvent{Type: EventHandsMucked, Players: names})
+	for _, name := range names {
+		p := g.playerNamed(name)
+		if p == nil {
+			return fmt.Errorf("unknown player %q", name)
+		}
+		p.Mucked = true
+	}
+	return nil
+}
+
+// CanShowPartial reports whether the player may reveal a single hole card. This
+// is allowed once the hand is over for a player who mucked at showdown or who
+// won the pot uncontested.
+func (g *Game) CanShowPartial(player *Player) bool {
+	if player.Status == PlayerStatusEliminated || len(player.Hand) == 0 || len(player.ShownCards) > 0 {
+		return false
+	}
+	wonUncontested := g.CountNonFoldedPlayers() == 1 && player.Status != PlayerStatusFolded
+	return player.Mucked || wonUncontested
+}
+
+// ShowPartial reveals one of the player's hole cards after the hand is over.
+// It returns an ActionEvent describing the revealed card.
+func (g *Game) ShowPartial(player *Player, action PlayerAction) (*ActionEvent, error) {
+	if action.Type != ActionShowPartial {
+		return nil, fmt.Errorf("unexpected action type for showing a card: %v", action.Type)
+	}
+	if !g.CanShowPartial(player) {
+		return nil, fmt.Errorf("%s cannot show a card now", player.Name)
+	}
+	if action.CardIndex < 0 || action.CardIndex >= len(player.Hand) {
+		return nil, fmt.Errorf("card index %d is out of range for a %d-card hand", action.CardIndex, len(player.Hand))
+	}
+
+	g.logEvent(GameEvent{Type: EventCardShown, Player: player.Name, Action: action})
+	card := player.Hand[action.CardIndex]
+	player.ShownCards = append(player.ShownCards, card)
+	player.LastActionDesc = fmt.Sprintf("Shows %s", card)
+	if g.History != nil {
+		for i := range g.History.Seats {
+			if g.History.Seats[i].Name == player.Name {
+				g.History.Seats[i].ShownCards = player.ShownCards
+			}
+		}
+	}
+	event := &ActionEvent{PlayerName: player.Name, Action: ActionShowPartial, Cards: player.ShownCards}
+	g.publish(event)
+	return event, nil
+}
@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+)
+
+func TestMuckLosingHands(t *testing.T) {
+	newShowdownGame := func() (*Game, []DistributionResult) {
+		g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3", "CPU 4"}, 10000, 50, 100)
+		tight, loose := builtInProfile("Tight-Passive"), builtInProfile("Loose-Aggressive")
+		g.Players[1].Profile, g.Players[2].Profile, g.Players[3].Profile = &tight, &loose, &tight
+		// CPU 3 bet the river and was called.
+		g.calledAggressor = g.Players[3]
+		return g, []DistributionResult{{PlayerName: "CPU 4", AmountWon: 300, HandDesc: "High: Flush"}}
+	}
+
+	g, results := newShowdownGame()
+	g.AutoMuck = true
+	g.MuckLosingHands(results)
+	for i, want := range []bool{true, true, false, false, false} {
+		if p := g.Players[i]; p.Mucked != want {
+			t.Errorf("Expected %s's hand to be mucked %v, but got %v", p.Name, want, p.Mucked)
+		}
+	}
+
+	g, results = newShowdownGame()
+	g.MuckLosingHands(results)
+	if g.Players[0].Mucked {
+		t.Error("Expected no muck for the human when AutoMuck is disabled")
+	}
+	if err := g.MuckHand(g.Players[0], results); err != nil || !g.Players[0].Mucked {
+		t.Errorf("Expected the human to muck on request, but got %v", err)
+	}
+	if err := g.MuckHand(g.Players[3], results); err == nil {
+		t.Error("Expected the player who was called to have to show")
+	}
+	if err := g.MuckHand(g.Players[4], results); err == nil {
+		t.Error("Expected the winner to have to show")
+	}
+}
+
+// TestCanMuck_CalledOnAnEarlierStreet tests that a player whose flop bet was
+// called must show at showdown after the turn and river are checked down.
+func TestCanMuck_CalledOnAnEarlierStreet(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	playStreet := func(bet bool) (bettor *Player) {
+		for !g.IsBettingRoundOver() {
+			p := g.CurrentPlayer()
+			action := PlayerAction{Type: ActionCheck}
+			if g.BetToCall > p.CurrentBet {
+				action = PlayerAction{Type: ActionCall}
+			} else if bet && bettor == nil {
+				minBet, _ := g.CalculateBettingLimits()
+				action, bettor = PlayerAction{Type: ActionBet, Amount: minBet}, p
+			}
+			g.ProcessAction(p, action)
+			g.AdvanceTurn()
+		}
+		g.Advance()
+		if g.Phase != PhaseShowdown {
+			g.PrepareNewBettingRound()
+		}
+		return bettor
+	}
+	playStreet(false)
+	bettor := playStreet(true)
+	playStreet(false)
+	playStreet(false)
+	if g.Phase != PhaseShowdown {
+		t.Fatalf("Expected the hand to reach the showdown, but it is at %v", g.Phase)
+	}
+
+	caller := g.Players[0]
+	if caller == bettor {
+		caller = g.Players[1]
+	}
+	results := []DistributionResult{{PlayerName: caller.Name, AmountWon: g.Pot}}
+	if g.CanMuck(bettor, results) {
+		t.Errorf("Expected %s, whose flop bet was called, to have to show", bettor.Name)
+	}
+	if err := g.MuckHand(bettor, results); err == nil || bettor.Mucked {
+		t.Errorf("Expected %s not to be allowed to muck, but got %v", bettor.Name, err)
+	}
+}
+
+func TestShowPartial(t *testing.T) {
+	newHandOverGame := func() *Game {
+		g := newGameForBettingTests([]string{"YOU", "CPU 1"}, 10000, 50, 100)
+		g.Players[0].Hand = poker.CardsFromStrings("As Kd 7c")
+		g.Players[1].Hand = poker.CardsFromStrings("2h 3h 4h")
+		return g
+	}
+
+	t.Run("mucked hand may show one card", func(t *testing.T) {
+		g := newHandOverGame()
+		g.Players[0].Mucked = true
+
+		event, err := g.ShowPartial(g.Players[0], PlayerAction{Type: ActionShowPartial, CardIndex: 1})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if event.Action != ActionShowPartial || len(event.Cards) != 1 || event.Cards[0] != g.Players[0].Hand[1] {
+			t.Errorf("unexpected event: %+v", event)
+		}
+		if g.CanShowPartial(g.Players[0]) {
+			t.Error("expected only one card to be shown per hand")
+		}
+	})
+
+	t.Run("uncontested winner may show one card", func(t *testing.T) {
+		g := newHandOverGame()
+		g.Players[1].Status = PlayerStatusFolded
+
+		if !g.CanShowPartial(g.Players[0]) {
+			t.Error("expected the uncontested winner to be able to show a card")
+		}
+		if g.CanShowPartial(g.Players[1]) {
+			t.Error("expected a folded player not to be able to show a card")
+		}
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		g := newHandOverGame()
+		if _, err := g.ShowPartial(g.Players[0], PlayerAction{Type: ActionShowPartial}); err == nil {
+			t.Error("expected an error when the hand is still contested")
+		}
+
+		g.Players[0].Mucked = true
+		if _, err := g.ShowPartial(g.Players[0], PlayerAction{Type: ActionShowPartial, CardIndex: 3}); err == nil {
+			t.Error("expected an error for an out-of-range card index")
+		}
+		if _, err := g.ShowPartial(g.Players[0], PlayerAction{Type: ActionFold}); err == nil {
+			t.Error("expected an error for a non-show action")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package engine
+
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
+// ShownHand is a hand a player showed down, kept in the session's showdown
+// gallery so that the human can look back at what an opponent turned over.
+type ShownHand struct {
+	HandNumber int
+	HandID     string
+	HoleCards  []poker.Card
+	Board      []poker.Card
+	// High and Low are the hands made, as recorded in the hand history.
+	High *poker.HandResult
+	Low  *poker.HandResult
+	// Won is the amount the player won from the pot, or 0 if they lost.
+	Won int
+}
+
+// recordShownHands adds the hands shown down in the current hand to the
+// showdown gallery. Hands that were folded or mucked were never seen at the
+// table, so they are left out.
+func (g *Game) recordShownHands() {
+	if g.History == nil {
+		return
+	}
+	for _, seat := range g.History.Seats {
+		if !seat.Showdown {
+			continue
+		}
+		shown := ShownHand{
+			HandNumber: g.History.HandNumber,
+			HandID:     g.History.ID,
+			HoleCards:  seat.HoleCards,
+			Board:      g.History.Board,
+			High:       seat.High,
+			Low:        seat.Low,
+		}
+		for _, result := range g.History.Results {
+			if result.PlayerName == seat.Name {
+				shown.Won += result.AmountWon
+			}
+		}
+		if g.ShowdownGallery == nil {
+			g.ShowdownGallery = make(map[string][]ShownHand)
+		}
+		g.ShowdownGallery[seat.Name] = append(g.ShowdownGallery[seat.Name], shown)
+	}
+}
@@ -0,0 +1,36 @@
+package engine
+
+import "testing"
+
+func TestRecordShownHands_KeepsShowdownsOnly(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "NLH")
+	g.History = &HandHistory{
+		ID:         "20250101-120000-0007",
+		HandNumber: 7,
+		Board:      mustParseCards(t, "Ks 9d 4c 2h 2s"),
+		Seats: []SeatRecord{
+			// YOU mucked the losing hand, and CPU2 folded.
+			{Name: "YOU", IsHuman: true, HoleCards: mustParseCards(t, "Qh Jh")},
+			{Name: "CPU1", Showdown: true, HoleCards: mustParseCards(t, "Kd Kh")},
+			{Name: "CPU2", HoleCards: mustParseCards(t, "7c 6c")},
+		},
+		Results: []DistributionResult{{PlayerName: "CPU1", AmountWon: 6000, HandDesc: "High: Full House"}},
+	}
+
+	g.recordShownHands()
+
+	if len(g.ShowdownGallery) != 1 {
+		t.Fatalf("Expected only CPU1's hand in the gallery, but got %v", g.ShowdownGallery)
+	}
+	shown := g.ShowdownGallery["CPU1"]
+	if len(shown) != 1 || shown[0].HandNumber != 7 || shown[0].Won != 6000 || len(shown[0].HoleCards) != 2 || len(shown[0].Board) != 5 {
+		t.Errorf("Expected CPU1's winning kings from hand #7, but got %+v", shown)
+	}
+
+	g.History.HandNumber = 8
+	g.History.Results = nil
+	g.recordShownHands()
+	if shown := g.ShowdownGallery["CPU1"]; len(shown) != 2 || shown[1].HandNumber != 8 || shown[1].Won != 0 {
+		t.Errorf("Expected a second, losing showdown for CPU1, but got %+v", shown)
+	}
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/config"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math/rand"
+	"sort"
+)
+
+// SimulationSetup describes the games of a headless simulation between CPUs.
+type SimulationSetup struct {
+	Rules      *poker.GameRules
+	Difficulty Difficulty
+	// Profiles is the set the CPUs' profiles come from, or nil for the
+	// built-in profiles.
+	Profiles *config.AIProfileSet
+	// Players is the number of CPUs at the table, from 2 to MaxTableSize.
+	Players                            int
+	InitialChips, SmallBlind, BigBlind int
+	// Hands is the number of hands to play over all the games.
+	Hands int
+}
+
+// ProfileResult is how the CPUs of one profile did over a simulation.
+type ProfileResult struct {
+	Profile string
+	// Hands counts the hands its CPUs were dealt in, and HandsWon the hands
+	// they won chips in.
+	Hands, HandsWon int
+	// Net is the chips its CPUs won, less the chips they lost.
+	Net int
+	// Places counts the games it finished in each place: Places[0] the games
+	// it won, Places[1] the games it finished second, and so on. Only the
+	// games played to a single winner count.
+	Places []int
+}
+
+// WinRate is the fraction of the hands it was dealt in that the profile won
+// chips in.
+func (p *ProfileResult) WinRate() float64 {
+	if p.Hands == 0 {
+		return 0
+	}
+	return float64(p.HandsWon) / float64(p.Hands)
+}
+
+// BBPer100 is the big blinds the profile won per 100 hands it was dealt in.
+func (p *ProfileResult) BBPer100(bigBlind int) float64 {
+	if p.Hands == 0 || bigBlind <= 0 {
+		return 0
+	}
+	return float64(p.Net) / float64(bigBlind) / float64(p.Hands) * 100
+}
+
+// SimulationReport is the result of a headless simulation.
+type SimulationReport struct {
+	// Hands is the number of hands played, and Games the number of games
+	// played to a single winner. A game cut short by the end of the
+	// simulation is not counted in Games.
+	Hands, Games int
+	BigBlind     int
+	// Profiles holds the result of every profile that played, by name.
+	Profiles []*ProfileResult
+}
+
+// Simulate plays hands between CPUs only, with no one to prompt and no
+// pauses, and adds up how each profile did. Each game seats setup.Players
+// CPUs from the lineup of the difficulty, in an order shuffled with r, at
+// fixed blinds, and is played until one CPU has all the chips; then a new
+// game starts, until setup.Hands hands have been played. It stops early, with
+// the hands played so far, if ctx is canceled.
+func Simulate(ctx context.Context, setup SimulationSetup, r *rand.Rand) (*SimulationReport, error) {
+	if setup.Players < 2 || setup.Players > MaxTableSize {
+		return nil, fmt.Errorf("a simulation needs 2 to %d players, got %d", MaxTableSize, setup.Players)
+	}
+	if setup.Hands < 1 {
+		return nil, fmt.Errorf("a simulation needs at least 1 hand, got %d", setup.Hands)
+	}
+	names := make([]string, setup.Players)
+	for i := range names {
+		names[i] = fmt.Sprintf("CPU %d", i+1)
+	}
+	profiles := setup.Profiles
+	if profiles == nil {
+		profiles = config.DefaultAIProfiles()
+	}
+
+	report := &SimulationReport{BigBlind: setup.BigBlind}
+	results := make(map[string]*ProfileResult)
+	result := func(p *Player) *ProfileResult {
+		res, ok := results[p.Profile.Name]
+		if !ok {
+			res = &ProfileResult{Profile: p.Profile.Name, Places: make([]int, setup.Players)}
+			results[p.Profile.Name] = res
+		}
+		return res
+	}
+
+	var g *Game
+	// places holds the places of the players busted in the game so far,
+	// counted once it is played to a single winner.
+	var places map[*Player]int
+	for report.Hands < setup.Hands && ctx.Err() == nil {
+		if g == nil {
+			places = make(map[*Player]int, setup.Players)
+			g = NewGame(names, setup.InitialChips, setup.SmallBlind, setup.BigBlind, setup.Difficulty, setup.Rules, false, false, 0)
+			if err := g.UseAIProfiles(profiles); err != nil {
+				return nil, err
+			}
+			g.Rand = r
+			r.Shuffle(len(g.Players), func(i, j int) {
+				g.Players[i].Profile, g.Players[j].Profile = g.Players[j].Profile, g.Players[i].Profile
+			})
+		}
+
+		stacks := make(map[*Player]int, len(g.Players))
+		for _, p := range g.Players {
+			if p.Status != PlayerStatusEliminated {
+				stacks[p] = p.Chips
+			}
+		}
+		g.PlayCPUHand(CPUHandHooks{})
+		report.Hands++
+
+		var busted []*Player
+		for _, p := range g.Players {
+			before, dealt := stacks[p]
+			if !dealt {
+				continue
+			}
+			res := result(p)
+			res.Hands++
+			res.Net += p.Chips - before
+			if p.Chips > before {
+				res.HandsWon++
+			}
+			if p.Status == PlayerStatusEliminated {
+				busted = append(busted, p)
+			}
+		}
+		// Players busted in the same hand are placed by the stacks they
+		// started it with, the bigger stack finishing higher.
+		sort.SliceStable(busted, func(i, j int) bool { return stacks[busted[i]] > stacks[busted[j]] })
+		remaining := g.CountRemainingPlayers()
+		for i, p := range busted {
+			places[p] = remaining + i
+		}
+		if remaining <= 1 {
+			for _, p := range g.Players {
+				result(p).Places[places[p]]++
+			}
+			report.Games++
+			g = nil
+		}
+	}
+
+	for _, res := range results {
+		report.Profiles = append(report.Profiles, res)
+	}
+	sort.Slice(report.Profiles, func(i, j int) bool { return report.Profiles[i].Profile < report.Profiles[j].Profile })
+	return report, nil
+}
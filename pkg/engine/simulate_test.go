@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	setup := SimulationSetup{
+		Rules:        loadRule(t, "nlh.yml"),
+		Difficulty:   DifficultyMedium,
+		Players:      3,
+		InitialChips: 2000,
+		SmallBlind:   50,
+		BigBlind:     100,
+		Hands:        300,
+	}
+	report, err := Simulate(context.Background(), setup, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Simulate returned an error: %v", err)
+	}
+	if report.Hands != 300 || report.Games == 0 {
+		t.Fatalf("Expected 300 hands over at least one whole game, but got %d hands and %d games", report.Hands, report.Games)
+	}
+
+	hands, net, wins, places := 0, 0, 0, 0
+	for _, p := range report.Profiles {
+		hands += p.Hands
+		net += p.Net
+		wins += p.Places[0]
+		for _, n := range p.Places {
+			places += n
+		}
+		if p.WinRate() < 0 || p.WinRate() > 1 {
+			t.Errorf("%s: win rate %v is not a fraction", p.Profile, p.WinRate())
+		}
+	}
+	// Every chip won was lost by someone, but a game cut short by the end of
+	// the simulation still has its chips on the table.
+	if hands < report.Hands*2 || hands > report.Hands*3 {
+		t.Errorf("Expected 2 or 3 players in each of %d hands, but counted %d", report.Hands, hands)
+	}
+	if net != 0 {
+		t.Errorf("Expected the chips won and lost to balance, but the profiles are %d up", net)
+	}
+	if wins != report.Games || places != report.Games*3 {
+		t.Errorf("Expected %d games won and %d places, but got %d and %d", report.Games, report.Games*3, wins, places)
+	}
+
+	again, err := Simulate(context.Background(), setup, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Simulate returned an error: %v", err)
+	}
+	if !reflect.DeepEqual(again, report) {
+		t.Error("Expected the same seed to simulate the same hands")
+	}
+}
+
+func TestSimulate_InvalidSetup(t *testing.T) {
+	rules := loadRule(t, "nlh.yml")
+	for _, setup := range []SimulationSetup{
+		{Rules: rules, Players: 1, InitialChips: 1000, SmallBlind: 5, BigBlind: 10, Hands: 10},
+		{Rules: rules, Players: MaxTableSize + 1, InitialChips: 1000, SmallBlind: 5, BigBlind: 10, Hands: 10},
+		{Rules: rules, Players: 6, InitialChips: 1000, SmallBlind: 5, BigBlind: 10},
+	} {
+		if _, err := Simulate(context.Background(), setup, rand.New(rand.NewSource(1))); err == nil {
+			t.Errorf("Expected an error for %+v", setup)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ChipViolation records chips that appeared or disappeared outside the
+// sanctioned chip flows: betting, pot distribution, rebuys, and add-ons.
+// Table stakes mean a stack can only change through these flows, so any
+// violation points at a bug such as a double award in pot distribution.
+type ChipViolation struct {
+	HandNumber int
+	// PlayerName is the player whose stack changed, or empty if the violation
+	// concerns the table as a whole.
+	PlayerName string
+	Expected   int
+	Actual     int
+	// Context describes when the violation was detected.
+	Context string
+}
+
+// String describes the violation, e.g.
+// "hand #3, between hands: CPU 1 has 1,500 chips, expected 1,000".
+func (v ChipViolation) String() string {
+	who := "table"
+	if v.PlayerName != "" {
+		who = v.PlayerName
+	}
+	return fmt.Sprintf("hand #%d, %s: %s has %d chips, expected %d", v.HandNumber, v.Context, who, v.Actual, v.Expected)
+}
+
+// Rebuy gives a busted player a fresh stack between hands. It is one of the
+// two sanctioned ways, with AddOn, to bring new chips into the game.
+func (g *Game) Rebuy(player *Player, amount int) error {
+	if g.handInProgress {
+		return errors.New("rebuys are only allowed between hands")
+	}
+	if player.Chips > 0 {
+		return fmt.Errorf("%s still has chips and cannot rebuy", player.Name)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("rebuy amount must be positive, got %d", amount)
+	}
+	g.logEvent(GameEvent{Type: EventRebuy, Player: player.Name, Amount: amount})
+	player.Status = PlayerStatusPlaying
+	g.addChips(player, amount)
+	return nil
+}
+
+// AddOn adds chips to an active player's stack between hands.
+func (g *Game) AddOn(player *Player, amount int) error {
+	if g.handInProgress {
+		return errors.New("add-ons are only allowed between hands")
+	}
+	if player.Status == PlayerStatusEliminated {
+		return fmt.Errorf("%s has been eliminated; use a rebuy instead", player.Name)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("add-on amount must be positive, got %d", amount)
+	}
+	g.logEvent(GameEvent{Type: EventAddOn, Player: player.Name, Amount: amount})
+	g.addChips(player, amount)
+	return nil
+}
+
+// addChips brings new chips into the game and updates the expected totals.
+func (g *Game) addChips(player *Player, amount int) {
+	player.Chips += amount
+	g.TotalInitialChips += amount
+	if g.stacksAfterHand != nil {
+		g.stacksAfterHand[player] = player.Chips
+	}
+}
+
+// checkChipConservation verifies that every chip in the game is either in a
+// player's stack or in the pot. A discrepancy is reported once, in the hand
+// where it happened, and then accepted as the new baseline.
+func (g *Game) checkChipConservation(context string) {
+	// Chips in the insurance pool are still in play.
+	total := g.Pot + g.InsurancePool
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	expected := g.TotalInitialChips + g.chipDrift
+	if total != expected {
+		g.reportChipViolation(ChipViolation{
+			HandNumber: g.HandCount, Expected: expected, Actual: total, Context: context,
+		})
+		g.chipDrift += total - expected
+	}
+}
+
+// recordStacksAfterHand remembers every stack at the end of a hand, so that
+// any change before the next hand can be caught.
+func (g *Game) recordStacksAfterHand() {
+	g.stacksAfterHand = make(map[*Player]int, len(g.Players))
+	for _, p := range g.Players {
+		g.stacksAfterHand[p] = p.Chips
+	}
+}
+
+// checkStacksBetweenHands reports every stack that changed since the end of
+// the previous hand without a rebuy or add-on.
+func (g *Game) checkStacksBetweenHands() {
+	for _, p := range g.Players {
+		expected, ok := g.stacksAfterHand[p]
+		if ok && p.Chips != expected {
+			g.reportChipViolation(ChipViolation{
+				HandNumber: g.HandCount, PlayerName: p.Name, Expected: expected, Actual: p.Chips, Context: "between hands",
+			})
+		}
+	}
+}
+
+// reportChipViolation logs a violation and keeps it for later inspection.
+func (g *Game) reportChipViolation(v ChipViolation) {
+	logrus.Errorf("Table stakes violated: %s", v)
+	g.ChipViolations = append(g.ChipViolations, v)
+}
@@ -0,0 +1,115 @@
+package engine
+
+import "testing"
+
+func playFoldedHand(g *Game) {
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+}
+
+func TestTableStakes_NoViolationsInNormalPlay(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	for i := 0; i < 3; i++ {
+		playFoldedHand(g)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("expected no violations, got %v", g.ChipViolations)
+	}
+}
+
+func TestTableStakes_DetectsChangesBetweenHands(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	playFoldedHand(g)
+
+	g.Players[1].Chips += 500 // Going south: chips added outside a sanctioned flow.
+	playFoldedHand(g)
+
+	if len(g.ChipViolations) != 2 {
+		t.Fatalf("expected a between-hands and a conservation violation, got %v", g.ChipViolations)
+	}
+	if v := g.ChipViolations[0]; v.PlayerName != "CPU 1" || v.Actual-v.Expected != 500 || v.HandNumber != 2 {
+		t.Errorf("unexpected between-hands violation: %+v", v)
+	}
+	if v := g.ChipViolations[1]; v.PlayerName != "" || v.Actual-v.Expected != 500 {
+		t.Errorf("unexpected conservation violation: %+v", v)
+	}
+
+	// The discrepancy is reported once, not again in every later hand.
+	playFoldedHand(g)
+	if len(g.ChipViolations) != 2 {
+		t.Errorf("expected no new violations, got %v", g.ChipViolations[2:])
+	}
+}
+
+func TestRebuyAndAddOn(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	playFoldedHand(g)
+
+	busted := g.Players[0]
+	g.Players[1].Chips += busted.Chips
+	busted.Chips = 0
+	busted.Status = PlayerStatusEliminated
+	g.recordStacksAfterHand() // Treat the transfer above as the result of a hand.
+
+	if err := g.AddOn(busted, 1000); err == nil {
+		t.Error("expected an eliminated player's add-on to be rejected")
+	}
+	if err := g.Rebuy(busted, 10000); err != nil {
+		t.Fatalf("unexpected rebuy error: %v", err)
+	}
+	if err := g.Rebuy(busted, 10000); err == nil {
+		t.Error("expected a rebuy with chips left to be rejected")
+	}
+	if err := g.AddOn(g.Players[2], 5000); err != nil {
+		t.Fatalf("unexpected add-on error: %v", err)
+	}
+	if g.TotalInitialChips != 45000 {
+		t.Errorf("expected 45,000 chips in play, got %d", g.TotalInitialChips)
+	}
+
+	playFoldedHand(g)
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("expected sanctioned chips to cause no violations, got %v", g.ChipViolations)
+	}
+
+	g.StartNewHand()
+	if err := g.AddOn(g.Players[2], 5000); err == nil {
+		t.Error("expected an add-on during a hand to be rejected")
+	}
+}
+
+// TestCleanupHand_ReportsDistributionViolations tests that a violation found
+// when the pot is distributed is reported at the end of the hand, although
+// the end-of-hand check, which takes it as the new baseline, finds nothing.
+func TestCleanupHand_ReportsDistributionViolations(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.Pot += 300 // Chips that came from nowhere.
+	g.AwardPotToLastPlayer()
+	var end *HandEndEvent
+	g.OnTableEvent = func(e TableEvent) {
+		if e, ok := e.(*HandEndEvent); ok {
+			end = e
+		}
+	}
+
+	g.CleanupHand()
+
+	if end == nil || len(end.ChipViolations) != 1 {
+		t.Fatalf("expected the end of the hand to report one violation, got %+v", end)
+	}
+	if v := end.ChipViolations[0]; v.Context != "after pot distribution" || v.Actual-v.Expected != 300 {
+		t.Errorf("unexpected violation: %+v", v)
+	}
+}
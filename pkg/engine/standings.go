@@ -0,0 +1,49 @@
+package engine
+
+import "sort"
+
+// Standing is a player's place at the table by their stack, as when a match
+// played to a hands limit ends.
+type Standing struct {
+	PlayerName string
+	// Place is 1 for the chip leader. Players with equal stacks share a place.
+	Place int
+	Chips int
+}
+
+// HandsLimitReached reports whether the game has played its HandsLimit, and
+// so is over between hands with the chip leader as the winner.
+func (g *Game) HandsLimitReached() bool {
+	return g.HandsLimit > 0 && g.HandCount >= g.HandsLimit && !g.handInProgress
+}
+
+// Standings ranks the players at the table by their stacks, the biggest
+// first. Players with equal stacks share a place, and keep their seat order.
+// Eliminated players, who have no chips, share the last place.
+func (g *Game) Standings() []Standing {
+	standings := make([]Standing, len(g.Players))
+	for i, p := range g.Players {
+		standings[i] = Standing{PlayerName: p.Name, Chips: p.Chips}
+	}
+	sort.SliceStable(standings, func(i, j int) bool { return standings[i].Chips > standings[j].Chips })
+	for i := range standings {
+		if i > 0 && standings[i].Chips == standings[i-1].Chips {
+			standings[i].Place = standings[i-1].Place
+		} else {
+			standings[i].Place = i + 1
+		}
+	}
+	return standings
+}
+
+// ChipLeaders returns the names of the players sharing first place in the
+// standings: the winners of a match played to a hands limit.
+func (g *Game) ChipLeaders() []string {
+	var leaders []string
+	for _, s := range g.Standings() {
+		if s.Place == 1 {
+			leaders = append(leaders, s.PlayerName)
+		}
+	}
+	return leaders
+}
@@ -0,0 +1,59 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandings(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100)
+	g.Players[0].Chips = 12000
+	g.Players[1].Chips = 0
+	g.Players[1].Status = PlayerStatusEliminated
+	g.Players[2].Chips = 16000
+	g.Players[3].Chips = 12000
+
+	expected := []Standing{
+		{PlayerName: "CPU 2", Place: 1, Chips: 16000},
+		{PlayerName: "YOU", Place: 2, Chips: 12000},
+		{PlayerName: "CPU 3", Place: 2, Chips: 12000},
+		{PlayerName: "CPU 1", Place: 4, Chips: 0},
+	}
+	if got := g.Standings(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected standings %v, but got %v", expected, got)
+	}
+	if got := g.ChipLeaders(); !reflect.DeepEqual(got, []string{"CPU 2"}) {
+		t.Errorf("Expected CPU 2 to lead, but got %v", got)
+	}
+
+	g.Players[0].Chips = 16000
+	if got := g.ChipLeaders(); !reflect.DeepEqual(got, []string{"YOU", "CPU 2"}) {
+		t.Errorf("Expected YOU and CPU 2 to share the lead, but got %v", got)
+	}
+}
+
+func TestHandsLimitReached(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	if playFoldedHand(g); g.HandsLimitReached() {
+		t.Error("Expected no hands limit to be reached without one")
+	}
+
+	g.HandsLimit = 3
+	if playFoldedHand(g); g.HandsLimitReached() {
+		t.Fatalf("Expected the hands limit not to be reached after %d hands", g.HandCount)
+	}
+	g.StartNewHand()
+	if g.HandsLimitReached() {
+		t.Error("Expected the game not to end during the last hand")
+	}
+	g.PrepareNewBettingRound()
+	for g.CountNonFoldedPlayers() > 1 {
+		g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionFold})
+		g.AdvanceTurn()
+	}
+	g.AwardPotToLastPlayer()
+	g.CleanupHand()
+	if !g.HandsLimitReached() {
+		t.Errorf("Expected the hands limit to be reached after %d hands", g.HandCount)
+	}
+}
@@ -0,0 +1,193 @@
+package engine
+
+import "fmt"
+
+// StraddleSeat decides which seat may straddle: post a blind of twice the big
+// blind before the cards are dealt, and act last pre-flop.
+type StraddleSeat int
+
+// StraddleSeat constants.
+const (
+	// StraddleNone plays without straddles.
+	StraddleNone StraddleSeat = iota
+	// StraddleUTG lets the player under the gun, after the big blind, straddle.
+	StraddleUTG
+	// StraddleButton lets the player on the button straddle. Pre-flop, the
+	// action then starts under the gun and skips the button, which acts after
+	// the blinds.
+	StraddleButton
+)
+
+// straddleSeatNames are the names of the straddle seats, as used on the
+// command line.
+var straddleSeatNames = []string{"none", "utg", "button"}
+
+// String returns the straddle seat's name, e.g. "utg".
+func (s StraddleSeat) String() string {
+	return straddleSeatNames[s]
+}
+
+// ParseStraddleSeat returns the straddle seat with the given name.
+func ParseStraddleSeat(name string) (StraddleSeat, error) {
+	for i, n := range straddleSeatNames {
+		if n == name {
+			return StraddleSeat(i), nil
+		}
+	}
+	return StraddleNone, fmt.Errorf("unknown straddle seat %q (available: %v)", name, straddleSeatNames)
+}
+
+// StraddleSeatNames returns the names of the straddle seats.
+func StraddleSeatNames() []string {
+	return append([]string(nil), straddleSeatNames...)
+}
+
+// straddleMinStackBB is the smallest stack, in big blinds, with which a CPU
+// straddles.
+const straddleMinStackBB = 40
+
+// StraddleAmount returns the size of a straddle: twice the big blind.
+func (g *Game) StraddleAmount() int {
+	return 2 * g.BigBlind
+}
+
+// straddlePos returns the seat that may straddle in the current hand, or -1
+// if nobody may: straddles are off, fewer than three players are dealt in,
+// the seat is a blind, or its player cannot post the straddle and still have
+// chips behind.
+func (g *Game) straddlePos() int {
+	var pos int
+	switch g.Straddle {
+	case StraddleUTG:
+		pos = g.FindNextActivePlayer(g.BigBlindPos)
+	case StraddleButton:
+		pos = g.DealerPos
+	default:
+		return -1
+	}
+	if g.CountRemainingPlayers() < 3 || pos == g.SmallBlindPos || pos == g.BigBlindPos {
+		return -1
+	}
+	if p := g.Players[pos]; p.Status != PlayerStatusPlaying || p.Chips <= g.StraddleAmount() {
+		return -1
+	}
+	return pos
+}
+
+// NextStraddler returns the player who may straddle in the next hand, or nil
+// if nobody may, so that the human can be asked before the hand is dealt
+// (see HumanStraddles). The blinds are not moved.
+func (g *Game) NextStraddler() *Player {
+	if g.Straddle == StraddleNone {
+		return nil
+	}
+	dealer, sb, bb := g.DealerPos, g.SmallBlindPos, g.BigBlindPos
+	defer func() { g.DealerPos, g.SmallBlindPos, g.BigBlindPos = dealer, sb, bb }()
+	g.moveButtonAndBlinds()
+	pos := g.straddlePos()
+	if pos < 0 {
+		return nil
+	}
+	return g.Players[pos]
+}
+
+// wantsToStraddle decides whether the player in the straddle seat straddles.
+// The human straddles when HumanStraddles is set; a CPU with a deep enough
+// stack straddles as often as half its aggression factor.
+func (g *Game) wantsToStraddle(p *Player) bool {
+	if !p.IsCPU {
+		return g.HumanStraddles
+	}
+	if p.Profile == nil || p.Chips < straddleMinStackBB*g.BigBlind {
+		return false
+	}
+	return g.Rand.Float64() < p.Profile.AggressionFactor/2
+}
+
+// postStraddle posts the straddle, if the player in the straddle seat wants
+// to, after the blinds. The straddle is a full raise: it becomes the bet to
+// call and the size of the last raise, so the next raise must be to at least
+// twice the straddle, and the straddler acts last pre-flop, with the option
+// the big blind would otherwise have.
+func (g *Game) postStraddle(wants func(p *Player) bool) {
+	g.Straddler = nil
+	pos := g.straddlePos()
+	if pos < 0 || !wants(g.Players[pos]) {
+		return
+	}
+	p := g.Players[pos]
+	g.Straddler = p
+	g.postBet(p, g.StraddleAmount())
+	p.LastActionDesc = fmt.Sprintf("Straddle %d", g.StraddleAmount())
+	g.BetToCall = g.StraddleAmount()
+	g.LastRaiseAmount = g.StraddleAmount()
+	g.ActionCloserPos = pos
+	g.CurrentTurnPos = g.nextTurnPos(g.BigBlindPos)
+	if g.CurrentTurnPos == pos {
+		g.CurrentTurnPos = g.nextTurnPos(pos)
+	}
+}
+
+// straddlerName returns the name of the player who straddled, or "".
+func (g *Game) straddlerName() string {
+	if g.Straddler == nil {
+		return ""
+	}
+	return g.Straddler.Name
+}
+
+// optionPos returns the seat with the pre-flop option: the straddler, or the
+// big blind when nobody straddled.
+func (g *Game) optionPos() int {
+	if g.Straddler != nil {
+		return g.straddlerPos()
+	}
+	return g.BigBlindPos
+}
+
+// straddlerPos returns the straddler's seat, or -1 if nobody straddled.
+func (g *Game) straddlerPos() int {
+	if g.Straddler == nil {
+		return -1
+	}
+	for i, p := range g.Players {
+		if p == g.Straddler {
+			return i
+		}
+	}
+	return -1
+}
+
+// openingBet returns the bet a player must call pre-flop before anyone
+// raises: the straddle, or the big blind.
+func (g *Game) openingBet() int {
+	if g.Straddler != nil {
+		return g.StraddleAmount()
+	}
+	return g.BigBlind
+}
+
+// nextTurnPos returns the seat that acts after the given one. The action goes
+// around the table, except pre-flop after a button straddle, when the button
+// acts after the blinds: under the gun to the cutoff, the blinds, then the
+// button.
+func (g *Game) nextTurnPos(pos int) int {
+	next := g.FindNextActivePlayer(pos)
+	straddler := g.straddlerPos()
+	if g.Phase != PhasePreFlop || straddler < 0 || straddler != g.DealerPos {
+		return next
+	}
+	switch {
+	case pos == g.BigBlindPos:
+		return straddler
+	case pos == straddler:
+		// Back to the first seat after the blinds, under the gun.
+		if utg := g.FindNextActivePlayer(g.BigBlindPos); utg != straddler {
+			return utg
+		}
+		return g.FindNextActivePlayer(straddler)
+	case next == straddler:
+		return g.FindNextActivePlayer(straddler)
+	}
+	return next
+}
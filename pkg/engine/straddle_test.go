@@ -0,0 +1,136 @@
+package engine
+
+import (
+	"testing"
+)
+
+// playPreFlop has each named player take the action given, in turn, checking
+// that the action is on them.
+func playPreFlop(t *testing.T, g *Game, actions []struct {
+	name     string
+	action   ActionType
+	expected ActionDetail
+}) {
+	t.Helper()
+	for _, a := range actions {
+		player := g.CurrentPlayer()
+		if player.Name != a.name {
+			t.Fatalf("Expected %s to act, but it is %s's turn", a.name, player.Name)
+		}
+		if g.IsBettingRoundOver() {
+			t.Fatalf("Expected the round to go on until %s acts", a.name)
+		}
+		if _, event := g.ProcessAction(player, PlayerAction{Type: a.action}); event.Detail != a.expected {
+			t.Errorf("Expected %s's %s to be %s, but got %s", a.name, a.action, a.expected, event.Detail)
+		}
+		g.AdvanceTurn()
+	}
+}
+
+func TestStartNewHand_UTGStraddle(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"CPU1", "CPU2", "CPU3", "YOU", "CPU4"}, 10000, 50, 100, "NLH")
+	g.Straddle = StraddleUTG
+	g.HumanStraddles = true
+
+	// CPU1 is on the button, CPU2 and CPU3 in the blinds and YOU under the gun.
+	if straddler := g.NextStraddler(); straddler == nil || straddler.Name != "YOU" {
+		t.Fatalf("Expected YOU to be asked to straddle, but got %v", straddler)
+	}
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	you := g.Players[3]
+	if g.Straddler != you || you.CurrentBet != 200 || you.Chips != 9800 {
+		t.Fatalf("Expected YOU to straddle 200, but got a bet of %d", you.CurrentBet)
+	}
+	if g.BetToCall != 200 || g.LastRaiseAmount != 200 {
+		t.Errorf("Expected 200 to call with a last raise of 200, but got %d and %d", g.BetToCall, g.LastRaiseAmount)
+	}
+	if minRaise, _ := g.CalculateBettingLimits(); minRaise != 400 {
+		t.Errorf("Expected the minimum raise to be to 400, but got %d", minRaise)
+	}
+	if g.CanComplete(g.Players[1]) {
+		t.Error("Expected the small blind to be unable to complete facing a straddle")
+	}
+
+	playPreFlop(t, g, []struct {
+		name     string
+		action   ActionType
+		expected ActionDetail
+	}{
+		{name: "CPU4", action: ActionCall, expected: ActionDetailLimp},
+		{name: "CPU1", action: ActionCall, expected: ActionDetailLimp},
+		{name: "CPU2", action: ActionCall, expected: ActionDetailNone},
+		{name: "CPU3", action: ActionCall, expected: ActionDetailNone},
+		{name: "YOU", action: ActionCheck, expected: ActionDetailOption},
+	})
+	if !g.IsBettingRoundOver() {
+		t.Error("Expected the straddler's check to close the pre-flop round")
+	}
+
+	if h := g.History; h.Straddle != 200 || h.StraddlePlayer != "YOU" {
+		t.Errorf("Expected the history to record YOU's straddle of 200, but got %d by %q", h.Straddle, h.StraddlePlayer)
+	}
+}
+
+func TestStartNewHand_ButtonStraddleActsAfterTheBlinds(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2", "CPU3", "CPU4"}, 10000, 50, 100, "NLH")
+	g.Straddle = StraddleButton
+	g.HumanStraddles = true
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	if g.Straddler != g.Players[0] {
+		t.Fatal("Expected YOU to straddle on the button")
+	}
+	playPreFlop(t, g, []struct {
+		name     string
+		action   ActionType
+		expected ActionDetail
+	}{
+		{name: "CPU3", action: ActionCall, expected: ActionDetailLimp},
+		{name: "CPU4", action: ActionFold, expected: ActionDetailNone},
+		{name: "CPU1", action: ActionCall, expected: ActionDetailNone},
+		{name: "CPU2", action: ActionCall, expected: ActionDetailNone},
+		{name: "YOU", action: ActionCheck, expected: ActionDetailOption},
+	})
+	if !g.IsBettingRoundOver() {
+		t.Fatal("Expected the button's check to close the pre-flop round")
+	}
+
+	// After the flop, the action goes around from the small blind again.
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	if g.CurrentPlayer().Name != "CPU1" {
+		t.Errorf("Expected the small blind to act first on the flop, but got %s", g.CurrentPlayer().Name)
+	}
+}
+
+func TestNextStraddler_NeedsThreePlayersAndChipsBehind(t *testing.T) {
+	headsUp := newGameForBettingTestsWithRules([]string{"YOU", "CPU1"}, 10000, 50, 100, "NLH")
+	headsUp.Straddle = StraddleButton
+	if straddler := headsUp.NextStraddler(); straddler != nil {
+		t.Errorf("Expected nobody to straddle heads-up, but got %s", straddler.Name)
+	}
+
+	short := newGameForBettingTestsWithRules([]string{"CPU1", "CPU2", "CPU3", "YOU"}, 10000, 50, 100, "NLH")
+	short.Straddle = StraddleUTG
+	short.HumanStraddles = true
+	short.Players[3].Chips = 200
+	short.StartNewHand()
+	if short.Straddler != nil || short.BetToCall != 100 {
+		t.Errorf("Expected a player who would be all-in not to straddle, but the bet to call is %d", short.BetToCall)
+	}
+}
+
+func TestParseStraddleSeat(t *testing.T) {
+	for _, name := range StraddleSeatNames() {
+		seat, err := ParseStraddleSeat(name)
+		if err != nil || seat.String() != name {
+			t.Errorf("Expected %q to parse, but got %v (%v)", name, seat, err)
+		}
+	}
+	if _, err := ParseStraddleSeat("cutoff"); err == nil {
+		t.Error("Expected an error for an unknown seat")
+	}
+}
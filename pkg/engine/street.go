@@ -0,0 +1,171 @@
+package engine
+
+// StreetCounts counts a player's decisions on one street.
+type StreetCounts struct {
+	// Aggressive counts bets and raises; Passive counts calls and checks.
+	Aggressive int
+	Passive    int
+	// BetsFaced counts decisions made facing a bet or raise, and FoldsToBet
+	// the folds among them.
+	BetsFaced  int
+	FoldsToBet int
+}
+
+// StreetLine describes the line one player took in one hand, street by street.
+// As with PreFlopLine, each pattern has an opportunity flag as well.
+type StreetLine struct {
+	// CBetOpportunity is set when the pre-flop aggressor acted on the flop
+	// before anyone bet. CBet is set if they bet: a continuation bet.
+	CBetOpportunity bool
+	CBet            bool
+	// FacedCBet is set when the player responded to a continuation bet that
+	// nobody had raised. FoldedToCBet is set if they folded to it.
+	FacedCBet    bool
+	FoldedToCBet bool
+	// Streets holds the player's decisions on each betting street, indexed by
+	// PhasePreFlop through PhaseRiver.
+	Streets [PhaseRiver + 1]StreetCounts
+}
+
+// ClassifyStreetLines recognizes the continuation bets, folds to continuation
+// bets and per-street aggression in a hand from its ordered actions.
+// Pre-flop, the big blind counts as a bet, which the big blind itself does
+// not face until someone raises.
+func ClassifyStreetLines(actions []ActionRecord) map[string]StreetLine {
+	lines := make(map[string]StreetLine)
+	aggressor := ""
+	raises := 0
+	phase := PhasePreFlop
+	betMade := false
+	cBetMade, cBetRaised := false, false
+	actedOnFlop := make(map[string]bool)
+
+	for _, a := range actions {
+		if a.Phase > PhaseRiver {
+			continue
+		}
+		if a.Phase != phase {
+			phase = a.Phase
+			betMade = false
+		}
+		line := lines[a.PlayerName]
+		isAggressive := a.Action == ActionBet || a.Action == ActionRaise
+
+		facingBet := betMade
+		if phase == PhasePreFlop {
+			facingBet = raises > 0 || a.Position != PositionBigBlind
+		}
+		counts := &line.Streets[phase]
+		switch {
+		case isAggressive:
+			counts.Aggressive++
+		case a.Action == ActionCall || a.Action == ActionCheck:
+			counts.Passive++
+		}
+		if facingBet {
+			counts.BetsFaced++
+			if a.Action == ActionFold {
+				counts.FoldsToBet++
+			}
+		}
+
+		switch phase {
+		case PhasePreFlop:
+			if isAggressive {
+				aggressor = a.PlayerName
+				raises++
+			}
+		case PhaseFlop:
+			if a.PlayerName == aggressor && !betMade && !actedOnFlop[a.PlayerName] {
+				line.CBetOpportunity = true
+				line.CBet = isAggressive
+				cBetMade = isAggressive
+			} else if cBetMade && !cBetRaised && !line.FacedCBet && a.PlayerName != aggressor {
+				line.FacedCBet = true
+				line.FoldedToCBet = a.Action == ActionFold
+			}
+			if isAggressive && a.PlayerName != aggressor {
+				cBetRaised = true
+			}
+			actedOnFlop[a.PlayerName] = true
+		}
+		if isAggressive {
+			betMade = true
+		}
+		lines[a.PlayerName] = line
+	}
+	return lines
+}
+
+// StreetStats accumulates a player's street lines over many hands.
+type StreetStats struct {
+	Hands             int
+	CBetOpportunities int
+	CBets             int
+	CBetsFaced        int
+	FoldsToCBet       int
+	Streets           [PhaseRiver + 1]StreetCounts
+}
+
+// Record adds one hand's line to the statistics.
+func (s *StreetStats) Record(line StreetLine) {
+	s.Hands++
+	if line.CBetOpportunity {
+		s.CBetOpportunities++
+		if line.CBet {
+			s.CBets++
+		}
+	}
+	if line.FacedCBet {
+		s.CBetsFaced++
+		if line.FoldedToCBet {
+			s.FoldsToCBet++
+		}
+	}
+	for i, c := range line.Streets {
+		total := &s.Streets[i]
+		total.Aggressive += c.Aggressive
+		total.Passive += c.Passive
+		total.BetsFaced += c.BetsFaced
+		total.FoldsToBet += c.FoldsToBet
+	}
+}
+
+// CBetFrequency returns how often the player continuation-bets the flop.
+func (s StreetStats) CBetFrequency() float64 {
+	return ratio(s.CBets, s.CBetOpportunities)
+}
+
+// FoldToCBetFrequency returns how often the player folds to a continuation bet.
+func (s StreetStats) FoldToCBetFrequency() float64 {
+	return ratio(s.FoldsToCBet, s.CBetsFaced)
+}
+
+// AggressionFrequency returns the fraction of the player's non-fold actions on
+// the street that were bets or raises.
+func (s StreetStats) AggressionFrequency(phase GamePhase) float64 {
+	c := s.Streets[phase]
+	return ratio(c.Aggressive, c.Aggressive+c.Passive)
+}
+
+// FoldToBetFrequency returns how often the player folds facing a bet on the street.
+func (s StreetStats) FoldToBetFrequency(phase GamePhase) float64 {
+	c := s.Streets[phase]
+	return ratio(c.FoldsToBet, c.BetsFaced)
+}
+
+// recordStreetLines classifies the current hand's street lines and adds every
+// player's line to their session statistics, and the human player's to the
+// coach's.
+func (g *Game) recordStreetLines() {
+	if g.History == nil {
+		return
+	}
+	lines := ClassifyStreetLines(g.History.Actions)
+	for _, seat := range g.History.Seats {
+		g.playerStats(seat.Name).Streets.Record(lines[seat.Name])
+		if seat.IsHuman {
+			g.HumanStreetStats.Record(lines[seat.Name])
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package engine
+
+import "testing"
+
+func TestClassifyStreetLines(t *testing.T) {
+	act := func(phase GamePhase, name, position string, action ActionType) ActionRecord {
+		return ActionRecord{Phase: phase, PlayerName: name, Position: position, Action: action}
+	}
+
+	t.Run("Continuation bet and fold to it", func(t *testing.T) {
+		lines := ClassifyStreetLines([]ActionRecord{
+			act(PhasePreFlop, "A", "BTN", ActionRaise),
+			act(PhasePreFlop, "B", "SB", ActionFold),
+			act(PhasePreFlop, "C", "BB", ActionCall),
+			act(PhaseFlop, "C", "BB", ActionCheck),
+			act(PhaseFlop, "A", "BTN", ActionBet),
+			act(PhaseFlop, "C", "BB", ActionFold),
+		})
+		if line := lines["A"]; !line.CBetOpportunity || !line.CBet {
+			t.Errorf("Expected A to continuation-bet, but got %+v", line)
+		}
+		if line := lines["C"]; !line.FacedCBet || !line.FoldedToCBet || line.CBetOpportunity {
+			t.Errorf("Expected C to fold to the continuation bet, but got %+v", line)
+		}
+		if flop := lines["C"].Streets[PhaseFlop]; flop.BetsFaced != 1 || flop.FoldsToBet != 1 || flop.Passive != 1 {
+			t.Errorf("Expected C's check and fold on the flop, but got %+v", flop)
+		}
+		if pre := lines["C"].Streets[PhasePreFlop]; pre.BetsFaced != 1 || pre.Passive != 1 {
+			t.Errorf("Expected the big blind to face the raise pre-flop, but got %+v", pre)
+		}
+	})
+
+	t.Run("Checked flop and a donk bet", func(t *testing.T) {
+		lines := ClassifyStreetLines([]ActionRecord{
+			act(PhasePreFlop, "A", "SB", ActionRaise),
+			act(PhasePreFlop, "B", "BB", ActionCall),
+			act(PhaseFlop, "B", "BB", ActionBet),
+			act(PhaseFlop, "A", "SB", ActionCall),
+			act(PhaseTurn, "B", "BB", ActionBet),
+			act(PhaseTurn, "A", "SB", ActionFold),
+		})
+		if line := lines["A"]; line.CBetOpportunity || line.FacedCBet {
+			t.Errorf("Expected no continuation bet after a donk bet, but got %+v", line)
+		}
+		if turn := lines["A"].Streets[PhaseTurn]; turn.BetsFaced != 1 || turn.FoldsToBet != 1 {
+			t.Errorf("Expected A to fold to the turn bet, but got %+v", turn)
+		}
+		if flop := lines["B"].Streets[PhaseFlop]; flop.Aggressive != 1 || flop.BetsFaced != 0 {
+			t.Errorf("Expected B to lead the flop, but got %+v", flop)
+		}
+	})
+
+	t.Run("Big blind option", func(t *testing.T) {
+		lines := ClassifyStreetLines([]ActionRecord{
+			act(PhasePreFlop, "A", "SB", ActionCall),
+			act(PhasePreFlop, "B", "BB", ActionCheck),
+		})
+		if pre := lines["B"].Streets[PhasePreFlop]; pre.BetsFaced != 0 || pre.Passive != 1 {
+			t.Errorf("Expected the big blind's option not to face a bet, but got %+v", pre)
+		}
+	})
+}
+
+func TestStreetStats_RecordedAtEndOfHand(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000)
+	g.StartNewHand()
+
+	// YOU is on the button: raise, then continuation-bet the flop.
+	you, sb, bb := g.Players[0], g.Players[1], g.Players[2]
+	g.ProcessAction(you, PlayerAction{Type: ActionRaise, Amount: 3000})
+	g.ProcessAction(sb, PlayerAction{Type: ActionFold})
+	g.ProcessAction(bb, PlayerAction{Type: ActionCall})
+	g.Phase = PhaseFlop
+	g.PrepareNewBettingRound()
+	g.ProcessAction(bb, PlayerAction{Type: ActionCheck})
+	g.ProcessAction(you, PlayerAction{Type: ActionBet, Amount: 3000})
+	g.ProcessAction(bb, PlayerAction{Type: ActionFold})
+	g.finishHandHistory()
+
+	stats := g.HumanStreetStats
+	if stats.Hands != 1 || stats.CBets != 1 || stats.CBetOpportunities != 1 {
+		t.Errorf("Expected one continuation bet in one hand, but got %+v", stats)
+	}
+	if got := stats.AggressionFrequency(PhaseFlop); got != 1 {
+		t.Errorf("Expected full aggression on the flop, but got %.2f", got)
+	}
+}
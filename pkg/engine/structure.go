@@ -0,0 +1,360 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BlindLevel holds the forced bets of a single blind level.
+type BlindLevel struct {
+	SmallBlind int
+	BigBlind   int
+	// Ante is posted by every player dealt in, before the blinds.
+	Ante int
+}
+
+// AnteFormat decides who posts the antes.
+type AnteFormat int
+
+// AnteFormat constants.
+const (
+	// AnteEveryone has every player dealt in post the ante, before the blinds.
+	AnteEveryone AnteFormat = iota
+	// AnteBigBlind has the big blind post the antes for the whole table.
+	AnteBigBlind
+	// AnteButton has the player on the button post the antes for the whole table.
+	AnteButton
+)
+
+// anteFormatNames are the names of the ante formats, as used on the command line.
+var anteFormatNames = []string{"everyone", "big-blind", "button"}
+
+// String returns the ante format's name, e.g. "big-blind".
+func (f AnteFormat) String() string {
+	return anteFormatNames[f]
+}
+
+// ParseAnteFormat returns the ante format with the given name.
+func ParseAnteFormat(name string) (AnteFormat, error) {
+	for i, n := range anteFormatNames {
+		if n == name {
+			return AnteFormat(i), nil
+		}
+	}
+	return AnteEveryone, fmt.Errorf("unknown ante format %q (available: %v)", name, anteFormatNames)
+}
+
+// AnteFormatNames returns the names of the ante formats.
+func AnteFormatNames() []string {
+	return append([]string(nil), anteFormatNames...)
+}
+
+// TableAnte returns the ante one payer posts, given the ante per player and
+// the number of players dealt in. When one player antes for the table, they
+// post everyone's antes, so the pot gets the same dead money in every format.
+func (f AnteFormat) TableAnte(ante, players int) int {
+	if f == AnteEveryone {
+		return ante
+	}
+	return ante * players
+}
+
+// BlindStructure is a named schedule of blind levels for tournament play.
+// Its levels are given in units of the game's starting small blind, so the
+// same structure works for any starting blinds.
+type BlindStructure struct {
+	Name string
+	// LevelDuration is how long each level lasts on the tournament clock.
+	LevelDuration time.Duration
+	// Levels lists the blinds and antes of each level, in units of the
+	// starting small blind. Past the last level, the blinds keep doubling.
+	Levels []BlindLevel
+	// AnteFormat is the format its antes are posted in.
+	AnteFormat AnteFormat
+}
+
+// tournamentBlindLevels is the blind ladder shared by the predefined
+// structures, which differ only in the length of their levels. Antes start at
+// level 3, and the small chips are raced off at levels 6, 10 and 15 when the
+// blinds and antes no longer need them.
+var tournamentBlindLevels = []BlindLevel{
+	{SmallBlind: 1, BigBlind: 2},
+	{SmallBlind: 2, BigBlind: 4},
+	{SmallBlind: 3, BigBlind: 6, Ante: 1},
+	{SmallBlind: 4, BigBlind: 8, Ante: 1},
+	{SmallBlind: 5, BigBlind: 10, Ante: 1},
+	{SmallBlind: 10, BigBlind: 20, Ante: 5},
+	{SmallBlind: 15, BigBlind: 30, Ante: 5},
+	{SmallBlind: 20, BigBlind: 40, Ante: 5},
+	{SmallBlind: 25, BigBlind: 50, Ante: 5},
+	{SmallBlind: 50, BigBlind: 100, Ante: 25},
+	{SmallBlind: 75, BigBlind: 150, Ante: 25},
+	{SmallBlind: 100, BigBlind: 200, Ante: 25},
+	{SmallBlind: 150, BigBlind: 300, Ante: 50},
+	{SmallBlind: 200, BigBlind: 400, Ante: 50},
+	{SmallBlind: 300, BigBlind: 600, Ante: 100},
+	{SmallBlind: 400, BigBlind: 800, Ante: 100},
+	{SmallBlind: 500, BigBlind: 1000, Ante: 100},
+}
+
+// blindStructures are the predefined structures, selectable by name.
+var blindStructures = map[string]BlindStructure{
+	"regular": {Name: "regular", LevelDuration: 20 * time.Minute, Levels: tournamentBlindLevels},
+	"turbo":   {Name: "turbo", LevelDuration: 10 * time.Minute, Levels: tournamentBlindLevels},
+	"hyper":   {Name: "hyper", LevelDuration: 5 * time.Minute, Levels: tournamentBlindLevels},
+}
+
+// chipDenominations are the chip values in play, in units of the starting
+// small blind.
+var chipDenominations = []int{1, 5, 25, 100}
+
+// endingStackBigBlinds is the number of big blinds all the chips in play are
+// worth when a tournament typically ends.
+const endingStackBigBlinds = 25
+
+// BlindStructureNames returns the names of the predefined blind structures.
+func BlindStructureNames() []string {
+	names := make([]string, 0, len(blindStructures))
+	for name := range blindStructures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupBlindStructure returns the predefined blind structure with the given name.
+func LookupBlindStructure(name string) (*BlindStructure, error) {
+	s, ok := blindStructures[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown blind structure %q (available: %v)", name, BlindStructureNames())
+	}
+	return &s, nil
+}
+
+// Level returns the blinds and ante of the given level, starting at 1, for a
+// game whose starting small blind is unit.
+func (s *BlindStructure) Level(level, unit int) BlindLevel {
+	if level < 1 {
+		level = 1
+	}
+	last := len(s.Levels) - 1
+	units := s.Levels[min(level-1, last)]
+	for i := last + 1; i < level; i++ {
+		units.SmallBlind, units.BigBlind, units.Ante = units.SmallBlind*2, units.BigBlind*2, units.Ante*2
+	}
+	return BlindLevel{SmallBlind: units.SmallBlind * unit, BigBlind: units.BigBlind * unit, Ante: units.Ante * unit}
+}
+
+// FirstAnteLevel returns the first level with an ante, or 0 if there is none.
+func (s *BlindStructure) FirstAnteLevel() int {
+	for i, level := range s.Levels {
+		if level.Ante > 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// EstimatedLevels estimates how many levels a tournament with totalChips in
+// play lasts: the number of levels until the big blind reaches
+// 1/endingStackBigBlinds of all the chips, when the blinds force the game to a
+// close.
+func (s *BlindStructure) EstimatedLevels(totalChips, unit int) int {
+	level := 1
+	for s.Level(level, unit).BigBlind*endingStackBigBlinds < totalChips {
+		level++
+	}
+	return level
+}
+
+// chipDenomination returns the smallest chip needed at a blind level: the
+// largest chip value that every blind and the ante are a multiple of.
+func chipDenomination(level BlindLevel, unit int) int {
+	denomination := unit
+	for _, d := range chipDenominations {
+		value := d * unit
+		if level.SmallBlind%value == 0 && level.BigBlind%value == 0 && level.Ante%value == 0 {
+			denomination = value
+		}
+	}
+	return denomination
+}
+
+// ChipRaceResult records how one player's stack changed in a chip race.
+type ChipRaceResult struct {
+	PlayerName  string
+	ChipsBefore int
+	ChipsAfter  int
+}
+
+// UseBlindStructure plays the game with a blind structure, starting at its
+// first level. The structure's levels are timed by the tournament clock; an
+// existing clock keeps its level duration.
+func (g *Game) UseBlindStructure(s *BlindStructure) {
+	g.Structure = s
+	g.structureUnit = g.SmallBlind
+	if g.Clock == nil {
+		g.Clock = NewTournamentClock(s.LevelDuration)
+	}
+	level := s.Level(1, g.structureUnit)
+	g.SmallBlind, g.BigBlind, g.Ante = level.SmallBlind, level.BigBlind, level.Ante
+	g.AnteFormat = s.AnteFormat
+	g.chipDenomination = chipDenomination(level, g.structureUnit)
+}
+
+// PostedAnte returns the ante each payer posts in the current hand: every
+// player's own ante, or the whole table's when one player antes for everyone.
+func (g *Game) PostedAnte() int {
+	return g.AnteFormat.TableAnte(g.Ante, g.CountRemainingPlayers())
+}
+
+// NextBlinds returns the blinds and ante of the level after the current one.
+// Without a blind structure, the blinds and the rules' ante double.
+func (g *Game) NextBlinds() BlindLevel {
+	if g.Structure == nil {
+		sb, bb := NextBlindLevel(g.SmallBlind, g.BigBlind)
+		return BlindLevel{SmallBlind: sb, BigBlind: bb, Ante: g.Ante * 2}
+	}
+	return g.Structure.Level(g.Clock.Level+1, g.structureUnit)
+}
+
+// raiseBlinds moves the blinds up a level. With a blind structure, the blinds
+// and ante follow the clock's current level, and the small chips are raced off
+// if the new level no longer needs them; otherwise the blinds and any ante
+// double. Either way, the blinds stay multiples of the rules' chip unit.
+func (g *Game) raiseBlinds() *BlindEvent {
+	if g.Structure == nil {
+		next := g.NextBlinds()
+		g.SmallBlind, g.BigBlind, g.Ante = next.SmallBlind, next.BigBlind, next.Ante
+		g.roundBlinds()
+		return &BlindEvent{SmallBlind: g.SmallBlind, BigBlind: g.BigBlind, Ante: g.PostedAnte(), AnteFormat: g.AnteFormat}
+	}
+
+	level := g.Structure.Level(g.Clock.Level, g.structureUnit)
+	g.SmallBlind, g.BigBlind, g.Ante = level.SmallBlind, level.BigBlind, level.Ante
+	g.roundBlinds()
+	event := &BlindEvent{SmallBlind: g.SmallBlind, BigBlind: g.BigBlind, Ante: g.PostedAnte(), AnteFormat: g.AnteFormat}
+	if denomination := chipDenomination(level, g.structureUnit); denomination > g.chipDenomination {
+		event.ChipRace = g.chipRace(denomination)
+		event.Denomination = denomination
+		g.chipDenomination = denomination
+	}
+	return event
+}
+
+// chipRace colors up every stack to the new chip denomination. Each player's
+// odd chips are pooled and exchanged for as many new chips as they are worth,
+// rounded to the nearest chip, and each new chip goes to a different player.
+// As in a casino, no player can be raced out of the tournament, so players
+// whose whole stack is odd chips receive a chip first, even if the pool is too
+// small. The rest go to the players with the most odd chips, ties broken by a
+// random draw.
+//
+// Because of the rounding, a chip race can change the chips in play, so it
+// updates the expected total as a sanctioned chip flow.
+func (g *Game) chipRace(denomination int) []ChipRaceResult {
+	type racer struct {
+		player *Player
+		odd    int
+	}
+	var racers []racer
+	pooled := 0
+	for _, i := range g.Rand.Perm(len(g.Players)) {
+		p := g.Players[i]
+		if p.Status == PlayerStatusEliminated || p.Chips%denomination == 0 {
+			continue
+		}
+		racers = append(racers, racer{player: p, odd: p.Chips % denomination})
+		pooled += p.Chips % denomination
+	}
+	sort.SliceStable(racers, func(i, j int) bool {
+		iOut, jOut := racers[i].player.Chips < denomination, racers[j].player.Chips < denomination
+		if iOut != jOut {
+			return iOut
+		}
+		return racers[i].odd > racers[j].odd
+	})
+
+	newChips := (pooled + denomination/2) / denomination
+	results := make([]ChipRaceResult, 0, len(racers))
+	for _, r := range racers {
+		before := r.player.Chips
+		r.player.Chips -= r.odd
+		if newChips > 0 || r.player.Chips == 0 {
+			r.player.Chips += denomination
+			newChips--
+		}
+		g.TotalInitialChips += r.player.Chips - before
+		results = append(results, ChipRaceResult{PlayerName: r.player.Name, ChipsBefore: before, ChipsAfter: r.player.Chips})
+	}
+	return results
+}
+
+// postAntes takes the ante from every player dealt into the hand. Antes are
+// dead money: they go into the pot but do not count toward the bet to call.
+// As everyone posts the same ante, the antes are counted with each player's
+// bets when the pot is split into side pots, except those of players who fold
+// (see Game.deadAntes).
+func (g *Game) postAntes() {
+	if g.Ante <= 0 {
+		return
+	}
+	for _, p := range g.Players {
+		if p.Status != PlayerStatusPlaying {
+			continue
+		}
+		amount := min(g.Ante, p.Chips)
+		p.Chips -= amount
+		p.TotalBetInHand += amount
+		p.Ante += amount
+		g.Pot += amount
+		if p.Chips == 0 {
+			p.Status = PlayerStatusAllIn
+		}
+	}
+}
+
+// postTableAnte takes the antes for the whole table from a single player. It
+// is called after the blinds, since the blind takes priority when the player
+// cannot cover both. Unlike everyone's antes, the single ante is not counted
+// with the player's bets: it is dead money for the main pot, which the payer
+// would otherwise win back as an uncalled side pot.
+func (g *Game) postTableAnte(player *Player) {
+	amount := min(g.PostedAnte(), player.Chips)
+	if amount <= 0 {
+		return
+	}
+	player.Chips -= amount
+	player.DeadAnte += amount
+	g.Pot += amount
+	if player.Chips == 0 {
+		player.Status = PlayerStatusAllIn
+	}
+}
+
+// deadAntes returns the antes that are dead money for the main pot in the
+// current hand: those posted for the whole table, and the antes of the players
+// who folded.
+func (g *Game) deadAntes() int {
+	total := 0
+	for _, p := range g.Players {
+		total += p.deadAnte()
+	}
+	return total
+}
+
+// deadAnte returns the player's ante that is dead money for the main pot: an
+// ante posted for the whole table, or their own if they folded.
+func (p *Player) deadAnte() int {
+	if p.Status == PlayerStatusFolded {
+		return p.DeadAnte + p.Ante
+	}
+	return p.DeadAnte
+}
+
+// tieredBet returns the part of the player's bets in the hand that is split
+// into the main and side pots: everything but a dead ante.
+func (p *Player) tieredBet() int {
+	return p.TotalBetInHand + p.DeadAnte - p.deadAnte()
+}
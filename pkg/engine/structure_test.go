@@ -0,0 +1,241 @@
+package engine
+
+import (
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"testing"
+	"time"
+)
+
+func TestBlindStructure_Level(t *testing.T) {
+	s, err := LookupBlindStructure("turbo")
+	if err != nil {
+		t.Fatalf("Failed to look up the turbo structure: %v", err)
+	}
+	if s.LevelDuration != 10*time.Minute {
+		t.Errorf("Expected 10-minute levels, but got %v", s.LevelDuration)
+	}
+
+	testCases := []struct {
+		level    int
+		expected BlindLevel
+	}{
+		{level: 1, expected: BlindLevel{SmallBlind: 500, BigBlind: 1000}},
+		{level: 3, expected: BlindLevel{SmallBlind: 1500, BigBlind: 3000, Ante: 500}},
+		{level: len(s.Levels), expected: BlindLevel{SmallBlind: 250000, BigBlind: 500000, Ante: 50000}},
+		{level: len(s.Levels) + 2, expected: BlindLevel{SmallBlind: 1000000, BigBlind: 2000000, Ante: 200000}},
+	}
+	for _, tc := range testCases {
+		if got := s.Level(tc.level, 500); got != tc.expected {
+			t.Errorf("Level %d: expected %+v, but got %+v", tc.level, tc.expected, got)
+		}
+	}
+
+	if _, err := LookupBlindStructure("glacial"); err == nil {
+		t.Error("Expected an error for an unknown structure")
+	}
+}
+
+func TestBlindStructure_EstimatedLevels(t *testing.T) {
+	regular, _ := LookupBlindStructure("regular")
+	// 6 players with 1,800,000 chips in all: the game ends around a 72,000 big
+	// blind, reached by the 75,000 big blind of level 11.
+	if got := regular.EstimatedLevels(6*300000, 500); got != 11 {
+		t.Errorf("Expected 11 levels, but got %d", got)
+	}
+}
+
+func TestChipDenomination(t *testing.T) {
+	testCases := []struct {
+		level    BlindLevel
+		expected int
+	}{
+		{level: BlindLevel{SmallBlind: 2500, BigBlind: 5000, Ante: 500}, expected: 500},
+		{level: BlindLevel{SmallBlind: 5000, BigBlind: 10000, Ante: 2500}, expected: 2500},
+		{level: BlindLevel{SmallBlind: 25000, BigBlind: 50000, Ante: 12500}, expected: 12500},
+		{level: BlindLevel{SmallBlind: 150000, BigBlind: 300000, Ante: 50000}, expected: 50000},
+	}
+	for _, tc := range testCases {
+		if got := chipDenomination(tc.level, 500); got != tc.expected {
+			t.Errorf("chipDenomination(%+v) = %d, expected %d", tc.level, got, tc.expected)
+		}
+	}
+}
+
+func TestChipRace(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 500, 1000)
+	g.Players[0].Chips = 10000 // No odd chips.
+	g.Players[1].Chips = 12000 // 2,000 odd chips.
+	g.Players[2].Chips = 11500 // 1,500 odd chips.
+	g.Players[3].Chips = 1000  // Nothing but odd chips.
+	g.TotalInitialChips = 34500
+
+	results := g.chipRace(2500)
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 players in the race, but got %d", len(results))
+	}
+	// 4,500 odd chips round to 2 new chips: the player with nothing else left
+	// cannot be raced out, and the player with the most odd chips gets the other.
+	expected := []int{10000, 12500, 10000, 2500}
+	for i, p := range g.Players {
+		if p.Chips != expected[i] {
+			t.Errorf("Expected %s to have %d chips, but got %d", p.Name, expected[i], p.Chips)
+		}
+	}
+	if g.TotalInitialChips != 35000 {
+		t.Errorf("Expected the chip race to be accounted for, but the expected total is %d", g.TotalInitialChips)
+	}
+}
+
+func TestStartNewHand_BlindStructure(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000)
+	s, _ := LookupBlindStructure("hyper")
+	g.UseBlindStructure(s)
+	c, now := newTestClock(s.LevelDuration)
+	g.Clock = c
+	c.Level = 2
+
+	// Move to level 3, where antes start.
+	playFoldedHand(g)
+	*now = now.Add(s.LevelDuration)
+	event := g.StartNewHand()
+	if event == nil || event.Ante != 500 || g.Ante != 500 {
+		t.Fatalf("Expected a 500 ante at level 3, but got %+v", event)
+	}
+	if g.Pot != 3*500+1500+3000 {
+		t.Errorf("Expected antes and blinds in the pot, but got %d", g.Pot)
+	}
+	if g.BetToCall != 3000 {
+		t.Errorf("Expected antes not to count toward the bet to call, but got %d", g.BetToCall)
+	}
+	if g.History.Ante != 500 {
+		t.Errorf("Expected the ante to be recorded, but got %d", g.History.Ante)
+	}
+	if status := g.ClockStatus(); status.NextAnte != 500 || status.NextBigBlind != 4000 {
+		t.Errorf("Expected next level 2,000/4,000 with a 500 ante, but got %+v", status)
+	}
+}
+
+func TestStartNewHand_RulesAnte(t *testing.T) {
+	rules := loadRule(t, "nlh.yml")
+	rules.Ante = 100
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, DifficultyMedium, rules, true, false, 0)
+	g.StartNewHand()
+
+	// YOU is on the button, CPU1 in the small blind and CPU2 in the big blind.
+	for i, blind := range []int{0, 500, 1000} {
+		if p := g.Players[i]; p.Chips != 10000-100-blind || p.TotalBetInHand != 100+blind || p.CurrentBet != blind {
+			t.Errorf("Expected %s to post a 100 ante and a %d blind, but got %+v", p.Name, blind, p)
+		}
+	}
+	if g.Pot != 3*100+500+1000 || g.BetToCall != 1000 {
+		t.Errorf("Expected the antes and blinds in the pot, but got pot %d, bet to call %d", g.Pot, g.BetToCall)
+	}
+
+	// YOU folds, leaving only the ante: it is dead money the blinds play for.
+	g.ProcessAction(g.Players[0], PlayerAction{Type: ActionFold})
+	g.ProcessAction(g.Players[1], PlayerAction{Type: ActionCall})
+	g.Players[1].Hand = poker.CardsFromStrings("As Ad")
+	g.Players[2].Hand = poker.CardsFromStrings("Ks Kd")
+	g.CommunityCards = poker.CardsFromStrings("2c 7d 9h Jc 3s")
+	results := g.DistributePot()
+	if len(results) != 1 || results[0].PlayerName != "CPU1" || results[0].AmountWon != 3*100+2*1000 {
+		t.Errorf("Expected CPU1 to win the antes and blinds, 2,300, but got %+v", results)
+	}
+
+	// Without a blind structure, the ante doubles with the blinds.
+	if event := g.raiseBlinds(); g.Ante != 200 || event.Ante != 200 || g.NextBlinds().Ante != 400 {
+		t.Errorf("Expected the ante to double to 200 with the blinds, but got %d (%+v)", g.Ante, event)
+	}
+}
+
+func TestStartNewHand_BigBlindAnte(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000)
+	g.Ante, g.AnteFormat = 100, AnteBigBlind
+	g.StartNewHand()
+
+	// YOU is on the button, CPU1 in the small blind and CPU2 in the big blind.
+	bb := g.Players[2]
+	if bb.DeadAnte != 300 || bb.Chips != 100000-1000-300 || bb.TotalBetInHand != 1000 {
+		t.Errorf("Expected the big blind to post 300 in antes apart from the blind, but got %+v", bb)
+	}
+	if g.Pot != 500+1000+300 || g.BetToCall != 1000 {
+		t.Errorf("Expected the blinds and the table's ante in the pot, but got pot %d, bet to call %d", g.Pot, g.BetToCall)
+	}
+	if g.History.Ante != 300 || g.History.AntePlayer != "CPU2" || g.History.Seats[2].StartingChips != 100000 {
+		t.Errorf("Expected the table's ante to be recorded, but got %d from %q", g.History.Ante, g.History.AntePlayer)
+	}
+
+	// Everyone limps: the ante goes to the main pot instead of coming back to
+	// the big blind as an uncalled side pot.
+	g.ProcessAction(g.Players[0], PlayerAction{Type: ActionCall})
+	g.ProcessAction(g.Players[1], PlayerAction{Type: ActionCall})
+	pots := g.buildPotTiers(g.getShowdownPlayers())
+	if len(pots) != 1 || pots[0].Amount != g.Pot {
+		t.Errorf("Expected a single main pot of %d, but got %+v", g.Pot, pots)
+	}
+}
+
+func TestStartNewHand_ShortTableAntePayer(t *testing.T) {
+	testCases := []struct {
+		name   string
+		format AnteFormat
+		payer  int
+		chips  int
+		blind  int
+		ante   int
+	}{
+		// The blind takes priority over the ante.
+		{name: "Big blind covers the blind only", format: AnteBigBlind, payer: 2, chips: 1200, blind: 1000, ante: 200},
+		{name: "Big blind cannot cover the blind", format: AnteBigBlind, payer: 2, chips: 800, blind: 800, ante: 0},
+		{name: "Button", format: AnteButton, payer: 0, chips: 200, blind: 0, ante: 200},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2"}, 100000, 500, 1000)
+			g.Ante, g.AnteFormat = 100, tc.format
+			payer := g.Players[tc.payer]
+			payer.Chips = tc.chips
+			g.TotalInitialChips = 200000 + tc.chips
+			g.StartNewHand()
+
+			if payer.TotalBetInHand != tc.blind || payer.DeadAnte != tc.ante || payer.Chips != 0 || payer.Status != PlayerStatusAllIn {
+				t.Errorf("Expected %s to post %d and %d in antes all in, but got %+v", payer.Name, tc.blind, tc.ante, payer)
+			}
+			if len(payer.Hand) != g.Rules.HoleCards.Count {
+				t.Errorf("Expected %s to be dealt in all in, but got %v", payer.Name, payer.Hand)
+			}
+		})
+	}
+}
+
+func TestStartNewHand_DeadButtonAnte(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU1", "CPU2", "CPU3"}, 10000, 50, 100)
+	g.Ante, g.AnteFormat = 10, AnteButton
+	playFoldedHand(g)
+
+	// The small blind busts, so the button moves to its empty seat, and the
+	// big blind posts the antes.
+	g.Players[1].Chips = 0
+	g.Players[1].Status = PlayerStatusEliminated
+	g.StartNewHand()
+	bb := g.Players[g.BigBlindPos]
+	if g.ButtonPlayer() != nil || bb.DeadAnte != AnteButton.TableAnte(g.Ante, 3) {
+		t.Errorf("Expected the big blind to post the antes for a dead button, but got %+v", bb)
+	}
+}
+
+func TestParseAnteFormat(t *testing.T) {
+	for _, name := range AnteFormatNames() {
+		f, err := ParseAnteFormat(name)
+		if err != nil || f.String() != name {
+			t.Errorf("ParseAnteFormat(%q) = %v, %v", name, f, err)
+		}
+	}
+	if _, err := ParseAnteFormat("small-blind"); err == nil {
+		t.Error("Expected an error for an unknown ante format")
+	}
+	if got := AnteButton.TableAnte(100, 6); got != 600 {
+		t.Errorf("Expected the button to ante 600 for six players, but got %d", got)
+	}
+}
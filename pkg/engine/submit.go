@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOutOfTurn is returned by SubmitAction for an action by a player who is
+// not the one to act.
+var ErrOutOfTurn = errors.New("action out of turn")
+
+// ErrStaleAction is returned by SubmitAction for an action decided on a state
+// of the hand that has since moved on.
+var ErrStaleAction = errors.New("stale action")
+
+// SubmitAction applies an action submitted from outside the game loop, e.g.
+// by a client that only sees the game over the network, as ProcessAction
+// does. Unlike ProcessAction, it first checks that the action is still
+// wanted: the player must be the one to act, and seq must be the game's
+// ActionSeq, the number the client was shown with the state it decided on. A
+// fold sent twice, or a call sent after the betting round was closed by
+// someone else, is rejected rather than applied to the new state. The action
+// must then be legal by ValidateAction; an illegal one is rejected with an
+// error matching validate.ErrIllegalAction. A rejected
+// action changes nothing, so the caller can answer with the current state for
+// the client to resynchronize. The caller passes the turn on afterwards, as
+// after ProcessAction.
+func (g *Game) SubmitAction(playerName string, seq int, action PlayerAction) (*ActionEvent, error) {
+	if seq != g.ActionSeq {
+		return nil, fmt.Errorf("%w: action %d was decided on an earlier state, the hand is now at action %d", ErrStaleAction, seq, g.ActionSeq)
+	}
+	if !g.awaitsAction() {
+		return nil, fmt.Errorf("%w: no action is awaited in the %s phase", ErrOutOfTurn, g.Phase)
+	}
+	if current := g.CurrentPlayer(); current.Name != playerName {
+		return nil, fmt.Errorf("%w: it is %s's turn, not %s's", ErrOutOfTurn, current.Name, playerName)
+	}
+	if err := g.ValidateAction(g.CurrentPlayer(), action); err != nil {
+		return nil, err
+	}
+	_, event := g.ProcessAction(g.CurrentPlayer(), action)
+	return event, nil
+}
+
+// awaitsAction reports whether the player whose turn it is may act: the hand
+// is in a betting round that is still open, and they have chips and cards.
+func (g *Game) awaitsAction() bool {
+	if g.Phase == PhaseShowdown || g.Phase == PhaseHandOver || g.CurrentTurnPos < 0 || g.CurrentTurnPos >= len(g.Players) {
+		return false
+	}
+	return g.CurrentPlayer().Status == PlayerStatusPlaying && !g.IsBettingRoundOver()
+}
@@ -0,0 +1,103 @@
+package engine
+
+import (
+	"errors"
+	"github.com/philipjkim/pls7-cli/pkg/validate"
+	"testing"
+)
+
+func TestSubmitAction_RejectsOutOfTurnAndStaleActions(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100, "NLH")
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	// YOU are on the button and act first pre-flop.
+	seq := g.ActionSeq
+	if _, err := g.SubmitAction("CPU1", seq, PlayerAction{Type: ActionFold}); !errors.Is(err, ErrOutOfTurn) {
+		t.Fatalf("Expected a fold out of turn to be rejected, but got %v", err)
+	}
+	if g.Players[1].Status != PlayerStatusPlaying || g.ActionSeq != seq {
+		t.Fatal("Expected a rejected action to change nothing")
+	}
+
+	event, err := g.SubmitAction("YOU", seq, PlayerAction{Type: ActionCall})
+	if err != nil || event.Seq != seq {
+		t.Fatalf("Expected YOU's call to be applied as action %d, but got %v (%v)", seq, event, err)
+	}
+	g.AdvanceTurn()
+	if g.ActionSeq != seq+1 {
+		t.Errorf("Expected the call to move the sequence on to %d, but got %d", seq+1, g.ActionSeq)
+	}
+
+	// The call sent again, e.g. after a network retry, is stale.
+	pot := g.Pot
+	if _, err := g.SubmitAction("YOU", seq, PlayerAction{Type: ActionCall}); !errors.Is(err, ErrStaleAction) {
+		t.Errorf("Expected a repeated action to be stale, but got %v", err)
+	}
+	// So is CPU1's action, decided before YOU called.
+	if _, err := g.SubmitAction("CPU1", seq, PlayerAction{Type: ActionCall}); !errors.Is(err, ErrStaleAction) {
+		t.Errorf("Expected an action on an earlier state to be stale, but got %v", err)
+	}
+	if g.Pot != pot {
+		t.Errorf("Expected the rejected actions to leave the pot at %d, but got %d", pot, g.Pot)
+	}
+
+	for _, name := range []string{"CPU1", "CPU2"} {
+		action := PlayerAction{Type: ActionCall}
+		if name == "CPU2" {
+			action.Type = ActionCheck
+		}
+		if _, err := g.SubmitAction(name, g.ActionSeq, action); err != nil {
+			t.Fatalf("Expected %s's action to be applied, but got %v", name, err)
+		}
+		g.AdvanceTurn()
+	}
+
+	// Once the round is over, nobody may act until the next one starts.
+	if _, err := g.SubmitAction(g.CurrentPlayer().Name, g.ActionSeq, PlayerAction{Type: ActionCheck}); !errors.Is(err, ErrOutOfTurn) {
+		t.Errorf("Expected an action after the round closed to be rejected, but got %v", err)
+	}
+	seq = g.ActionSeq
+	g.Advance()
+	g.PrepareNewBettingRound()
+	if g.ActionSeq == seq {
+		t.Error("Expected the next betting round to move the sequence on")
+	}
+}
+
+func TestSubmitAction_RejectsIllegalActions(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100, "NLH")
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	for _, action := range []PlayerAction{
+		{Type: ActionCheck},
+		{Type: ActionBet, Amount: 300},
+		{Type: ActionRaise, Amount: 150},
+		{Type: ActionRaise, Amount: 20000},
+	} {
+		if _, err := g.SubmitAction("YOU", g.ActionSeq, action); !errors.Is(err, validate.ErrIllegalAction) {
+			t.Errorf("Expected %s to %d to be rejected as illegal, but got %v", action.Type, action.Amount, err)
+		}
+	}
+	if g.Pot != 150 || len(g.IllegalActions) != 0 {
+		t.Fatalf("Expected the rejected actions to change nothing, but the pot is %d", g.Pot)
+	}
+	if _, err := g.SubmitAction("YOU", g.ActionSeq, PlayerAction{Type: ActionRaise, Amount: 300}); err != nil {
+		t.Errorf("Expected a legal raise to be applied, but got %v", err)
+	}
+}
+
+func TestProcessAction_KeepsIllegalActions(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 50, 100, "NLH")
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	g.ProcessAction(g.CurrentPlayer(), PlayerAction{Type: ActionCheck})
+	if len(g.IllegalActions) != 1 || g.IllegalActions[0].PlayerName != "YOU" {
+		t.Fatalf("Expected YOU's check facing the big blind to be kept as illegal, but got %v", g.IllegalActions)
+	}
+	if want := "hand #1: YOU check: cannot check facing a bet of 100"; g.IllegalActions[0].String() != want {
+		t.Errorf("Expected %q, but got %q", want, g.IllegalActions[0].String())
+	}
+}
@@ -0,0 +1,53 @@
+package engine
+
+// TableConfig describes the game played at a table: the variant and its rules,
+// and the current forced bets. It goes with the table's state to clients, such
+// as the browser demo or the replayer, so that they can draw any variant, with
+// its number of hole cards and any low pot, without being configured for it.
+type TableConfig struct {
+	// Variant and RulesName are the rules' abbreviation and full name, e.g.
+	// "PLS7" and "Pot-Limit Sampyeong 7-or-Better".
+	Variant   string `json:"variant"`
+	RulesName string `json:"rules_name"`
+	// RulesHash fingerprints the full rules (see poker.GameRules.Hash).
+	RulesHash    string `json:"rules_hash"`
+	BettingLimit string `json:"betting_limit"`
+	HoleCards    int    `json:"hole_cards"`
+	// LowHand is whether the pot is split with the best low, made of cards no
+	// higher than LowMaxRank.
+	LowHand    bool `json:"low_hand"`
+	LowMaxRank int  `json:"low_max_rank,omitempty"`
+	ChipUnit   int  `json:"chip_unit,omitempty"`
+	// BlindLevel is the current blind level, starting at 1.
+	BlindLevel int `json:"blind_level"`
+	SmallBlind int `json:"small_blind"`
+	BigBlind   int `json:"big_blind"`
+	// Ante is each player's ante, or 0 if there is none. AnteFormat names who
+	// posts it (see AnteFormat).
+	Ante       int    `json:"ante,omitempty"`
+	AnteFormat string `json:"ante_format,omitempty"`
+}
+
+// TableConfig returns the configuration of the game as it stands.
+func (g *Game) TableConfig() TableConfig {
+	config := TableConfig{
+		Variant:      g.Rules.Abbreviation,
+		RulesName:    g.Rules.Name,
+		RulesHash:    g.Rules.Hash(),
+		BettingLimit: g.Rules.BettingLimit,
+		HoleCards:    g.Rules.HoleCards.Count,
+		LowHand:      g.Rules.LowHand.Enabled,
+		ChipUnit:     g.Rules.ChipUnit,
+		BlindLevel:   g.BlindLevel(),
+		SmallBlind:   g.SmallBlind,
+		BigBlind:     g.BigBlind,
+		Ante:         g.Ante,
+	}
+	if config.LowHand {
+		config.LowMaxRank = g.Rules.LowHand.MaxRank
+	}
+	if g.Ante > 0 {
+		config.AnteFormat = g.AnteFormat.String()
+	}
+	return config
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestTableConfig(t *testing.T) {
+	rules := loadRule(t, "pls7.yml")
+	g := NewGame([]string{"YOU", "CPU1", "CPU2"}, 10000, 100, 200, DifficultyMedium, rules, false, false, 2)
+	g.Ante = 25
+	for range 3 {
+		g.StartNewHand()
+		g.CleanupHand()
+	}
+
+	config := g.TableConfig()
+	if config.Variant != "PLS7" || config.HoleCards != 3 || config.BettingLimit != "pot_limit" {
+		t.Errorf("Expected pot-limit PLS7 with 3 hole cards, but got %+v", config)
+	}
+	if !config.LowHand || config.LowMaxRank != 7 {
+		t.Errorf("Expected a 7-or-better low, but got %+v", config)
+	}
+	if config.BlindLevel != 2 || config.BigBlind != g.BigBlind || config.Ante != g.Ante || config.AnteFormat != "everyone" {
+		t.Errorf("Expected level 2 with the current blinds and ante, but got %+v", config)
+	}
+	if config.RulesHash != rules.Hash() {
+		t.Errorf("Expected the rules' hash %s, but got %s", rules.Hash(), config.RulesHash)
+	}
+	if h := g.History; h.Config == nil || *h.Config != config {
+		t.Errorf("Expected the hand history to record the table config, but got %+v", h.Config)
+	}
+
+	if other := loadRule(t, "pls.yml"); other.Hash() == rules.Hash() {
+		t.Error("Expected different rules to hash differently")
+	}
+}
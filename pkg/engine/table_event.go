@@ -0,0 +1,111 @@
+package engine
+
+import "github.com/philipjkim/pls7-cli/pkg/poker"
+
+// TableEvent is something that happened at the table, described for a
+// frontend to present, such as a terminal, a network server or a bot. Unlike
+// the entries of the event log, which record how to play the game again,
+// table events carry outcomes, and the engine leaves their wording to the
+// frontend. A TableEvent is one of *DealEvent, *BlindEvent, *ActionEvent,
+// *PhaseChangeEvent, *PotAwardedEvent, *EliminationEvent, *HandEndEvent and
+// *GameWonEvent.
+type TableEvent interface {
+	tableEvent()
+}
+
+// DealEvent is a new hand dealt, after the blinds are posted.
+type DealEvent struct {
+	HandNumber int
+	// Dealer names the player on the button, or is empty when the button is
+	// on an empty seat.
+	Dealer string
+	// Players names the players dealt in, in seating order.
+	Players []string
+}
+
+// PhaseChangeEvent is the hand moving on to a new street, or to the
+// showdown, with the board as it is then.
+type PhaseChangeEvent struct {
+	Phase GamePhase
+	Board []poker.Card
+}
+
+// PotAwardedEvent is the pot paid out at the end of a hand, at a showdown or
+// to the last player left in it.
+type PotAwardedEvent struct {
+	Results []DistributionResult
+	// Uncalled is the bet returned before the pot was paid out, if any.
+	Uncalled *UncalledBet
+}
+
+// EliminationEvent is a player running out of chips at the end of a hand.
+type EliminationEvent struct {
+	PlayerName string
+}
+
+// HandEndEvent is the end of a hand, once its chips are counted.
+type HandEndEvent struct {
+	HandNumber int
+	// ChipViolations lists the changes to the chips in play found at the end
+	// of the hand, which should never happen.
+	ChipViolations []ChipViolation
+}
+
+// GameWonEvent is the last player with chips winning the game.
+type GameWonEvent struct {
+	PlayerName string
+}
+
+func (*DealEvent) tableEvent()        {}
+func (*BlindEvent) tableEvent()       {}
+func (*ActionEvent) tableEvent()      {}
+func (*PhaseChangeEvent) tableEvent() {}
+func (*PotAwardedEvent) tableEvent()  {}
+func (*EliminationEvent) tableEvent() {}
+func (*HandEndEvent) tableEvent()     {}
+func (*GameWonEvent) tableEvent()     {}
+
+// Subscribe returns a channel on which every table event from now on is
+// sent, with room for buffer events, until Unsubscribe closes it. The game
+// never waits for a subscriber: an event that finds the buffer full is
+// dropped for that subscriber, so the channel should be drained, usually on
+// another goroutine, and the buffer sized for the events that may pile up in
+// between. Subscribe and Unsubscribe may be called from any goroutine.
+// Subscriptions are not part of the event log.
+func (g *Game) Subscribe(buffer int) <-chan TableEvent {
+	ch := make(chan TableEvent, buffer)
+	g.subscribersMu.Lock()
+	defer g.subscribersMu.Unlock()
+	g.subscribers = append(g.subscribers, ch)
+	return ch
+}
+
+// Unsubscribe stops sending table events on a channel returned by Subscribe,
+// and closes it.
+func (g *Game) Unsubscribe(ch <-chan TableEvent) {
+	g.subscribersMu.Lock()
+	defer g.subscribersMu.Unlock()
+	for i, sub := range g.subscribers {
+		if sub == ch {
+			g.subscribers = append(g.subscribers[:i], g.subscribers[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
+// publish hands a table event to OnTableEvent and then to every subscriber
+// with room for it.
+func (g *Game) publish(e TableEvent) {
+	if g.OnTableEvent != nil {
+		g.OnTableEvent(e)
+	}
+	g.subscribersMu.Lock()
+	defer g.subscribersMu.Unlock()
+	for _, sub := range g.subscribers {
+		select {
+		case sub <- e:
+		default:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestSubscribe_SeesTheHandInOrder tests that a subscriber and OnTableEvent
+// see the same table events of a hand, from the deal to its end.
+func TestSubscribe_SeesTheHandInOrder(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(3))
+	g.Players[0].Profile = g.Players[1].Profile
+	var hooked []TableEvent
+	g.OnTableEvent = func(e TableEvent) { hooked = append(hooked, e) }
+	ch := g.Subscribe(1000)
+
+	playLoggedHand(g)
+	g.Unsubscribe(ch)
+
+	var seen []TableEvent
+	for e := range ch {
+		seen = append(seen, e)
+	}
+	if !reflect.DeepEqual(seen, hooked) {
+		t.Fatalf("the subscriber saw %d events, but OnTableEvent saw %d", len(seen), len(hooked))
+	}
+	deal, ok := seen[0].(*DealEvent)
+	if !ok || deal.HandNumber != 1 || len(deal.Players) != 3 {
+		t.Fatalf("first event = %#v, want the deal of hand 1 to 3 players", seen[0])
+	}
+	actions, awarded := 0, 0
+	for _, e := range seen {
+		switch e.(type) {
+		case *ActionEvent:
+			actions++
+		case *PotAwardedEvent:
+			awarded++
+		}
+	}
+	if actions == 0 || awarded != 1 {
+		t.Errorf("saw %d actions and %d pots awarded, want some actions and one pot", actions, awarded)
+	}
+	if end, ok := seen[len(seen)-1].(*HandEndEvent); !ok || end.HandNumber != 1 || len(end.ChipViolations) != 0 {
+		t.Errorf("last event = %#v, want the end of hand 1 without violations", seen[len(seen)-1])
+	}
+}
+
+// TestCleanupHand_PublishesEliminations tests that a player out of chips is
+// published as eliminated, and the last player left as the winner.
+func TestCleanupHand_PublishesEliminations(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1"}, 10000, 50, 100)
+	playFoldedHand(g)
+	var seen []TableEvent
+	g.OnTableEvent = func(e TableEvent) { seen = append(seen, e) }
+	g.Players[1].Chips, g.Players[0].Chips = 0, g.Players[0].Chips+g.Players[1].Chips
+
+	g.CleanupHand()
+
+	want := []TableEvent{
+		&HandEndEvent{HandNumber: 1},
+		&EliminationEvent{PlayerName: "CPU 1"},
+		&GameWonEvent{PlayerName: "YOU"},
+	}
+	if !reflect.DeepEqual(seen, want) {
+		t.Errorf("CleanupHand published %#v, want %#v", seen, want)
+	}
+}
+
+// TestSubscribe_DropsEventsWhenFull tests that a subscriber that is not read
+// does not hold up the game, and only gets the events its buffer had room
+// for.
+func TestSubscribe_DropsEventsWhenFull(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.Rand = rand.New(rand.NewSource(3))
+	g.Players[0].Profile = g.Players[1].Profile
+	ch := g.Subscribe(2)
+
+	playLoggedHand(g)
+	g.Unsubscribe(ch)
+
+	var seen []TableEvent
+	for e := range ch {
+		seen = append(seen, e)
+	}
+	if len(seen) != 2 {
+		t.Fatalf("the subscriber got %d events, want the 2 its buffer holds", len(seen))
+	}
+	if deal, ok := seen[0].(*DealEvent); !ok || deal.HandNumber != 1 {
+		t.Errorf("first event = %#v, want the deal of hand 1", seen[0])
+	}
+}
@@ -0,0 +1,135 @@
+package engine
+
+// showdownDecay is the weight a showdown keeps in a table image each time the
+// same player shows down another hand, so that the image follows a player who
+// changes gears.
+const showdownDecay = 0.8
+
+// weakStartingHandScore is the starting hand score (see startingHandScore)
+// below which a hand shown down counts as weak: one that no tight profile
+// would play.
+const weakStartingHandScore = 15
+
+// minShowdownsForImage is the decayed count of showdowns with a pre-flop line
+// the AI must have seen before it adjusts to a player's range for it: three
+// in a row, or more spread out.
+const minShowdownsForImage = 2
+
+// ShownRange is what a player's showdowns reveal about the starting hands
+// they play with one pre-flop line. Both counts decay with every newer
+// showdown by the player.
+type ShownRange struct {
+	// Shown counts the hands shown down.
+	Shown float64 `json:"shown"`
+	// Weak counts those that were weak starting hands.
+	Weak float64 `json:"weak"`
+}
+
+// WeakFrequency returns the fraction of the hands shown down that were weak
+// starting hands.
+func (r ShownRange) WeakFrequency() float64 {
+	if r.Shown == 0 {
+		return 0
+	}
+	return r.Weak / r.Shown
+}
+
+// TableImage is the table's picture of the hands a player plays, learned from
+// the hands they showed down, e.g. "raises pre-flop with weak hands".
+type TableImage struct {
+	// Raised is the range of hands the player raised pre-flop.
+	Raised ShownRange `json:"raised"`
+	// Called is the range of hands the player called with pre-flop, but never
+	// raised.
+	Called ShownRange `json:"called"`
+}
+
+// observeShowdown adds a hand shown down to the image, after decaying the
+// older showdowns.
+func (t *TableImage) observeShowdown(raised, weak bool) {
+	for _, r := range []*ShownRange{&t.Raised, &t.Called} {
+		r.Shown *= showdownDecay
+		r.Weak *= showdownDecay
+	}
+	r := &t.Called
+	if raised {
+		r = &t.Raised
+	}
+	r.Shown++
+	if weak {
+		r.Weak++
+	}
+}
+
+// recordTableImages adds the hands shown down in the current hand to the
+// table images of the players who showed them, and to the human model for
+// the human player. A hand that only checked the big blind's option shows
+// nothing about a pre-flop range and is left out.
+func (g *Game) recordTableImages() {
+	if g.History == nil {
+		return
+	}
+	if g.TableImages == nil {
+		g.TableImages = make(map[string]*TableImage)
+	}
+	raised := make(map[string]bool)
+	called := make(map[string]bool)
+	for _, a := range g.History.Actions {
+		if a.Phase != PhasePreFlop {
+			continue
+		}
+		switch {
+		case a.Action == ActionBet || a.Action == ActionRaise:
+			raised[a.PlayerName] = true
+		case a.Action == ActionCall && a.Detail != ActionDetailOption:
+			called[a.PlayerName] = true
+		}
+	}
+	for _, seat := range g.History.Seats {
+		if !seat.Showdown || !raised[seat.Name] && !called[seat.Name] {
+			continue
+		}
+		weak := g.startingHandScore(seat.HoleCards) < weakStartingHandScore
+		image, ok := g.TableImages[seat.Name]
+		if !ok {
+			image = &TableImage{}
+			g.TableImages[seat.Name] = image
+		}
+		image.observeShowdown(raised[seat.Name], weak)
+		if seat.IsHuman && g.HumanModel != nil {
+			g.HumanModel.Image.observeShowdown(raised[seat.Name], weak)
+		}
+	}
+}
+
+// tableImage returns the player's table image: the remembered one
+// for the human when there is a human model, and this session's otherwise.
+func (g *Game) tableImage(p *Player) *TableImage {
+	if !p.IsCPU && g.HumanModel != nil {
+		return &g.HumanModel.Image
+	}
+	return g.TableImages[p.Name]
+}
+
+// adjustedPlayHandThreshold tunes the hand strength a CPU needs to play a
+// hand pre-flop against the raiser's table image. Against a player who has
+// shown down weak hands they raised, it defends wider; against one who has
+// only ever shown strong hands, it calls with hands it would raise itself.
+func (g *Game) adjustedPlayHandThreshold(player *Player) float64 {
+	threshold := player.Profile.PlayHandThreshold
+	raiser := g.Aggressor
+	if raiser == nil || raiser == player || g.BetToCall <= g.BigBlind || !g.Difficulty.Preset().UsesOpponentModel {
+		return threshold
+	}
+	image := g.tableImage(raiser)
+	if image == nil || image.Raised.Shown < minShowdownsForImage {
+		return threshold
+	}
+	switch weak := image.Raised.WeakFrequency(); {
+	case weak >= 0.5:
+		threshold *= 0.8
+	case weak <= 0.1:
+		threshold = max(threshold, player.Profile.RaiseHandThreshold)
+	}
+	return threshold
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTableImage_ObserveShowdownDecaysOlderHands(t *testing.T) {
+	var image TableImage
+	image.observeShowdown(true, true)
+	image.observeShowdown(false, false)
+	image.observeShowdown(true, false)
+
+	// The first raised hand now counts 0.8*0.8, the second 1.
+	if want := 1 + showdownDecay*showdownDecay; math.Abs(image.Raised.Shown-want) > 1e-9 {
+		t.Errorf("Expected %.2f raised hands shown, but got %.2f", want, image.Raised.Shown)
+	}
+	if want := showdownDecay * showdownDecay; math.Abs(image.Raised.Weak-want) > 1e-9 {
+		t.Errorf("Expected %.2f weak raised hands, but got %.2f", want, image.Raised.Weak)
+	}
+	if want := showdownDecay; math.Abs(image.Called.Shown-want) > 1e-9 {
+		t.Errorf("Expected %.2f called hands shown, but got %.2f", want, image.Called.Shown)
+	}
+}
+
+func TestRecordTableImages_LearnsFromShowdowns(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"YOU", "CPU1", "CPU2"}, 10000, 500, 1000, "NLH")
+	g.HumanModel = NewOpponentModel("default")
+	g.History = &HandHistory{
+		Seats: []SeatRecord{
+			{Name: "YOU", IsHuman: true, Showdown: true, HoleCards: mustParseCards(t, "7s 2d")},
+			{Name: "CPU1", Showdown: true, HoleCards: mustParseCards(t, "As Ah")},
+			{Name: "CPU2", Showdown: true, HoleCards: mustParseCards(t, "Kd Qd")},
+		},
+		Actions: []ActionRecord{
+			{Phase: PhasePreFlop, PlayerName: "YOU", Action: ActionRaise, Amount: 3000},
+			{Phase: PhasePreFlop, PlayerName: "CPU1", Action: ActionCall, Amount: 2500},
+			{Phase: PhasePreFlop, PlayerName: "CPU2", Action: ActionCall, Detail: ActionDetailOption},
+			{Phase: PhaseFlop, PlayerName: "CPU2", Action: ActionBet, Amount: 1000},
+		},
+	}
+
+	g.recordTableImages()
+
+	you := g.TableImages["YOU"]
+	if you == nil || you.Raised.Shown != 1 || you.Raised.Weak != 1 {
+		t.Fatalf("Expected one weak raised hand for YOU, but got %+v", you)
+	}
+	if g.HumanModel.Image != *you {
+		t.Errorf("Expected the human model to remember %+v, but got %+v", *you, g.HumanModel.Image)
+	}
+	if cpu1 := g.TableImages["CPU1"]; cpu1 == nil || cpu1.Called.Shown != 1 || cpu1.Called.Weak != 0 {
+		t.Errorf("Expected one strong called hand for CPU1, but got %+v", cpu1)
+	}
+	// CPU2 only checked its option pre-flop; its flop bet says nothing about
+	// its pre-flop range.
+	if cpu2, ok := g.TableImages["CPU2"]; ok {
+		t.Errorf("Expected no image for CPU2, but got %+v", cpu2)
+	}
+}
+
+func TestAdjustedPlayHandThreshold_ByRaiserImage(t *testing.T) {
+	tagProfile := builtInProfile("Tight-Aggressive")
+	testCases := []struct {
+		name     string
+		image    *TableImage
+		expected float64
+	}{
+		{name: "No image", image: nil, expected: tagProfile.PlayHandThreshold},
+		{name: "Too few showdowns", image: &TableImage{Raised: ShownRange{Shown: 1, Weak: 1}}, expected: tagProfile.PlayHandThreshold},
+		{name: "Raises weak hands", image: &TableImage{Raised: ShownRange{Shown: 3, Weak: 2}}, expected: tagProfile.PlayHandThreshold * 0.8},
+		{name: "Raises strong hands", image: &TableImage{Raised: ShownRange{Shown: 3}}, expected: tagProfile.RaiseHandThreshold},
+		{name: "Balanced", image: &TableImage{Raised: ShownRange{Shown: 3, Weak: 1}}, expected: tagProfile.PlayHandThreshold},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raiser := &Player{Name: "CPU2", IsCPU: true}
+			g := &Game{
+				Difficulty: DifficultyMedium,
+				BigBlind:   1000,
+				BetToCall:  3000,
+				Aggressor:  raiser,
+			}
+			if tc.image != nil {
+				g.TableImages = map[string]*TableImage{"CPU2": tc.image}
+			}
+			cpu := &Player{Name: "CPU1", IsCPU: true, Profile: &tagProfile}
+			if got := g.adjustedPlayHandThreshold(cpu); got != tc.expected {
+				t.Errorf("Expected play threshold %.1f, but got %.1f", tc.expected, got)
+			}
+		})
+	}
+}
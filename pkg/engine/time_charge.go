@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeCharge is a fixed fee in chips taken from every player at the table
+// once per period, in hands or in minutes, instead of a rake from the pots.
+// It is the house's cut in many live high-stakes cash games. The charge is
+// collected in advance, between hands: first before the first hand, then
+// whenever a period has gone by.
+type TimeCharge struct {
+	// Amount is the charge each player pays per period.
+	Amount int
+	// EveryHands is the period in hands, or 0 if the period is in time.
+	EveryHands int
+	// Every is the period in time, or 0 if the period is in hands.
+	Every time.Duration
+}
+
+// Validate checks that the charge is positive and that exactly one period is
+// set.
+func (c TimeCharge) Validate() error {
+	if c.Amount <= 0 {
+		return fmt.Errorf("time charge must be positive, got %d", c.Amount)
+	}
+	if c.EveryHands < 0 || c.Every < 0 {
+		return errors.New("time charge period must not be negative")
+	}
+	if (c.EveryHands > 0) == (c.Every > 0) {
+		return errors.New("time charge needs a period in either hands or time, not both")
+	}
+	return nil
+}
+
+// TimeChargePayment is the charge one player paid at a collection.
+type TimeChargePayment struct {
+	PlayerName string
+	Amount     int
+}
+
+// TimeChargeDue reports whether the time charge should be collected before
+// the next hand. It is never due without a time charge or during a hand.
+func (g *Game) TimeChargeDue() bool {
+	if g.TimeCharge == nil || g.handInProgress {
+		return false
+	}
+	if !g.timeChargeCollected {
+		return true
+	}
+	if g.TimeCharge.EveryHands > 0 {
+		return g.HandCount-g.lastTimeChargeHand >= g.TimeCharge.EveryHands
+	}
+	return g.now().Sub(g.lastTimeCharge) >= g.TimeCharge.Every
+}
+
+// CollectTimeCharge takes the time charge from every player still in the
+// game, between hands. A player is never charged out of the game: one whose
+// stack does not cover more than the charge pays nothing this period. The
+// chips leave the game, so the expected total of the chips in play drops by
+// what was collected.
+func (g *Game) CollectTimeCharge() ([]TimeChargePayment, error) {
+	if g.TimeCharge == nil {
+		return nil, errors.New("there is no time charge")
+	}
+	return g.chargeTime(g.TimeCharge.Amount)
+}
+
+// chargeTime collects amount from every player whose stack covers more than
+// it, and logs the collection.
+func (g *Game) chargeTime(amount int) ([]TimeChargePayment, error) {
+	if g.handInProgress {
+		return nil, errors.New("the time charge is only collected between hands")
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("time charge must be positive, got %d", amount)
+	}
+	g.logEvent(GameEvent{Type: EventTimeCharged, Amount: amount})
+	g.timeChargeCollected = true
+	g.lastTimeCharge = g.now()
+	g.lastTimeChargeHand = g.HandCount
+
+	var payments []TimeChargePayment
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusEliminated || p.Chips <= amount {
+			continue
+		}
+		p.Chips -= amount
+		g.TotalInitialChips -= amount
+		if g.stacksAfterHand != nil {
+			g.stacksAfterHand[p] = p.Chips
+		}
+		if g.TimeCharged == nil {
+			g.TimeCharged = make(map[string]int)
+		}
+		g.TimeCharged[p.Name] += amount
+		payments = append(payments, TimeChargePayment{PlayerName: p.Name, Amount: amount})
+	}
+	return payments, nil
+}
+
+// TotalTimeCharged returns the time charge collected from all players this
+// session.
+func (g *Game) TotalTimeCharged() int {
+	total := 0
+	for _, amount := range g.TimeCharged {
+		total += amount
+	}
+	return total
+}
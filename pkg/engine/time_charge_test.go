@@ -0,0 +1,152 @@
+package engine
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeCharge_CollectedEveryNHands(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.TimeCharge = &TimeCharge{Amount: 200, EveryHands: 2}
+
+	collections := 0
+	for i := 0; i < 5; i++ {
+		if g.TimeChargeDue() {
+			if _, err := g.CollectTimeCharge(); err != nil {
+				t.Fatalf("Failed to collect the time charge: %v", err)
+			}
+			collections++
+		}
+		playFoldedHand(g)
+	}
+
+	// Charged before hands 1, 3 and 5.
+	if collections != 3 {
+		t.Errorf("Expected 3 collections in 5 hands, but got %d", collections)
+	}
+	if got := g.TotalTimeCharged(); got != 3*3*200 {
+		t.Errorf("Expected %d chips charged, but got %d", 3*3*200, got)
+	}
+	if got := g.TimeCharged["CPU 1"]; got != 600 {
+		t.Errorf("Expected CPU 1 to have paid 600, but got %d", got)
+	}
+	total := 0
+	for _, p := range g.Players {
+		total += p.Chips
+	}
+	if total != 30000-1800 || g.TotalInitialChips != total {
+		t.Errorf("Expected %d chips in play, but got %d (expected total %d)", 30000-1800, total, g.TotalInitialChips)
+	}
+	if len(g.ChipViolations) != 0 {
+		t.Errorf("Expected the time charge to pass the chip audits, but got %v", g.ChipViolations)
+	}
+}
+
+func TestTimeCharge_CollectedEveryPeriodOfTime(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1"}, 10000, 50, 100)
+	now := time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC)
+	g.Now = func() time.Time { return now }
+	g.TimeCharge = &TimeCharge{Amount: 300, Every: 30 * time.Minute}
+
+	if !g.TimeChargeDue() {
+		t.Fatal("Expected the time charge to be due before the first hand")
+	}
+	if _, err := g.CollectTimeCharge(); err != nil {
+		t.Fatalf("Failed to collect the time charge: %v", err)
+	}
+	now = now.Add(29 * time.Minute)
+	if g.TimeChargeDue() {
+		t.Error("Expected no time charge due before the period is up")
+	}
+	now = now.Add(time.Minute)
+	if !g.TimeChargeDue() {
+		t.Error("Expected the time charge to be due once the period is up")
+	}
+}
+
+func TestTimeCharge_NeverChargesAPlayerOut(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.TimeCharge = &TimeCharge{Amount: 500, EveryHands: 1}
+	g.Players[1].Chips = 500
+	g.Players[2].Chips = 0
+	g.Players[2].Status = PlayerStatusEliminated
+	g.TotalInitialChips = 10500
+
+	payments, err := g.CollectTimeCharge()
+	if err != nil {
+		t.Fatalf("Failed to collect the time charge: %v", err)
+	}
+	if len(payments) != 1 || payments[0].PlayerName != "YOU" {
+		t.Errorf("Expected only YOU to pay, but got %v", payments)
+	}
+	if g.Players[1].Chips != 500 {
+		t.Errorf("Expected CPU 1 to keep a stack that does not cover more than the charge, but got %d", g.Players[1].Chips)
+	}
+}
+
+func TestTimeCharge_Replayed(t *testing.T) {
+	g := newGameForBettingTests([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100)
+	g.TimeCharge = &TimeCharge{Amount: 100, EveryHands: 1}
+	for i := 0; i < 3; i++ {
+		if _, err := g.CollectTimeCharge(); err != nil {
+			t.Fatalf("Failed to collect the time charge: %v", err)
+		}
+		playFoldedHand(g)
+	}
+
+	replayed, err := Replay(g.Events)
+	if err != nil {
+		t.Fatalf("Failed to replay the event log: %v", err)
+	}
+	if replayed.TotalInitialChips != g.TotalInitialChips || replayed.TotalTimeCharged() != g.TotalTimeCharged() {
+		t.Errorf("Expected %d chips in play and %d charged, but got %d and %d",
+			g.TotalInitialChips, g.TotalTimeCharged(), replayed.TotalInitialChips, replayed.TotalTimeCharged())
+	}
+	for i, p := range g.Players {
+		if r := replayed.Players[i]; r.Chips != p.Chips {
+			t.Errorf("%s: expected %d chips, but got %d", p.Name, p.Chips, r.Chips)
+		}
+	}
+	if len(replayed.ChipViolations) != 0 {
+		t.Errorf("Expected no chip violations in the replay, got %v", replayed.ChipViolations)
+	}
+}
+
+func TestTimeCharge_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		charge TimeCharge
+		valid  bool
+	}{
+		{name: "Every N hands", charge: TimeCharge{Amount: 100, EveryHands: 10}, valid: true},
+		{name: "Every N minutes", charge: TimeCharge{Amount: 100, Every: 30 * time.Minute}, valid: true},
+		{name: "No amount", charge: TimeCharge{EveryHands: 10}},
+		{name: "No period", charge: TimeCharge{Amount: 100}},
+		{name: "Both periods", charge: TimeCharge{Amount: 100, EveryHands: 10, Every: time.Minute}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.charge.Validate(); (err == nil) != tc.valid {
+				t.Errorf("Expected valid=%v, but got %v", tc.valid, err)
+			}
+		})
+	}
+}
+
+// TestTimeCharge_WithoutNow tests that a game built without Now times the
+// time charge by the system clock.
+func TestTimeCharge_WithoutNow(t *testing.T) {
+	g := &Game{
+		Players:    []*Player{{Name: "YOU", Chips: 1000}, {Name: "CPU 1", Chips: 1000}},
+		TimeCharge: &TimeCharge{Amount: 100, Every: time.Hour},
+	}
+	if _, err := g.CollectTimeCharge(); err != nil {
+		t.Fatalf("Failed to collect the time charge: %v", err)
+	}
+	if g.TimeChargeDue() {
+		t.Error("Expected the time charge not to be due again within the hour")
+	}
+	if time.Since(g.lastTimeCharge) > time.Minute {
+		t.Errorf("Expected the collection to be timed by the system clock, but got %v", g.lastTimeCharge)
+	}
+}
@@ -0,0 +1,567 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxTableSize is the most players seated at one table.
+const MaxTableSize = 6
+
+// minTournamentTableSize is the fewest seats a tournament table may have. With
+// two, balancing the tables could leave a player alone at one.
+const minTournamentTableSize = 3
+
+// MaxEntrants is the largest field a tournament can have.
+const MaxEntrants = 60
+
+// TournamentConfig describes a tournament: its field, stacks and blind
+// schedule, and how the prize pool is paid out.
+type TournamentConfig struct {
+	// Entrants is the number of players, the human included if they play.
+	Entrants int
+	// TableSize is the most players seated at one table, up to MaxTableSize.
+	TableSize int
+	// StartingStack is the chips every player starts with.
+	StartingStack int
+	// SmallBlind is the small blind of the first level, in units of which the
+	// structure's levels are given.
+	SmallBlind int
+	// Structure is the schedule of blind levels and antes.
+	Structure *BlindStructure
+	// HandsPerLevel, when positive, counts the levels in hands: the blinds go
+	// up after every table has played this many hands at a level. Otherwise
+	// the levels are timed by the tournament clock.
+	HandsPerLevel int
+	// LevelDuration is the length of a level on the tournament clock. 0 uses
+	// the structure's.
+	LevelDuration time.Duration
+	// BuyIn is what each entrant pays into the prize pool.
+	BuyIn int
+	// Payouts are the percentages of the prize pool paid to first place,
+	// second place, and so on. Nil uses DefaultPayouts.
+	Payouts []float64
+	// Human seats the human player, as "YOU", at the first table. Without
+	// them, every player is a CPU.
+	Human      bool
+	Difficulty Difficulty
+	Rules      *poker.GameRules
+}
+
+// validate checks the config, filling in the default payouts.
+func (c *TournamentConfig) validate() error {
+	switch {
+	case c.Entrants < 2 || c.Entrants > MaxEntrants:
+		return fmt.Errorf("a tournament needs 2 to %d entrants, got %d", MaxEntrants, c.Entrants)
+	case c.TableSize < minTournamentTableSize || c.TableSize > MaxTableSize:
+		return fmt.Errorf("tables must seat %d to %d players, got %d", minTournamentTableSize, MaxTableSize, c.TableSize)
+	case c.StartingStack <= 0:
+		return fmt.Errorf("starting stack must be positive, got %d", c.StartingStack)
+	case c.SmallBlind <= 0:
+		return fmt.Errorf("small blind must be positive, got %d", c.SmallBlind)
+	case c.Structure == nil:
+		return errors.New("a tournament needs a blind structure")
+	case c.HandsPerLevel < 0:
+		return fmt.Errorf("hands per level cannot be negative, got %d", c.HandsPerLevel)
+	case c.BuyIn < 0:
+		return fmt.Errorf("buy-in cannot be negative, got %d", c.BuyIn)
+	case c.Rules == nil:
+		return errors.New("a tournament needs game rules")
+	}
+	if c.Payouts == nil {
+		c.Payouts = DefaultPayouts(c.Entrants)
+	}
+	if len(c.Payouts) > c.Entrants {
+		return fmt.Errorf("%d places are paid, but there are only %d entrants", len(c.Payouts), c.Entrants)
+	}
+	total := 0.0
+	for _, p := range c.Payouts {
+		if p <= 0 {
+			return fmt.Errorf("payouts must be positive percentages, got %g", p)
+		}
+		total += p
+	}
+	if math.Abs(total-100) > 1e-6 {
+		return fmt.Errorf("payouts must add up to 100%%, got %g%%", total)
+	}
+	return nil
+}
+
+// DefaultPayouts returns the percentages of the prize pool paid to each place
+// in a field of the given size: about the top fifth of the field is paid, and
+// the winner takes all in the smallest fields.
+func DefaultPayouts(entrants int) []float64 {
+	switch {
+	case entrants <= 4:
+		return []float64{100}
+	case entrants <= 6:
+		return []float64{65, 35}
+	case entrants <= 12:
+		return []float64{50, 30, 20}
+	case entrants <= 24:
+		return []float64{40, 25, 15, 12, 8}
+	default:
+		return []float64{30, 20, 14, 10, 8, 6, 5, 4, 3}
+	}
+}
+
+// PrizeAmounts splits a prize pool by the payout percentages. Each prize is
+// rounded down, and the chips left over from the rounding go to the winner.
+func PrizeAmounts(prizePool int, payouts []float64) []int {
+	prizes := make([]int, len(payouts))
+	paid := 0
+	for i, p := range payouts {
+		prizes[i] = int(math.Floor(float64(prizePool)*p/100 + 1e-9))
+		paid += prizes[i]
+	}
+	if len(prizes) > 0 {
+		prizes[0] += prizePool - paid
+	}
+	return prizes
+}
+
+// Finish records where a player finished in a tournament.
+type Finish struct {
+	PlayerName string
+	// Place is the finishing position, 1 for the winner.
+	Place int
+	// Round is the round in which the player was eliminated, or the last
+	// round for the winner.
+	Round int
+	// Prize is the player's share of the prize pool, 0 outside the money.
+	Prize int
+}
+
+// TournamentTable is one of the tables of a tournament, with its own game.
+type TournamentTable struct {
+	// Number identifies the table, starting at 1. Numbers of broken tables
+	// are not reused.
+	Number int
+	Game   *Game
+	// tournament is the tournament the table belongs to.
+	tournament *Tournament
+	// level is the blind level last applied at the table.
+	level int
+}
+
+// syncLevel reports whether the tournament's clock has moved to a new level
+// since the table's blinds were last raised, and catches the table up.
+func (t *TournamentTable) syncLevel() bool {
+	if t.Game.Clock.Level == t.level {
+		return false
+	}
+	t.level = t.Game.Clock.Level
+	return true
+}
+
+// livePlayers returns the players at the table who have not been eliminated.
+func (t *TournamentTable) livePlayers() []*Player {
+	var players []*Player
+	for _, p := range t.Game.Players {
+		if p.Status != PlayerStatusEliminated {
+			players = append(players, p)
+		}
+	}
+	return players
+}
+
+// Tournament is a multi-table tournament. It is played in rounds, each of
+// which deals one hand at every table. Between rounds, the tournament places
+// the players eliminated, and balances the tables: it moves players from the
+// longest tables to the shortest, and breaks tables once the remaining players
+// fit at fewer of them, until the final table plays down to the winner.
+type Tournament struct {
+	Config TournamentConfig
+	// Tables are the tables still in play, in the order they were opened.
+	Tables []*TournamentTable
+	// Clock sets the blind level of every table.
+	Clock *TournamentClock
+	// Round counts the rounds started.
+	Round int
+	// PrizePool is the sum of the buy-ins.
+	PrizePool int
+	// Prizes are the prize amounts for first place, second place, and so on.
+	Prizes []int
+	// Finishes lists the players placed so far, from the first eliminated;
+	// the winner comes last, once the tournament is over.
+	Finishes []Finish
+	// human is the human player, or nil if every player is a CPU.
+	human *Player
+	// roundStacks holds the stack of every player still in at the start of
+	// the current round, to place the players eliminated in it.
+	roundStacks map[*Player]int
+}
+
+// NewTournament seats the entrants at as few tables as hold them, with the
+// same number of players at each table give or take one. The human, if they
+// play, sits at the first table.
+func NewTournament(config TournamentConfig) (*Tournament, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	levelDuration := config.LevelDuration
+	if levelDuration == 0 {
+		levelDuration = config.Structure.LevelDuration
+	}
+	if config.HandsPerLevel > 0 {
+		levelDuration = 0
+	}
+	t := &Tournament{
+		Config:    config,
+		Clock:     NewTournamentClock(levelDuration),
+		PrizePool: config.BuyIn * config.Entrants,
+	}
+	t.Prizes = PrizeAmounts(t.PrizePool, config.Payouts)
+
+	numTables := (config.Entrants + config.TableSize - 1) / config.TableSize
+	seats := make([][]string, numTables)
+	cpu := 0
+	for i := 0; i < config.Entrants; i++ {
+		name := "YOU"
+		if i > 0 || !config.Human {
+			cpu++
+			name = fmt.Sprintf("CPU %d", cpu)
+		}
+		seats[i%numTables] = append(seats[i%numTables], name)
+	}
+	for i, names := range seats {
+		g := NewGame(names, config.StartingStack, config.SmallBlind, 2*config.SmallBlind, config.Difficulty, config.Rules, false, false, 0)
+		g.Clock = t.Clock
+		g.UseBlindStructure(config.Structure)
+		table := &TournamentTable{Number: i + 1, Game: g, tournament: t, level: t.Clock.Level}
+		g.table = table
+		t.Tables = append(t.Tables, table)
+	}
+	if config.Human {
+		t.human = t.Tables[0].Game.Players[0]
+	}
+	return t, nil
+}
+
+// HumanTable returns the table the human player sits at, or nil if they do
+// not play or have been eliminated.
+func (t *Tournament) HumanTable() *TournamentTable {
+	if t.human == nil || t.human.Status == PlayerStatusEliminated {
+		return nil
+	}
+	for _, table := range t.Tables {
+		for _, p := range table.Game.Players {
+			if p == t.human {
+				return table
+			}
+		}
+	}
+	return nil
+}
+
+// PlayersRemaining returns the number of players who have not been eliminated.
+func (t *Tournament) PlayersRemaining() int {
+	count := 0
+	for _, table := range t.Tables {
+		count += table.Game.CountRemainingPlayers()
+	}
+	return count
+}
+
+// ChipsInPlay returns the chips in play at all the tables.
+func (t *Tournament) ChipsInPlay() int {
+	total := 0
+	for _, table := range t.Tables {
+		total += table.Game.TotalInitialChips
+	}
+	return total
+}
+
+// Over reports whether the tournament has a winner.
+func (t *Tournament) Over() bool {
+	return t.PlayersRemaining() <= 1
+}
+
+// handsRemaining returns the hands left in the current level, counting the
+// current round's, or 0 if the levels are timed.
+func (t *Tournament) handsRemaining() int {
+	if t.Config.HandsPerLevel <= 0 {
+		return 0
+	}
+	if t.Round < 1 {
+		return t.Config.HandsPerLevel
+	}
+	return t.Config.HandsPerLevel - (t.Round-1)%t.Config.HandsPerLevel
+}
+
+// levelOver reports whether the current level's hands have been played, or
+// its time has run out.
+func (t *Tournament) levelOver() bool {
+	if hands := t.Config.HandsPerLevel; hands > 0 {
+		return (t.Round-1)%hands == 0
+	}
+	return t.Clock.LevelExpired()
+}
+
+// StartRound begins a round. The clock moves to the next level once the
+// level's hands have been played or its time has run out, and each table
+// raises its blinds when it deals its next hand.
+func (t *Tournament) StartRound() {
+	t.Round++
+	if t.Round > 1 && t.levelOver() {
+		t.Clock.NextLevel()
+	}
+	t.roundStacks = make(map[*Player]int)
+	for _, table := range t.Tables {
+		for _, p := range table.livePlayers() {
+			t.roundStacks[p] = p.Chips
+		}
+	}
+}
+
+// PlayCPUHands plays the round's hand at every table but the human's, with
+// every player acting as a CPU at once. The human's hand is left to the
+// caller, who prompts them for their actions.
+func (t *Tournament) PlayCPUHands() {
+	human := t.HumanTable()
+	for _, table := range t.Tables {
+		if table != human {
+			table.Game.PlayCPUHand(CPUHandHooks{})
+		}
+	}
+}
+
+// CPUHandHooks let the caller of PlayCPUHand observe the parts of the hand,
+// e.g. to time them. Any of them may be nil.
+type CPUHandHooks struct {
+	// BeforeBettingRound is called before each betting round.
+	BeforeBettingRound func()
+	// Decision is called with each CPU decision, which it must run.
+	Decision func(decide func())
+	// PotDistribution is called with the distribution of the pot at the end
+	// of the hand, which it must run.
+	PotDistribution func(distribute func())
+}
+
+// PlayCPUHand plays a hand from the deal to the cleanup with every player
+// acting as a CPU, calling the hooks along the way.
+func (g *Game) PlayCPUHand(hooks CPUHandHooks) {
+	run := func(hook func(func()), f func()) {
+		if hook == nil {
+			f()
+			return
+		}
+		hook(f)
+	}
+	g.StartNewHand()
+	for g.Phase != PhaseShowdown && g.Phase != PhaseHandOver {
+		if g.CountNonFoldedPlayers() <= 1 {
+			break
+		}
+		if hooks.BeforeBettingRound != nil {
+			hooks.BeforeBettingRound()
+		}
+		g.PrepareNewBettingRound()
+		for !g.IsBettingRoundOver() {
+			player := g.CurrentPlayer()
+			if player.Status != PlayerStatusPlaying {
+				g.AdvanceTurn()
+				continue
+			}
+			var action PlayerAction
+			run(hooks.Decision, func() { action = g.GetCPUAction(player, g.Rand) })
+			g.ProcessAction(player, action)
+			g.AdvanceTurn()
+		}
+		g.Advance()
+	}
+	run(hooks.PotDistribution, func() {
+		if g.CountNonFoldedPlayers() > 1 {
+			g.DistributePot()
+		} else {
+			g.AwardPotToLastPlayer()
+		}
+	})
+	g.CleanupHand()
+}
+
+// FinishRound ends a round once every table has played its hand. It places
+// the players eliminated in the round, the winner once one player is left,
+// and balances the tables. Players eliminated in the same round are placed by
+// the stacks they started the round with, the bigger stack finishing higher.
+// It returns messages announcing the finishes and the players moved.
+func (t *Tournament) FinishRound() []string {
+	var eliminated []*Player
+	for _, table := range t.Tables {
+		for _, p := range table.Game.Players {
+			if _, ok := t.roundStacks[p]; ok && p.Status == PlayerStatusEliminated {
+				eliminated = append(eliminated, p)
+			}
+		}
+	}
+	sort.SliceStable(eliminated, func(i, j int) bool {
+		return t.roundStacks[eliminated[i]] < t.roundStacks[eliminated[j]]
+	})
+	t.roundStacks = nil
+
+	var messages []string
+	place := t.PlayersRemaining() + len(eliminated)
+	for _, p := range eliminated {
+		messages = append(messages, t.place(p, place))
+		place--
+	}
+	if t.Over() {
+		for _, table := range t.Tables {
+			for _, p := range table.livePlayers() {
+				messages = append(messages, t.place(p, 1))
+			}
+		}
+		return messages
+	}
+	return append(messages, t.balanceTables()...)
+}
+
+// place records a player's finish and announces it.
+func (t *Tournament) place(p *Player, place int) string {
+	finish := Finish{PlayerName: p.Name, Place: place, Round: t.Round}
+	if place <= len(t.Prizes) {
+		finish.Prize = t.Prizes[place-1]
+	}
+	t.Finishes = append(t.Finishes, finish)
+	if place == 1 {
+		return fmt.Sprintf("%s wins the tournament and %d!", p.Name, finish.Prize)
+	}
+	if finish.Prize > 0 {
+		return fmt.Sprintf("%s finishes %s and wins %d.", p.Name, Ordinal(place), finish.Prize)
+	}
+	return fmt.Sprintf("%s finishes %s.", p.Name, Ordinal(place))
+}
+
+// Results returns the finishes from the winner down.
+func (t *Tournament) Results() []Finish {
+	results := append([]Finish(nil), t.Finishes...)
+	sort.Slice(results, func(i, j int) bool { return results[i].Place < results[j].Place })
+	return results
+}
+
+// balanceTables breaks tables until the remaining players fill as few tables
+// as hold them, then moves players from the longest table to the shortest
+// until no table has two players more than another. The human's table is
+// never broken, and the human never moves.
+func (t *Tournament) balanceTables() []string {
+	var messages []string
+	needed := (t.PlayersRemaining() + t.Config.TableSize - 1) / t.Config.TableSize
+	for len(t.Tables) > needed {
+		broken := t.shortestTable(t.HumanTable())
+		for i, table := range t.Tables {
+			if table == broken {
+				t.Tables = append(t.Tables[:i], t.Tables[i+1:]...)
+				break
+			}
+		}
+		messages = append(messages, fmt.Sprintf("Table %d breaks.", broken.Number))
+		for _, p := range broken.livePlayers() {
+			messages = append(messages, t.movePlayer(p, broken, t.shortestTable(nil)))
+		}
+	}
+	for {
+		from, to := t.longestTable(), t.shortestTable(nil)
+		if len(from.livePlayers())-len(to.livePlayers()) < 2 {
+			return messages
+		}
+		messages = append(messages, t.movePlayer(t.nextToMove(from), from, to))
+	}
+}
+
+// shortestTable returns the table with the fewest players, other than except.
+func (t *Tournament) shortestTable(except *TournamentTable) *TournamentTable {
+	var shortest *TournamentTable
+	for _, table := range t.Tables {
+		if table != except && (shortest == nil || len(table.livePlayers()) < len(shortest.livePlayers())) {
+			shortest = table
+		}
+	}
+	return shortest
+}
+
+// longestTable returns the table with the most players.
+func (t *Tournament) longestTable() *TournamentTable {
+	longest := t.Tables[0]
+	for _, table := range t.Tables[1:] {
+		if len(table.livePlayers()) > len(longest.livePlayers()) {
+			longest = table
+		}
+	}
+	return longest
+}
+
+// nextToMove returns the player a table gives up when it is balanced: as in a
+// casino, the player due the big blind next, so that nobody pays the blinds
+// twice or skips them. The human is passed over.
+func (t *Tournament) nextToMove(table *TournamentTable) *Player {
+	g := table.Game
+	pos := g.FindNextActivePlayer(max(g.BigBlindPos, 0))
+	for g.Players[pos] == t.human {
+		pos = g.FindNextActivePlayer(pos)
+	}
+	return g.Players[pos]
+}
+
+// movePlayer moves a player, with their stack, to the last seat of another
+// table, between hands.
+func (t *Tournament) movePlayer(p *Player, from, to *TournamentTable) string {
+	from.Game.unseat(p)
+	to.Game.seat(p)
+	return fmt.Sprintf("%s moves from table %d to table %d.", p.Name, from.Number, to.Number)
+}
+
+// unseat removes a player from the table between hands, taking their chips
+// out of play. The positions of the seats after theirs shift down by one.
+func (g *Game) unseat(p *Player) {
+	idx := -1
+	for i, seated := range g.Players {
+		if seated == p {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return
+	}
+	g.Players = append(g.Players[:idx], g.Players[idx+1:]...)
+	for i, seated := range g.Players {
+		seated.Position = i
+	}
+	for _, pos := range []*int{&g.DealerPos, &g.SmallBlindPos, &g.BigBlindPos, &g.smallBlindSeat} {
+		if *pos > idx {
+			*pos--
+		}
+	}
+	g.TotalInitialChips -= p.Chips
+	delete(g.stacksAfterHand, p)
+}
+
+// seat adds a player to the last seat of the table between hands, bringing
+// their chips into play.
+func (g *Game) seat(p *Player) {
+	p.Position = len(g.Players)
+	g.Players = append(g.Players, p)
+	g.TotalInitialChips += p.Chips
+	if g.stacksAfterHand != nil {
+		g.stacksAfterHand[p] = p.Chips
+	}
+}
+
+// Ordinal returns a place as an English ordinal, e.g. "1st" or "12th".
+func Ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
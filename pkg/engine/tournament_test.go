@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"testing"
+)
+
+func newTestTournament(t *testing.T, entrants int, human bool) *Tournament {
+	t.Helper()
+	structure, err := LookupBlindStructure("hyper")
+	if err != nil {
+		t.Fatalf("Failed to look up the hyper structure: %v", err)
+	}
+	tournament, err := NewTournament(TournamentConfig{
+		Entrants:      entrants,
+		TableSize:     6,
+		StartingStack: 20000,
+		SmallBlind:    100,
+		Structure:     structure,
+		HandsPerLevel: 3,
+		BuyIn:         100,
+		Human:         human,
+		Difficulty:    DifficultyMedium,
+		Rules:         loadRule(t, "nlh.yml"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create the tournament: %v", err)
+	}
+	return tournament
+}
+
+func tableSizes(tournament *Tournament) []int {
+	sizes := make([]int, len(tournament.Tables))
+	for i, table := range tournament.Tables {
+		sizes[i] = len(table.livePlayers())
+	}
+	return sizes
+}
+
+func TestPrizeAmounts_OddChipsToWinner(t *testing.T) {
+	prizes := PrizeAmounts(1001, []float64{50, 30, 20})
+	expected := []int{501, 300, 200}
+	for i := range expected {
+		if prizes[i] != expected[i] {
+			t.Fatalf("Expected prizes %v, but got %v", expected, prizes)
+		}
+	}
+}
+
+func TestDefaultPayouts_AddUpToTheWholePool(t *testing.T) {
+	for _, entrants := range []int{2, 5, 9, 18, 60} {
+		total := 0.0
+		payouts := DefaultPayouts(entrants)
+		for _, p := range payouts {
+			total += p
+		}
+		if total != 100 || len(payouts) > entrants {
+			t.Errorf("%d entrants: expected payouts adding up to 100%% for at most every entrant, but got %v", entrants, payouts)
+		}
+	}
+}
+
+func TestNewTournament_SeatsEntrantsEvenly(t *testing.T) {
+	tournament := newTestTournament(t, 14, true)
+
+	sizes := tableSizes(tournament)
+	if len(sizes) != 3 || sizes[0] != 5 || sizes[1] != 5 || sizes[2] != 4 {
+		t.Errorf("Expected tables of 5, 5 and 4 players, but got %v", sizes)
+	}
+	if human := tournament.HumanTable(); human != tournament.Tables[0] || human.Game.Players[0].Name != "YOU" {
+		t.Errorf("Expected the human in the first seat of table 1")
+	}
+	if tournament.PrizePool != 1400 || len(tournament.Prizes) != 5 {
+		t.Errorf("Expected a 1,400 prize pool paid to 5 places, but got %d paid to %d", tournament.PrizePool, len(tournament.Prizes))
+	}
+}
+
+func TestNewTournament_RejectsInvalidConfigs(t *testing.T) {
+	structure, _ := LookupBlindStructure("turbo")
+	valid := TournamentConfig{
+		Entrants: 9, TableSize: 6, StartingStack: 10000, SmallBlind: 50,
+		Structure: structure, Rules: loadRule(t, "nlh.yml"),
+	}
+	testCases := []struct {
+		name   string
+		modify func(c *TournamentConfig)
+	}{
+		{name: "One entrant", modify: func(c *TournamentConfig) { c.Entrants = 1 }},
+		{name: "Tables too small", modify: func(c *TournamentConfig) { c.TableSize = 2 }},
+		{name: "No structure", modify: func(c *TournamentConfig) { c.Structure = nil }},
+		{name: "Payouts short of 100%", modify: func(c *TournamentConfig) { c.Payouts = []float64{60, 30} }},
+		{name: "More places paid than entrants", modify: func(c *TournamentConfig) { c.Payouts = make([]float64, 10) }},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := valid
+			tc.modify(&config)
+			if _, err := NewTournament(config); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTournament_FinishRoundPlacesAndBalances(t *testing.T) {
+	tournament := newTestTournament(t, 12, true)
+	second := tournament.Tables[1].Game.Players
+	// Three players at table 2 bust in the same round, the shortest stack first.
+	second[0].Chips, second[1].Chips = 5000, 8000
+	tournament.StartRound()
+	for _, p := range second[:3] {
+		p.Chips, p.Status = 0, PlayerStatusEliminated
+	}
+
+	tournament.FinishRound()
+
+	wantPlaces := map[string]int{second[0].Name: 12, second[1].Name: 11, second[2].Name: 10}
+	for _, f := range tournament.Finishes {
+		if wantPlaces[f.PlayerName] != f.Place {
+			t.Errorf("Expected %s to finish %d, but got %d", f.PlayerName, wantPlaces[f.PlayerName], f.Place)
+		}
+	}
+	if sizes := tableSizes(tournament); sizes[0] != 5 || sizes[1] != 4 {
+		t.Errorf("Expected one player moved to table 2, leaving 5 and 4, but got %v", sizes)
+	}
+	if tournament.HumanTable() != tournament.Tables[0] {
+		t.Error("Expected the human to stay at table 1")
+	}
+
+	// Once the players left fit at one table, table 2 breaks.
+	tournament.StartRound()
+	for _, p := range tournament.Tables[1].livePlayers()[:3] {
+		p.Chips, p.Status = 0, PlayerStatusEliminated
+	}
+	tournament.FinishRound()
+	if len(tournament.Tables) != 1 || tournament.Tables[0].Number != 1 || len(tournament.Tables[0].livePlayers()) != 6 {
+		t.Errorf("Expected a final table 1 of 6 players, but got %d tables of %v", len(tournament.Tables), tableSizes(tournament))
+	}
+}
+
+func TestTournament_PlaysDownToTheWinner(t *testing.T) {
+	tournament := newTestTournament(t, 14, false)
+
+	for rounds := 0; !tournament.Over(); rounds++ {
+		if rounds == 2000 {
+			t.Fatalf("Expected the tournament to end within %d rounds; %d players remain", rounds, tournament.PlayersRemaining())
+		}
+		tournament.StartRound()
+		tournament.PlayCPUHands()
+		tournament.FinishRound()
+
+		sizes := tableSizes(tournament)
+		for _, size := range sizes {
+			if size-sizes[0] > 1 || sizes[0]-size > 1 {
+				t.Fatalf("Round %d: expected balanced tables, but got %v", tournament.Round, sizes)
+			}
+		}
+		for _, table := range tournament.Tables {
+			if len(table.Game.ChipViolations) > 0 {
+				t.Fatalf("Round %d, table %d: unexpected chip violations: %v", tournament.Round, table.Number, table.Game.ChipViolations)
+			}
+		}
+	}
+
+	results := tournament.Results()
+	if len(results) != 14 {
+		t.Fatalf("Expected 14 finishes, but got %d", len(results))
+	}
+	names := make(map[string]bool)
+	paid := 0
+	for i, f := range results {
+		if f.Place != i+1 {
+			t.Errorf("Expected place %d, but got %d", i+1, f.Place)
+		}
+		names[f.PlayerName] = true
+		paid += f.Prize
+	}
+	if len(names) != 14 {
+		t.Errorf("Expected every player to finish once, but got %v", results)
+	}
+	if paid != tournament.PrizePool {
+		t.Errorf("Expected the whole prize pool of %d to be paid, but %d was", tournament.PrizePool, paid)
+	}
+	if tournament.Clock.Level < 2 {
+		t.Errorf("Expected the blinds to go up during the tournament, but the level is %d", tournament.Clock.Level)
+	}
+}
+
+// TestPlayCPUHand_CallsHooks tests that the hooks see every decision, a
+// betting round per street played, and one pot distribution.
+func TestPlayCPUHand_CallsHooks(t *testing.T) {
+	g := newGameForBettingTestsWithRules([]string{"CPU 1", "CPU 2", "CPU 3"}, 10000, 50, 100, "NLH")
+	var rounds, decisions, distributions int
+	g.PlayCPUHand(CPUHandHooks{
+		BeforeBettingRound: func() { rounds++ },
+		Decision: func(decide func()) {
+			decisions++
+			decide()
+		},
+		PotDistribution: func(distribute func()) {
+			distributions++
+			distribute()
+		},
+	})
+
+	if rounds < 1 || decisions < 2 || distributions != 1 {
+		t.Errorf("Expected the hooks to be called, but got %d betting rounds, %d decisions and %d distributions", rounds, decisions, distributions)
+	}
+	if len(g.History.Actions) != decisions {
+		t.Errorf("Expected a decision for each of the %d actions, but got %d", len(g.History.Actions), decisions)
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import "github.com/sirupsen/logrus"
+
+// UncalledBet is the part of a bet no other player matched, which goes back
+// to the player who made it before the pot is distributed.
+type UncalledBet struct {
+	PlayerName string `json:"player_name"`
+	Amount     int    `json:"amount"`
+}
+
+// returnUncalledBet gives the player with the largest bet in the pot back the
+// excess over the second-largest, so that every pot tier is contested by at
+// least two bets. Nothing is returned to a player who has folded: their chips
+// stay in the pot as dead money. Dead antes, which are not bets, are left
+// out. It records the bet returned in Uncalled and the hand history.
+func (g *Game) returnUncalledBet() {
+	g.Uncalled = nil
+	var top *Player
+	second := 0
+	for _, p := range g.Players {
+		if p.Status == PlayerStatusEliminated {
+			continue
+		}
+		switch bet := p.tieredBet(); {
+		case top == nil || bet > top.tieredBet():
+			if top != nil {
+				second = top.tieredBet()
+			}
+			top = p
+		case bet > second:
+			second = bet
+		}
+	}
+	if top == nil || top.Status == PlayerStatusFolded {
+		return
+	}
+	excess := top.tieredBet() - second
+	if excess <= 0 {
+		return
+	}
+	top.Chips += excess
+	top.TotalBetInHand -= excess
+	// With no other bet in, even the player's ante comes back.
+	top.Ante = min(top.Ante, top.TotalBetInHand)
+	g.Pot -= excess
+	g.Uncalled = &UncalledBet{PlayerName: top.Name, Amount: excess}
+	if g.History != nil {
+		g.History.Uncalled = g.Uncalled
+	}
+	logrus.Debugf("Uncalled bet of %d returned to %s", excess, top.Name)
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/validate"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ActionSpot describes the betting as the player to act faces it, for the
+// validate package: what they must call, their stack, and the totals they may
+// bet or raise to.
+func (g *Game) ActionSpot(player *Player) validate.Spot {
+	spot := validate.Spot{
+		ToCall:     max(g.BetToCall-player.CurrentBet, 0),
+		Stack:      player.Chips,
+		CurrentBet: player.CurrentBet,
+		Capped:     g.BettingCapped(),
+		ChipUnit:   g.ChipUnit(),
+		MustBet:    g.mustBet(player),
+	}
+	if g.BettingCalculator != nil && g.CurrentTurnPos >= 0 && g.CurrentTurnPos < len(g.Players) {
+		spot.MinTotal, spot.MaxTotal = g.CalculateBettingLimits()
+	}
+	return spot
+}
+
+// ValidateAction checks that the player to act may take the action, by the
+// rules of the validate package. Actions that do not bet, such as showing a
+// card, are always valid.
+func (g *Game) ValidateAction(player *Player, action PlayerAction) error {
+	move, ok := bettingMoves[action.Type]
+	if !ok {
+		return nil
+	}
+	return g.ActionSpot(player).Validate(move, action.Amount)
+}
+
+// bettingMoves maps the betting actions to the validate package's moves.
+var bettingMoves = map[ActionType]validate.Move{
+	ActionFold:  validate.Fold,
+	ActionCheck: validate.Check,
+	ActionCall:  validate.Call,
+	ActionBet:   validate.Bet,
+	ActionRaise: validate.Raise,
+}
+
+// IllegalAction records an action by the player to act that broke the
+// betting rules when it was applied.
+type IllegalAction struct {
+	HandNumber int
+	PlayerName string
+	Action     PlayerAction
+	// Reason is why the validate package rejected the action.
+	Reason string
+}
+
+// String describes the illegal action, e.g.
+// "hand #3: CPU 1 raise to 1500: there is no bet to raise; bet instead".
+func (a IllegalAction) String() string {
+	action := a.Action.Type.String()
+	if a.Action.Type == ActionBet || a.Action.Type == ActionRaise {
+		action += fmt.Sprintf(" to %d", a.Action.Amount)
+	}
+	return fmt.Sprintf("hand #%d: %s %s: %s", a.HandNumber, a.PlayerName, strings.ToLower(action), a.Reason)
+}
+
+// guardAction checks an action about to be applied by ProcessAction. Like a
+// chip violation, an illegal action points at a bug in whatever chose it, so
+// it is logged and kept rather than refused: ProcessAction has no way to
+// refuse it. Actions from outside the game loop are refused before this, by
+// SubmitAction.
+func (g *Game) guardAction(player *Player, action PlayerAction) {
+	if g.CurrentTurnPos < 0 || g.CurrentTurnPos >= len(g.Players) || g.CurrentPlayer() != player {
+		return
+	}
+	if err := g.ValidateAction(player, action); err != nil {
+		a := IllegalAction{HandNumber: g.HandCount, PlayerName: player.Name, Action: action, Reason: err.Error()}
+		logrus.Errorf("Illegal action: %s", a)
+		g.IllegalActions = append(g.IllegalActions, a)
+	}
+}
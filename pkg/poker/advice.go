@@ -0,0 +1,96 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Advice sums up a hand's prospects on a board, away from any game: the hand
+// made so far, the outs to improve it, and its equity against random hands.
+type Advice struct {
+	// High and Low are the best hands made so far, or nil if none is.
+	High, Low *HandResult
+	// Outs holds the outs to improve the hand. It is nil before the flop and
+	// on the river, when there are no outs to count.
+	Outs *OutsInfo
+	// Opponents is the number of opponents the equity was estimated against.
+	Opponents int
+	// Equity is the hand's expected share of the pot against that many
+	// random hands.
+	Equity float64
+}
+
+// Advise evaluates the hole cards on the board, counts their outs, and
+// estimates their equity against opponents random hands from samples runouts.
+// The hole cards must be as many as the rules deal, the board 0, 3, 4 or 5
+// cards, and no card may appear twice.
+func Advise(ctx context.Context, hole, board []Card, opponents, samples int, rules *GameRules, r *rand.Rand) (*Advice, error) {
+	if len(hole) != rules.HoleCards.Count {
+		return nil, fmt.Errorf("%s deals %d hole cards, got %d", rules.Name, rules.HoleCards.Count, len(hole))
+	}
+	if n := len(board); n != 0 && (n < StreetFlop.BoardSize() || n > StreetRiver.BoardSize()) {
+		return nil, fmt.Errorf("the board must have 0, 3, 4 or 5 cards, got %d", n)
+	}
+	seen := make(map[Card]bool)
+	for _, c := range append(append([]Card(nil), hole...), board...) {
+		if seen[c] {
+			return nil, fmt.Errorf("%s appears twice", c)
+		}
+		seen[c] = true
+	}
+
+	street, err := StreetForBoard(len(board))
+	if err != nil {
+		return nil, err
+	}
+	evaluation, err := EvaluateStreet(ctx, hole, board, street, rules)
+	if err != nil {
+		return nil, err
+	}
+	advice := &Advice{High: evaluation.High, Low: evaluation.Low, Outs: evaluation.Outs, Opponents: opponents}
+	equity, err := EstimateEquity(ctx, hole, board, opponents, samples, rules, r)
+	if err != nil {
+		return nil, err
+	}
+	advice.Equity = equity
+	return advice, nil
+}
+
+// MaxProfitableCall returns the largest bet into a pot of the given size that
+// is worth calling at the hand's equity: the bet at which the call just breaks
+// even, winning the pot, the bet and the call. It is 0 without equity, and -1
+// if any bet is worth calling, as it is from an equity of one half up.
+func (a *Advice) MaxProfitableCall(pot int) int {
+	switch {
+	case a.Equity <= 0:
+		return 0
+	case a.Equity >= 0.5:
+		return -1
+	}
+	// Calling a bet of c wins pot + 2c with the equity: e*(pot+2c) = c.
+	return int(a.Equity * float64(pot) / (1 - 2*a.Equity))
+}
+
+// BreakEvenEquity returns the equity a call needs to break even, facing a bet
+// into a pot of the given size.
+func BreakEvenEquity(pot, bet int) float64 {
+	return CalculateBreakEvenEquityBasedOnPotOdds(pot+bet, bet)
+}
+
+// ParseHandAndBoard parses hole cards and a board separated by a slash, e.g.
+// "As Ks 2s / Qs Js 4d". The board may be left out.
+func ParseHandAndBoard(s string) (hole, board []Card, err error) {
+	holePart, boardPart, _ := strings.Cut(s, "/")
+	if hole, err = ParseCards(holePart); err != nil {
+		return nil, nil, fmt.Errorf("hole cards: %w", err)
+	}
+	if len(hole) == 0 {
+		return nil, nil, fmt.Errorf("no hole cards in %q", s)
+	}
+	if board, err = ParseCards(boardPart); err != nil {
+		return nil, nil, fmt.Errorf("board: %w", err)
+	}
+	return hole, board, nil
+}
@@ -0,0 +1,84 @@
+package poker
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestAdvise(t *testing.T) {
+	hole, board, err := ParseHandAndBoard("Ah Kh / Qh 7h 2c")
+	if err != nil {
+		t.Fatalf("Failed to parse the cards: %v", err)
+	}
+	advice, err := Advise(context.Background(), hole, board, 1, 2000, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to advise: %v", err)
+	}
+	if advice.High == nil || advice.High.Rank != HighCard {
+		t.Errorf("Expected ace high, but got %v", advice.High)
+	}
+	if advice.Outs == nil || len(advice.Outs.OutsPerHandRank[Flush]) != 9 {
+		t.Errorf("Expected 9 outs to a flush, but got %+v", advice.Outs)
+	}
+	if advice.Equity < 0.5 || advice.Equity > 0.8 {
+		t.Errorf("Expected the nut flush draw with two overcards to be ahead of a random hand, but got %.3f", advice.Equity)
+	}
+
+	river, err := Advise(context.Background(), hole, CardsFromStrings("Qh 7h 2c 3s 9d"), 1, 100, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to advise: %v", err)
+	}
+	if river.Outs != nil {
+		t.Errorf("Expected no outs on the river, but got %+v", river.Outs)
+	}
+}
+
+func TestAdvise_InvalidCards(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{name: "Too many hole cards", input: "Ah Kh Qh / 2c 3c 4c"},
+		{name: "Board of two cards", input: "Ah Kh / 2c 3c"},
+		{name: "Card on the board and in the hole", input: "Ah Kh / Ah 3c 4c"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			hole, board, err := ParseHandAndBoard(tc.input)
+			if err != nil {
+				t.Fatalf("Failed to parse the cards: %v", err)
+			}
+			if _, err := Advise(context.Background(), hole, board, 1, 10, holdemRules, rand.New(rand.NewSource(1))); err == nil {
+				t.Errorf("Expected %q to be rejected", tc.input)
+			}
+		})
+	}
+	var parseErr *CardParseError
+	if _, _, err := ParseHandAndBoard("Ah Kx / 2c 3c 4c"); !errors.As(err, &parseErr) || parseErr.Token != "Kx" {
+		t.Errorf("Expected a *CardParseError for Kx, but got %v", err)
+	}
+}
+
+func TestAdvice_MaxProfitableCall(t *testing.T) {
+	testCases := []struct {
+		equity   float64
+		expected int
+	}{
+		{equity: 0, expected: 0},
+		{equity: 0.25, expected: 500}, // 0.25 * (1,000 + 500 + 500) = 500
+		{equity: 1.0 / 3, expected: 1000},
+		{equity: 0.5, expected: -1},
+	}
+	for _, tc := range testCases {
+		advice := &Advice{Equity: tc.equity}
+		// The bet is rounded down to a chip.
+		if got := advice.MaxProfitableCall(1000); got != tc.expected && got != tc.expected-1 {
+			t.Errorf("Equity %.3f: expected calls up to %d, but got %d", tc.equity, tc.expected, got)
+		}
+	}
+	if got := BreakEvenEquity(1000, 500); got != 0.25 {
+		t.Errorf("Expected a bet of half the pot to need 25%% equity, but got %.3f", got)
+	}
+}
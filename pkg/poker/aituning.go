@@ -0,0 +1,90 @@
+package poker
+
+import "fmt"
+
+// AITuning is a variant's AI tuning pack, loaded from the file named by the
+// rules' ai_tuning key. It tells the CPUs how to value starting hands, how
+// their aggression changes from street to street, and how much a low hand is
+// worth, so that a new variant can ship with sensible CPU play. Without a pack,
+// the CPUs fall back to generic heuristics.
+type AITuning struct {
+	// StartingHands scores hole cards before the flop.
+	StartingHands StartingHandTable `yaml:"starting_hands"`
+	// Aggression scales each CPU's aggression on the streets after the flop.
+	Aggression AggressionCurve `yaml:"aggression"`
+	// LowHand weighs low cards and made lows in High-Low split games.
+	LowHand LowHandWeights `yaml:"low_hand"`
+}
+
+// StartingHandTable scores hole cards before the flop. The score is compared
+// with each CPU profile's play and raise thresholds, so the points should add
+// up to roughly 10 for a marginal hand and 30 for a premium one.
+type StartingHandTable struct {
+	// RankPoints scores each hole card by its rank, keyed by the rank as
+	// written in card notation ("A", "K", "Q", "J", "T", "9", ... "2"). Ranks
+	// that are not listed score nothing.
+	RankPoints map[string]float64 `yaml:"rank_points"`
+	// CountedCards limits the rank points to the highest hole cards, so that
+	// games with more hole cards score on the same scale. Zero counts them all.
+	CountedCards int `yaml:"counted_cards"`
+	// PairBonus is added, with the pair's rank, for the highest pair in the
+	// hole cards.
+	PairBonus float64 `yaml:"pair_bonus"`
+	// SuitedBonus is added when two hole cards share a suit, and
+	// DoubleSuitedBonus instead when two pairs of them share two suits.
+	SuitedBonus       float64 `yaml:"suited_bonus"`
+	DoubleSuitedBonus float64 `yaml:"double_suited_bonus"`
+	// ConnectorBonus is added for every two hole card ranks in sequence.
+	ConnectorBonus float64 `yaml:"connector_bonus"`
+}
+
+// PointsFor returns the points scored by a hole card of the given rank.
+func (t StartingHandTable) PointsFor(rank Rank) float64 {
+	key := rank.String()
+	if rank == Ten {
+		key = "T"
+	}
+	return t.RankPoints[key]
+}
+
+// AggressionCurve scales a CPU's aggression on each street after the flop.
+// Zero leaves it unchanged on that street.
+type AggressionCurve struct {
+	Flop  float64 `yaml:"flop"`
+	Turn  float64 `yaml:"turn"`
+	River float64 `yaml:"river"`
+}
+
+// LowHandWeights weighs low hands in High-Low split games. It is ignored in
+// games without a low.
+type LowHandWeights struct {
+	// CardPoints is added before the flop for every hole card rank that can
+	// make a low, the ace included.
+	CardPoints float64 `yaml:"card_points"`
+	// MadeLowBonus is added after the flop to the strength of a player who
+	// has a low. The strength is measured in hand ranks, so 1 makes a high
+	// card with a low play like one pair.
+	MadeLowBonus float64 `yaml:"made_low_bonus"`
+}
+
+// Validate checks that the pack can be used. It returns an error describing
+// the first problem found.
+func (t *AITuning) Validate() error {
+	for key, points := range t.StartingHands.RankPoints {
+		if len(key) != 1 || cardRanks[key[0]] == 0 {
+			return fmt.Errorf("unknown rank %q in the starting hand rank points", key)
+		}
+		if points < 0 {
+			return fmt.Errorf("rank points for %q must not be negative, got %g", key, points)
+		}
+	}
+	if t.StartingHands.CountedCards < 0 {
+		return fmt.Errorf("counted cards must not be negative, got %d", t.StartingHands.CountedCards)
+	}
+	for street, scale := range map[string]float64{"flop": t.Aggression.Flop, "turn": t.Aggression.Turn, "river": t.Aggression.River} {
+		if scale < 0 {
+			return fmt.Errorf("%s aggression must not be negative, got %g", street, scale)
+		}
+	}
+	return nil
+}
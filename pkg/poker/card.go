@@ -6,6 +6,8 @@ package poker
 import (
 	"fmt"
 	"strings"
+
+	"github.com/sirupsen/logrus"
 )
 
 // Suit represents the suit of a playing card (Spade, Heart, Diamond, Club).
@@ -72,33 +74,146 @@ func (c Card) String() string {
 	return fmt.Sprintf("%s%s ", c.Rank.String(), c.Suit.String())
 }
 
+// Notation returns the plain-text notation of a card (e.g., "As", "Td"), using
+// the same format that ParseCard accepts. It is meant for text that is shared
+// outside the terminal, where suit emojis may not render.
+func (c Card) Notation() string {
+	rank := c.Rank.String()
+	if c.Rank == Ten {
+		rank = "T"
+	}
+	return rank + []string{"s", "h", "d", "c"}[c.Suit]
+}
+
+// CardParseError describes a malformed card token encountered by ParseCards.
+// It carries enough position information to point the user at the offending input.
+type CardParseError struct {
+	Token  string // Token is the offending card token as it appeared in the input.
+	Index  int    // Index is the zero-based position of the token among all tokens.
+	Offset int    // Offset is the zero-based byte offset of the token in the input string.
+	Reason string // Reason is a short human-readable explanation of the problem.
+}
+
+// Error implements the error interface.
+func (e *CardParseError) Error() string {
+	return fmt.Sprintf("invalid card %q at token %d (offset %d): %s", e.Token, e.Index+1, e.Offset, e.Reason)
+}
+
+// cardRanks maps the accepted rank characters (upper case) to their Rank.
+var cardRanks = map[byte]Rank{
+	'2': Two, '3': Three, '4': Four, '5': Five, '6': Six, '7': Seven,
+	'8': Eight, '9': Nine, 'T': Ten, 'J': Jack, 'Q': Queen, 'K': King, 'A': Ace,
+}
+
+// cardSuits maps the accepted suit characters (lower case) to their Suit.
+var cardSuits = map[byte]Suit{
+	's': Spade, 'h': Heart, 'd': Diamond, 'c': Club,
+}
+
+// ParseCard parses a single card token such as "As", "td" or "10h".
+// Ranks and suits are case-insensitive, and "10" is accepted as an alias for "T".
+func ParseCard(token string) (Card, error) {
+	card, reason := parseCardToken(token)
+	if reason != "" {
+		return Card{}, &CardParseError{Token: token, Reason: reason}
+	}
+	return card, nil
+}
+
+// ParseCards parses a list of cards separated by whitespace or commas (e.g.,
+// "As 10d tc" or "As,10d, tc"). Any number of separators between tokens is
+// accepted. On malformed input it
+// returns a *CardParseError identifying the first offending token, and it also
+// rejects duplicate cards since a standard deck contains each card only once.
+func ParseCards(s string) ([]Card, error) {
+	cards := make([]Card, 0)
+	seen := make(map[Card]int)
+	index := 0
+	for offset := 0; offset < len(s); {
+		// Skip separators.
+		if isCardSeparator(s[offset]) {
+			offset++
+			continue
+		}
+		end := offset
+		for end < len(s) && !isCardSeparator(s[end]) {
+			end++
+		}
+		token := s[offset:end]
+
+		card, reason := parseCardToken(token)
+		if reason == "" {
+			if first, dup := seen[card]; dup {
+				reason = fmt.Sprintf("duplicate of token %d", first+1)
+			}
+		}
+		if reason != "" {
+			return nil, &CardParseError{Token: token, Index: index, Offset: offset, Reason: reason}
+		}
+		seen[card] = index
+		cards = append(cards, card)
+
+		index++
+		offset = end
+	}
+	return cards, nil
+}
+
+// parseCardToken converts a single token into a Card. It returns a non-empty
+// reason string when the token is malformed.
+func parseCardToken(token string) (Card, string) {
+	if len(token) < 2 {
+		return Card{}, "expected a rank followed by a suit"
+	}
+	rankPart, suitPart := token[:len(token)-1], token[len(token)-1]
+
+	var rank Rank
+	if rankPart == "10" {
+		rank = Ten
+	} else if len(rankPart) == 1 {
+		r, ok := cardRanks[strings.ToUpper(rankPart)[0]]
+		if !ok {
+			return Card{}, fmt.Sprintf("unknown rank %q", rankPart)
+		}
+		rank = r
+	} else {
+		return Card{}, fmt.Sprintf("unknown rank %q", rankPart)
+	}
+
+	suit, ok := cardSuits[strings.ToLower(string(suitPart))[0]]
+	if !ok {
+		return Card{}, fmt.Sprintf("unknown suit %q", string(suitPart))
+	}
+	return Card{Rank: rank, Suit: suit}, ""
+}
+
+// isCardSeparator reports whether b separates card tokens: whitespace or a
+// comma.
+func isCardSeparator(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r' || b == ','
+}
+
 // CardsFromStrings is a utility function for creating a slice of cards from a
 // space-separated string. It is primarily used for testing and setting up
 // specific game scenarios.
 //
-// The string format for each card is a two-character string:
-// The first character represents the rank ('A', 'K', 'Q', 'J', 'T', '9'-'2').
-// The second character represents the suit ('s', 'h', 'd', 'c').
+// The string format for each card is a rank followed by a suit:
+// The rank is one of 'A', 'K', 'Q', 'J', 'T' (or "10"), '9'-'2'.
+// The suit is one of 's', 'h', 'd', 'c'. Both are case-insensitive.
 // Example: "As Kd Tc" creates a slice with the Ace of Spades, King of Diamonds,
 // and Ten of Clubs.
+//
+// Unlike ParseCards, malformed tokens are skipped (with a warning) rather than
+// reported as an error, so callers that need validation should use ParseCards.
 func CardsFromStrings(s string) []Card {
-	if s == "" {
-		return []Card{}
-	}
-	parts := strings.Split(s, " ")
-
-	cards := make([]Card, len(parts))
-	rankMap := map[rune]Rank{
-		'2': Two, '3': Three, '4': Four, '5': Five, '6': Six, '7': Seven,
-		'8': Eight, '9': Nine, 'T': Ten, 'J': Jack, 'Q': Queen, 'K': King, 'A': Ace,
-	}
-	suitMap := map[rune]Suit{
-		's': Spade, 'h': Heart, 'd': Diamond, 'c': Club,
-	}
-	for i, part := range parts {
-		rank := rankMap[rune(part[0])]
-		suit := suitMap[rune(part[1])]
-		cards[i] = Card{Rank: rank, Suit: suit}
+	cards := make([]Card, 0)
+	for _, token := range strings.Fields(s) {
+		card, reason := parseCardToken(token)
+		if reason != "" {
+			logrus.Warnf("CardsFromStrings: skipping invalid card %q: %s", token, reason)
+			continue
+		}
+		cards = append(cards, card)
 	}
 	return cards
 }
@@ -0,0 +1,87 @@
+package poker
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseCards(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []Card
+	}{
+		{name: "Standard notation", input: "As Kd Tc", expected: []Card{{Spade, Ace}, {Diamond, King}, {Club, Ten}}},
+		{name: "Ten as 10", input: "10s 10h", expected: []Card{{Spade, Ten}, {Heart, Ten}}},
+		{name: "Lowercase ranks and uppercase suits", input: "as kD qh", expected: []Card{{Spade, Ace}, {Diamond, King}, {Heart, Queen}}},
+		{name: "Extra whitespace", input: "  2c   3d\t4h ", expected: []Card{{Club, Two}, {Diamond, Three}, {Heart, Four}}},
+		{name: "Commas", input: "As,Kd, Tc ,2c", expected: []Card{{Spade, Ace}, {Diamond, King}, {Club, Ten}, {Club, Two}}},
+		{name: "Empty input", input: "", expected: []Card{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cards, err := ParseCards(tc.input)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if !reflect.DeepEqual(cards, tc.expected) {
+				t.Errorf("Expected %v, but got %v", tc.expected, cards)
+			}
+		})
+	}
+}
+
+func TestParseCards_Errors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedToken  string
+		expectedIndex  int
+		expectedOffset int
+	}{
+		{name: "Unknown rank", input: "As Xd", expectedToken: "Xd", expectedIndex: 1, expectedOffset: 3},
+		{name: "Unknown suit", input: "As  Kx", expectedToken: "Kx", expectedIndex: 1, expectedOffset: 4},
+		{name: "Too short", input: "A", expectedToken: "A", expectedIndex: 0, expectedOffset: 0},
+		{name: "Invalid two-digit rank", input: "11s", expectedToken: "11s", expectedIndex: 0, expectedOffset: 0},
+		{name: "Duplicate card", input: "As Kd as", expectedToken: "as", expectedIndex: 2, expectedOffset: 6},
+		{name: "After a comma", input: "As,Kx", expectedToken: "Kx", expectedIndex: 1, expectedOffset: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseCards(tc.input)
+			var parseErr *CardParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Expected a *CardParseError, but got: %v", err)
+			}
+			if parseErr.Token != tc.expectedToken || parseErr.Index != tc.expectedIndex || parseErr.Offset != tc.expectedOffset {
+				t.Errorf(
+					"Expected token %q at index %d (offset %d), but got %q at index %d (offset %d)",
+					tc.expectedToken, tc.expectedIndex, tc.expectedOffset, parseErr.Token, parseErr.Index, parseErr.Offset,
+				)
+			}
+		})
+	}
+}
+
+func TestCardsFromStrings_SkipsInvalidTokens(t *testing.T) {
+	cards := CardsFromStrings("As  10d Zz kc")
+	expected := []Card{{Spade, Ace}, {Diamond, Ten}, {Club, King}}
+	if !reflect.DeepEqual(cards, expected) {
+		t.Errorf("Expected %v, but got %v", expected, cards)
+	}
+}
+
+func TestCard_Notation(t *testing.T) {
+	for _, token := range []string{"As", "Td", "2c", "Qh"} {
+		card, err := ParseCard(token)
+		if err != nil {
+			t.Fatalf("ParseCard(%q) failed: %v", token, err)
+		}
+		if got := card.Notation(); got != token {
+			t.Errorf("Notation() = %q, want %q", got, token)
+		}
+	}
+}
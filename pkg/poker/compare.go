@@ -0,0 +1,53 @@
+package poker
+
+import "cmp"
+
+// CompareHigh compares two high hands, as evaluated by EvaluateHand. It returns
+// 1 if a beats b, -1 if b beats a, and 0 if they tie. Hands are ordered by
+// rank, then by their HighValues, in order; suits never break a tie. A nil
+// hand, i.e. no hand at all, loses to any hand and ties with another nil.
+func CompareHigh(a, b *HandResult) int {
+	if c, ok := compareNil(a, b); ok {
+		return c
+	}
+	if a.Rank != b.Rank {
+		return cmp.Compare(a.Rank, b.Rank)
+	}
+	for i := 0; i < min(len(a.HighValues), len(b.HighValues)); i++ {
+		if c := cmp.Compare(a.HighValues[i], b.HighValues[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// CompareLow compares two qualifying low hands, as evaluated by EvaluateHand.
+// It returns 1 if a is the better low, -1 if b is, and 0 if they tie. The
+// better low is the lower one: hands are compared card by card from their
+// highest, with the ace low, so 6-5-4-3-A beats 6-5-4-3-2. As with
+// CompareHigh, a nil hand, i.e. no qualifying low, loses to any low.
+func CompareLow(a, b *HandResult) int {
+	if c, ok := compareNil(a, b); ok {
+		return c
+	}
+	for i := 0; i < min(len(a.HighValues), len(b.HighValues)); i++ {
+		if c := cmp.Compare(getLowRankValue(b.HighValues[i]), getLowRankValue(a.HighValues[i])); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareNil orders nil hands below every other hand. It reports false if
+// neither hand is nil.
+func compareNil(a, b *HandResult) (int, bool) {
+	switch {
+	case a == nil && b == nil:
+		return 0, true
+	case a == nil:
+		return -1, true
+	case b == nil:
+		return 1, true
+	}
+	return 0, false
+}
@@ -0,0 +1,71 @@
+package poker
+
+import "testing"
+
+func TestCompareHigh(t *testing.T) {
+	rules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 2, UseConstraint: "any"},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	hand := func(hole, board string) *HandResult {
+		high, _ := EvaluateHand(CardsFromStrings(hole), CardsFromStrings(board), rules)
+		return high
+	}
+	board := "Kc 8d 4s 3h 2c"
+
+	testCases := []struct {
+		name     string
+		a, b     *HandResult
+		expected int
+	}{
+		{"a pair beats a high card", hand("Kd 9c", board), hand("Ac Qd", board), 1},
+		{"the kicker breaks a tie", hand("Kd 9c", board), hand("Ks Jc", board), -1},
+		{"suits never break a tie", hand("Kd 9c", board), hand("Kh 9s", board), 0},
+		{"any hand beats no hand", hand("Kd 9c", board), nil, 1},
+		{"no hand ties with no hand", nil, nil, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CompareHigh(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+			if got := CompareHigh(tc.b, tc.a); got != -tc.expected {
+				t.Errorf("expected %d with the hands swapped, got %d", -tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompareLow(t *testing.T) {
+	rules := &GameRules{
+		HoleCards: HoleCardRules{Count: 3, UseConstraint: "any"},
+		LowHand:   LowHandRules{Enabled: true, MaxRank: 7},
+	}
+	low := func(hole, board string) *HandResult {
+		_, low := EvaluateHand(CardsFromStrings(hole), CardsFromStrings(board), rules)
+		return low
+	}
+	board := "6c 5d 4s 3h Kc"
+
+	testCases := []struct {
+		name     string
+		a, b     *HandResult
+		expected int
+	}{
+		{"the lower top card wins", low("2d Qs Qh", board), low("7d Qs Qh", board), 1},
+		{"the ace is low", low("Ad Qs Qh", board), low("2d Qs Qh", board), 1},
+		{"suits never break a tie", low("Ad Qs Qh", board), low("As Qs Qh", board), 0},
+		{"any low beats no low", low("7d Qs Qh", board), nil, 1},
+		{"no low ties with no low", nil, nil, 0},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CompareLow(tc.a, tc.b); got != tc.expected {
+				t.Errorf("expected %d, got %d", tc.expected, got)
+			}
+			if got := CompareLow(tc.b, tc.a); got != -tc.expected {
+				t.Errorf("expected %d with the hands swapped, got %d", -tc.expected, got)
+			}
+		})
+	}
+}
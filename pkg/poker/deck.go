@@ -5,10 +5,13 @@ import (
 	"math/rand"
 )
 
-// Deck represents a collection of playing cards.
+// Deck represents a collection of playing cards. It keeps track of the cards
+// it has dealt, so that no card can be dealt twice.
 type Deck struct {
-	// Cards is a slice holding the cards in the deck.
-	Cards []Card
+	// cards holds the cards left in the deck. The top card is the last one.
+	cards []Card
+	// dealt holds the cards that have left the deck.
+	dealt map[Card]bool
 }
 
 // NewDeck creates a new, unshuffled, standard 52-card deck.
@@ -21,7 +24,27 @@ func NewDeck() *Deck {
 			cards = append(cards, Card{Suit: suit, Rank: rank})
 		}
 	}
-	return &Deck{Cards: cards}
+	return &Deck{cards: cards, dealt: make(map[Card]bool)}
+}
+
+// NewStackedDeck creates a deck that deals exactly the given cards, in the
+// given order, e.g. to replay a hand dealt from a deck returned by
+// PeekForDebug. It returns an error if a card is listed twice or invalid.
+func NewStackedDeck(cards []Card) (*Deck, error) {
+	d := &Deck{cards: make([]Card, 0, len(cards)), dealt: make(map[Card]bool)}
+	seen := make(map[Card]bool, len(cards))
+	for i := len(cards) - 1; i >= 0; i-- {
+		card := cards[i]
+		if card.Suit < Spade || card.Suit > Club || card.Rank < Two || card.Rank > Ace {
+			return nil, fmt.Errorf("card %v is not a valid card", card)
+		}
+		if seen[card] {
+			return nil, fmt.Errorf("card %s is listed twice", card)
+		}
+		seen[card] = true
+		d.cards = append(d.cards, card)
+	}
+	return d, nil
 }
 
 // Shuffle randomizes the order of the cards in the deck.
@@ -29,33 +52,87 @@ func NewDeck() *Deck {
 // testing purposes. For production use, a cryptographically secure random
 // source should be used.
 func (d *Deck) Shuffle(r *rand.Rand) {
-	r.Shuffle(len(d.Cards), func(i, j int) {
-		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+	r.Shuffle(len(d.cards), func(i, j int) {
+		d.cards[i], d.cards[j] = d.cards[j], d.cards[i]
 	})
 }
 
 // Deal removes and returns the top card from the deck (the last card in the slice).
 // It returns an error if the deck is empty.
 func (d *Deck) Deal() (Card, error) {
-	if len(d.Cards) == 0 {
+	if len(d.cards) == 0 {
 		return Card{}, fmt.Errorf("deck is empty")
 	}
-	card := d.Cards[len(d.Cards)-1]
-	d.Cards = d.Cards[:len(d.Cards)-1]
+	card := d.cards[len(d.cards)-1]
+	d.cards = d.cards[:len(d.cards)-1]
+	d.dealt[card] = true
 	return card, nil
 }
 
 // DealForDebug removes and returns a specific card from the deck.
 // This function is intended for testing and debugging purposes to control the
 // game state by dealing known cards. It searches for the card in the deck,
-// removes it if found, and returns it. If the card is not found, it returns
-// an error.
+// removes it if found, and returns it. If the card has already been dealt, or
+// is not found, it returns an error and leaves the deck unchanged.
 func (d *Deck) DealForDebug(card Card) (Card, error) {
-	for i, c := range d.Cards {
+	if err := d.RemoveCards([]Card{card}); err != nil {
+		return Card{}, err
+	}
+	return card, nil
+}
+
+// RemainingCount returns the number of cards left in the deck.
+func (d *Deck) RemainingCount() int {
+	return len(d.cards)
+}
+
+// Contains reports whether the card is still in the deck.
+func (d *Deck) Contains(card Card) bool {
+	return d.indexOf(card) >= 0
+}
+
+// RemoveCards removes the given cards from the deck, e.g. to set up a
+// scenario with known cards. Either every card is removed or, if any card has
+// already been dealt, is listed twice, or is not in the deck, none is and an
+// error names the first such card.
+func (d *Deck) RemoveCards(cards []Card) error {
+	seen := make(map[Card]bool, len(cards))
+	for _, card := range cards {
+		switch {
+		case d.dealt[card]:
+			return fmt.Errorf("card %s has already been dealt", card)
+		case seen[card]:
+			return fmt.Errorf("card %s is listed twice", card)
+		case !d.Contains(card):
+			return fmt.Errorf("card %s not found in deck", card)
+		}
+		seen[card] = true
+	}
+	for _, card := range cards {
+		i := d.indexOf(card)
+		d.cards = append(d.cards[:i], d.cards[i+1:]...)
+		d.dealt[card] = true
+	}
+	return nil
+}
+
+// PeekForDebug returns the next n cards to be dealt, in dealing order, without
+// removing them. It returns fewer cards if the deck runs out.
+func (d *Deck) PeekForDebug(n int) []Card {
+	n = min(n, len(d.cards))
+	peeked := make([]Card, n)
+	for i := range peeked {
+		peeked[i] = d.cards[len(d.cards)-1-i]
+	}
+	return peeked
+}
+
+// indexOf returns the position of the card in the deck, or -1 if it is not there.
+func (d *Deck) indexOf(card Card) int {
+	for i, c := range d.cards {
 		if c == card {
-			d.Cards = append(d.Cards[:i], d.Cards[i+1:]...)
-			return c, nil
+			return i
 		}
 	}
-	return Card{}, fmt.Errorf("card %s not found in deck", card)
+	return -1
 }
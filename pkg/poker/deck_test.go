@@ -17,11 +17,11 @@ func TestDeck_DealForDebug(t *testing.T) {
 	deck := NewDeck()
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	deck.Shuffle(r)
-	if len(deck.Cards) < 52 {
-		t.Fatalf("Expected deck to have 52 cards, but got %d", len(deck.Cards))
+	if deck.RemainingCount() < 52 {
+		t.Fatalf("Expected deck to have 52 cards, but got %d", deck.RemainingCount())
 	}
 	for _, card := range wantedCards {
-		expectedDeckSize := len(deck.Cards) - 1
+		expectedDeckSize := deck.RemainingCount() - 1
 		dealtCard, err := deck.DealForDebug(card)
 		if err != nil {
 			t.Errorf("Failed to deal card %s: %v", card, err)
@@ -29,11 +29,105 @@ func TestDeck_DealForDebug(t *testing.T) {
 		if dealtCard != card {
 			t.Errorf("Expected dealt card to be %s, but got %s", card, dealtCard)
 		}
-		if len(deck.Cards) != expectedDeckSize {
+		if deck.RemainingCount() != expectedDeckSize {
 			t.Errorf(
 				"Expected deck size to be %d after dealing %s, but got %d",
-				expectedDeckSize, card, len(deck.Cards),
+				expectedDeckSize, card, deck.RemainingCount(),
 			)
 		}
 	}
 }
+
+// TestDeck_DealForDebugRejectsDealtCard tests that a card cannot be dealt twice.
+func TestDeck_DealForDebugRejectsDealtCard(t *testing.T) {
+	deck := NewDeck()
+	card := Card{Suit: Heart, Rank: Seven}
+	if _, err := deck.DealForDebug(card); err != nil {
+		t.Fatalf("Failed to deal card %s: %v", card, err)
+	}
+	if _, err := deck.DealForDebug(card); err == nil {
+		t.Errorf("Expected an error when dealing %s twice", card)
+	}
+	if deck.RemainingCount() != 51 {
+		t.Errorf("Expected 51 cards to remain, but got %d", deck.RemainingCount())
+	}
+
+	top, err := deck.Deal()
+	if err != nil {
+		t.Fatalf("Failed to deal: %v", err)
+	}
+	if _, err := deck.DealForDebug(top); err == nil {
+		t.Errorf("Expected an error when dealing %s again for debugging", top)
+	}
+}
+
+// TestDeck_ContainsAndRemainingCount tests the inspection methods.
+func TestDeck_ContainsAndRemainingCount(t *testing.T) {
+	deck := NewDeck()
+	if deck.RemainingCount() != 52 {
+		t.Fatalf("Expected 52 cards, but got %d", deck.RemainingCount())
+	}
+	card := Card{Suit: Club, Rank: Two}
+	if !deck.Contains(card) {
+		t.Errorf("Expected a new deck to contain %s", card)
+	}
+	deck.DealForDebug(card)
+	if deck.Contains(card) {
+		t.Errorf("Expected %s to have left the deck", card)
+	}
+	if deck.RemainingCount() != 51 {
+		t.Errorf("Expected 51 cards, but got %d", deck.RemainingCount())
+	}
+}
+
+// TestDeck_RemoveCards tests that RemoveCards removes every card or none.
+func TestDeck_RemoveCards(t *testing.T) {
+	aceOfSpades := Card{Suit: Spade, Rank: Ace}
+	kingOfSpades := Card{Suit: Spade, Rank: King}
+
+	deck := NewDeck()
+	if err := deck.RemoveCards([]Card{aceOfSpades, kingOfSpades}); err != nil {
+		t.Fatalf("Failed to remove cards: %v", err)
+	}
+	if deck.RemainingCount() != 50 || deck.Contains(aceOfSpades) || deck.Contains(kingOfSpades) {
+		t.Errorf("Expected both cards to leave the deck, but %d cards remain", deck.RemainingCount())
+	}
+
+	tests := []struct {
+		name  string
+		cards []Card
+	}{
+		{"already dealt", []Card{{Suit: Heart, Rank: Two}, aceOfSpades}},
+		{"listed twice", []Card{{Suit: Heart, Rank: Three}, {Suit: Heart, Rank: Three}}},
+		{"not in deck", []Card{{Suit: Heart, Rank: Four}, {}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := deck.RemoveCards(tt.cards); err == nil {
+				t.Errorf("Expected an error removing %v", tt.cards)
+			}
+			if deck.RemainingCount() != 50 || !deck.Contains(tt.cards[0]) {
+				t.Errorf("Expected the deck to be unchanged, but %d cards remain", deck.RemainingCount())
+			}
+		})
+	}
+}
+
+// TestDeck_PeekForDebug tests that peeking shows the cards in dealing order.
+func TestDeck_PeekForDebug(t *testing.T) {
+	deck := NewDeck()
+	deck.Shuffle(rand.New(rand.NewSource(1)))
+	peeked := deck.PeekForDebug(3)
+	if len(peeked) != 3 || deck.RemainingCount() != 52 {
+		t.Fatalf("Expected to peek 3 cards without dealing, got %d with %d left", len(peeked), deck.RemainingCount())
+	}
+	for i, want := range peeked {
+		got, _ := deck.Deal()
+		if got != want {
+			t.Errorf("Card %d: peeked %s, but dealt %s", i+1, want, got)
+		}
+	}
+	if n := len(deck.PeekForDebug(100)); n != 49 {
+		t.Errorf("Expected peeking past the end to return 49 cards, got %d", n)
+	}
+}
@@ -0,0 +1,270 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// maxExactRunouts is the largest number of board runouts CalculateTierEquity
+// enumerates exactly. Beyond this (typically before the flop) it samples
+// sampledRunouts random runouts instead.
+const (
+	maxExactRunouts = 20000
+	sampledRunouts  = 1000
+)
+
+// cancelCheckInterval is the number of runouts or deals a long computation
+// evaluates between checks for the cancellation of its context.
+const cancelCheckInterval = 64
+
+// TierEquityResult holds each player's equity in each pot tier.
+type TierEquityResult struct {
+	// Equities[t][p] is player p's expected share of tier t, between 0 and 1.
+	// Players who are not eligible for a tier always have 0.
+	Equities [][]float64
+	// Runouts is the number of board runouts evaluated.
+	Runouts int
+	// Exact is true if every possible runout was evaluated, and false if the
+	// equities were estimated from a random sample.
+	Exact bool
+}
+
+// CalculateTierEquity computes every player's equity in each pot tier (main
+// pot and side pots) by running out the rest of the board. tiers lists, for
+// each tier, the indices into hands of the players eligible to win it. In
+// hi-lo games a tier is split between the best high and the best qualifying
+// low hand, and ties split each half evenly, just as at a real showdown.
+//
+// Runouts are enumerated exactly when there are few enough of them; otherwise
+// they are sampled using r. If ctx is canceled first, the calculation stops
+// and returns ctx's error.
+func CalculateTierEquity(ctx context.Context, hands [][]Card, board []Card, tiers [][]int, rules *GameRules, r *rand.Rand) (*TierEquityResult, error) {
+	if len(board) > 5 {
+		return nil, fmt.Errorf("board has %d cards, expected at most 5", len(board))
+	}
+
+	seen := make(map[Card]bool)
+	for _, c := range board {
+		seen[c] = true
+	}
+	for _, hand := range hands {
+		for _, c := range hand {
+			if seen[c] {
+				return nil, fmt.Errorf("card %s appears more than once", c.Notation())
+			}
+			seen[c] = true
+		}
+	}
+	for _, tier := range tiers {
+		for _, p := range tier {
+			if p < 0 || p >= len(hands) {
+				return nil, fmt.Errorf("tier player index %d is out of range", p)
+			}
+		}
+	}
+
+	var remaining []Card
+	for _, c := range NewDeck().cards {
+		if !seen[c] {
+			remaining = append(remaining, c)
+		}
+	}
+
+	result := &TierEquityResult{Equities: make([][]float64, len(tiers))}
+	for t := range tiers {
+		result.Equities[t] = make([]float64, len(hands))
+	}
+
+	needed := 5 - len(board)
+	runout := func(extra []Card) {
+		fullBoard := append(append(make([]Card, 0, 5), board...), extra...)
+		highs := make([]*HandResult, len(hands))
+		lows := make([]*HandResult, len(hands))
+		for p, hand := range hands {
+			highs[p], lows[p] = EvaluateHand(hand, fullBoard, rules)
+		}
+		for t, eligible := range tiers {
+			for p, share := range showdownShares(eligible, highs, lows, rules) {
+				result.Equities[t][p] += share
+			}
+		}
+		result.Runouts++
+	}
+
+	if countCombinations(len(remaining), needed) <= maxExactRunouts {
+		result.Exact = true
+		for i, extra := range combinations(remaining, needed) {
+			if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			runout(extra)
+		}
+	} else {
+		for i := 0; i < sampledRunouts; i++ {
+			if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			r.Shuffle(len(remaining), func(a, b int) { remaining[a], remaining[b] = remaining[b], remaining[a] })
+			runout(remaining[:needed])
+		}
+	}
+
+	for t := range result.Equities {
+		for p := range result.Equities[t] {
+			result.Equities[t][p] /= float64(result.Runouts)
+		}
+	}
+	return result, nil
+}
+
+// EstimateEquity estimates a hand's expected share of the pot against a number
+// of opponents holding random hands, by sampling the opponents' hole cards and
+// the rest of the board. Opponents are dealt as many hole cards as the rules
+// call for. Ties and split pots count as fractions of the pot. If ctx is
+// canceled first, the estimate stops and returns ctx's error.
+func EstimateEquity(ctx context.Context, hand, board []Card, opponents, samples int, rules *GameRules, r *rand.Rand) (float64, error) {
+	if len(board) > 5 {
+		return 0, fmt.Errorf("board has %d cards, expected at most 5", len(board))
+	}
+	if opponents < 1 || samples < 1 {
+		return 0, fmt.Errorf("need at least one opponent and one sample, got %d and %d", opponents, samples)
+	}
+
+	deck := NewDeck()
+	if err := deck.RemoveCards(append(append([]Card(nil), hand...), board...)); err != nil {
+		return 0, err
+	}
+	remaining := deck.cards
+	holeCount := rules.HoleCards.Count
+	needed := 5 - len(board)
+	if opponents*holeCount+needed > len(remaining) {
+		return 0, fmt.Errorf("not enough cards to deal %d opponents", opponents)
+	}
+
+	everyone := make([]int, opponents+1)
+	for i := range everyone {
+		everyone[i] = i
+	}
+	highs := make([]*HandResult, opponents+1)
+	lows := make([]*HandResult, opponents+1)
+	fullBoard := make([]Card, 0, 5)
+	total := 0.0
+	for i := 0; i < samples; i++ {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		r.Shuffle(len(remaining), func(a, b int) { remaining[a], remaining[b] = remaining[b], remaining[a] })
+		fullBoard = append(append(fullBoard[:0], board...), remaining[:needed]...)
+		highs[0], lows[0] = EvaluateHand(hand, fullBoard, rules)
+		for o := 1; o <= opponents; o++ {
+			start := needed + (o-1)*holeCount
+			highs[o], lows[o] = EvaluateHand(remaining[start:start+holeCount], fullBoard, rules)
+		}
+		total += showdownShares(everyone, highs, lows, rules)[0]
+	}
+	return total / float64(samples), nil
+}
+
+// maxRangeDeals is the number of hands EstimateRangeEquity deals for each
+// sample before it gives up on a range that holds too few of them.
+const maxRangeDeals = 1000
+
+// EstimateRangeEquity estimates a hand's expected share of the pot against one
+// opponent whose hole cards come from a range, by dealing the opponent random
+// hands until inRange accepts one, then the rest of the board. It also returns
+// the range's share of all the hands dealt, which tells how wide the range is.
+// If ctx is canceled first, the estimate stops and returns ctx's error.
+func EstimateRangeEquity(ctx context.Context, hand, board []Card, inRange func([]Card) bool, samples int, rules *GameRules, r *rand.Rand) (equity, share float64, err error) {
+	if len(board) > 5 {
+		return 0, 0, fmt.Errorf("board has %d cards, expected at most 5", len(board))
+	}
+	if samples < 1 {
+		return 0, 0, fmt.Errorf("need at least one sample, got %d", samples)
+	}
+
+	deck := NewDeck()
+	if err := deck.RemoveCards(append(append([]Card(nil), hand...), board...)); err != nil {
+		return 0, 0, err
+	}
+	remaining := deck.cards
+	holeCount := rules.HoleCards.Count
+	needed := 5 - len(board)
+	if holeCount+needed > len(remaining) {
+		return 0, 0, fmt.Errorf("not enough cards to deal an opponent")
+	}
+
+	highs := make([]*HandResult, 2)
+	lows := make([]*HandResult, 2)
+	fullBoard := make([]Card, 0, 5)
+	total := 0.0
+	dealt := 0
+	for i := 0; i < samples; i++ {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+		for deals := 0; ; deals++ {
+			if deals == maxRangeDeals {
+				return 0, 0, fmt.Errorf("no hand in the range after %d deals", maxRangeDeals)
+			}
+			r.Shuffle(len(remaining), func(a, b int) { remaining[a], remaining[b] = remaining[b], remaining[a] })
+			dealt++
+			if inRange(remaining[:holeCount]) {
+				break
+			}
+		}
+		fullBoard = append(append(fullBoard[:0], board...), remaining[holeCount:holeCount+needed]...)
+		highs[0], lows[0] = EvaluateHand(hand, fullBoard, rules)
+		highs[1], lows[1] = EvaluateHand(remaining[:holeCount], fullBoard, rules)
+		total += showdownShares([]int{0, 1}, highs, lows, rules)[0]
+	}
+	return total / float64(samples), float64(samples) / float64(dealt), nil
+}
+
+// showdownShares returns the fraction of a pot won by each eligible player for
+// one complete board.
+func showdownShares(eligible []int, highs, lows []*HandResult, rules *GameRules) map[int]float64 {
+	var highWinners, lowWinners []int
+	var bestHigh, bestLow *HandResult
+	for _, p := range eligible {
+		if high := highs[p]; high != nil {
+			if bestHigh == nil || CompareHigh(high, bestHigh) > 0 {
+				bestHigh, highWinners = high, []int{p}
+			} else if CompareHigh(high, bestHigh) == 0 {
+				highWinners = append(highWinners, p)
+			}
+		}
+		if low := lows[p]; low != nil {
+			if bestLow == nil || CompareLow(low, bestLow) > 0 {
+				bestLow, lowWinners = low, []int{p}
+			} else if CompareLow(low, bestLow) == 0 {
+				lowWinners = append(lowWinners, p)
+			}
+		}
+	}
+
+	shares := make(map[int]float64)
+	highPortion := 1.0
+	if rules.LowHand.Enabled && len(lowWinners) > 0 {
+		highPortion = 0.5
+		for _, p := range lowWinners {
+			shares[p] += 0.5 / float64(len(lowWinners))
+		}
+	}
+	for _, p := range highWinners {
+		shares[p] += highPortion / float64(len(highWinners))
+	}
+	return shares
+}
+
+// countCombinations returns n choose k.
+func countCombinations(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
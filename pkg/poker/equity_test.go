@@ -0,0 +1,176 @@
+package poker
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var holdemRules = &GameRules{
+	HoleCards:    HoleCardRules{Count: 2, UseConstraint: "any"},
+	HandRankings: HandRankingsRules{UseStandardRankings: true},
+}
+
+func TestCalculateTierEquity_CompleteBoard(t *testing.T) {
+	hands := [][]Card{
+		CardsFromStrings("As Ah"), // Set of aces: best hand, but only in the main pot.
+		CardsFromStrings("Ks Kh"), // Set of kings.
+		CardsFromStrings("Qs Jh"),
+	}
+	board := CardsFromStrings("Ad Kd 7c 4s 2h")
+	tiers := [][]int{{0, 1, 2}, {1, 2}}
+
+	result, err := CalculateTierEquity(context.Background(), hands, board, tiers, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Exact || result.Runouts != 1 {
+		t.Errorf("expected a single exact runout, got %d (exact: %v)", result.Runouts, result.Exact)
+	}
+	if result.Equities[0][0] != 1 {
+		t.Errorf("expected aces to win the main pot, got %v", result.Equities[0])
+	}
+	if result.Equities[1][1] != 1 || result.Equities[1][0] != 0 {
+		t.Errorf("expected kings to win the side pot, got %v", result.Equities[1])
+	}
+}
+
+func TestCalculateTierEquity_SumsToOnePerTier(t *testing.T) {
+	hands := [][]Card{
+		CardsFromStrings("As Ah"),
+		CardsFromStrings("Ks Kh"),
+		CardsFromStrings("9c 8c"),
+	}
+	board := CardsFromStrings("7c 6d 2c")
+	tiers := [][]int{{0, 1, 2}, {1, 2}}
+
+	result, err := CalculateTierEquity(context.Background(), hands, board, tiers, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Exact || result.Runouts != 903 { // 43 choose 2
+		t.Errorf("expected 903 exact runouts, got %d (exact: %v)", result.Runouts, result.Exact)
+	}
+	for i, equities := range result.Equities {
+		sum := 0.0
+		for _, e := range equities {
+			sum += e
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("tier %d equities sum to %f, expected 1", i, sum)
+		}
+	}
+	if result.Equities[1][0] != 0 {
+		t.Errorf("expected no side pot equity for an ineligible player, got %f", result.Equities[1][0])
+	}
+	if result.Equities[1][2] <= result.Equities[0][2] {
+		t.Errorf("expected the draw to do better against one opponent than two: %f vs %f", result.Equities[1][2], result.Equities[0][2])
+	}
+}
+
+func TestCalculateTierEquity_SamplesPreFlop(t *testing.T) {
+	hands := [][]Card{CardsFromStrings("As Ah"), CardsFromStrings("7d 2c")}
+	result, err := CalculateTierEquity(context.Background(), hands, nil, [][]int{{0, 1}}, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Exact || result.Runouts != sampledRunouts {
+		t.Errorf("expected %d sampled runouts, got %d (exact: %v)", sampledRunouts, result.Runouts, result.Exact)
+	}
+	if result.Equities[0][0] < 0.8 {
+		t.Errorf("expected aces to be a big favorite, got %f", result.Equities[0][0])
+	}
+}
+
+func TestCalculateTierEquity_RejectsDuplicateCards(t *testing.T) {
+	hands := [][]Card{CardsFromStrings("As Ah"), CardsFromStrings("As Kd")}
+	if _, err := CalculateTierEquity(context.Background(), hands, nil, [][]int{{0, 1}}, holdemRules, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("expected an error for a duplicated card")
+	}
+}
+
+func TestEstimateEquity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	aces := CardsFromStrings("As Ah")
+
+	headsUp, err := EstimateEquity(context.Background(), aces, nil, 1, 2000, holdemRules, r)
+	if err != nil {
+		t.Fatalf("Failed to estimate equity: %v", err)
+	}
+	// Pocket aces win about 85% of the time against a random hand.
+	if math.Abs(headsUp-0.85) > 0.03 {
+		t.Errorf("Expected about 0.85 equity heads-up, got %.3f", headsUp)
+	}
+
+	multiway, err := EstimateEquity(context.Background(), aces, nil, 4, 2000, holdemRules, r)
+	if err != nil {
+		t.Fatalf("Failed to estimate equity: %v", err)
+	}
+	if multiway >= headsUp {
+		t.Errorf("Expected less equity against four opponents (%.3f) than one (%.3f)", multiway, headsUp)
+	}
+
+	// The nuts on a complete board can only tie.
+	nuts, err := EstimateEquity(context.Background(), aces, CardsFromStrings("Ad Ac Kh 7d 2c"), 2, 200, holdemRules, r)
+	if err != nil {
+		t.Fatalf("Failed to estimate equity: %v", err)
+	}
+	if nuts != 1 {
+		t.Errorf("Expected quad aces to win every time, got %.3f", nuts)
+	}
+}
+
+func TestEstimateEquity_RejectsInvalidInput(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	if _, err := EstimateEquity(context.Background(), CardsFromStrings("As Ah"), CardsFromStrings("As"), 1, 10, holdemRules, r); err == nil {
+		t.Errorf("Expected an error for a card in both the hand and the board")
+	}
+	if _, err := EstimateEquity(context.Background(), CardsFromStrings("As Ah"), nil, 0, 10, holdemRules, r); err == nil {
+		t.Errorf("Expected an error without opponents")
+	}
+	if _, err := EstimateEquity(context.Background(), CardsFromStrings("As Ah"), nil, 30, 10, holdemRules, r); err == nil {
+		t.Errorf("Expected an error when the deck cannot deal every opponent")
+	}
+}
+
+func TestEstimateRangeEquity(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	kings := CardsFromStrings("Ks Kh")
+	pairs := func(hand []Card) bool { return hand[0].Rank == hand[1].Rank }
+
+	equity, share, err := EstimateRangeEquity(context.Background(), kings, nil, pairs, 1000, holdemRules, r)
+	if err != nil {
+		t.Fatalf("Failed to estimate equity: %v", err)
+	}
+	// Kings are ahead of every pair but aces, and 73 of the 1,225 hands
+	// left are pairs.
+	if equity < 0.7 || equity > 0.85 {
+		t.Errorf("Expected kings to be a big favorite against a pair, got %.3f", equity)
+	}
+	if math.Abs(share-73.0/1225) > 0.02 {
+		t.Errorf("Expected pairs to be about %.3f of the hands, got %.3f", 73.0/1225, share)
+	}
+
+	none := func([]Card) bool { return false }
+	if _, _, err := EstimateRangeEquity(context.Background(), kings, nil, none, 10, holdemRules, r); err == nil {
+		t.Error("Expected an error for an empty range")
+	}
+}
+
+func TestEquity_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	hands := [][]Card{CardsFromStrings("As Ah"), CardsFromStrings("Ks Kh")}
+
+	if _, err := CalculateTierEquity(ctx, hands, CardsFromStrings("2c 7d 9h"), [][]int{{0, 1}}, holdemRules, rand.New(rand.NewSource(1))); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the exact calculation to be canceled, got %v", err)
+	}
+	if _, err := CalculateTierEquity(ctx, hands, nil, [][]int{{0, 1}}, holdemRules, rand.New(rand.NewSource(1))); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the sampled calculation to be canceled, got %v", err)
+	}
+	if _, err := EstimateEquity(ctx, hands[0], nil, 2, 1000, holdemRules, rand.New(rand.NewSource(1))); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the estimate to be canceled, got %v", err)
+	}
+}
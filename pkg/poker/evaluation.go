@@ -91,6 +91,30 @@ type HandResult struct {
 	Rank       HandRank // The rank of the hand (e.g., Flush, Straight).
 	Cards      []Card   // The best 5 cards that form this hand.
 	HighValues []Rank   // A sorted slice of ranks used for tie-breaking. For a pair, this would be [PairRank, Kicker1, Kicker2, Kicker3]. For a flush, it's the ranks of the 5 flush cards.
+	HoleCards  []Card   // The subset of Cards that came from the player's hole cards. Set by EvaluateHand.
+	BoardCards []Card   // The subset of Cards that came from the community cards. Set by EvaluateHand.
+}
+
+// setCardProvenance splits the hand's cards into those taken from the player's
+// hole cards and those taken from the board. Since every card in a deck is unique,
+// membership in the hole cards is enough to determine where a card came from.
+func (hr *HandResult) setCardProvenance(holeCards []Card) {
+	if hr == nil {
+		return
+	}
+	isHoleCard := make(map[Card]bool, len(holeCards))
+	for _, c := range holeCards {
+		isHoleCard[c] = true
+	}
+	hr.HoleCards = make([]Card, 0, len(hr.Cards))
+	hr.BoardCards = make([]Card, 0, len(hr.Cards))
+	for _, c := range hr.Cards {
+		if isHoleCard[c] {
+			hr.HoleCards = append(hr.HoleCards, c)
+		} else {
+			hr.BoardCards = append(hr.BoardCards, c)
+		}
+	}
 }
 
 // String returns a detailed string representation of the HandResult,
@@ -186,6 +210,8 @@ func getHandIterator(rules *GameRules) HandIterator {
 	switch rules.HoleCards.UseConstraint {
 	case "exact":
 		return &ExactCombinationGenerator{}
+	case "max":
+		return &MaxCombinationGenerator{}
 	default:
 		// Default to "any" for safety and backward compatibility.
 		if rules.HoleCards.UseConstraint != "any" && rules.HoleCards.UseConstraint != "" {
@@ -197,9 +223,10 @@ func getHandIterator(rules *GameRules) HandIterator {
 
 // EvaluateHand is the main evaluation function. It takes a player's hole cards and the
 // community cards and, based on the provided game rules, determines the best possible
-// high hand and, if applicable, the best possible low hand.
+// high hand and, if applicable, the best possible low hand. The hands are
+// evaluated by the backend the rules select (see GameRules.Evaluator).
 //
-// The evaluation process is as follows:
+// The generic backend, which can evaluate any rules, works as follows:
 //
 // 1. High Hand Evaluation:
 //   - The function first combines the hole cards and community cards into a single pool.
@@ -212,7 +239,8 @@ func getHandIterator(rules *GameRules) HandIterator {
 //     all hand ranks to ensure the absolute best hand is found.
 //
 // 2. Low Hand Evaluation (only for Hi-Lo games):
-//   - If the game rules enable low hands, it calls `findBestLowHand`.
+//   - If the game rules enable low hands and the board can support one (see
+//     LowPossible), it calls `findBestLowHand`.
 //   - This function attempts to find the best qualifying low hand (e.g., 8-low or better)
 //     from the card pool, independent of the high hand result.
 //
@@ -225,6 +253,11 @@ func getHandIterator(rules *GameRules) HandIterator {
 //   - highResult: A HandResult for the best high hand, or nil if no hand could be formed.
 //   - lowResult: A HandResult for the best low hand (if enabled by rules), or nil.
 func EvaluateHand(holeCards []Card, communityCards []Card, gameRules *GameRules) (highResult *HandResult, lowResult *HandResult) {
+	return gameRules.evaluator().Evaluate(holeCards, communityCards, gameRules)
+}
+
+// Evaluate implements Evaluator by trying every 5-card hand the rules allow.
+func (genericEvaluator) Evaluate(holeCards []Card, communityCards []Card, gameRules *GameRules) (highResult *HandResult, lowResult *HandResult) {
 	// 1. Select the combination generation strategy based on the game rules.
 	iterator := getHandIterator(gameRules)
 
@@ -241,7 +274,7 @@ func EvaluateHand(holeCards []Card, communityCards []Card, gameRules *GameRules)
 	for _, combo := range all5CardCombos {
 		handResult := evaluateSingleHand(combo, gameRules)
 		if handResult != nil {
-			if bestHand == nil || compareHandResults(handResult, bestHand) > 0 {
+			if bestHand == nil || CompareHigh(handResult, bestHand) > 0 {
 				bestHand = handResult
 			}
 		}
@@ -249,7 +282,7 @@ func EvaluateHand(holeCards []Card, communityCards []Card, gameRules *GameRules)
 	highResult = bestHand
 
 	// 4. From the same combinations, find the best low hand if the game rules enable it.
-	if gameRules.LowHand.Enabled {
+	if gameRules.LowHand.Enabled && LowPossible(communityCards, gameRules) {
 		var bestLowHand *HandResult
 		for _, combo := range all5CardCombos {
 			if isQualifyingLowHand(combo, Rank(gameRules.LowHand.MaxRank)) {
@@ -261,7 +294,7 @@ func EvaluateHand(holeCards []Card, communityCards []Card, gameRules *GameRules)
 					HighValues: getLowHandHighValues(combo),
 				}
 
-				if bestLowHand == nil || compareLowHands(currentLowHand, bestLowHand) > 0 {
+				if bestLowHand == nil || CompareLow(currentLowHand, bestLowHand) > 0 {
 					bestLowHand = currentLowHand
 				}
 			}
@@ -269,9 +302,42 @@ func EvaluateHand(holeCards []Card, communityCards []Card, gameRules *GameRules)
 		lowResult = bestLowHand
 	}
 
+	// 5. Record which of the winning cards came from the hole and which from the board.
+	highResult.setCardProvenance(holeCards)
+	lowResult.setCardProvenance(holeCards)
+
 	return highResult, lowResult
 }
 
+// LowPossible reports whether a qualifying low hand can still be made on the
+// board, counting the cards yet to be dealt. A low needs five distinct low
+// ranks, and a player can add at most as many as they may use hole cards, so
+// the rest must come from the board, e.g. three for exact-2 and max-2
+// variants. It is
+// false for games without a low.
+func LowPossible(board []Card, rules *GameRules) bool {
+	if !rules.LowHand.Enabled {
+		return false
+	}
+	// Rules without a hole card count put no limit on the hole cards used.
+	holeCardsUsed := 5
+	switch {
+	case rules.HoleCards.UseConstraint == "exact" || rules.HoleCards.UseConstraint == "max":
+		holeCardsUsed = rules.HoleCards.UseCount
+	case rules.HoleCards.Count > 0:
+		holeCardsUsed = min(rules.HoleCards.Count, 5)
+	}
+	maxRank := Rank(rules.LowHand.MaxRank)
+	lowRanks := make(map[Rank]bool)
+	for _, c := range board {
+		if c.Rank <= maxRank || c.Rank == Ace {
+			lowRanks[c.Rank] = true
+		}
+	}
+	toCome := max(5-len(board), 0)
+	return len(lowRanks)+toCome >= 5-holeCardsUsed
+}
+
 // isQualifyingLowHand checks if a 5-card hand meets the criteria for a low hand.
 func isQualifyingLowHand(cards []Card, maxRank Rank) bool {
 	if len(cards) != 5 {
@@ -290,22 +356,6 @@ func isQualifyingLowHand(cards []Card, maxRank Rank) bool {
 	return true
 }
 
-// compareLowHands compares two low hands. It returns 1 if h1 is better (lower) than h2,
-// -1 if h2 is better, and 0 if they are identical.
-func compareLowHands(h1, h2 *HandResult) int {
-	for i := 0; i < len(h1.HighValues); i++ {
-		v1 := getLowRankValue(h1.HighValues[i])
-		v2 := getLowRankValue(h2.HighValues[i])
-		if v1 < v2 {
-			return 1 // h1 is better because its card is lower.
-		}
-		if v1 > v2 {
-			return -1 // h2 is better.
-		}
-	}
-	return 0 // Hands are identical.
-}
-
 // getLowHandHighValues returns the ranks of the cards sorted for low-hand comparison (highest to lowest).
 func getLowHandHighValues(cards []Card) []Rank {
 	sortedCards := make([]Card, 5)
@@ -809,28 +859,6 @@ func findKickers(sortedPool []Card, excludeRanks []Rank, n int) (bool, []Card) {
 	return len(kickers) == n, kickers
 }
 
-// compareHandResults compares two HandResult objects to determine which is stronger.
-// It first compares by HandRank, then by HighValues for tie-breaking.
-// Returns 1 if h1 > h2, -1 if h1 < h2, 0 if h1 == h2.
-func compareHandResults(h1, h2 *HandResult) int {
-	if h1.Rank > h2.Rank {
-		return 1
-	}
-	if h1.Rank < h2.Rank {
-		return -1
-	}
-	// Ranks are the same, compare kickers.
-	for i := 0; i < len(h1.HighValues); i++ {
-		if h1.HighValues[i] > h2.HighValues[i] {
-			return 1
-		}
-		if h1.HighValues[i] < h2.HighValues[i] {
-			return -1
-		}
-	}
-	return 0 // Hands are identical.
-}
-
 // getHandRanks determines the order of hand ranks to be evaluated based on the game rules.
 // It can either use the standard poker ranking or a custom ranking defined in the rules.
 func getHandRanks(rules *HandRankingsRules) []HandRank {
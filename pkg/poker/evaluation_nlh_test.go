@@ -1,12 +1,11 @@
 package poker
 
 import (
-	"pls7-cli/internal/util"
 	"testing"
 )
 
 func TestNLHHighHands(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	testCases := []struct {
 		name         string
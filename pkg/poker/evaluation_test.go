@@ -1,14 +1,22 @@
 package poker
 
 import (
-	"pls7-cli/internal/util"
+	"os"
 	"reflect"
 	"sort"
 	"testing"
+
+	"github.com/sirupsen/logrus"
 )
 
+// initDebugLogger shows the debug logs in tests, as the CLI's dev mode does.
+func initDebugLogger() {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetLevel(logrus.DebugLevel)
+}
+
 func TestPLS7HighHands(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	testCases := []struct {
 		name         string
@@ -204,7 +212,7 @@ func TestPLS7FindBestStraight(t *testing.T) {
 
 // TestPLS7LowHandComparison specifically tests the comparison logic between two low hands.
 func TestPLS7LowHandComparison(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	// compare is a helper to simulate the comparison logic.
 	// Returns 1 if h1 is better (lower), -1 if h2 is better, 0 if tie.
@@ -277,7 +285,7 @@ func TestPLS7LowHandComparison(t *testing.T) {
 
 // TestHandRankOrder tests the order of hand ranks by given game rules.
 func TestHandRankOrder(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 
 	testCases := []struct {
 		name         string
@@ -414,6 +422,83 @@ func TestPLOHandEvaluation(t *testing.T) {
 	}
 }
 
+func TestMaxConstraintHandEvaluation(t *testing.T) {
+	// Pineapple-style rule: use at most 2 of 3 hole cards, with a 7-or-better low.
+	rules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 3, UseConstraint: "max", UseCount: 2},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+		LowHand:      LowHandRules{Enabled: true, MaxRank: 7},
+	}
+
+	testCases := []struct {
+		name          string
+		holeCards     string
+		board         string
+		expectedRank  HandRank
+		expectedCards string
+		expectedLow   string // expectedLow is empty when there is no low.
+	}{
+		{
+			// The royal flush needs all three hole cards, so only A-K of it plays.
+			name: "Cannot use three hole cards", holeCards: "Ah Kh Qh", board: "Jh Th 2c 3d 8s",
+			expectedRank: HighCard, expectedCards: "Ah Kh Jh Th 8s",
+		},
+		{
+			name: "The board can play", holeCards: "2c 3d 4h", board: "As Ks Qs Js Ts",
+			expectedRank: RoyalFlush, expectedCards: "As Ks Qs Js Ts",
+		},
+		{
+			name: "One hole card plays", holeCards: "9h 2c 3d", board: "9s 9d Kc Kh 4s",
+			expectedRank: FullHouse, expectedCards: "9h 9s 9d Kc Kh",
+		},
+		{
+			// A-2-3 would make a 5-4-3-2-A low, but only two of them may play.
+			name: "Low from two hole cards", holeCards: "As 2d 3c", board: "4h 5s 6d Kc Qh",
+			expectedRank: Straight, expectedCards: "2d 3c 4h 5s 6d", expectedLow: "6d 5s 4h 2d As",
+		},
+		{
+			name: "No low with only two low board cards", holeCards: "As 2d 3c", board: "4h 5s Jd Kc Qh",
+			expectedRank: HighCard, expectedCards: "As Kc Qh Jd 5s",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			high, low := EvaluateHand(CardsFromStrings(tc.holeCards), CardsFromStrings(tc.board), rules)
+			if high == nil {
+				t.Fatalf("EvaluateHand returned nil for the high hand")
+			}
+			if high.Rank != tc.expectedRank {
+				t.Errorf("Expected hand rank %v, but got %v", tc.expectedRank, high.Rank)
+			}
+			if !sameCards(high.Cards, CardsFromStrings(tc.expectedCards)) {
+				t.Errorf("Expected the high hand %s, but got %v", tc.expectedCards, high.Cards)
+			}
+			switch {
+			case tc.expectedLow == "" && low != nil:
+				t.Errorf("Expected no low hand, but got %v", low.Cards)
+			case tc.expectedLow != "" && (low == nil || !sameCards(low.Cards, CardsFromStrings(tc.expectedLow))):
+				t.Errorf("Expected the low hand %s, but got %v", tc.expectedLow, low)
+			}
+		})
+	}
+}
+
+// sameCards reports whether two hands hold the same cards, in any order.
+func sameCards(a, b []Card) bool {
+	key := func(cards []Card) []Card {
+		sorted := append([]Card(nil), cards...)
+		sort.Slice(sorted, func(i, j int) bool {
+			if sorted[i].Rank != sorted[j].Rank {
+				return sorted[i].Rank > sorted[j].Rank
+			}
+			return sorted[i].Suit > sorted[j].Suit
+		})
+		return sorted
+	}
+	return reflect.DeepEqual(key(a), key(b))
+}
+
 func TestPLO8LowHandEvaluation(t *testing.T) {
 	// PLO8 Rule: Must use exactly 2 hole cards and 3 community cards for low hand.
 	plo8Rules := &GameRules{
@@ -573,3 +658,73 @@ func TestEvaluationRegression_AnyConstraint(t *testing.T) {
 		})
 	}
 }
+
+// TestEvaluateHand_CardProvenance tests that the evaluated hand records which
+// cards came from the hole and which from the board, including in exact-use games.
+func TestEvaluateHand_CardProvenance(t *testing.T) {
+	omahaRules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 4, UseConstraint: "exact", UseCount: 2},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	holeCards := CardsFromStrings("As Ks 2d 3c")
+	communityCards := CardsFromStrings("Qs Js Ts 4s 5s")
+
+	highResult, _ := EvaluateHand(holeCards, communityCards, omahaRules)
+	if highResult == nil {
+		t.Fatal("Expected a high hand, but got nil")
+	}
+	if highResult.Rank != RoyalFlush {
+		t.Fatalf("Expected Royal Flush, but got %v", highResult.Rank)
+	}
+
+	expectedHole := CardsFromStrings("As Ks")
+	expectedBoard := CardsFromStrings("Qs Js Ts")
+	if !reflect.DeepEqual(highResult.HoleCards, expectedHole) {
+		t.Errorf("Expected hole cards %v, but got %v", expectedHole, highResult.HoleCards)
+	}
+	if !reflect.DeepEqual(highResult.BoardCards, expectedBoard) {
+		t.Errorf("Expected board cards %v, but got %v", expectedBoard, highResult.BoardCards)
+	}
+}
+
+func TestLowPossible(t *testing.T) {
+	pls7Rules := &GameRules{
+		HoleCards: HoleCardRules{Count: 3, UseConstraint: "any"},
+		LowHand:   LowHandRules{Enabled: true, MaxRank: 7},
+	}
+	plo8Rules := &GameRules{
+		HoleCards: HoleCardRules{Count: 4, UseConstraint: "exact", UseCount: 2},
+		LowHand:   LowHandRules{Enabled: true, MaxRank: 8},
+	}
+	nlhRules := &GameRules{HoleCards: HoleCardRules{Count: 2, UseConstraint: "any"}}
+	pineapple7Rules := &GameRules{
+		HoleCards: HoleCardRules{Count: 3, UseConstraint: "max", UseCount: 2},
+		LowHand:   LowHandRules{Enabled: true, MaxRank: 7},
+	}
+
+	testCases := []struct {
+		name     string
+		rules    *GameRules
+		board    string
+		expected bool
+	}{
+		{name: "PLS7 two low ranks", rules: pls7Rules, board: "As 7d Kc Qh Jd", expected: true},
+		{name: "PLS7 one low rank", rules: pls7Rules, board: "As Ad Kc Qh 8d", expected: false},
+		{name: "PLS7 flop without low cards can still get them", rules: pls7Rules, board: "Ks Qd Jc", expected: true},
+		{name: "PLO8 three low ranks", rules: plo8Rules, board: "2s 5d 8c Kh Kd", expected: true},
+		{name: "PLO8 two low ranks", rules: plo8Rules, board: "2s 2d 8c Kh Qd", expected: false},
+		{name: "PLO8 turn needs the river", rules: plo8Rules, board: "2s 8c Kh Qd", expected: true},
+		{name: "PLO8 turn without low cards", rules: plo8Rules, board: "9s Tc Kh Qd", expected: false},
+		{name: "No low game", rules: nlhRules, board: "As 2d 3c 4h 5d", expected: false},
+		{name: "Max 2 three low ranks", rules: pineapple7Rules, board: "4s 5d 6c Kh Kd", expected: true},
+		{name: "Max 2 two low ranks", rules: pineapple7Rules, board: "4s 5d Qc Kh Kd", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := LowPossible(CardsFromStrings(tc.board), tc.rules); got != tc.expected {
+				t.Errorf("Expected LowPossible to be %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultEvaluator is the name of the evaluation backend used by rules that
+// do not select one.
+const DefaultEvaluator = "generic"
+
+// Evaluator is a hand evaluation backend. Rules select one by name (see
+// GameRules.Evaluator), so that each variant can be evaluated by the fastest
+// backend that evaluates it correctly.
+type Evaluator interface {
+	// Evaluate returns the best high hand that can be made from the hole and
+	// community cards under the rules and, in High-Low games, the best
+	// qualifying low hand, as EvaluateHand does.
+	Evaluate(holeCards, communityCards []Card, rules *GameRules) (high, low *HandResult)
+	// Supports returns an error describing why the backend cannot evaluate
+	// hands under the rules, or nil if it can.
+	Supports(rules *GameRules) error
+}
+
+// evaluators holds the evaluation backends by the names rules select them by.
+var evaluators = map[string]Evaluator{
+	"generic":    genericEvaluator{},
+	"lookup_nlh": lookupNLHEvaluator{},
+}
+
+// EvaluatorNames returns the names of the evaluation backends, sorted.
+func EvaluatorNames() []string {
+	names := make([]string, 0, len(evaluators))
+	for name := range evaluators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupEvaluator returns the evaluation backend with the given name, the
+// default one for an empty name.
+func lookupEvaluator(name string) (Evaluator, error) {
+	if name == "" {
+		name = DefaultEvaluator
+	}
+	e, ok := evaluators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown evaluator %q (available: %s)", name, strings.Join(EvaluatorNames(), ", "))
+	}
+	return e, nil
+}
+
+// evaluator returns the rules' evaluation backend. Rules are validated when
+// they are loaded, so an unknown backend here falls back to the generic one.
+func (r *GameRules) evaluator() Evaluator {
+	e, err := lookupEvaluator(r.Evaluator)
+	if err != nil {
+		logrus.Warnf("%v, defaulting to %q", err, DefaultEvaluator)
+		return genericEvaluator{}
+	}
+	return e
+}
+
+// genericEvaluator evaluates hands under any rules, by generating every
+// 5-card hand the hole card rules allow and ranking each by the rules' hand
+// rankings.
+type genericEvaluator struct{}
+
+// Supports implements Evaluator. The generic backend supports every rule.
+func (genericEvaluator) Supports(*GameRules) error {
+	return nil
+}
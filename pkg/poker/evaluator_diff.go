@@ -0,0 +1,170 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// maxReportedDivergences is the most divergences an evaluator diff keeps the
+// cards of; the rest are only counted.
+const maxReportedDivergences = 20
+
+// diffChunkDeals is the number of deals in each chunk of an evaluator diff,
+// the unit of work evaluated in parallel.
+const diffChunkDeals = 10000
+
+// EvaluatorDivergence is a deal on which two evaluation backends disagree.
+type EvaluatorDivergence struct {
+	HoleCards      []Card
+	CommunityCards []Card
+	// Reference and Candidate are the hands each backend found, high and low.
+	ReferenceHigh, ReferenceLow *HandResult
+	CandidateHigh, CandidateLow *HandResult
+}
+
+// String describes the divergence with the exact cards, e.g. "As Kd / Qh Jh
+// Th 2c 3d: reference Royal Flush, ..., candidate Straight, ...".
+func (d EvaluatorDivergence) String() string {
+	return fmt.Sprintf("%s / %s: reference %s, candidate %s",
+		notation(d.HoleCards), notation(d.CommunityCards),
+		formatHighLow(d.ReferenceHigh, d.ReferenceLow), formatHighLow(d.CandidateHigh, d.CandidateLow))
+}
+
+// notation writes cards as they are typed, e.g. "As Kd".
+func notation(cards []Card) string {
+	tokens := make([]string, len(cards))
+	for i, c := range cards {
+		tokens[i] = c.Notation()
+	}
+	return strings.Join(tokens, " ")
+}
+
+// formatHighLow describes a high hand and, if there is one, a low hand.
+func formatHighLow(high, low *HandResult) string {
+	if low == nil {
+		return high.String()
+	}
+	return fmt.Sprintf("%s, low %s", high, low)
+}
+
+// EvaluatorDiff is the result of a differential test of a candidate evaluation
+// backend against a reference one, usually the generic backend.
+type EvaluatorDiff struct {
+	Reference, Candidate string
+	// Deals is the number of random deals both backends evaluated.
+	Deals int
+	// Divergences counts the deals they disagreed on, and Examples holds the
+	// first of them.
+	Divergences int
+	Examples    []EvaluatorDivergence
+}
+
+// DiffEvaluators evaluates random deals under the rules with two evaluation
+// backends, and reports every deal on which their high or low hands differ in
+// rank or strength. Each deal has the rules' hole cards and a flop, a turn or
+// a full board, drawn with r, and the deals are evaluated in parallel. Both
+// backends must support the rules. It stops early, with the deals done so
+// far, if the context is canceled.
+func DiffEvaluators(ctx context.Context, reference, candidate string, rules *GameRules, deals int, r *rand.Rand) (*EvaluatorDiff, error) {
+	ref, err := lookupEvaluator(reference)
+	if err != nil {
+		return nil, err
+	}
+	cand, err := lookupEvaluator(candidate)
+	if err != nil {
+		return nil, err
+	}
+	for name, e := range map[string]Evaluator{reference: ref, candidate: cand} {
+		if err := e.Supports(rules); err != nil {
+			return nil, fmt.Errorf("evaluator %q does not support the rules: %w", name, err)
+		}
+	}
+	if holeCount := rules.HoleCards.Count; holeCount+StreetRiver.BoardSize() > deckSize {
+		return nil, fmt.Errorf("%d hole cards and a board do not fit in a deck", holeCount)
+	}
+
+	// The deals are split into chunks, each drawn with its own generator
+	// seeded from r, so that they can be evaluated in parallel and still be
+	// the same deals for the same r on any machine.
+	chunks := make([]*EvaluatorDiff, (deals+diffChunkDeals-1)/diffChunkDeals)
+	seeds := make([]int64, len(chunks))
+	for i := range seeds {
+		seeds[i] = r.Int63()
+	}
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(chunks)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				size := min(diffChunkDeals, deals-i*diffChunkDeals)
+				chunks[i] = diffChunk(ctx, ref, cand, rules, size, rand.New(rand.NewSource(seeds[i])))
+			}
+		}()
+	}
+	for i := range chunks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	diff := &EvaluatorDiff{Reference: reference, Candidate: candidate}
+	for _, c := range chunks {
+		diff.Deals += c.Deals
+		diff.Divergences += c.Divergences
+		diff.Examples = append(diff.Examples, c.Examples...)
+	}
+	if len(diff.Examples) > maxReportedDivergences {
+		diff.Examples = diff.Examples[:maxReportedDivergences]
+	}
+	return diff, nil
+}
+
+// diffChunk diffs the evaluators on one chunk of deals, drawn with r.
+func diffChunk(ctx context.Context, ref, cand Evaluator, rules *GameRules, deals int, r *rand.Rand) *EvaluatorDiff {
+	diff := &EvaluatorDiff{}
+	holeCount := rules.HoleCards.Count
+	deck := NewDeck().cards
+	for ; diff.Deals < deals; diff.Deals++ {
+		if diff.Deals%1000 == 0 && ctx.Err() != nil {
+			break
+		}
+		boardSize := StreetFlop.BoardSize() + r.Intn(StreetRiver.BoardSize()-StreetFlop.BoardSize()+1)
+		n := holeCount + boardSize
+		// A partial Fisher-Yates shuffle deals the first n cards.
+		for i := 0; i < n; i++ {
+			j := i + r.Intn(len(deck)-i)
+			deck[i], deck[j] = deck[j], deck[i]
+		}
+		hole, board := deck[:holeCount], deck[holeCount:n]
+		refHigh, refLow := ref.Evaluate(hole, board, rules)
+		candHigh, candLow := cand.Evaluate(hole, board, rules)
+		if sameHand(refHigh, candHigh, CompareHigh) && sameHand(refLow, candLow, CompareLow) {
+			continue
+		}
+		diff.Divergences++
+		if len(diff.Examples) < maxReportedDivergences {
+			diff.Examples = append(diff.Examples, EvaluatorDivergence{
+				HoleCards:      append([]Card(nil), hole...),
+				CommunityCards: append([]Card(nil), board...),
+				ReferenceHigh:  refHigh, ReferenceLow: refLow,
+				CandidateHigh: candHigh, CandidateLow: candLow,
+			})
+		}
+	}
+	return diff
+}
+
+// sameHand reports whether two evaluations found the same hand: both none, or
+// hands of the same rank that compare equal.
+func sameHand(a, b *HandResult, compare func(a, b *HandResult) int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Rank == b.Rank && compare(a, b) == 0
+}
@@ -0,0 +1,65 @@
+package poker
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// pairBlindEvaluator is a broken backend for the differential tests: it sees
+// every pair as high card.
+type pairBlindEvaluator struct{ genericEvaluator }
+
+func (e pairBlindEvaluator) Evaluate(holeCards, communityCards []Card, rules *GameRules) (*HandResult, *HandResult) {
+	high, low := e.genericEvaluator.Evaluate(holeCards, communityCards, rules)
+	if high.Rank == OnePair {
+		blind := *high
+		blind.Rank = HighCard
+		high = &blind
+	}
+	return high, low
+}
+
+func TestDiffEvaluators_LookupNLH(t *testing.T) {
+	diff, err := DiffEvaluators(context.Background(), "generic", "lookup_nlh", holdemRules, 20000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to diff the evaluators: %v", err)
+	}
+	if diff.Deals != 20000 || diff.Divergences != 0 {
+		t.Errorf("Expected no divergences in 20,000 deals, but got %d in %d: %v", diff.Divergences, diff.Deals, diff.Examples)
+	}
+}
+
+func TestDiffEvaluators_ReportsDivergences(t *testing.T) {
+	evaluators["pair_blind"] = pairBlindEvaluator{}
+	defer delete(evaluators, "pair_blind")
+
+	diff, err := DiffEvaluators(context.Background(), "generic", "pair_blind", holdemRules, 2000, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Failed to diff the evaluators: %v", err)
+	}
+	if diff.Divergences == 0 || len(diff.Examples) != min(diff.Divergences, maxReportedDivergences) {
+		t.Fatalf("Expected the pairs to diverge, but got %d divergences and %d examples", diff.Divergences, len(diff.Examples))
+	}
+	d := diff.Examples[0]
+	if d.ReferenceHigh.Rank != OnePair || d.CandidateHigh.Rank != HighCard {
+		t.Errorf("Expected a pair seen as high card, but got %s", d)
+	}
+	if high, _ := EvaluateHand(d.HoleCards, d.CommunityCards, holdemRules); high.Rank != OnePair {
+		t.Errorf("Expected the reported cards to make a pair, but got %s", high)
+	}
+	if !strings.HasPrefix(d.String(), d.HoleCards[0].Notation()+" ") {
+		t.Errorf("Expected the divergence to start with the hole cards, but got %q", d)
+	}
+}
+
+func TestDiffEvaluators_Unsupported(t *testing.T) {
+	rules := &GameRules{HoleCards: HoleCardRules{Count: 3}, LowHand: LowHandRules{Enabled: true, MaxRank: 7}}
+	if _, err := DiffEvaluators(context.Background(), "generic", "lookup_nlh", rules, 10, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected lookup_nlh to be refused for a Hi-Lo game")
+	}
+	if _, err := DiffEvaluators(context.Background(), "generic", "short_deck", holdemRules, 10, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected an unknown evaluator to be refused")
+	}
+}
@@ -0,0 +1,185 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// straightTops maps a set of ranks, as a mask with bit r set for each rank r,
+// to the top rank of the best straight among them, or 0 if there is none. An
+// ace also plays low, so A-2-3-4-5 is a straight to the five.
+var straightTops [1 << (Ace + 1)]Rank
+
+func init() {
+	for mask := range straightTops {
+		ranks := mask
+		if ranks&(1<<Ace) != 0 {
+			ranks |= 1 << 1 // The ace, played low.
+		}
+		for top := Ace; top >= Five; top-- {
+			run := 0x1f << (top - 4)
+			if ranks&run == run {
+				straightTops[mask] = top
+				break
+			}
+		}
+	}
+}
+
+// lookupNLHEvaluator evaluates hands of standard poker, such as No-Limit
+// Hold'em, directly from the rank and suit counts of all the cards and a
+// table of straights, instead of ranking every 5-card hand. It supports rules
+// that play any of the hole cards, with the standard hand rankings and no low.
+type lookupNLHEvaluator struct{}
+
+// Supports implements Evaluator.
+func (lookupNLHEvaluator) Supports(rules *GameRules) error {
+	switch {
+	case rules.HoleCards.UseConstraint != "" && rules.HoleCards.UseConstraint != "any":
+		return fmt.Errorf("evaluator lookup_nlh needs a game that plays any hole cards, not %q", rules.HoleCards.UseConstraint)
+	case len(rules.HandRankings.CustomRankings) > 0:
+		return fmt.Errorf("evaluator lookup_nlh needs the standard hand rankings, without custom ones")
+	case rules.LowHand.Enabled:
+		return fmt.Errorf("evaluator lookup_nlh cannot evaluate low hands")
+	}
+	return nil
+}
+
+// Evaluate implements Evaluator. It never returns a low hand.
+func (lookupNLHEvaluator) Evaluate(holeCards, communityCards []Card, _ *GameRules) (*HandResult, *HandResult) {
+	pool := make([]Card, 0, len(holeCards)+len(communityCards))
+	pool = append(pool, holeCards...)
+	pool = append(pool, communityCards...)
+	if len(pool) < 5 {
+		logrus.Warnf("EvaluateHand: No card combinations could be generated with the given hole and community cards.")
+		return nil, nil
+	}
+	sort.Slice(pool, func(i, j int) bool { return pool[i].Rank > pool[j].Rank })
+
+	high := evaluateStandardHigh(pool)
+	high.setCardProvenance(holeCards)
+	return high, nil
+}
+
+// evaluateStandardHigh returns the best standard high hand among five or more
+// cards, sorted by rank in descending order.
+func evaluateStandardHigh(pool []Card) *HandResult {
+	var rankCounts [Ace + 1]int
+	var suitCounts [Club + 1]int
+	var suitMasks [Club + 1]int
+	rankMask := 0
+	for _, c := range pool {
+		rankCounts[c.Rank]++
+		suitCounts[c.Suit]++
+		suitMasks[c.Suit] |= 1 << c.Rank
+		rankMask |= 1 << c.Rank
+	}
+
+	// best returns the highest rank held at least n times, other than except.
+	best := func(n int, except Rank) Rank {
+		for r := Ace; r >= Two; r-- {
+			if r != except && rankCounts[r] >= n {
+				return r
+			}
+		}
+		return 0
+	}
+
+	// Straight flushes and flushes.
+	var flushCards []Card
+	for suit, count := range suitCounts {
+		if count < 5 {
+			continue
+		}
+		for _, c := range pool {
+			if c.Suit == Suit(suit) {
+				flushCards = append(flushCards, c)
+			}
+		}
+		if top := straightTops[suitMasks[suit]]; top != 0 {
+			rank := StraightFlush
+			if top == Ace {
+				rank = RoyalFlush
+			}
+			return &HandResult{Rank: rank, Cards: findCardsForStraight(flushCards, straightRanks(top)), HighValues: []Rank{top}}
+		}
+	}
+
+	if quads := best(4, 0); quads != 0 {
+		_, kickers := findKickers(pool, []Rank{quads}, 1)
+		return &HandResult{
+			Rank:       FourOfAKind,
+			Cards:      append(findCardsByRank(pool, quads, 4), kickers...),
+			HighValues: []Rank{quads, kickers[0].Rank},
+		}
+	}
+
+	trips := best(3, 0)
+	if trips != 0 {
+		if pair := best(2, trips); pair != 0 {
+			return &HandResult{
+				Rank:       FullHouse,
+				Cards:      append(findCardsByRank(pool, trips, 3), findCardsByRank(pool, pair, 2)...),
+				HighValues: []Rank{trips, pair},
+			}
+		}
+	}
+
+	if flushCards != nil {
+		flushCards = flushCards[:5]
+		values := make([]Rank, 5)
+		for i, c := range flushCards {
+			values[i] = c.Rank
+		}
+		return &HandResult{Rank: Flush, Cards: flushCards, HighValues: values}
+	}
+
+	if top := straightTops[rankMask]; top != 0 {
+		return &HandResult{Rank: Straight, Cards: findCardsForStraight(pool, straightRanks(top)), HighValues: []Rank{top}}
+	}
+
+	if trips != 0 {
+		_, kickers := findKickers(pool, []Rank{trips}, 2)
+		return &HandResult{
+			Rank:       ThreeOfAKind,
+			Cards:      append(findCardsByRank(pool, trips, 3), kickers...),
+			HighValues: []Rank{trips, kickers[0].Rank, kickers[1].Rank},
+		}
+	}
+
+	if highPair := best(2, 0); highPair != 0 {
+		if lowPair := best(2, highPair); lowPair != 0 {
+			_, kickers := findKickers(pool, []Rank{highPair, lowPair}, 1)
+			cards := append(findCardsByRank(pool, highPair, 2), findCardsByRank(pool, lowPair, 2)...)
+			return &HandResult{
+				Rank:       TwoPair,
+				Cards:      append(cards, kickers...),
+				HighValues: []Rank{highPair, lowPair, kickers[0].Rank},
+			}
+		}
+		_, kickers := findKickers(pool, []Rank{highPair}, 3)
+		return &HandResult{
+			Rank:       OnePair,
+			Cards:      append(findCardsByRank(pool, highPair, 2), kickers...),
+			HighValues: []Rank{highPair, kickers[0].Rank, kickers[1].Rank, kickers[2].Rank},
+		}
+	}
+
+	cards := append([]Card(nil), pool[:5]...)
+	values := make([]Rank, 5)
+	for i, c := range cards {
+		values[i] = c.Rank
+	}
+	return &HandResult{Rank: HighCard, Cards: cards, HighValues: values}
+}
+
+// straightRanks returns the ranks of the straight to the given top rank, from
+// the highest; the wheel's ace comes last.
+func straightRanks(top Rank) []Rank {
+	if top == Five {
+		return []Rank{Five, Four, Three, Two, Ace}
+	}
+	return []Rank{top, top - 1, top - 2, top - 3, top - 4}
+}
@@ -0,0 +1,103 @@
+package poker
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLookupNLHEvaluator_MatchesGeneric(t *testing.T) {
+	rules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 2, UseConstraint: "any"},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		deck := NewDeck()
+		deck.Shuffle(r)
+		cards := make([]Card, 7)
+		for j := range cards {
+			cards[j], _ = deck.Deal()
+		}
+		hole, board := cards[:2], cards[2:5+r.Intn(3)]
+		want, _ := genericEvaluator{}.Evaluate(hole, board, rules)
+		got, low := lookupNLHEvaluator{}.Evaluate(hole, board, rules)
+		if CompareHigh(got, want) != 0 || got.Rank != want.Rank {
+			t.Fatalf("%v %v: lookup_nlh found %s, generic %s", hole, board, got, want)
+		}
+		if low != nil {
+			t.Fatalf("%v %v: lookup_nlh found a low hand %s", hole, board, low)
+		}
+		if len(got.HoleCards)+len(got.BoardCards) != 5 {
+			t.Fatalf("%v %v: expected the provenance of 5 cards, got %v and %v", hole, board, got.HoleCards, got.BoardCards)
+		}
+	}
+}
+
+func TestLookupNLHEvaluator_Hands(t *testing.T) {
+	rules := &GameRules{HoleCards: HoleCardRules{Count: 2}, Evaluator: "lookup_nlh"}
+	testCases := []struct {
+		hole, board string
+		rank        HandRank
+		highValues  []Rank
+	}{
+		{hole: "As Ks", board: "Qs Js Ts 2c 3d", rank: RoyalFlush, highValues: []Rank{Ace}},
+		{hole: "As 2s", board: "3s 4s 5s 6d Kc", rank: StraightFlush, highValues: []Rank{Five}},
+		{hole: "Ah 2c", board: "3d 4s 5s 6d Kc", rank: Straight, highValues: []Rank{Six}},
+		{hole: "Ah 2c", board: "3d 4s 5s Qd Kc", rank: Straight, highValues: []Rank{Five}},
+		{hole: "As Ac", board: "Ah Ks Kc Kd 2c", rank: FullHouse, highValues: []Rank{Ace, King}},
+		{hole: "7s 7c", board: "7h 7d Ac Kd 2c", rank: FourOfAKind, highValues: []Rank{Seven, Ace}},
+		{hole: "As Ac", board: "Ks Kc Qs Qd 2c", rank: TwoPair, highValues: []Rank{Ace, King, Queen}},
+		{hole: "9s 2c", board: "Ks 7c 5s 4d 3h", rank: HighCard, highValues: []Rank{King, Nine, Seven, Five, Four}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.hole+" "+tc.board, func(t *testing.T) {
+			hole, _ := ParseCards(tc.hole)
+			board, _ := ParseCards(tc.board)
+			high, _ := EvaluateHand(hole, board, rules)
+			if high == nil || high.Rank != tc.rank {
+				t.Fatalf("Expected %s, but got %s", tc.rank, high)
+			}
+			for i, v := range tc.highValues {
+				if high.HighValues[i] != v {
+					t.Errorf("Expected high values %v, but got %v", tc.highValues, high.HighValues)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_Evaluator(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rules   GameRules
+		wantErr string
+	}{
+		{name: "Default", rules: GameRules{}},
+		{name: "Generic for PLS7", rules: GameRules{Evaluator: "generic", LowHand: LowHandRules{Enabled: true, MaxRank: 7}}},
+		{name: "Lookup for NLH", rules: GameRules{Evaluator: "lookup_nlh"}},
+		{name: "Unknown", rules: GameRules{Evaluator: "short_deck"}, wantErr: `unknown evaluator "short_deck"`},
+		{name: "Lookup for Omaha", rules: GameRules{Evaluator: "lookup_nlh", HoleCards: HoleCardRules{Count: 4, UseConstraint: "exact", UseCount: 2}}, wantErr: "any hole cards"},
+		{name: "Lookup with a low", rules: GameRules{Evaluator: "lookup_nlh", LowHand: LowHandRules{Enabled: true, MaxRank: 8}}, wantErr: "low hands"},
+		{name: "Lookup with skip straights", rules: GameRules{Evaluator: "lookup_nlh", HandRankings: HandRankingsRules{
+			CustomRankings: []CustomHandRanking{{Name: "skip_straight", InsertAfterRank: "flush"}},
+		}}, wantErr: "standard hand rankings"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := tc.rules
+			rules.BettingLimit = "no_limit"
+			if rules.HoleCards.Count == 0 {
+				rules.HoleCards = HoleCardRules{Count: 2, UseConstraint: "any"}
+			}
+			err := rules.Validate()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Expected no error, but got %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("Expected an error containing %q, but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
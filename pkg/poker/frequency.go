@@ -0,0 +1,78 @@
+package poker
+
+// FiveCardHands is the number of distinct five-card hands dealt from a full
+// deck: 52 choose 5.
+const FiveCardHands = 2598960
+
+// FiveCardHandCounts returns how many of the FiveCardHands five-card hands
+// make each hand rank under the rules, so that the exact frequency of a hand,
+// Skip Straights included, can be quoted. Hands are counted by their ranks
+// rather than dealt one by one: the suits only matter for flushes, so every
+// combination of ranks is evaluated once with the suits it can be dealt in.
+func FiveCardHandCounts(rules *GameRules) map[HandRank]int {
+	counts := make(map[HandRank]int)
+	multiplicity := make([]int, Ace+1)
+
+	var count func(rank Rank, left int)
+	count = func(rank Rank, left int) {
+		if left == 0 {
+			countRankCombination(multiplicity, rules, counts)
+			return
+		}
+		if rank > Ace {
+			return
+		}
+		for n := min(left, 4); n >= 0; n-- {
+			multiplicity[rank] = n
+			count(rank+1, left-n)
+		}
+		multiplicity[rank] = 0
+	}
+	count(Two, 5)
+	return counts
+}
+
+// FiveCardFrequency returns the exact probability that a five-card hand dealt
+// from a full deck makes the given hand rank under the rules.
+func FiveCardFrequency(rank HandRank, rules *GameRules) float64 {
+	return float64(FiveCardHandCounts(rules)[rank]) / FiveCardHands
+}
+
+// countRankCombination adds the hands that can be dealt with the given number
+// of cards of each rank to counts.
+func countRankCombination(multiplicity []int, rules *GameRules, counts map[HandRank]int) {
+	var cards []Card
+	combinations := 1
+	for rank, n := range multiplicity {
+		for suit := range n {
+			cards = append(cards, Card{Rank: Rank(rank), Suit: Suit(suit)})
+		}
+		combinations *= binomial(4, n)
+	}
+
+	if combinations != 1024 {
+		// A pair or better cannot be a flush, so every choice of suits makes
+		// the same hand.
+		counts[evaluateSingleHand(cards, rules).Rank] += combinations
+		return
+	}
+
+	// Five different ranks: 4 of the 4^5 choices of suits are flushes.
+	suited := make([]Card, len(cards))
+	for i, c := range cards {
+		suited[i] = Card{Rank: c.Rank, Suit: Spade}
+	}
+	unsuited := append([]Card(nil), suited...)
+	unsuited[4].Suit = Heart
+	counts[evaluateSingleHand(suited, rules).Rank] += 4
+	counts[evaluateSingleHand(unsuited, rules).Rank] += combinations - 4
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) int {
+	result := 1
+	for i := range k {
+		result = result * (n - i) / (i + 1)
+	}
+	return result
+}
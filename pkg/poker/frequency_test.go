@@ -0,0 +1,59 @@
+package poker
+
+import "testing"
+
+func TestFiveCardHandCounts_Standard(t *testing.T) {
+	rules := &GameRules{HandRankings: HandRankingsRules{UseStandardRankings: true}}
+	counts := FiveCardHandCounts(rules)
+
+	// The textbook counts of the standard hand ranks.
+	expected := map[HandRank]int{
+		RoyalFlush:    4,
+		StraightFlush: 36,
+		FourOfAKind:   624,
+		FullHouse:     3744,
+		Flush:         5108,
+		Straight:      10200,
+		ThreeOfAKind:  54912,
+		TwoPair:       123552,
+		OnePair:       1098240,
+		HighCard:      1302540,
+	}
+	for rank, want := range expected {
+		if counts[rank] != want {
+			t.Errorf("Expected %d hands of %v, but got %d", want, rank, counts[rank])
+		}
+	}
+	if counts[SkipStraight] != 0 || counts[SkipStraightFlush] != 0 {
+		t.Errorf("Expected no skip straights under standard rankings, but got %v", counts)
+	}
+}
+
+func TestFiveCardHandCounts_SkipStraights(t *testing.T) {
+	rules := &GameRules{
+		HandRankings: HandRankingsRules{
+			CustomRankings: []CustomHandRanking{
+				{Name: "skip_straight_flush", InsertAfterRank: "royal_flush"},
+				{Name: "skip_straight", InsertAfterRank: "flush"},
+			},
+		},
+	}
+	counts := FiveCardHandCounts(rules)
+
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	if total != FiveCardHands {
+		t.Errorf("Expected the counts to cover all %d hands, but got %d", FiveCardHands, total)
+	}
+	if counts[RoyalFlush] != 4 || counts[SkipStraightFlush] == 0 || counts[SkipStraightFlush]%4 != 0 {
+		t.Errorf("Expected 4 royal flushes and skip straight flushes in every suit, but got %v", counts)
+	}
+	if counts[SkipStraight] != counts[SkipStraightFlush]/4*1020 {
+		t.Errorf("Expected 1020 unsuited skip straights per suited one, but got %d and %d", counts[SkipStraight], counts[SkipStraightFlush])
+	}
+	if got, want := FiveCardFrequency(SkipStraightFlush, rules), float64(counts[SkipStraightFlush])/FiveCardHands; got != want {
+		t.Errorf("Expected a skip straight flush frequency of %g, but got %g", want, got)
+	}
+}
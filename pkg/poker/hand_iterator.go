@@ -28,7 +28,27 @@ func (g *AnyCombinationGenerator) Generate(holeCards, communityCards []Card, rul
 type ExactCombinationGenerator struct{}
 
 func (g *ExactCombinationGenerator) Generate(holeCards, communityCards []Card, rules *GameRules) [][]Card {
-	numHoleCardsToUse := rules.HoleCards.UseCount
+	return holeAndBoardCombinations(holeCards, communityCards, rules.HoleCards.UseCount)
+}
+
+// MaxCombinationGenerator is a strategy that generates 5-card hands by taking
+// up to a specific number of cards from the hole and the rest from the
+// community. It implements the "max" UseConstraint, as in Pineapple-style
+// games where at most 2 of 3 hole cards may be used.
+type MaxCombinationGenerator struct{}
+
+func (g *MaxCombinationGenerator) Generate(holeCards, communityCards []Card, rules *GameRules) [][]Card {
+	var all5CardCombos [][]Card
+	for numHoleCardsToUse := 0; numHoleCardsToUse <= min(rules.HoleCards.UseCount, 5); numHoleCardsToUse++ {
+		all5CardCombos = append(all5CardCombos, holeAndBoardCombinations(holeCards, communityCards, numHoleCardsToUse)...)
+	}
+	return all5CardCombos // nil if there are not enough cards to form a valid hand
+}
+
+// holeAndBoardCombinations generates every 5-card hand made of exactly
+// numHoleCardsToUse hole cards and the rest from the community cards, or nil
+// if there are not enough cards to form one.
+func holeAndBoardCombinations(holeCards, communityCards []Card, numHoleCardsToUse int) [][]Card {
 	numBoardCardsToUse := 5 - numHoleCardsToUse
 
 	if len(holeCards) < numHoleCardsToUse || len(communityCards) < numBoardCardsToUse {
@@ -14,7 +14,53 @@ type OutsInfo struct {
 	AllOuts []Card
 	// OutsPerHandRank maps a specific hand rank to the cards that would complete it.
 	// For example, OutsPerHandRank[Flush] would list all cards that complete a flush.
+	// A high card is never an improvement, so OutsPerHandRank[HighCard] holds
+	// the outs to a qualifying low hand instead (see LowOuts).
 	OutsPerHandRank map[HandRank][]Card
+	// CardsToCome is the number of community cards still to be dealt: 2 on the
+	// flop and 1 on the turn.
+	CardsToCome int
+	// Unseen is the number of cards the player has not seen, from which the
+	// next community cards are drawn.
+	Unseen int
+	// Improvement gives the odds of hitting any of AllOuts.
+	Improvement OutsOdds
+	// OddsPerHandRank gives the odds of hitting the outs to each hand rank in
+	// OutsPerHandRank.
+	OddsPerHandRank map[HandRank]OutsOdds
+}
+
+// OutsOdds gives the exact chances of hitting a set of outs, counting every
+// unseen card as live.
+type OutsOdds struct {
+	// Count is the number of outs.
+	Count int
+	// NextCard is the chance that the next community card is an out.
+	NextCard float64
+	// ByRiver is the chance that at least one out comes by the river. On the
+	// turn it is the same as NextCard.
+	ByRiver float64
+	// Cumulative is the chance of improving to the hand rank or better by the
+	// river, counting the outs to every higher rank too. For low hand outs,
+	// which are kept under HighCard, it is the same as ByRiver.
+	Cumulative float64
+}
+
+// HitProbability returns the exact chance that at least one of outs cards is
+// among draws cards dealt from unseen cards.
+func HitProbability(outs, unseen, draws int) float64 {
+	if outs <= 0 || unseen <= 0 || draws <= 0 {
+		return 0
+	}
+	// The chance of missing is the chance that every card drawn is a blank.
+	miss := 1.0
+	for i := 0; i < draws; i++ {
+		if unseen-outs-i <= 0 {
+			return 1
+		}
+		miss *= float64(unseen-outs-i) / float64(unseen-i)
+	}
+	return 1 - miss
 }
 
 // CalculateOuts determines which cards from the remaining deck would improve the
@@ -38,6 +84,7 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	if currentHand == nil {
 		return false, &OutsInfo{
 			OutsPerHandRank: make(map[HandRank][]Card),
+			OddsPerHandRank: make(map[HandRank]OutsOdds),
 		}
 	}
 
@@ -148,10 +195,10 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 
 	// --- Low Hand ---
 	logrus.Tracef("CalculateOuts: Checking for low hands draws, lowGameEnabled: %v", gameRules.LowHand.Enabled)
-	if gameRules.LowHand.Enabled {
-		logrus.Debugf("CalculateOuts: Low game enabled, checking for low hand draws")
+	if LowPossible(communityCards, gameRules) {
+		logrus.Debugf("CalculateOuts: Low possible on the board, checking for low hand draws")
 		if hasDraw, outs := hasLowHandDraw(holeCards, communityCards, seenCards, Rank(gameRules.LowHand.MaxRank)); hasDraw {
-			// Note: Low hand outs are stored under HighCard rank for simplicity.
+			// Low hand outs are stored under HighCard; see LowOuts.
 			outsInfo.OutsPerHandRank[HighCard] = outs
 			logrus.Debugf("CalculateOuts: outsInfo.OutsPerHandRank updated: %+v", outsInfo.OutsPerHandRank)
 			for _, out := range outs {
@@ -164,10 +211,51 @@ func CalculateOuts(holeCards []Card, communityCards []Card, gameRules *GameRules
 	for card := range allOutsMap {
 		outsInfo.AllOuts = append(outsInfo.AllOuts, card)
 	}
+	outsInfo.calculateOdds(len(seenCards), len(communityCards))
 
 	return len(outsInfo.AllOuts) > 0, outsInfo
 }
 
+// calculateOdds fills in the exact odds of hitting the outs, given the number
+// of cards seen and of community cards dealt so far.
+// LowOuts returns the cards that would complete a qualifying low hand, which
+// are kept under HighCard in OutsPerHandRank.
+func (o *OutsInfo) LowOuts() []Card {
+	return o.OutsPerHandRank[HighCard]
+}
+
+func (o *OutsInfo) calculateOdds(numSeen, numCommunityCards int) {
+	o.CardsToCome = max(5-numCommunityCards, 0)
+	o.Unseen = 52 - numSeen
+	odds := func(outs, cumulative int) OutsOdds {
+		return OutsOdds{
+			Count:      outs,
+			NextCard:   HitProbability(outs, o.Unseen, min(o.CardsToCome, 1)),
+			ByRiver:    HitProbability(outs, o.Unseen, o.CardsToCome),
+			Cumulative: HitProbability(cumulative, o.Unseen, o.CardsToCome),
+		}
+	}
+
+	o.Improvement = odds(len(o.AllOuts), len(o.AllOuts))
+	o.OddsPerHandRank = make(map[HandRank]OutsOdds, len(o.OutsPerHandRank))
+	for rank, outs := range o.OutsPerHandRank {
+		if rank == HighCard {
+			o.OddsPerHandRank[rank] = odds(len(outs), len(outs))
+			continue
+		}
+		// Count each out once, however many of the higher ranks it makes.
+		better := make(map[Card]bool)
+		for other, otherOuts := range o.OutsPerHandRank {
+			if other >= rank {
+				for _, c := range otherOuts {
+					better[c] = true
+				}
+			}
+		}
+		o.OddsPerHandRank[rank] = odds(len(outs), len(better))
+	}
+}
+
 // hasSkipStraightFlushDraw checks for a draw to a Skip Straight Flush.
 // This requires having 4 cards of the same suit that are also 4 of the 5 cards
 // needed for a Skip Straight.
@@ -2,12 +2,11 @@ package poker
 
 import (
 	"fmt"
-	"pls7-cli/internal/util"
 	"testing"
 )
 
 func TestNLHCalculateOuts(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 	testCases := []struct {
 		name                string
 		holeCards           []Card
@@ -203,7 +202,7 @@ func TestNLHCalculateBreakEvenEquityBasedOnPotOdds(t *testing.T) {
 }
 
 func TestNLHCalculateEquityWithCards(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 	testCases := []struct {
 		name           string
 		holeCards      []Card
@@ -2,13 +2,13 @@ package poker
 
 import (
 	"fmt"
-	"pls7-cli/internal/util"
+	"math"
 	"sort"
 	"testing"
 )
 
 func TestCalculateOuts(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 	testCases := []struct {
 		name                string
 		holeCards           []Card
@@ -311,7 +311,7 @@ func TestCalculateBreakEvenEquityBasedOnPotOdds(t *testing.T) {
 }
 
 func TestCalculateEquityWithCards(t *testing.T) {
-	util.InitLogger(true)
+	initDebugLogger()
 	testCases := []struct {
 		name           string
 		holeCards      []Card
@@ -341,3 +341,61 @@ func TestCalculateEquityWithCards(t *testing.T) {
 		})
 	}
 }
+
+func TestHitProbability(t *testing.T) {
+	testCases := []struct {
+		name     string
+		outs     int
+		unseen   int
+		draws    int
+		expected float64
+	}{
+		{"One card", 9, 46, 1, 9.0 / 46},
+		{"Two cards", 9, 46, 2, 1 - (37.0*36)/(46*45)},
+		{"No outs", 0, 46, 2, 0},
+		{"No cards to come", 9, 46, 0, 0},
+		{"Every card is an out", 46, 46, 2, 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := HitProbability(tc.outs, tc.unseen, tc.draws)
+			if math.Abs(actual-tc.expected) > 1e-9 {
+				t.Errorf("Expected %.4f, but got %.4f", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCalculateOuts_Odds(t *testing.T) {
+	initDebugLogger()
+	rules := &GameRules{HandRankings: HandRankingsRules{UseStandardRankings: true}}
+
+	// A flush draw and an open-ended straight draw on the flop: 9 spades for
+	// the flush, and 6 more tens and fives for the straight.
+	_, outsInfo := CalculateOuts(CardsFromStrings("9s 8s Kc"), CardsFromStrings("7s 6d 2s"), rules)
+	if outsInfo.CardsToCome != 2 || outsInfo.Unseen != 46 {
+		t.Fatalf("Expected 2 cards to come from 46 unseen, got %d from %d", outsInfo.CardsToCome, outsInfo.Unseen)
+	}
+	flush := outsInfo.OddsPerHandRank[Flush]
+	if flush.Count != 9 || math.Abs(flush.NextCard-HitProbability(9, 46, 1)) > 1e-9 || math.Abs(flush.ByRiver-HitProbability(9, 46, 2)) > 1e-9 {
+		t.Errorf("Unexpected flush odds: %+v", flush)
+	}
+	if flush.Cumulative != flush.ByRiver {
+		t.Errorf("Expected the flush's cumulative odds to be its own, got %+v", flush)
+	}
+	straight := outsInfo.OddsPerHandRank[Straight]
+	if straight.Count != 8 || math.Abs(straight.Cumulative-HitProbability(15, 46, 2)) > 1e-9 {
+		t.Errorf("Expected 8 straight outs and 15 for a straight or better, got %+v", straight)
+	}
+	if outsInfo.Improvement.Count != 15 || outsInfo.Improvement.ByRiver != straight.Cumulative {
+		t.Errorf("Expected 15 outs in all, got %+v", outsInfo.Improvement)
+	}
+
+	// On the turn only the river is to come.
+	_, outsInfo = CalculateOuts(CardsFromStrings("9s 8s Kc"), CardsFromStrings("7s 6d 2s Qh"), rules)
+	flush = outsInfo.OddsPerHandRank[Flush]
+	if outsInfo.CardsToCome != 1 || flush.NextCard != flush.ByRiver || math.Abs(flush.ByRiver-9.0/45) > 1e-9 {
+		t.Errorf("Unexpected flush odds on the turn: %+v", flush)
+	}
+}
@@ -0,0 +1,96 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// maxExactBehindHands is the largest number of hands a single opponent can
+// hold that EstimateBehind evaluates one by one; with more, or with more than
+// one opponent, it evaluates sampledBehindDeals random deals instead.
+const (
+	maxExactBehindHands = 2000
+	sampledBehindDeals  = 1000
+)
+
+// BehindResult is how likely a made hand is to be beaten already: the
+// "reverse outs" of the hand, which complement its outs to improve.
+type BehindResult struct {
+	// Hand is the high hand made so far.
+	Hand *HandResult
+	// Probability is the chance that at least one opponent already holds a
+	// better high hand.
+	Probability float64
+	// Deals is the number of opponent hands, or sets of hands multiway,
+	// evaluated.
+	Deals int
+	// Exact is true if every hand the opponent can hold was evaluated, and
+	// false if the probability was estimated from a random sample.
+	Exact bool
+}
+
+// EstimateBehind finds the probability that at least one of opponents random
+// hands already makes a better high hand than the hole cards on the board.
+// Only the hole cards and the board are removed from the deck the opponents'
+// hands come from. Against one opponent, every hand they can hold is
+// evaluated when there are few enough of them; otherwise the opponents' hands
+// are sampled using r. If ctx is canceled first, the estimate stops and
+// returns ctx's error.
+func EstimateBehind(ctx context.Context, hand, board []Card, opponents int, rules *GameRules, r *rand.Rand) (*BehindResult, error) {
+	if n := len(board); n < StreetFlop.BoardSize() || n > StreetRiver.BoardSize() {
+		return nil, fmt.Errorf("the board must have 3, 4 or 5 cards, got %d", n)
+	}
+	if opponents < 1 {
+		return nil, fmt.Errorf("need at least one opponent, got %d", opponents)
+	}
+
+	deck := NewDeck()
+	if err := deck.RemoveCards(append(append([]Card(nil), hand...), board...)); err != nil {
+		return nil, err
+	}
+	remaining := deck.cards
+	holeCount := rules.HoleCards.Count
+	if opponents*holeCount > len(remaining) {
+		return nil, fmt.Errorf("not enough cards to deal %d opponents", opponents)
+	}
+	made, _ := EvaluateHand(hand, board, rules)
+	if made == nil {
+		return nil, fmt.Errorf("no hand made with %v on %v", hand, board)
+	}
+	beats := func(opponent []Card) bool {
+		high, _ := EvaluateHand(opponent, board, rules)
+		return high != nil && CompareHigh(high, made) > 0
+	}
+
+	result := &BehindResult{Hand: made}
+	behind := 0
+	if opponents == 1 && countCombinations(len(remaining), holeCount) <= maxExactBehindHands {
+		result.Exact = true
+		for i, opponent := range combinations(remaining, holeCount) {
+			if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if beats(opponent) {
+				behind++
+			}
+			result.Deals++
+		}
+	} else {
+		for i := 0; i < sampledBehindDeals; i++ {
+			if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			r.Shuffle(len(remaining), func(a, b int) { remaining[a], remaining[b] = remaining[b], remaining[a] })
+			for o := 0; o < opponents; o++ {
+				if beats(remaining[o*holeCount : (o+1)*holeCount]) {
+					behind++
+					break
+				}
+			}
+			result.Deals++
+		}
+	}
+	result.Probability = float64(behind) / float64(result.Deals)
+	return result, nil
+}
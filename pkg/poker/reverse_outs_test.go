@@ -0,0 +1,61 @@
+package poker
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestEstimateBehind_Exact(t *testing.T) {
+	// Top pair, top kicker is behind sets (AA, 77, 22) and two pairs (A7,
+	// A2, 72): 1 + 3 + 3 + 6 + 6 + 9 = 28 of the 1,081 hands left.
+	result, err := EstimateBehind(context.Background(), CardsFromStrings("Ah Kd"), CardsFromStrings("Ac 7s 2d"), 1, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Exact || result.Deals != 1081 {
+		t.Errorf("expected 1,081 exact hands, got %d (exact: %v)", result.Deals, result.Exact)
+	}
+	if want := 28.0 / 1081; math.Abs(result.Probability-want) > 1e-9 {
+		t.Errorf("expected a probability of %.4f, got %.4f", want, result.Probability)
+	}
+	if result.Hand.Rank != OnePair {
+		t.Errorf("expected the made hand to be a pair, got %v", result.Hand)
+	}
+
+	nuts, err := EstimateBehind(context.Background(), CardsFromStrings("As Ks"), CardsFromStrings("Qs Js Ts"), 1, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nuts.Probability != 0 {
+		t.Errorf("expected a royal flush never to be behind, got %.4f", nuts.Probability)
+	}
+}
+
+func TestEstimateBehind_Multiway(t *testing.T) {
+	result, err := EstimateBehind(context.Background(), CardsFromStrings("Ah Kd"), CardsFromStrings("Ac 7s 2d"), 3, holdemRules, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Exact || result.Deals != sampledBehindDeals {
+		t.Errorf("expected %d sampled deals, got %d (exact: %v)", sampledBehindDeals, result.Deals, result.Exact)
+	}
+	// Each opponent holds one of the 28 better hands about 2.6% of the time.
+	if want := 1 - math.Pow(1-28.0/1081, 3); math.Abs(result.Probability-want) > 0.03 {
+		t.Errorf("expected a probability near %.3f against three opponents, got %.3f", want, result.Probability)
+	}
+}
+
+func TestEstimateBehind_Errors(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	if _, err := EstimateBehind(context.Background(), CardsFromStrings("Ah Kd"), nil, 1, holdemRules, r); err == nil {
+		t.Error("expected an error before the flop")
+	}
+	if _, err := EstimateBehind(context.Background(), CardsFromStrings("Ah Kd"), CardsFromStrings("Ac 7s 2d"), 0, holdemRules, r); err == nil {
+		t.Error("expected an error without opponents")
+	}
+	if _, err := EstimateBehind(context.Background(), CardsFromStrings("Ah Kd"), CardsFromStrings("Ah 7s 2d"), 1, holdemRules, r); err == nil {
+		t.Error("expected an error for a card dealt twice")
+	}
+}
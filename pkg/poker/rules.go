@@ -1,5 +1,12 @@
 package poker
 
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // HoleCardRules defines the rules governing the use of a player's private cards
 // (hole cards) when forming a 5-card poker hand.
 type HoleCardRules struct {
@@ -13,7 +20,8 @@ type HoleCardRules struct {
 	//           This is typical for games like No-Limit Hold'em.
 	//  - "exact": The player must use a specific number of hole cards, defined by UseCount.
 	//             This is the rule in Omaha, where players must use exactly 2.
-	//  - "max": The player can use up to a specific number of hole cards.
+	//  - "max": The player can use up to a specific number of hole cards, defined by
+	//           UseCount. This is the rule in Pineapple-style games.
 	UseConstraint string `yaml:"use_constraint"`
 
 	// UseCount specifies the number of hole cards to be used when UseConstraint is
@@ -61,6 +69,22 @@ type LowHandRules struct {
 	MaxRank int `yaml:"max_rank"`
 }
 
+// FixedLimitRules sets the sizes of the bets and raises in a fixed-limit game,
+// where every bet and raise is exactly one bet of the street's size.
+type FixedLimitRules struct {
+	// SmallBet is the size of a bet or raise pre-flop and on the flop. Zero
+	// means the big blind, so the bets rise with the blinds.
+	SmallBet int `yaml:"small_bet"`
+
+	// BigBet is the size of a bet or raise on the turn and river. Zero means
+	// twice the small bet.
+	BigBet int `yaml:"big_bet"`
+
+	// RaiseCap is the most bets a street may have, the first bet (or the big
+	// blind pre-flop) included, e.g. 4 for a bet and three raises. Zero means 4.
+	RaiseCap int `yaml:"raise_cap"`
+}
+
 // GameRules is the top-level container for all the rules that define a specific
 // poker game variant. This struct is typically populated by loading a YAML configuration
 // file, allowing for flexible and dynamic game creation without changing the engine's code.
@@ -76,10 +100,175 @@ type GameRules struct {
 	// Common values are "pot_limit", "no_limit", and "fixed_limit".
 	BettingLimit string `yaml:"betting_limit"`
 
+	// FixedLimit sets the bet sizes and raise cap when BettingLimit is
+	// "fixed_limit". It is ignored otherwise.
+	FixedLimit FixedLimitRules `yaml:"fixed_limit"`
+
+	// ChipUnit is the smallest chip in play, e.g. 100. Blinds, bets and raises
+	// are rounded to multiples of it; only an all-in may be an odd amount.
+	// Zero means any amount can be bet.
+	ChipUnit int `yaml:"chip_unit"`
+
+	// Ante is the ante every player dealt in posts each hand, before the
+	// blinds. It doubles with the blinds; a tournament blind structure sets
+	// its own antes instead. Zero means no ante.
+	Ante int `yaml:"ante"`
+
 	// HoleCards defines the rules for the player's private cards.
 	HoleCards HoleCardRules `yaml:"hole_cards"`
 	// HandRankings defines the hierarchy of valid poker hands.
 	HandRankings HandRankingsRules `yaml:"hand_rankings"`
 	// LowHand defines the rules for the low hand in High-Low split games.
 	LowHand LowHandRules `yaml:"low_hand"`
+
+	// MustBet sets a "must bet" house rule, which does not allow checking on
+	// some streets. Nil means there is none; it is left out of the rules'
+	// encoding then, so that their Hash stays as it was.
+	MustBet *MustBetRules `yaml:"must_bet" json:",omitempty"`
+
+	// Evaluator names the hand evaluation backend: "generic", which evaluates
+	// any rules, or "lookup_nlh", which is faster but only evaluates standard
+	// hands made from any of the hole cards, without a low. Empty means
+	// "generic".
+	Evaluator string `yaml:"evaluator"`
+
+	// AITuningFile names the variant's AI tuning pack, relative to the rules
+	// file. It is optional.
+	AITuningFile string `yaml:"ai_tuning"`
+	// AITuning is the pack loaded from AITuningFile, or nil if there is none,
+	// in which case the CPUs play by generic heuristics.
+	AITuning *AITuning `yaml:"-"`
+}
+
+// MustBetRules sets a "must bet" house rule: on its streets, the player it
+// binds may not check while no one has bet, and must open the betting or fold.
+type MustBetRules struct {
+	// Streets names the streets after the flop the rule applies to: "flop",
+	// "turn" and "river".
+	Streets []string `yaml:"streets"`
+	// Player names who must bet: "first", the first player to act on the
+	// street, or "previous_winner", a winner of the previous hand, whenever
+	// the betting comes to them unopened. Empty means "first".
+	Player string `yaml:"player"`
+}
+
+// Applies reports whether the rule applies on the street. A nil rule applies
+// on none.
+func (m *MustBetRules) Applies(street Street) bool {
+	if m == nil {
+		return false
+	}
+	for _, name := range m.Streets {
+		if strings.EqualFold(name, street.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks that the streets and the player bound can be played.
+func (m *MustBetRules) validate() error {
+	if m == nil {
+		return nil
+	}
+	for _, name := range m.Streets {
+		switch strings.ToLower(name) {
+		case "flop", "turn", "river":
+		default:
+			return fmt.Errorf("must-bet street must be flop, turn or river, got %q", name)
+		}
+	}
+	switch m.Player {
+	case "", "first", "previous_winner":
+	default:
+		return fmt.Errorf("unsupported must-bet player %q", m.Player)
+	}
+	return nil
+}
+
+// Hash returns a short fingerprint of the rules, the same for identical rules,
+// so that a client can tell whether it already knows them. The AI tuning pack
+// does not change the game, so it is left out.
+func (r *GameRules) Hash() string {
+	rules := *r
+	rules.AITuning = nil
+	data, err := json.Marshal(rules)
+	if err != nil {
+		// Rules are plain data, which always encodes.
+		panic(fmt.Sprintf("failed to encode the rules: %v", err))
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(data))[:16]
+}
+
+// Validate checks that the rules describe a game the engine can play. It
+// returns an error describing the first problem found.
+func (r *GameRules) Validate() error {
+	switch r.BettingLimit {
+	case "pot_limit", "no_limit":
+	case "fixed_limit":
+		if err := r.FixedLimit.validate(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unsupported betting limit %q", r.BettingLimit)
+	}
+	if r.ChipUnit < 0 {
+		return fmt.Errorf("chip unit must not be negative, got %d", r.ChipUnit)
+	}
+	if r.Ante < 0 || (r.ChipUnit > 1 && r.Ante%r.ChipUnit != 0) {
+		return fmt.Errorf("ante must be a non-negative multiple of the chip unit %d, got %d", r.ChipUnit, r.Ante)
+	}
+	if err := r.MustBet.validate(); err != nil {
+		return err
+	}
+	if r.HoleCards.Count < 2 || r.HoleCards.Count > 5 {
+		return fmt.Errorf("hole card count must be between 2 and 5, got %d", r.HoleCards.Count)
+	}
+	switch r.HoleCards.UseConstraint {
+	case "", "any":
+	case "exact", "max":
+		if r.HoleCards.UseCount < 1 || r.HoleCards.UseCount > r.HoleCards.Count {
+			return fmt.Errorf("use count must be between 1 and %d, got %d", r.HoleCards.Count, r.HoleCards.UseCount)
+		}
+	default:
+		return fmt.Errorf("unsupported hole card use constraint %q", r.HoleCards.UseConstraint)
+	}
+	for _, custom := range r.HandRankings.CustomRankings {
+		if _, ok := handRankFromString(custom.Name); !ok {
+			return fmt.Errorf("unknown custom hand ranking %q", custom.Name)
+		}
+		if _, ok := handRankFromString(custom.InsertAfterRank); !ok {
+			return fmt.Errorf("unknown rank %q to insert %q after", custom.InsertAfterRank, custom.Name)
+		}
+	}
+	if r.LowHand.Enabled && (r.LowHand.MaxRank < 5 || r.LowHand.MaxRank > 8) {
+		return fmt.Errorf("low hand max rank must be between 5 and 8, got %d", r.LowHand.MaxRank)
+	}
+	evaluator, err := lookupEvaluator(r.Evaluator)
+	if err != nil {
+		return err
+	}
+	if err := evaluator.Supports(r); err != nil {
+		return err
+	}
+	if r.AITuning != nil {
+		if err := r.AITuning.Validate(); err != nil {
+			return fmt.Errorf("AI tuning pack %q: %w", r.AITuningFile, err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the bet sizes and raise cap can be played.
+func (f FixedLimitRules) validate() error {
+	if f.SmallBet < 0 || f.BigBet < 0 {
+		return fmt.Errorf("fixed-limit bet sizes must not be negative, got %d/%d", f.SmallBet, f.BigBet)
+	}
+	if f.SmallBet > 0 && f.BigBet > 0 && f.BigBet < f.SmallBet {
+		return fmt.Errorf("fixed-limit big bet %d must not be smaller than the small bet %d", f.BigBet, f.SmallBet)
+	}
+	if f.RaiseCap < 0 || f.RaiseCap == 1 {
+		return fmt.Errorf("fixed-limit raise cap must be at least 2, got %d", f.RaiseCap)
+	}
+	return nil
 }
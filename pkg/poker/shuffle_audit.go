@@ -0,0 +1,238 @@
+package poker
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// deckSize is the number of cards in a full deck.
+const deckSize = 52
+
+// MinAuditShuffles is the fewest shuffles a shuffle audit needs for every
+// cell of its tests to expect at least five observations.
+const MinAuditShuffles = 5 * 13 * 13 * 2
+
+// shuffleBackends holds the random number generators a deck can be shuffled
+// with, by name.
+var shuffleBackends = map[string]func() *rand.Rand{
+	// "math" is math/rand seeded with the time, as a game is.
+	"math": func() *rand.Rand { return rand.New(rand.NewSource(time.Now().UnixNano())) },
+	// "crypto" draws every number from crypto/rand.
+	"crypto": NewCryptoRand,
+}
+
+// ShuffleBackendNames returns the names of the random number generators a
+// deck can be shuffled with, sorted.
+func ShuffleBackendNames() []string {
+	names := make([]string, 0, len(shuffleBackends))
+	for name := range shuffleBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewShuffleRand returns a new random number generator of the named backend.
+func NewShuffleRand(backend string) (*rand.Rand, error) {
+	newRand, ok := shuffleBackends[backend]
+	if !ok {
+		return nil, fmt.Errorf("unknown shuffle backend %q (available: %s)", backend, strings.Join(ShuffleBackendNames(), ", "))
+	}
+	return newRand(), nil
+}
+
+// cryptoSource is a rand.Source that reads every number from crypto/rand. It
+// cannot be seeded.
+type cryptoSource struct{}
+
+// NewCryptoRand returns a generator whose numbers all come from crypto/rand.
+func NewCryptoRand() *rand.Rand {
+	return rand.New(cryptoSource{})
+}
+
+// Seed implements rand.Source. A cryptographic source ignores the seed.
+func (cryptoSource) Seed(int64) {}
+
+// Int63 implements rand.Source.
+func (s cryptoSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Uint64 implements rand.Source64.
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := crand.Read(b[:]); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// ShuffleTest is the result of one chi-square test of a shuffle audit.
+type ShuffleTest struct {
+	// Name describes what the test checks.
+	Name string
+	// ChiSquare is the test statistic, with DegreesOfFreedom degrees of freedom.
+	ChiSquare        float64
+	DegreesOfFreedom int
+	// PValue is the probability of a statistic at least as large from a fair
+	// shuffle.
+	PValue float64
+	// Passed is false if the statistic is too large to be likely from a fair
+	// shuffle, or too small: a shuffle more even than chance, such as one that
+	// rotates the deck, is no fairer than a biased one.
+	Passed bool
+}
+
+// ShuffleAudit is the report of a statistical audit of a shuffle.
+type ShuffleAudit struct {
+	Backend  string
+	Shuffles int
+	// Alpha is the significance level: each test fails when its p-value is
+	// below Alpha/2 or above 1-Alpha/2.
+	Alpha float64
+	Tests []ShuffleTest
+}
+
+// Passed reports whether every test passed.
+func (a *ShuffleAudit) Passed() bool {
+	for _, t := range a.Tests {
+		if !t.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// AuditShuffle shuffles a full deck the given number of times with r and
+// tests the orders dealt for three properties of a fair shuffle:
+//   - card-position uniformity: every card is dealt at every position equally
+//     often;
+//   - adjacency independence: every card is followed by every other card, or
+//     dealt last, equally often;
+//   - shuffle independence: the rank of the first card dealt does not depend
+//     on the rank dealt first by the shuffle before, which catches a generator
+//     reseeded so that shuffles repeat.
+func AuditShuffle(backend string, r *rand.Rand, shuffles int, alpha float64) (*ShuffleAudit, error) {
+	if shuffles < MinAuditShuffles {
+		return nil, fmt.Errorf("a shuffle audit needs at least %d shuffles, got %d", MinAuditShuffles, shuffles)
+	}
+	if alpha <= 0 || alpha >= 1 {
+		return nil, fmt.Errorf("the significance level must be between 0 and 1, got %g", alpha)
+	}
+
+	// Each table counts how often a pair of values occurred, e.g. a card at a
+	// position, at index first*columns+second.
+	var positions, successors [deckSize * deckSize]int
+	var firstRanks [13 * 13]int
+	previousFirst := -1
+	for i := 0; i < shuffles; i++ {
+		deck := NewDeck()
+		deck.Shuffle(r)
+		order := deck.PeekForDebug(deckSize)
+		for pos, card := range order {
+			positions[cardIndex(card)*deckSize+pos]++
+		}
+		for pos := 1; pos < deckSize; pos++ {
+			successors[cardIndex(order[pos-1])*deckSize+cardIndex(order[pos])]++
+		}
+		// The card dealt last has no successor: it is counted against itself.
+		last := cardIndex(order[deckSize-1])
+		successors[last*deckSize+last]++
+
+		// Pairs of shuffles do not overlap, so the pairs are independent.
+		first := int(order[0].Rank - Two)
+		if i%2 == 0 {
+			previousFirst = first
+		} else {
+			firstRanks[previousFirst*13+first]++
+		}
+	}
+
+	audit := &ShuffleAudit{Backend: backend, Shuffles: shuffles, Alpha: alpha}
+	addTest := func(name string, chiSquare float64, df int) {
+		p := ChiSquarePValue(chiSquare, df)
+		audit.Tests = append(audit.Tests, ShuffleTest{
+			Name:             name,
+			ChiSquare:        chiSquare,
+			DegreesOfFreedom: df,
+			PValue:           p,
+			Passed:           p >= alpha/2 && p <= 1-alpha/2,
+		})
+	}
+	addTest("card-position uniformity", chiSquareUniform(positions[:], float64(shuffles)/deckSize), deckSize*(deckSize-1))
+	addTest("adjacency independence", chiSquareUniform(successors[:], float64(shuffles)/deckSize), deckSize*(deckSize-1))
+	addTest("shuffle independence", chiSquareUniform(firstRanks[:], float64(shuffles/2)/(13*13)), 13*13-1)
+	return audit, nil
+}
+
+// cardIndex numbers the cards of a deck from 0 to 51.
+func cardIndex(c Card) int {
+	return int(c.Suit)*13 + int(c.Rank-Two)
+}
+
+// chiSquareUniform returns Pearson's chi-square statistic for counts that
+// are each expected to be the same.
+func chiSquareUniform(counts []int, expected float64) float64 {
+	sum := 0.0
+	for _, observed := range counts {
+		d := float64(observed) - expected
+		sum += d * d / expected
+	}
+	return sum
+}
+
+// ChiSquarePValue returns the probability that a chi-square distributed
+// variable with df degrees of freedom is at least x.
+func ChiSquarePValue(x float64, df int) float64 {
+	return upperRegularizedGamma(float64(df)/2, x/2)
+}
+
+// upperRegularizedGamma returns Q(a, x), by its series for small x and its
+// continued fraction otherwise.
+func upperRegularizedGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	scale := math.Exp(a*math.Log(x) - x - lgamma)
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+		for n := 1; n < 10000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if term < sum*1e-15 {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*scale)
+	}
+
+	const tiny = 1e-300
+	b := x + 1 - a
+	c, d := 1/tiny, 1/b
+	h := d
+	for i := 1; i < 10000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		if d = an*d + b; math.Abs(d) < tiny {
+			d = tiny
+		}
+		if c = b + an/c; math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return scale * h
+}
@@ -0,0 +1,79 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// repeatingSource restarts the same sequence for every shuffle, like a
+// generator reseeded with the same seed before each hand.
+type repeatingSource struct{ rand.Source }
+
+func (s repeatingSource) Int63() int64 {
+	s.Source.Seed(1)
+	return s.Source.Int63()
+}
+
+func TestAuditShuffle_FairShufflePasses(t *testing.T) {
+	for _, backend := range ShuffleBackendNames() {
+		r, err := NewShuffleRand(backend)
+		if err != nil {
+			t.Fatalf("Failed to create the %s generator: %v", backend, err)
+		}
+		// A seeded generator always passes; a generator that cannot be seeded
+		// is held to a level at which a fair shuffle hardly ever fails.
+		alpha := 1e-6
+		if backend == "math" {
+			r, alpha = rand.New(rand.NewSource(1)), 0.001
+		}
+		audit, err := AuditShuffle(backend, r, 5000, alpha)
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if !audit.Passed() {
+			t.Errorf("Expected the %s shuffle to pass, but got %+v", backend, audit.Tests)
+		}
+	}
+}
+
+func TestAuditShuffle_RepeatedShufflesFail(t *testing.T) {
+	r := rand.New(repeatingSource{rand.NewSource(1)})
+	audit, err := AuditShuffle("repeating", r, MinAuditShuffles, 0.001)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	for _, test := range audit.Tests {
+		if test.Passed {
+			t.Errorf("Expected %s to fail for a shuffle that repeats, but got p = %g", test.Name, test.PValue)
+		}
+	}
+}
+
+func TestAuditShuffle_TooFewShuffles(t *testing.T) {
+	if _, err := AuditShuffle("math", rand.New(rand.NewSource(1)), MinAuditShuffles-1, 0.001); err == nil {
+		t.Error("Expected an error for too few shuffles")
+	}
+	if _, err := NewShuffleRand("lcg"); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+}
+
+func TestChiSquarePValue(t *testing.T) {
+	testCases := []struct {
+		x        float64
+		df       int
+		expected float64
+	}{
+		{x: 3.841, df: 1, expected: 0.05},
+		{x: 18.307, df: 10, expected: 0.05},
+		{x: 6.635, df: 1, expected: 0.01},
+		{x: 2652, df: 2652, expected: 0.4963},
+		{x: 0, df: 5, expected: 1},
+	}
+	for _, tc := range testCases {
+		if got := ChiSquarePValue(tc.x, tc.df); math.Abs(got-tc.expected) > 1e-3 {
+			t.Errorf("ChiSquarePValue(%g, %d) = %.4f, expected %.4f", tc.x, tc.df, got, tc.expected)
+		}
+	}
+}
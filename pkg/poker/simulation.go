@@ -0,0 +1,105 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+)
+
+// HandRankStats holds hand rank frequencies collected by SimulateShowdowns.
+type HandRankStats struct {
+	// Hands is the number of simulated hands.
+	Hands int
+	// Players is the number of players dealt into each hand.
+	Players int
+	// Made counts how often each hand rank was made by any player at the river.
+	Made map[HandRank]int
+	// Wins counts how often each hand rank was the winning high hand. A tied
+	// pot counts once, since all tied hands share the same rank.
+	Wins map[HandRank]int
+	// LowHands counts hands in which at least one player made a qualifying low.
+	// It is only populated for games with low hands enabled.
+	LowHands int
+}
+
+// WinFrequency returns the fraction of simulated hands won by the given rank.
+func (s *HandRankStats) WinFrequency(rank HandRank) float64 {
+	if s.Hands == 0 {
+		return 0
+	}
+	return float64(s.Wins[rank]) / float64(s.Hands)
+}
+
+// MadeFrequency returns the fraction of dealt hands (hands x players) that
+// ended with the given rank at the river.
+func (s *HandRankStats) MadeFrequency(rank HandRank) float64 {
+	if s.Hands == 0 || s.Players == 0 {
+		return 0
+	}
+	return float64(s.Made[rank]) / float64(s.Hands*s.Players)
+}
+
+// SimulateShowdowns deals numHands random hands to numPlayers players under the
+// given rules, runs every hand to the river, and records which hand ranks were
+// made and which won at showdown. It is a validation tool for the evaluator as
+// much as a way to answer "how rare is a skip straight?". If ctx is canceled
+// first, the simulation stops and returns ctx's error.
+func SimulateShowdowns(ctx context.Context, rules *GameRules, numPlayers, numHands int, r *rand.Rand) (*HandRankStats, error) {
+	if numPlayers < 2 {
+		return nil, fmt.Errorf("at least 2 players are required, got %d", numPlayers)
+	}
+	if numPlayers*rules.HoleCards.Count+5 > 52 {
+		return nil, fmt.Errorf("not enough cards to deal %d hole cards to %d players", rules.HoleCards.Count, numPlayers)
+	}
+
+	stats := &HandRankStats{
+		Players: numPlayers,
+		Made:    make(map[HandRank]int),
+		Wins:    make(map[HandRank]int),
+	}
+
+	for h := 0; h < numHands; h++ {
+		if h%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		deck := NewDeck()
+		deck.Shuffle(r)
+
+		hands := make([][]Card, numPlayers)
+		for i := 0; i < rules.HoleCards.Count; i++ {
+			for p := range hands {
+				card, _ := deck.Deal()
+				hands[p] = append(hands[p], card)
+			}
+		}
+		board := make([]Card, 0, 5)
+		for i := 0; i < 5; i++ {
+			card, _ := deck.Deal()
+			board = append(board, card)
+		}
+
+		var bestHigh *HandResult
+		hasLow := false
+		for _, hole := range hands {
+			high, low := EvaluateHand(hole, board, rules)
+			if high == nil {
+				continue
+			}
+			stats.Made[high.Rank]++
+			if bestHigh == nil || CompareHigh(high, bestHigh) > 0 {
+				bestHigh = high
+			}
+			if low != nil {
+				hasLow = true
+			}
+		}
+		if bestHigh != nil {
+			stats.Wins[bestHigh.Rank]++
+		}
+		if hasLow {
+			stats.LowHands++
+		}
+		stats.Hands++
+	}
+	return stats, nil
+}
@@ -0,0 +1,58 @@
+package poker
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestSimulateShowdowns(t *testing.T) {
+	rules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 2, UseConstraint: "any"},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	stats, err := SimulateShowdowns(context.Background(), rules, 4, 200, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	if stats.Hands != 200 {
+		t.Errorf("Expected 200 hands, but got %d", stats.Hands)
+	}
+
+	totalWins, totalMade := 0, 0
+	for _, n := range stats.Wins {
+		totalWins += n
+	}
+	for _, n := range stats.Made {
+		totalMade += n
+	}
+	if totalWins != 200 {
+		t.Errorf("Expected one winning rank per hand (200), but got %d", totalWins)
+	}
+	if totalMade != 800 {
+		t.Errorf("Expected one made hand per player per hand (800), but got %d", totalMade)
+	}
+	if stats.Wins[SkipStraight] != 0 {
+		t.Errorf("Expected no skip straights under standard rankings, but got %d", stats.Wins[SkipStraight])
+	}
+}
+
+func TestSimulateShowdowns_TooManyPlayers(t *testing.T) {
+	rules := &GameRules{HoleCards: HoleCardRules{Count: 4}}
+	if _, err := SimulateShowdowns(context.Background(), rules, 12, 1, rand.New(rand.NewSource(1))); err == nil {
+		t.Error("Expected an error when the deck cannot cover all players, but got nil")
+	}
+}
+
+func TestSimulateShowdowns_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rules := &GameRules{
+		HoleCards:    HoleCardRules{Count: 2, UseConstraint: "any"},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	if _, err := SimulateShowdowns(ctx, rules, 6, 10000, rand.New(rand.NewSource(1))); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the simulation to be canceled, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package poker
+
+import (
+	"context"
+	"fmt"
+)
+
+// Street identifies a stage of community-card dealing. It mirrors the betting
+// rounds of the engine but lives in the poker package so that evaluation can be
+// performed without depending on the engine's game state.
+type Street int
+
+// Street constants, ordered by the number of community cards on the board.
+const (
+	StreetPreFlop Street = iota // StreetPreFlop has no community cards.
+	StreetFlop                  // StreetFlop has three community cards.
+	StreetTurn                  // StreetTurn has four community cards.
+	StreetRiver                 // StreetRiver has all five community cards.
+)
+
+// String returns the human-readable name of the street.
+func (s Street) String() string {
+	switch s {
+	case StreetPreFlop:
+		return "Pre-Flop"
+	case StreetFlop:
+		return "Flop"
+	case StreetTurn:
+		return "Turn"
+	case StreetRiver:
+		return "River"
+	default:
+		return "Unknown"
+	}
+}
+
+// BoardSize returns the number of community cards expected on this street.
+func (s Street) BoardSize() int {
+	return []int{0, 3, 4, 5}[s]
+}
+
+// StreetForBoard returns the street whose board has size community cards. It
+// returns an error for a board no street has.
+func StreetForBoard(size int) (Street, error) {
+	for s := StreetPreFlop; s <= StreetRiver; s++ {
+		if s.BoardSize() == size {
+			return s, nil
+		}
+	}
+	return 0, fmt.Errorf("no street has %d community cards", size)
+}
+
+// DrawFlags summarizes which kinds of draws are live for a hand. The flags are
+// derived from the same outs calculation used for the "show outs" helper, so all
+// consumers agree on what counts as a draw.
+type DrawFlags struct {
+	FlushDraw        bool // FlushDraw is true if one card completes a flush.
+	StraightDraw     bool // StraightDraw is true if one card completes a straight.
+	SkipStraightDraw bool // SkipStraightDraw is true if one card completes a skip straight.
+	LowDraw          bool // LowDraw is true if one card completes a qualifying low hand (see OutsInfo.LowOuts).
+	FullHouseDraw    bool // FullHouseDraw is true if one card fills up the hand.
+	SetDraw          bool // SetDraw is true if one card improves a pocket pair to three of a kind.
+}
+
+// Any reports whether at least one draw is live.
+func (d DrawFlags) Any() bool {
+	return d.FlushDraw || d.StraightDraw || d.SkipStraightDraw || d.LowDraw || d.FullHouseDraw || d.SetDraw
+}
+
+// StreetEvaluation is the combined result of evaluating a hand at a given street.
+// It bundles the made hand, its tie-breaking kicker vector, and normalized draw
+// information so that callers need only a single call per decision.
+type StreetEvaluation struct {
+	// Street is the street the evaluation was performed for.
+	Street Street
+	// High is the best high hand currently made, or nil pre-flop.
+	High *HandResult
+	// Low is the best qualifying low hand currently made, or nil if none.
+	Low *HandResult
+	// MadeRank is the rank of the best high hand. It is HighCard when no hand is made yet.
+	MadeRank HandRank
+	// Kickers is the tie-breaking rank vector of the best high hand.
+	Kickers []Rank
+	// Draws holds the draw flags. It is always empty pre-flop and on the river.
+	Draws DrawFlags
+	// Outs holds the detailed outs, or nil when no draws are possible on this street.
+	Outs *OutsInfo
+}
+
+// EvaluateStreet evaluates a hand for an explicit street. The number of community
+// cards must match the street (0 pre-flop, 3 on the flop, 4 on the turn, 5 on the
+// river); otherwise an error is returned. Draws are only computed on the flop and
+// turn, where cards remain to come. If ctx is canceled before the outs are
+// counted, ctx's error is returned.
+func EvaluateStreet(ctx context.Context, holeCards, communityCards []Card, street Street, rules *GameRules) (*StreetEvaluation, error) {
+	if street < StreetPreFlop || street > StreetRiver {
+		return nil, fmt.Errorf("unknown street: %d", street)
+	}
+	if len(communityCards) != street.BoardSize() {
+		return nil, fmt.Errorf(
+			"%s expects %d community cards, got %d", street, street.BoardSize(), len(communityCards),
+		)
+	}
+
+	result := &StreetEvaluation{Street: street, MadeRank: HighCard}
+	if street == StreetPreFlop {
+		return result, nil
+	}
+
+	result.High, result.Low = EvaluateHand(holeCards, communityCards, rules)
+	if result.High != nil {
+		result.MadeRank = result.High.Rank
+		result.Kickers = result.High.HighValues
+	}
+
+	if street == StreetFlop || street == StreetTurn {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		_, outs := CalculateOuts(holeCards, communityCards, rules)
+		result.Outs = outs
+		result.Draws = DrawFlags{
+			FlushDraw:        len(outs.OutsPerHandRank[Flush]) > 0,
+			StraightDraw:     len(outs.OutsPerHandRank[Straight]) > 0,
+			SkipStraightDraw: len(outs.OutsPerHandRank[SkipStraight]) > 0,
+			LowDraw:          len(outs.LowOuts()) > 0,
+			FullHouseDraw:    len(outs.OutsPerHandRank[FullHouse]) > 0,
+			SetDraw:          len(outs.OutsPerHandRank[ThreeOfAKind]) > 0,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package poker
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEvaluateStreet(t *testing.T) {
+	rules := &GameRules{
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+		LowHand:      LowHandRules{Enabled: false},
+	}
+
+	testCases := []struct {
+		name              string
+		holeCardsStr      string
+		communityCardsStr string
+		street            Street
+		expectedRank      HandRank
+		expectedFlushDraw bool
+		expectOuts        bool
+	}{
+		{name: "Pre-Flop has no made hand", holeCardsStr: "As Ks", street: StreetPreFlop, expectedRank: HighCard},
+		{name: "Flop flush draw", holeCardsStr: "As Ks", communityCardsStr: "2s 7s Jd", street: StreetFlop, expectedRank: HighCard, expectedFlushDraw: true, expectOuts: true},
+		{name: "Turn pair", holeCardsStr: "As Kd", communityCardsStr: "Ac 7h 2d 9c", street: StreetTurn, expectedRank: OnePair, expectOuts: true},
+		{name: "River has no draws", holeCardsStr: "As Ks", communityCardsStr: "2s 7s Jd 9c 3h", street: StreetRiver, expectedRank: HighCard},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := EvaluateStreet(context.Background(), CardsFromStrings(tc.holeCardsStr), CardsFromStrings(tc.communityCardsStr), tc.street, rules)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if result.MadeRank != tc.expectedRank {
+				t.Errorf("Expected made rank %v, but got %v", tc.expectedRank, result.MadeRank)
+			}
+			if result.Draws.FlushDraw != tc.expectedFlushDraw {
+				t.Errorf("Expected flush draw %v, but got %v", tc.expectedFlushDraw, result.Draws.FlushDraw)
+			}
+			if (result.Outs != nil) != tc.expectOuts {
+				t.Errorf("Expected outs present: %v, but got %v", tc.expectOuts, result.Outs != nil)
+			}
+			if result.High != nil && len(result.Kickers) != len(result.High.HighValues) {
+				t.Errorf("Expected kickers to mirror HighValues, got %v", result.Kickers)
+			}
+		})
+	}
+}
+
+func TestEvaluateStreet_BoardSizeMismatch(t *testing.T) {
+	rules := &GameRules{HandRankings: HandRankingsRules{UseStandardRankings: true}}
+	_, err := EvaluateStreet(context.Background(), CardsFromStrings("As Ks"), CardsFromStrings("2s 7s Jd 9c"), StreetFlop, rules)
+	if err == nil {
+		t.Error("Expected an error for a flop evaluation with 4 community cards, but got nil")
+	}
+}
+
+func TestStreetForBoard(t *testing.T) {
+	for street := StreetPreFlop; street <= StreetRiver; street++ {
+		if got, err := StreetForBoard(street.BoardSize()); err != nil || got != street {
+			t.Errorf("Expected %d community cards to be the %s, but got %v (%v)", street.BoardSize(), street, got, err)
+		}
+	}
+	for _, size := range []int{1, 2, 6} {
+		if _, err := StreetForBoard(size); err == nil {
+			t.Errorf("Expected an error for a board of %d cards, but got nil", size)
+		}
+	}
+}
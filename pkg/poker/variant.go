@@ -0,0 +1,65 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// RandomVariant generates a random but legal game variant, as in a home game's
+// "dealer's choice": 2 to 4 hole cards (4-card games may play Omaha-style,
+// using exactly two), high-only or hi-lo, with or without skip straights, and
+// pot-limit or no-limit betting. The result always passes Validate.
+func RandomVariant(r *rand.Rand) *GameRules {
+	rules := &GameRules{
+		Abbreviation: "CHAOS",
+		BettingLimit: []string{"pot_limit", "no_limit"}[r.Intn(2)],
+		HoleCards:    HoleCardRules{Count: 2 + r.Intn(3), UseConstraint: "any"},
+		HandRankings: HandRankingsRules{UseStandardRankings: true},
+	}
+	if rules.HoleCards.Count == 4 && r.Intn(2) == 0 {
+		rules.HoleCards.UseConstraint = "exact"
+		rules.HoleCards.UseCount = 2
+	}
+	if r.Intn(2) == 0 {
+		rules.HandRankings = HandRankingsRules{
+			CustomRankings: []CustomHandRanking{
+				{Name: "skip_straight_flush", InsertAfterRank: "royal_flush"},
+				{Name: "skip_straight", InsertAfterRank: "flush"},
+			},
+		}
+	}
+	if r.Intn(2) == 0 {
+		rules.LowHand = LowHandRules{Enabled: true, MaxRank: 7 + r.Intn(2)}
+	}
+	rules.Name = variantName(rules)
+	return rules
+}
+
+// variantName builds a descriptive name for generated rules, e.g.
+// "Pot-Limit 3-Card Hold'em Hi-Lo 7-or-Better with Skip Straights".
+func variantName(rules *GameRules) string {
+	parts := []string{"Pot-Limit"}
+	switch rules.BettingLimit {
+	case "no_limit":
+		parts[0] = "No-Limit"
+	case "fixed_limit":
+		parts[0] = "Fixed-Limit"
+	}
+	parts = append(parts, fmt.Sprintf("%d-Card", rules.HoleCards.Count))
+	switch rules.HoleCards.UseConstraint {
+	case "exact":
+		parts = append(parts, fmt.Sprintf("Omaha (use %d)", rules.HoleCards.UseCount))
+	case "max":
+		parts = append(parts, fmt.Sprintf("Hold'em (use up to %d)", rules.HoleCards.UseCount))
+	default:
+		parts = append(parts, "Hold'em")
+	}
+	if rules.LowHand.Enabled {
+		parts = append(parts, fmt.Sprintf("Hi-Lo %d-or-Better", rules.LowHand.MaxRank))
+	}
+	if len(rules.HandRankings.CustomRankings) > 0 {
+		parts = append(parts, "with Skip Straights")
+	}
+	return strings.Join(parts, " ")
+}
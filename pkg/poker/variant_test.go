@@ -0,0 +1,101 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestRandomVariant_AlwaysValid(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[string]bool)
+	for i := 0; i < 500; i++ {
+		rules := RandomVariant(r)
+		if err := rules.Validate(); err != nil {
+			t.Fatalf("generated invalid rules %+v: %v", rules, err)
+		}
+		if rules.HoleCards.Count < 2 || rules.HoleCards.Count > 4 {
+			t.Errorf("hole card count %d out of range", rules.HoleCards.Count)
+		}
+		seen[rules.Name] = true
+
+		// Every generated variant must be playable by the evaluator.
+		deck := NewDeck()
+		deck.Shuffle(r)
+		hole := make([]Card, rules.HoleCards.Count)
+		for j := range hole {
+			hole[j], _ = deck.Deal()
+		}
+		board := make([]Card, 5)
+		for j := range board {
+			board[j], _ = deck.Deal()
+		}
+		if high, _ := EvaluateHand(hole, board, rules); high == nil {
+			t.Fatalf("no high hand for %s", rules.Name)
+		}
+	}
+	if len(seen) < 10 {
+		t.Errorf("expected a wide variety of variants, got only %d", len(seen))
+	}
+}
+
+func TestGameRules_Validate(t *testing.T) {
+	valid := GameRules{BettingLimit: "pot_limit", HoleCards: HoleCardRules{Count: 4, UseConstraint: "exact", UseCount: 2}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("expected valid rules, got %v", err)
+	}
+	fixed := valid
+	fixed.BettingLimit = "fixed_limit"
+	fixed.FixedLimit = FixedLimitRules{SmallBet: 100, BigBet: 200, RaiseCap: 5}
+	if err := fixed.Validate(); err != nil {
+		t.Fatalf("expected valid fixed-limit rules, got %v", err)
+	}
+	pineapple := GameRules{BettingLimit: "no_limit", HoleCards: HoleCardRules{Count: 3, UseConstraint: "max", UseCount: 2}}
+	if err := pineapple.Validate(); err != nil {
+		t.Fatalf("expected valid max-constraint rules, got %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(r *GameRules)
+	}{
+		{"unknown betting limit", func(r *GameRules) { r.BettingLimit = "spread_limit" }},
+		{"negative fixed-limit bet", func(r *GameRules) {
+			r.BettingLimit = "fixed_limit"
+			r.FixedLimit = FixedLimitRules{SmallBet: -100}
+		}},
+		{"big bet below the small bet", func(r *GameRules) {
+			r.BettingLimit = "fixed_limit"
+			r.FixedLimit = FixedLimitRules{SmallBet: 200, BigBet: 100}
+		}},
+		{"raise cap without raises", func(r *GameRules) {
+			r.BettingLimit = "fixed_limit"
+			r.FixedLimit = FixedLimitRules{RaiseCap: 1}
+		}},
+		{"too many hole cards", func(r *GameRules) { r.HoleCards.Count = 7 }},
+		{"use count above hole cards", func(r *GameRules) { r.HoleCards.UseCount = 5 }},
+		{"unknown constraint", func(r *GameRules) { r.HoleCards.UseConstraint = "some" }},
+		{"max constraint without a use count", func(r *GameRules) { r.HoleCards = HoleCardRules{Count: 3, UseConstraint: "max"} }},
+		{"unknown custom ranking", func(r *GameRules) {
+			r.HandRankings.CustomRankings = []CustomHandRanking{{Name: "wrap", InsertAfterRank: "flush"}}
+		}},
+		{"low max rank out of range", func(r *GameRules) { r.LowHand = LowHandRules{Enabled: true, MaxRank: 10} }},
+		{"negative chip unit", func(r *GameRules) { r.ChipUnit = -100 }},
+		{"must bet before the flop", func(r *GameRules) { r.MustBet = &MustBetRules{Streets: []string{"pre-flop"}} }},
+		{"unknown must-bet player", func(r *GameRules) { r.MustBet = &MustBetRules{Streets: []string{"flop"}, Player: "button"} }},
+		{"unknown rank in AI tuning", func(r *GameRules) {
+			r.AITuning = &AITuning{StartingHands: StartingHandTable{RankPoints: map[string]float64{"10": 5}}}
+		}},
+		{"negative aggression in AI tuning", func(r *GameRules) {
+			r.AITuning = &AITuning{Aggression: AggressionCurve{River: -1}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := valid
+			tt.modify(&rules)
+			if err := rules.Validate(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
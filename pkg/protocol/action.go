@@ -0,0 +1,68 @@
+package protocol
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+)
+
+// ActionRequest is an action sent by a client for its player. Seq is the
+// engine.Game.ActionSeq of the state the client decided on, so that an
+// action sent on a state the hand has moved past is recognized as stale.
+type ActionRequest struct {
+	Player string `json:"player"`
+	Seq    int    `json:"seq"`
+	// Action is the action type's wire code, e.g. "raise".
+	Action string `json:"action"`
+	// Amount is the total a bet or raise is made to.
+	Amount int `json:"amount,omitempty"`
+}
+
+// ActionAck answers an ActionRequest with whether it was applied, and with
+// the state the hand is in afterwards, so that a client whose action was
+// rejected can resynchronize before acting again.
+type ActionAck struct {
+	Accepted bool `json:"accepted"`
+	// Error says why the action was rejected.
+	Error string `json:"error,omitempty"`
+	// Seq is the sequence number the next action must carry.
+	Seq int `json:"seq"`
+	// Phase is the game phase's wire code.
+	Phase string `json:"phase"`
+	// Turn is the player to act, or "" if no action is awaited.
+	Turn      string `json:"turn,omitempty"`
+	BetToCall int    `json:"bet_to_call"`
+	Pot       int    `json:"pot"`
+}
+
+// HandleAction applies a client's action to the game with
+// engine.Game.SubmitAction and, once it is accepted, passes the turn on. An
+// action out of turn, stale, illegal, or with an unknown type changes nothing. Either
+// way, the acknowledgement describes the game as it now stands.
+func HandleAction(g *engine.Game, req ActionRequest) ActionAck {
+	err := submitAction(g, req)
+	if err == nil {
+		g.AdvanceTurn()
+	}
+	ack := ActionAck{Accepted: err == nil, Seq: g.ActionSeq, BetToCall: g.BetToCall, Pot: g.Pot}
+	if err != nil {
+		ack.Error = err.Error()
+	}
+	ack.Phase, _ = GamePhases.Code(g.Phase)
+	if g.Phase != engine.PhaseShowdown && g.Phase != engine.PhaseHandOver && !g.IsBettingRoundOver() {
+		ack.Turn = g.CurrentPlayer().Name
+	}
+	return ack
+}
+
+// submitAction decodes the request's action and submits it.
+func submitAction(g *engine.Game, req ActionRequest) error {
+	actionType, err := ActionTypes.FromCode(req.Action)
+	if err != nil {
+		return err
+	}
+	if actionType == engine.ActionShowPartial || actionType == engine.ActionFastForward {
+		return fmt.Errorf("%q is not a betting action", req.Action)
+	}
+	_, err = g.SubmitAction(req.Player, req.Seq, engine.PlayerAction{Type: actionType, Amount: req.Amount})
+	return err
+}
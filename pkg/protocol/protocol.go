@@ -0,0 +1,198 @@
+// Package protocol freezes the wire format of the enumerations that are
+// written out in hand histories, event logs, saved files and messages: the
+// integer value and the string code of each constant of poker.Suit,
+// poker.Rank, engine.ActionType, engine.GamePhase and engine.PlayerStatus.
+//
+// The constants themselves are declared with iota, so reordering them in a
+// refactor would silently change what old files mean. The tables here are
+// written out value by value instead, and the package's tests fail if a
+// constant no longer has its frozen value. A value or code, once published,
+// is never reused for another constant; new constants get new ones, and a
+// change that cannot keep to that bumps Version.
+//
+// The amount of a bet or raise is always the total the player bets or raises
+// to in the betting round, never the increment over the current bet.
+//
+// A client acts with an ActionRequest carrying the sequence number of the
+// state it decided on, and is answered with an ActionAck, so that an action
+// sent out of turn or after the hand moved on, or one the validate package
+// finds illegal, is rejected rather than applied.
+//
+//	Suit          value  code    Rank   value  code
+//	Spade         0      s       Two    2      2
+//	Heart         1      h       ...
+//	Diamond       2      d       Ten    10     T
+//	Club          3      c       Jack   11     J
+//	                             Queen  12     Q
+//	                             King   13     K
+//	                             Ace    14     A
+//
+//	ActionType    value  code            GamePhase  value  code
+//	Fold          0      fold            PreFlop    0      preflop
+//	Check         1      check           Flop       1      flop
+//	Call          2      call            Turn       2      turn
+//	Bet           3      bet             River      3      river
+//	Raise         4      raise           Showdown   4      showdown
+//	ShowPartial   5      show_partial    HandOver   5      hand_over
+//	FastForward   6      fast_forward
+//
+//	PlayerStatus  value  code
+//	Playing       0      playing
+//	Folded        1      folded
+//	AllIn         2      all_in
+//	Eliminated    3      eliminated
+package protocol
+
+import (
+	"fmt"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+)
+
+// Version is the version of the wire format described by this package.
+const Version = 1
+
+// Entry is the wire form of one constant of an enumeration.
+type Entry[T ~int] struct {
+	Constant T      // Constant is the Go constant.
+	Value    int    // Value is its frozen integer value.
+	Code     string // Code is its frozen string code.
+}
+
+// Enum is the wire format of an enumeration.
+type Enum[T ~int] struct {
+	// Name names the enumeration in errors, e.g. "suit".
+	Name    string
+	entries []Entry[T]
+}
+
+// Entries returns the wire form of every constant of the enumeration, in
+// order of value.
+func (e *Enum[T]) Entries() []Entry[T] {
+	return append([]Entry[T](nil), e.entries...)
+}
+
+// Value returns the frozen integer value of a constant.
+func (e *Enum[T]) Value(c T) (int, error) {
+	for _, entry := range e.entries {
+		if entry.Constant == c {
+			return entry.Value, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %d has no wire value", e.Name, int(c))
+}
+
+// Code returns the frozen string code of a constant.
+func (e *Enum[T]) Code(c T) (string, error) {
+	for _, entry := range e.entries {
+		if entry.Constant == c {
+			return entry.Code, nil
+		}
+	}
+	return "", fmt.Errorf("%s %d has no wire code", e.Name, int(c))
+}
+
+// FromValue returns the constant with the given integer value.
+func (e *Enum[T]) FromValue(value int) (T, error) {
+	for _, entry := range e.entries {
+		if entry.Value == value {
+			return entry.Constant, nil
+		}
+	}
+	return 0, fmt.Errorf("%d is not a %s value", value, e.Name)
+}
+
+// FromCode returns the constant with the given string code.
+func (e *Enum[T]) FromCode(code string) (T, error) {
+	for _, entry := range e.entries {
+		if entry.Code == code {
+			return entry.Constant, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a %s code", code, e.Name)
+}
+
+// Suits is the wire format of poker.Suit.
+var Suits = &Enum[poker.Suit]{Name: "suit", entries: []Entry[poker.Suit]{
+	{poker.Spade, 0, "s"},
+	{poker.Heart, 1, "h"},
+	{poker.Diamond, 2, "d"},
+	{poker.Club, 3, "c"},
+}}
+
+// Ranks is the wire format of poker.Rank. The values are the ranks' poker
+// values, so an Ace is 14.
+var Ranks = &Enum[poker.Rank]{Name: "rank", entries: []Entry[poker.Rank]{
+	{poker.Two, 2, "2"},
+	{poker.Three, 3, "3"},
+	{poker.Four, 4, "4"},
+	{poker.Five, 5, "5"},
+	{poker.Six, 6, "6"},
+	{poker.Seven, 7, "7"},
+	{poker.Eight, 8, "8"},
+	{poker.Nine, 9, "9"},
+	{poker.Ten, 10, "T"},
+	{poker.Jack, 11, "J"},
+	{poker.Queen, 12, "Q"},
+	{poker.King, 13, "K"},
+	{poker.Ace, 14, "A"},
+}}
+
+// ActionTypes is the wire format of engine.ActionType.
+var ActionTypes = &Enum[engine.ActionType]{Name: "action type", entries: []Entry[engine.ActionType]{
+	{engine.ActionFold, 0, "fold"},
+	{engine.ActionCheck, 1, "check"},
+	{engine.ActionCall, 2, "call"},
+	{engine.ActionBet, 3, "bet"},
+	{engine.ActionRaise, 4, "raise"},
+	{engine.ActionShowPartial, 5, "show_partial"},
+	{engine.ActionFastForward, 6, "fast_forward"},
+}}
+
+// GamePhases is the wire format of engine.GamePhase.
+var GamePhases = &Enum[engine.GamePhase]{Name: "game phase", entries: []Entry[engine.GamePhase]{
+	{engine.PhasePreFlop, 0, "preflop"},
+	{engine.PhaseFlop, 1, "flop"},
+	{engine.PhaseTurn, 2, "turn"},
+	{engine.PhaseRiver, 3, "river"},
+	{engine.PhaseShowdown, 4, "showdown"},
+	{engine.PhaseHandOver, 5, "hand_over"},
+}}
+
+// PlayerStatuses is the wire format of engine.PlayerStatus.
+var PlayerStatuses = &Enum[engine.PlayerStatus]{Name: "player status", entries: []Entry[engine.PlayerStatus]{
+	{engine.PlayerStatusPlaying, 0, "playing"},
+	{engine.PlayerStatusFolded, 1, "folded"},
+	{engine.PlayerStatusAllIn, 2, "all_in"},
+	{engine.PlayerStatusEliminated, 3, "eliminated"},
+}}
+
+// CardCode returns the wire code of a card: its rank code followed by its
+// suit code, e.g. "As" or "Td", as accepted by poker.ParseCard.
+func CardCode(c poker.Card) (string, error) {
+	rank, err := Ranks.Code(c.Rank)
+	if err != nil {
+		return "", err
+	}
+	suit, err := Suits.Code(c.Suit)
+	if err != nil {
+		return "", err
+	}
+	return rank + suit, nil
+}
+
+// CardFromCode returns the card with the given wire code, e.g. "As".
+func CardFromCode(code string) (poker.Card, error) {
+	if len(code) != 2 {
+		return poker.Card{}, fmt.Errorf("%q is not a card code", code)
+	}
+	rank, err := Ranks.FromCode(code[:1])
+	if err != nil {
+		return poker.Card{}, err
+	}
+	suit, err := Suits.FromCode(code[1:])
+	if err != nil {
+		return poker.Card{}, err
+	}
+	return poker.Card{Suit: suit, Rank: rank}, nil
+}
@@ -0,0 +1,168 @@
+package protocol
+
+import (
+	"encoding/json"
+	"github.com/philipjkim/pls7-cli/pkg/engine"
+	"github.com/philipjkim/pls7-cli/pkg/poker"
+	"strings"
+	"testing"
+)
+
+// checkEnum tests that every constant of an enumeration still has its frozen
+// value, that its values and codes are unique and round-trip, and that the
+// codes are the expected ones. named reports whether a constant just past the
+// last entry has a name, which would mean a constant was added without a
+// wire entry.
+func checkEnum[T ~int](t *testing.T, e *Enum[T], codes string, named func(T) bool) {
+	t.Helper()
+	entries := e.Entries()
+
+	var got []string
+	values := make(map[int]bool)
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		got = append(got, entry.Code)
+		if int(entry.Constant) != entry.Value {
+			t.Errorf("%s %q: constant is %d, but its frozen value is %d", e.Name, entry.Code, int(entry.Constant), entry.Value)
+		}
+		if values[entry.Value] || seen[entry.Code] {
+			t.Errorf("%s %q: value %d or code is used twice", e.Name, entry.Code, entry.Value)
+		}
+		values[entry.Value], seen[entry.Code] = true, true
+
+		if value, err := e.Value(entry.Constant); err != nil || value != entry.Value {
+			t.Errorf("%s Value(%d) = %d, %v, want %d", e.Name, int(entry.Constant), value, err, entry.Value)
+		}
+		if c, err := e.FromValue(entry.Value); err != nil || c != entry.Constant {
+			t.Errorf("%s FromValue(%d) = %d, %v, want %d", e.Name, entry.Value, int(c), err, int(entry.Constant))
+		}
+		if code, err := e.Code(entry.Constant); err != nil || code != entry.Code {
+			t.Errorf("%s Code(%d) = %q, %v, want %q", e.Name, int(entry.Constant), code, err, entry.Code)
+		}
+		if c, err := e.FromCode(entry.Code); err != nil || c != entry.Constant {
+			t.Errorf("%s FromCode(%q) = %d, %v, want %d", e.Name, entry.Code, int(c), err, int(entry.Constant))
+		}
+	}
+	if strings.Join(got, " ") != codes {
+		t.Errorf("%s codes = %q, want %q", e.Name, strings.Join(got, " "), codes)
+	}
+
+	next := entries[len(entries)-1].Constant + 1
+	if named(next) {
+		t.Errorf("%s %d has a name but no wire entry", e.Name, int(next))
+	}
+	if _, err := e.Value(next); err == nil {
+		t.Errorf("%s Value(%d) returned no error", e.Name, int(next))
+	}
+	if _, err := e.FromValue(int(next)); err == nil {
+		t.Errorf("%s FromValue(%d) returned no error", e.Name, int(next))
+	}
+	if _, err := e.FromCode("nope"); err == nil {
+		t.Errorf("%s FromCode(\"nope\") returned no error", e.Name)
+	}
+}
+
+// hasName reports whether String gives a constant a name, treating a panic
+// as no name, since most String methods index a slice of names.
+func hasName(s interface{ String() string }, unnamed string) (named bool) {
+	defer func() {
+		if recover() != nil {
+			named = false
+		}
+	}()
+	return s.String() != unnamed
+}
+
+func TestSuits(t *testing.T) {
+	checkEnum(t, Suits, "s h d c", func(s poker.Suit) bool { return hasName(s, "") })
+}
+
+func TestRanks(t *testing.T) {
+	checkEnum(t, Ranks, "2 3 4 5 6 7 8 9 T J Q K A", func(r poker.Rank) bool { return hasName(r, "") })
+	if _, err := Ranks.Value(poker.Rank(1)); err == nil {
+		t.Error("Ranks.Value(1) returned no error")
+	}
+}
+
+func TestActionTypes(t *testing.T) {
+	checkEnum(t, ActionTypes, "fold check call bet raise show_partial fast_forward",
+		func(a engine.ActionType) bool { return hasName(a, "") })
+}
+
+func TestGamePhases(t *testing.T) {
+	checkEnum(t, GamePhases, "preflop flop turn river showdown hand_over",
+		func(p engine.GamePhase) bool { return hasName(p, "") })
+}
+
+func TestPlayerStatuses(t *testing.T) {
+	checkEnum(t, PlayerStatuses, "playing folded all_in eliminated",
+		func(s engine.PlayerStatus) bool { return hasName(s, "Unknown") })
+}
+
+// TestCardCodes tests that every card round-trips through its code, and that
+// the codes are the notation poker.ParseCard accepts.
+func TestCardCodes(t *testing.T) {
+	for _, suit := range Suits.Entries() {
+		for _, rank := range Ranks.Entries() {
+			card := poker.Card{Suit: suit.Constant, Rank: rank.Constant}
+			code, err := CardCode(card)
+			if err != nil {
+				t.Fatalf("CardCode(%v) returned an error: %v", card, err)
+			}
+			if code != card.Notation() {
+				t.Errorf("CardCode(%v) = %q, want its notation %q", card, code, card.Notation())
+			}
+			if parsed, err := poker.ParseCard(code); err != nil || parsed != card {
+				t.Errorf("poker.ParseCard(%q) = %v, %v, want %v", code, parsed, err, card)
+			}
+			if back, err := CardFromCode(code); err != nil || back != card {
+				t.Errorf("CardFromCode(%q) = %v, %v, want %v", code, back, err, card)
+			}
+		}
+	}
+	for _, code := range []string{"", "A", "Asx", "1s", "Ax", "10h"} {
+		if _, err := CardFromCode(code); err == nil {
+			t.Errorf("CardFromCode(%q) returned no error", code)
+		}
+	}
+}
+
+// TestSavedActionUsesWireValues tests that a recorded action is saved with the
+// frozen values of its phase and action type.
+func TestSavedActionUsesWireValues(t *testing.T) {
+	data, err := json.Marshal(engine.ActionRecord{Phase: engine.PhaseRiver, PlayerName: "YOU", Action: engine.ActionRaise, Amount: 3000})
+	if err != nil {
+		t.Fatalf("Failed to marshal the action: %v", err)
+	}
+	want := `{"phase":3,"player_name":"YOU","action":4,"amount":3000}`
+	if string(data) != want {
+		t.Errorf("Saved action = %s, want %s", data, want)
+	}
+}
+
+// TestHandleAction tests that an accepted action passes the turn, and that a
+// stale one is acknowledged with the current state instead of being applied.
+func TestHandleAction(t *testing.T) {
+	rules := &poker.GameRules{Name: "NLH", Abbreviation: "NLH", BettingLimit: "no_limit", HoleCards: poker.HoleCardRules{Count: 2, UseConstraint: "any"}}
+	g := engine.NewGame([]string{"YOU", "CPU 1", "CPU 2"}, 10000, 50, 100, engine.DifficultyMedium, rules, false, false, 0)
+	g.StartNewHand()
+	g.PrepareNewBettingRound()
+
+	seq := g.ActionSeq
+	ack := HandleAction(g, ActionRequest{Player: "YOU", Seq: seq, Action: "call"})
+	if !ack.Accepted || ack.Seq != seq+1 || ack.Turn != "CPU 1" || ack.Phase != "preflop" {
+		t.Fatalf("Expected the call to be accepted and the turn passed to CPU 1, but got %+v", ack)
+	}
+
+	stale := HandleAction(g, ActionRequest{Player: "CPU 1", Seq: seq, Action: "fold"})
+	if stale.Accepted || stale.Error == "" {
+		t.Errorf("Expected an action with an old sequence number to be rejected, but got %+v", stale)
+	}
+	if stale.Seq != ack.Seq || stale.Turn != "CPU 1" || g.Players[1].Status != engine.PlayerStatusPlaying {
+		t.Errorf("Expected the rejection to change nothing and describe the current state, but got %+v", stale)
+	}
+
+	if bad := HandleAction(g, ActionRequest{Player: "CPU 1", Seq: ack.Seq, Action: "show_partial"}); bad.Accepted {
+		t.Error("Expected a non-betting action to be rejected")
+	}
+}
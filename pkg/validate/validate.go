@@ -0,0 +1,149 @@
+// Package validate decides which betting actions a player may take, and which
+// amounts they may bet or raise to, from a snapshot of their spot in the hand.
+//
+// It is the one place those rules are written down. The CLI prompt asks it
+// which actions to offer and which amounts to accept, the engine checks every
+// action it applies against it, and actions submitted over the network are
+// rejected by it before they reach the game, so what the prompt allows and
+// what the engine accepts cannot drift apart. The package knows nothing of the
+// game itself; the engine describes the spot with Game.ActionSpot.
+package validate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalAction matches every error the package returns, with errors.Is.
+var ErrIllegalAction = errors.New("illegal action")
+
+// Move is a betting action.
+type Move int
+
+// Move constants.
+const (
+	Fold Move = iota
+	Check
+	Call
+	Bet
+	Raise
+)
+
+// String returns the move's name, e.g. "raise".
+func (m Move) String() string {
+	return []string{"fold", "check", "call", "bet", "raise"}[m]
+}
+
+// Spot describes the betting as the player to act faces it.
+type Spot struct {
+	// ToCall is the amount the player must add to call the current bet. It
+	// is zero when there is no bet to call.
+	ToCall int
+	// Stack is the player's chips behind, not counting their bet in front.
+	Stack int
+	// CurrentBet is the amount the player has already bet this round.
+	CurrentBet int
+	// MinTotal and MaxTotal are the smallest and largest totals the player
+	// may bet or raise to under the betting limits.
+	MinTotal, MaxTotal int
+	// Capped is set when a fixed limit allows no more raises this round.
+	Capped bool
+	// ChipUnit is the smallest chip in play. Every total is a multiple of it,
+	// except an all-in.
+	ChipUnit int
+	// MustBet is set when a house rule does not allow the player to check:
+	// with no bet to call, they must bet or fold.
+	MustBet bool
+}
+
+// AllIn returns the total the player bets by going all-in.
+func (s Spot) AllIn() int {
+	return s.CurrentBet + s.Stack
+}
+
+// Allows checks that the move may be made at all, whatever its amount. It
+// returns an error wrapping ErrIllegalAction that gives the reason if not.
+func (s Spot) Allows(m Move) error {
+	switch m {
+	case Fold:
+		return nil
+	case Check:
+		if s.ToCall > 0 {
+			return illegal("cannot check facing a bet of %d", s.CurrentBet+s.ToCall)
+		}
+		if s.MustBet && s.Stack > 0 {
+			return illegal("cannot check when the house rules make you bet; bet or fold")
+		}
+	case Call:
+		if s.ToCall == 0 {
+			return illegal("there is no bet to call")
+		}
+	case Bet:
+		if s.ToCall > 0 {
+			return illegal("cannot bet facing a bet of %d; raise instead", s.CurrentBet+s.ToCall)
+		}
+		if s.Stack == 0 {
+			return illegal("no chips left to bet")
+		}
+	case Raise:
+		if s.ToCall <= 0 {
+			return illegal("there is no bet to raise; bet instead")
+		}
+		if s.Stack <= s.ToCall {
+			return illegal("not enough chips to raise")
+		}
+		if s.Capped {
+			return illegal("the betting is capped; call or fold")
+		}
+	default:
+		return illegal("unknown move %d", int(m))
+	}
+	return nil
+}
+
+// Validate checks a move, and for a bet or raise the total it is made to. The
+// total must lie between MinTotal and MaxTotal and be a multiple of the chip
+// unit, unless it puts the player all-in. Other moves ignore the total.
+func (s Spot) Validate(m Move, total int) error {
+	if err := s.Allows(m); err != nil {
+		return err
+	}
+	if m != Bet && m != Raise {
+		return nil
+	}
+	if total < s.MinTotal || total > s.MaxTotal {
+		return illegal("%s to %d is outside the limits, %d to %d", m, total, s.MinTotal, s.MaxTotal)
+	}
+	if unit := s.ChipUnit; unit > 1 && total%unit != 0 && total != s.AllIn() {
+		return illegal("%s to %d is not a multiple of the %d chip unit", m, total, unit)
+	}
+	return nil
+}
+
+// Moves returns the moves the player may make, in the order fold, check, call,
+// bet, raise.
+func (s Spot) Moves() []Move {
+	var moves []Move
+	for m := Fold; m <= Raise; m++ {
+		if s.Allows(m) == nil {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// Error is an illegal action. It is ErrIllegalAction to errors.Is, and reads
+// as its reason alone, so that it can be shown to the player as it is.
+type Error struct {
+	Reason string
+}
+
+func (e *Error) Error() string { return e.Reason }
+
+// Is reports whether the target is ErrIllegalAction.
+func (e *Error) Is(target error) bool { return target == ErrIllegalAction }
+
+// illegal returns an Error with the formatted reason.
+func illegal(format string, args ...any) error {
+	return &Error{Reason: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,60 @@
+package validate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSpot_Moves(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spot     Spot
+		expected []Move
+	}{
+		{name: "No bet", spot: Spot{Stack: 1000, MinTotal: 100, MaxTotal: 1000}, expected: []Move{Fold, Check, Bet}},
+		{name: "Facing a bet", spot: Spot{ToCall: 100, Stack: 1000, MinTotal: 200, MaxTotal: 1000}, expected: []Move{Fold, Call, Raise}},
+		{name: "Stack covers only the call", spot: Spot{ToCall: 100, Stack: 100}, expected: []Move{Fold, Call}},
+		{name: "Capped", spot: Spot{ToCall: 100, Stack: 1000, Capped: true}, expected: []Move{Fold, Call}},
+		{name: "No chips behind", spot: Spot{CurrentBet: 500}, expected: []Move{Fold, Check}},
+		{name: "Must bet", spot: Spot{Stack: 1000, MinTotal: 100, MaxTotal: 1000, MustBet: true}, expected: []Move{Fold, Bet}},
+		{name: "Must bet with no chips behind", spot: Spot{CurrentBet: 500, MustBet: true}, expected: []Move{Fold, Check}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.spot.Moves(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("Expected moves %v, but got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestSpot_Validate(t *testing.T) {
+	spot := Spot{ToCall: 200, Stack: 2550, CurrentBet: 100, MinTotal: 600, MaxTotal: 1200, ChipUnit: 100}
+	testCases := []struct {
+		name  string
+		spot  Spot
+		move  Move
+		total int
+		legal bool
+	}{
+		{name: "Raise within the limits", spot: spot, move: Raise, total: 900, legal: true},
+		{name: "Raise below the minimum", spot: spot, move: Raise, total: 500},
+		{name: "Raise over the maximum", spot: spot, move: Raise, total: 1300},
+		{name: "Raise off the chip unit", spot: spot, move: Raise, total: 950},
+		{name: "All-in off the chip unit", spot: Spot{ToCall: 200, Stack: 2550, CurrentBet: 100, MinTotal: 600, MaxTotal: 2650, ChipUnit: 100}, move: Raise, total: 2650, legal: true},
+		{name: "Bet facing a bet", spot: spot, move: Bet, total: 900},
+		{name: "Call ignores the total", spot: spot, move: Call, total: 12345, legal: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.spot.Validate(tc.move, tc.total)
+			if tc.legal && err != nil {
+				t.Errorf("Expected %s to %d to be legal, but got %v", tc.move, tc.total, err)
+			}
+			if !tc.legal && !errors.Is(err, ErrIllegalAction) {
+				t.Errorf("Expected %s to %d to be illegal, but got %v", tc.move, tc.total, err)
+			}
+		})
+	}
+}